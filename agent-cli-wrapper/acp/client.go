@@ -21,19 +21,20 @@ func extractToolName(toolCallID string) string {
 // Client manages an ACP-compatible agent subprocess and provides
 // a high-level API for interacting with ACP agents (Gemini CLI, etc.).
 type Client struct {
-	pending   map[int64]chan *rpcResult
-	sessions  map[string]*Session
-	process   *processManager
-	state     *clientStateManager
-	idGen     *idGenerator
-	agentInfo *InitializeResponse
-	events    chan Event
-	done      chan struct{}
-	config    ClientConfig
-	mu        sync.RWMutex
-	readWg    sync.WaitGroup // tracks readLoop goroutine
-	started   bool
-	stopping  bool
+	pending      map[int64]chan *rpcResult
+	sessions     map[string]*Session
+	elicitations map[string]*pendingElicitation // keyed by elicitation ID
+	process      *processManager
+	state        *clientStateManager
+	idGen        *idGenerator
+	agentInfo    *InitializeResponse
+	events       chan Event
+	done         chan struct{}
+	config       ClientConfig
+	mu           sync.RWMutex
+	readWg       sync.WaitGroup // tracks readLoop goroutine
+	started      bool
+	stopping     bool
 }
 
 // rpcResult holds the result of a JSON-RPC request.
@@ -61,13 +62,14 @@ func NewClient(opts ...ClientOption) *Client {
 	}
 
 	return &Client{
-		config:   config,
-		state:    newClientStateManager(),
-		sessions: make(map[string]*Session),
-		idGen:    &idGenerator{},
-		pending:  make(map[int64]chan *rpcResult),
-		events:   make(chan Event, config.EventBufferSize),
-		done:     make(chan struct{}),
+		config:       config,
+		state:        newClientStateManager(),
+		sessions:     make(map[string]*Session),
+		idGen:        &idGenerator{},
+		pending:      make(map[int64]chan *rpcResult),
+		elicitations: make(map[string]*pendingElicitation),
+		events:       make(chan Event, config.EventBufferSize),
+		done:         make(chan struct{}),
 	}
 }
 
@@ -128,7 +130,8 @@ func (c *Client) initialize(ctx context.Context) error {
 				ReadTextFile:  true,
 				WriteTextFile: true,
 			},
-			Terminal: true,
+			Terminal:    true,
+			Elicitation: true,
 		},
 	}
 
@@ -195,6 +198,7 @@ func (c *Client) NewSession(ctx context.Context, opts ...SessionOption) (*Sessio
 	}
 
 	session := newSession(c, sessionResp.SessionID)
+	session.cwd = cfg.CWD
 
 	c.mu.Lock()
 	c.sessions[sessionResp.SessionID] = session
@@ -205,6 +209,27 @@ func (c *Client) NewSession(ctx context.Context, opts ...SessionOption) (*Sessio
 	return session, nil
 }
 
+// supportsPromptCWD reports whether the agent advertised that it honours a
+// per-prompt _meta.cwd.
+func (c *Client) supportsPromptCWD() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.agentInfo != nil &&
+		c.agentInfo.AgentCapabilities != nil &&
+		c.agentInfo.AgentCapabilities.Meta != nil &&
+		c.agentInfo.AgentCapabilities.Meta.PromptCWD
+}
+
+// CanLoadSession reports whether the agent advertised the loadSession
+// capability during initialize. It is false before Start completes.
+func (c *Client) CanLoadSession() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.agentInfo != nil &&
+		c.agentInfo.AgentCapabilities != nil &&
+		c.agentInfo.AgentCapabilities.LoadSession
+}
+
 // LoadSession loads an existing ACP session when the agent advertises support.
 func (c *Client) LoadSession(ctx context.Context, sessionID string, opts ...SessionOption) (*Session, error) {
 	c.mu.RLock()
@@ -216,12 +241,9 @@ func (c *Client) LoadSession(ctx context.Context, sessionID string, opts ...Sess
 		c.mu.RUnlock()
 		return nil, ErrStopping
 	}
-	supportsLoad := c.agentInfo != nil &&
-		c.agentInfo.AgentCapabilities != nil &&
-		c.agentInfo.AgentCapabilities.LoadSession
 	c.mu.RUnlock()
 
-	if !supportsLoad {
+	if !c.CanLoadSession() {
 		return nil, ErrSessionNotFound
 	}
 
@@ -272,6 +294,7 @@ func (c *Client) LoadSession(ctx context.Context, sessionID string, opts ...Sess
 		resolvedID = sessionID
 	}
 	session := newSession(c, resolvedID)
+	session.cwd = cfg.CWD
 
 	c.mu.Lock()
 	c.sessions[resolvedID] = session
@@ -282,7 +305,39 @@ func (c *Client) LoadSession(ctx context.Context, sessionID string, opts ...Sess
 	return session, nil
 }
 
-// Stop gracefully shuts down the client.
+// ResumeOrNewSession loads sessionID when the agent supports session/load
+// and otherwise starts a fresh session. When it falls back, either because
+// the capability is missing or the agent no longer knows the session, it
+// emits a ResumeFallbackEvent so callers can tell the user that earlier
+// context was lost.
+func (c *Client) ResumeOrNewSession(ctx context.Context, sessionID string, opts ...SessionOption) (*Session, error) {
+	reason := "agent does not support session/load"
+	if sessionID == "" {
+		reason = "no session to resume"
+	} else if c.CanLoadSession() {
+		session, err := c.LoadSession(ctx, sessionID, opts...)
+		if err == nil {
+			return session, nil
+		}
+		if !errors.Is(err, ErrSessionNotFound) {
+			return nil, err
+		}
+		reason = "agent no longer has the session"
+	}
+
+	session, err := c.NewSession(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if sessionID != "" {
+		c.emit(ResumeFallbackEvent{RequestedID: sessionID, SessionID: session.ID(), Reason: reason})
+	}
+	return session, nil
+}
+
+// Stop shuts down the client right away, stopping the agent process even if
+// a prompt is still streaming. Use StopWithTimeout to let in-flight turns
+// finish first.
 func (c *Client) Stop() error {
 	c.mu.Lock()
 	if !c.started || c.stopping {
@@ -305,6 +360,10 @@ func (c *Client) Stop() error {
 	for _, session := range c.sessions {
 		session.close()
 	}
+	for id, p := range c.elicitations {
+		p.timer.Stop()
+		delete(c.elicitations, id)
+	}
 	c.mu.Unlock()
 
 	// Wait for readLoop to exit before closing events channel
@@ -316,6 +375,67 @@ func (c *Client) Stop() error {
 	return nil
 }
 
+// StopWithTimeout shuts the client down without cutting a turn off
+// mid-stream. It sends session/cancel for every session with a prompt in
+// flight and waits for those turns to complete, then closes the agent's stdin
+// and waits for its stdout to reach EOF so every pending session/update is
+// read and emitted. Whatever is left when ctx ends, the process is then
+// stopped as by Stop. drained reports whether everything was flushed before
+// ctx ended.
+func (c *Client) StopWithTimeout(ctx context.Context) (drained bool, err error) {
+	c.mu.RLock()
+	if !c.started || c.stopping {
+		c.mu.RUnlock()
+		return true, nil
+	}
+	sessions := make([]*Session, 0, len(c.sessions))
+	for _, session := range c.sessions {
+		sessions = append(sessions, session)
+	}
+	c.mu.RUnlock()
+
+	drained = true
+	var turns []<-chan *TurnResult
+	for _, session := range sessions {
+		turn := session.inFlightTurn()
+		if turn == nil {
+			continue
+		}
+		if err := session.Cancel(); err != nil {
+			drained = false
+			continue
+		}
+		turns = append(turns, turn)
+	}
+wait:
+	for _, turn := range turns {
+		select {
+		case <-turn:
+		case <-ctx.Done():
+			drained = false
+			break wait
+		}
+	}
+
+	if drained {
+		// The agent exits once its stdin closes; the read loop returns at
+		// EOF, after everything the agent wrote has been dispatched.
+		c.process.CloseInput()
+		eof := make(chan struct{})
+		go func() {
+			c.readWg.Wait()
+			close(eof)
+		}()
+		select {
+		case <-eof:
+		case <-ctx.Done():
+			drained = false
+		}
+	}
+
+	return drained, c.Stop()
+}
+
 // Events returns a read-only channel for receiving events.
 func (c *Client) Events() <-chan Event {
 	return c.events
@@ -437,37 +557,31 @@ func (c *Client) handleSessionUpdate(params json.RawMessage) {
 		return
 	}
 
-	session.handleUpdate(&notif.Update)
+	full, isChunk := session.handleUpdate(&notif.Update)
 
 	// Emit events based on the update type
 	switch notif.Update.Type {
 	case UpdateTypeAgentMessage:
-		if notif.Update.Content != nil && notif.Update.Content.Type == "text" {
-			session.mu.Lock()
-			fullText := session.text.String()
-			session.mu.Unlock()
-
+		if isChunk {
 			c.emit(TextDeltaEvent{
 				SessionID: notif.SessionID,
 				Delta:     notif.Update.Content.Text,
-				FullText:  fullText,
+				FullText:  full,
 			})
 		}
 
 	case UpdateTypeAgentThought:
-		if notif.Update.Content != nil && notif.Update.Content.Type == "text" {
-			session.mu.Lock()
-			fullThinking := session.thinking.String()
-			session.mu.Unlock()
-
+		if isChunk {
 			c.emit(ThinkingDeltaEvent{
 				SessionID: notif.SessionID,
 				Delta:     notif.Update.Content.Text,
-				FullText:  fullThinking,
+				FullText:  full,
 			})
 		}
 
 	case UpdateTypeToolCall:
+		session.trackFileEdit(notif.Update.ToolCallID, notif.Update.Kind, notif.Update.ToolName, notif.Update.Locations, notif.Update.Input)
+		session.recordToolDiffs(notif.Update.ToolCallID, notif.Update.ToolContent)
 		if notif.Update.Status == "running" || notif.Update.Status == "pending" {
 			c.emit(ToolCallStartEvent{
 				SessionID:  notif.SessionID,
@@ -483,6 +597,7 @@ func (c *Client) handleSessionUpdate(params json.RawMessage) {
 				Status:     notif.Update.Status,
 				Input:      notif.Update.Input,
 			})
+			c.emitFileEdits(session, notif.Update.ToolCallID, notif.Update.Status)
 		}
 
 	case UpdateTypeToolCallUpdate:
@@ -491,6 +606,8 @@ func (c *Client) handleSessionUpdate(params json.RawMessage) {
 		if toolName == "" {
 			toolName = extractToolName(notif.Update.ToolCallID)
 		}
+		session.trackFileEdit(notif.Update.ToolCallID, notif.Update.Kind, toolName, notif.Update.Locations, notif.Update.Input)
+		session.recordToolDiffs(notif.Update.ToolCallID, notif.Update.ToolContent)
 		c.emit(ToolCallUpdateEvent{
 			SessionID:  notif.SessionID,
 			ToolCallID: notif.Update.ToolCallID,
@@ -498,6 +615,7 @@ func (c *Client) handleSessionUpdate(params json.RawMessage) {
 			Status:     notif.Update.Status,
 			Input:      notif.Update.Input,
 		})
+		c.emitFileEdits(session, notif.Update.ToolCallID, notif.Update.Status)
 
 	case UpdateTypePlanUpdate:
 		c.emit(PlanUpdateEvent{
@@ -507,6 +625,14 @@ func (c *Client) handleSessionUpdate(params json.RawMessage) {
 	}
 }
 
+// emitFileEdits emits a FileEditEvent for each file a tracked edit tool call
+// touched, once the call reaches a terminal status.
+func (c *Client) emitFileEdits(session *Session, toolCallID, status string) {
+	for _, ev := range session.finishFileEdit(toolCallID, status) {
+		c.emit(ev)
+	}
+}
+
 // handleAgentRequest processes a JSON-RPC request from the agent.
 // ACP agents can request file operations, terminal commands, and permissions.
 func (c *Client) handleAgentRequest(line []byte, method string, id int64) {
@@ -535,11 +661,41 @@ func (c *Client) handleAgentRequest(line []byte, method string, id int64) {
 		c.handleTerminalRelease(ctx, id, req.Params)
 	case MethodRequestPermission:
 		c.handleRequestPermission(ctx, id, req.Params)
+	case MethodElicitation:
+		c.handleElicitation(id, req.Params)
 	default:
+		if handler, ok := c.config.RawRequestHandlers[method]; ok {
+			c.handleRawRequest(id, handler, req.Params)
+			return
+		}
 		c.sendErrorResponse(id, ErrCodeMethodNotFound, "unknown method: "+method)
 	}
 }
 
+// handleRawRequest answers a request for a method the SDK does not model
+// with a handler registered by WithRawRequestHandler.
+func (c *Client) handleRawRequest(id int64, handler func(json.RawMessage) (json.RawMessage, error), params json.RawMessage) {
+	result, err := handler(params)
+	if err != nil {
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) {
+			c.sendErrorResponse(id, rpcErr.Code, rpcErr.Message)
+			return
+		}
+		c.sendErrorResponse(id, ErrCodeInternalError, err.Error())
+		return
+	}
+	if len(result) == 0 {
+		// A response must carry a result or an error.
+		result = json.RawMessage("null")
+	}
+	if !json.Valid(result) {
+		c.sendErrorResponse(id, ErrCodeInternalError, "raw request handler returned invalid JSON")
+		return
+	}
+	c.process.WriteJSON(&JSONRPCResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
 func (c *Client) handleFsReadTextFile(ctx context.Context, id int64, params json.RawMessage) {
 	var req ReadTextFileRequest
 	if err := json.Unmarshal(params, &req); err != nil {
@@ -664,6 +820,12 @@ func (c *Client) handleRequestPermission(ctx context.Context, id int64, params j
 		ToolName:   toolName,
 		Input:      input,
 	})
+	c.mu.RLock()
+	session := c.sessions[req.SessionID]
+	c.mu.RUnlock()
+	if session != nil {
+		session.trackFileEdit(req.ToolCall.ToolCallID, req.ToolCall.Kind, toolName, req.ToolCall.Locations, req.ToolCall.Input)
+	}
 
 	resp, err := c.config.PermissionHandler.RequestPermission(ctx, req)
 	if err != nil {
@@ -723,6 +885,12 @@ func (c *Client) sendRequestAndWait(ctx context.Context, method string, params i
 		delete(c.pending, id)
 		c.mu.Unlock()
 		return nil, ctx.Err()
+	case <-c.done:
+		// Stopped while waiting: the agent will never answer.
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, ErrClientClosed
 	}
 }
 
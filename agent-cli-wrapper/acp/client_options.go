@@ -1,7 +1,9 @@
 package acp
 
 import (
+	"encoding/json"
 	"io"
+	"time"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/llmendpoint"
 )
@@ -13,21 +15,34 @@ type ClientConfig struct {
 	PermissionHandler PermissionHandler
 	StderrHandler     func([]byte)
 	ProtocolLogger    io.Writer
-	Env               map[string]string
-	BinaryPath        string
-	ClientName        string
-	ClientVersion     string
-	BinaryArgs        []string
-	EventBufferSize   int
+	// ProtocolLogRedactor, when set, rewrites each message before it is
+	// written to ProtocolLogger. It never affects what is sent to the agent.
+	ProtocolLogRedactor func([]byte) []byte
+	// RawMessageObserver, when set, sees every JSON-RPC message exchanged
+	// with the agent; see WithRawMessageObserver.
+	RawMessageObserver func(direction string, raw json.RawMessage)
+	// RawRequestHandlers answers agent requests for methods the SDK does
+	// not model, keyed by method; see WithRawRequestHandler.
+	RawRequestHandlers map[string]func(params json.RawMessage) (json.RawMessage, error)
+	Env                map[string]string
+	BinaryPath         string
+	ClientName         string
+	ClientVersion      string
+	BinaryArgs         []string
+	EventBufferSize    int
+	// ElicitationTimeout is how long an agent question waits for
+	// Session.Respond before it is declined automatically.
+	ElicitationTimeout time.Duration
 }
 
 func defaultACPClientConfig() ClientConfig {
 	return ClientConfig{
-		BinaryPath:      "gemini",
-		BinaryArgs:      []string{"--experimental-acp"},
-		ClientName:      "acp-go-sdk",
-		ClientVersion:   "1.0.0",
-		EventBufferSize: 100,
+		BinaryPath:         "gemini",
+		BinaryArgs:         []string{"--experimental-acp"},
+		ClientName:         "acp-go-sdk",
+		ClientVersion:      "1.0.0",
+		EventBufferSize:    100,
+		ElicitationTimeout: 5 * time.Minute,
 	}
 }
 
@@ -74,6 +89,12 @@ func WithTerminalHandler(h TerminalHandler) ClientOption {
 	return func(c *ClientConfig) { c.TerminalHandler = h }
 }
 
+// WithElicitationTimeout sets how long an agent question (ElicitationEvent)
+// waits for an answer before it is declined so the turn can continue.
+func WithElicitationTimeout(d time.Duration) ClientOption {
+	return func(c *ClientConfig) { c.ElicitationTimeout = d }
+}
+
 // WithPermissionHandler sets the permission handler.
 func WithPermissionHandler(h PermissionHandler) ClientOption {
 	return func(c *ClientConfig) { c.PermissionHandler = h }
@@ -87,6 +108,47 @@ func WithProtocolLogger(w io.Writer) ClientOption {
 	return func(c *ClientConfig) { c.ProtocolLogger = w }
 }
 
+// WithProtocolLoggerRedactor sets a function applied to every message before
+// it reaches the protocol logger, e.g. DefaultProtocolLogRedactor to mask API
+// keys and tokens that appear in prompts. Pass nil to log messages verbatim,
+// which is the default.
+func WithProtocolLoggerRedactor(fn func([]byte) []byte) ClientOption {
+	return func(c *ClientConfig) { c.ProtocolLogRedactor = fn }
+}
+
+// Directions passed to a RawMessageObserver.
+const (
+	RawMessageSent     = "sent"     // client to agent
+	RawMessageReceived = "received" // agent to client
+)
+
+// WithRawMessageObserver sets a function that sees every JSON-RPC message
+// exchanged with the agent, unredacted, with direction RawMessageSent or
+// RawMessageReceived. It complements the protocol logger for callers that
+// want to inspect messages the typed API does not surface, such as
+// notifications for vendor extensions. It is called from the client's read
+// and write paths, so it must be safe for concurrent use, must not block,
+// and must not retain raw after returning.
+func WithRawMessageObserver(fn func(direction string, raw json.RawMessage)) ClientOption {
+	return func(c *ClientConfig) { c.RawMessageObserver = fn }
+}
+
+// WithRawRequestHandler answers agent requests for method, one the SDK does
+// not model (e.g. a vendor "_acme/lookup" extension), with handler. The
+// handler receives the request's raw params and returns the raw result; an
+// error is sent as a JSON-RPC error, using the code of an *RPCError and
+// ErrCodeInternalError otherwise. Methods the SDK handles itself are never
+// routed here. Like the other handlers it runs on the read loop, so it
+// should return promptly.
+func WithRawRequestHandler(method string, handler func(params json.RawMessage) (json.RawMessage, error)) ClientOption {
+	return func(c *ClientConfig) {
+		if c.RawRequestHandlers == nil {
+			c.RawRequestHandlers = make(map[string]func(json.RawMessage) (json.RawMessage, error))
+		}
+		c.RawRequestHandlers[method] = handler
+	}
+}
+
 // WithEnv sets additional environment variables for the agent subprocess.
 func WithEnv(env map[string]string) ClientOption {
 	return func(c *ClientConfig) { c.Env = env }
@@ -0,0 +1,417 @@
+package acp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFakeAgentProcess is not a real test: when ACP_FAKE_AGENT is set, the
+// test binary re-executed by startFakeAgent speaks just enough ACP over
+// stdio to drive a prompt turn. Modes:
+//
+//	drain:    streams "partial ", then on session/cancel streams "and final"
+//	          and ends the turn as cancelled
+//	stubborn: streams "partial " and ignores session/cancel
+//	cwd:      advertises _meta.promptCwd and ends each turn after streaming
+//	          the prompt's _meta.cwd (or "-" when absent)
+//	cwd-plain: like cwd, but without advertising _meta.promptCwd
+//	echo:     ends each turn after streaming the prompt text back, and
+//	          fails the prompt "fail" with an RPC error
+//	replay:F  answers each prompt by replaying the recorded log F: updates
+//	          are sent as is and the recorded prompt result is sent as the
+//	          reply to the live request
+//	elicit:   asks "Which database?" on each prompt and ends the turn after
+//	          streaming the answer as "action:answer"
+//	custom:   sends a "_fake/lookup" request with the prompt text as its
+//	          key and ends the turn after streaming the raw result, or
+//	          "code:message" for an error reply
+//
+// It exits when stdin closes.
+func TestFakeAgentProcess(t *testing.T) {
+	mode := os.Getenv("ACP_FAKE_AGENT")
+	if mode == "" {
+		return
+	}
+	out := json.NewEncoder(os.Stdout)
+	chunk := func(text string) {
+		_ = out.Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"method":  MethodSessionUpdate,
+			"params": map[string]any{
+				"sessionId": "fake-1",
+				"update": map[string]any{
+					"sessionUpdate": "agent_message_chunk",
+					"content":       map[string]any{"type": "text", "text": text},
+				},
+			},
+		})
+	}
+	reply := func(id json.RawMessage, result any) {
+		_ = out.Encode(map[string]any{"jsonrpc": "2.0", "id": id, "result": result})
+	}
+
+	var promptID json.RawMessage
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var msg struct {
+			Params struct {
+				Meta   *PromptMeta    `json:"_meta"`
+				Prompt []ContentBlock `json:"prompt"`
+			} `json:"params"`
+			Error  *JSONRPCError   `json:"error"`
+			Method string          `json:"method"`
+			Result json.RawMessage `json:"result"`
+			ID     json.RawMessage `json:"id"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		switch msg.Method {
+		case MethodInitialize:
+			result := map[string]any{"protocolVersion": ProtocolVersion}
+			if mode == "cwd" {
+				result["agentCapabilities"] = map[string]any{"_meta": map[string]any{"promptCwd": true}}
+			}
+			reply(msg.ID, result)
+		case MethodSessionNew:
+			if strings.HasPrefix(mode, "replay:") {
+				// Recorded Gemini logs use this session ID.
+				reply(msg.ID, map[string]any{"sessionId": "gem-1"})
+				continue
+			}
+			reply(msg.ID, map[string]any{"sessionId": "fake-1"})
+		case MethodSessionPrompt:
+			if path, ok := strings.CutPrefix(mode, "replay:"); ok {
+				replayPrompt(path, msg.ID, out)
+				continue
+			}
+			if mode == "cwd" || mode == "cwd-plain" {
+				dir := "-"
+				if msg.Params.Meta != nil {
+					dir = msg.Params.Meta.CWD
+				}
+				chunk(dir)
+				reply(msg.ID, map[string]any{"stopReason": "end_turn"})
+				continue
+			}
+			if mode == "echo" {
+				var text string
+				if len(msg.Params.Prompt) > 0 {
+					text = msg.Params.Prompt[0].Text
+				}
+				if text == "fail" {
+					_ = out.Encode(map[string]any{"jsonrpc": "2.0", "id": msg.ID, "error": map[string]any{"code": -32603, "message": "boom"}})
+					continue
+				}
+				chunk(text)
+				reply(msg.ID, map[string]any{"stopReason": "end_turn"})
+				continue
+			}
+			promptID = msg.ID
+			if mode == "custom" {
+				var key string
+				if len(msg.Params.Prompt) > 0 {
+					key = msg.Params.Prompt[0].Text
+				}
+				_ = out.Encode(map[string]any{
+					"jsonrpc": "2.0",
+					"id":      901,
+					"method":  "_fake/lookup",
+					"params":  map[string]any{"key": key},
+				})
+				continue
+			}
+			if mode == "elicit" {
+				_ = out.Encode(map[string]any{
+					"jsonrpc": "2.0",
+					"id":      900,
+					"method":  MethodElicitation,
+					"params": map[string]any{
+						"sessionId": "fake-1",
+						"message":   "Which database?",
+						"options":   []string{"postgres", "sqlite"},
+					},
+				})
+				continue
+			}
+			chunk("partial ")
+		case "":
+			if mode == "elicit" && msg.Result != nil && promptID != nil {
+				var answer ElicitationResponse
+				_ = json.Unmarshal(msg.Result, &answer)
+				chunk(answer.Action + ":" + answer.Answer)
+				reply(promptID, map[string]any{"stopReason": "end_turn"})
+			}
+			if mode == "custom" && promptID != nil {
+				if msg.Error != nil {
+					chunk(fmt.Sprintf("%d:%s", msg.Error.Code, msg.Error.Message))
+				} else {
+					chunk(string(msg.Result))
+				}
+				reply(promptID, map[string]any{"stopReason": "end_turn"})
+			}
+		case MethodSessionCancel:
+			if mode == "drain" && promptID != nil {
+				chunk("and final")
+				reply(promptID, map[string]any{"stopReason": "cancelled"})
+			}
+		}
+	}
+	// Exit before the testing framework prints PASS onto the protocol stream.
+	os.Exit(0)
+}
+
+// replayPrompt writes the recorded log at path to out, sending its response
+// line as the reply to the prompt request id.
+func replayPrompt(path string, id json.RawMessage, out *json.Encoder) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var line map[string]json.RawMessage
+		if err := json.Unmarshal(sc.Bytes(), &line); err != nil {
+			continue
+		}
+		if _, isResponse := line["result"]; isResponse {
+			line["id"] = id
+		}
+		_ = out.Encode(line)
+	}
+}
+
+// startFakeAgent starts a client backed by TestFakeAgentProcess in the given
+// mode and begins a prompt, returning once the first chunk has streamed.
+func startFakeAgent(t *testing.T, mode string) (*Client, <-chan *TurnResult) {
+	t.Helper()
+	client := NewClient(
+		WithBinaryPath(os.Args[0]),
+		WithBinaryArgs("-test.run=^TestFakeAgentProcess$"),
+		WithEnv(map[string]string{"ACP_FAKE_AGENT": mode}),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = client.Stop() })
+	session, err := client.NewSession(ctx)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	results := make(chan *TurnResult, 1)
+	go func() {
+		result, err := session.Prompt(ctx, "go")
+		if result == nil {
+			result = &TurnResult{Error: err}
+		}
+		results <- result
+	}()
+	for ev := range client.Events() {
+		if _, ok := ev.(TextDeltaEvent); ok {
+			return client, results
+		}
+	}
+	t.Fatal("events closed before the first chunk")
+	return nil, nil
+}
+
+func TestStopWithTimeoutDrainsInFlightTurn(t *testing.T) {
+	client, results := startFakeAgent(t, "drain")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	drained, err := client.StopWithTimeout(ctx)
+	if err != nil {
+		t.Fatalf("StopWithTimeout() error = %v", err)
+	}
+	if !drained {
+		t.Error("StopWithTimeout() drained = false, want true")
+	}
+
+	result := <-results
+	if got := fmt.Sprintf("%q/%s", result.FullText, result.StopReason); got != `"partial and final"/cancelled` {
+		t.Errorf("turn = %s, want the final chunk kept and a cancelled stop", got)
+	}
+	if client.State() != ClientStateClosed {
+		t.Errorf("State() = %v, want closed", client.State())
+	}
+}
+
+func TestStopWithTimeoutGivesUpOnStuckAgent(t *testing.T) {
+	client, results := startFakeAgent(t, "stubborn")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	drained, err := client.StopWithTimeout(ctx)
+	if err != nil {
+		t.Fatalf("StopWithTimeout() error = %v", err)
+	}
+	if drained {
+		t.Error("StopWithTimeout() drained = true for an agent that ignores cancel")
+	}
+	select {
+	case <-results:
+	case <-time.After(5 * time.Second):
+		t.Fatal("prompt still blocked after the client stopped")
+	}
+}
+
+func TestPromptInDirSendsCWDToCapableAgent(t *testing.T) {
+	cwd := t.TempDir()
+	if err := os.Mkdir(filepath.Join(cwd, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(
+		WithBinaryPath(os.Args[0]),
+		WithBinaryArgs("-test.run=^TestFakeAgentProcess$"),
+		WithEnv(map[string]string{"ACP_FAKE_AGENT": "cwd"}),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer client.Stop()
+	session, err := client.NewSession(ctx, WithSessionCWD(cwd))
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	result, err := session.PromptInDir(ctx, "pkg", "go")
+	if err != nil {
+		t.Fatalf("PromptInDir() error = %v", err)
+	}
+	if want := filepath.Join(cwd, "pkg"); result.FullText != want {
+		t.Errorf("agent saw cwd %q, want %q", result.FullText, want)
+	}
+
+	// The session root needs no override; the agent falls back to its cwd.
+	result, err = session.PromptInDir(ctx, ".", "go")
+	if err != nil {
+		t.Fatalf("PromptInDir(.) error = %v", err)
+	}
+	if result.FullText != "-" {
+		t.Errorf("agent saw cwd %q for the session root, want none", result.FullText)
+	}
+
+	if _, err := session.PromptInDir(ctx, "..", "go"); !errors.Is(err, ErrDirOutsideSession) {
+		t.Errorf("PromptInDir(..) error = %v, want ErrDirOutsideSession", err)
+	}
+}
+
+func TestPromptInDirOmitsCWDWithoutCapability(t *testing.T) {
+	cwd := t.TempDir()
+	if err := os.Mkdir(filepath.Join(cwd, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(
+		WithBinaryPath(os.Args[0]),
+		WithBinaryArgs("-test.run=^TestFakeAgentProcess$"),
+		WithEnv(map[string]string{"ACP_FAKE_AGENT": "cwd-plain"}),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer client.Stop()
+	session, err := client.NewSession(ctx, WithSessionCWD(cwd))
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	result, err := session.PromptInDir(ctx, "pkg", "go")
+	if err != nil {
+		t.Fatalf("PromptInDir() error = %v", err)
+	}
+	if result.FullText != "-" {
+		t.Errorf("agent without promptCwd saw cwd %q, want none", result.FullText)
+	}
+}
+
+func TestResumeOrNewSessionFallsBackWithoutLoadSupport(t *testing.T) {
+	client := NewClient(
+		WithBinaryPath(os.Args[0]),
+		WithBinaryArgs("-test.run=^TestFakeAgentProcess$"),
+		WithEnv(map[string]string{"ACP_FAKE_AGENT": "echo"}),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer client.Stop()
+
+	session, err := client.ResumeOrNewSession(ctx, "old-1")
+	if err != nil {
+		t.Fatalf("ResumeOrNewSession() error = %v", err)
+	}
+	if session.ID() != "fake-1" {
+		t.Errorf("session ID = %q, want a fresh fake-1", session.ID())
+	}
+	if session.CanResume() {
+		t.Error("CanResume() = true for an agent without loadSession")
+	}
+	for ev := range client.Events() {
+		if fb, ok := ev.(ResumeFallbackEvent); ok {
+			if fb.RequestedID != "old-1" || fb.SessionID != "fake-1" || fb.Reason == "" {
+				t.Errorf("fallback event = %+v", fb)
+			}
+			return
+		}
+	}
+	t.Fatal("events closed without a ResumeFallbackEvent")
+}
+
+func TestPromptBatchRunsPromptsInOrder(t *testing.T) {
+	client := NewClient(
+		WithBinaryPath(os.Args[0]),
+		WithBinaryArgs("-test.run=^TestFakeAgentProcess$"),
+		WithEnv(map[string]string{"ACP_FAKE_AGENT": "echo"}),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer client.Stop()
+	session, err := client.NewSession(ctx)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	results, err := session.PromptBatch(ctx, []string{"one", "two", "three"})
+	if err != nil {
+		t.Fatalf("PromptBatch() error = %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.FullText)
+	}
+	if fmt.Sprint(got) != "[one two three]" {
+		t.Errorf("PromptBatch() texts = %q, want [one two three]", got)
+	}
+
+	results, err = session.PromptBatch(ctx, []string{"a", "fail", "never"})
+	if err == nil {
+		t.Fatal("PromptBatch() error = nil, want the failed prompt's error")
+	}
+	if len(results) != 2 || results[0].FullText != "a" || results[1].Success {
+		t.Errorf("PromptBatch() partial results = %+v, want the first turn and the failed one", results)
+	}
+	if session.State() != SessionStateReady {
+		t.Errorf("State() = %v after a failed batch, want ready", session.State())
+	}
+}
@@ -43,6 +43,11 @@
 //	result2, _ := session.Prompt(ctx, "Summarize the main.go file")
 //	fmt.Println(result2.FullText)
 //
+// PromptBatch queues several prompts and runs them one turn at a time,
+// stopping at the first error:
+//
+//	results, err := session.PromptBatch(ctx, []string{"Add tests", "Run them"})
+//
 // # Streaming Events
 //
 //	go func() {
@@ -58,6 +63,35 @@
 //	    }
 //	}()
 //
+// # Agent Questions
+//
+// An agent may pause a turn to ask the user a question. The client emits an
+// ElicitationEvent; answer it with Session.Respond to resume the turn. A
+// question left unanswered is declined after the timeout set by
+// WithElicitationTimeout (5 minutes by default), so the turn never hangs:
+//
+//	case acp.ElicitationEvent:
+//	    answer := askUser(e.Prompt, e.Options)
+//	    _ = session.Respond(ctx, e.ElicitationID, answer)
+//
+// # Protocol Extensions
+//
+// Agents may speak methods the SDK does not model. WithRawRequestHandler
+// answers such agent requests from raw JSON, and WithRawMessageObserver sees
+// every message in both directions, e.g. to pick up vendor notifications:
+//
+//	acp.WithRawRequestHandler("_acme/lookup", func(params json.RawMessage) (json.RawMessage, error) {
+//	    return json.RawMessage(`{"value":"42"}`), nil
+//	})
+//
+// Session.PromptInDir scopes one prompt to a subdirectory of the session cwd
+// by sending _meta.cwd on session/prompt. This is not part of ACP, so the
+// field is only sent to agents that advertise it in their initialize
+// response as agentCapabilities._meta.promptCwd = true; other agents run
+// the prompt in the session cwd, exactly like Prompt:
+//
+//	result, err := session.PromptInDir(ctx, "services/api", "Run the tests")
+//
 // # Agent Compatibility
 //
 // This SDK works with any ACP-compatible agent binary:
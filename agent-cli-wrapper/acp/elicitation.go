@@ -0,0 +1,78 @@
+package acp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// pendingElicitation is an agent question waiting for Session.Respond.
+type pendingElicitation struct {
+	timer     *time.Timer
+	sessionID string
+	requestID int64
+}
+
+// handleElicitation surfaces an agent question as an ElicitationEvent. It
+// does not block the read loop: the answer is sent when Session.Respond is
+// called, or a decline when the timeout passes first.
+func (c *Client) handleElicitation(id int64, params json.RawMessage) {
+	var req ElicitationRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		c.sendErrorResponse(id, ErrCodeInvalidParams, err.Error())
+		return
+	}
+
+	elicitationID := strconv.FormatInt(id, 10)
+	p := &pendingElicitation{sessionID: req.SessionID, requestID: id}
+	c.mu.Lock()
+	c.elicitations[elicitationID] = p
+	p.timer = time.AfterFunc(c.config.ElicitationTimeout, func() {
+		_ = c.answerElicitation(req.SessionID, elicitationID, ElicitationResponse{Action: ElicitationActionDecline})
+	})
+	c.mu.Unlock()
+
+	c.emit(ElicitationEvent{
+		SessionID:     req.SessionID,
+		ElicitationID: elicitationID,
+		Prompt:        req.Message,
+		Options:       req.Options,
+	})
+}
+
+// answerElicitation sends resp for the pending question elicitationID of
+// sessionID. Each question is answered at most once.
+func (c *Client) answerElicitation(sessionID, elicitationID string, resp ElicitationResponse) error {
+	c.mu.Lock()
+	p, ok := c.elicitations[elicitationID]
+	if ok && p.sessionID == sessionID {
+		delete(c.elicitations, elicitationID)
+	}
+	c.mu.Unlock()
+	if !ok || p.sessionID != sessionID {
+		return fmt.Errorf("%w: %s", ErrElicitationNotFound, elicitationID)
+	}
+
+	p.timer.Stop()
+	r, err := newResponse(p.requestID, resp)
+	if err != nil {
+		return err
+	}
+	return c.process.WriteJSON(r)
+}
+
+// Respond answers the agent question elicitationID (from an
+// ElicitationEvent) and lets the paused turn continue. An empty answer
+// declines the question.
+func (s *Session) Respond(ctx context.Context, elicitationID, answer string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	resp := ElicitationResponse{Action: ElicitationActionDecline}
+	if answer != "" {
+		resp = ElicitationResponse{Action: ElicitationActionAccept, Answer: answer}
+	}
+	return s.client.answerElicitation(s.id, elicitationID, resp)
+}
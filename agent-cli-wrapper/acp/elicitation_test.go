@@ -0,0 +1,84 @@
+package acp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// startElicitAgent starts a client backed by the "elicit" fake agent and
+// begins a prompt, returning once the agent has asked its question.
+func startElicitAgent(t *testing.T, opts ...ClientOption) (*Session, ElicitationEvent, <-chan *TurnResult) {
+	t.Helper()
+	opts = append([]ClientOption{
+		WithBinaryPath(os.Args[0]),
+		WithBinaryArgs("-test.run=^TestFakeAgentProcess$"),
+		WithEnv(map[string]string{"ACP_FAKE_AGENT": "elicit"}),
+	}, opts...)
+	client := NewClient(opts...)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = client.Stop() })
+	session, err := client.NewSession(ctx)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	results := make(chan *TurnResult, 1)
+	go func() {
+		result, err := session.Prompt(ctx, "go")
+		if result == nil {
+			result = &TurnResult{Error: err}
+		}
+		results <- result
+	}()
+	for ev := range client.Events() {
+		if e, ok := ev.(ElicitationEvent); ok {
+			return session, e, results
+		}
+	}
+	t.Fatal("events closed without an ElicitationEvent")
+	return nil, ElicitationEvent{}, nil
+}
+
+func TestRespondAnswersElicitation(t *testing.T) {
+	session, ev, results := startElicitAgent(t)
+	if ev.Prompt != "Which database?" || len(ev.Options) != 2 || ev.Options[0] != "postgres" || ev.SessionID != "fake-1" {
+		t.Fatalf("ElicitationEvent = %+v", ev)
+	}
+
+	ctx := context.Background()
+	if err := session.Respond(ctx, ev.ElicitationID, "postgres"); err != nil {
+		t.Fatalf("Respond() error = %v", err)
+	}
+	result := <-results
+	if result.Error != nil || result.FullText != "accept:postgres" {
+		t.Errorf("turn = %+v, want text accept:postgres", result)
+	}
+
+	if err := session.Respond(ctx, ev.ElicitationID, "sqlite"); !errors.Is(err, ErrElicitationNotFound) {
+		t.Errorf("second Respond() error = %v, want ErrElicitationNotFound", err)
+	}
+}
+
+func TestUnansweredElicitationIsDeclined(t *testing.T) {
+	session, ev, results := startElicitAgent(t, WithElicitationTimeout(50*time.Millisecond))
+
+	select {
+	case result := <-results:
+		if result.Error != nil || result.FullText != "decline:" {
+			t.Errorf("turn = %+v, want text decline:", result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("turn still waiting on the unanswered question")
+	}
+
+	if err := session.Respond(context.Background(), ev.ElicitationID, "postgres"); !errors.Is(err, ErrElicitationNotFound) {
+		t.Errorf("Respond() after timeout error = %v, want ErrElicitationNotFound", err)
+	}
+}
@@ -27,6 +27,14 @@ var (
 
 	// ErrInvalidState is returned for invalid state transitions.
 	ErrInvalidState = errors.New("invalid state transition")
+
+	// ErrDirOutsideSession is returned when a per-prompt directory is not
+	// inside the session's working directory.
+	ErrDirOutsideSession = errors.New("directory is outside the session working directory")
+
+	// ErrElicitationNotFound is returned when answering a question that was
+	// already answered, timed out, or never asked.
+	ErrElicitationNotFound = errors.New("elicitation not found")
 )
 
 // RPCError represents a JSON-RPC error from the agent.
@@ -32,6 +32,17 @@ const (
 
 	// EventTypeError fires on errors.
 	EventTypeError
+
+	// EventTypeFileEdit fires when a tool call that edits files completes.
+	EventTypeFileEdit
+
+	// EventTypeResumeFallback fires when a resume started a fresh session
+	// instead of loading the requested one.
+	EventTypeResumeFallback
+
+	// EventTypeElicitation fires when the agent asks the user a question
+	// mid-turn.
+	EventTypeElicitation
 )
 
 // Event is the interface for all ACP SDK events.
@@ -128,7 +139,7 @@ type TurnCompleteEvent struct {
 	SessionID  string
 	FullText   string
 	Thinking   string
-	StopReason string
+	StopReason string // one of the StopReason* constants
 	DurationMs int64
 	TurnNumber int
 	Success    bool
@@ -167,3 +178,48 @@ func (e ErrorEvent) Type() EventType { return EventTypeError }
 func (e ErrorEvent) StreamEventKind() agentstream.EventKind { return agentstream.KindError }
 func (e ErrorEvent) StreamErr() error                       { return e.Error }
 func (e ErrorEvent) StreamErrorContext() string             { return e.Context }
+
+// FileEditEvent fires, alongside the generic tool events, for each file a
+// completed edit tool call created, modified, or deleted. Edits are
+// recognized from the ACP tool kind ("edit", "delete") or, when the agent
+// does not label them, from well-known tool names such as write_file and
+// replace.
+type FileEditEvent struct {
+	SessionID  string
+	ToolCallID string
+	Path       string
+	Kind       string // FileEditCreate, FileEditModify, or FileEditDelete
+	// Diff is the change as a unified diff: the one the agent reported in
+	// the tool call's content, or else one synthesized from the file before
+	// and after the call. It is empty when neither is available, e.g. for
+	// binary or very large files.
+	Diff string
+}
+
+// Type returns the event type.
+func (e FileEditEvent) Type() EventType { return EventTypeFileEdit }
+
+// ResumeFallbackEvent fires when ResumeOrNewSession could not load the
+// requested session and started a fresh one, so the agent has lost the
+// earlier conversation.
+type ResumeFallbackEvent struct {
+	RequestedID string
+	SessionID   string
+	Reason      string
+}
+
+// Type returns the event type.
+func (e ResumeFallbackEvent) Type() EventType { return EventTypeResumeFallback }
+
+// ElicitationEvent fires when the agent pauses a turn to ask the user a
+// question. Answer it with Session.Respond; unanswered questions are
+// declined after the client's elicitation timeout.
+type ElicitationEvent struct {
+	SessionID     string
+	ElicitationID string
+	Prompt        string
+	Options       []string // suggested answers, if any
+}
+
+// Type returns the event type.
+func (e ElicitationEvent) Type() EventType { return EventTypeElicitation }
@@ -0,0 +1,311 @@
+package acp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// File edit kinds reported by FileEditEvent.
+const (
+	FileEditCreate = "create"
+	FileEditModify = "modify"
+	FileEditDelete = "delete"
+)
+
+// editToolNames and deleteToolNames classify tool calls whose ACP kind is
+// missing or "other", keyed by the tool name Gemini CLI and similar agents
+// use.
+var (
+	editToolNames = map[string]bool{
+		"write_file":  true,
+		"write":       true,
+		"create_file": true,
+		"replace":     true,
+		"edit":        true,
+		"edit_file":   true,
+		"multi_edit":  true,
+	}
+	deleteToolNames = map[string]bool{
+		"delete_file": true,
+		"remove_file": true,
+	}
+)
+
+// maxDiffFileSize caps the size of a file snapshotted to synthesize a diff.
+const maxDiffFileSize = 1 << 20
+
+// pendingFileEdit is an in-flight tool call expected to change files.
+// existed records, per path, whether the file was on disk when the call
+// was first seen, which tells a create from a modify once it completes.
+// before holds each file's content at that point, when it is small text,
+// and reported the diff content the agent itself sent, keyed by path.
+type pendingFileEdit struct {
+	reported   map[string]toolDiffContent
+	paths      []string
+	before     []string
+	existed    []bool
+	haveBefore []bool
+	delete     bool
+}
+
+// classifyFileEdit reports whether a tool call edits or deletes files. The
+// ACP kind wins; unlabeled calls fall back to well-known tool names.
+func classifyFileEdit(kind, toolName string) (isDelete, ok bool) {
+	switch kind {
+	case "edit":
+		return false, true
+	case "delete":
+		return true, true
+	case "", "other":
+		name := strings.ToLower(toolName)
+		return deleteToolNames[name], editToolNames[name] || deleteToolNames[name]
+	default:
+		return false, false
+	}
+}
+
+// fileEditPaths returns the files a tool call touches: its reported
+// locations, or else a path-like input argument. Relative paths are
+// resolved against cwd.
+func fileEditPaths(locations []ToolLocation, input map[string]interface{}, cwd string) []string {
+	var paths []string
+	for _, loc := range locations {
+		if loc.Path != "" {
+			paths = append(paths, loc.Path)
+		}
+	}
+	if len(paths) == 0 {
+		for _, key := range []string{"file_path", "absolute_path", "path"} {
+			if p, ok := input[key].(string); ok && p != "" {
+				paths = append(paths, p)
+				break
+			}
+		}
+	}
+	for i, p := range paths {
+		if !filepath.IsAbs(p) && cwd != "" {
+			paths[i] = filepath.Join(cwd, p)
+		}
+	}
+	return paths
+}
+
+// trackFileEdit starts tracking a tool call that edits files, snapshotting
+// whether each target exists. Calls already tracked keep their first
+// snapshot; calls that do not edit files are ignored.
+func (s *Session) trackFileEdit(toolCallID, kind, toolName string, locations []ToolLocation, input map[string]interface{}) {
+	if toolCallID == "" {
+		return
+	}
+	if toolName == "" {
+		toolName = extractToolName(toolCallID)
+	}
+	isDelete, ok := classifyFileEdit(kind, toolName)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, tracked := s.pendingEdits[toolCallID]; tracked {
+		return
+	}
+	paths := fileEditPaths(locations, input, s.cwd)
+	if len(paths) == 0 {
+		return
+	}
+	edit := &pendingFileEdit{
+		paths:      paths,
+		delete:     isDelete,
+		existed:    make([]bool, len(paths)),
+		before:     make([]string, len(paths)),
+		haveBefore: make([]bool, len(paths)),
+	}
+	for i, p := range paths {
+		_, err := os.Stat(p)
+		edit.existed[i] = err == nil || !errors.Is(err, fs.ErrNotExist)
+		if edit.existed[i] {
+			edit.before[i], edit.haveBefore[i] = readDiffableFile(p)
+		} else {
+			edit.haveBefore[i] = true
+		}
+	}
+	if s.pendingEdits == nil {
+		s.pendingEdits = make(map[string]*pendingFileEdit)
+	}
+	s.pendingEdits[toolCallID] = edit
+}
+
+// finishFileEdit resolves a tracked tool call once it reaches a terminal
+// status, returning one FileEditEvent per touched file when it completed.
+// Failed calls are dropped without events.
+func (s *Session) finishFileEdit(toolCallID, status string) []FileEditEvent {
+	switch status {
+	case "completed", "failed", "errored":
+	default:
+		return nil
+	}
+
+	s.mu.Lock()
+	edit, ok := s.pendingEdits[toolCallID]
+	delete(s.pendingEdits, toolCallID)
+	s.mu.Unlock()
+	if !ok || status != "completed" {
+		return nil
+	}
+
+	events := make([]FileEditEvent, 0, len(edit.paths))
+	for i, p := range edit.paths {
+		kind := FileEditModify
+		switch {
+		case edit.delete:
+			kind = FileEditDelete
+		case !edit.existed[i]:
+			kind = FileEditCreate
+		}
+		events = append(events, FileEditEvent{
+			SessionID:  s.id,
+			ToolCallID: toolCallID,
+			Path:       p,
+			Kind:       kind,
+			Diff:       edit.diffFor(i, kind),
+		})
+	}
+	return events
+}
+
+// toolDiffContent is a "diff" item of a tool call's content array. OldText
+// is absent when the agent is creating the file.
+type toolDiffContent struct {
+	OldText *string `json:"oldText"`
+	Type    string  `json:"type"`
+	Path    string  `json:"path"`
+	NewText string  `json:"newText"`
+}
+
+// recordToolDiffs keeps the diff items a tracked edit tool call reports in
+// its content, so its FileEditEvents can carry them. Content without diff
+// items, or that fails to parse, is ignored.
+func (s *Session) recordToolDiffs(toolCallID string, content json.RawMessage) {
+	if toolCallID == "" || !bytes.Contains(content, []byte(`"diff"`)) {
+		return
+	}
+	var items []toolDiffContent
+	if err := json.Unmarshal(content, &items); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	edit, ok := s.pendingEdits[toolCallID]
+	if !ok {
+		return
+	}
+	for _, item := range items {
+		if item.Type != "diff" || item.Path == "" {
+			continue
+		}
+		path := item.Path
+		if !filepath.IsAbs(path) && s.cwd != "" {
+			path = filepath.Join(s.cwd, path)
+		}
+		if edit.reported == nil {
+			edit.reported = make(map[string]toolDiffContent)
+		}
+		edit.reported[path] = item
+	}
+}
+
+// diffFor returns the unified diff for the i'th path of a completed edit:
+// the agent's own diff when it sent one, else one synthesized from the
+// snapshot taken before the call and the file now on disk. It is "" when
+// neither side is available as text or nothing changed.
+func (e *pendingFileEdit) diffFor(i int, kind string) string {
+	p := e.paths[i]
+	created, deleted := kind == FileEditCreate, kind == FileEditDelete
+	if item, ok := e.reported[p]; ok {
+		oldText := ""
+		if item.OldText != nil {
+			oldText = *item.OldText
+		}
+		return unifiedDiff(p, oldText, item.NewText, created, deleted)
+	}
+	if !e.haveBefore[i] {
+		return ""
+	}
+	after := ""
+	if deleted {
+		if _, err := os.Stat(p); !errors.Is(err, fs.ErrNotExist) {
+			return ""
+		}
+	} else {
+		var ok bool
+		if after, ok = readDiffableFile(p); !ok {
+			return ""
+		}
+	}
+	return unifiedDiff(p, e.before[i], after, created, deleted)
+}
+
+// readDiffableFile reads a file for diffing, reporting false when it is
+// missing, too large, or binary.
+func readDiffableFile(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxDiffFileSize {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.IndexByte(data, 0) >= 0 {
+		return "", false
+	}
+	return string(data), true
+}
+
+// unifiedDiff renders the change from oldText to newText as a unified diff
+// with three lines of context, or "" when they are equal. Created and
+// deleted files diff against /dev/null.
+func unifiedDiff(path, oldText, newText string, created, deleted bool) string {
+	if oldText == newText {
+		return ""
+	}
+	from, to := path, path
+	if created {
+		from = "/dev/null"
+	}
+	if deleted {
+		to = "/dev/null"
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(oldText),
+		B:        diffLines(newText),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// diffLines splits text into lines that keep their newlines, with none for
+// empty text. A final line without a newline is marked as git marks it.
+func diffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n\\ No newline at end of file\n"
+	}
+	return lines
+}
@@ -0,0 +1,59 @@
+package acp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileEdit_SynthesizedDiffs checks that edits whose tool calls carry no
+// diff content get one synthesized from the files before and after the call,
+// and that binary files get none.
+func TestFileEdit_SynthesizedDiffs(t *testing.T) {
+	cwd := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(cwd, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("main.go", "package main\n\nfunc main() {\n}\n")
+	write("gone.txt", "bye\n")
+	write("blob.bin", "a\x00b")
+
+	client := NewClient()
+	session := newSession(client, "s-1")
+	session.cwd = cwd
+
+	session.trackFileEdit("edit-1", "edit", "", []ToolLocation{{Path: "main.go"}}, nil)
+	session.trackFileEdit("delete-2", "delete", "", []ToolLocation{{Path: "gone.txt"}}, nil)
+	session.trackFileEdit("write-3", "edit", "", []ToolLocation{{Path: "blob.bin"}}, nil)
+	session.trackFileEdit("write-4", "edit", "", []ToolLocation{{Path: "new.txt"}}, nil)
+
+	write("main.go", "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n")
+	if err := os.Remove(filepath.Join(cwd, "gone.txt")); err != nil {
+		t.Fatal(err)
+	}
+	write("blob.bin", "a\x00c")
+	write("new.txt", "no newline")
+
+	tests := []struct {
+		toolCallID string
+		want       string
+	}{
+		{"edit-1", "--- " + filepath.Join(cwd, "main.go") + "\n+++ " + filepath.Join(cwd, "main.go") + "\n" +
+			"@@ -1,4 +1,5 @@\n package main\n \n func main() {\n+\tprintln(\"hi\")\n }\n"},
+		{"delete-2", "--- " + filepath.Join(cwd, "gone.txt") + "\n+++ /dev/null\n@@ -1 +0,0 @@\n-bye\n"},
+		{"write-3", ""},
+		{"write-4", "--- /dev/null\n+++ " + filepath.Join(cwd, "new.txt") + "\n@@ -0,0 +1 @@\n+no newline\n\\ No newline at end of file\n"},
+	}
+	for _, tt := range tests {
+		events := session.finishFileEdit(tt.toolCallID, "completed")
+		if len(events) != 1 {
+			t.Fatalf("%s: got %d events, want 1", tt.toolCallID, len(events))
+		}
+		if events[0].Diff != tt.want {
+			t.Errorf("%s diff =\n%s\nwant\n%s", tt.toolCallID, events[0].Diff, tt.want)
+		}
+	}
+}
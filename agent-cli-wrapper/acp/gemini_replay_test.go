@@ -0,0 +1,168 @@
+package acp
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestHandleSessionUpdate_GeminiInterleavedThoughts replays a recorded Gemini
+// CLI protocol log in which thought chunks are interleaved with message
+// chunks, and checks that each stream is emitted and accumulated separately.
+func TestHandleSessionUpdate_GeminiInterleavedThoughts(t *testing.T) {
+	data, err := os.ReadFile("testdata/gemini_thought_interleaved.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient()
+	session := newSession(client, "gem-1")
+	client.sessions[session.id] = session
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		client.handleMessage(sc.Bytes())
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	type chunk struct {
+		kind  string
+		delta string
+		full  string
+	}
+	want := []chunk{
+		{"thinking", "**Locating the handler**\n\nThe user wants the retry logic. ", "**Locating the handler**\n\nThe user wants the retry logic. "},
+		{"thinking", "I should read client.go first.", "**Locating the handler**\n\nThe user wants the retry logic. I should read client.go first."},
+		{"text", "Let me look at ", "Let me look at "},
+		{"text", "the client.", "Let me look at the client."},
+		{"thinking", "**Reviewing backoff**\n\nThe delay doubles each attempt.", "**Locating the handler**\n\nThe user wants the retry logic. I should read client.go first.**Reviewing backoff**\n\nThe delay doubles each attempt."},
+		{"text", " Retries back off exponentially.", "Let me look at the client. Retries back off exponentially."},
+	}
+
+	var got []chunk
+	for len(client.events) > 0 {
+		switch e := (<-client.events).(type) {
+		case TextDeltaEvent:
+			got = append(got, chunk{"text", e.Delta, e.FullText})
+		case ThinkingDeltaEvent:
+			got = append(got, chunk{"thinking", e.Delta, e.FullText})
+		default:
+			t.Errorf("unexpected event %T", e)
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d chunk events, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if text := session.text.String(); text != "Let me look at the client. Retries back off exponentially." {
+		t.Errorf("session text = %q", text)
+	}
+	if thinking := session.thinking.String(); thinking != want[4].full {
+		t.Errorf("session thinking = %q", thinking)
+	}
+}
+
+// TestHandleSessionUpdate_GeminiFileEdits replays Gemini CLI tool calls and
+// checks that completed edits surface as FileEditEvents: labeled edits via
+// their ACP kind and locations, unlabeled ones via the tool name, with
+// failed and non-edit calls producing none. Only the call that reported a
+// diff carries one, since the replay leaves the files untouched.
+func TestHandleSessionUpdate_GeminiFileEdits(t *testing.T) {
+	cwd := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cwd, "existing.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("testdata/gemini_file_edits.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = bytes.ReplaceAll(data, []byte("__CWD__"), []byte(cwd))
+
+	client := NewClient()
+	session := newSession(client, "gem-1")
+	session.cwd = cwd
+	client.sessions[session.id] = session
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		client.handleMessage(sc.Bytes())
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	var edits []FileEditEvent
+	toolUpdates := 0
+	for len(client.events) > 0 {
+		switch e := (<-client.events).(type) {
+		case FileEditEvent:
+			edits = append(edits, e)
+		case ToolCallUpdateEvent:
+			toolUpdates++
+		}
+	}
+	if toolUpdates != 6 {
+		t.Errorf("got %d tool call updates, want 6 (content arrays must not drop updates)", toolUpdates)
+	}
+
+	want := []FileEditEvent{
+		{
+			SessionID: "gem-1", ToolCallID: "write_file-1", Path: filepath.Join(cwd, "new.go"), Kind: FileEditCreate,
+			Diff: "--- /dev/null\n+++ " + filepath.Join(cwd, "new.go") + "\n@@ -0,0 +1 @@\n+package main\n",
+		},
+		{SessionID: "gem-1", ToolCallID: "replace-2", Path: filepath.Join(cwd, "existing.go"), Kind: FileEditModify},
+		{SessionID: "gem-1", ToolCallID: "write_file-5", Path: filepath.Join(cwd, "notes", "todo.md"), Kind: FileEditCreate},
+		{SessionID: "gem-1", ToolCallID: "delete_file-6", Path: filepath.Join(cwd, "existing.go"), Kind: FileEditDelete},
+	}
+	if len(edits) != len(want) {
+		t.Fatalf("got %d file edits, want %d: %+v", len(edits), len(want), edits)
+	}
+	for i := range want {
+		if edits[i] != want[i] {
+			t.Errorf("edit %d = %+v, want %+v", i, edits[i], want[i])
+		}
+	}
+	if len(session.pendingEdits) != 0 {
+		t.Errorf("pending edits not cleared: %v", session.pendingEdits)
+	}
+}
+
+// TestPrompt_GeminiMaxTokensStopReason replays a recorded Gemini turn that
+// ran out of output tokens and checks that the stop reason reaches both the
+// TurnResult and the TurnCompleteEvent.
+func TestPrompt_GeminiMaxTokensStopReason(t *testing.T) {
+	client, results := startFakeAgent(t, "replay:testdata/gemini_max_tokens.jsonl")
+
+	var complete TurnCompleteEvent
+	for ev := range client.Events() {
+		if e, ok := ev.(TurnCompleteEvent); ok {
+			complete = e
+			break
+		}
+	}
+	result := <-results
+	if result.Error != nil {
+		t.Fatalf("Prompt() error = %v", result.Error)
+	}
+	if result.StopReason != StopReasonMaxTokens || complete.StopReason != StopReasonMaxTokens {
+		t.Errorf("StopReason = %q (result), %q (event); want %q", result.StopReason, complete.StopReason, StopReasonMaxTokens)
+	}
+	if result.Success || complete.Success {
+		t.Error("a max_tokens turn reported success")
+	}
+	if !result.Truncated() {
+		t.Error("Truncated() = false for a max_tokens turn")
+	}
+	if want := "Here is a summary of each package:\n\n- acp: the ACP client and"; result.FullText != want {
+		t.Errorf("FullText = %q, want %q", result.FullText, want)
+	}
+}
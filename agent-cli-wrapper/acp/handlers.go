@@ -26,7 +26,10 @@ type TerminalHandler interface {
 	Release(ctx context.Context, req ReleaseTerminalRequest) (*ReleaseTerminalResponse, error)
 }
 
-// PermissionHandler handles permission requests from the agent.
+// PermissionHandler handles permission requests from the agent. The request
+// carries the options the agent offered (allow once, allow always, reject,
+// ...); the response selects one by ID or cancels. PermissionChooser adapts a
+// plain function for UIs that let the user pick.
 type PermissionHandler interface {
 	RequestPermission(ctx context.Context, req RequestPermissionRequest) (*RequestPermissionResponse, error)
 }
@@ -216,36 +219,67 @@ func (h *DefaultTerminalHandler) Release(_ context.Context, req ReleaseTerminalR
 	return &ReleaseTerminalResponse{}, nil
 }
 
+// SelectPermissionOption returns a response choosing the option with id.
+func SelectPermissionOption(id string) *RequestPermissionResponse {
+	return &RequestPermissionResponse{
+		Outcome: PermissionOutcome{Type: "selected", OptionID: id},
+	}
+}
+
+// CancelPermission returns a response that declines to choose any option.
+func CancelPermission() *RequestPermissionResponse {
+	return &RequestPermissionResponse{
+		Outcome: PermissionOutcome{Type: "cancelled"},
+	}
+}
+
+// OptionWithKind returns the first offered option whose kind starts with
+// prefix, e.g. "allow" matches both "allow_once" and "allow_always".
+func (r RequestPermissionRequest) OptionWithKind(prefix string) (PermissionOption, bool) {
+	for _, opt := range r.Options {
+		if strings.HasPrefix(opt.Kind, prefix) {
+			return opt, true
+		}
+	}
+	return PermissionOption{}, false
+}
+
+// PermissionChooser is a PermissionHandler backed by a function that picks
+// one of the offered options, typically by asking the user. Returning an
+// empty ID cancels the request; an ID the agent did not offer is an error.
+type PermissionChooser func(ctx context.Context, req RequestPermissionRequest) (optionID string, err error)
+
+func (f PermissionChooser) RequestPermission(ctx context.Context, req RequestPermissionRequest) (*RequestPermissionResponse, error) {
+	id, err := f(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return CancelPermission(), nil
+	}
+	for _, opt := range req.Options {
+		if opt.ID == id {
+			return SelectPermissionOption(id), nil
+		}
+	}
+	return nil, fmt.Errorf("permission option %q was not offered", id)
+}
+
 // BypassPermissionHandler auto-approves all permission requests.
 // It selects the first "allow" option, or the first option if no "allow" exists.
 type BypassPermissionHandler struct{}
 
 func (h *BypassPermissionHandler) RequestPermission(_ context.Context, req RequestPermissionRequest) (*RequestPermissionResponse, error) {
-	// Find the first allow option
-	for _, opt := range req.Options {
-		if strings.HasPrefix(opt.Kind, "allow") {
-			return &RequestPermissionResponse{
-				Outcome: PermissionOutcome{
-					Type:     "selected",
-					OptionID: opt.ID,
-				},
-			}, nil
-		}
+	if opt, ok := req.OptionWithKind("allow"); ok {
+		return SelectPermissionOption(opt.ID), nil
 	}
 
 	// Fallback: select the first option
 	if len(req.Options) > 0 {
-		return &RequestPermissionResponse{
-			Outcome: PermissionOutcome{
-				Type:     "selected",
-				OptionID: req.Options[0].ID,
-			},
-		}, nil
+		return SelectPermissionOption(req.Options[0].ID), nil
 	}
 
-	return &RequestPermissionResponse{
-		Outcome: PermissionOutcome{Type: "cancelled"},
-	}, nil
+	return CancelPermission(), nil
 }
 
 // PlanOnlyPermissionHandler allows read-only operations and rejects write operations.
@@ -273,33 +307,16 @@ func (h *PlanOnlyPermissionHandler) RequestPermission(_ context.Context, req Req
 
 	// Check if this is a read-only tool
 	if isReadOnly, exists := readOnlyTools[toolName]; exists && isReadOnly {
-		// Find the first allow option
-		for _, opt := range req.Options {
-			if strings.HasPrefix(opt.Kind, "allow") {
-				return &RequestPermissionResponse{
-					Outcome: PermissionOutcome{
-						Type:     "selected",
-						OptionID: opt.ID,
-					},
-				}, nil
-			}
+		if opt, ok := req.OptionWithKind("allow"); ok {
+			return SelectPermissionOption(opt.ID), nil
 		}
 	}
 
 	// For write operations or unknown tools, find a reject option
-	for _, opt := range req.Options {
-		if strings.HasPrefix(opt.Kind, "reject") {
-			return &RequestPermissionResponse{
-				Outcome: PermissionOutcome{
-					Type:     "selected",
-					OptionID: opt.ID,
-				},
-			}, nil
-		}
+	if opt, ok := req.OptionWithKind("reject"); ok {
+		return SelectPermissionOption(opt.ID), nil
 	}
 
 	// Fallback: cancel the request
-	return &RequestPermissionResponse{
-		Outcome: PermissionOutcome{Type: "cancelled"},
-	}, nil
+	return CancelPermission(), nil
 }
@@ -233,3 +233,42 @@ func TestBypassPermissionHandler_AllowsEverything(t *testing.T) {
 		})
 	}
 }
+
+func TestPermissionChooser(t *testing.T) {
+	req := RequestPermissionRequest{
+		ToolCall: ToolCallInfo{ToolCallID: "write_file-1", Kind: "edit"},
+		Options: []PermissionOption{
+			{ID: "proceed_once", Name: "Allow once", Kind: "allow_once"},
+			{ID: "proceed_always", Name: "Allow for session", Kind: "allow_always"},
+			{ID: "cancel", Name: "Reject", Kind: "reject_once"},
+		},
+	}
+
+	var offered []PermissionOption
+	chooser := PermissionChooser(func(_ context.Context, r RequestPermissionRequest) (string, error) {
+		offered = r.Options
+		opt, _ := r.OptionWithKind("allow_always")
+		return opt.ID, nil
+	})
+	resp, err := chooser.RequestPermission(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(offered) != 3 {
+		t.Errorf("chooser saw %d options, want 3", len(offered))
+	}
+	if resp.Outcome.Type != "selected" || resp.Outcome.OptionID != "proceed_always" {
+		t.Errorf("outcome = %+v, want selected proceed_always", resp.Outcome)
+	}
+
+	cancel := PermissionChooser(func(context.Context, RequestPermissionRequest) (string, error) { return "", nil })
+	resp, err = cancel.RequestPermission(context.Background(), req)
+	if err != nil || resp.Outcome.Type != "cancelled" {
+		t.Errorf("empty choice = %+v, %v; want cancelled", resp, err)
+	}
+
+	bogus := PermissionChooser(func(context.Context, RequestPermissionRequest) (string, error) { return "yolo", nil })
+	if _, err := bogus.RequestPermission(context.Background(), req); err == nil {
+		t.Error("expected error for an option the agent did not offer")
+	}
+}
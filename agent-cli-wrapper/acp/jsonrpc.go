@@ -22,6 +22,7 @@ const (
 
 	// Client-provided methods (agent sends, client responds)
 	MethodRequestPermission = "session/request_permission"
+	MethodElicitation       = "session/elicitation"
 	MethodFsReadTextFile    = "fs/read_text_file"
 	MethodFsWriteTextFile   = "fs/write_text_file"
 	MethodTerminalCreate    = "terminal/create"
@@ -44,6 +45,19 @@ const (
 	UpdateTypeConfigOption      = "config_option_update"
 )
 
+// Stop reasons reported in PromptResponse.StopReason.
+const (
+	StopReasonEndTurn         = "end_turn"
+	StopReasonMaxTokens       = "max_tokens"
+	StopReasonMaxTurnRequests = "max_turn_requests"
+	StopReasonRefusal         = "refusal"
+	StopReasonCancelled       = "cancelled"
+
+	// stopReasonLegacyEndTurn is the camelCase spelling sent by older
+	// agents; it is treated like StopReasonEndTurn.
+	stopReasonLegacyEndTurn = "endTurn"
+)
+
 // JSONRPCRequest represents a JSON-RPC 2.0 request.
 type JSONRPCRequest struct {
 	JSONRPC string          `json:"jsonrpc"`
@@ -105,14 +105,29 @@ func (pm *processManager) ReadLine() ([]byte, error) {
 		line = line[:len(line)-1]
 	}
 
-	if w := pm.config.ProtocolLogger; w != nil {
-		// Best-effort write; ignore errors to avoid disrupting the read path.
-		_, _ = w.Write(append(append([]byte("<< "), line...), '\n'))
+	// Best-effort write; ignore errors to avoid disrupting the read path.
+	pm.logProtocol("<< ", line)
+	if observe := pm.config.RawMessageObserver; observe != nil {
+		observe(RawMessageReceived, line)
 	}
 
 	return line, nil
 }
 
+// logProtocol writes one message to the protocol logger, if any, after
+// applying the configured redactor. Write errors are ignored.
+func (pm *processManager) logProtocol(prefix string, msg []byte) {
+	w := pm.config.ProtocolLogger
+	if w == nil {
+		return
+	}
+	if redact := pm.config.ProtocolLogRedactor; redact != nil {
+		// Copy first: msg may alias the caller's read buffer.
+		msg = redact(append([]byte(nil), msg...))
+	}
+	_, _ = w.Write(append(append([]byte(prefix), msg...), '\n'))
+}
+
 // WriteJSON writes a JSON message to stdin.
 func (pm *processManager) WriteJSON(v interface{}) error {
 	pm.mu.Lock()
@@ -129,17 +144,30 @@ func (pm *processManager) WriteJSON(v interface{}) error {
 		return err
 	}
 
-	if w := pm.config.ProtocolLogger; w != nil {
+	if pm.config.ProtocolLogger != nil || pm.config.RawMessageObserver != nil {
 		// Best-effort: re-encode for logging. Use a separate encoder to avoid
 		// writing to stdin again.
 		if b, err := json.Marshal(v); err == nil {
-			_, _ = w.Write(append(append([]byte(">> "), b...), '\n'))
+			pm.logProtocol(">> ", b)
+			if observe := pm.config.RawMessageObserver; observe != nil {
+				observe(RawMessageSent, b)
+			}
 		}
 	}
 
 	return nil
 }
 
+// CloseInput closes the agent's stdin, which asks it to exit once it has
+// written everything pending. Later writes fail.
+func (pm *processManager) CloseInput() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if pm.stdin != nil {
+		_ = pm.stdin.Close()
+	}
+}
+
 // Stop gracefully stops the process.
 func (pm *processManager) Stop() error {
 	pm.mu.Lock()
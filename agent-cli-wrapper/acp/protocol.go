@@ -1,6 +1,9 @@
 package acp
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+)
 
 // ACP protocol version supported by this SDK.
 const ProtocolVersion = 1
@@ -30,8 +33,9 @@ type Implementation struct {
 
 // ClientCapabilities advertises what the client supports.
 type ClientCapabilities struct {
-	Fs       *FsCapability `json:"fs,omitempty"`
-	Terminal bool          `json:"terminal,omitempty"`
+	Fs          *FsCapability `json:"fs,omitempty"`
+	Terminal    bool          `json:"terminal,omitempty"`
+	Elicitation bool          `json:"elicitation,omitempty"`
 }
 
 // FsCapability describes file system capabilities.
@@ -42,8 +46,18 @@ type FsCapability struct {
 
 // AgentCapabilities advertises what the agent supports.
 type AgentCapabilities struct {
-	McpCapabilities *McpCapabilities `json:"mcpCapabilities,omitempty"`
-	LoadSession     bool             `json:"loadSession,omitempty"`
+	McpCapabilities *McpCapabilities       `json:"mcpCapabilities,omitempty"`
+	Meta            *AgentCapabilitiesMeta `json:"_meta,omitempty"`
+	LoadSession     bool                   `json:"loadSession,omitempty"`
+}
+
+// AgentCapabilitiesMeta holds non-standard capabilities an agent advertises
+// under _meta.
+type AgentCapabilitiesMeta struct {
+	// PromptCWD is true when the agent honours _meta.cwd on session/prompt.
+	// PromptMeta.CWD is only sent to agents that set it; see
+	// Session.PromptInDir.
+	PromptCWD bool `json:"promptCwd,omitempty"`
 }
 
 // McpCapabilities describes supported MCP transports.
@@ -155,13 +169,21 @@ type EnvVar struct {
 
 // PromptRequest sends a user prompt to the agent.
 type PromptRequest struct {
+	Meta      *PromptMeta    `json:"_meta,omitempty"`
 	SessionID string         `json:"sessionId"`
 	Prompt    []ContentBlock `json:"prompt"`
 }
 
+// PromptMeta carries extension fields on a session/prompt request. Agents
+// that do not understand them ignore _meta.
+type PromptMeta struct {
+	// CWD scopes the prompt to a directory inside the session cwd.
+	CWD string `json:"cwd,omitempty"`
+}
+
 // PromptResponse indicates the prompt turn has completed.
 type PromptResponse struct {
-	StopReason string `json:"stopReason"` // "endTurn", "cancelled", "error", "maxTokens"
+	StopReason string `json:"stopReason"` // one of the StopReason* constants
 }
 
 // --- Content Blocks ---
@@ -207,11 +229,19 @@ type SessionUpdate struct {
 	// agent_message_chunk / agent_thought_chunk fields
 	Content *ContentBlock `json:"content,omitempty"`
 
+	// ToolContent holds the raw "content" array of tool_call and
+	// tool_call_update entries (text, diff, terminal items); see
+	// UnmarshalJSON.
+	ToolContent json.RawMessage `json:"-"`
+
 	// tool_call fields
 	ToolCallID string                 `json:"toolCallId,omitempty"`
 	ToolName   string                 `json:"toolName,omitempty"`
 	Status     string                 `json:"status,omitempty"` // "running", "completed", "errored"
 	Input      map[string]interface{} `json:"input,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Kind       string                 `json:"kind,omitempty"` // "read", "edit", "delete", "execute", ...
+	Locations  []ToolLocation         `json:"locations,omitempty"`
 
 	// tool_call_result fields
 	Result []ContentBlock `json:"result,omitempty"`
@@ -229,6 +259,36 @@ type SessionUpdate struct {
 	Meta json.RawMessage `json:"_meta,omitempty"`
 }
 
+// UnmarshalJSON decodes "content" as a ContentBlock for message chunks and
+// keeps it raw in ToolContent when it is an array, as it is for tool calls,
+// so tool call updates that carry content still decode.
+func (u *SessionUpdate) UnmarshalJSON(data []byte) error {
+	type plain SessionUpdate
+	var aux struct {
+		*plain
+		Content json.RawMessage `json:"content,omitempty"`
+	}
+	aux.plain = (*plain)(u)
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	raw := bytes.TrimSpace(aux.Content)
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+		u.Content = nil
+	case raw[0] == '[':
+		u.Content = nil
+		u.ToolContent = aux.Content
+	default:
+		var block ContentBlock
+		if err := json.Unmarshal(raw, &block); err != nil {
+			return err
+		}
+		u.Content = &block
+	}
+	return nil
+}
+
 // Plan represents an agent's execution plan.
 type Plan struct {
 	Entries []PlanEntry `json:"entries"`
@@ -377,3 +437,23 @@ type PermissionOutcome struct {
 	Type     string `json:"type"` // "cancelled", "selected"
 	OptionID string `json:"optionId,omitempty"`
 }
+
+// ElicitationRequest is sent by the agent to ask the user a question
+// mid-turn. Options, when set, are the suggested answers.
+type ElicitationRequest struct {
+	SessionID string   `json:"sessionId"`
+	Message   string   `json:"message"`
+	Options   []string `json:"options,omitempty"`
+}
+
+// ElicitationResponse answers an ElicitationRequest.
+type ElicitationResponse struct {
+	Action string `json:"action"` // one of the ElicitationAction* constants
+	Answer string `json:"answer,omitempty"`
+}
+
+// Elicitation response actions.
+const (
+	ElicitationActionAccept  = "accept"
+	ElicitationActionDecline = "decline"
+)
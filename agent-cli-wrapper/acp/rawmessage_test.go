@@ -0,0 +1,110 @@
+package acp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// startCustomAgent starts a client backed by the "custom" fake agent and
+// returns a session on it.
+func startCustomAgent(t *testing.T, opts ...ClientOption) (context.Context, *Session) {
+	t.Helper()
+	opts = append([]ClientOption{
+		WithBinaryPath(os.Args[0]),
+		WithBinaryArgs("-test.run=^TestFakeAgentProcess$"),
+		WithEnv(map[string]string{"ACP_FAKE_AGENT": "custom"}),
+	}, opts...)
+	client := NewClient(opts...)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = client.Stop() })
+	session, err := client.NewSession(ctx)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	return ctx, session
+}
+
+func TestRawRequestHandlerAnswersCustomMethod(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		observed []string
+	)
+	ctx, session := startCustomAgent(t,
+		WithRawRequestHandler("_fake/lookup", func(params json.RawMessage) (json.RawMessage, error) {
+			var req struct {
+				Key string `json:"key"`
+			}
+			if err := json.Unmarshal(params, &req); err != nil {
+				return nil, err
+			}
+			if req.Key == "missing" {
+				return nil, &RPCError{Code: -32001, Message: "no such key"}
+			}
+			return json.RawMessage(`{"value":"` + strings.ToUpper(req.Key) + `"}`), nil
+		}),
+		WithRawMessageObserver(func(direction string, raw json.RawMessage) {
+			var msg struct {
+				Method string `json:"method"`
+			}
+			_ = json.Unmarshal(raw, &msg)
+			mu.Lock()
+			observed = append(observed, direction+" "+msg.Method)
+			mu.Unlock()
+		}),
+	)
+
+	result, err := session.Prompt(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Prompt() error = %v", err)
+	}
+	if result.FullText != `{"value":"ABC"}` {
+		t.Errorf("agent got result %q, want the handler's raw result", result.FullText)
+	}
+
+	result, err = session.Prompt(ctx, "missing")
+	if err != nil {
+		t.Fatalf("Prompt() error = %v", err)
+	}
+	if result.FullText != "-32001:no such key" {
+		t.Errorf("agent got %q, want the handler's RPCError", result.FullText)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, want := range []string{
+		RawMessageSent + " " + MethodInitialize,
+		RawMessageSent + " " + MethodSessionPrompt,
+		RawMessageReceived + " _fake/lookup",
+		RawMessageReceived + " " + MethodSessionUpdate,
+	} {
+		if !slices.Contains(observed, want) {
+			t.Errorf("observer missed %q; saw %v", want, observed)
+		}
+	}
+}
+
+func TestUnhandledCustomMethodIsMethodNotFound(t *testing.T) {
+	ctx, session := startCustomAgent(t,
+		WithRawRequestHandler("_fake/other", func(json.RawMessage) (json.RawMessage, error) {
+			return nil, errors.New("not called")
+		}),
+	)
+	result, err := session.Prompt(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Prompt() error = %v", err)
+	}
+	if result.FullText != "-32601:unknown method: _fake/lookup" {
+		t.Errorf("agent got %q, want method not found", result.FullText)
+	}
+}
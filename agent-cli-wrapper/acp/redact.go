@@ -0,0 +1,34 @@
+package acp
+
+import "regexp"
+
+// redactedPlaceholder replaces secrets matched by DefaultProtocolLogRedactor.
+const redactedPlaceholder = "[REDACTED]"
+
+// secretPatterns match common credential formats that can end up in prompts,
+// tool output, or environment dumps relayed over ACP.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`),                                       // OpenAI / Anthropic API keys
+	regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{20,}`),                                    // GitHub tokens
+	regexp.MustCompile(`github_pat_[A-Za-z0-9_]{20,}`),                                  // GitHub fine-grained tokens
+	regexp.MustCompile(`AIza[0-9A-Za-z_-]{35}`),                                         // Google API keys
+	regexp.MustCompile(`xox[abprs]-[A-Za-z0-9-]{10,}`),                                  // Slack tokens
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),                                 // AWS access key IDs
+	regexp.MustCompile(`glpat-[A-Za-z0-9_-]{20,}`),                                      // GitLab tokens
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), // JWTs
+}
+
+// bearerPattern keeps the scheme so the log still shows an auth header was
+// present.
+var bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]{8,}`)
+
+// DefaultProtocolLogRedactor masks API keys, access tokens, and bearer
+// credentials in a protocol log line. Pass it to WithProtocolLoggerRedactor.
+// Matching is pattern-based, so secrets in unusual formats can still slip
+// through; treat redacted logs as reduced-risk, not safe to publish.
+func DefaultProtocolLogRedactor(b []byte) []byte {
+	for _, re := range secretPatterns {
+		b = re.ReplaceAll(b, []byte(redactedPlaceholder))
+	}
+	return bearerPattern.ReplaceAll(b, []byte("${1}"+redactedPlaceholder))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -14,7 +16,9 @@ type Session struct {
 	client          *Client
 	state           *sessionStateManager
 	turnDone        chan *TurnResult
+	pendingEdits    map[string]*pendingFileEdit // keyed by tool call ID
 	id              string
+	cwd             string
 	text            strings.Builder
 	thinking        strings.Builder
 	mu              sync.Mutex
@@ -27,11 +31,17 @@ type TurnResult struct {
 	Error      error
 	FullText   string
 	Thinking   string
-	StopReason string // "endTurn", "cancelled", "error", "maxTokens"
+	StopReason string // why the turn ended; one of the StopReason* constants
 	DurationMs int64
 	Success    bool
 }
 
+// Truncated reports whether the turn stopped at a token or request limit
+// rather than finishing, so the caller may want to prompt it to continue.
+func (r *TurnResult) Truncated() bool {
+	return r.StopReason == StopReasonMaxTokens || r.StopReason == StopReasonMaxTurnRequests
+}
+
 func newSession(client *Client, id string) *Session {
 	s := &Session{
 		client: client,
@@ -47,6 +57,12 @@ func (s *Session) ID() string {
 	return s.id
 }
 
+// CanResume reports whether this session can be reloaded later with
+// LoadSession, i.e. whether the agent advertised session/load support.
+func (s *Session) CanResume() bool {
+	return s.client != nil && s.client.CanLoadSession()
+}
+
 // State returns the current session state.
 func (s *Session) State() SessionState {
 	return s.state.Current()
@@ -54,6 +70,74 @@ func (s *Session) State() SessionState {
 
 // Prompt sends a text prompt and waits for the turn to complete.
 func (s *Session) Prompt(ctx context.Context, text string) (*TurnResult, error) {
+	return s.prompt(ctx, text, nil)
+}
+
+// PromptBatch sends prompts one after another, waiting for each turn to
+// complete before sending the next, and returns their results in order.
+// The prompts run sequentially, not concurrently: an ACP session handles a
+// single turn at a time. On the first error PromptBatch stops and returns
+// the results so far, including the failed turn's result when there is one.
+func (s *Session) PromptBatch(ctx context.Context, prompts []string) ([]*TurnResult, error) {
+	results := make([]*TurnResult, 0, len(prompts))
+	for i, text := range prompts {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result, err := s.prompt(ctx, text, nil)
+		if result != nil {
+			results = append(results, result)
+		}
+		if err != nil {
+			return results, fmt.Errorf("prompt %d of %d: %w", i+1, len(prompts), err)
+		}
+	}
+	return results, nil
+}
+
+// PromptInDir sends a text prompt scoped to dir and waits for the turn to
+// complete. dir must be inside the session's working directory; a relative
+// dir is resolved against it. Agents that advertise _meta.promptCwd run the
+// prompt in dir; others run it in the session cwd, as Prompt does.
+func (s *Session) PromptInDir(ctx context.Context, dir, text string) (*TurnResult, error) {
+	resolved, err := resolvePromptDir(s.cwd, dir)
+	if err != nil {
+		return nil, err
+	}
+	var meta *PromptMeta
+	if resolved != s.cwd && s.client.supportsPromptCWD() {
+		meta = &PromptMeta{CWD: resolved}
+	}
+	return s.prompt(ctx, text, meta)
+}
+
+// resolvePromptDir resolves dir against the session cwd and checks that it
+// stays inside it, following symlinks where the paths exist so a link
+// cannot point the agent outside the session.
+func resolvePromptDir(cwd, dir string) (string, error) {
+	if cwd == "" {
+		return "", fmt.Errorf("%w: session has no working directory", ErrDirOutsideSession)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cwd, dir)
+	}
+	dir = filepath.Clean(dir)
+	root := filepath.Clean(cwd)
+	if r, err := filepath.EvalSymlinks(root); err == nil {
+		root = r
+	}
+	target := dir
+	if r, err := filepath.EvalSymlinks(dir); err == nil {
+		target = r
+	}
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrDirOutsideSession, dir)
+	}
+	return dir, nil
+}
+
+func (s *Session) prompt(ctx context.Context, text string, meta *PromptMeta) (*TurnResult, error) {
 	s.mu.Lock()
 	if s.state.IsClosed() {
 		s.mu.Unlock()
@@ -73,6 +157,7 @@ func (s *Session) Prompt(ctx context.Context, text string) (*TurnResult, error)
 	s.text.Reset()
 	s.thinking.Reset()
 	s.sawToolActivity = false
+	s.pendingEdits = nil
 	s.turnDone = make(chan *TurnResult, 1)
 	s.mu.Unlock()
 
@@ -80,6 +165,7 @@ func (s *Session) Prompt(ctx context.Context, text string) (*TurnResult, error)
 
 	// Send prompt request
 	params := PromptRequest{
+		Meta:      meta,
 		SessionID: s.id,
 		Prompt:    []ContentBlock{NewTextContent(text)},
 	}
@@ -94,7 +180,7 @@ func (s *Session) Prompt(ctx context.Context, text string) (*TurnResult, error)
 		// streamed, so treat this as a successful turn when we have
 		// accumulated content from the stream.
 		if s.isRecoverablePromptError(err) {
-			return s.completeTurn("endTurn", durationMs), nil
+			return s.completeTurn(StopReasonEndTurn, durationMs), nil
 		}
 
 		_ = s.state.SetReady()
@@ -114,7 +200,9 @@ func (s *Session) Prompt(ctx context.Context, text string) (*TurnResult, error)
 	if resp.Result != nil {
 		if jsonErr := json.Unmarshal(resp.Result, &promptResp); jsonErr != nil {
 			_ = s.state.SetReady()
-			return nil, &ProtocolError{Message: "failed to parse prompt response", Cause: jsonErr}
+			err := &ProtocolError{Message: "failed to parse prompt response", Cause: jsonErr}
+			s.signalTurnDone(&TurnResult{Error: err, DurationMs: durationMs})
+			return nil, err
 		}
 	}
 
@@ -128,22 +216,36 @@ func (s *Session) Cancel() error {
 	})
 }
 
+// inFlightTurn returns the channel the running prompt's result is delivered
+// on, or nil when no prompt is in flight.
+func (s *Session) inFlightTurn() <-chan *TurnResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state.Current() != SessionStateProcessing {
+		return nil
+	}
+	return s.turnDone
+}
+
 // handleUpdate processes a session/update notification from the agent.
-// Called by the client's readLoop.
-func (s *Session) handleUpdate(update *SessionUpdate) {
+// Message and thought chunks are kept in separate buffers keyed on the update
+// kind, so interleaved Gemini thoughts never leak into the response text. For
+// those chunks it returns the accumulated text of the matching stream, read
+// under the same lock as the append; ok is false for every other update.
+func (s *Session) handleUpdate(update *SessionUpdate) (full string, ok bool) {
 	switch update.Type {
-	case UpdateTypeAgentMessage:
-		if update.Content != nil && update.Content.Type == "text" {
-			s.mu.Lock()
-			s.text.WriteString(update.Content.Text)
-			s.mu.Unlock()
+	case UpdateTypeAgentMessage, UpdateTypeAgentThought:
+		if update.Content == nil || update.Content.Type != "text" {
+			return "", false
 		}
-	case UpdateTypeAgentThought:
-		if update.Content != nil && update.Content.Type == "text" {
-			s.mu.Lock()
-			s.thinking.WriteString(update.Content.Text)
-			s.mu.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		buf := &s.text
+		if update.Type == UpdateTypeAgentThought {
+			buf = &s.thinking
 		}
+		buf.WriteString(update.Content.Text)
+		return buf.String(), true
 	case UpdateTypeToolCall, UpdateTypeToolCallUpdate:
 		s.mu.Lock()
 		s.sawToolActivity = true
@@ -151,11 +253,12 @@ func (s *Session) handleUpdate(update *SessionUpdate) {
 	}
 	// Other update types (plan_update, etc.) are handled by
 	// the client's event emission in handleSessionUpdate.
+	return "", false
 }
 
-// completeTurn builds a TurnResult from accumulated session updates, signals
-// the turnDone channel, transitions state to ready, and emits a TurnComplete
-// event. Used by both the normal success path and the recovery path.
+// completeTurn builds a TurnResult from accumulated session updates,
+// transitions state to ready, emits a TurnComplete event, and signals the
+// turnDone channel. Used by both the normal success path and the recovery path.
 func (s *Session) completeTurn(stopReason string, durationMs int64) *TurnResult {
 	s.mu.Lock()
 	s.turnCount++
@@ -165,9 +268,8 @@ func (s *Session) completeTurn(stopReason string, durationMs int64) *TurnResult
 		Thinking:   s.thinking.String(),
 		StopReason: stopReason,
 		DurationMs: durationMs,
-		Success:    stopReason == "endTurn" || stopReason == "end_turn" || stopReason == "",
+		Success:    isEndTurn(stopReason),
 	}
-	s.signalTurnDoneLocked(result)
 	s.mu.Unlock()
 
 	_ = s.state.SetReady()
@@ -181,6 +283,9 @@ func (s *Session) completeTurn(stopReason string, durationMs int64) *TurnResult
 		TurnNumber: turnNum,
 		Success:    result.Success,
 	})
+	// Signal last: StopWithTimeout waits on turnDone and then closes the
+	// events channel, so the TurnComplete emit must already have happened.
+	s.signalTurnDone(result)
 
 	return result
 }
@@ -227,3 +332,13 @@ func (s *Session) isRecoverablePromptError(err error) bool {
 func (s *Session) close() {
 	s.state.SetClosed()
 }
+
+// isEndTurn reports whether stopReason means the model finished naturally.
+// An empty reason is treated as end_turn for agents that omit it.
+func isEndTurn(stopReason string) bool {
+	switch stopReason {
+	case StopReasonEndTurn, stopReasonLegacyEndTurn, "":
+		return true
+	}
+	return false
+}
@@ -2,8 +2,11 @@ package acp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -100,3 +103,80 @@ func TestLoadSession_UnsupportedCapabilityFallsBackError(t *testing.T) {
 		t.Fatalf("LoadSession error = %v, want ErrSessionNotFound", err)
 	}
 }
+
+func TestCanResumeFollowsLoadSessionCapability(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    bool
+	}{
+		{"no capabilities", `{"protocolVersion":1}`, false},
+		{"load missing", `{"protocolVersion":1,"agentCapabilities":{"promptCapabilities":{"image":true}}}`, false},
+		{"load false", `{"protocolVersion":1,"agentCapabilities":{"loadSession":false}}`, false},
+		{"load true", `{"protocolVersion":1,"agentCapabilities":{"loadSession":true}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info InitializeResponse
+			if err := json.Unmarshal([]byte(tt.payload), &info); err != nil {
+				t.Fatal(err)
+			}
+			client := NewClient()
+			client.started = true
+			client.agentInfo = &info
+			s := newSession(client, "session-123")
+			if got := s.CanResume(); got != tt.want {
+				t.Errorf("CanResume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePromptDir(t *testing.T) {
+	root := t.TempDir()
+	cwd := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(cwd, "pkg", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(root, "other")
+	if err := os.Mkdir(outside, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(cwd, "escape")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cwd     string
+		dir     string
+		want    string
+		wantErr bool
+	}{
+		{name: "relative", cwd: cwd, dir: "pkg/sub", want: filepath.Join(cwd, "pkg", "sub")},
+		{name: "absolute inside", cwd: cwd, dir: filepath.Join(cwd, "pkg"), want: filepath.Join(cwd, "pkg")},
+		{name: "session root", cwd: cwd, dir: ".", want: cwd},
+		{name: "dotdot escape", cwd: cwd, dir: "pkg/../../other", wantErr: true},
+		{name: "absolute outside", cwd: cwd, dir: outside, wantErr: true},
+		{name: "sibling prefix", cwd: cwd, dir: cwd + "-evil", wantErr: true},
+		{name: "symlink escape", cwd: cwd, dir: "escape", wantErr: true},
+		{name: "no session cwd", cwd: "", dir: "pkg", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePromptDir(tt.cwd, tt.dir)
+			if tt.wantErr {
+				if !errors.Is(err, ErrDirOutsideSession) {
+					t.Fatalf("resolvePromptDir() error = %v, want ErrDirOutsideSession", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePromptDir() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolvePromptDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,7 +18,9 @@
 //
 // This package defines a narrow set of interfaces that SDK event types can
 // optionally implement. The 6 event kinds (Text, Thinking, ToolStart, ToolEnd,
-// TurnComplete, Error) capture the common subset that all providers need.
+// TurnComplete, Error) capture the common subset that all providers need; a
+// seventh, ToolInputDelta, is emitted only by providers that stream tool input
+// (claude) so UIs can show progress on large tool calls.
 //
 // Key design choices:
 //
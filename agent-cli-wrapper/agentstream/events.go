@@ -14,6 +14,9 @@ const (
 	KindToolEnd
 	KindTurnComplete
 	KindError
+	// KindToolInputDelta carries the partial JSON input of a tool call that
+	// is still being streamed, before the matching ToolEnd.
+	KindToolInputDelta
 )
 
 // Event is the common interface that SDK event types implement to participate
@@ -56,6 +59,16 @@ type ToolEnd interface {
 	StreamToolIsError() bool
 }
 
+// ToolInputDelta provides the accumulated, possibly incomplete, JSON input of
+// a tool call whose input is still streaming.
+// Method names are prefixed with "Stream" to avoid conflicts with SDK struct fields.
+type ToolInputDelta interface {
+	Event
+	StreamToolName() string
+	StreamToolCallID() string
+	StreamPartialInput() string
+}
+
 // TurnComplete provides turn completion metadata.
 type TurnComplete interface {
 	Event
@@ -65,6 +78,16 @@ type TurnComplete interface {
 	StreamCost() float64
 }
 
+// Usage is an optional interface for TurnComplete events that report token
+// counts. Reasoning tokens are the part of the output spent on hidden
+// reasoning; they are included in StreamOutputTokens and are 0 for
+// providers that do not break them out.
+type Usage interface {
+	StreamInputTokens() int64
+	StreamOutputTokens() int64
+	StreamReasoningTokens() int64
+}
+
 // Error provides error information.
 type Error interface {
 	Event
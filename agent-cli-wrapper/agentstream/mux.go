@@ -0,0 +1,122 @@
+package agentstream
+
+import "sync"
+
+// MuxedEvent is an event delivered by a Mux, tagged with the name of the
+// source it was read from.
+type MuxedEvent[E any] struct {
+	Event  E
+	Source string
+}
+
+// Mux fans several event channels into one. Each source is read by its own
+// goroutine; events from a single source keep their order, while events from
+// different sources are interleaved in arrival order.
+//
+// The merged channel closes once every added source has closed, or after
+// Close. Sources must be added before the last one closes; an Add after the
+// merged channel has closed, or once Close has started, is ignored.
+//
+// To merge channels of different SDK event types, use a Mux[any] (or a
+// Mux[Event]) and add each source with AddConverted.
+type Mux[E any] struct {
+	out       chan MuxedEvent[E]
+	done      chan struct{}
+	active    int
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	mu        sync.Mutex
+	closing   bool // set by Close before it waits; later Adds are ignored
+	outClosed bool
+}
+
+// NewMux creates an empty Mux.
+func NewMux[E any]() *Mux[E] {
+	return &Mux[E]{
+		out:  make(chan MuxedEvent[E]),
+		done: make(chan struct{}),
+	}
+}
+
+// Events returns the merged channel.
+func (m *Mux[E]) Events() <-chan MuxedEvent[E] {
+	return m.out
+}
+
+// Add starts forwarding events from ch, tagged with source.
+func (m *Mux[E]) Add(source string, ch <-chan E) {
+	AddConverted(m, source, ch, func(ev E) E { return ev })
+}
+
+// AddConverted starts forwarding events from a channel whose element type
+// differs from the Mux's, converting each event with convert.
+func AddConverted[S, E any](m *Mux[E], source string, ch <-chan S, convert func(S) E) {
+	if !m.track() {
+		return
+	}
+	go func() {
+		defer m.untrack()
+		for {
+			select {
+			case <-m.done:
+				return
+			case ev, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case m.out <- MuxedEvent[E]{Source: source, Event: convert(ev)}:
+				case <-m.done:
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Close stops forwarding from all sources and closes the merged channel.
+// Events still buffered in the sources are dropped. Close waits for the
+// forwarding goroutines to exit and is safe to call more than once. An Add
+// racing with Close is ignored.
+func (m *Mux[E]) Close() {
+	m.closeOnce.Do(func() {
+		// Flag the close under mu so no track can wg.Add once Wait starts.
+		m.mu.Lock()
+		m.closing = true
+		m.mu.Unlock()
+		close(m.done)
+	})
+	m.wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closeOutLocked()
+}
+
+func (m *Mux[E]) track() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closing || m.outClosed {
+		return false
+	}
+	m.active++
+	m.wg.Add(1)
+	return true
+}
+
+func (m *Mux[E]) untrack() {
+	m.mu.Lock()
+	m.active--
+	if m.active == 0 {
+		m.closeOutLocked()
+	}
+	m.mu.Unlock()
+	m.wg.Done()
+}
+
+func (m *Mux[E]) closeOutLocked() {
+	if !m.outClosed {
+		m.outClosed = true
+		close(m.out)
+	}
+}
@@ -0,0 +1,148 @@
+package agentstream
+
+import (
+	"testing"
+)
+
+func TestMuxMergesSourcesAndClosesWhenAllClose(t *testing.T) {
+	t.Parallel()
+
+	builder := make(chan string, 3)
+	reviewer := make(chan string, 2)
+	builder <- "b1"
+	builder <- "b2"
+	builder <- "b3"
+	reviewer <- "r1"
+	reviewer <- "r2"
+	close(builder)
+	close(reviewer)
+
+	m := NewMux[string]()
+	m.Add("builder", builder)
+	m.Add("reviewer", reviewer)
+
+	got := map[string][]string{}
+	for ev := range m.Events() {
+		got[ev.Source] = append(got[ev.Source], ev.Event)
+	}
+
+	// Per-source order is preserved; cross-source interleaving is not defined.
+	if want := []string{"b1", "b2", "b3"}; !equalStrings(got["builder"], want) {
+		t.Errorf("builder events = %v, want %v", got["builder"], want)
+	}
+	if want := []string{"r1", "r2"}; !equalStrings(got["reviewer"], want) {
+		t.Errorf("reviewer events = %v, want %v", got["reviewer"], want)
+	}
+}
+
+func TestMuxCloseStopsOpenSources(t *testing.T) {
+	t.Parallel()
+
+	src := make(chan int)
+	m := NewMux[int]()
+	m.Add("open", src)
+
+	src <- 1
+	if ev := <-m.Events(); ev.Source != "open" || ev.Event != 1 {
+		t.Fatalf("event = %+v, want {open 1}", ev)
+	}
+
+	m.Close()
+	m.Close() // idempotent
+	if _, ok := <-m.Events(); ok {
+		t.Fatal("merged channel should be closed after Close")
+	}
+
+	// Adding after close is ignored rather than panicking on a closed channel.
+	late := make(chan int, 1)
+	late <- 2
+	m.Add("late", late)
+	if _, ok := <-m.Events(); ok {
+		t.Fatal("Add after close should not reopen the merged channel")
+	}
+}
+
+func TestMuxAddDuringCloseIsIgnored(t *testing.T) {
+	t.Parallel()
+
+	src := make(chan int, 1)
+	src <- 1
+	converting := make(chan struct{})
+	release := make(chan struct{})
+	m := NewMux[int]()
+	// The slow conversion keeps a forwarder busy, holding Close in Wait.
+	AddConverted(m, "slow", src, func(v int) int {
+		close(converting)
+		<-release
+		return v
+	})
+	<-converting
+
+	closed := make(chan struct{})
+	go func() {
+		m.Close()
+		close(closed)
+	}()
+	<-m.done
+
+	late := make(chan int, 1)
+	late <- 2
+	m.Add("late", late)
+	m.mu.Lock()
+	active := m.active
+	m.mu.Unlock()
+	if active != 1 {
+		t.Fatalf("active sources = %d, want 1: an Add during Close must be ignored", active)
+	}
+
+	close(release)
+	<-closed
+	if _, ok := <-m.Events(); ok {
+		t.Fatal("merged channel should be closed after Close")
+	}
+}
+
+func TestMuxCloseWithNoSources(t *testing.T) {
+	t.Parallel()
+
+	m := NewMux[int]()
+	m.Close()
+	if _, ok := <-m.Events(); ok {
+		t.Fatal("merged channel should be closed")
+	}
+}
+
+func TestAddConverted(t *testing.T) {
+	t.Parallel()
+
+	texts := make(chan textEvent, 1)
+	errs := make(chan errorEvent, 1)
+	texts <- textEvent{kind: KindText, delta: "hi"}
+	errs <- errorEvent{context: "stream"}
+	close(texts)
+	close(errs)
+
+	m := NewMux[Event]()
+	AddConverted(m, "claude", texts, func(ev textEvent) Event { return ev })
+	AddConverted(m, "codex", errs, func(ev errorEvent) Event { return ev })
+
+	kinds := map[string]EventKind{}
+	for ev := range m.Events() {
+		kinds[ev.Source] = ev.Event.StreamEventKind()
+	}
+	if kinds["claude"] != KindText || kinds["codex"] != KindError {
+		t.Errorf("kinds = %v, want claude=text codex=error", kinds)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/agentstream"
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/protocol"
 )
 
@@ -147,6 +148,16 @@ func TestStreamAccumulator_ToolUse(t *testing.T) {
 		t.Errorf("unexpected partial input: %q", progressEvents[1].PartialInput)
 	}
 
+	// Progress events bridge to providers as agentstream tool-input deltas.
+	var sev agentstream.Event = progressEvents[1]
+	delta, ok := sev.(agentstream.ToolInputDelta)
+	if !ok || sev.StreamEventKind() != agentstream.KindToolInputDelta {
+		t.Fatalf("ToolProgressEvent does not implement agentstream.ToolInputDelta")
+	}
+	if delta.StreamToolCallID() != "toolu_123" || delta.StreamPartialInput() != `{"query": "test search"}` {
+		t.Errorf("unexpected delta: id=%q partial=%q", delta.StreamToolCallID(), delta.StreamPartialInput())
+	}
+
 	// Simulate content_block_stop
 	acc.HandleEvent(protocol.StreamEvent{
 		SessionID: "test",
@@ -112,4 +112,17 @@
 //
 //	// Load a previous recording
 //	loaded, _ := claude.LoadRecording("./recordings/session-abc123")
+//
+// # Transcript Observers
+//
+// To persist the conversation in your own store without re-parsing the
+// recording, register an observer. It receives each prompt, assistant
+// message and tool result as a TranscriptEntry, on the session's goroutine,
+// so it must not block:
+//
+//	session := claude.NewSession(
+//	    claude.WithTranscriptObserver(func(e claude.TranscriptEntry) {
+//	        auditLog <- e // buffered; drop or spill if full
+//	    }),
+//	)
 package claude
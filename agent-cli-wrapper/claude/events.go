@@ -149,7 +149,10 @@ func (e ToolStartEvent) StreamToolName() string                  { return e.Name
 func (e ToolStartEvent) StreamToolCallID() string                { return e.ID }
 func (e ToolStartEvent) StreamToolInput() map[string]interface{} { return nil }
 
-// ToolProgressEvent contains partial tool input.
+// ToolProgressEvent contains partial tool input, built from the CLI's
+// input_json_delta stream. PartialInput is the JSON accumulated so far and
+// is usually not valid JSON until the tool input finishes; the parsed input
+// arrives on the following ToolCompleteEvent.
 type ToolProgressEvent struct {
 	ID           string
 	Name         string
@@ -161,6 +164,13 @@ type ToolProgressEvent struct {
 // Type returns the event type.
 func (e ToolProgressEvent) Type() EventType { return EventTypeToolProgress }
 
+func (e ToolProgressEvent) StreamEventKind() agentstream.EventKind {
+	return agentstream.KindToolInputDelta
+}
+func (e ToolProgressEvent) StreamToolName() string     { return e.Name }
+func (e ToolProgressEvent) StreamToolCallID() string   { return e.ID }
+func (e ToolProgressEvent) StreamPartialInput() string { return e.PartialInput }
+
 // ToolCompleteEvent fires when tool input is fully parsed.
 type ToolCompleteEvent struct {
 	Timestamp  time.Time
@@ -91,6 +91,9 @@ func (pm *processManager) BuildCLIArgs() ([]string, error) {
 	if pm.config.SystemPrompt != "" {
 		args = append(args, "--system-prompt", pm.config.SystemPrompt)
 	}
+	if pm.config.AppendSystemPrompt != "" {
+		args = append(args, "--append-system-prompt", pm.config.AppendSystemPrompt)
+	}
 
 	// Add resume session ID if provided
 	if pm.config.Resume != "" {
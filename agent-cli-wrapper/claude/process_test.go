@@ -296,6 +296,52 @@ func TestBuildCLIArgs_ExtraArgs(t *testing.T) {
 	}
 }
 
+func TestBuildCLIArgs_SystemPrompts(t *testing.T) {
+	tests := []struct {
+		name       string
+		system     string
+		appendText string
+		want       [][2]string
+		absent     []string
+	}{
+		{name: "neither", absent: []string{"--system-prompt", "--append-system-prompt"}},
+		{name: "override only", system: "custom", want: [][2]string{{"--system-prompt", "custom"}}, absent: []string{"--append-system-prompt"}},
+		{name: "append only", appendText: "extra", want: [][2]string{{"--append-system-prompt", "extra"}}, absent: []string{"--system-prompt"}},
+		{name: "both", system: "custom", appendText: "extra", want: [][2]string{{"--system-prompt", "custom"}, {"--append-system-prompt", "extra"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultConfig()
+			config.SystemPrompt = tt.system
+			config.AppendSystemPrompt = tt.appendText
+
+			args, err := newProcessManager(config).BuildCLIArgs()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, pair := range tt.want {
+				found := false
+				for i := 0; i < len(args)-1; i++ {
+					if args[i] == pair[0] && args[i+1] == pair[1] {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("expected %s %q in args %v", pair[0], pair[1], args)
+				}
+			}
+			for _, flag := range tt.absent {
+				for _, arg := range args {
+					if arg == flag {
+						t.Errorf("unexpected %s in args %v", flag, args)
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestBuildCLIArgs_MultipleOptions(t *testing.T) {
 	config := defaultConfig()
 	config.Model = "opus"
@@ -2,6 +2,7 @@ package claude
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
@@ -31,8 +32,41 @@ func NewTypedToolRegistry() *TypedToolRegistry {
 	}
 }
 
+// ToolContent is one item of a tool result's content array: text, an image,
+// or a reference to a file. Build values with TextContent, ImageContent, and
+// FileContent.
+type ToolContent = protocol.MCPContentItem
+
+// TextContent returns a text tool result item.
+func TextContent(text string) ToolContent {
+	return ToolContent{Type: protocol.MCPContentTypeText, Text: text}
+}
+
+// ImageContent returns an image tool result item. data is the raw image bytes
+// (base64-encoded here) and mimeType its format, e.g. "image/png".
+func ImageContent(data []byte, mimeType string) ToolContent {
+	return ToolContent{
+		Type:     protocol.MCPContentTypeImage,
+		Data:     base64.StdEncoding.EncodeToString(data),
+		MimeType: mimeType,
+	}
+}
+
+// FileContent returns a tool result item that refers to a file by URI (for a
+// local file, a file:// URI) rather than inlining it. name and mimeType are
+// optional.
+func FileContent(uri, name, mimeType string) ToolContent {
+	return ToolContent{
+		Type:     protocol.MCPContentTypeResourceLink,
+		URI:      uri,
+		Name:     name,
+		MimeType: mimeType,
+	}
+}
+
 // AddTool is a helper function that registers a type-safe tool handler using generics.
-// It returns the modified registry for method chaining.
+// It returns the modified registry for method chaining. The handler's string
+// result is sent as a single text item; use AddRichTool for images or files.
 //
 // The generic type parameter T should be a struct with json and jsonschema struct tags.
 //
@@ -51,6 +85,35 @@ func AddTool[T any](
 	registry *TypedToolRegistry,
 	name, description string,
 	handler func(context.Context, T) (string, error),
+) *TypedToolRegistry {
+	return AddRichTool(registry, name, description,
+		func(ctx context.Context, params T) ([]ToolContent, error) {
+			result, err := handler(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			return []ToolContent{TextContent(result)}, nil
+		})
+}
+
+// AddRichTool registers a type-safe tool whose handler returns a list of
+// content items, letting a tool return images or file references alongside
+// text. An error from the handler is reported to Claude as an error result.
+//
+// Example:
+//
+//	AddRichTool(registry, "chart", "Render a chart",
+//	    func(ctx context.Context, p ChartParams) ([]ToolContent, error) {
+//	        png, err := render(p)
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        return []ToolContent{TextContent(p.Title), ImageContent(png, "image/png")}, nil
+//	    })
+func AddRichTool[T any](
+	registry *TypedToolRegistry,
+	name, description string,
+	handler func(context.Context, T) ([]ToolContent, error),
 ) *TypedToolRegistry {
 	schema := generateSchema[T]()
 
@@ -61,7 +124,7 @@ func AddTool[T any](
 			return nil, fmt.Errorf("invalid arguments for tool %s: %w", name, err)
 		}
 
-		result, err := handler(ctx, params)
+		content, err := handler(ctx, params)
 		if err != nil {
 			return &protocol.MCPToolCallResult{
 				Content: []protocol.MCPContentItem{
@@ -70,12 +133,12 @@ func AddTool[T any](
 				IsError: true,
 			}, nil
 		}
+		if content == nil {
+			// The CLI expects a content array, never null.
+			content = []ToolContent{}
+		}
 
-		return &protocol.MCPToolCallResult{
-			Content: []protocol.MCPContentItem{
-				{Type: "text", Text: result},
-			},
-		}, nil
+		return &protocol.MCPToolCallResult{Content: content}, nil
 	}
 
 	registry.tools = append(registry.tools, toolRegistration{
@@ -390,3 +390,57 @@ func TestTypedToolRegistry_EmptyRegistry(t *testing.T) {
 	assert.True(t, result.IsError)
 	assert.Contains(t, result.Content[0].Text, "Unknown tool")
 }
+
+func TestTypedToolRegistry_RichTool(t *testing.T) {
+	t.Run("mixed content serializes to MCP items", func(t *testing.T) {
+		registry := NewTypedToolRegistry()
+		AddRichTool(registry, "chart", "Render a chart",
+			func(ctx context.Context, p SimpleParams) ([]ToolContent, error) {
+				return []ToolContent{
+					TextContent(p.Text),
+					ImageContent([]byte{0x89, 'P', 'N', 'G'}, "image/png"),
+					FileContent("file:///tmp/chart.csv", "chart.csv", "text/csv"),
+				}, nil
+			})
+
+		result, err := registry.HandleToolCall(context.Background(), "chart", json.RawMessage(`{"text": "sales"}`))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		raw, err := json.Marshal(result)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"content":[
+			{"type":"text","text":"sales"},
+			{"type":"image","data":"iVBORw==","mimeType":"image/png"},
+			{"type":"resource_link","uri":"file:///tmp/chart.csv","name":"chart.csv","mimeType":"text/csv"}
+		]}`, string(raw))
+	})
+
+	t.Run("nil content is an empty array", func(t *testing.T) {
+		registry := NewTypedToolRegistry()
+		AddRichTool(registry, "noop", "No output",
+			func(ctx context.Context, p SimpleParams) ([]ToolContent, error) {
+				return nil, nil
+			})
+
+		result, err := registry.HandleToolCall(context.Background(), "noop", json.RawMessage(`{"text": "x"}`))
+		require.NoError(t, err)
+		raw, err := json.Marshal(result)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"content":[]}`, string(raw))
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		registry := NewTypedToolRegistry()
+		AddRichTool(registry, "broken", "Always fails",
+			func(ctx context.Context, p SimpleParams) ([]ToolContent, error) {
+				return nil, fmt.Errorf("render failed")
+			})
+
+		result, err := registry.HandleToolCall(context.Background(), "broken", json.RawMessage(`{"text": "x"}`))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, "render failed", result.Content[0].Text)
+	})
+}
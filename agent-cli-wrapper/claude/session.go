@@ -71,6 +71,7 @@ type Session struct {
 	events                  chan Event
 	recorder                *sessionRecorder
 	cancel                  context.CancelFunc
+	model                   string // last model passed to SetModel after start; protected by mu
 
 	// Value / struct fields.
 	config            SessionConfig
@@ -78,10 +79,11 @@ type Session struct {
 	cumulativeCostUSD float64
 
 	// Scalar and sync fields.
-	mu        sync.RWMutex
-	pendingMu sync.Mutex
-	started   bool
-	stopping  bool
+	mu         sync.RWMutex
+	pendingMu  sync.Mutex
+	observerMu sync.Mutex // serializes TranscriptObserver calls
+	started    bool
+	stopping   bool
 }
 
 // NewSession creates a new Claude session with options.
@@ -306,6 +308,7 @@ func (s *Session) SendMessage(ctx context.Context, content string) (int, error)
 	if s.recorder != nil {
 		s.recorder.RecordSent(msg)
 	}
+	s.observe(TranscriptEntry{Role: TranscriptRoleUser, Text: content, TurnNumber: turn.Number})
 
 	// Transition to processing state if we're ready
 	// Ignore error if already processing (multiple messages in flight)
@@ -363,6 +366,13 @@ func (s *Session) SendToolResult(ctx context.Context, toolUseID, content string)
 	if s.recorder != nil {
 		s.recorder.RecordSent(msg)
 	}
+	if s.config.TranscriptObserver != nil {
+		entry := TranscriptEntry{Role: TranscriptRoleTool, Text: content, ToolUseID: toolUseID, TurnNumber: turn.Number}
+		if tool := s.turnManager.FindToolByID(toolUseID); tool != nil {
+			entry.ToolName = tool.Name
+		}
+		s.observe(entry)
+	}
 
 	// Transition to processing state if we're ready
 	_ = s.state.Transition(TransitionUserMessageSent)
@@ -479,7 +489,10 @@ func (s *Session) Interrupt(ctx context.Context) error {
 	return s.process.WriteMessage(req)
 }
 
-// SetModel sends a set_model control request to switch the model mid-session.
+// SetModel switches the model mid-session. Before Start it only changes the
+// model the CLI is spawned with; afterwards it sends a set_model control
+// request, which takes effect from the next SendMessage. A turn already in
+// progress finishes on the model it started with.
 func (s *Session) SetModel(ctx context.Context, model string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -497,7 +510,26 @@ func (s *Session) SetModel(ctx context.Context, model string) error {
 		s.recorder.RecordSent(req)
 	}
 
-	return s.process.WriteMessage(req)
+	if err := s.process.WriteMessage(req); err != nil {
+		return err
+	}
+	s.model = model
+	return nil
+}
+
+// CurrentModel returns the model the next turn will use: the last model
+// set with SetModel, else the model the CLI reported at init, else the
+// configured model. It is empty when none of those is known.
+func (s *Session) CurrentModel() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.model != "" {
+		return s.model
+	}
+	if s.info != nil && s.info.Model != "" {
+		return s.info.Model
+	}
+	return s.config.Model
 }
 
 // Stop gracefully shuts down the session.
@@ -990,6 +1022,7 @@ func (s *Session) handleAssistant(msg protocol.AssistantMessage) {
 		ParentToolUse: msg.ParentToolUseID,
 		TurnNumber:    turnNumber,
 	})
+	s.observeAssistant(blocks, msg.ParentToolUseID, turnNumber)
 
 	// Extract text from complete message
 	for _, block := range blocks {
@@ -1084,7 +1117,15 @@ func (s *Session) handleUser(msg protocol.UserMessage) {
 				Content:    resultBlock.Content,
 				IsError:    isError,
 			})
-
+			s.observe(TranscriptEntry{
+				Role:            TranscriptRoleTool,
+				Text:            transcriptText(resultBlock.Content),
+				ToolUseID:       resultBlock.ToolUseID,
+				ToolName:        toolName,
+				ParentToolUseID: derefString(msg.ParentToolUseID),
+				TurnNumber:      s.turnManager.CurrentTurnNumber(),
+				IsError:         isError,
+			})
 		}
 	}
 
@@ -724,3 +724,28 @@ func TestSessionFinalizeTurn_NormalPathNotWakeupTimedOut(t *testing.T) {
 	require.False(t, tc.WakeupTimedOut,
 		"a normal turn completion must not carry WakeupTimedOut")
 }
+
+func TestSessionSetModel(t *testing.T) {
+	s := newTestSession(t, WithModel("sonnet"))
+	require.Equal(t, "sonnet", s.CurrentModel())
+
+	// Before start, SetModel only changes the spawn config.
+	require.NoError(t, s.SetModel(context.Background(), "haiku"))
+	require.Equal(t, "haiku", s.config.Model)
+	require.Equal(t, "haiku", s.CurrentModel())
+
+	buf := attachCapturingProcess(t, s)
+	s.started = true
+	s.info = &SessionInfo{Model: "claude-haiku-4-5"}
+	require.Equal(t, "claude-haiku-4-5", s.CurrentModel(), "the model reported at init wins over the config")
+
+	require.NoError(t, s.SetModel(context.Background(), "opus"))
+	require.Equal(t, "opus", s.CurrentModel())
+
+	var sent map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &sent))
+	require.Equal(t, "control_request", sent["type"])
+	request, _ := sent["request"].(map[string]interface{})
+	require.Equal(t, "set_model", request["subtype"])
+	require.Equal(t, "opus", request["model"])
+}
@@ -42,9 +42,11 @@ type SessionConfig struct {
 	Env                        map[string]string
 	Tools                      *string
 	HookCallbackHandler        func(ctx context.Context, req protocol.HookCallbackRequest) (map[string]any, error)
+	TranscriptObserver         TranscriptObserver
 	UsageHTTPClient            UsageHTTPClient
 	Model                      string
 	SystemPrompt               string
+	AppendSystemPrompt         string
 	Resume                     string
 	RecordingDir               string
 	CLIPath                    string
@@ -179,13 +181,50 @@ func WithMCPConfig(cfg *MCPConfig) SessionOption {
 	}
 }
 
-// WithSystemPrompt sets a custom system prompt.
+// WithSystemPrompt sets a custom system prompt, replacing the CLI's default
+// entirely.
 func WithSystemPrompt(prompt string) SessionOption {
 	return func(c *SessionConfig) {
 		c.SystemPrompt = prompt
 	}
 }
 
+// SystemPromptSectionDelimiter separates the sections joined by
+// JoinSystemPromptSections.
+const SystemPromptSectionDelimiter = "\n\n---\n\n"
+
+// JoinSystemPromptSections joins prompt sections (e.g. base behavior, repo
+// conventions, task) with SystemPromptSectionDelimiter. Sections are trimmed
+// and blank ones dropped.
+func JoinSystemPromptSections(sections ...string) string {
+	parts := make([]string, 0, len(sections))
+	for _, section := range sections {
+		if section = strings.TrimSpace(section); section != "" {
+			parts = append(parts, section)
+		}
+	}
+	return strings.Join(parts, SystemPromptSectionDelimiter)
+}
+
+// WithSystemPromptSections sets a custom system prompt composed of sections
+// joined by JoinSystemPromptSections. Like WithSystemPrompt, it replaces the
+// CLI's default prompt.
+func WithSystemPromptSections(sections ...string) SessionOption {
+	return func(c *SessionConfig) {
+		c.SystemPrompt = JoinSystemPromptSections(sections...)
+	}
+}
+
+// WithAppendSystemPrompt adds text after the system prompt
+// (--append-system-prompt) instead of replacing it, so the CLI's default
+// prompt, or one set by WithSystemPrompt, is kept. Repeated calls add
+// further sections, joined by JoinSystemPromptSections.
+func WithAppendSystemPrompt(prompt string) SessionOption {
+	return func(c *SessionConfig) {
+		c.AppendSystemPrompt = JoinSystemPromptSections(c.AppendSystemPrompt, prompt)
+	}
+}
+
 // WithPermissionPromptToolStdio enables stdio-based permission prompts.
 // This causes all tool permissions to be sent as can_use_tool control requests
 // instead of being handled by the CLI's interactive UI.
@@ -100,3 +100,33 @@ func TestWithLLMEndpoint_stripsTrailingV1(t *testing.T) {
 		}
 	}
 }
+
+func TestWithSystemPromptSections(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	WithSystemPromptSections("  base behavior\n", "", "repo conventions", "  ", "task")(&cfg)
+
+	want := "base behavior" + SystemPromptSectionDelimiter + "repo conventions" + SystemPromptSectionDelimiter + "task"
+	if cfg.SystemPrompt != want {
+		t.Errorf("SystemPrompt = %q, want %q", cfg.SystemPrompt, want)
+	}
+	if cfg.AppendSystemPrompt != "" {
+		t.Errorf("AppendSystemPrompt = %q, want empty", cfg.AppendSystemPrompt)
+	}
+}
+
+func TestWithAppendSystemPromptAccumulates(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	WithAppendSystemPrompt("repo conventions")(&cfg)
+	WithAppendSystemPrompt("")(&cfg)
+	WithAppendSystemPrompt("task guidance")(&cfg)
+
+	if cfg.SystemPrompt != "" {
+		t.Errorf("SystemPrompt = %q, want the CLI default kept", cfg.SystemPrompt)
+	}
+	want := "repo conventions" + SystemPromptSectionDelimiter + "task guidance"
+	if cfg.AppendSystemPrompt != want {
+		t.Errorf("AppendSystemPrompt = %q, want %q", cfg.AppendSystemPrompt, want)
+	}
+}
@@ -0,0 +1,142 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/protocol"
+)
+
+// TranscriptRole identifies who produced a transcript entry.
+type TranscriptRole string
+
+const (
+	// TranscriptRoleUser is a prompt sent to the CLI with SendMessage.
+	TranscriptRoleUser TranscriptRole = "user"
+	// TranscriptRoleAssistant is assistant text or a tool call. Tool calls
+	// carry ToolUseID, ToolName and ToolInput and have no Text.
+	TranscriptRoleAssistant TranscriptRole = "assistant"
+	// TranscriptRoleTool is a tool result, whether the CLI ran the tool or
+	// the caller answered it with SendToolResult.
+	TranscriptRoleTool TranscriptRole = "tool"
+)
+
+// TranscriptEntry is one normalized turn-by-turn transcript record.
+type TranscriptEntry struct {
+	Timestamp time.Time
+	// ToolInput is the input of an assistant tool call.
+	ToolInput map[string]interface{}
+	Role      TranscriptRole
+	// Text is the prompt, the assistant text, or a tool result flattened to
+	// plain text.
+	Text      string
+	ToolUseID string
+	ToolName  string
+	// ParentToolUseID is set for entries produced inside a sub-agent.
+	ParentToolUseID string
+	TurnNumber      int
+	// IsError marks a tool result that reported an error.
+	IsError bool
+}
+
+// TranscriptObserver receives transcript entries as they happen.
+type TranscriptObserver func(entry TranscriptEntry)
+
+// WithTranscriptObserver registers fn to receive every user prompt,
+// assistant message and tool result as a TranscriptEntry, for sinks such as
+// databases or audit logs. It is in addition to the event stream and the
+// CLI recording.
+//
+// fn is called synchronously on the session's goroutines (the read loop,
+// and the caller of SendMessage or SendToolResult), one entry at a time and
+// in order. It must not block and must not call back into the Session.
+func WithTranscriptObserver(fn TranscriptObserver) SessionOption {
+	return func(c *SessionConfig) {
+		c.TranscriptObserver = fn
+	}
+}
+
+// observe delivers entry to the transcript observer, if any.
+func (s *Session) observe(entry TranscriptEntry) {
+	if s.config.TranscriptObserver == nil {
+		return
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	s.observerMu.Lock()
+	defer s.observerMu.Unlock()
+	s.config.TranscriptObserver(entry)
+}
+
+// observeAssistant records an assistant message: its text blocks joined as
+// one entry, then one entry per tool call. Thinking blocks are skipped.
+func (s *Session) observeAssistant(blocks protocol.ContentBlocks, parentToolUseID *string, turnNumber int) {
+	if s.config.TranscriptObserver == nil {
+		return
+	}
+	parent := derefString(parentToolUseID)
+	var text []string
+	for _, block := range blocks {
+		if b, ok := block.(protocol.TextBlock); ok && b.Text != "" {
+			text = append(text, b.Text)
+		}
+	}
+	if len(text) > 0 {
+		s.observe(TranscriptEntry{
+			Role:            TranscriptRoleAssistant,
+			Text:            strings.Join(text, "\n"),
+			ParentToolUseID: parent,
+			TurnNumber:      turnNumber,
+		})
+	}
+	for _, block := range blocks {
+		if b, ok := block.(protocol.ToolUseBlock); ok {
+			s.observe(TranscriptEntry{
+				Role:            TranscriptRoleAssistant,
+				ToolUseID:       b.ID,
+				ToolName:        b.Name,
+				ToolInput:       b.Input,
+				ParentToolUseID: parent,
+				TurnNumber:      turnNumber,
+			})
+		}
+	}
+}
+
+// transcriptText flattens tool result content into plain text. Content is
+// a string, a list of content blocks, or some other JSON value.
+func transcriptText(content interface{}) string {
+	switch c := content.(type) {
+	case nil:
+		return ""
+	case string:
+		return c
+	case []interface{}:
+		parts := make([]string, 0, len(c))
+		for _, item := range c {
+			if block, ok := item.(map[string]interface{}); ok {
+				if text, ok := block["text"].(string); ok {
+					parts = append(parts, text)
+					continue
+				}
+			}
+			parts = append(parts, transcriptText(item))
+		}
+		return strings.Join(parts, "\n")
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Sprint(content)
+	}
+	return string(data)
+}
+
+func derefString(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
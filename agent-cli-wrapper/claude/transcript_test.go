@@ -0,0 +1,86 @@
+package claude
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptObserver_RecordsTurn(t *testing.T) {
+	var entries []TranscriptEntry
+	s := newTestSession(t, WithTranscriptObserver(func(e TranscriptEntry) {
+		entries = append(entries, e)
+	}))
+	s.started = true
+	attachCapturingProcess(t, s)
+
+	turn, err := s.SendMessage(context.Background(), "list the files")
+	require.NoError(t, err)
+
+	s.handleLine([]byte(`{"type":"assistant","message":{"model":"claude-sonnet-4-6","role":"assistant","content":[{"type":"thinking","thinking":"plan","signature":"sig"},{"type":"text","text":"Listing."},{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}]},"session_id":"s1","uuid":"u1"}`))
+	s.handleLine([]byte(`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":[{"type":"text","text":"a.go"},{"type":"text","text":"b.go"}],"is_error":true}]},"parent_tool_use_id":"toolu_0","session_id":"s1","uuid":"u2"}`))
+
+	require.Len(t, entries, 4)
+	for _, e := range entries {
+		require.Equal(t, turn, e.TurnNumber)
+		require.False(t, e.Timestamp.IsZero())
+	}
+
+	require.Equal(t, TranscriptRoleUser, entries[0].Role)
+	require.Equal(t, "list the files", entries[0].Text)
+
+	require.Equal(t, TranscriptRoleAssistant, entries[1].Role)
+	require.Equal(t, "Listing.", entries[1].Text)
+
+	require.Equal(t, TranscriptRoleAssistant, entries[2].Role)
+	require.Equal(t, "toolu_1", entries[2].ToolUseID)
+	require.Equal(t, "Bash", entries[2].ToolName)
+	require.Equal(t, map[string]interface{}{"command": "ls"}, entries[2].ToolInput)
+	require.Empty(t, entries[2].Text)
+
+	require.Equal(t, TranscriptRoleTool, entries[3].Role)
+	require.Equal(t, "toolu_1", entries[3].ToolUseID)
+	require.Equal(t, "Bash", entries[3].ToolName)
+	require.Equal(t, "a.go\nb.go", entries[3].Text)
+	require.Equal(t, "toolu_0", entries[3].ParentToolUseID)
+	require.True(t, entries[3].IsError)
+}
+
+func TestTranscriptObserver_SendToolResult(t *testing.T) {
+	var entries []TranscriptEntry
+	s := newTestSession(t, WithTranscriptObserver(func(e TranscriptEntry) {
+		entries = append(entries, e)
+	}))
+	s.started = true
+	attachCapturingProcess(t, s)
+
+	_, err := s.SendMessage(context.Background(), "ask me")
+	require.NoError(t, err)
+	s.handleLine([]byte(`{"type":"assistant","message":{"model":"claude-sonnet-4-6","role":"assistant","content":[{"type":"tool_use","id":"toolu_q","name":"AskUserQuestion","input":{}}]},"session_id":"s1","uuid":"u1"}`))
+	_, err = s.SendToolResult(context.Background(), "toolu_q", "blue")
+	require.NoError(t, err)
+
+	last := entries[len(entries)-1]
+	require.Equal(t, TranscriptRoleTool, last.Role)
+	require.Equal(t, "toolu_q", last.ToolUseID)
+	require.Equal(t, "AskUserQuestion", last.ToolName)
+	require.Equal(t, "blue", last.Text)
+}
+
+func TestTranscriptText(t *testing.T) {
+	tests := []struct {
+		content interface{}
+		want    string
+	}{
+		{nil, ""},
+		{"plain", "plain"},
+		{[]interface{}{map[string]interface{}{"type": "text", "text": "one"}, "two"}, "one\ntwo"},
+		{map[string]interface{}{"code": float64(1)}, `{"code":1}`},
+	}
+	for _, tt := range tests {
+		if got := transcriptText(tt.content); got != tt.want {
+			t.Errorf("transcriptText(%v) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
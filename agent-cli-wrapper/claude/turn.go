@@ -141,6 +141,9 @@ type TurnUsage struct {
 	CacheReadTokens     int
 	CostUSD             float64
 	ContextWindow       int // total context window size for the model
+	// ReasoningTokens is the part of OutputTokens spent on reasoning, for
+	// providers that report it (codex). Claude leaves it at 0.
+	ReasoningTokens int
 }
 
 // Add accumulates other's counts into u. ContextWindow is not summed —
@@ -150,6 +153,7 @@ func (u *TurnUsage) Add(other TurnUsage) {
 	u.OutputTokens += other.OutputTokens
 	u.CacheCreationTokens += other.CacheCreationTokens
 	u.CacheReadTokens += other.CacheReadTokens
+	u.ReasoningTokens += other.ReasoningTokens
 	u.CostUSD += other.CostUSD
 }
 
@@ -33,10 +33,63 @@ type ApprovalRequest struct {
 }
 
 // ApprovalResponse contains the response to an approval request.
+// ForSession, with Approved, also approves matching commands for the rest
+// of the session.
 type ApprovalResponse struct {
 	UpdatedInput map[string]interface{}
 	Message      string
 	Approved     bool
+	ForSession   bool
+}
+
+// ApprovalDecision answers an ApprovalRequestEvent via RespondToApproval.
+type ApprovalDecision string
+
+const (
+	// ApprovalApprove runs the command once.
+	ApprovalApprove ApprovalDecision = "approve"
+
+	// ApprovalApproveForSession runs the command and stops asking about
+	// matching commands for the rest of the session.
+	ApprovalApproveForSession ApprovalDecision = "approve_for_session"
+
+	// ApprovalDeny refuses the command; the turn continues without it.
+	ApprovalDeny ApprovalDecision = "deny"
+)
+
+// wireDecision maps d to the decision string the app-server expects for the
+// given request method.
+func (d ApprovalDecision) wireDecision(method string) string {
+	legacy := method == RequestLegacyExecApproval
+	switch d {
+	case ApprovalApprove:
+		if legacy {
+			return "approved"
+		}
+		return "accept"
+	case ApprovalApproveForSession:
+		if legacy {
+			return "approved_for_session"
+		}
+		return "acceptForSession"
+	default:
+		if legacy {
+			return "denied"
+		}
+		return "decline"
+	}
+}
+
+// decisionFromResponse converts an ApprovalHandler's answer to a decision.
+func decisionFromResponse(resp *ApprovalResponse) ApprovalDecision {
+	switch {
+	case resp == nil || !resp.Approved:
+		return ApprovalDeny
+	case resp.ForSession:
+		return ApprovalApproveForSession
+	default:
+		return ApprovalApprove
+	}
 }
 
 // ApprovalHandler handles tool execution approval requests.
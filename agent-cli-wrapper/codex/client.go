@@ -14,6 +14,7 @@ import (
 // a high-level API for interacting with Codex.
 type Client struct {
 	pending     map[int64]chan *rpcResult
+	approvals   map[string]string // pending approval request ID -> method
 	process     *processManager
 	state       *clientStateManager
 	threads     map[string]*Thread
@@ -61,6 +62,7 @@ func NewClient(opts ...ClientOption) *Client {
 		readyBefore: make(map[string]struct{}),
 		idGen:       &idGenerator{},
 		pending:     make(map[int64]chan *rpcResult),
+		approvals:   make(map[string]string),
 		events:      make(chan Event, config.EventBufferSize),
 		accumulator: newStreamAccumulator(),
 		done:        make(chan struct{}),
@@ -201,10 +203,7 @@ func (c *Client) CreateThread(ctx context.Context, opts ...ThreadOption) (*Threa
 		return nil, err
 	}
 
-	cfg := defaultCodexThreadConfig()
-	for _, opt := range opts {
-		opt(&cfg)
-	}
+	cfg := c.threadConfig(opts)
 
 	params := threadStartParamsFromConfig(cfg)
 
@@ -221,16 +220,17 @@ func (c *Client) CreateThread(ctx context.Context, opts ...ThreadOption) (*Threa
 	return c.registerThreadResponse(threadResp, cfg), nil
 }
 
-// ResumeThread loads an existing conversation thread.
+// ResumeThread loads an existing conversation thread so follow-up turns
+// continue its prior context. threadID is the value Thread.ID returned in an
+// earlier session; persist it to reattach after a restart. If the app-server
+// does not know the thread, the error is a *ThreadNotFoundError (matching
+// ErrThreadNotFound) so callers can fall back to CreateThread.
 func (c *Client) ResumeThread(ctx context.Context, threadID string, opts ...ThreadOption) (*Thread, error) {
 	if err := c.ensureReadyForThreadRequest(); err != nil {
 		return nil, err
 	}
 
-	cfg := defaultCodexThreadConfig()
-	for _, opt := range opts {
-		opt(&cfg)
-	}
+	cfg := c.threadConfig(opts)
 
 	params := ThreadResumeParams{
 		ThreadStartParams: threadStartParamsFromConfig(cfg),
@@ -239,7 +239,7 @@ func (c *Client) ResumeThread(ctx context.Context, threadID string, opts ...Thre
 
 	resp, err := c.sendRequestAndWait(ctx, "thread/resume", params)
 	if err != nil {
-		return nil, err
+		return nil, resumeNotFoundError(threadID, err)
 	}
 
 	var threadResp ThreadResumeResponse
@@ -260,6 +260,18 @@ func (c *Client) ResumeThread(ctx context.Context, threadID string, opts ...Thre
 	return thread, nil
 }
 
+// threadConfig applies opts over the client's thread defaults.
+func (c *Client) threadConfig(opts []ThreadOption) ThreadConfig {
+	cfg := defaultCodexThreadConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Sandbox == nil && c.config.Sandbox != "" {
+		cfg.Sandbox = c.config.Sandbox
+	}
+	return cfg
+}
+
 func (c *Client) ensureReadyForThreadRequest() error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -290,6 +302,7 @@ func threadStartParamsFromConfig(cfg ThreadConfig) ThreadStartParams {
 func (c *Client) registerThreadResponse(threadResp ThreadStartResponse, cfg ThreadConfig) *Thread {
 	thread := newThread(c, threadResp.Thread.ID, cfg)
 	thread.setInfo(&threadResp.Thread)
+	thread.setServedModel(threadResp.Model, threadResp.ReasoningEffort)
 	// Seed the monotonic turn counter from the thread's history so a resumed
 	// thread keeps numbering where it left off. A freshly-started thread has
 	// no turns, so this is a no-op there.
@@ -474,7 +487,10 @@ func (c *Client) handleMessage(line []byte) {
 		return
 	}
 
-	if base.ID != nil {
+	if base.ID != nil && base.Method != "" {
+		// This is a request from the server
+		c.handleServerRequest(line, base.Method)
+	} else if base.ID != nil {
 		// This is a response
 		c.handleResponse(line, *base.ID)
 	} else if base.Method != "" {
@@ -510,6 +526,129 @@ func (c *Client) handleResponse(line []byte, id int64) {
 	}
 }
 
+// handleServerRequest answers a request the app-server sent to the client.
+// Exec approvals go to the configured ApprovalHandler or, without one, out
+// as an ApprovalRequestEvent. Any other request is refused so the server
+// does not wait on it forever.
+func (c *Client) handleServerRequest(line []byte, method string) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(line, &req); err != nil {
+		c.emitError("", "", &ProtocolError{Message: "failed to parse server request", Line: string(line), Cause: err}, "parse_server_request")
+		return
+	}
+
+	switch method {
+	case RequestCommandApproval, RequestLegacyExecApproval:
+		c.handleCommandApproval(req.ID, method, req.Params)
+	default:
+		if err := c.respond(req.ID, nil, &JSONRPCError{Code: -32601, Message: "unsupported request: " + method}); err != nil {
+			c.emitError("", "", err, "server_request")
+		}
+	}
+}
+
+func (c *Client) handleCommandApproval(id json.RawMessage, method string, params json.RawMessage) {
+	var p CommandApprovalParams
+	if err := unmarshalRaw(params, &p); err != nil {
+		c.emitError("", "", &ProtocolError{Message: "failed to parse approval request", Cause: err}, "parse_approval")
+		_ = c.respondApproval(id, method, ApprovalDeny)
+		return
+	}
+	ev := ApprovalRequestEvent{
+		RequestID: string(id),
+		ThreadID:  p.ThreadID,
+		TurnID:    p.TurnID,
+		CallID:    p.ItemID,
+		CWD:       p.CWD,
+		Reason:    p.Reason,
+		Command:   approvalCommand(p.Command),
+	}
+	if ev.ThreadID == "" {
+		ev.ThreadID = p.ConversationID
+	}
+	if ev.CallID == "" {
+		ev.CallID = p.CallID
+	}
+
+	if h := c.config.ApprovalHandler; h != nil {
+		req := &ApprovalRequest{
+			ThreadID: ev.ThreadID,
+			TurnID:   ev.TurnID,
+			ToolName: "Bash",
+			Input: map[string]interface{}{
+				"command": ev.CommandText(),
+				"cwd":     ev.CWD,
+				"reason":  ev.Reason,
+			},
+		}
+		// The handler may block on a user, so it must not hold up readLoop.
+		go func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				select {
+				case <-c.done:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+			decision := ApprovalDeny
+			if resp, err := h.HandleApproval(ctx, req); err == nil {
+				decision = decisionFromResponse(resp)
+			}
+			if err := c.respondApproval(id, method, decision); err != nil && ctx.Err() == nil {
+				c.emitError(ev.ThreadID, ev.TurnID, err, "approval_response")
+			}
+		}()
+		return
+	}
+
+	c.mu.Lock()
+	c.approvals[ev.RequestID] = method
+	c.mu.Unlock()
+	c.emit(ev)
+}
+
+// approvalCommand decodes the command of an approval request, which is a
+// string in the v2 protocol and an argv array in the legacy one.
+func approvalCommand(raw json.RawMessage) []string {
+	var argv []string
+	if err := json.Unmarshal(raw, &argv); err == nil {
+		return argv
+	}
+	var cmd string
+	if err := json.Unmarshal(raw, &cmd); err == nil && cmd != "" {
+		return []string{cmd}
+	}
+	return nil
+}
+
+// RespondToApproval answers an ApprovalRequestEvent. It returns
+// ErrApprovalNotFound if requestID is not pending, e.g. because it was
+// already answered.
+func (c *Client) RespondToApproval(requestID string, decision ApprovalDecision) error {
+	c.mu.Lock()
+	method, ok := c.approvals[requestID]
+	delete(c.approvals, requestID)
+	c.mu.Unlock()
+	if !ok {
+		return ErrApprovalNotFound
+	}
+	return c.respondApproval(json.RawMessage(requestID), method, decision)
+}
+
+func (c *Client) respondApproval(id json.RawMessage, method string, decision ApprovalDecision) error {
+	return c.respond(id, map[string]string{"decision": decision.wireDecision(method)}, nil)
+}
+
+// respond writes the answer to a server request.
+func (c *Client) respond(id json.RawMessage, result interface{}, rpcErr *JSONRPCError) error {
+	return c.process.WriteJSON(serverResponse{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+}
+
 // handleNotification processes a JSON-RPC notification.
 func (c *Client) handleNotification(line []byte, method string) {
 	var notif JSONRPCNotification
@@ -557,6 +696,9 @@ func (c *Client) handleNotification(line []byte, method string) {
 
 	case NotifyCodexEventReasoningDelta:
 		c.handleReasoningDelta(notif.Params)
+
+	case NotifyCodexEventWebSearchEnd:
+		c.handleWebSearchEnd(notif.Params)
 	}
 }
 
@@ -617,11 +759,10 @@ func (c *Client) handleTurnCompleted(params json.RawMessage) {
 	errMsg := extractErrorMessage(notif.Turn.Error)
 	turnErr := classifyTurnError(notif.ThreadID, notif.Turn.ID, errMsg)
 	fullText := ""
-	var durationMs int64
-	var turnIndex int
+	var completion turnCompletion
 	var usage TurnUsage
 	if ok {
-		durationMs, turnIndex = thread.handleTurnCompleted(notif.Turn.ID, success, turnErr)
+		completion = thread.handleTurnCompleted(notif.Turn.ID, success, notif.Turn.Status == "interrupted", turnErr)
 		fullText = thread.GetFullText()
 		// Get token usage from the last token_count event
 		if lastUsage := thread.getAndClearLastUsage(); lastUsage != nil {
@@ -635,14 +776,30 @@ func (c *Client) handleTurnCompleted(params json.RawMessage) {
 		}
 	}
 
+	// The server kills running commands on interrupt without always sending
+	// their end events; report them so tool displays don't stay "running".
+	for _, callID := range completion.abortedExecs {
+		c.emit(CommandEndEvent{
+			ThreadID: notif.ThreadID,
+			TurnID:   notif.Turn.ID,
+			CallID:   callID,
+			ExitCode: -1,
+			Stderr:   "command aborted: turn cancelled",
+			Aborted:  true,
+		})
+	}
+
 	c.emit(TurnCompletedEvent{
 		ThreadID:   notif.ThreadID,
 		TurnID:     notif.Turn.ID,
 		Success:    success,
+		Cancelled:  completion.cancelled,
 		Error:      turnErr,
 		FullText:   fullText,
-		DurationMs: durationMs,
-		TurnIndex:  turnIndex,
+		Model:      completion.model,
+		Effort:     completion.effort,
+		DurationMs: completion.durationMs,
+		TurnIndex:  completion.turnIndex,
 		Usage:      usage,
 	})
 }
@@ -917,6 +1074,13 @@ func (c *Client) handleExecCommandBegin(params json.RawMessage) {
 		parsedCmd = msg.Command[2]
 	}
 
+	c.mu.RLock()
+	thread, ok := c.threads[notif.ConversationID]
+	c.mu.RUnlock()
+	if ok {
+		thread.execStarted(msg.CallID, msg.TurnID)
+	}
+
 	c.emit(CommandStartEvent{
 		ThreadID:  notif.ConversationID,
 		TurnID:    msg.TurnID,
@@ -938,6 +1102,13 @@ func (c *Client) handleExecCommandEnd(params json.RawMessage) {
 		return
 	}
 
+	c.mu.RLock()
+	thread, ok := c.threads[notif.ConversationID]
+	c.mu.RUnlock()
+	if ok {
+		thread.execEnded(msg.CallID)
+	}
+
 	// Convert duration to milliseconds
 	durationMs := msg.Duration.Secs*1000 + msg.Duration.Nanos/1000000
 
@@ -995,6 +1166,25 @@ func (c *Client) handleReasoningDelta(params json.RawMessage) {
 	})
 }
 
+func (c *Client) handleWebSearchEnd(params json.RawMessage) {
+	var notif CodexEventNotification
+	if err := json.Unmarshal(params, &notif); err != nil {
+		return
+	}
+
+	var msg WebSearchEndMsg
+	if err := json.Unmarshal(notif.Msg, &msg); err != nil {
+		return
+	}
+
+	c.emit(WebSearchEvent{
+		ThreadID: notif.ConversationID,
+		CallID:   msg.CallID,
+		Query:    msg.Query,
+		Results:  msg.Results,
+	})
+}
+
 // unmarshalRaw is a helper to unmarshal json.RawMessage.
 func unmarshalRaw(raw json.RawMessage, v interface{}) error {
 	return json.Unmarshal(raw, v)
@@ -38,6 +38,11 @@ type ClientConfig struct {
 	// If empty, no session logging is performed.
 	SessionLogPath string
 
+	// Sandbox is the sandbox level (SandboxReadOnly, SandboxWorkspaceWrite
+	// or SandboxDangerFullAccess) for threads that don't set their own with
+	// WithSandbox. Empty leaves codex's configured default.
+	Sandbox string
+
 	// EventBufferSize is the event channel buffer size (default: 100).
 	EventBufferSize int
 }
@@ -103,6 +108,32 @@ func WithSessionLogPath(path string) ClientOption {
 	}
 }
 
+// Sandbox levels codex accepts for the thread sandbox setting.
+const (
+	SandboxReadOnly         = "read-only"
+	SandboxWorkspaceWrite   = "workspace-write"
+	SandboxDangerFullAccess = "danger-full-access"
+)
+
+// ValidateSandboxLevel returns an error unless level is one of the sandbox
+// levels codex accepts.
+func ValidateSandboxLevel(level string) error {
+	switch level {
+	case SandboxReadOnly, SandboxWorkspaceWrite, SandboxDangerFullAccess:
+		return nil
+	}
+	return fmt.Errorf("unknown codex sandbox level %q (want %s, %s or %s)",
+		level, SandboxReadOnly, SandboxWorkspaceWrite, SandboxDangerFullAccess)
+}
+
+// WithClientSandbox sets the sandbox level for every thread the client
+// starts or resumes without its own WithSandbox.
+func WithClientSandbox(level string) ClientOption {
+	return func(c *ClientConfig) {
+		c.Sandbox = level
+	}
+}
+
 // WithEnv sets additional environment variables for the codex app-server
 // subprocess. Existing entries are preserved.
 func WithEnv(env map[string]string) ClientOption {
@@ -203,6 +203,33 @@ func TestThreadOption_WithSandbox(t *testing.T) {
 	})
 }
 
+func TestWithClientSandboxDefaultsThreads(t *testing.T) {
+	c := NewClient(WithClientSandbox(SandboxReadOnly))
+
+	if got := c.threadConfig(nil).Sandbox; got != SandboxReadOnly {
+		t.Errorf("thread without WithSandbox: Sandbox = %v, want %q", got, SandboxReadOnly)
+	}
+	if got := c.threadConfig([]ThreadOption{WithSandbox(SandboxDangerFullAccess)}).Sandbox; got != SandboxDangerFullAccess {
+		t.Errorf("thread with WithSandbox: Sandbox = %v, want %q", got, SandboxDangerFullAccess)
+	}
+	if got := NewClient().threadConfig(nil).Sandbox; got != nil {
+		t.Errorf("client without a sandbox: Sandbox = %v, want nil", got)
+	}
+}
+
+func TestValidateSandboxLevel(t *testing.T) {
+	for _, level := range []string{SandboxReadOnly, SandboxWorkspaceWrite, SandboxDangerFullAccess} {
+		if err := ValidateSandboxLevel(level); err != nil {
+			t.Errorf("ValidateSandboxLevel(%q) = %v", level, err)
+		}
+	}
+	for _, level := range []string{"", "full", "Read-Only"} {
+		if err := ValidateSandboxLevel(level); err == nil {
+			t.Errorf("ValidateSandboxLevel(%q) = nil, want an error", level)
+		}
+	}
+}
+
 func TestThreadOption_WithThreadConfig(t *testing.T) {
 	cfg := defaultCodexThreadConfig()
 	extraConfig := map[string]interface{}{
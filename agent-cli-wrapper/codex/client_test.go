@@ -1,8 +1,12 @@
 package codex
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -728,3 +732,312 @@ func TestClient_TokenUsageClearedAfterTurn(t *testing.T) {
 		t.Error("TurnCompletedEvent not received")
 	}
 }
+
+// Test that a cancelled turn reports partial text, Cancelled, and aborts
+// commands that were still running.
+func TestClient_TurnCompletedCancelled(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          string
+		cancelRequested bool
+	}{
+		{name: "CancelTurn", status: "failed", cancelRequested: true},
+		{name: "server interrupted", status: "interrupted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(WithEventBufferSize(10))
+			thread := newThread(client, "thread-1", ThreadConfig{})
+			client.threads["thread-1"] = thread
+
+			thread.handleTurnStarted("turn-1")
+			thread.handleTextDelta("turn-1", "item-1", "partial answer")
+			thread.cancelRequested = tt.cancelRequested
+
+			waiter := make(chan *TurnResult, 1)
+			thread.turnWaiters["turn-1"] = []chan *TurnResult{waiter}
+
+			begin, err := json.Marshal(ExecCommandBeginMsg{CallID: "call-running", TurnID: "turn-1"})
+			require.NoError(t, err)
+			beginNotif, err := json.Marshal(CodexEventNotification{ConversationID: "thread-1", Msg: begin})
+			require.NoError(t, err)
+			client.handleExecCommandBegin(beginNotif)
+			<-client.events // drain CommandStartEvent
+
+			notifJSON, err := json.Marshal(TurnCompletedNotification{
+				ThreadID: "thread-1",
+				Turn:     Turn{ID: "turn-1", Status: tt.status},
+			})
+			require.NoError(t, err)
+			client.handleTurnCompleted(notifJSON)
+
+			end, ok := (<-client.events).(CommandEndEvent)
+			require.True(t, ok, "expected aborted CommandEndEvent first")
+			require.Equal(t, "call-running", end.CallID)
+			require.True(t, end.Aborted)
+			require.True(t, end.StreamToolIsError())
+
+			done, ok := (<-client.events).(TurnCompletedEvent)
+			require.True(t, ok, "expected TurnCompletedEvent")
+			require.True(t, done.Cancelled)
+			require.False(t, done.Success)
+			require.Equal(t, "partial answer", done.FullText)
+
+			result := <-waiter
+			require.True(t, result.Cancelled)
+			require.Equal(t, "partial answer", result.FullText)
+		})
+	}
+}
+
+// Test that the turn-complete event reports the model and effort the server
+// echoed for the thread, falling back to the requested model.
+func TestClient_TurnCompletedReportsServedModel(t *testing.T) {
+	tests := []struct {
+		name       string
+		requested  string
+		wantModel  string
+		wantEffort string
+		resp       ThreadStartResponse
+	}{
+		{
+			name:       "echoed",
+			requested:  "gpt-5.4",
+			resp:       ThreadStartResponse{Model: "gpt-5.4-mini", ReasoningEffort: "medium"},
+			wantModel:  "gpt-5.4-mini",
+			wantEffort: "medium",
+		},
+		{name: "not echoed", requested: "gpt-5.4", wantModel: "gpt-5.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(WithEventBufferSize(10))
+			tt.resp.Thread.ID = "thread-1"
+			thread := client.registerThreadResponse(tt.resp, ThreadConfig{Model: tt.requested})
+			<-client.events // drain ThreadStartedEvent
+
+			waiter := make(chan *TurnResult, 1)
+			thread.turnWaiters["turn-1"] = []chan *TurnResult{waiter}
+			thread.handleTurnStarted("turn-1")
+			notifJSON, err := json.Marshal(TurnCompletedNotification{
+				ThreadID: "thread-1",
+				Turn:     Turn{ID: "turn-1", Status: "completed"},
+			})
+			require.NoError(t, err)
+			client.handleTurnCompleted(notifJSON)
+
+			done, ok := (<-client.events).(TurnCompletedEvent)
+			require.True(t, ok, "expected TurnCompletedEvent")
+			require.Equal(t, tt.wantModel, done.Model)
+			require.Equal(t, tt.wantEffort, done.Effort)
+
+			result := <-waiter
+			require.Equal(t, tt.wantModel, result.Model)
+			require.Equal(t, tt.wantEffort, result.Effort)
+		})
+	}
+}
+
+// Test that a turn override changes the reported model only when the
+// turn/start response reports it.
+func TestThread_SendInputKeepsServedModelUntilReported(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     string
+		wantModel  string
+		wantEffort string
+	}{
+		{
+			name:       "not reported",
+			result:     `{"turn":{"id":"turn-1","status":"inProgress","items":[]}}`,
+			wantModel:  "gpt-5.4",
+			wantEffort: "medium",
+		},
+		{
+			name:       "reported",
+			result:     `{"model":"gpt-5.4-mini","reasoningEffort":"low","turn":{"id":"turn-1","status":"inProgress","items":[]}}`,
+			wantModel:  "gpt-5.4-mini",
+			wantEffort: "low",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, out := newApprovalTestClient()
+			thread := client.registerThreadResponse(ThreadStartResponse{
+				Model: "gpt-5.4", ReasoningEffort: "medium", Thread: ThreadInfo{ID: "thread-1"},
+			}, ThreadConfig{})
+			thread.setReady()
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := thread.SendMessage(context.Background(), "hello", WithTurnModel("gpt-5.4-mini"), WithEffort("high"))
+				done <- err
+			}()
+			respondToTurnStart(t, client, out, tt.result)
+			require.NoError(t, <-done)
+
+			completion := thread.handleTurnCompleted("turn-1", true, false, nil)
+			require.Equal(t, tt.wantModel, completion.model)
+			require.Equal(t, tt.wantEffort, completion.effort)
+		})
+	}
+}
+
+// Test that commands still running at a normal turn end are not reported
+// as aborted and do not leak into the next turn.
+func TestClient_TurnCompletedNotCancelled(t *testing.T) {
+	client := NewClient(WithEventBufferSize(10))
+	thread := newThread(client, "thread-1", ThreadConfig{})
+	client.threads["thread-1"] = thread
+	thread.execStarted("call-1", "turn-1")
+
+	notifJSON, err := json.Marshal(TurnCompletedNotification{
+		ThreadID: "thread-1",
+		Turn:     Turn{ID: "turn-1", Status: "completed"},
+	})
+	require.NoError(t, err)
+	client.handleTurnCompleted(notifJSON)
+
+	done, ok := (<-client.events).(TurnCompletedEvent)
+	require.True(t, ok, "expected TurnCompletedEvent, no aborted commands")
+	require.False(t, done.Cancelled)
+	require.Empty(t, thread.runningExecs)
+}
+
+func TestClient_HandleWebSearchEnd(t *testing.T) {
+	client := NewClient(WithEventBufferSize(10))
+
+	params := json.RawMessage(`{"id":"0","conversationId":"thread-1","msg":{"type":"web_search_end","call_id":"ws_1","query":"codex changelog"}}`)
+	client.handleNotification([]byte(`{"jsonrpc":"2.0","method":"`+NotifyCodexEventWebSearchEnd+`","params":`+string(params)+`}`), NotifyCodexEventWebSearchEnd)
+
+	select {
+	case event := <-client.events:
+		e, ok := event.(WebSearchEvent)
+		require.True(t, ok, "expected WebSearchEvent, got %T", event)
+		require.Equal(t, "thread-1", e.ThreadID)
+		require.Equal(t, "ws_1", e.CallID)
+		require.Equal(t, "codex changelog", e.Query)
+		require.Empty(t, e.Results)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("WebSearchEvent not received")
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for a writer and reader on different
+// goroutines.
+type syncBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newApprovalTestClient returns a client whose responses to the server are
+// captured in the returned buffer instead of going to a subprocess.
+func newApprovalTestClient(opts ...ClientOption) (*Client, *syncBuffer) {
+	client := NewClient(append([]ClientOption{WithEventBufferSize(10)}, opts...)...)
+	out := &syncBuffer{}
+	client.process = &processManager{encoder: json.NewEncoder(out)}
+	return client, out
+}
+
+// respondToTurnStart waits for SendInput's turn/start request in out and
+// answers it with result.
+func respondToTurnStart(t *testing.T, client *Client, out *syncBuffer, result string) {
+	t.Helper()
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), `"turn/start"`) },
+		time.Second, 5*time.Millisecond, "turn/start not sent")
+	var req struct {
+		ID int64 `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &req))
+	client.handleMessage([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, req.ID, result)))
+}
+
+func TestThread_SendInputStartsTurn(t *testing.T) {
+	client, out := newApprovalTestClient()
+	thread := client.registerThreadResponse(ThreadStartResponse{Thread: ThreadInfo{ID: "thread-1"}}, ThreadConfig{})
+	thread.setReady()
+
+	type sent struct {
+		err    error
+		turnID string
+	}
+	done := make(chan sent, 1)
+	go func() {
+		turnID, err := thread.SendMessage(context.Background(), "hello")
+		done <- sent{turnID: turnID, err: err}
+	}()
+	respondToTurnStart(t, client, out, `{"turn":{"id":"turn-1","status":"inProgress","items":[]}}`)
+
+	select {
+	case got := <-done:
+		require.NoError(t, got.err)
+		require.Equal(t, "turn-1", got.turnID)
+	case <-time.After(time.Second):
+		t.Fatal("SendInput did not return")
+	}
+}
+
+func TestClient_CommandApprovalEventAndResponse(t *testing.T) {
+	client, out := newApprovalTestClient()
+
+	client.handleMessage([]byte(`{"jsonrpc":"2.0","id":7,"method":"` + RequestCommandApproval + `","params":{"threadId":"thread-1","turnId":"turn-1","itemId":"call_1","command":"rm -rf build","cwd":"/repo","reason":"needs write access"}}`))
+
+	var ev ApprovalRequestEvent
+	select {
+	case event := <-client.events:
+		var ok bool
+		ev, ok = event.(ApprovalRequestEvent)
+		require.True(t, ok, "expected ApprovalRequestEvent, got %T", event)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("ApprovalRequestEvent not received")
+	}
+	require.Equal(t, "7", ev.RequestID)
+	require.Equal(t, "thread-1", ev.ThreadID)
+	require.Equal(t, "call_1", ev.CallID)
+	require.Equal(t, "rm -rf build", ev.CommandText())
+	require.Equal(t, "needs write access", ev.Reason)
+
+	require.NoError(t, client.RespondToApproval(ev.RequestID, ApprovalApproveForSession))
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":7,"result":{"decision":"acceptForSession"}}`, out.String())
+	require.ErrorIs(t, client.RespondToApproval(ev.RequestID, ApprovalDeny), ErrApprovalNotFound)
+}
+
+func TestClient_LegacyExecApprovalUsesHandler(t *testing.T) {
+	var got *ApprovalRequest
+	handler := ApprovalHandlerFunc(func(ctx context.Context, req *ApprovalRequest) (*ApprovalResponse, error) {
+		got = req
+		return &ApprovalResponse{Approved: false}, nil
+	})
+	client, out := newApprovalTestClient(WithApprovalHandler(handler))
+
+	client.handleMessage([]byte(`{"jsonrpc":"2.0","id":3,"method":"` + RequestLegacyExecApproval + `","params":{"conversationId":"thread-1","callId":"call_2","command":["git","push"],"cwd":"/repo"}}`))
+
+	require.Eventually(t, func() bool { return out.String() != "" }, time.Second, 10*time.Millisecond)
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":3,"result":{"decision":"denied"}}`, out.String())
+	require.Equal(t, "thread-1", got.ThreadID)
+	require.Equal(t, "git push", got.Input["command"])
+	require.Empty(t, client.events, "a handled approval should not be emitted")
+}
+
+func TestClient_UnsupportedServerRequestIsRefused(t *testing.T) {
+	client, out := newApprovalTestClient()
+
+	client.handleMessage([]byte(`{"jsonrpc":"2.0","id":9,"method":"item/tool/call","params":{}}`))
+
+	require.JSONEq(t, `{"jsonrpc":"2.0","id":9,"error":{"code":-32601,"message":"unsupported request: item/tool/call"}}`, out.String())
+}
@@ -68,6 +68,25 @@
 //	    }
 //	}
 //
+// # Approvals
+//
+// Under an approval policy other than "never", codex asks before running
+// gated commands. With WithApprovalHandler the handler answers; otherwise
+// the request arrives as an ApprovalRequestEvent and the turn waits for
+// RespondToApproval:
+//
+//	case codex.ApprovalRequestEvent:
+//	    client.RespondToApproval(e.RequestID, codex.ApprovalApprove)
+//
+// # Replaying Session Logs
+//
+// ParseProtocolLog turns a protocol log written with WithSessionLogPath into
+// normalized ReplayLines (merged text, one line per command execution, turn
+// summaries) plus the session's first prompt and final ReplayStatus:
+//
+//	f, _ := os.Open("session.jsonl")
+//	replay, err := codex.ParseProtocolLog(f)
+//
 // # Configuration Options
 //
 // Client-level options:
@@ -77,6 +96,7 @@
 //   - WithEventBufferSize: Event channel buffer size
 //   - WithStderrHandler: Handler for app-server stderr
 //   - WithApprovalHandler: Handler for tool approval requests
+//   - WithClientSandbox: Default sandbox level for threads without WithSandbox
 //
 // Thread-level options:
 //   - WithModel: Model to use (e.g., "gpt-4o")
@@ -3,6 +3,7 @@ package codex
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	transientmeta "github.com/bazelment/yoloswe/agent-cli-wrapper/transient"
 )
@@ -38,6 +39,10 @@ var (
 
 	// ErrInvalidState is returned for invalid state transitions.
 	ErrInvalidState = errors.New("invalid state transition")
+
+	// ErrApprovalNotFound is returned when answering an approval request
+	// that is not pending.
+	ErrApprovalNotFound = errors.New("approval request not found")
 )
 
 // RPCError represents a JSON-RPC error from the app-server.
@@ -50,6 +55,43 @@ func (e *RPCError) Error() string {
 	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
 }
 
+// ThreadNotFoundError is returned by ResumeThread when the app-server has no
+// record of the requested thread (e.g. its rollout was deleted or it belongs
+// to another CODEX_HOME). It matches ErrThreadNotFound via errors.Is.
+type ThreadNotFoundError struct {
+	Cause    error
+	ThreadID string
+}
+
+func (e *ThreadNotFoundError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("thread %s not found: %v", e.ThreadID, e.Cause)
+	}
+	return fmt.Sprintf("thread %s not found", e.ThreadID)
+}
+
+func (e *ThreadNotFoundError) Is(target error) bool {
+	return target == ErrThreadNotFound
+}
+
+func (e *ThreadNotFoundError) Unwrap() error {
+	return e.Cause
+}
+
+// resumeNotFoundError maps a thread/resume RPC failure that reports an
+// unknown thread to a ThreadNotFoundError; other errors pass through.
+func resumeNotFoundError(threadID string, err error) error {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+	msg := strings.ToLower(rpcErr.Message)
+	if strings.Contains(msg, "not found") || strings.Contains(msg, "no rollout found") {
+		return &ThreadNotFoundError{ThreadID: threadID, Cause: err}
+	}
+	return err
+}
+
 // ProcessError represents an error with the app-server subprocess.
 type ProcessError struct {
 	Cause    error
@@ -273,3 +273,31 @@ func TestSentinelErrors_Messages(t *testing.T) {
 		}
 	}
 }
+
+func TestResumeNotFoundError(t *testing.T) {
+	notFound := &RPCError{Code: -32600, Message: "no rollout found for thread id thr_123"}
+	err := resumeNotFoundError("thr_123", notFound)
+
+	var tnf *ThreadNotFoundError
+	if !errors.As(err, &tnf) {
+		t.Fatalf("expected *ThreadNotFoundError, got %T", err)
+	}
+	if tnf.ThreadID != "thr_123" {
+		t.Errorf("ThreadID = %q, want thr_123", tnf.ThreadID)
+	}
+	if !errors.Is(err, ErrThreadNotFound) {
+		t.Error("expected errors.Is(err, ErrThreadNotFound)")
+	}
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr != notFound {
+		t.Error("expected the RPC error to stay reachable via errors.As")
+	}
+
+	other := &RPCError{Code: -32603, Message: "internal error"}
+	if got := resumeNotFoundError("thr_123", other); got != other {
+		t.Errorf("unrelated RPC error should pass through, got %v", got)
+	}
+	if got := resumeNotFoundError("thr_123", ErrTimeout); got != ErrTimeout {
+		t.Errorf("non-RPC error should pass through, got %v", got)
+	}
+}
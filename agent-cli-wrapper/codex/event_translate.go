@@ -19,6 +19,7 @@ const (
 	MappedEventTurnCompleted
 	MappedEventError
 	MappedEventTokenUsage
+	MappedEventWebSearch
 )
 
 // MappedEvent is a normalized Codex event.
@@ -35,10 +36,13 @@ type MappedEvent struct { //nolint:govet // fieldalignment: keep semantic groupi
 	Stdout       string
 	Stderr       string
 	ErrorContext string
-	Usage        TurnUsage
-	ExitCode     int
-	DurationMs   int64
-	Success      bool
+	Query        string
+	// SearchResults holds the hits of a MappedEventWebSearch, when reported.
+	SearchResults []SearchResult
+	Usage         TurnUsage
+	ExitCode      int
+	DurationMs    int64
+	Success       bool
 	// UsageIsCumulative is set on MappedEventTokenUsage when the source
 	// notification only carried TotalTokenUsage (cumulative across the
 	// thread), not the per-turn LastTokenUsage. Consumers that render
@@ -120,6 +124,23 @@ func ParseMappedNotification(method string, params json.RawMessage) (MappedEvent
 			Success:    msg.ExitCode == 0,
 		}, true
 
+	case NotifyCodexEventWebSearchEnd:
+		var notif CodexEventNotification
+		if err := json.Unmarshal(params, &notif); err != nil {
+			return MappedEvent{}, false
+		}
+		var msg WebSearchEndMsg
+		if err := json.Unmarshal(notif.Msg, &msg); err != nil {
+			return MappedEvent{}, false
+		}
+		return MappedEvent{
+			Kind:          MappedEventWebSearch,
+			ThreadID:      notif.ConversationID,
+			CallID:        msg.CallID,
+			Query:         msg.Query,
+			SearchResults: msg.Results,
+		}, true
+
 	case NotifyTurnCompleted:
 		var notif TurnCompletedNotification
 		if err := json.Unmarshal(params, &notif); err != nil {
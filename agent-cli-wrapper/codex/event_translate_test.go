@@ -139,6 +139,23 @@ func mustJSON(t *testing.T, v any) json.RawMessage {
 	return data
 }
 
+func TestParseMappedNotification_WebSearchEnd(t *testing.T) {
+	params := json.RawMessage(`{"id":"0","conversationId":"t1","msg":{"type":"web_search_end","call_id":"ws_1","query":"golang generics","results":[{"title":"Tutorial","url":"https://go.dev/doc/tutorial/generics"}]}}`)
+	ev, ok := ParseMappedNotification(NotifyCodexEventWebSearchEnd, params)
+	if !ok {
+		t.Fatal("expected mapped event")
+	}
+	if ev.Kind != MappedEventWebSearch {
+		t.Fatalf("Kind = %v, want web search", ev.Kind)
+	}
+	if ev.ThreadID != "t1" || ev.CallID != "ws_1" || ev.Query != "golang generics" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+	if len(ev.SearchResults) != 1 || ev.SearchResults[0].URL != "https://go.dev/doc/tutorial/generics" {
+		t.Fatalf("SearchResults = %+v", ev.SearchResults)
+	}
+}
+
 func TestTurnNumberFromID(t *testing.T) {
 	tests := []struct {
 		name   string
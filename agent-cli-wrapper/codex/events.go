@@ -55,6 +55,12 @@ const (
 
 	// EventTypeReasoningDelta fires for streaming reasoning/thinking text.
 	EventTypeReasoningDelta
+
+	// EventTypeWebSearch fires when a web search completes.
+	EventTypeWebSearch
+
+	// EventTypeApprovalRequest fires when codex asks to run a gated command.
+	EventTypeApprovalRequest
 )
 
 // Event is the interface for all events.
@@ -110,7 +116,12 @@ type TurnCompletedEvent struct {
 	ThreadID string
 	TurnID   string
 	FullText string
-	Usage    TurnUsage
+	// Model and Effort are the model and reasoning effort that served the
+	// turn, as echoed by the server, else the model the thread was started
+	// with. Empty Effort means the model default.
+	Model  string
+	Effort string
+	Usage  TurnUsage
 	// TurnIndex is the 1-based monotonic turn number for the thread,
 	// maintained by the client. It is the authoritative display turn
 	// number — codex turn IDs are opaque UUIDs from which no number can
@@ -120,6 +131,9 @@ type TurnCompletedEvent struct {
 	TurnIndex  int
 	DurationMs int64
 	Success    bool
+	// Cancelled is true when the turn was stopped by Thread.CancelTurn or an
+	// interrupt; FullText then holds the partial response.
+	Cancelled bool
 }
 
 // Type returns the event type.
@@ -139,6 +153,10 @@ func (e TurnCompletedEvent) StreamDuration() int64 { return e.DurationMs }
 func (e TurnCompletedEvent) StreamCost() float64   { return 0 }
 func (e TurnCompletedEvent) ScopeID() string       { return e.ThreadID }
 
+func (e TurnCompletedEvent) StreamInputTokens() int64     { return e.Usage.InputTokens }
+func (e TurnCompletedEvent) StreamOutputTokens() int64    { return e.Usage.OutputTokens }
+func (e TurnCompletedEvent) StreamReasoningTokens() int64 { return e.Usage.ReasoningOutputTokens }
+
 // TextDeltaEvent contains streaming text chunks.
 type TextDeltaEvent struct {
 	ThreadID string
@@ -281,6 +299,9 @@ type CommandEndEvent struct {
 	Stderr     string
 	ExitCode   int
 	DurationMs int64
+	// Aborted is true for a synthetic end reported when the command was
+	// still running as its turn was cancelled.
+	Aborted bool
 }
 
 // Type returns the event type.
@@ -298,7 +319,7 @@ func (e CommandEndEvent) StreamToolResult() interface{} {
 		"duration_ms": e.DurationMs,
 	}
 }
-func (e CommandEndEvent) StreamToolIsError() bool { return e.ExitCode != 0 }
+func (e CommandEndEvent) StreamToolIsError() bool { return e.ExitCode != 0 || e.Aborted }
 func (e CommandEndEvent) ScopeID() string         { return e.ThreadID }
 
 // ReasoningDeltaEvent fires for streaming reasoning/thinking text.
@@ -316,6 +337,39 @@ func (e ReasoningDeltaEvent) StreamEventKind() agentstream.EventKind { return ag
 func (e ReasoningDeltaEvent) StreamDelta() string                    { return e.Delta }
 func (e ReasoningDeltaEvent) ScopeID() string                        { return e.ThreadID }
 
+// WebSearchEvent fires when a web search performed by the model completes.
+type WebSearchEvent struct {
+	ThreadID string
+	CallID   string
+	Query    string
+	Results  []SearchResult
+}
+
+// Type returns the event type.
+func (e WebSearchEvent) Type() EventType { return EventTypeWebSearch }
+
+// ApprovalRequestEvent fires when codex asks permission to run a command
+// and the client has no ApprovalHandler. The turn blocks until the request
+// is answered with Client.RespondToApproval.
+type ApprovalRequestEvent struct {
+	RequestID string
+	ThreadID  string
+	TurnID    string
+	CallID    string
+	CWD       string
+	Reason    string
+	Command   []string
+}
+
+// Type returns the event type.
+func (e ApprovalRequestEvent) Type() EventType { return EventTypeApprovalRequest }
+
+// ScopeID returns the thread the request belongs to.
+func (e ApprovalRequestEvent) ScopeID() string { return e.ThreadID }
+
+// CommandText returns the command as a single line.
+func (e ApprovalRequestEvent) CommandText() string { return commandText("", e.Command) }
+
 // commandText returns the best available human-readable command text.
 func commandText(parsed string, command []string) string {
 	cmd := strings.TrimSpace(parsed)
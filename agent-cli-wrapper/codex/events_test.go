@@ -1,9 +1,12 @@
 package codex
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/agentstream"
 )
 
 func TestEventType_Values(t *testing.T) {
@@ -264,6 +267,34 @@ func TestTurnUsage_Fields(t *testing.T) {
 	}
 }
 
+func TestTokenUsage_ReasoningTokens(t *testing.T) {
+	var withReasoning TokenUsage
+	if err := json.Unmarshal([]byte(`{"input_tokens":100,"output_tokens":75,"reasoning_output_tokens":25}`), &withReasoning); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if withReasoning.ReasoningOutputTokens != 25 {
+		t.Errorf("ReasoningOutputTokens = %d, want 25", withReasoning.ReasoningOutputTokens)
+	}
+
+	// Older protocol versions omit the field.
+	var legacy TokenUsage
+	if err := json.Unmarshal([]byte(`{"input_tokens":100,"output_tokens":75}`), &legacy); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if legacy.ReasoningOutputTokens != 0 {
+		t.Errorf("legacy ReasoningOutputTokens = %d, want 0", legacy.ReasoningOutputTokens)
+	}
+
+	var ev agentstream.Event = TurnCompletedEvent{Usage: TurnUsage{InputTokens: 100, OutputTokens: 75, ReasoningOutputTokens: 25}}
+	u, ok := ev.(agentstream.Usage)
+	if !ok {
+		t.Fatal("TurnCompletedEvent does not implement agentstream.Usage")
+	}
+	if u.StreamInputTokens() != 100 || u.StreamOutputTokens() != 75 || u.StreamReasoningTokens() != 25 {
+		t.Errorf("usage = %d/%d/%d, want 100/75/25", u.StreamInputTokens(), u.StreamOutputTokens(), u.StreamReasoningTokens())
+	}
+}
+
 func TestErrorEvent(t *testing.T) {
 	testErr := errors.New("test error")
 	now := time.Now()
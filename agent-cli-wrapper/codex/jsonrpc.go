@@ -185,9 +185,12 @@ type TurnItem struct {
 	Text string `json:"text,omitempty"`
 }
 
-// TurnStartResponse from turn/start request.
+// TurnStartResponse from turn/start request. Model and ReasoningEffort are
+// set when the server reports what serves the turn.
 type TurnStartResponse struct {
-	Turn Turn `json:"turn"`
+	Model           string `json:"model,omitempty"`
+	ReasoningEffort string `json:"reasoningEffort,omitempty"`
+	Turn            Turn   `json:"turn"`
 }
 
 // App-server notification types (methods)
@@ -209,9 +212,40 @@ const (
 	NotifyCodexEventExecEnd        = "codex/event/exec_command_end"
 	NotifyCodexEventExecOutput     = "codex/event/exec_command_output_delta"
 	NotifyCodexEventReasoningDelta = "codex/event/agent_reasoning_delta"
+	NotifyCodexEventWebSearchEnd   = "codex/event/web_search_end"
 	NotifyItemCommandOutputDelta   = "item/commandExecution/outputDelta"
 )
 
+// App-server requests the client must answer (server to client). The
+// legacy exec approval is sent by servers that predate the v2 item API.
+const (
+	RequestCommandApproval    = "item/commandExecution/requestApproval"
+	RequestLegacyExecApproval = "execCommandApproval"
+)
+
+// CommandApprovalParams are the params of an exec approval request. The v2
+// request identifies the command by ItemID and sends Command as a string;
+// the legacy one uses CallID and an argv array, so Command is left raw.
+type CommandApprovalParams struct {
+	ThreadID       string          `json:"threadId"`
+	TurnID         string          `json:"turnId"`
+	ItemID         string          `json:"itemId"`
+	ConversationID string          `json:"conversationId"`
+	CallID         string          `json:"callId"`
+	CWD            string          `json:"cwd"`
+	Reason         string          `json:"reason"`
+	Command        json.RawMessage `json:"command"`
+}
+
+// serverResponse answers a request the app-server sent to the client. ID is
+// echoed back verbatim.
+type serverResponse struct {
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+}
+
 // Notification params
 
 // ThreadStartedNotification params.
@@ -405,3 +439,19 @@ type ReasoningDeltaMsg struct {
 	Type  string `json:"type"`
 	Delta string `json:"delta"`
 }
+
+// WebSearchEndMsg from codex/event/web_search_end. The begin event carries
+// only the call ID; the query is known once the search completes. Results
+// are present only on codex versions that report them.
+type WebSearchEndMsg struct {
+	Type    string         `json:"type"`
+	CallID  string         `json:"call_id"`
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results,omitempty"`
+}
+
+// SearchResult is a single web search hit.
+type SearchResult struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,724 @@
+package codex
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ReplayLineType classifies a ReplayLine. The values match bramble's
+// OutputLineType strings so display layers can convert with a cast.
+type ReplayLineType string
+
+const (
+	ReplayLineText      ReplayLineType = "text"
+	ReplayLineThinking  ReplayLineType = "thinking"
+	ReplayLineToolStart ReplayLineType = "tool_start"
+	ReplayLineError     ReplayLineType = "error"
+	ReplayLineStatus    ReplayLineType = "status"
+	ReplayLineTurnEnd   ReplayLineType = "turn_end"
+)
+
+// ReplayToolState is the execution state of a tool line.
+type ReplayToolState string
+
+const (
+	ReplayToolRunning  ReplayToolState = "running"
+	ReplayToolComplete ReplayToolState = "complete"
+	ReplayToolError    ReplayToolState = "error"
+)
+
+// ReplayStatus is the session state a protocol log ends in.
+type ReplayStatus string
+
+const (
+	// ReplayStatusCompleted means every started turn completed.
+	ReplayStatusCompleted ReplayStatus = "completed"
+	// ReplayStatusRunning means the log ends mid-turn.
+	ReplayStatusRunning ReplayStatus = "running"
+	// ReplayStatusAwaitingApproval means a command approval request was
+	// never answered.
+	ReplayStatusAwaitingApproval ReplayStatus = "awaiting_approval"
+	// ReplayStatusFailed means a provider error occurred or a thread's last
+	// turn failed.
+	ReplayStatusFailed ReplayStatus = "failed"
+)
+
+// ReplayLine is one normalized line of a replayed Codex session: streamed
+// text and reasoning are merged per item, command executions become a single
+// tool line carrying both input and result, and each turn ends with a
+// ReplayLineTurnEnd (or ReplayLineError) line followed by a token summary.
+type ReplayLine struct { //nolint:govet // fieldalignment: readability over packing
+	Timestamp  time.Time
+	StartTime  time.Time
+	ToolInput  map[string]interface{}
+	ToolResult interface{}
+	Type       ReplayLineType
+	Content    string
+	ToolName   string
+	ToolID     string
+	ToolState  ReplayToolState
+	TurnNumber int
+	DurationMs int64
+	IsError    bool
+}
+
+// Replay is the result of parsing a Codex protocol log.
+type Replay struct {
+	// Prompt is the text of the first turn/start; later prompts appear in
+	// Lines after a "Follow-up prompt:" status line.
+	Prompt string
+	Status ReplayStatus
+	Lines  []ReplayLine
+}
+
+// maxReplayLineSize bounds a single protocol log line.
+const maxReplayLineSize = 10 * 1024 * 1024
+
+// ParseProtocolLog parses a Codex protocol log, as written by
+// WithSessionLogPath, into normalized replay lines. The header line and
+// lines that are not valid JSON are skipped.
+func ParseProtocolLog(r io.Reader) (*Replay, error) {
+	p := newReplayParser()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), maxReplayLineSize)
+
+	for scanner.Scan() {
+		var entry SessionLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if entry.Direction == "" {
+			continue // header
+		}
+		ts := parseTimestamp(entry.Timestamp)
+		switch entry.Direction {
+		case "sent":
+			p.handleSent(entry.Message, ts)
+		case "received":
+			p.handleReceived(entry.Message, ts)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read protocol log: %w", err)
+	}
+
+	return &Replay{
+		Lines:  p.lines,
+		Prompt: p.prompt,
+		Status: p.deriveStatus(),
+	}, nil
+}
+
+// replayParser accumulates state while parsing a protocol log.
+type replayParser struct { //nolint:govet // fieldalignment: readability over packing
+	itemTextLine     map[string]int
+	threadActiveItem map[string]string
+	toolLineIndex    map[string]int
+	threadTokenUsage map[string]TokenUsage
+	// threadCumulativeBaseline holds the cumulative TotalTokenUsage seen
+	// at the previous TurnCompleted on threads whose Codex protocol
+	// version omits per-turn LastTokenUsage. We subtract it from the
+	// next cumulative reading to recover a per-turn delta. Empty for
+	// modern Codex logs (LastTokenUsage is per-turn already).
+	threadCumulativeBaseline map[string]TokenUsage
+	// threadUsageCumulative is true when the most recent token-usage
+	// event on this thread was a cumulative-only fallback. Replay must
+	// label such usage so it isn't read as a per-turn delta.
+	threadUsageCumulative map[string]bool
+	threadReasoning       map[string]bool
+	threadText            map[string]*strings.Builder
+	threadFailures        map[string]struct{}
+	pendingApprovals      map[string]map[string]struct{}
+	emittedApprovals      map[string]map[string]struct{}
+	prompt                string
+	lines                 []ReplayLine
+	turnCount             int
+	turnStarts            int
+	turnCompletions       int
+	hadProviderErrors     bool
+}
+
+func newReplayParser() *replayParser {
+	return &replayParser{
+		itemTextLine:             make(map[string]int),
+		threadActiveItem:         make(map[string]string),
+		toolLineIndex:            make(map[string]int),
+		threadTokenUsage:         make(map[string]TokenUsage),
+		threadCumulativeBaseline: make(map[string]TokenUsage),
+		threadUsageCumulative:    make(map[string]bool),
+		threadReasoning:          make(map[string]bool),
+		threadText:               make(map[string]*strings.Builder),
+		threadFailures:           make(map[string]struct{}),
+		pendingApprovals:         make(map[string]map[string]struct{}),
+		emittedApprovals:         make(map[string]map[string]struct{}),
+	}
+}
+
+type replayExecApprovalRequest struct {
+	CallID  string   `json:"call_id"`
+	Reason  string   `json:"reason"`
+	Command []string `json:"command"`
+}
+
+type replayItemApprovalRequest struct {
+	ThreadID string `json:"threadId"`
+	ItemID   string `json:"itemId"`
+	Reason   string `json:"reason"`
+	Command  string `json:"command"`
+}
+
+func (p *replayParser) handleSent(raw json.RawMessage, ts time.Time) {
+	var msg struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return
+	}
+	if msg.Method != "turn/start" {
+		return
+	}
+
+	var params TurnStartParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return
+	}
+
+	p.turnStarts++
+
+	text := strings.TrimSpace(firstTurnText(params.Input))
+	if text == "" {
+		return
+	}
+
+	if p.prompt == "" {
+		p.prompt = text
+	} else {
+		p.lines = append(p.lines,
+			ReplayLine{
+				Timestamp: ts,
+				Type:      ReplayLineStatus,
+				Content:   "Follow-up prompt:",
+			},
+			ReplayLine{
+				Timestamp: ts,
+				Type:      ReplayLineText,
+				Content:   text,
+			},
+		)
+	}
+
+	p.threadText[params.ThreadID] = &strings.Builder{}
+	p.threadReasoning[params.ThreadID] = false
+	p.threadActiveItem[params.ThreadID] = ""
+}
+
+func (p *replayParser) handleReceived(raw json.RawMessage, ts time.Time) {
+	var msg struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return
+	}
+
+	if mapped, ok := ParseMappedNotification(msg.Method, msg.Params); ok {
+		p.handleMappedEvent(mapped, ts)
+		return
+	}
+
+	switch msg.Method {
+	case "item/reasoning/summaryTextDelta":
+		var notif struct {
+			ThreadID string `json:"threadId"`
+			Delta    string `json:"delta"`
+		}
+		if err := json.Unmarshal(msg.Params, &notif); err != nil {
+			return
+		}
+		if p.threadReasoning[notif.ThreadID] {
+			return
+		}
+		p.appendOrAddThinking(ts, notif.Delta)
+
+	case "codex/event/reasoning_content_delta":
+		var notif CodexEventNotification
+		if err := json.Unmarshal(msg.Params, &notif); err != nil {
+			return
+		}
+		var reasoning struct {
+			Delta string `json:"delta"`
+		}
+		if err := json.Unmarshal(notif.Msg, &reasoning); err != nil {
+			return
+		}
+		if p.threadReasoning[notif.ConversationID] {
+			return
+		}
+		p.appendOrAddThinking(ts, reasoning.Delta)
+
+	case NotifyCodexEventTaskComplete:
+		var notif CodexEventNotification
+		if err := json.Unmarshal(msg.Params, &notif); err != nil {
+			return
+		}
+		var taskComplete TaskCompleteMsg
+		if err := json.Unmarshal(notif.Msg, &taskComplete); err != nil {
+			return
+		}
+		last := strings.TrimSpace(taskComplete.LastAgentMessage)
+		if last == "" {
+			return
+		}
+		threadText := p.threadText[notif.ConversationID]
+		if threadText == nil || threadText.Len() == 0 {
+			p.appendOrAddText(ts, last)
+		}
+
+	case NotifyItemCompleted:
+		var notif struct {
+			ThreadID string `json:"threadId"`
+			Item     struct {
+				Type string `json:"type"`
+				ID   string `json:"id"`
+				Text string `json:"text"`
+			} `json:"item"`
+		}
+		if err := json.Unmarshal(msg.Params, &notif); err != nil {
+			return
+		}
+		if !strings.EqualFold(notif.Item.Type, "agentMessage") || strings.TrimSpace(notif.Item.Text) == "" {
+			return
+		}
+		p.setFinalItemText(ts, notif.ThreadID, notif.Item.ID, notif.Item.Text)
+
+	case "codex/event/exec_approval_request":
+		var notif CodexEventNotification
+		if err := json.Unmarshal(msg.Params, &notif); err != nil {
+			return
+		}
+		var req replayExecApprovalRequest
+		if err := json.Unmarshal(notif.Msg, &req); err != nil {
+			return
+		}
+		p.recordApprovalRequest(
+			ts,
+			notif.ConversationID,
+			req.CallID,
+			strings.TrimSpace(strings.Join(req.Command, " ")),
+			req.Reason,
+		)
+
+	case "item/commandExecution/requestApproval":
+		var req replayItemApprovalRequest
+		if err := json.Unmarshal(msg.Params, &req); err != nil {
+			return
+		}
+		p.recordApprovalRequest(ts, req.ThreadID, req.ItemID, req.Command, req.Reason)
+	}
+}
+
+func (p *replayParser) handleMappedEvent(ev MappedEvent, ts time.Time) {
+	switch ev.Kind {
+	case MappedEventTextDelta:
+		p.appendTextDelta(ts, ev.ThreadID, ev.ItemID, ev.Delta)
+		p.appendThreadText(ev.ThreadID, ev.Delta)
+
+	case MappedEventReasoningDelta:
+		p.threadReasoning[ev.ThreadID] = true
+		p.appendOrAddThinking(ts, ev.Delta)
+
+	case MappedEventCommandStart:
+		input := map[string]interface{}{}
+		if ev.Command != "" {
+			input["command"] = ev.Command
+		}
+		if ev.CWD != "" {
+			input["cwd"] = ev.CWD
+		}
+		p.lines = append(p.lines, ReplayLine{
+			Timestamp: ts,
+			Type:      ReplayLineToolStart,
+			Content:   "Bash: " + ev.Command,
+			ToolName:  "Bash",
+			ToolID:    ev.CallID,
+			ToolInput: input,
+			ToolState: ReplayToolRunning,
+			StartTime: ts,
+		})
+		p.toolLineIndex[ev.CallID] = len(p.lines) - 1
+		p.clearPendingApproval(ev.ThreadID, ev.CallID)
+
+	case MappedEventCommandEnd:
+		p.updateToolCompletion(ev, ts)
+
+	case MappedEventWebSearch:
+		results := make([]map[string]interface{}, 0, len(ev.SearchResults))
+		for _, r := range ev.SearchResults {
+			results = append(results, map[string]interface{}{
+				"title": r.Title,
+				"url":   r.URL,
+			})
+		}
+		p.lines = append(p.lines, ReplayLine{
+			Timestamp:  ts,
+			Type:       ReplayLineToolStart,
+			Content:    "web_search: " + ev.Query,
+			ToolName:   "web_search",
+			ToolID:     ev.CallID,
+			ToolInput:  map[string]interface{}{"query": ev.Query},
+			ToolResult: map[string]interface{}{"results": results},
+			ToolState:  ReplayToolComplete,
+			StartTime:  ts,
+		})
+
+	case MappedEventTokenUsage:
+		p.threadTokenUsage[ev.ThreadID] = TokenUsage{
+			InputTokens:           ev.Usage.InputTokens,
+			CachedInputTokens:     ev.Usage.CachedInputTokens,
+			OutputTokens:          ev.Usage.OutputTokens,
+			ReasoningOutputTokens: ev.Usage.ReasoningOutputTokens,
+			TotalTokens:           ev.Usage.TotalTokens,
+		}
+		p.threadUsageCumulative[ev.ThreadID] = ev.UsageIsCumulative
+
+	case MappedEventTurnCompleted:
+		p.turnCount++
+		p.turnCompletions++
+		p.clearThreadApprovals(ev.ThreadID)
+		usage := p.threadTokenUsage[ev.ThreadID]
+		// On older Codex protocol versions that emit only TotalTokenUsage
+		// (cumulative across the thread), recover a per-turn delta by
+		// subtracting the prior turn's cumulative baseline. Falls back
+		// to the cumulative value itself for the very first turn.
+		// Each per-field subtraction is clamped at zero — if cumulative
+		// totals ever decrease (mid-log session reset, replay of
+		// concatenated logs) negative deltas would surface as confusing
+		// near-MAX_INT values once cast or as nonsensical "negative
+		// tokens" lines; clamping prefers a transient zero-delta.
+		if p.threadUsageCumulative[ev.ThreadID] {
+			baseline := p.threadCumulativeBaseline[ev.ThreadID]
+			delta := TokenUsage{
+				InputTokens:           clampSubInt64(usage.InputTokens, baseline.InputTokens),
+				CachedInputTokens:     clampSubInt64(usage.CachedInputTokens, baseline.CachedInputTokens),
+				OutputTokens:          clampSubInt64(usage.OutputTokens, baseline.OutputTokens),
+				ReasoningOutputTokens: clampSubInt64(usage.ReasoningOutputTokens, baseline.ReasoningOutputTokens),
+				TotalTokens:           clampSubInt64(usage.TotalTokens, baseline.TotalTokens),
+			}
+			p.threadCumulativeBaseline[ev.ThreadID] = usage
+			usage = delta
+		}
+		lineType := ReplayLineTurnEnd
+		content := "Turn complete"
+		if !ev.Success || ev.Error != nil {
+			lineType = ReplayLineError
+			content = "turn failed"
+			if ev.Error != nil {
+				content = strings.TrimSpace(ev.Error.Error())
+			}
+			if content == "" {
+				content = "turn failed"
+			}
+			p.threadFailures[ev.ThreadID] = struct{}{}
+		} else {
+			delete(p.threadFailures, ev.ThreadID)
+		}
+		p.lines = append(p.lines, ReplayLine{
+			Timestamp:  ts,
+			Type:       lineType,
+			Content:    content,
+			TurnNumber: p.turnCount,
+			DurationMs: ev.DurationMs,
+		})
+		if usage.InputTokens > 0 || usage.OutputTokens > 0 {
+			p.lines = append(p.lines, ReplayLine{
+				Timestamp: ts,
+				Type:      ReplayLineStatus,
+				Content:   tokenSummaryContent(usage),
+			})
+		}
+		p.threadText[ev.ThreadID] = &strings.Builder{}
+		p.threadReasoning[ev.ThreadID] = false
+		p.threadActiveItem[ev.ThreadID] = ""
+
+	case MappedEventError:
+		content := "provider error"
+		if ev.Error != nil {
+			content = strings.TrimSpace(ev.Error.Error())
+		}
+		if content == "" {
+			content = "provider error"
+		}
+		p.lines = append(p.lines, ReplayLine{
+			Timestamp: ts,
+			Type:      ReplayLineError,
+			Content:   content,
+		})
+		p.hadProviderErrors = true
+	}
+}
+
+func (p *replayParser) deriveStatus() ReplayStatus {
+	if p.hadProviderErrors || len(p.threadFailures) > 0 {
+		return ReplayStatusFailed
+	}
+	for _, byKey := range p.pendingApprovals {
+		if len(byKey) > 0 {
+			return ReplayStatusAwaitingApproval
+		}
+	}
+	if p.turnStarts > p.turnCompletions {
+		return ReplayStatusRunning
+	}
+	return ReplayStatusCompleted
+}
+
+func (p *replayParser) recordApprovalRequest(ts time.Time, threadID, callID, command, reason string) {
+	key := approvalKey(callID, command, reason)
+	if key == "" {
+		return
+	}
+	threadID = normalizeThreadKey(threadID)
+	if _, ok := p.emittedApprovals[threadID]; !ok {
+		p.emittedApprovals[threadID] = make(map[string]struct{})
+	}
+	if _, ok := p.pendingApprovals[threadID]; !ok {
+		p.pendingApprovals[threadID] = make(map[string]struct{})
+	}
+	if _, seen := p.emittedApprovals[threadID][key]; seen {
+		return
+	}
+	p.emittedApprovals[threadID][key] = struct{}{}
+	p.pendingApprovals[threadID][key] = struct{}{}
+
+	p.lines = append(p.lines, ReplayLine{
+		Timestamp: ts,
+		Type:      ReplayLineStatus,
+		Content:   "Approval required before command execution",
+	})
+
+	details := strings.TrimSpace(command)
+	if details == "" {
+		details = "(command unavailable)"
+	}
+	reason = strings.TrimSpace(reason)
+	if reason != "" {
+		details += "\n\nReason: " + reason
+	}
+	p.lines = append(p.lines, ReplayLine{
+		Timestamp: ts,
+		Type:      ReplayLineText,
+		Content:   details,
+	})
+}
+
+func (p *replayParser) clearPendingApproval(threadID, callID string) {
+	key := approvalKey(callID, "", "")
+	if key == "" {
+		return
+	}
+	threadID = normalizeThreadKey(threadID)
+	if threadPending, ok := p.pendingApprovals[threadID]; ok {
+		delete(threadPending, key)
+		if len(threadPending) == 0 {
+			delete(p.pendingApprovals, threadID)
+		}
+	}
+}
+
+func (p *replayParser) clearThreadApprovals(threadID string) {
+	threadID = normalizeThreadKey(threadID)
+	delete(p.pendingApprovals, threadID)
+	delete(p.emittedApprovals, threadID)
+}
+
+func (p *replayParser) appendTextDelta(ts time.Time, threadID, itemID, delta string) {
+	if delta == "" {
+		return
+	}
+	if threadID == "" || itemID == "" {
+		p.appendOrAddText(ts, delta)
+		return
+	}
+
+	if p.threadActiveItem[threadID] != itemID {
+		p.lines = append(p.lines, ReplayLine{
+			Timestamp: ts,
+			Type:      ReplayLineText,
+			Content:   delta,
+		})
+		idx := len(p.lines) - 1
+		p.threadActiveItem[threadID] = itemID
+		p.itemTextLine[itemID] = idx
+		return
+	}
+
+	if idx, ok := p.itemTextLine[itemID]; ok && idx >= 0 && idx < len(p.lines) {
+		p.lines[idx].Content = appendStreamingDelta(p.lines[idx].Content, delta)
+		return
+	}
+
+	p.appendOrAddText(ts, delta)
+}
+
+func (p *replayParser) setFinalItemText(ts time.Time, threadID, itemID, text string) {
+	if idx, ok := p.itemTextLine[itemID]; ok && idx >= 0 && idx < len(p.lines) {
+		p.lines[idx].Content = text
+	} else {
+		p.lines = append(p.lines, ReplayLine{
+			Timestamp: ts,
+			Type:      ReplayLineText,
+			Content:   text,
+		})
+		p.itemTextLine[itemID] = len(p.lines) - 1
+	}
+	p.threadActiveItem[threadID] = ""
+}
+
+func (p *replayParser) updateToolCompletion(ev MappedEvent, ts time.Time) {
+	idx, ok := p.toolLineIndex[ev.CallID]
+	if !ok || idx < 0 || idx >= len(p.lines) {
+		return
+	}
+	line := p.lines[idx]
+	if line.ToolInput == nil {
+		line.ToolInput = map[string]interface{}{}
+	}
+	if _, ok := line.ToolInput["command"]; !ok && ev.Command != "" {
+		line.ToolInput["command"] = ev.Command
+	}
+	if _, ok := line.ToolInput["cwd"]; !ok && ev.CWD != "" {
+		line.ToolInput["cwd"] = ev.CWD
+	}
+	line.ToolResult = map[string]interface{}{
+		"stdout":      ev.Stdout,
+		"stderr":      ev.Stderr,
+		"exit_code":   ev.ExitCode,
+		"duration_ms": ev.DurationMs,
+	}
+	line.ToolState = ReplayToolComplete
+	line.IsError = ev.ExitCode != 0
+	if line.IsError {
+		line.ToolState = ReplayToolError
+	}
+	line.DurationMs = ev.DurationMs
+	if line.DurationMs == 0 && !line.StartTime.IsZero() {
+		line.DurationMs = ts.Sub(line.StartTime).Milliseconds()
+	}
+	p.lines[idx] = line
+}
+
+func (p *replayParser) appendOrAddText(ts time.Time, text string) {
+	if text == "" {
+		return
+	}
+	if len(p.lines) > 0 && p.lines[len(p.lines)-1].Type == ReplayLineText {
+		p.lines[len(p.lines)-1].Content = appendStreamingDelta(p.lines[len(p.lines)-1].Content, text)
+		return
+	}
+	p.lines = append(p.lines, ReplayLine{
+		Timestamp: ts,
+		Type:      ReplayLineText,
+		Content:   text,
+	})
+}
+
+func (p *replayParser) appendOrAddThinking(ts time.Time, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	if len(p.lines) > 0 && p.lines[len(p.lines)-1].Type == ReplayLineThinking {
+		p.lines[len(p.lines)-1].Content = appendStreamingDelta(p.lines[len(p.lines)-1].Content, text)
+		return
+	}
+	p.lines = append(p.lines, ReplayLine{
+		Timestamp: ts,
+		Type:      ReplayLineThinking,
+		Content:   text,
+	})
+}
+
+func (p *replayParser) appendThreadText(threadID, delta string) {
+	if threadID == "" || delta == "" {
+		return
+	}
+	b, ok := p.threadText[threadID]
+	if !ok {
+		b = &strings.Builder{}
+		p.threadText[threadID] = b
+	}
+	b.WriteString(delta)
+}
+
+// appendStreamingDelta appends delta to existing, dropping any overlap
+// between the end of existing and the start of delta so chunk boundaries
+// do not duplicate text.
+func appendStreamingDelta(existing, delta string) string {
+	if existing == "" || delta == "" {
+		return existing + delta
+	}
+	for overlap := min(len(existing), len(delta)); overlap > 0; overlap-- {
+		if existing[len(existing)-overlap:] == delta[:overlap] {
+			return existing + delta[overlap:]
+		}
+	}
+	return existing + delta
+}
+
+func parseTimestamp(ts string) time.Time {
+	if ts == "" {
+		return time.Now()
+	}
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+func tokenSummaryContent(usage TokenUsage) string {
+	return fmt.Sprintf("Tokens: %d input / %d output", usage.InputTokens, usage.OutputTokens)
+}
+
+// clampSubInt64 returns max(0, a-b). Used in the cumulative-token
+// baseline subtraction to defend against non-monotonic cumulative
+// totals (e.g. replay of concatenated sessions, mid-log resets).
+func clampSubInt64(a, b int64) int64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+func approvalKey(callID, command, reason string) string {
+	callID = strings.TrimSpace(callID)
+	if callID != "" {
+		return callID
+	}
+	command = strings.TrimSpace(command)
+	reason = strings.TrimSpace(reason)
+	if command == "" && reason == "" {
+		return ""
+	}
+	return command + "\n" + reason
+}
+
+func normalizeThreadKey(threadID string) string {
+	threadID = strings.TrimSpace(threadID)
+	if threadID == "" {
+		return "_global"
+	}
+	return threadID
+}
+
+func firstTurnText(inputs []UserInput) string {
+	for i := range inputs {
+		if strings.EqualFold(inputs[i].Type, "text") && strings.TrimSpace(inputs[i].Text) != "" {
+			return inputs[i].Text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,237 @@
+package codex
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseProtocolLog_CommandAndText(t *testing.T) {
+	log := strings.Join([]string{
+		`{"format":"codex","version":"1.0","client":"test","timestamp":"2026-02-12T00:00:00Z"}`,
+		`not json`,
+		`{"timestamp":"2026-02-12T00:00:01Z","direction":"sent","message":{"method":"turn/start","params":{"threadId":"t1","input":[{"type":"text","text":"  list files  "}]}}}`,
+		`{"timestamp":"2026-02-12T00:00:02Z","direction":"received","message":{"method":"codex/event/exec_command_begin","params":{"conversationId":"t1","msg":{"type":"exec_command_begin","call_id":"call_1","turn_id":"1","cwd":"/repo","command":["ls"]}}}}`,
+		`{"timestamp":"2026-02-12T00:00:03Z","direction":"received","message":{"method":"codex/event/exec_command_end","params":{"conversationId":"t1","msg":{"type":"exec_command_end","call_id":"call_1","turn_id":"1","cwd":"/repo","command":["ls"],"stdout":"a.go\n","exit_code":0,"duration":{"secs":0,"nanos":12000000}}}}}`,
+		`{"timestamp":"2026-02-12T00:00:04Z","direction":"received","message":{"method":"item/agentMessage/delta","params":{"threadId":"t1","turnId":"1","itemId":"msg_1","delta":"Found "}}}`,
+		`{"timestamp":"2026-02-12T00:00:05Z","direction":"received","message":{"method":"item/agentMessage/delta","params":{"threadId":"t1","turnId":"1","itemId":"msg_1","delta":"a.go"}}}`,
+		`{"timestamp":"2026-02-12T00:00:06Z","direction":"received","message":{"method":"turn/completed","params":{"threadId":"t1","turn":{"id":"turn-1","status":"completed","error":null,"items":[]}}}}`,
+	}, "\n")
+
+	replay, err := ParseProtocolLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("ParseProtocolLog() error = %v", err)
+	}
+	if replay.Prompt != "list files" {
+		t.Errorf("Prompt = %q, want %q", replay.Prompt, "list files")
+	}
+	if replay.Status != ReplayStatusCompleted {
+		t.Errorf("Status = %q, want completed", replay.Status)
+	}
+
+	var tool, text, turnEnd *ReplayLine
+	for i := range replay.Lines {
+		l := &replay.Lines[i]
+		switch l.Type {
+		case ReplayLineToolStart:
+			tool = l
+		case ReplayLineText:
+			text = l
+		case ReplayLineTurnEnd:
+			turnEnd = l
+		}
+	}
+	if tool == nil || tool.ToolName != "Bash" || tool.ToolID != "call_1" || tool.ToolState != ReplayToolComplete {
+		t.Fatalf("tool line = %+v, want a completed Bash call_1", tool)
+	}
+	if got := tool.ToolInput["cwd"]; got != "/repo" {
+		t.Errorf("tool cwd = %v, want /repo", got)
+	}
+	if text == nil || text.Content != "Found a.go" {
+		t.Errorf("text line = %+v, want streamed deltas merged into %q", text, "Found a.go")
+	}
+	if turnEnd == nil || turnEnd.TurnNumber != 1 {
+		t.Errorf("turn end line = %+v, want turn 1", turnEnd)
+	}
+}
+
+func TestParseProtocolLog_UnansweredApprovalAwaitsApproval(t *testing.T) {
+	log := strings.Join([]string{
+		`{"timestamp":"2026-02-12T00:00:01Z","direction":"sent","message":{"method":"turn/start","params":{"threadId":"t1","input":[{"type":"text","text":"hello"}]}}}`,
+		`{"timestamp":"2026-02-12T00:00:02Z","direction":"received","message":{"method":"item/commandExecution/requestApproval","params":{"threadId":"t1","turnId":"0","itemId":"call_1","reason":"Need write access","command":"touch out.txt"}}}`,
+	}, "\n")
+
+	replay, err := ParseProtocolLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("ParseProtocolLog() error = %v", err)
+	}
+	if replay.Status != ReplayStatusAwaitingApproval {
+		t.Errorf("Status = %q, want awaiting_approval", replay.Status)
+	}
+}
+
+// tokenLines returns the token summary lines the parser emitted.
+func tokenLines(p *replayParser) []string {
+	var lines []string
+	for _, l := range p.lines {
+		if l.Type == ReplayLineStatus && strings.HasPrefix(l.Content, "Tokens:") {
+			lines = append(lines, l.Content)
+		}
+	}
+	return lines
+}
+
+// TestReplayParser_CumulativeUsageRendersAsPerTurnDelta verifies that on
+// older Codex protocol versions where MappedEventTokenUsage carries a
+// cumulative TotalTokenUsage (UsageIsCumulative=true), replay subtracts
+// the prior turn's cumulative baseline so the rendered "Tokens:" line
+// shows real per-turn deltas, not the running total.
+//
+// Without baseline subtraction the second turn would render
+// "Tokens: 250 input / 90 output" (the cumulative) instead of the
+// actual per-turn delta of "Tokens: 150 input / 50 output."
+func TestReplayParser_CumulativeUsageRendersAsPerTurnDelta(t *testing.T) {
+	p := newReplayParser()
+	ts := time.Time{}
+
+	// Turn 1: cumulative 100 input / 40 output.
+	p.handleMappedEvent(MappedEvent{
+		Kind:              MappedEventTokenUsage,
+		ThreadID:          "t1",
+		Usage:             TurnUsage{InputTokens: 100, OutputTokens: 40, TotalTokens: 140},
+		UsageIsCumulative: true,
+	}, ts)
+	p.handleMappedEvent(MappedEvent{
+		Kind:     MappedEventTurnCompleted,
+		ThreadID: "t1",
+		TurnID:   "1",
+		Success:  true,
+	}, ts)
+
+	// Turn 2: cumulative 250 input / 90 output (delta should be 150 / 50).
+	p.handleMappedEvent(MappedEvent{
+		Kind:              MappedEventTokenUsage,
+		ThreadID:          "t1",
+		Usage:             TurnUsage{InputTokens: 250, OutputTokens: 90, TotalTokens: 340},
+		UsageIsCumulative: true,
+	}, ts)
+	p.handleMappedEvent(MappedEvent{
+		Kind:     MappedEventTurnCompleted,
+		ThreadID: "t1",
+		TurnID:   "2",
+		Success:  true,
+	}, ts)
+
+	got := tokenLines(p)
+	want := []string{
+		// Turn 1 has no prior baseline, so cumulative IS the delta.
+		"Tokens: 100 input / 40 output",
+		// Turn 2 subtracts turn 1's cumulative baseline (250-100, 90-40).
+		"Tokens: 150 input / 50 output",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("token lines = %q, want %q", got, want)
+	}
+}
+
+// TestReplayParser_PerTurnUsageNotSubtracted verifies that when
+// MappedEventTokenUsage carries non-cumulative LastTokenUsage
+// (UsageIsCumulative=false, the modern Codex path), replay does NOT
+// subtract a baseline — the value already IS the per-turn delta.
+func TestReplayParser_PerTurnUsageNotSubtracted(t *testing.T) {
+	p := newReplayParser()
+	ts := time.Time{}
+
+	for i, deltas := range [][2]int64{{100, 40}, {150, 50}} {
+		p.handleMappedEvent(MappedEvent{
+			Kind:     MappedEventTokenUsage,
+			ThreadID: "t1",
+			Usage: TurnUsage{
+				InputTokens: deltas[0], OutputTokens: deltas[1],
+				TotalTokens: deltas[0] + deltas[1],
+			},
+			UsageIsCumulative: false, // per-turn, no baseline subtraction
+		}, ts)
+		p.handleMappedEvent(MappedEvent{
+			Kind:     MappedEventTurnCompleted,
+			ThreadID: "t1",
+			TurnID:   string(rune('1' + i)),
+			Success:  true,
+		}, ts)
+	}
+
+	got := tokenLines(p)
+	want := []string{"Tokens: 100 input / 40 output", "Tokens: 150 input / 50 output"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("token lines = %q, want %q (per-turn usage renders as-is)", got, want)
+	}
+}
+
+func TestReplayParser_TurnCompletedErrorRendersFailure(t *testing.T) {
+	p := newReplayParser()
+
+	p.handleMappedEvent(MappedEvent{
+		Kind:     MappedEventTurnCompleted,
+		ThreadID: "t1",
+		TurnID:   "1",
+		Success:  false,
+		Error:    errors.New("connection reset by peer"),
+	}, time.Time{})
+
+	if len(p.lines) != 1 {
+		t.Fatalf("lines = %d, want 1", len(p.lines))
+	}
+	if p.lines[0].Type != ReplayLineError || p.lines[0].Content != "connection reset by peer" {
+		t.Errorf("line = %+v, want the turn error", p.lines[0])
+	}
+	if got := p.deriveStatus(); got != ReplayStatusFailed {
+		t.Errorf("deriveStatus() = %q, want failed", got)
+	}
+}
+
+func TestReplayParser_SuccessfulRetryClearsTurnFailureStatus(t *testing.T) {
+	p := newReplayParser()
+	ts := time.Time{}
+
+	p.handleMappedEvent(MappedEvent{
+		Kind:     MappedEventTurnCompleted,
+		ThreadID: "t1",
+		TurnID:   "1",
+		Success:  false,
+		Error:    errors.New("connection reset by peer"),
+	}, ts)
+	p.handleMappedEvent(MappedEvent{
+		Kind:     MappedEventTurnCompleted,
+		ThreadID: "t1",
+		TurnID:   "2",
+		Success:  true,
+	}, ts)
+
+	if got := p.deriveStatus(); got != ReplayStatusCompleted {
+		t.Errorf("deriveStatus() = %q, want completed", got)
+	}
+}
+
+func TestReplayParser_UnrelatedThreadSuccessDoesNotClearTurnFailureStatus(t *testing.T) {
+	p := newReplayParser()
+	ts := time.Time{}
+
+	p.handleMappedEvent(MappedEvent{
+		Kind:     MappedEventTurnCompleted,
+		ThreadID: "failed-thread",
+		TurnID:   "1",
+		Success:  false,
+		Error:    errors.New("connection reset by peer"),
+	}, ts)
+	p.handleMappedEvent(MappedEvent{
+		Kind:     MappedEventTurnCompleted,
+		ThreadID: "other-thread",
+		TurnID:   "1",
+		Success:  true,
+	}, ts)
+
+	if got := p.deriveStatus(); got != ReplayStatusFailed {
+		t.Errorf("deriveStatus() = %q, want failed", got)
+	}
+}
@@ -2,22 +2,33 @@ package codex
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 )
 
 // Thread represents an active conversation thread.
 type Thread struct {
-	client        *Client
-	info          *ThreadInfo
-	state         *threadStateManager
-	accumulator   *threadAccumulator
-	turnWaiters   map[string][]chan *TurnResult
+	client      *Client
+	info        *ThreadInfo
+	state       *threadStateManager
+	accumulator *threadAccumulator
+	turnWaiters map[string][]chan *TurnResult
+	// runningExecs maps the call IDs of commands that have begun but not
+	// ended in the current turn to their turn IDs. On a cancelled turn the
+	// leftovers are reported as aborted.
+	runningExecs  map[string]string
 	lastUsage     *TokenUsage // Token usage from last token_count event
 	config        ThreadConfig
 	turnStartTime time.Time
 	id            string
 	currentTurnID string
+	// model and effort are what serves the thread's turns: the values the
+	// server echoed for thread/start or thread/resume (falling back to the
+	// requested model), updated when a turn/start response reports others.
+	// Empty effort means the model default.
+	model  string
+	effort string
 	// turnCount is a monotonic per-thread counter incremented once per
 	// completed turn. Codex turn IDs are opaque UUIDs, so the display turn
 	// number must be derived from a real counter rather than scraped from
@@ -25,16 +36,20 @@ type Thread struct {
 	// continues across sessions. See handleTurnCompleted / seedTurnCount.
 	turnCount int
 	mu        sync.RWMutex
+	// cancelRequested is set by CancelTurn and consumed when the turn
+	// completes, marking the result as Cancelled.
+	cancelRequested bool
 }
 
 func newThread(client *Client, id string, config ThreadConfig) *Thread {
 	return &Thread{
-		client:      client,
-		id:          id,
-		config:      config,
-		state:       newThreadStateManager(),
-		accumulator: &threadAccumulator{items: make(map[string]*itemAccumulator)},
-		turnWaiters: make(map[string][]chan *TurnResult),
+		client:       client,
+		id:           id,
+		config:       config,
+		state:        newThreadStateManager(),
+		accumulator:  &threadAccumulator{items: make(map[string]*itemAccumulator)},
+		turnWaiters:  make(map[string][]chan *TurnResult),
+		runningExecs: make(map[string]string),
 	}
 }
 
@@ -118,9 +133,11 @@ func (t *Thread) SendInput(ctx context.Context, input []UserInput, opts ...TurnO
 		return "", err
 	}
 
-	// Reset accumulator for new turn
+	// Reset accumulator for new turn. t.mu is held for the whole call, so
+	// the per-turn fields are reset under the thread lock.
 	t.accumulator.reset()
 	t.turnStartTime = time.Now()
+	t.cancelRequested = false
 
 	// Send request
 	resp, err := t.client.sendRequestAndWait(ctx, "turn/start", params)
@@ -136,7 +153,14 @@ func (t *Thread) SendInput(ctx context.Context, input []UserInput, opts ...TurnO
 		return "", &ProtocolError{Message: "failed to parse turn/start response", Cause: err}
 	}
 
+	// t.mu is held for the whole call. A requested override is not proof
+	// of what serves the turn, so only the server's report changes the
+	// model.
 	t.currentTurnID = turnResp.Turn.ID
+	if turnResp.Model != "" {
+		t.model = turnResp.Model
+		t.effort = turnResp.ReasoningEffort
+	}
 	return turnResp.Turn.ID, nil
 }
 
@@ -207,6 +231,29 @@ func (t *Thread) Interrupt(ctx context.Context) error {
 	return err
 }
 
+// CancelTurn interrupts the in-flight turn and marks it cancelled. Unlike a
+// bare Interrupt, the turn's completion is reported with Cancelled set and
+// the partial text accumulated so far, so a blocked Ask or WaitForTurn
+// returns normally instead of needing the process to be killed. Commands
+// still running when the turn ends are reported as aborted tool errors.
+func (t *Thread) CancelTurn(ctx context.Context) error {
+	t.mu.Lock()
+	if !t.state.IsProcessing() {
+		t.mu.Unlock()
+		return ErrNoTurnInProgress
+	}
+	t.cancelRequested = true
+	t.mu.Unlock()
+
+	if err := t.Interrupt(ctx); err != nil {
+		t.mu.Lock()
+		t.cancelRequested = false
+		t.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
 // CurrentTurnID returns the current turn ID.
 func (t *Thread) CurrentTurnID() string {
 	t.mu.RLock()
@@ -233,6 +280,18 @@ func (t *Thread) setInfo(info *ThreadInfo) {
 	t.info = info
 }
 
+// setServedModel records the model and effort the server reported serving
+// the thread with, falling back to the requested model.
+func (t *Thread) setServedModel(model, effort string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if model == "" {
+		model = t.config.Model
+	}
+	t.model = model
+	t.effort = effort
+}
+
 // seedTurnCount initialises the monotonic turn counter from a resumed
 // thread's history so the first turn after a resume is numbered after the
 // historical turns rather than restarting at 1. A freshly-started thread
@@ -255,9 +314,24 @@ func (t *Thread) handleTextDelta(turnID, itemID, delta string) string {
 	return t.accumulator.handleDelta(turnID, itemID, delta)
 }
 
-// handleTurnCompleted processes a turn completion and returns the calculated
-// duration and the 1-based monotonic turn index for this thread.
-func (t *Thread) handleTurnCompleted(turnID string, success bool, turnErr error) (int64, int) {
+// turnCompletion is what handleTurnCompleted reports back to the client for
+// the emitted TurnCompletedEvent.
+type turnCompletion struct {
+	model  string
+	effort string
+	// abortedExecs are the call IDs of commands still running when a
+	// cancelled turn ended.
+	abortedExecs []string
+	durationMs   int64
+	// turnIndex is the 1-based monotonic turn index for this thread.
+	turnIndex int
+	cancelled bool
+}
+
+// handleTurnCompleted processes a turn completion. interrupted is true when
+// the server reported the turn as interrupted; a turn is also treated as
+// cancelled when CancelTurn was called for it.
+func (t *Thread) handleTurnCompleted(turnID string, success, interrupted bool, turnErr error) turnCompletion {
 	t.mu.Lock()
 
 	// Calculate duration
@@ -268,11 +342,34 @@ func (t *Thread) handleTurnCompleted(turnID string, success bool, turnErr error)
 	t.turnCount++
 	turnIndex := t.turnCount
 
+	cancelled := interrupted || t.cancelRequested
+	t.cancelRequested = false
+	var abortedExecs []string
+	for callID := range t.runningExecs {
+		if cancelled {
+			abortedExecs = append(abortedExecs, callID)
+		}
+		delete(t.runningExecs, callID)
+	}
+	sort.Strings(abortedExecs)
+
+	completion := turnCompletion{
+		model:        t.model,
+		effort:       t.effort,
+		durationMs:   durationMs,
+		turnIndex:    turnIndex,
+		cancelled:    cancelled,
+		abortedExecs: abortedExecs,
+	}
+
 	// Build result
 	result := &TurnResult{
 		TurnID:     turnID,
 		Success:    success,
+		Cancelled:  cancelled,
 		FullText:   t.accumulator.getFullText(),
+		Model:      t.model,
+		Effort:     t.effort,
 		DurationMs: durationMs,
 	}
 
@@ -296,7 +393,21 @@ func (t *Thread) handleTurnCompleted(turnID string, success bool, turnErr error)
 	}
 
 	t.mu.Unlock()
-	return durationMs, turnIndex
+	return completion
+}
+
+// execStarted records a command that began in the current turn.
+func (t *Thread) execStarted(callID, turnID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.runningExecs[callID] = turnID
+}
+
+// execEnded forgets a command once it has reported its end.
+func (t *Thread) execEnded(callID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.runningExecs, callID)
 }
 
 func (t *Thread) setReady() {
@@ -325,10 +436,18 @@ func (t *Thread) getAndClearLastUsage() *TokenUsage {
 
 // TurnResult contains the result of a completed turn.
 type TurnResult struct {
-	Error      error
-	TurnID     string
-	FullText   string
+	Error    error
+	TurnID   string
+	FullText string
+	// Model and Effort are the model and reasoning effort that served the
+	// turn, as echoed by the server, else the model the thread was started
+	// with. Empty Effort means the model default.
+	Model      string
+	Effort     string
 	Usage      TurnUsage
 	DurationMs int64
 	Success    bool
+	// Cancelled is true when the turn was stopped by CancelTurn or an
+	// interrupt; FullText then holds the partial response.
+	Cancelled bool
 }
@@ -142,6 +142,20 @@ func TestThread_Interrupt_NoTurn(t *testing.T) {
 	}
 }
 
+func TestThread_CancelTurn_NoTurn(t *testing.T) {
+	client := NewClient()
+	thread := newThread(client, "thread-123", ThreadConfig{})
+	ctx := context.Background()
+
+	err := thread.CancelTurn(ctx)
+	if err != ErrNoTurnInProgress {
+		t.Errorf("expected ErrNoTurnInProgress, got %v", err)
+	}
+	if thread.cancelRequested {
+		t.Error("cancelRequested should not be set when no turn is in progress")
+	}
+}
+
 func TestThread_Close(t *testing.T) {
 	client := NewClient()
 	thread := newThread(client, "thread-123", ThreadConfig{})
@@ -217,7 +231,7 @@ func TestThread_HandleTurnCompleted(t *testing.T) {
 	thread.turnWaiters["turn-456"] = []chan *TurnResult{waiterCh}
 
 	// Complete the turn
-	thread.handleTurnCompleted("turn-456", true, nil)
+	thread.handleTurnCompleted("turn-456", true, false, nil)
 
 	// Check state transitioned back to ready
 	if thread.State() != ThreadStateReady {
@@ -260,7 +274,7 @@ func TestThread_HandleTurnCompleted_MonotonicTurnIndex(t *testing.T) {
 	for i, id := range ids {
 		thread.state.SetProcessing()
 		thread.handleTurnStarted(id)
-		_, turnIndex := thread.handleTurnCompleted(id, true, nil)
+		turnIndex := thread.handleTurnCompleted(id, true, false, nil).turnIndex
 		if turnIndex != i+1 {
 			t.Errorf("turn %d: turnIndex = %d, want %d", i, turnIndex, i+1)
 		}
@@ -284,8 +298,8 @@ func TestThread_SeedTurnCount_ResumeContinuesNumbering(t *testing.T) {
 	// The next turn after resume must be numbered 4, not 1.
 	thread.state.SetProcessing()
 	thread.handleTurnStarted("0198f2c1-7a3e-7b21-a26a-9671fa590905")
-	_, turnIndex := thread.handleTurnCompleted(
-		"0198f2c1-7a3e-7b21-a26a-9671fa590905", true, nil)
+	turnIndex := thread.handleTurnCompleted(
+		"0198f2c1-7a3e-7b21-a26a-9671fa590905", true, false, nil).turnIndex
 	if turnIndex != 4 {
 		t.Errorf("first turn after resume: turnIndex = %d, want 4", turnIndex)
 	}
@@ -293,8 +307,8 @@ func TestThread_SeedTurnCount_ResumeContinuesNumbering(t *testing.T) {
 	// And it keeps advancing monotonically from there.
 	thread.state.SetProcessing()
 	thread.handleTurnStarted("0198f2c1-9b4f-7c32-b37b-a782db691426")
-	_, turnIndex = thread.handleTurnCompleted(
-		"0198f2c1-9b4f-7c32-b37b-a782db691426", true, nil)
+	turnIndex = thread.handleTurnCompleted(
+		"0198f2c1-9b4f-7c32-b37b-a782db691426", true, false, nil).turnIndex
 	if turnIndex != 5 {
 		t.Errorf("second turn after resume: turnIndex = %d, want 5", turnIndex)
 	}
@@ -310,7 +324,7 @@ func TestThread_SeedTurnCount_FreshThreadStartsAtOne(t *testing.T) {
 
 	thread.state.SetProcessing()
 	thread.handleTurnStarted("turn-1")
-	_, turnIndex := thread.handleTurnCompleted("turn-1", true, nil)
+	turnIndex := thread.handleTurnCompleted("turn-1", true, false, nil).turnIndex
 	if turnIndex != 1 {
 		t.Errorf("fresh thread first turn: turnIndex = %d, want 1", turnIndex)
 	}
@@ -327,7 +341,7 @@ func TestThread_HandleTurnCompleted_WithError(t *testing.T) {
 	waiterCh := make(chan *TurnResult, 1)
 	thread.turnWaiters["turn-456"] = []chan *TurnResult{waiterCh}
 
-	thread.handleTurnCompleted("turn-456", false, &TurnError{
+	thread.handleTurnCompleted("turn-456", false, false, &TurnError{
 		ThreadID: "thread-123",
 		TurnID:   "turn-456",
 		Message:  "Something went wrong",
@@ -368,7 +382,7 @@ func TestThread_WaitForTurnReturnsFailedResultWithoutError(t *testing.T) {
 		return len(thread.turnWaiters["turn-456"]) == 1
 	}, time.Second, time.Millisecond)
 
-	thread.handleTurnCompleted("turn-456", false, &TurnError{
+	thread.handleTurnCompleted("turn-456", false, false, &TurnError{
 		ThreadID: "thread-123",
 		TurnID:   "turn-456",
 		Message:  "permanent failure",
@@ -448,7 +462,7 @@ func TestThread_MultipleWaiters(t *testing.T) {
 		return len(thread.turnWaiters["turn-456"]) == len(results)
 	}, time.Second, time.Millisecond)
 
-	thread.handleTurnCompleted("turn-456", true, nil)
+	thread.handleTurnCompleted("turn-456", true, false, nil)
 	wg.Wait()
 
 	for i := range results {
@@ -49,12 +49,27 @@ type MCPToolCallResult struct {
 	IsError bool             `json:"isError,omitempty"`
 }
 
-// MCPContentItem is a single content item in a tool call result.
+// MCPContentItem is a single content item in a tool call result. Type selects
+// which fields are meaningful:
+//   - "text": Text
+//   - "image": Data (base64) and MimeType
+//   - "resource_link": URI, with optional Name and MimeType
 type MCPContentItem struct {
-	Type string `json:"type"`
-	Text string `json:"text,omitempty"`
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Data     string `json:"data,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	URI      string `json:"uri,omitempty"`
+	Name     string `json:"name,omitempty"`
 }
 
+// MCP tool result content types.
+const (
+	MCPContentTypeText         = "text"
+	MCPContentTypeImage        = "image"
+	MCPContentTypeResourceLink = "resource_link"
+)
+
 // MCPInitializeResult is the MCP initialize response payload.
 type MCPInitializeResult struct {
 	ProtocolVersion string                `json:"protocolVersion"`
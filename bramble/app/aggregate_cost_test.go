@@ -3,6 +3,8 @@ package app
 import (
 	"context"
 	"math"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -57,6 +59,144 @@ func TestRenderStatusBar_CostShownWhenNonZero(t *testing.T) {
 	}
 }
 
+func TestRepoUsageHUD(t *testing.T) {
+	// The HUD is hidden in tmux mode, which NewManager picks up from $TMUX.
+	t.Setenv("TMUX", "")
+	mgr := session.NewManager()
+	for _, id := range []session.SessionID{"s1", "s2"} {
+		mgr.AddSession(&session.Session{ID: id, Status: session.StatusRunning, Progress: &session.SessionProgress{}})
+	}
+	mgr.AddSession(&session.Session{ID: "done", Status: session.StatusCompleted, Progress: &session.SessionProgress{}})
+	m := NewModel(context.Background(), "", "", "", mgr, nil, nil, 200, 24, nil, nil, session.ManagerConfig{}, nil)
+	m.sessions = []session.SessionInfo{
+		{Status: session.StatusRunning, Progress: session.SessionProgressSnapshot{TotalCostUSD: 1.0, InputTokens: 40_000, OutputTokens: 2_000}},
+		{Status: session.StatusIdle, Progress: session.SessionProgressSnapshot{TotalCostUSD: 0.23, InputTokens: 3_000}},
+		{Status: session.StatusCompleted, Progress: session.SessionProgressSnapshot{TotalCostUSD: 5, InputTokens: 90_000}},
+	}
+
+	u := m.repoUsage()
+	if u.Sessions != 2 || u.Tokens != 45_000 || math.Abs(u.CostUSD-1.23) > 1e-9 {
+		t.Errorf("repoUsage() = %+v", u)
+	}
+	want := "repo total: $1.23 · 45k tokens · 2 sessions"
+	if got := repoUsageHUD(u); got != want {
+		t.Errorf("repoUsageHUD() = %q, want %q", got, want)
+	}
+	if got := stripAnsi(m.renderTopBar()); !contains(got, want) {
+		t.Errorf("top bar missing HUD, got: %s", got)
+	}
+
+	// Too narrow: the HUD is dropped rather than wrapping the bar.
+	m.width = 60
+	if got := stripAnsi(m.renderTopBar()); contains(got, "repo total") {
+		t.Errorf("narrow top bar should omit HUD, got: %s", got)
+	}
+
+	if got := repoUsageHUD(repoUsage{}); got != "" {
+		t.Errorf("repoUsageHUD(empty) = %q, want empty", got)
+	}
+	if got := repoUsageHUD(repoUsage{Sessions: 1}); got != "repo total: $0.00 · 0 tokens · 1 session" {
+		t.Errorf("repoUsageHUD(one) = %q", got)
+	}
+}
+
+func TestSessionUsageIndicator(t *testing.T) {
+	sdk := &session.SessionInfo{
+		RunnerType: "tui",
+		Progress: session.SessionProgressSnapshot{
+			TotalCostUSD: 0.1234,
+			InputTokens:  12345,
+			OutputTokens: 3100,
+			TurnCount:    4,
+		},
+	}
+	if got, want := sessionUsageIndicator(sdk, false), "$0.1234 · 12k↑ / 3.1k↓ · T4"; got != want {
+		t.Errorf("sessionUsageIndicator() = %q, want %q", got, want)
+	}
+
+	codexSess := &session.SessionInfo{
+		RunnerType: "tui",
+		Progress: session.SessionProgressSnapshot{
+			InputTokens:     12345,
+			OutputTokens:    3100,
+			ReasoningTokens: 2100,
+			TurnCount:       1,
+		},
+	}
+	if got, want := sessionUsageIndicator(codexSess, false), "$0.0000 · 12k↑ / 3.1k↓ (2.1k reasoning) · T1"; got != want {
+		t.Errorf("sessionUsageIndicator(reasoning) = %q, want %q", got, want)
+	}
+
+	tmux := &session.SessionInfo{RunnerType: session.RunnerTypeTmux}
+	if got := sessionUsageIndicator(tmux, false); got != "-" {
+		t.Errorf("tmux session: got %q, want dash", got)
+	}
+	if got := sessionUsageIndicator(sdk, true); got != "-" {
+		t.Errorf("tmux mode: got %q, want dash", got)
+	}
+}
+
+func TestFormatTokenCount(t *testing.T) {
+	tests := []struct {
+		want string
+		n    int
+	}{
+		{"0", 0},
+		{"950", 950},
+		{"1.2k", 1234},
+		{"12k", 12999},
+		{"3.4M", 3_400_000},
+	}
+	for _, tt := range tests {
+		if got := formatTokenCount(tt.n); got != tt.want {
+			t.Errorf("formatTokenCount(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestExportCostReport_WritesCSVUnderRepo(t *testing.T) {
+	wtRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(wtRoot, "repo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	mgr := session.NewManager()
+	defer mgr.Close()
+	mgr.AddSession(&session.Session{
+		ID:           "s1",
+		Type:         session.SessionTypeBuilder,
+		Status:       session.StatusIdle,
+		WorktreeName: "feature",
+		Progress:     &session.SessionProgress{TurnCount: 1, TotalCostUSD: 0.25},
+	})
+	m := NewModel(context.Background(), wtRoot, "repo", "", mgr, nil, nil, 80, 24, nil, nil, session.ManagerConfig{}, nil)
+
+	_, cmd := m.handleKeyPress(keyPress('$'))
+	if cmd == nil {
+		t.Fatal("expected an export command")
+	}
+	msg, ok := cmd().(costReportMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("expected a successful costReportMsg, got %#v", msg)
+	}
+	want := filepath.Join(wtRoot, "repo", "cost-report.csv")
+	if msg.path != want {
+		t.Errorf("path = %q, want %q", msg.path, want)
+	}
+	data, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "s1,feature,builder,,1,0,0,0.250000,idle") {
+		t.Errorf("cost report missing session row:\n%s", data)
+	}
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if len(m.toasts.toasts) == 0 || !strings.Contains(m.toasts.toasts[len(m.toasts.toasts)-1].Message, want) {
+		t.Error("expected a toast naming the report path")
+	}
+}
@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"charm.land/lipgloss/v2"
@@ -9,13 +11,16 @@ import (
 	"github.com/bazelment/yoloswe/bramble/session"
 )
 
-// AllSessionsOverlay displays all active sessions across all worktrees.
+// AllSessionsOverlay displays all active sessions across all worktrees,
+// grouped under a header per worktree. sessions holds the flattened,
+// grouped order, which is what navigation and number keys index into.
 type AllSessionsOverlay struct {
-	sessions    []session.SessionInfo
-	selectedIdx int
-	width       int
-	height      int
-	visible     bool
+	currentWorktree string
+	sessions        []session.SessionInfo
+	selectedIdx     int
+	width           int
+	height          int
+	visible         bool
 }
 
 const (
@@ -28,9 +33,12 @@ func NewAllSessionsOverlay() *AllSessionsOverlay {
 	return &AllSessionsOverlay{}
 }
 
-// Show populates and displays the overlay with the given sessions.
-func (o *AllSessionsOverlay) Show(sessions []session.SessionInfo, w, h int) {
-	o.sessions = sessions
+// Show populates and displays the overlay with the given sessions, grouped
+// by worktree with currentWorktree's group first and the rest sorted by
+// name. currentWorktree may be empty.
+func (o *AllSessionsOverlay) Show(sessions []session.SessionInfo, currentWorktree string, w, h int) {
+	o.currentWorktree = currentWorktree
+	o.sessions = groupSessionsByWorktree(sessions, currentWorktree)
 	o.width = w
 	o.height = h
 	o.selectedIdx = 0
@@ -85,11 +93,92 @@ func (o *AllSessionsOverlay) SelectedSession() *session.SessionInfo {
 	return &o.sessions[o.selectedIdx]
 }
 
-// Sessions returns the overlay's session list.
+// Sessions returns the overlay's session list in display (grouped) order.
 func (o *AllSessionsOverlay) Sessions() []session.SessionInfo {
 	return o.sessions
 }
 
+// allSessionsGroupKey identifies the worktree group a session belongs to.
+func allSessionsGroupKey(sess *session.SessionInfo) string {
+	return sess.RepoName + "\x00" + sess.WorktreePath
+}
+
+// allSessionsGroupLabel is the header text for a session's worktree group.
+func allSessionsGroupLabel(sess *session.SessionInfo, multiRepo bool) string {
+	name := sess.WorktreeName
+	if name == "" && sess.WorktreePath != "" {
+		name = filepath.Base(sess.WorktreePath)
+	}
+	if name == "" {
+		name = "(no worktree)"
+	}
+	if multiRepo && sess.RepoName != "" {
+		return sess.RepoName + " / " + name
+	}
+	return name
+}
+
+// groupSessionsByWorktree returns sessions reordered so each worktree's
+// sessions are contiguous: the current worktree first, then the others by
+// repo and worktree name. Order within a group is preserved.
+func groupSessionsByWorktree(sessions []session.SessionInfo, currentWorktree string) []session.SessionInfo {
+	grouped := append([]session.SessionInfo(nil), sessions...)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		a, b := &grouped[i], &grouped[j]
+		aCur := currentWorktree != "" && a.WorktreePath == currentWorktree
+		bCur := currentWorktree != "" && b.WorktreePath == currentWorktree
+		if aCur != bCur {
+			return aCur
+		}
+		if a.RepoName != b.RepoName {
+			return a.RepoName < b.RepoName
+		}
+		al, bl := allSessionsGroupLabel(a, false), allSessionsGroupLabel(b, false)
+		if al != bl {
+			return al < bl
+		}
+		return a.WorktreePath < b.WorktreePath
+	})
+	return grouped
+}
+
+// groupHeaders counts the worktree headers rendered for sessions[start:end]:
+// one for each group that starts in the range, plus one for a group already
+// in progress at start.
+func (o *AllSessionsOverlay) groupHeaders(start, end int) int {
+	n := 0
+	for i := start; i < end; i++ {
+		if i == start || allSessionsGroupKey(&o.sessions[i]) != allSessionsGroupKey(&o.sessions[i-1]) {
+			n++
+		}
+	}
+	return n
+}
+
+// groupSize returns the number of sessions in the group containing idx.
+func (o *AllSessionsOverlay) groupSize(idx int) int {
+	key := allSessionsGroupKey(&o.sessions[idx])
+	n := 0
+	for i := range o.sessions {
+		if allSessionsGroupKey(&o.sessions[i]) == key {
+			n++
+		}
+	}
+	return n
+}
+
+// visibleRows returns the session range to render within maxRows lines,
+// leaving room for the worktree headers interleaved with it.
+func (o *AllSessionsOverlay) visibleRows(maxRows int) (int, int) {
+	for budget := maxRows; budget > 1; budget-- {
+		start, end := o.visibleSessionRange(budget)
+		if budget+o.groupHeaders(start, end) <= maxRows {
+			return start, end
+		}
+	}
+	return o.visibleSessionRange(1)
+}
+
 func (o *AllSessionsOverlay) boxWidth() int {
 	w := o.width - 4
 	if w < allSessionsMinBoxWidth {
@@ -152,7 +241,7 @@ func (o *AllSessionsOverlay) View(s *Styles) string {
 	if len(o.sessions) == 0 {
 		lines = append(lines, s.Dim.Render("  No active sessions across any worktree."), "")
 	} else {
-		// Check if sessions span multiple repos to decide whether to show a Repo column.
+		// Check if sessions span multiple repos to decide whether group headers name the repo.
 		multiRepo := false
 		if len(o.sessions) > 1 {
 			first := o.sessions[0].RepoName
@@ -164,7 +253,8 @@ func (o *AllSessionsOverlay) View(s *Styles) string {
 			}
 		}
 
-		// Scale column widths to fit contentWidth.
+		// Scale column widths to fit contentWidth. The worktree is shown in
+		// the group headers, so rows only carry the session columns.
 		// Fixed overhead: " #. 🔨  " prefix (~9 cols) + status (~12 cols) + gaps = ~27 cols
 		fixedCols := 27 // num(3) + icon(4) + status(12) + spacing(8)
 		flexBudget := contentWidth - fixedCols
@@ -172,47 +262,21 @@ func (o *AllSessionsOverlay) View(s *Styles) string {
 			flexBudget = 30
 		}
 
-		// Allocate flex budget; add a Repo column when multiple repos are present.
-		var repoColWidth int
-		if multiRepo {
-			repoColWidth = flexBudget * 15 / 100
-			if repoColWidth < 8 {
-				repoColWidth = 8
-			}
-			flexBudget -= repoColWidth
-		}
-
-		// Allocate: 30% worktree, 25% name, 45% prompt (with minimums)
-		wtColWidth := flexBudget * 30 / 100
-		if wtColWidth < 8 {
-			wtColWidth = 8
-		}
-		nameColWidth := flexBudget * 25 / 100
+		// Allocate: 35% name, 65% prompt (with minimums)
+		nameColWidth := flexBudget * 35 / 100
 		if nameColWidth < 8 {
 			nameColWidth = 8
 		}
-		promptWidth := flexBudget - wtColWidth - nameColWidth
+		promptWidth := flexBudget - nameColWidth
 		if promptWidth < 10 {
 			promptWidth = 10
 		}
 
 		// Table header
-		wtFmt := fmt.Sprintf("%%-%ds", wtColWidth)
 		nameFmt := fmt.Sprintf("%%-%ds", nameColWidth)
-
-		var headerFmt, rowFmt string
-		if multiRepo {
-			repoFmt := fmt.Sprintf("%%-%ds", repoColWidth)
-			headerFmt = " %-3s %-4s " + repoFmt + " " + wtFmt + " " + nameFmt + " %-12s %s"
-			rowFmt = " %-3s %s  " + repoFmt + " " + wtFmt + " " + nameFmt + " %-12s %s"
-			header := s.Dim.Render(fmt.Sprintf(headerFmt, "#", "Type", "Repo", "Worktree", "Name", "Status", "Prompt"))
-			lines = append(lines, header)
-		} else {
-			headerFmt = " %-3s %-4s " + wtFmt + " " + nameFmt + " %-12s %s"
-			rowFmt = " %-3s %s  " + wtFmt + " " + nameFmt + " %-12s %s"
-			header := s.Dim.Render(fmt.Sprintf(headerFmt, "#", "Type", "Worktree", "Name", "Status", "Prompt"))
-			lines = append(lines, header)
-		}
+		headerFmt := "   %-3s %-4s " + nameFmt + " %-12s %s"
+		rowFmt := "   %-3s %s  " + nameFmt + " %-12s %s"
+		lines = append(lines, s.Dim.Render(fmt.Sprintf(headerFmt, "#", "Type", "Name", "Status", "Prompt")))
 		sepWidth := contentWidth - 1
 		if sepWidth < 20 {
 			sepWidth = 20
@@ -225,11 +289,21 @@ func (o *AllSessionsOverlay) View(s *Styles) string {
 		if maxSessionRows < 1 {
 			maxSessionRows = 1
 		}
-		start, end := o.visibleSessionRange(maxSessionRows)
+		start, end := o.visibleRows(maxSessionRows)
 
 		for i := start; i < end; i++ {
 			sess := &o.sessions[i]
 
+			// Worktree header when a new group starts (or continues from
+			// above the visible window).
+			if i == start || allSessionsGroupKey(sess) != allSessionsGroupKey(&o.sessions[i-1]) {
+				label := truncate(allSessionsGroupLabel(sess, multiRepo), contentWidth-20)
+				if o.currentWorktree != "" && sess.WorktreePath == o.currentWorktree {
+					label += " (current)"
+				}
+				lines = append(lines, " "+s.HelpSectionTitle.Render(label)+" "+s.Dim.Render(fmt.Sprintf("(%d)", o.groupSize(i))))
+			}
+
 			// Number (1-9 for quick select, blank otherwise)
 			num := ""
 			if i < 9 {
@@ -239,9 +313,6 @@ func (o *AllSessionsOverlay) View(s *Styles) string {
 			// Type icon
 			typeIcon := sessionTypeEmojiIcon(sess.Type)
 
-			// Worktree name
-			wtName := truncate(sess.WorktreeName, wtColWidth-1)
-
 			// Session name
 			nameDisplay := sess.TmuxWindowName
 			if nameDisplay == "" {
@@ -264,14 +335,7 @@ func (o *AllSessionsOverlay) View(s *Styles) string {
 			}
 			promptDisplay := truncate(prompt, promptWidth)
 
-			var line string
-			if multiRepo {
-				repoName := truncate(sess.RepoName, repoColWidth-1)
-				line = fmt.Sprintf(rowFmt, num, typeIcon, repoName, wtName, nameDisplay, statusStr, promptDisplay)
-			} else {
-				line = fmt.Sprintf(rowFmt, num, typeIcon, wtName, nameDisplay, statusStr, promptDisplay)
-			}
-
+			line := fmt.Sprintf(rowFmt, num, typeIcon, nameDisplay, statusStr, promptDisplay)
 			if i == o.selectedIdx {
 				line = s.Selected.Render(line)
 			}
@@ -283,16 +347,16 @@ func (o *AllSessionsOverlay) View(s *Styles) string {
 	lines = append(lines, "")
 
 	// Footer
-	footer := s.Dim.Render("[↑/↓] Navigate  [Enter] Switch  [p/b/c] New session  [1-9] Quick select  [Esc] Close")
+	footer := s.Dim.Render("[↑/↓] Navigate  [Enter] Switch  [p/b/c] New session  [m] Merge PR  [1-9] Quick select  [Esc] Close")
 	if len(o.sessions) > 0 {
 		maxSessionRows := contentHeight - 6
 		if maxSessionRows < 1 {
 			maxSessionRows = 1
 		}
-		start, end := o.visibleSessionRange(maxSessionRows)
+		start, end := o.visibleRows(maxSessionRows)
 		if start > 0 || end < len(o.sessions) {
 			footer = s.Dim.Render(fmt.Sprintf(
-				"[↑/↓] Navigate  [Enter] Switch  [p/b/c] New session  [1-9] Quick select  [Esc] Close   (%d-%d/%d)",
+				"[↑/↓] Navigate  [Enter] Switch  [p/b/c] New session  [m] Merge PR  [1-9] Quick select  [Esc] Close   (%d-%d/%d)",
 				start+1, end, len(o.sessions),
 			))
 		}
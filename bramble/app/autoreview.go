@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// autoReviewPrompt is what an auto-started reviewer is asked. Reviewers run
+// as codetalk sessions, which are read-only.
+const autoReviewPrompt = "Review the changes on this branch: the diff against the branch it was forked from plus any uncommitted changes. " +
+	"List concrete bugs, risks and missing tests, most important first, each with the file and line. " +
+	"Do not edit any files. If the changes look good, say so."
+
+// autoReviews links reviewer sessions started by the "auto-review on builder
+// idle" repo setting to the builders they review, and holds each finished
+// review as a follow-up suggestion for its builder.
+type autoReviews struct {
+	builderOf   map[session.SessionID]session.SessionID // reviewer -> builder
+	suggestions map[session.SessionID]string            // builder -> follow-up prompt
+}
+
+// reviewing reports whether a reviewer for builder is still running.
+func (a *autoReviews) reviewing(mgr *session.Manager, builder session.SessionID) bool {
+	for reviewer, b := range a.builderOf {
+		if b != builder {
+			continue
+		}
+		if info, ok := mgr.GetSessionInfo(reviewer); ok && !info.Status.IsTerminal() && info.Status != session.StatusIdle {
+			return true
+		}
+	}
+	return false
+}
+
+// link records reviewer as the reviewer of builder.
+func (a *autoReviews) link(reviewer, builder session.SessionID) {
+	if a.builderOf == nil {
+		a.builderOf = make(map[session.SessionID]session.SessionID)
+	}
+	a.builderOf[reviewer] = builder
+}
+
+// suggest stores prompt as the follow-up suggestion for builder.
+func (a *autoReviews) suggest(builder session.SessionID, prompt string) {
+	if a.suggestions == nil {
+		a.suggestions = make(map[session.SessionID]string)
+	}
+	a.suggestions[builder] = prompt
+}
+
+// takeSuggestion returns and clears the follow-up suggestion for builder.
+func (a *autoReviews) takeSuggestion(builder session.SessionID) (string, bool) {
+	prompt, ok := a.suggestions[builder]
+	if ok {
+		delete(a.suggestions, builder)
+	}
+	return prompt, ok
+}
+
+// onSessionIdle starts a reviewer when a builder in a repo with auto-review
+// goes idle, and turns a finished review into a follow-up suggestion for
+// its builder.
+func (m *Model) onSessionIdle(repoName string, mgr *session.Manager, id session.SessionID) tea.Cmd {
+	if mgr == nil {
+		return nil
+	}
+	info, ok := mgr.GetSessionInfo(id)
+	if !ok {
+		return nil
+	}
+	if builder, ok := m.autoReviews.builderOf[id]; ok {
+		return m.offerReview(mgr, info, builder)
+	}
+	if info.Type != session.SessionTypeBuilder {
+		return nil
+	}
+	cfg := m.settings.RepoSettingsFor(repoName)
+	if !cfg.AutoReview || m.autoReviews.reviewing(mgr, id) {
+		return nil
+	}
+	reviewModel := cfg.ReviewModel
+	if reviewModel == "" {
+		reviewModel = cfg.CodeTalkModel
+	}
+	reviewer, err := mgr.StartSession(session.SessionTypeCodeTalk, info.WorktreePath, autoReviewPrompt, pickDefaultModel(m.modelRegistry, reviewModel, "opus"))
+	if err != nil {
+		return m.addToast(fmt.Sprintf("Auto-review of %s failed to start: %v", sessionLabel(info), err), ToastError)
+	}
+	m.autoReviews.link(reviewer, id)
+	if repoName == m.repoName {
+		m.sessions = m.sessionManager.GetAllSessions()
+		m.updateSessionDropdown()
+	}
+	return m.addToast("Reviewing "+sessionLabel(info), ToastInfo)
+}
+
+// offerReview stores a finished review as the builder's next follow-up.
+func (m *Model) offerReview(mgr *session.Manager, review session.SessionInfo, builder session.SessionID) tea.Cmd {
+	prompt := fmt.Sprintf("Address the findings of the code review in session %s", review.ID)
+	if review.ResearchFilePath != "" {
+		prompt = fmt.Sprintf("Address the findings of the code review in %s", review.ResearchFilePath)
+	}
+	m.autoReviews.suggest(builder, prompt)
+	label := string(builder)
+	if info, ok := mgr.GetSessionInfo(builder); ok {
+		label = sessionLabel(info)
+	}
+	return m.addToast("Review of "+label+" ready: press f on the builder to follow up", ToastSuccess)
+}
+
+// reviewLabel names a reviewer session after the builder it reviews, so the
+// two read as a pair in the session list. It returns "" for other sessions.
+func (m *Model) reviewLabel(id session.SessionID) string {
+	builder, ok := m.autoReviews.builderOf[id]
+	if !ok {
+		return ""
+	}
+	for i := range m.sessions {
+		if m.sessions[i].ID == builder {
+			return "Review: " + sessionLabel(m.sessions[i])
+		}
+	}
+	return "Review: " + string(builder)
+}
+
+// sessionLabel is a session's title, or its ID when it has none.
+func sessionLabel(info session.SessionInfo) string {
+	if info.Title != "" {
+		return info.Title
+	}
+	return string(info.ID)
+}
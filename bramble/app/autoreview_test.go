@@ -0,0 +1,61 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+func TestAutoReview_DisabledByDefault(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	builder, err := m.sessionManager.StartSession(session.SessionTypeBuilder, "/tmp/wt/main", "add the feature", "")
+	require.NoError(t, err)
+
+	before := len(m.sessionManager.GetAllSessions())
+	assert.Nil(t, m.onSessionIdle("test-repo", m.sessionManager, builder))
+	assert.Len(t, m.sessionManager.GetAllSessions(), before)
+}
+
+func TestAutoReview_StartsReviewerAndSuggestsFollowUp(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	m.settings.SetRepoSettings("test-repo", RepoSettings{AutoReview: true})
+	builder, err := m.sessionManager.StartSession(session.SessionTypeBuilder, "/tmp/wt/main", "add the feature", "")
+	require.NoError(t, err)
+
+	require.NotNil(t, m.onSessionIdle("test-repo", m.sessionManager, builder))
+	require.Len(t, m.autoReviews.builderOf, 1)
+	var reviewer session.SessionID
+	for r, b := range m.autoReviews.builderOf {
+		reviewer = r
+		assert.Equal(t, builder, b)
+	}
+	info, ok := m.sessionManager.GetSessionInfo(reviewer)
+	require.True(t, ok)
+	assert.Equal(t, session.SessionTypeCodeTalk, info.Type)
+	assert.Equal(t, "/tmp/wt/main", info.WorktreePath)
+	assert.Equal(t, autoReviewPrompt, info.Prompt)
+	assert.True(t, strings.HasPrefix(m.reviewLabel(reviewer), "Review: "), m.reviewLabel(reviewer))
+
+	// The reviewer going idle offers its findings to the builder, once.
+	require.NotNil(t, m.onSessionIdle("test-repo", m.sessionManager, reviewer))
+	assert.Contains(t, m.toasts.toasts[len(m.toasts.toasts)-1].Message, "press f on the builder")
+	suggestion, ok := m.autoReviews.takeSuggestion(builder)
+	require.True(t, ok)
+	assert.Contains(t, suggestion, "Address the findings of the code review")
+	_, ok = m.autoReviews.takeSuggestion(builder)
+	assert.False(t, ok)
+}
+
+func TestAutoReview_IgnoresNonBuilders(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	m.settings.SetRepoSettings("test-repo", RepoSettings{AutoReview: true})
+	planner, err := m.sessionManager.StartSession(session.SessionTypePlanner, "/tmp/wt/main", "plan it", "")
+	require.NoError(t, err)
+
+	assert.Nil(t, m.onSessionIdle("test-repo", m.sessionManager, planner))
+	assert.Empty(t, m.autoReviews.builderOf)
+}
@@ -402,7 +402,7 @@ func (cc *CommandCenter) renderHeader(s *Styles) string {
 	var running, idle, pending, terminal int
 	for i := range cc.sessions {
 		switch cc.sessions[i].Status {
-		case session.StatusRunning:
+		case session.StatusRunning, session.StatusWaitingApproval:
 			running++
 		case session.StatusIdle:
 			idle++
@@ -493,7 +493,7 @@ func (cc *CommandCenter) renderPreviewRow(s *Styles) string {
 // sessionPriority returns a sort priority for a session (lower = higher priority).
 func sessionPriority(sess *session.SessionInfo) int {
 	switch sess.Status {
-	case session.StatusIdle:
+	case session.StatusIdle, session.StatusWaitingApproval:
 		return 0 // needs action — highest priority
 	case session.StatusRunning:
 		return 1
@@ -573,6 +573,8 @@ func renderSessionCard(sess *session.SessionInfo, cardWidth, idx int, selected b
 		line3 = s.Idle.Render(truncate("PLAN READY", innerWidth))
 	case sess.Status == session.StatusIdle:
 		line3 = s.Idle.Render(truncate("AWAITING FOLLOW-UP", innerWidth))
+	case sess.Status == session.StatusWaitingApproval && sess.PendingApproval != nil:
+		line3 = s.Idle.Render(truncate("APPROVE: "+sess.PendingApproval.Command, innerWidth))
 	case sess.Status == session.StatusRunning && sess.Progress.CurrentPhase != "":
 		line3 = truncate(sess.Progress.CurrentPhase, innerWidth)
 	default:
@@ -609,7 +611,7 @@ func renderSessionCard(sess *session.SessionInfo, cardWidth, idx int, selected b
 // cardBorderColor returns the border color for a session card based on status.
 func cardBorderColor(sess *session.SessionInfo, palette ColorPalette) color.Color {
 	switch sess.Status {
-	case session.StatusIdle:
+	case session.StatusIdle, session.StatusWaitingApproval:
 		return lipgloss.Color(palette.Idle)
 	case session.StatusRunning:
 		return lipgloss.Color(palette.Running)
@@ -629,6 +631,8 @@ func statusText(status session.SessionStatus) string {
 		return "Running"
 	case session.StatusIdle:
 		return "Idle"
+	case session.StatusWaitingApproval:
+		return "Needs approval"
 	case session.StatusCompleted:
 		return "Completed"
 	case session.StatusFailed:
@@ -649,6 +653,8 @@ func statusIconPlain(status session.SessionStatus) string {
 		return "●"
 	case session.StatusIdle:
 		return "◐"
+	case session.StatusWaitingApproval:
+		return "!"
 	case session.StatusCompleted:
 		return "✓"
 	case session.StatusFailed:
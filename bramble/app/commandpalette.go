@@ -0,0 +1,314 @@
+package app
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// PaletteEntry is one action offered by the command palette.
+type PaletteEntry struct {
+	Section     string // help section the action belongs to, e.g. "Sessions"
+	Key         string // binding as shown in help, e.g. "Alt-W"
+	Description string
+	key         tea.KeyPressMsg // key press that runs the action
+}
+
+// CommandPalette is the ctrl+p overlay that fuzzy-filters every action
+// available in the current context and runs the selected one by replaying
+// its key binding.
+type CommandPalette struct {
+	query         string
+	entries       []PaletteEntry
+	matches       []int // indexes into entries, best match first
+	selectedIdx   int
+	width         int
+	height        int
+	previousFocus FocusArea
+	visible       bool
+}
+
+// NewCommandPalette creates a new command palette.
+func NewCommandPalette() *CommandPalette {
+	return &CommandPalette{}
+}
+
+// Show opens the palette with entries and an empty query.
+func (p *CommandPalette) Show(entries []PaletteEntry, previousFocus FocusArea, w, h int) {
+	p.entries = entries
+	p.previousFocus = previousFocus
+	p.width = w
+	p.height = h
+	p.visible = true
+	p.SetQuery("")
+}
+
+// Hide closes the palette.
+func (p *CommandPalette) Hide() {
+	p.visible = false
+}
+
+// IsVisible returns whether the palette is showing.
+func (p *CommandPalette) IsVisible() bool {
+	return p.visible
+}
+
+// SetSize updates the overlay dimensions (e.g. on terminal resize).
+func (p *CommandPalette) SetSize(w, h int) {
+	p.width = w
+	p.height = h
+}
+
+// PreviousFocus returns the focus the palette was opened from.
+func (p *CommandPalette) PreviousFocus() FocusArea {
+	return p.previousFocus
+}
+
+// Query returns the current filter text.
+func (p *CommandPalette) Query() string {
+	return p.query
+}
+
+// SetQuery replaces the filter text and reselects the best match.
+func (p *CommandPalette) SetQuery(q string) {
+	p.query = q
+	p.selectedIdx = 0
+	p.matches = p.matches[:0]
+	type scored struct {
+		idx, score int
+	}
+	var found []scored
+	for i, e := range p.entries {
+		if score, ok := fuzzyScore(q, e.Key+" "+e.Description+" "+e.Section); ok {
+			found = append(found, scored{idx: i, score: score})
+		}
+	}
+	sort.SliceStable(found, func(a, b int) bool { return found[a].score > found[b].score })
+	for _, f := range found {
+		p.matches = append(p.matches, f.idx)
+	}
+}
+
+// Backspace removes the last rune of the query.
+func (p *CommandPalette) Backspace() {
+	if p.query == "" {
+		return
+	}
+	_, size := utf8.DecodeLastRuneInString(p.query)
+	p.SetQuery(p.query[:len(p.query)-size])
+}
+
+// MoveSelection moves the selection by delta (positive = down).
+func (p *CommandPalette) MoveSelection(delta int) {
+	p.selectedIdx += delta
+	if p.selectedIdx >= len(p.matches) {
+		p.selectedIdx = len(p.matches) - 1
+	}
+	if p.selectedIdx < 0 {
+		p.selectedIdx = 0
+	}
+}
+
+// Selected returns the highlighted entry, or false when nothing matches.
+func (p *CommandPalette) Selected() (PaletteEntry, bool) {
+	if p.selectedIdx < 0 || p.selectedIdx >= len(p.matches) {
+		return PaletteEntry{}, false
+	}
+	return p.entries[p.matches[p.selectedIdx]], true
+}
+
+// View renders the palette as a centered box.
+func (p *CommandPalette) View(s *Styles) string {
+	lines := []string{
+		s.Title.Render("Command Palette"),
+		"> " + p.query + "█",
+		"",
+	}
+
+	// Box chrome, title, query and footer take ~9 lines.
+	visible := p.height - 9
+	if visible < 5 {
+		visible = 5
+	}
+	start := 0
+	if p.selectedIdx >= visible {
+		start = p.selectedIdx - visible + 1
+	}
+	end := start + visible
+	if end > len(p.matches) {
+		end = len(p.matches)
+	}
+	if len(p.matches) == 0 {
+		lines = append(lines, s.Dim.Render("  No matching actions"))
+	}
+	for i := start; i < end; i++ {
+		e := p.entries[p.matches[i]]
+		key := s.HelpKey.Render(s.HelpKeyAlign.Render(e.Key))
+		line := "  " + key + "  " + e.Description + " " + s.Dim.Render("· "+e.Section)
+		if i == p.selectedIdx {
+			line = s.Selected.Render("> " + e.Key + "  " + e.Description)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", s.Dim.Render("[Type] Filter  [Up/Down] Navigate  [Enter] Run  [Esc] Close"))
+
+	boxWidth := p.width - 10
+	if boxWidth > 72 {
+		boxWidth = 72
+	}
+	if boxWidth < 40 {
+		boxWidth = 40
+	}
+	box := s.ModalBox.Width(boxWidth).Render(strings.Join(lines, "\n"))
+
+	if p.width > 0 && p.height > 0 {
+		return lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, box)
+	}
+	return box
+}
+
+// fuzzyScore reports whether every rune of query appears in text in order,
+// ignoring case, and scores the match: consecutive runs and matches at the
+// start of a word score higher. An empty query matches everything equally.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, true
+	}
+	score, qi := 0, 0
+	prevMatch := false
+	prev := ' '
+	for _, r := range strings.ToLower(text) {
+		if qi < len(q) && r == q[qi] {
+			score++
+			if prevMatch {
+				score += 2
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 3
+			}
+			qi++
+			prevMatch = true
+		} else {
+			prevMatch = false
+		}
+		prev = r
+	}
+	return score, qi == len(q)
+}
+
+// paletteEntries lists the actions for the current context: the help
+// bindings (see helpSectionsFor) as seen from the main view, minus those
+// that are not a single key press (navigation ranges, Enter, Esc).
+func paletteEntries(m *Model) []PaletteEntry {
+	var entries []PaletteEntry
+	for _, section := range helpSectionsFor(m, FocusOutput) {
+		for _, b := range section.Bindings {
+			key, ok := paletteKey(b.Key)
+			if !ok {
+				continue
+			}
+			entries = append(entries, PaletteEntry{Section: section.Title, Key: b.Key, Description: b.Description, key: key})
+		}
+	}
+	return entries
+}
+
+// paletteKey turns a help binding such as "Alt-W", "Ctrl+L", "F2" or "$"
+// into the key press that triggers it. Bindings that name several keys,
+// Enter, Esc, or the palette itself are not runnable from the palette.
+func paletteKey(binding string) (tea.KeyPressMsg, bool) {
+	switch binding {
+	case "F2":
+		return tea.KeyPressMsg{Code: tea.KeyF2}, true
+	case "Tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab}, true
+	}
+	var mod tea.KeyMod
+	rest := binding
+	for _, prefix := range []struct {
+		name string
+		mod  tea.KeyMod
+	}{{"Alt", tea.ModAlt}, {"Ctrl", tea.ModCtrl}} {
+		for _, sep := range []string{"-", "+"} {
+			if r, ok := strings.CutPrefix(rest, prefix.name+sep); ok {
+				mod |= prefix.mod
+				rest = r
+			}
+		}
+	}
+	if utf8.RuneCountInString(rest) != 1 {
+		return tea.KeyPressMsg{}, false
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	if mod == 0 {
+		return tea.KeyPressMsg{Code: r, Text: rest}, true
+	}
+	if mod == tea.ModCtrl && unicode.ToLower(r) == 'p' {
+		return tea.KeyPressMsg{}, false
+	}
+	return tea.KeyPressMsg{Code: unicode.ToLower(r), Mod: mod}, true
+}
+
+// canOpenCommandPalette reports whether ctrl+p opens the palette from the
+// current focus. Text-entry states keep the key for themselves.
+func (m Model) canOpenCommandPalette() bool {
+	if m.inputMode || m.taskModal.IsVisible() {
+		return false
+	}
+	switch m.focus {
+	case FocusOutput, FocusWorktreeDropdown, FocusSessionDropdown, FocusRepoDropdown,
+		FocusHelp, FocusAllSessions, FocusCommandCenter:
+		return true
+	}
+	return false
+}
+
+// openCommandPalette shows the palette over the current view.
+func (m Model) openCommandPalette() (tea.Model, tea.Cmd) {
+	m.commandPalette.Show(paletteEntries(&m), m.focus, m.width, m.height)
+	m.focus = FocusCommandPalette
+	return m, nil
+}
+
+// handleCommandPalette handles key presses while the palette is open.
+// Running an action closes every overlay and replays its key binding from
+// the main view.
+func (m Model) handleCommandPalette(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		m.commandPalette.Hide()
+		m.focus = m.commandPalette.PreviousFocus()
+		return m, nil
+	case "enter":
+		entry, ok := m.commandPalette.Selected()
+		m.commandPalette.Hide()
+		if !ok {
+			m.focus = m.commandPalette.PreviousFocus()
+			return m, nil
+		}
+		m.worktreeDropdown.Close()
+		m.sessionDropdown.Close()
+		m.repoDropdown.Close()
+		m.allSessionsOverlay.Hide()
+		m.commandCenter.Hide()
+		m.focus = FocusOutput
+		return m.handleKeyPress(entry.key)
+	case "up", "ctrl+k":
+		m.commandPalette.MoveSelection(-1)
+	case "down", "ctrl+j":
+		m.commandPalette.MoveSelection(1)
+	case "backspace":
+		m.commandPalette.Backspace()
+	default:
+		if msg.Text != "" {
+			m.commandPalette.SetQuery(m.commandPalette.Query() + msg.Text)
+		}
+	}
+	return m, nil
+}
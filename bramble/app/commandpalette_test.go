@@ -0,0 +1,121 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestPaletteKey(t *testing.T) {
+	runnable := map[string]string{
+		"Alt-W":  "alt+w",
+		"Alt-C":  "alt+c",
+		"Ctrl+L": "ctrl+l",
+		"Ctrl-C": "ctrl+c",
+		"F2":     "f2",
+		"Tab":    "tab",
+		"$":      "$",
+		"S":      "S",
+		"?":      "?",
+	}
+	for binding, want := range runnable {
+		key, ok := paletteKey(binding)
+		require.True(t, ok, binding)
+		assert.Equal(t, want, key.String(), binding)
+	}
+	for _, binding := range []string{"Up/k", "1..9", "n/N", "Enter", "Esc", "PgUp", "Shift+Enter", "Ctrl+P"} {
+		_, ok := paletteKey(binding)
+		assert.False(t, ok, binding)
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	_, ok := fuzzyScore("owt", "Open worktree selector")
+	assert.True(t, ok)
+	_, ok = fuzzyScore("xyz", "Open worktree selector")
+	assert.False(t, ok)
+
+	word, _ := fuzzyScore("merge", "Merge PR")
+	scattered, _ := fuzzyScore("merge", "Remove the grumpy edge")
+	assert.Greater(t, word, scattered)
+}
+
+func TestCommandPaletteListsContextActions(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{{Path: "/tmp/wt/feature", Branch: "feature"}}, "test-repo")
+
+	entries := paletteEntries(&m)
+	descriptions := make(map[string]string)
+	for _, e := range entries {
+		descriptions[e.Key] = e.Description
+	}
+	assert.Equal(t, "Merge PR", descriptions["m"])
+	assert.Equal(t, "Open worktree selector", descriptions["Alt-W"])
+	assert.NotContains(t, descriptions, "Up/k")
+	assert.NotContains(t, descriptions, "Ctrl+P")
+}
+
+func TestCommandPaletteRunsSelectedAction(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{{Path: "/tmp/wt/feature", Branch: "feature"}}, "test-repo")
+
+	newM, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	m = newM.(Model)
+	require.Equal(t, FocusCommandPalette, m.focus)
+	assert.Contains(t, m.View().Content, "Command Palette")
+
+	for _, r := range "all sess" {
+		newM, _ = m.Update(keyPress(r))
+		m = newM.(Model)
+	}
+	entry, ok := m.commandPalette.Selected()
+	require.True(t, ok)
+	assert.Equal(t, "S", entry.Key)
+
+	newM, _ = m.Update(specialKey(tea.KeyEnter))
+	m = newM.(Model)
+	assert.False(t, m.commandPalette.IsVisible())
+	assert.Equal(t, FocusAllSessions, m.focus)
+	assert.True(t, m.allSessionsOverlay.IsVisible())
+}
+
+func TestCommandPaletteEscapeRestoresFocus(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{{Path: "/tmp/wt/feature", Branch: "feature"}}, "test-repo")
+	m.worktreeDropdown.Open()
+	m.focus = FocusWorktreeDropdown
+
+	newM, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	m = newM.(Model)
+	require.Equal(t, FocusCommandPalette, m.focus)
+
+	newM, _ = m.Update(specialKey(tea.KeyBackspace))
+	m = newM.(Model)
+	newM, _ = m.Update(specialKey(tea.KeyEscape))
+	m = newM.(Model)
+	assert.Equal(t, FocusWorktreeDropdown, m.focus)
+	assert.True(t, m.worktreeDropdown.IsOpen())
+}
+
+func TestCommandPaletteNotOpenedWhileTyping(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{{Path: "/tmp/wt/feature", Branch: "feature"}}, "test-repo")
+	m.inputMode = true
+	m.focus = FocusInput
+
+	newM, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	m = newM.(Model)
+	assert.False(t, m.commandPalette.IsVisible())
+	assert.Equal(t, FocusInput, m.focus)
+}
+
+func TestCommandPaletteNoMatches(t *testing.T) {
+	p := NewCommandPalette()
+	p.Show([]PaletteEntry{{Key: "m", Description: "Merge PR", Section: "Worktrees"}}, FocusOutput, 80, 24)
+	p.SetQuery("zzz")
+	_, ok := p.Selected()
+	assert.False(t, ok)
+	assert.True(t, strings.Contains(p.View(NewStyles(Dark)), "No matching actions"))
+}
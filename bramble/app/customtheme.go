@@ -0,0 +1,292 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Custom themes are user-defined palettes read from themes.toml in the
+// bramble config directory. Despite the name, the file is not parsed as TOML
+// but in a small line-based format of its own: a [name] header starts a
+// theme, and each line after it sets one key:
+//
+//	[nord]
+//	accent    = "#88c0d0"
+//	dim       = "#4c566a"
+//	border    = "#3b4252"
+//	bar_bg    = "#3b4252"
+//	bar_fg    = "#eceff4"
+//	select_bg = "#434c5e"
+//	select_fg = "#eceff4"
+//	error     = "#bf616a"
+//	running   = "#a3be8c"
+//	idle      = "#81a1c1"
+//	pending   = "#ebcb8b"
+//	done      = "#d8dee9"
+//	# optional: toast_{success,info,error}_{bg,fg}, glamour_style
+//
+// Colors are hex ("#rrggbb" or "#rgb") or ANSI 256 numbers. Optional keys
+// default to the Dark palette, or Light when glamour_style = "light".
+
+var (
+	customThemesMu sync.RWMutex
+	customThemes   []ColorPalette
+)
+
+// themeKeys maps themes.toml keys to the ColorPalette field they set.
+// Required keys must be present for a theme to load.
+var themeKeys = []struct {
+	field    func(*ColorPalette) *string
+	key      string
+	required bool
+}{
+	{func(p *ColorPalette) *string { return &p.Accent }, "accent", true},
+	{func(p *ColorPalette) *string { return &p.Dim }, "dim", true},
+	{func(p *ColorPalette) *string { return &p.Border }, "border", true},
+	{func(p *ColorPalette) *string { return &p.BarBg }, "bar_bg", true},
+	{func(p *ColorPalette) *string { return &p.BarFg }, "bar_fg", true},
+	{func(p *ColorPalette) *string { return &p.SelectBg }, "select_bg", true},
+	{func(p *ColorPalette) *string { return &p.SelectFg }, "select_fg", true},
+	{func(p *ColorPalette) *string { return &p.Error }, "error", true},
+	{func(p *ColorPalette) *string { return &p.Running }, "running", true},
+	{func(p *ColorPalette) *string { return &p.Idle }, "idle", true},
+	{func(p *ColorPalette) *string { return &p.Pending }, "pending", true},
+	{func(p *ColorPalette) *string { return &p.Done }, "done", true},
+	{func(p *ColorPalette) *string { return &p.ToastSuccessBg }, "toast_success_bg", false},
+	{func(p *ColorPalette) *string { return &p.ToastSuccessFg }, "toast_success_fg", false},
+	{func(p *ColorPalette) *string { return &p.ToastInfoBg }, "toast_info_bg", false},
+	{func(p *ColorPalette) *string { return &p.ToastInfoFg }, "toast_info_fg", false},
+	{func(p *ColorPalette) *string { return &p.ToastErrorBg }, "toast_error_bg", false},
+	{func(p *ColorPalette) *string { return &p.ToastErrorFg }, "toast_error_fg", false},
+}
+
+// CustomThemesPath returns the path to the user's themes file, honoring
+// $XDG_CONFIG_HOME and defaulting to ~/.config/bramble/themes.toml.
+func CustomThemesPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "bramble", "themes.toml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "bramble", "themes.toml"), nil
+}
+
+// LoadCustomThemes reads the user's themes file and registers every valid
+// theme so ThemeByName and the pickers can find it. A missing file is not an
+// error. Malformed themes are skipped and reported in the returned errors; a
+// saved ThemeName that refers to one then falls back to the built-in default.
+func LoadCustomThemes() []error {
+	p, err := CustomThemesPath()
+	if err != nil {
+		return []error{err}
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return []error{err}
+	}
+	themes, errs := ParseCustomThemes(data)
+	SetCustomThemes(themes)
+	for i, err := range errs {
+		errs[i] = fmt.Errorf("%s: %w", p, err)
+	}
+	return errs
+}
+
+// SetCustomThemes replaces the registered custom themes.
+func SetCustomThemes(themes []ColorPalette) {
+	customThemesMu.Lock()
+	defer customThemesMu.Unlock()
+	customThemes = append([]ColorPalette(nil), themes...)
+}
+
+// AvailableThemes returns the built-in themes followed by any custom themes.
+func AvailableThemes() []ColorPalette {
+	customThemesMu.RLock()
+	defer customThemesMu.RUnlock()
+	out := make([]ColorPalette, 0, len(BuiltinThemes)+len(customThemes))
+	out = append(out, BuiltinThemes...)
+	return append(out, customThemes...)
+}
+
+// ParseCustomThemes parses themes.toml content: [name] section headers,
+// key = "string" or key = integer lines, and # comments. Any other syntax is
+// an error. Each valid section becomes a theme; invalid ones are reported and
+// left out.
+func ParseCustomThemes(data []byte) ([]ColorPalette, []error) {
+	type rawTheme struct {
+		err    error
+		values map[string]string
+		name   string
+		line   int
+	}
+	var (
+		raws []*rawTheme
+		cur  *rawTheme
+		errs []error
+	)
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || strings.HasPrefix(line, "[[") {
+				errs = append(errs, fmt.Errorf("line %d: invalid section header %q", lineNo, line))
+				cur = nil
+				continue
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if unq, err := strconv.Unquote(name); err == nil {
+				name = unq
+			}
+			cur = &rawTheme{name: name, line: lineNo, values: make(map[string]string)}
+			raws = append(raws, cur)
+			continue
+		}
+		if cur == nil {
+			errs = append(errs, fmt.Errorf("line %d: key outside of a [theme] section", lineNo))
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			cur.err = fmt.Errorf("line %d: expected key = value", lineNo)
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if unq, err := strconv.Unquote(value); err == nil {
+			value = unq
+		} else if _, err := strconv.Atoi(value); err != nil {
+			cur.err = fmt.Errorf("line %d: %s must be a string or integer", lineNo, key)
+			continue
+		}
+		cur.values[key] = value
+	}
+	if err := sc.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	var themes []ColorPalette
+	seen := make(map[string]bool)
+	for _, b := range BuiltinThemes {
+		seen[b.Name] = true
+	}
+	for _, r := range raws {
+		if r.err == nil && seen[r.name] {
+			r.err = fmt.Errorf("name %q is already taken", r.name)
+		}
+		var p ColorPalette
+		if r.err == nil {
+			p, r.err = buildCustomTheme(r.name, r.values)
+		}
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("theme %q (line %d): %w", r.name, r.line, r.err))
+			continue
+		}
+		seen[r.name] = true
+		themes = append(themes, p)
+	}
+	return themes, errs
+}
+
+// buildCustomTheme validates the key/value pairs of one theme section and
+// fills optional fields from the matching built-in base.
+func buildCustomTheme(name string, values map[string]string) (ColorPalette, error) {
+	if name == "" {
+		return ColorPalette{}, fmt.Errorf("theme name is empty")
+	}
+	base := Dark
+	glamour := values["glamour_style"]
+	switch glamour {
+	case "", "dark", "auto":
+	case "light":
+		base = Light
+	default:
+		return ColorPalette{}, fmt.Errorf("glamour_style must be dark, light, or auto, got %q", glamour)
+	}
+
+	p := base
+	p.Name = name
+	if glamour != "" {
+		p.GlamourStyle = glamour
+	}
+
+	known := map[string]bool{"glamour_style": true}
+	var missing []string
+	for _, k := range themeKeys {
+		known[k.key] = true
+		v, ok := values[k.key]
+		if !ok {
+			if k.required {
+				missing = append(missing, k.key)
+			}
+			continue
+		}
+		if !validThemeColor(v) {
+			return ColorPalette{}, fmt.Errorf("%s: invalid color %q", k.key, v)
+		}
+		*k.field(&p) = v
+	}
+	if len(missing) > 0 {
+		return ColorPalette{}, fmt.Errorf("missing required keys: %s", strings.Join(missing, ", "))
+	}
+	var unknown []string
+	for k := range values {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return ColorPalette{}, fmt.Errorf("unknown keys: %s", strings.Join(unknown, ", "))
+	}
+	return p, nil
+}
+
+// validThemeColor accepts "#rgb", "#rrggbb", or an ANSI 256 number.
+func validThemeColor(v string) bool {
+	if hex, ok := strings.CutPrefix(v, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(v)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// stripComment removes a trailing # comment that is not inside a quoted
+// string (colors like "#88c0d0" contain '#').
+func stripComment(line string) string {
+	inQuote := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inQuote {
+				i++
+			}
+		case '"':
+			inQuote = !inQuote
+		case '#':
+			if !inQuote {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
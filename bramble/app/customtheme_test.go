@@ -0,0 +1,147 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nordTheme = `
+# A comment line
+[nord]
+accent    = "#88c0d0"  # trailing comment
+dim       = "#4c566a"
+border    = "#3b4252"
+bar_bg    = "#3b4252"
+bar_fg    = "#eceff4"
+select_bg = "#434c5e"
+select_fg = "#eceff4"
+error     = "#bf616a"
+running   = "#a3be8c"
+idle      = 110
+pending   = "#ebcb8b"
+done      = "#d8dee9"
+`
+
+func TestParseCustomThemes(t *testing.T) {
+	t.Parallel()
+
+	themes, errs := ParseCustomThemes([]byte(nordTheme + `
+[paper]
+glamour_style = "light"
+accent    = "#333"
+dim       = "245"
+border    = "250"
+bar_bg    = "254"
+bar_fg    = "0"
+select_bg = "195"
+select_fg = "0"
+error     = "1"
+running   = "2"
+idle      = "4"
+pending   = "3"
+done      = "8"
+toast_error_bg = "#ffdddd"
+`))
+	require.Empty(t, errs)
+	require.Len(t, themes, 2)
+
+	nord := themes[0]
+	assert.Equal(t, "nord", nord.Name)
+	assert.Equal(t, "#88c0d0", nord.Accent)
+	assert.Equal(t, "110", nord.Idle)
+	assert.Equal(t, Dark.ToastInfoBg, nord.ToastInfoBg, "optional keys default to the dark base")
+	assert.Equal(t, "dark", nord.GlamourStyle)
+
+	paper := themes[1]
+	assert.Equal(t, "light", paper.GlamourStyle)
+	assert.Equal(t, "#ffdddd", paper.ToastErrorBg)
+	assert.Equal(t, Light.ToastInfoBg, paper.ToastInfoBg, "optional keys default to the light base")
+}
+
+func TestParseCustomThemesSkipsMalformed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "missing required key", input: "[broken]\naccent = \"#fff\"\n", wantErr: "missing required keys: dim"},
+		{name: "invalid color", input: replaceLine(nordTheme, "done", `done = "#zzzzzz"`), wantErr: `done: invalid color "#zzzzzz"`},
+		{name: "out of range ansi", input: replaceLine(nordTheme, "done", `done = 300`), wantErr: `done: invalid color "300"`},
+		{name: "unknown key", input: nordTheme + "accnet = \"#fff\"\n", wantErr: "unknown keys: accnet"},
+		{name: "builtin name", input: replaceLine(nordTheme, "[nord]", "[dark]"), wantErr: `name "dark" is already taken`},
+		{name: "bad value", input: replaceLine(nordTheme, "done", "done = #fff"), wantErr: "must be a string or integer"},
+		{name: "bad glamour", input: nordTheme + "glamour_style = \"neon\"\n", wantErr: "glamour_style must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			themes, errs := ParseCustomThemes([]byte(tt.input + "\n[ok]\n" + themeBody(nordTheme)))
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), tt.wantErr)
+			require.Len(t, themes, 1, "valid themes after a malformed one still load")
+			assert.Equal(t, "ok", themes[0].Name)
+		})
+	}
+}
+
+func TestLoadCustomThemesRegistersThemes(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bramble"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bramble", "themes.toml"),
+		[]byte(nordTheme+"\n[half]\naccent = \"1\"\n"), 0o644))
+	t.Cleanup(func() { SetCustomThemes(nil) })
+
+	errs := LoadCustomThemes()
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "themes.toml")
+
+	p, ok := ThemeByName("nord")
+	require.True(t, ok)
+	assert.Equal(t, "#bf616a", p.Error)
+
+	_, ok = ThemeByName("half")
+	assert.False(t, ok, "malformed theme must not be selectable")
+
+	names := make([]string, 0)
+	for _, th := range NewThemePicker().themes {
+		names = append(names, th.Name)
+	}
+	assert.Contains(t, names, "nord")
+	assert.Contains(t, names, "dark")
+}
+
+func TestLoadCustomThemesMissingFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	assert.Empty(t, LoadCustomThemes())
+}
+
+// replaceLine swaps the first line of src starting with prefix for repl.
+func replaceLine(src, prefix, repl string) string {
+	lines := strings.Split(src, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, prefix) {
+			lines[i] = repl
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// themeBody returns src without its [name] header lines.
+func themeBody(src string) string {
+	var out []string
+	for _, l := range strings.Split(src, "\n") {
+		if !strings.HasPrefix(l, "[") {
+			out = append(out, l)
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/multiagent/agent"
+)
+
+func testModelRegistry(providers ...string) (*agent.ProviderAvailability, *agent.ModelRegistry) {
+	statuses := make(map[string]agent.ProviderStatus)
+	for _, p := range []string{agent.ProviderClaude, agent.ProviderCodex, agent.ProviderGemini} {
+		statuses[p] = agent.ProviderStatus{Provider: p}
+	}
+	for _, p := range providers {
+		statuses[p] = agent.ProviderStatus{Provider: p, Installed: true}
+	}
+	availability := agent.NewProviderAvailabilityFromMap(statuses)
+	return availability, agent.NewModelRegistry(availability, nil)
+}
+
+func TestDefaultModelsRestoredPerRepo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var s Settings
+	s.SetRepoSettings("repo", RepoSettings{PlanModel: "gpt-5.5", BuildModel: "haiku"})
+	require.NoError(t, SaveSettings(s))
+	_, registry := testModelRegistry(agent.ProviderClaude, agent.ProviderCodex)
+
+	mgr := session.NewManagerWithConfig(session.ManagerConfig{SessionMode: session.SessionModeTUI})
+	t.Cleanup(mgr.Close)
+	m := NewModel(context.Background(), "/tmp/wt", "repo", "", mgr, nil, nil, 80, 24, nil, registry, session.ManagerConfig{}, nil)
+	assert.Equal(t, "gpt-5.5", m.defaultPlanModel)
+	assert.Equal(t, "haiku", m.defaultBuildModel)
+	assert.Equal(t, "opus", m.defaultCodeTalkModel, "unsaved types keep the built-in default")
+
+	other := NewModel(context.Background(), "/tmp/wt", "other", "", mgr, nil, nil, 80, 24, nil, registry, session.ManagerConfig{}, nil)
+	assert.Equal(t, "opus", other.defaultPlanModel, "saved defaults are per repo")
+}
+
+func TestDefaultModelsRevalidatedAgainstRegistry(t *testing.T) {
+	availability, registry := testModelRegistry(agent.ProviderClaude, agent.ProviderCodex)
+	m := setupModel(t, session.SessionModeTUI, nil, "repo")
+	m.providerAvailability = availability
+	m.modelRegistry = registry
+	require.NoError(t, m.saveDefaultModel(session.SessionTypePlanner, "gpt-5.5"))
+
+	registry.Rebuild(availability, []string{agent.ProviderClaude})
+	m.resolveDefaultModels()
+	assert.Equal(t, "opus", m.defaultPlanModel, "a disabled provider's model falls back")
+	assert.Equal(t, "gpt-5.5", m.settings.RepoSettingsFor("repo").PlanModel, "the saved choice is kept")
+
+	registry.Rebuild(availability, nil)
+	m.resolveDefaultModels()
+	assert.Equal(t, "gpt-5.5", m.defaultPlanModel, "re-enabling the provider restores the saved choice")
+}
+
+func TestCycleModelPersistsDefault(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "repo")
+	m.pendingModel = "opus"
+	m.pendingSessionType = session.SessionTypeBuilder
+
+	newModel, _ := m.handleInputMode(tea.KeyPressMsg{Code: 'm', Mod: tea.ModAlt})
+	m2 := newModel.(Model)
+
+	next := session.NextModel("opus").ID
+	assert.Equal(t, next, m2.pendingModel)
+	assert.Equal(t, next, m2.defaultBuildModel)
+	assert.Equal(t, next, LoadSettings().RepoSettingsFor("repo").BuildModel)
+	assert.Contains(t, m2.inputPrompt, "Build prompt ["+next+"]")
+}
+
+func TestProviderStartupNotice(t *testing.T) {
+	availability, registry := testModelRegistry(agent.ProviderCodex)
+	notice := providerStartupNotice(availability, registry, RepoSettings{}, "gpt-5.5")
+	assert.Contains(t, notice, "claude not found in PATH")
+	assert.Contains(t, notice, "npm install -g @anthropic-ai/claude-code")
+	assert.Contains(t, notice, "Using gpt-5.5 instead")
+
+	availability, registry = testModelRegistry(agent.ProviderClaude)
+	assert.Empty(t, providerStartupNotice(availability, registry, RepoSettings{}, "sonnet"))
+
+	registry.Rebuild(availability, []string{agent.ProviderCodex})
+	notice = providerStartupNotice(availability, registry, RepoSettings{}, "sonnet")
+	assert.Contains(t, notice, "claude is disabled in settings")
+	assert.Contains(t, notice, "sessions cannot start")
+}
+
+func TestStartupWithMissingDefaultProvider(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	availability, registry := testModelRegistry(agent.ProviderCodex)
+
+	mgr := session.NewManagerWithConfig(session.ManagerConfig{SessionMode: session.SessionModeTUI})
+	t.Cleanup(mgr.Close)
+	m := NewModel(context.Background(), "/tmp/wt", "repo", "", mgr, nil, nil, 80, 24, availability, registry, session.ManagerConfig{}, nil)
+
+	first, _ := registry.FirstAvailableModel()
+	assert.Equal(t, first.ID, m.defaultBuildModel, "an available model is selected")
+	require.Equal(t, 1, m.toasts.Count())
+	assert.True(t, m.toasts.toasts[0].Sticky)
+	assert.Contains(t, m.toasts.toasts[0].Message, "claude not found in PATH")
+
+	// The first key press only dismisses the notice.
+	newModel, cmd := m.Update(keyPress('?'))
+	assert.Nil(t, cmd)
+	assert.False(t, newModel.(Model).toasts.HasToasts())
+	assert.NotEqual(t, FocusHelp, newModel.(Model).focus)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestDeleteDirtyWorktreeAsksBeforeDiscarding(t *testing.T) {
+	dir := t.TempDir()
+	out, err := exec.Command("git", "init", "-q", dir).CombinedOutput()
+	require.NoError(t, err, string(out))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scratch.txt"), []byte("wip"), 0o644))
+
+	worktrees := []wt.Worktree{{Branch: "feature", Path: dir}}
+	m := setupModel(t, session.SessionModeTUI, worktrees, "test-repo")
+	m.worktreeDropdown.SelectIndex(0)
+	m.viewingSessionID = "s1"
+
+	newModel, cmd := m.deleteWorktree("feature", true, false)
+	m = newModel.(Model)
+	require.NotNil(t, cmd)
+	msg := cmd()
+	require.Equal(t, worktreeDirtyMsg{branch: "feature", deleteBranch: true}, msg)
+	_, err = os.Stat(filepath.Join(dir, "scratch.txt"))
+	require.NoError(t, err, "a dirty worktree must not be touched before the user confirms")
+	assert.Equal(t, session.SessionID("s1"), m.viewingSessionID, "the session stays in view until the user confirms")
+
+	newModel, _ = m.Update(msg)
+	m = newModel.(Model)
+	require.Equal(t, FocusConfirm, m.focus)
+	require.NotNil(t, m.confirmPrompt)
+	assert.Contains(t, m.confirmPrompt.message, "uncommitted changes")
+	assert.Empty(t, m.worktreeOpMessages)
+
+	newModel, cmd = m.handleConfirmMode(keyPress('f'))
+	m = newModel.(Model)
+	require.NotNil(t, cmd)
+	msg = cmd()
+	assert.Equal(t, deleteWorktreeMsg{branch: "feature", deleteBranch: true, force: true}, msg)
+	assert.Nil(t, m.confirmPrompt)
+	assert.Equal(t, session.SessionID("s1"), m.viewingSessionID)
+
+	newModel, cmd = m.Update(msg)
+	m = newModel.(Model)
+	require.NotNil(t, cmd)
+	assert.Empty(t, m.viewingSessionID, "confirming the delete leaves the worktree's session")
+}
+
+func TestDeleteCleanWorktreeRemovesIt(t *testing.T) {
+	dir := t.TempDir()
+	out, err := exec.Command("git", "init", "-q", dir).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{{Branch: "feature", Path: dir}}, "test-repo")
+	m.worktreeDropdown.SelectIndex(0)
+	m.viewingSessionID = "s1"
+
+	newModel, cmd := m.deleteWorktree("feature", false, false)
+	m = newModel.(Model)
+	require.NotNil(t, cmd)
+	msg := cmd()
+	require.Equal(t, worktreeCleanMsg{branch: "feature"}, msg)
+	assert.Equal(t, session.SessionID("s1"), m.viewingSessionID)
+
+	newModel, cmd = m.Update(msg)
+	m = newModel.(Model)
+	require.NotNil(t, cmd)
+	assert.Empty(t, m.viewingSessionID)
+	assert.Equal(t, []string{"Deleting worktree feature..."}, m.worktreeOpMessages)
+}
+
+func TestDeleteDirtyWorktreeCancelKeepsIt(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{{Branch: "feature", Path: "/tmp/wt/feature"}}, "test-repo")
+
+	newModel, _ := m.Update(worktreeDirtyMsg{branch: "feature"})
+	m = newModel.(Model)
+	require.Equal(t, FocusConfirm, m.focus)
+
+	newModel, cmd := m.handleConfirmMode(keyPress('y'))
+	m = newModel.(Model)
+	assert.Nil(t, cmd, "only f discards changes")
+	assert.Equal(t, FocusConfirm, m.focus)
+
+	newModel, cmd = m.handleConfirmMode(specialKey(tea.KeyEscape))
+	m = newModel.(Model)
+	assert.Nil(t, cmd)
+	assert.Equal(t, FocusOutput, m.focus)
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// sessionDiffStatMsg carries a session's diff stat computed off the UI
+// goroutine.
+type sessionDiffStatMsg struct {
+	err  error
+	id   session.SessionID
+	stat session.DiffStat
+}
+
+// refreshSessionDiffStat computes the diff stat of session id in mgr.
+func refreshSessionDiffStat(mgr *session.Manager, id session.SessionID) tea.Cmd {
+	if mgr == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		stat, err := mgr.SessionDiffStat(id)
+		return sessionDiffStatMsg{id: id, stat: stat, err: err}
+	}
+}
+
+// diffStatBadge renders the compact "+120 −30" badge for the session list,
+// or "" when the session has changed nothing yet.
+func (m Model) diffStatBadge(id session.SessionID) string {
+	stat, ok := m.sessionDiffStats[id]
+	if !ok || stat.IsZero() {
+		return ""
+	}
+	return m.styles.Dim.Render(fmt.Sprintf("+%d −%d", stat.Insertions, stat.Deletions))
+}
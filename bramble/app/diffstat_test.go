@@ -0,0 +1,47 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestSessionDiffStatBadge(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{{Path: "/tmp/wt/feature", Branch: "feature"}}, "test-repo")
+	m.sessionManager.AddSession(&session.Session{
+		ID:           "s1",
+		Type:         session.SessionTypeBuilder,
+		Status:       session.StatusIdle,
+		WorktreePath: "/tmp/wt/feature",
+		Progress:     &session.SessionProgress{},
+	})
+	m.sessions = m.sessionManager.GetAllSessions()
+	m.viewingSessionID = "s1"
+
+	// Sessions without a start commit get no badge.
+	newM, _ := m.Update(sessionDiffStatMsg{id: "s1", err: session.ErrNoDiffBase})
+	m = newM.(Model)
+	assert.Empty(t, m.diffStatBadge("s1"))
+
+	newM, _ = m.Update(sessionDiffStatMsg{id: "s1", stat: session.DiffStat{FilesChanged: 2, Insertions: 12, Deletions: 3}})
+	m = newM.(Model)
+
+	items := m.sessionDropdown.effectiveItems()
+	require.NotEmpty(t, items)
+	assert.Contains(t, items[0].Badge, "+12 −3")
+	assert.Contains(t, m.renderOutputArea(120, 20), "+12 −3 across 2 files")
+}
+
+func TestRefreshSessionDiffStatReportsErrors(t *testing.T) {
+	mgr := session.NewManager()
+	defer mgr.Close()
+	msg := refreshSessionDiffStat(mgr, "missing")().(sessionDiffStatMsg)
+	assert.Error(t, msg.err)
+	assert.False(t, errors.Is(msg.err, session.ErrNoDiffBase))
+	assert.Nil(t, refreshSessionDiffStat(nil, "missing"))
+}
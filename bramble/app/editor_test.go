@@ -6,31 +6,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestShellSplit(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  []string
-	}{
-		{"simple command", "code", []string{"code"}},
-		{"command with flag", "emacsclient -n", []string{"emacsclient", "-n"}},
-		{"command with multiple flags", "code --wait --new-window", []string{"code", "--wait", "--new-window"}},
-		{"double-quoted path", `"/path/to/My Editor" --wait`, []string{"/path/to/My Editor", "--wait"}},
-		{"single-quoted path", `'/path/to/My Editor' --wait`, []string{"/path/to/My Editor", "--wait"}},
-		{"empty string", "", nil},
-		{"only spaces", "   ", nil},
-		{"extra whitespace", "  code   --wait  ", []string{"code", "--wait"}},
-		{"quoted flag value", `editor "--flag=hello world"`, []string{"editor", "--flag=hello world"}},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := shellSplit(tt.input)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestEditorCommand(t *testing.T) {
 	tests := []struct {
 		name     string
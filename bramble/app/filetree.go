@@ -8,6 +8,7 @@ import (
 
 	"github.com/mattn/go-runewidth"
 
+	"github.com/bazelment/yoloswe/bramble/service"
 	"github.com/bazelment/yoloswe/wt"
 )
 
@@ -211,20 +212,18 @@ func (ft *FileTree) SelectedPath() string {
 
 // AbsSelectedPath returns the absolute path of the currently selected file,
 // or empty string if the selection is a directory or nothing is selected.
-// It ensures the result is contained within the worktree root.
+// Containment is checked with service.ResolvePath, the same guard the
+// worktree service applies to remote reads.
 func (ft *FileTree) AbsSelectedPath() string {
 	rel := ft.SelectedPath()
 	if rel == "" || ft.root == "" {
 		return ""
 	}
-	abs := filepath.Join(ft.root, rel)
-	// Clean both paths and verify containment to prevent path traversal.
-	cleanAbs := filepath.Clean(abs)
-	cleanRoot := filepath.Clean(ft.root)
-	if !strings.HasPrefix(cleanAbs, cleanRoot+string(filepath.Separator)) && cleanAbs != cleanRoot {
+	abs, err := service.ResolvePath(ft.root, rel)
+	if err != nil {
 		return ""
 	}
-	return cleanAbs
+	return abs
 }
 
 // SetFocused sets whether the file tree pane has focus.
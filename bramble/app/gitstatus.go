@@ -90,6 +90,35 @@ func mergeGitStatus(statuses map[string]*wt.WorktreeStatus, branch string, statu
 	return statuses
 }
 
+// replaceStatuses stores full statuses from wt.Manager.StatusAll, keyed by
+// branch. Each replaces the worktree's previous status, so a PR that was
+// merged or closed since stops showing as open. For worktrees in known,
+// whose git status the fetch reused, the current git fields are kept: a git
+// refresh may have landed while the PRs were fetched.
+func replaceStatuses(statuses map[string]*wt.WorktreeStatus, full []wt.WorktreeStatus, known map[string]wt.WorktreeStatus) map[string]*wt.WorktreeStatus {
+	if statuses == nil {
+		statuses = make(map[string]*wt.WorktreeStatus)
+	}
+	for i := range full {
+		status := &full[i]
+		branch := status.Worktree.Branch
+		if branch == "" {
+			continue
+		}
+		if _, reused := known[status.Worktree.Path]; reused {
+			if cur := statuses[branch]; cur != nil {
+				status.IsDirty = cur.IsDirty
+				status.Ahead = cur.Ahead
+				status.Behind = cur.Behind
+				status.LastCommitTime = cur.LastCommitTime
+				status.LastCommitMsg = cur.LastCommitMsg
+			}
+		}
+		statuses[branch] = status
+	}
+	return statuses
+}
+
 func (m Model) fetchDirtyGitStatuses() tea.Cmd {
 	cmds := make([]tea.Cmd, 0, len(m.repos))
 	for repoName, rc := range m.repos {
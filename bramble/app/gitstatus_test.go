@@ -0,0 +1,60 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestRepoStatusesMsg_ReplacesStatuses(t *testing.T) {
+	worktrees := []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+		{Branch: "merged", Path: "/tmp/wt/merged"},
+	}
+	m := setupModel(t, session.SessionModeTUI, worktrees, "test-repo")
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"merged": {Worktree: worktrees[1], PRNumber: 3, PRState: "OPEN"},
+	}
+
+	newModel, _ := m.Update(repoStatusesMsg{repoName: "test-repo", statuses: []wt.WorktreeStatus{
+		{Worktree: worktrees[0], IsDirty: true, PRNumber: 7, PRState: "OPEN", PRChecksState: wt.ChecksPassing},
+		{Worktree: worktrees[1], Ahead: 2},
+	}})
+	m = newModel.(Model)
+
+	require.Contains(t, m.worktreeStatuses, "feature")
+	feature := m.worktreeStatuses["feature"]
+	assert.True(t, feature.IsDirty)
+	assert.Equal(t, 7, feature.PRNumber)
+	assert.Equal(t, wt.ChecksPassing, feature.PRChecksState)
+	// The PR that is no longer open is cleared along with the rest.
+	assert.Equal(t, 0, m.worktreeStatuses["merged"].PRNumber)
+	assert.Equal(t, 2, m.worktreeStatuses["merged"].Ahead)
+}
+
+func TestRepoStatusesMsg_KeepsGitFieldsOfReusedStatuses(t *testing.T) {
+	worktrees := []wt.Worktree{{Branch: "feature", Path: "/tmp/wt/feature"}}
+	m := setupModel(t, session.SessionModeTUI, worktrees, "test-repo")
+	// The PR fetch reused a clean status, but a git refresh has since seen
+	// the worktree go dirty.
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"feature": {Worktree: worktrees[0], IsDirty: true, Ahead: 1},
+	}
+
+	newModel, _ := m.Update(repoStatusesMsg{
+		repoName: "test-repo",
+		known:    map[string]wt.WorktreeStatus{"/tmp/wt/feature": {Worktree: worktrees[0]}},
+		statuses: []wt.WorktreeStatus{{Worktree: worktrees[0], PRNumber: 7, PRState: "OPEN"}},
+	})
+	m = newModel.(Model)
+
+	feature := m.worktreeStatuses["feature"]
+	require.NotNil(t, feature)
+	assert.True(t, feature.IsDirty)
+	assert.Equal(t, 1, feature.Ahead)
+	assert.Equal(t, 7, feature.PRNumber)
+}
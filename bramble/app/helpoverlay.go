@@ -145,6 +145,14 @@ func (h *HelpOverlay) View(s *Styles) string {
 // buildHelpSections returns help sections appropriate for the given context.
 // It reads the model state to determine which keys are relevant.
 func buildHelpSections(m *Model) []HelpSection {
+	return helpSectionsFor(m, m.helpOverlay.previousFocus)
+}
+
+// helpSectionsFor lists the key bindings relevant to the model's state, with
+// the dropdown or input-mode bindings included when from is that focus. It
+// is the action registry behind both the help overlay and the command
+// palette.
+func helpSectionsFor(m *Model, from FocusArea) []HelpSection {
 	inTmux := m.sessionManager.IsInTmuxMode()
 	hasWorktree := m.selectedWorktree() != nil
 	hasSession := m.viewingSessionID != ""
@@ -193,6 +201,11 @@ func buildHelpSections(m *Model) []HelpSection {
 		sess.Bindings = append(sess.Bindings,
 			HelpBinding{"f", "Resume session"},
 		)
+		if m.viewingHistoryData != nil {
+			sess.Bindings = append(sess.Bindings,
+				HelpBinding{"v", "Revive history session as live (idle)"},
+			)
+		}
 	} else if hasSession && sessIdle && !inTmux {
 		sess.Bindings = append(sess.Bindings,
 			HelpBinding{"f", "Follow-up on idle session"},
@@ -207,11 +220,33 @@ func buildHelpSections(m *Model) []HelpSection {
 		sess.Bindings = append(sess.Bindings,
 			HelpBinding{"s", "Stop session"},
 		)
+		if session.IsInsideTmux() {
+			sess.Bindings = append(sess.Bindings,
+				HelpBinding{"O", "Pop session out into a tmux window that follows it"},
+			)
+		}
+	}
+	if hasSession {
+		sess.Bindings = append(sess.Bindings,
+			HelpBinding{"J", "Jump to the session's worktree"},
+			HelpBinding{"P", "Pin/unpin session to the always-visible strip"},
+		)
+	}
+	if len(m.pinnedSessionInfos()) > 0 {
+		sess.Bindings = append(sess.Bindings,
+			HelpBinding{"Alt-P", "Jump to the next pinned session"},
+		)
 	}
 	sess.Bindings = append(sess.Bindings,
 		HelpBinding{"S", "Show all sessions across worktrees"},
 		HelpBinding{"Alt-C", "Open command center (full-screen dashboard)"},
+		HelpBinding{"$", "Export session costs to cost-report.csv"},
 	)
+	if !inTmux {
+		sess.Bindings = append(sess.Bindings,
+			HelpBinding{"X", "Stop all active sessions"},
+		)
+	}
 	if len(sess.Bindings) > 0 {
 		sections = append(sections, sess)
 	}
@@ -224,6 +259,7 @@ func buildHelpSections(m *Model) []HelpSection {
 	if hasWorktree {
 		wt.Bindings = append(wt.Bindings,
 			HelpBinding{"d", "Delete worktree"},
+			HelpBinding{"N", "Rename worktree"},
 			HelpBinding{"m", "Merge PR"},
 			HelpBinding{"e", "Open in editor"},
 			HelpBinding{"w", "Open tmux window in worktree"},
@@ -263,6 +299,9 @@ func buildHelpSections(m *Model) []HelpSection {
 			HelpBinding{"PgDn", "Scroll down 10 lines"},
 			HelpBinding{"Home", "Scroll to top"},
 			HelpBinding{"End", "Scroll to bottom"},
+			HelpBinding{"/", "Search output (content, tools, tool input)"},
+			HelpBinding{"n/N", "Jump to older/newer match"},
+			HelpBinding{"x", "Jump to next tool error"},
 		)
 		if m.splitPane.IsSplit() {
 			out.Bindings = append(out.Bindings,
@@ -273,8 +312,7 @@ func buildHelpSections(m *Model) []HelpSection {
 	}
 
 	// Dropdown mode bindings (shown if user opened help from dropdown)
-	if m.helpOverlay.previousFocus == FocusWorktreeDropdown ||
-		m.helpOverlay.previousFocus == FocusSessionDropdown {
+	if from == FocusWorktreeDropdown || from == FocusSessionDropdown {
 		dd := HelpSection{Title: "Dropdown"}
 		dd.Bindings = append(dd.Bindings,
 			HelpBinding{"Up/k", "Move selection up"},
@@ -286,14 +324,15 @@ func buildHelpSections(m *Model) []HelpSection {
 	}
 
 	// Input mode bindings (shown if user opened help from input)
-	if m.helpOverlay.previousFocus == FocusInput {
+	if from == FocusInput {
 		inp := HelpSection{Title: "Input Mode"}
 		inp.Bindings = append(inp.Bindings,
 			HelpBinding{"Tab", "Cycle focus (text/send/cancel)"},
 			HelpBinding{"Enter", "Submit prompt (non-empty)"},
 			HelpBinding{"Shift+Enter", "Insert newline"},
 			HelpBinding{"Ctrl+Enter", "Submit (alternative)"},
-			HelpBinding{"Esc", "Cancel input"},
+			HelpBinding{"Up/Down", "Recall earlier prompts in this worktree"},
+			HelpBinding{"Esc", "Back to draft / cancel input"},
 		)
 		sections = append(sections, inp)
 	}
@@ -301,6 +340,7 @@ func buildHelpSections(m *Model) []HelpSection {
 	// General
 	gen := HelpSection{Title: "General"}
 	gen.Bindings = append(gen.Bindings,
+		HelpBinding{"Ctrl+P", "Open command palette (run any action)"},
 		HelpBinding{"Ctrl+L", "Open settings"},
 		HelpBinding{"Esc", "Clear error / close overlay"},
 		HelpBinding{"q", "Quit Bramble"},
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestJumpToSessionWorktree(t *testing.T) {
+	worktrees := []wt.Worktree{
+		{Branch: "main", Path: "/tmp/wt/test-repo/main"},
+		{Branch: "feature", Path: "/tmp/wt/test-repo/feature"},
+	}
+	m := setupModel(t, session.SessionModeTUI, worktrees, "test-repo")
+	m.sessionManager.AddSession(&session.Session{
+		ID:           "s1",
+		WorktreePath: "/tmp/wt/test-repo/feature",
+		WorktreeName: "feature",
+		Status:       session.StatusIdle,
+		Progress:     &session.SessionProgress{},
+	})
+	m.worktreeDropdown.SelectByID("main")
+	m.viewingSessionID = "s1"
+
+	newModel, cmd := m.handleKeyPress(keyPress('J'))
+	m2 := newModel.(Model)
+
+	require.NotNil(t, cmd, "file tree and history are refreshed")
+	assert.Equal(t, "feature", m2.worktreeDropdown.SelectedItem().ID)
+	assert.Equal(t, session.SessionID("s1"), m2.viewingSessionID, "the session stays in view")
+
+	newModel, _ = m2.handleKeyPress(keyPress('J'))
+	assert.Contains(t, newModel.(Model).toasts.toasts[0].Message, "Already on feature")
+}
+
+func TestJumpToSessionWorktreeWithoutSession(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, _ := m.handleKeyPress(keyPress('J'))
+
+	assert.Contains(t, newModel.(Model).toasts.toasts[0].Message, "No session selected")
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+var lastSelectionWorktrees = []wt.Worktree{
+	{Branch: "main", Path: "/tmp/wt/main"},
+	{Branch: "feature", Path: "/tmp/wt/feature"},
+}
+
+func TestLastSelectionRestoredOnLaunch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stubWorktreePathExists(t)
+
+	mgr := session.NewManagerWithConfig(session.ManagerConfig{SessionMode: session.SessionModeTUI})
+	t.Cleanup(mgr.Close)
+	mgr.AddSession(&session.Session{ID: "live", WorktreePath: "/tmp/wt/feature", Status: session.StatusIdle, Progress: &session.SessionProgress{}})
+
+	m := NewModel(context.Background(), "/tmp/wt", "repo", "", mgr, nil, lastSelectionWorktrees, 80, 24, nil, nil, session.ManagerConfig{}, nil)
+	require.Equal(t, "main", m.worktreeDropdown.SelectedItem().ID, "no saved selection selects the first worktree")
+
+	m.worktreeDropdown.SelectByID("feature")
+	m.viewingSessionID = "live"
+	require.NoError(t, m.SaveLastSelection())
+	assert.Equal(t, RepoSelection{Worktree: "feature", Session: "live"}, LoadSettings().LastSelectionFor("repo"))
+
+	m = NewModel(context.Background(), "/tmp/wt", "repo", "", mgr, nil, lastSelectionWorktrees, 80, 24, nil, nil, session.ManagerConfig{}, nil)
+	assert.Equal(t, "feature", m.worktreeDropdown.SelectedItem().ID)
+	assert.Equal(t, session.SessionID("live"), m.viewingSessionID)
+}
+
+func TestLastSelectionRestoredOnFirstWorktreesMsg(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	settings := Settings{}
+	settings.SetLastSelection("repo", RepoSelection{Worktree: "feature", Session: "gone-session"})
+	require.NoError(t, SaveSettings(settings))
+
+	m := setupModel(t, session.SessionModeTUI, nil, "repo")
+	next, _ := m.Update(worktreesMsg{repoName: "repo", worktrees: lastSelectionWorktrees})
+	m = next.(Model)
+	assert.Equal(t, "feature", m.worktreeDropdown.SelectedItem().ID)
+	assert.Empty(t, m.viewingSessionID, "a session that is no longer live is not restored")
+
+	// Later refreshes keep the user's current selection.
+	m.worktreeDropdown.SelectByID("main")
+	next, _ = m.Update(worktreesMsg{repoName: "repo", worktrees: lastSelectionWorktrees})
+	m = next.(Model)
+	assert.Equal(t, "main", m.worktreeDropdown.SelectedItem().ID)
+}
+
+func TestLastSelectionFallsBackWhenWorktreeGone(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	settings := Settings{}
+	settings.SetLastSelection("repo", RepoSelection{Worktree: "deleted"})
+	require.NoError(t, SaveSettings(settings))
+
+	m := setupModel(t, session.SessionModeTUI, lastSelectionWorktrees, "repo")
+	assert.Equal(t, "main", m.worktreeDropdown.SelectedItem().ID)
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+const newOutputLabel = "new since last view"
+
+func addStatusLines(mgr *session.Manager, id session.SessionID, from, to int) {
+	for i := from; i < to; i++ {
+		mgr.AddOutputLine(id, session.OutputLine{
+			Type:    session.OutputTypeStatus,
+			Content: fmt.Sprintf("Line-%03d", i),
+		})
+	}
+}
+
+func TestNewSinceLastViewDivider(t *testing.T) {
+	mgr := session.NewManagerWithConfig(session.ManagerConfig{SessionMode: session.SessionModeTUI})
+	defer mgr.Close()
+	for _, id := range []session.SessionID{"s1", "s2"} {
+		mgr.AddSession(&session.Session{ID: id, Type: session.SessionTypeBuilder, Status: session.StatusRunning, Prompt: "p"})
+		mgr.InitOutputBuffer(id)
+	}
+	addStatusLines(mgr, "s1", 0, 3)
+
+	m := NewModel(context.Background(), "/tmp/wt", "test-repo", "", mgr, nil, nil, 0, 0, nil, nil, session.ManagerConfig{}, nil)
+	m.switchViewingSession("s1")
+	assert.NotContains(t, m.renderCenter(80, 30), newOutputLabel, "a first view has nothing to mark")
+
+	// Leaving at the bottom marks everything seen; output arrives meanwhile.
+	m.switchViewingSession("s2")
+	addStatusLines(mgr, "s1", 3, 5)
+	m.switchViewingSession("s1")
+
+	center := m.renderCenter(80, 30)
+	divider := strings.Index(center, newOutputLabel)
+	require.NotEqual(t, -1, divider, center)
+	assert.Less(t, strings.Index(center, "Line-002"), divider)
+	assert.Less(t, divider, strings.Index(center, "Line-003"))
+
+	// Output arriving while viewing keeps the divider where it was.
+	addStatusLines(mgr, "s1", 5, 6)
+	center = m.renderCenter(80, 30)
+	assert.Less(t, strings.Index(center, newOutputLabel), strings.Index(center, "Line-003"))
+
+	// Scrolling back to the bottom moves the marker past the end.
+	m.scrollOutput(5)
+	m.scrollOutput(-5)
+	assert.NotContains(t, m.renderCenter(80, 30), newOutputLabel)
+	assert.Equal(t, 6, m.lastSeenOutput["s1"])
+}
+
+func TestLeavingScrolledUpKeepsLastSeen(t *testing.T) {
+	mgr := session.NewManagerWithConfig(session.ManagerConfig{SessionMode: session.SessionModeTUI})
+	defer mgr.Close()
+	mgr.AddSession(&session.Session{ID: "s1", Type: session.SessionTypeBuilder, Status: session.StatusRunning})
+	mgr.InitOutputBuffer("s1")
+	addStatusLines(mgr, "s1", 0, 4)
+
+	m := NewModel(context.Background(), "/tmp/wt", "test-repo", "", mgr, nil, nil, 0, 0, nil, nil, session.ManagerConfig{}, nil)
+	m.viewingSessionID = "s1"
+	m.lastSeenOutput["s1"] = 2
+	m.scrollOutput(3)
+	m.switchViewingSession("")
+
+	// The user never reached the bottom, so lines 2.. are still new.
+	assert.Equal(t, 2, m.lastSeenOutput["s1"])
+	assert.Equal(t, 3, m.scrollPositions["s1"])
+}
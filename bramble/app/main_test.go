@@ -0,0 +1,20 @@
+package app
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain points HOME at a scratch directory so tests that start sessions
+// or cycle models, which save settings, never touch the real ~/.bramble.
+// Tests that need specific settings still set their own HOME.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "bramble-app-test-home")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", home)
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
@@ -132,6 +132,92 @@ func TestMergeKey_ShowsConfirmPrompt(t *testing.T) {
 	assert.Contains(t, m2.confirmPrompt.message, "APPROVED")
 }
 
+func TestMergeKey_FailingChecksWarnInPrompt(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+	m.worktreeDropdown.SelectIndex(0)
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"feature": {PRNumber: 42, PRState: "OPEN", PRChecksState: wt.ChecksFailing},
+	}
+
+	m2 := pressKey(m, 'm')
+
+	require.NotNil(t, m2.confirmPrompt)
+	assert.Contains(t, m2.confirmPrompt.message, "CI checks are failing")
+}
+
+func confirmKeys(p *ConfirmPrompt) []string {
+	keys := make([]string, len(p.options))
+	for i, opt := range p.options {
+		keys[i] = opt.Key
+	}
+	return keys
+}
+
+func TestMergeKey_FailingChecksRequireForceKey(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+	m.worktreeDropdown.SelectIndex(0)
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"feature": {
+			PRNumber: 42, PRState: "OPEN", PRChecksState: wt.ChecksFailing,
+			PRFailedChecks: []string{"lint", "test", "e2e", "build"},
+		},
+	}
+
+	m2 := pressKey(m, 'm')
+
+	require.NotNil(t, m2.confirmPrompt)
+	assert.Contains(t, m2.confirmPrompt.message, "CI checks are failing: lint, test, e2e (+1 more)")
+	assert.Equal(t, []string{"a", "F"}, confirmKeys(m2.confirmPrompt),
+		"the plain merge keys must not merge a red PR")
+
+	// The habitual 's' is ignored; only F merges.
+	m3 := pressKey(m2, 's')
+	assert.Equal(t, FocusConfirm, m3.focus)
+
+	cmd := m3.confirmHandler("F")
+	require.NotNil(t, cmd)
+	assert.Equal(t, mergePRMsg{branch: "feature", mergeMethod: "squash"}, cmd())
+}
+
+func TestMergeKey_PendingChecksOfferAutoMerge(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+	m.worktreeDropdown.SelectIndex(0)
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"feature": {PRNumber: 42, PRState: "OPEN", PRChecksState: wt.ChecksPending},
+	}
+
+	m2 := pressKey(m, 'm')
+
+	require.NotNil(t, m2.confirmPrompt)
+	assert.Equal(t, []string{"a", "F"}, confirmKeys(m2.confirmPrompt))
+	cmd := m2.confirmHandler("a")
+	require.NotNil(t, cmd)
+	assert.Equal(t, mergePRMsg{branch: "feature", mergeMethod: "squash", auto: true}, cmd())
+}
+
+func TestMergeKey_RepoSettingAllowsMergeAroundChecks(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+	m.settings.SetRepoSettings("test-repo", RepoSettings{AllowMergeWithFailingChecks: true})
+	m.worktreeDropdown.SelectIndex(0)
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"feature": {PRNumber: 42, PRState: "OPEN", PRChecksState: wt.ChecksFailing},
+	}
+
+	m2 := pressKey(m, 'm')
+
+	require.NotNil(t, m2.confirmPrompt)
+	assert.Contains(t, m2.confirmPrompt.message, "CI checks are failing")
+	assert.Equal(t, []string{"s", "r", "m", "a"}, confirmKeys(m2.confirmPrompt))
+}
+
 func TestMergeKey_DraftPRShownInPrompt(t *testing.T) {
 	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
 		{Branch: "feature", Path: "/tmp/wt/feature"},
@@ -210,6 +296,27 @@ func TestMergePRDone_Success_ShowsPostMergePrompt(t *testing.T) {
 	assert.Contains(t, m2.confirmPrompt.message, "feature")
 }
 
+func TestMergePRDone_ShowsCascadeReport(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+
+	msg := mergePRDoneMsg{
+		branch:        "feature",
+		prNumber:      42,
+		cascade:       "rebased feature-b; 1 failed",
+		cascadeFailed: true,
+	}
+
+	newModel, _ := m.Update(msg)
+	m2 := newModel.(Model)
+
+	assert.NotNil(t, m2.confirmPrompt, "the post-merge prompt still opens")
+	require.True(t, m2.toasts.HasToasts())
+	assert.Equal(t, "Cascade: rebased feature-b; 1 failed", m2.toasts.toasts[0].Message)
+	assert.Equal(t, ToastError, m2.toasts.toasts[0].Level)
+}
+
 func TestPostMergeAction_Keep(t *testing.T) {
 	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
 		{Branch: "feature", Path: "/tmp/wt/feature"},
@@ -225,3 +332,71 @@ func TestPostMergeAction_Keep(t *testing.T) {
 	// No toasts - just a refresh
 	assert.False(t, m2.toasts.HasToasts())
 }
+
+func TestMergePRDone_AutoMergeArmed_SkipsPostMergePrompt(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+
+	msg := mergePRDoneMsg{
+		branch:         "feature",
+		prNumber:       42,
+		autoMergeArmed: true,
+	}
+
+	newModel, cmd := m.Update(msg)
+	m2 := newModel.(Model)
+
+	assert.NotNil(t, cmd)
+	assert.Nil(t, m2.confirmPrompt)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Equal(t, ToastSuccess, m2.toasts.toasts[0].Level)
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Auto-merge enabled for PR #42")
+}
+
+func TestMergeFromAllSessionsOverlay_ShowsConfirmPrompt(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "main", Path: "/tmp/wt/main"},
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+	m.worktreeDropdown.SelectIndex(0)
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"feature": {PRNumber: 42, PRState: "OPEN", PRReviewStatus: "APPROVED"},
+	}
+	m.allSessionsOverlay.Show([]session.SessionInfo{
+		{ID: "s1", Status: session.StatusCompleted, WorktreePath: "/tmp/wt/feature", WorktreeName: "feature"},
+	}, "", m.width, m.height)
+	m.focus = FocusAllSessions
+
+	newModel, _ := m.handleAllSessionsOverlay(keyPress('m'))
+	m2 := newModel.(Model)
+
+	assert.False(t, m2.allSessionsOverlay.IsVisible())
+	assert.Equal(t, FocusConfirm, m2.focus)
+	require.NotNil(t, m2.confirmPrompt)
+	assert.Contains(t, m2.confirmPrompt.message, "Merge PR #42 (feature)")
+	require.NotNil(t, m2.selectedWorktree())
+	assert.Equal(t, "feature", m2.selectedWorktree().Branch)
+}
+
+func TestMergeFromAllSessionsOverlay_NotReadyKeepsOverlay(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "feature", Path: "/tmp/wt/feature"},
+	}, "test-repo")
+	m.worktreeStatuses = map[string]*wt.WorktreeStatus{
+		"feature": {PRNumber: 42, PRState: "OPEN", Ahead: 2},
+	}
+	m.allSessionsOverlay.Show([]session.SessionInfo{
+		{ID: "s1", Status: session.StatusCompleted, WorktreePath: "/tmp/wt/feature", WorktreeName: "feature"},
+	}, "", m.width, m.height)
+	m.focus = FocusAllSessions
+
+	newModel, _ := m.handleAllSessionsOverlay(keyPress('m'))
+	m2 := newModel.(Model)
+
+	assert.True(t, m2.allSessionsOverlay.IsVisible())
+	assert.Equal(t, FocusAllSessions, m2.focus)
+	assert.Nil(t, m2.confirmPrompt)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Can't merge feature: 2 unpushed commits")
+}
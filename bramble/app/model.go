@@ -2,8 +2,13 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,6 +38,8 @@ const (
 	FocusRepoSettings                      // Repo settings overlay open
 	FocusRepoDropdown                      // Alt-R repo dropdown open
 	FocusCommandCenter                     // Command center full-screen view
+	FocusSearch                            // Output search query being typed
+	FocusCommandPalette                    // Ctrl-P command palette open
 )
 
 // Model is the root application model.
@@ -44,6 +51,9 @@ type Model struct { //nolint:govet // fieldalignment: readability over packing
 	confirmPrompt             *ConfirmPrompt
 	worktreeStatuses          map[string]*wt.WorktreeStatus
 	scrollPositions           map[session.SessionID]int
+	lastSeenOutput            map[session.SessionID]int              // output line count last seen at the bottom; see markOutputSeen
+	popOutWindows             map[session.SessionID]string           // tmux window ID following each popped-out session
+	sessionDiffStats          map[session.SessionID]session.DiffStat // changes since start, refreshed when a turn ends
 	viewingHistoryData        *session.StoredSession
 	sessionManager            *session.Manager
 	taskRouter                *taskrouter.Router
@@ -56,12 +66,14 @@ type Model struct { //nolint:govet // fieldalignment: readability over packing
 	providerAvailability      *agent.ProviderAvailability
 	taskModal                 *TaskModal
 	themePicker               *ThemePicker
+	commandPalette            *CommandPalette
 	repoSettingsDialog        *RepoSettingsDialog
 	repos                     map[string]*RepoContext
 	repoDropdown              *Dropdown
 	fileTree                  *FileTree
 	splitPane                 *SplitPane
 	inputArea                 *TextArea
+	inputHistory              *promptHistory // nil when the current input has no history
 	modelRegistry             *agent.ModelRegistry
 	sharedEvents              chan repoSessionEvent
 	sharedGitInvalidates      chan gitWorktreeInvalidation
@@ -70,27 +82,34 @@ type Model struct { //nolint:govet // fieldalignment: readability over packing
 	inputHandler              func(value, model string, sessionType session.SessionType) tea.Cmd
 	sharedManagerConfig       session.ManagerConfig
 	pendingSessionTarget      sessionTarget
+	search                    outputSearch // "/" search over the viewed session's output
+	toolErrorJump             toolErrorJump
+	autoReviews               autoReviews // reviewers started by the auto-review repo setting
 	pendingModel              string
 	repoName                  string
 	historyBranch             string
 	viewingSessionID          session.SessionID
+	pinJumpID                 session.SessionID // pin Alt-P last jumped to in tmux mode, where no session is viewed
 	pendingPlannerPrompt      string
 	pendingWorktreeSelect     string
 	defaultBuildModel         string
 	editor                    string
 	inputPrompt               string
+	inputHistoryKey           string // worktree path the input history belongs to
 	wtRoot                    string
 	pendingSessionType        session.SessionType
 	defaultPlanModel          string
 	defaultCodeTalkModel      string
 	openedRepos               []string
 	resumeRepos               []string
+	pinnedSessions            []session.SessionID // shown in the pin strip whatever is in view; see togglePin
 	cachedHistory             []*session.SessionMeta
 	worktrees                 []wt.Worktree
 	sessions                  []session.SessionInfo
 	worktreeOpMessages        []string
 	scrollOffset              int
 	selectedSessionIndex      int
+	outputFlushInterval       time.Duration // see Settings.OutputFlushMs
 	height                    int
 	width                     int
 	focus                     FocusArea
@@ -130,25 +149,14 @@ func NewModel(ctx context.Context, wtRoot, repoName, editor string, sessionManag
 		palette = p
 	}
 	styles := NewStyles(palette)
+	applyRepoSessionSettings(sessionManager, settings.RepoSettingsFor(repoName))
 
-	// Resolve default models from the registry (prefer claude if available)
-	defaultPlanModel := "opus"
-	defaultCodeTalkModel := "opus"
-	defaultBuildModel := "sonnet"
-	if modelRegistry != nil {
-		if m, ok := modelRegistry.ModelByID("opus"); ok {
-			defaultPlanModel = m.ID
-			defaultCodeTalkModel = m.ID
-		} else if models := modelRegistry.Models(); len(models) > 0 {
-			defaultPlanModel = models[0].ID
-			defaultCodeTalkModel = models[0].ID
-		}
-		if m, ok := modelRegistry.ModelByID("sonnet"); ok {
-			defaultBuildModel = m.ID
-		} else if models := modelRegistry.Models(); len(models) > 0 {
-			defaultBuildModel = models[0].ID
-		}
-	}
+	// Resolve default models: the repo's saved choices when the registry
+	// still offers them, otherwise prefer claude if available.
+	repoCfg := settings.RepoSettingsFor(repoName)
+	defaultPlanModel := pickDefaultModel(modelRegistry, repoCfg.PlanModel, "opus")
+	defaultCodeTalkModel := pickDefaultModel(modelRegistry, repoCfg.CodeTalkModel, "opus")
+	defaultBuildModel := pickDefaultModel(modelRegistry, repoCfg.BuildModel, "sonnet")
 
 	sharedEvents := make(chan repoSessionEvent, 64)
 	sharedGitInvalidates := make(chan gitWorktreeInvalidation, 256)
@@ -172,6 +180,7 @@ func NewModel(ctx context.Context, wtRoot, repoName, editor string, sessionManag
 		settings:             settings,
 		worktreeStatuses:     make(map[string]*wt.WorktreeStatus),
 		themePicker:          NewThemePicker(),
+		commandPalette:       NewCommandPalette(),
 		repoSettingsDialog:   NewRepoSettingsDialog(),
 		focus:                FocusOutput,
 		width:                width,
@@ -190,10 +199,15 @@ func NewModel(ctx context.Context, wtRoot, repoName, editor string, sessionManag
 		splitPane:            NewSplitPane(),
 		fileTree:             NewFileTree("", nil),
 		scrollPositions:      make(map[session.SessionID]int),
+		lastSeenOutput:       make(map[session.SessionID]int),
 		resumeRepos:          resumeRepos,
 		lastUserInputAt:      time.Now(),
+		outputFlushInterval:  settings.OutputFlushInterval(),
 	}
 	sessionManager.SetWorktreeDirtyCallback(makeGitDirtyCallback(sharedGitInvalidates))
+	if notice := providerStartupNotice(providerAvailability, modelRegistry, repoCfg, defaultBuildModel); notice != "" {
+		m.toasts.AddSticky(notice, ToastError)
+	}
 
 	// Sync placeholder colors with the loaded theme (NewTextArea defaults to "245")
 	dimColor := lipgloss.Color(palette.Dim)
@@ -212,7 +226,7 @@ func NewModel(ctx context.Context, wtRoot, repoName, editor string, sessionManag
 	if len(initialWorktrees) > 0 {
 		m.worktrees = initialWorktrees
 		m.updateWorktreeDropdown()
-		m.worktreeDropdown.SelectIndex(0)
+		m.restoreLastSelection()
 		m.updateSessionDropdown()
 	}
 
@@ -226,6 +240,7 @@ func NewModel(ctx context.Context, wtRoot, repoName, editor string, sessionManag
 		worktreeDropdown: m.worktreeDropdown,
 		sessionDropdown:  m.sessionDropdown,
 		scrollPositions:  m.scrollPositions,
+		lastSeenOutput:   m.lastSeenOutput,
 	}
 
 	// Start fan-in goroutine for the initial manager.
@@ -318,18 +333,50 @@ func (m Model) refreshWorktrees() tea.Cmd {
 }
 
 // listenForSessionEvents listens for session events from all opened repos
-// via the shared fan-in channel.
+// via the shared fan-in channel. Output events are coalesced over
+// outputFlushInterval; see coalesceOutputEvents.
 func (m Model) listenForSessionEvents() tea.Cmd {
 	return func() tea.Msg {
 		select {
 		case <-m.ctx.Done():
 			return nil
 		case ev := <-m.sharedEvents:
+			if _, ok := ev.event.(session.SessionOutputEvent); ok && m.outputFlushInterval > 0 {
+				return coalesceOutputEvents(m.ctx, m.sharedEvents, ev, m.outputFlushInterval)
+			}
 			return repoSessionEventMsg{repoName: ev.repoName, event: ev.event}
 		}
 	}
 }
 
+// coalesceOutputEvents gathers the output events arriving within interval
+// of first into one outputBatchMsg, so a fast-streaming session costs one
+// refresh and render per window rather than one per delta. Nothing is lost:
+// the output itself is read back from the manager when rendering. Any other
+// event ends the window early and rides along in the batch, keeping events
+// in order.
+func coalesceOutputEvents(ctx context.Context, events <-chan repoSessionEvent, first repoSessionEvent, interval time.Duration) outputBatchMsg {
+	batch := outputBatchMsg{repoNames: []string{first.repoName}}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return batch
+		case <-timer.C:
+			return batch
+		case ev := <-events:
+			if _, ok := ev.event.(session.SessionOutputEvent); !ok {
+				batch.next = &ev
+				return batch
+			}
+			if !slices.Contains(batch.repoNames, ev.repoName) {
+				batch.repoNames = append(batch.repoNames, ev.repoName)
+			}
+		}
+	}
+}
+
 // fanInEvents forwards events from a single manager to the shared channel.
 func fanInEvents(ctx context.Context, repoName string, mgr *session.Manager, out chan<- repoSessionEvent) {
 	for {
@@ -349,6 +396,51 @@ func fanInEvents(ctx context.Context, repoName string, mgr *session.Manager, out
 	}
 }
 
+// restoreLastSelection selects the worktree and session that were selected
+// in the active repo when Bramble last exited. It falls back to the first
+// worktree when the saved one is gone, and only restores the session while
+// it is still live in that worktree.
+func (m *Model) restoreLastSelection() {
+	sel := m.settings.LastSelectionFor(m.repoName)
+	if sel.Worktree == "" || !m.worktreeDropdown.SelectByID(sel.Worktree) {
+		m.worktreeDropdown.SelectIndex(0)
+		return
+	}
+	if sel.Session == "" || m.viewingSessionID != "" {
+		return
+	}
+	info, ok := m.sessionManager.GetSessionInfo(session.SessionID(sel.Session))
+	if !ok || info.WorktreePath != m.selectedWorktreePath() {
+		return
+	}
+	m.viewingSessionID = info.ID
+	m.scrollOffset = 0
+	m.sessions = m.sessionManager.GetAllSessions()
+}
+
+// SaveLastSelection records the selected worktree and viewed session of
+// every opened repo in settings so the next launch can restore them. It is
+// meant to be called on the final model after the program exits, and
+// re-reads the settings file so it does not clobber changes made by another
+// Bramble instance.
+func (m Model) SaveLastSelection() error {
+	m.saveActiveContext()
+	settings := LoadSettings()
+	for repoName, rc := range m.repos {
+		// A repo whose worktrees never loaded has no meaningful selection;
+		// keep what was saved for it last time.
+		if !rc.worktreesLoaded || rc.worktreeDropdown == nil {
+			continue
+		}
+		sel := RepoSelection{Session: string(rc.viewingSessionID)}
+		if item := rc.worktreeDropdown.SelectedItem(); item != nil {
+			sel.Worktree = item.ID
+		}
+		settings.SetLastSelection(repoName, sel)
+	}
+	return SaveSettings(settings)
+}
+
 // selectedWorktree returns the currently selected worktree.
 func (m *Model) selectedWorktree() *wt.Worktree {
 	item := m.worktreeDropdown.SelectedItem()
@@ -363,6 +455,14 @@ func (m *Model) selectedWorktree() *wt.Worktree {
 	return nil
 }
 
+// selectedWorktreePath returns the path of the selected worktree, or "".
+func (m *Model) selectedWorktreePath() string {
+	if w := m.selectedWorktree(); w != nil {
+		return w.Path
+	}
+	return ""
+}
+
 // selectedSession returns the currently selected/viewing session.
 func (m *Model) selectedSession() *session.SessionInfo {
 	if m.viewingSessionID == "" {
@@ -389,6 +489,51 @@ func (m *Model) aggregateCost() float64 {
 	return total
 }
 
+// exportCostReport writes the current repo's session cost report to
+// WT_ROOT/<repo>/cost-report.csv.
+func (m *Model) exportCostReport() tea.Cmd {
+	mgr := m.sessionManager
+	path := filepath.Join(m.wtRoot, m.repoName, "cost-report.csv")
+	return func() tea.Msg {
+		var buf bytes.Buffer
+		if err := mgr.ExportCostCSV(&buf); err != nil {
+			return costReportMsg{err: err}
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return costReportMsg{err: err}
+		}
+		return costReportMsg{path: path}
+	}
+}
+
+// repoUsage is the running cost/token total across a repo's sessions.
+type repoUsage struct {
+	CostUSD  float64
+	Tokens   int
+	Sessions int
+}
+
+// repoUsage sums cost and tokens over the active repo's live sessions;
+// finished sessions no longer add to the running total. The session count
+// comes from CountByStatus so it matches the status bar counters.
+func (m *Model) repoUsage() repoUsage {
+	var u repoUsage
+	for i := range m.sessions {
+		if m.sessions[i].Status.IsTerminal() {
+			continue
+		}
+		p := &m.sessions[i].Progress
+		u.CostUSD += p.TotalCostUSD
+		u.Tokens += p.InputTokens + p.OutputTokens
+	}
+	for status, n := range m.sessionManager.CountByStatus() {
+		if !status.IsTerminal() {
+			u.Sessions += n
+		}
+	}
+	return u
+}
+
 // currentWorktreeSessions returns sessions for the current worktree.
 func (m *Model) currentWorktreeSessions() []session.SessionInfo {
 	wt := m.selectedWorktree()
@@ -461,11 +606,17 @@ func (m *Model) updateSessionDropdown() {
 		// Type icon
 		icon := sessionTypeEmojiIcon(sess.Type)
 
-		// Status badge
+		// Status badge, then what the session has changed so far
 		badge := statusIcon(sess.Status, m.styles)
+		if diff := m.diffStatBadge(sess.ID); diff != "" {
+			badge += " " + diff
+		}
 
 		// Prefer a human-readable title; fall back to tmux window name, then prompt.
 		label := sess.Title
+		if review := m.reviewLabel(sess.ID); review != "" {
+			label = review
+		}
 		if label == "" {
 			label = sess.TmuxWindowName
 		}
@@ -643,25 +794,35 @@ func (m Model) fetchRepoGitStatuses(repoName string, worktrees []wt.Worktree, sc
 	}
 }
 
-// fetchPRStatuses fetches all open PRs in a single batch API call.
+// fetchFullStatuses fetches every worktree's PR status with one
+// wt.Manager.StatusAll call, which costs a single PR API call. Worktrees
+// that already have a git status reuse it (git status refreshes run on
+// their own tick and on invalidation); only new worktrees are read.
 // Does NOT schedule the next tick — callers must manage timers separately.
-func (m Model) fetchPRStatuses() tea.Cmd {
+func (m Model) fetchFullStatuses() tea.Cmd {
 	if m.repoName == "" || len(m.worktrees) == 0 {
 		return nil
 	}
 	wtRoot := m.wtRoot
 	repoName := m.repoName
 	ctx := m.ctx
-	// Use first worktree's path as working dir for gh CLI (needs a valid Git repo)
-	wtDir := m.worktrees[0].Path
+	// Copy the statuses: the command runs concurrently with Update.
+	known := make(map[string]wt.WorktreeStatus, len(m.worktreeStatuses))
+	for _, status := range m.worktreeStatuses {
+		if status != nil && status.Worktree.Path != "" {
+			known[status.Worktree.Path] = *status
+		}
+	}
 
 	return func() tea.Msg {
 		manager := wt.NewManager(wtRoot, repoName)
-		prs, err := manager.FetchAllPRInfo(ctx, wtDir)
-		if err != nil {
+		statuses, err := manager.StatusAll(ctx, wt.StatusAllOptions{Known: known})
+		// Without PR info the statuses would clear every PR badge; keep the
+		// last known ones until the next refresh.
+		if errors.Is(err, wt.ErrPRInfoUnavailable) || len(statuses) == 0 {
 			return nil
 		}
-		return batchPRInfoMsg{prs: prs, repoName: repoName}
+		return repoStatusesMsg{statuses: statuses, known: known, repoName: repoName}
 	}
 }
 
@@ -714,6 +875,9 @@ func formatWorktreeStatus(ws *wt.WorktreeStatus, sessionCount int, s *Styles) st
 			if ws.PRIsDraft {
 				prText = fmt.Sprintf("PR#%d", ws.PRNumber) + " " + s.Dim.Render("DRAFT")
 			}
+			if checks := prChecksIndicator(ws.PRChecksState, s); checks != "" {
+				prText += " " + checks
+			}
 			if ws.PRReviewStatus == "APPROVED" {
 				prText += " " + s.Completed.Render("✓approved")
 			} else if ws.PRReviewStatus == "CHANGES_REQUESTED" {
@@ -738,6 +902,64 @@ func formatWorktreeStatus(ws *wt.WorktreeStatus, sessionCount int, s *Styles) st
 	return strings.Join(parts, " | ")
 }
 
+// pickDefaultModel returns saved when the registry offers it, else
+// fallback, else the registry's first model. Without a registry the saved
+// model is trusted as is.
+func pickDefaultModel(registry *agent.ModelRegistry, saved, fallback string) string {
+	if registry == nil {
+		if saved != "" {
+			return saved
+		}
+		return fallback
+	}
+	for _, id := range []string{saved, fallback} {
+		if id == "" {
+			continue
+		}
+		if mdl, ok := registry.ModelByID(id); ok {
+			return mdl.ID
+		}
+	}
+	if mdl, ok := registry.FirstAvailableModel(); ok {
+		return mdl.ID
+	}
+	return fallback
+}
+
+// resolveDefaultModels resets the plan, build and codetalk defaults from the
+// current repo's saved models, re-validated against the model registry.
+func (m *Model) resolveDefaultModels() {
+	cfg := m.settings.RepoSettingsFor(m.repoName)
+	m.defaultPlanModel = pickDefaultModel(m.modelRegistry, cfg.PlanModel, "opus")
+	m.defaultCodeTalkModel = pickDefaultModel(m.modelRegistry, cfg.CodeTalkModel, "opus")
+	m.defaultBuildModel = pickDefaultModel(m.modelRegistry, cfg.BuildModel, "sonnet")
+}
+
+// applyRepoSessionSettings pushes the repo settings that affect new sessions
+// (the codex reasoning effort and sandbox) into the repo's session manager.
+func applyRepoSessionSettings(mgr *session.Manager, cfg RepoSettings) {
+	if mgr == nil {
+		return
+	}
+	// cfg is normalized, so the effort and sandbox are always valid levels.
+	_ = mgr.SetCodexEffort(agent.EffortLevel(cfg.CodexEffort))
+	_ = mgr.SetCodexSandbox(cfg.CodexSandbox)
+}
+
+// prChecksIndicator renders a PR's CI state as ● (passing), ◐ (pending) or
+// ✗ (failing); it is empty when the state is unknown.
+func prChecksIndicator(state string, s *Styles) string {
+	switch state {
+	case wt.ChecksPassing:
+		return s.Completed.Render("●")
+	case wt.ChecksPending:
+		return s.Pending.Render("◐")
+	case wt.ChecksFailing:
+		return s.Failed.Render("✗")
+	}
+	return ""
+}
+
 // timeAgo returns a human-readable relative time string.
 func timeAgo(t time.Time) string {
 	d := time.Since(t)
@@ -821,9 +1043,27 @@ type (
 		event    interface{}
 		repoName string
 	}
+	// outputBatchMsg carries the repos that emitted output during one
+	// coalescing window, and the non-output event that ended it, if any.
+	outputBatchMsg struct {
+		next      *repoSessionEvent
+		repoNames []string
+	}
 	// reposLoadedMsg is sent when the available repo list has been loaded.
 	reposLoadedMsg  struct{ repos []string }
 	sessionsUpdated struct{}
+	// sessionRevivedMsg is sent when a history session was revived as live.
+	sessionRevivedMsg struct{ id session.SessionID }
+	// allSessionsStoppedMsg reports the outcome of the stop-all action.
+	allSessionsStoppedMsg struct {
+		errs    []error
+		stopped int
+	}
+	// costReportMsg reports where the cost report was written, or why not.
+	costReportMsg struct {
+		err  error
+		path string
+	}
 	promptInputMsg  struct{ value string }
 	startSessionMsg struct {
 		sessionType session.SessionType
@@ -846,8 +1086,10 @@ type (
 	}
 	// worktreeOpResultMsg contains the result of a worktree operation
 	worktreeOpResultMsg struct {
-		err      error
-		branch   string
+		err    error
+		branch string
+		// summary replaces the generic success toast, e.g. a sync's counts.
+		summary  string
 		warning  string
 		messages []string
 	}
@@ -871,10 +1113,13 @@ type (
 	batchWorktreeStatusMsg struct {
 		statuses []singleWorktreeStatusMsg
 	}
-	// batchPRInfoMsg carries all open PRs fetched in a single API call.
-	batchPRInfoMsg struct {
+	// repoStatusesMsg carries the full git and PR status of a repo's
+	// worktrees, from wt.Manager.StatusAll.
+	repoStatusesMsg struct {
+		// known holds the git statuses the fetch reused, by worktree path.
+		known    map[string]wt.WorktreeStatus
 		repoName string
-		prs      []wt.PRInfo
+		statuses []wt.WorktreeStatus
 	}
 	// fileTreeContextMsg carries gathered worktree context for the file tree
 	fileTreeContextMsg struct {
@@ -903,10 +1148,37 @@ type (
 	// resumeReposMsg triggers auto-opening of repos that have live tmux sessions
 	// from a previous run.
 	resumeReposMsg struct{ repos []string }
-	// deleteWorktreeMsg is sent to delete a worktree
+	// deleteWorktreeMsg is sent to delete a worktree. force discards
+	// uncommitted changes; without it a dirty worktree is left alone and
+	// reported back as a worktreeDirtyMsg.
 	deleteWorktreeMsg struct {
 		branch       string
 		deleteBranch bool
+		force        bool
+	}
+	// worktreeDirtyMsg reports that a worktree about to be deleted has
+	// uncommitted changes, so nothing was removed.
+	worktreeDirtyMsg struct {
+		branch       string
+		deleteBranch bool
+	}
+	// worktreeCleanMsg reports that a worktree about to be deleted has no
+	// uncommitted changes, so it can be removed.
+	worktreeCleanMsg struct {
+		branch       string
+		deleteBranch bool
+	}
+	// renameWorktreeMsg is sent to rename a worktree's branch and directory
+	renameWorktreeMsg struct {
+		branch    string
+		newBranch string
+	}
+	// worktreeRenamedMsg reports the result of a worktree rename
+	worktreeRenamedMsg struct {
+		err       error
+		oldBranch string
+		newBranch string
+		relocated int // sessions re-pointed at the new path
 	}
 	// syncWorktreesMsg is sent to sync all worktrees (fetch + rebase)
 	syncWorktreesMsg struct{}
@@ -914,6 +1186,13 @@ type (
 	syncWorktreeMsg struct {
 		branch string
 	}
+	// popOutMsg reports the tmux window opened (or re-selected) to follow a
+	// session's output.
+	popOutMsg struct {
+		err       error
+		sessionID session.SessionID
+		windowID  string
+	}
 	// tmuxWindowMsg carries the result of opening a new tmux window.
 	tmuxWindowMsg struct {
 		err          error
@@ -927,13 +1206,17 @@ type (
 	mergePRMsg struct {
 		branch      string
 		mergeMethod string // "squash", "rebase", "merge"
+		auto        bool   // arm auto-merge if checks are pending
 	}
 	// mergePRDoneMsg signals merge completed, triggers post-merge prompt.
 	mergePRDoneMsg struct {
-		err      error
-		branch   string
-		messages []string
-		prNumber int
+		err            error
+		branch         string
+		cascade        string // wt.MergeResult.CascadeSummary of child branch handling
+		messages       []string
+		prNumber       int
+		autoMergeArmed bool // merge deferred to GitHub auto-merge
+		cascadeFailed  bool // some child branch could not be rebased or retargeted
 	}
 	// postMergeActionMsg triggers post-merge worktree action.
 	postMergeActionMsg struct {
@@ -963,19 +1246,21 @@ func (m *Model) addToast(message string, level ToastLevel) tea.Cmd {
 	return m.scheduleToastExpiry()
 }
 
-// scheduleToastExpiry schedules a tea.Tick at the earliest toast expiration time.
+// scheduleToastExpiry schedules a tea.Tick at the earliest toast expiration
+// time. Sticky toasts never expire and are ignored.
 func (m *Model) scheduleToastExpiry() tea.Cmd {
-	if !m.toasts.HasToasts() {
-		return nil
-	}
-	// Find the earliest expiration
-	earliest := m.toasts.toasts[0].CreatedAt.Add(m.toasts.toasts[0].Duration)
-	for _, t := range m.toasts.toasts[1:] {
-		exp := t.CreatedAt.Add(t.Duration)
-		if exp.Before(earliest) {
+	var earliest time.Time
+	for _, t := range m.toasts.toasts {
+		if t.Sticky {
+			continue
+		}
+		if exp := t.CreatedAt.Add(t.Duration); earliest.IsZero() || exp.Before(earliest) {
 			earliest = exp
 		}
 	}
+	if earliest.IsZero() {
+		return nil
+	}
 	delay := time.Until(earliest)
 	if delay < 0 {
 		delay = 0
@@ -70,7 +70,7 @@ func TestNewSessionFromOverlay_CrossRepo_UsesTargetManager(t *testing.T) {
 		RepoName:     "repoB",
 		Title:        "Repo B session",
 	})
-	m.allSessionsOverlay.Show([]session.SessionInfo{repoBSess}, m.width, m.height)
+	m.allSessionsOverlay.Show([]session.SessionInfo{repoBSess}, "", m.width, m.height)
 	m.focus = FocusAllSessions
 
 	newModel, _ := m.handleAllSessionsOverlay(keyPress('b'))
@@ -185,7 +185,7 @@ func TestNewSessionFromOverlay_SameRepo_SyncsWorktreeDropdown(t *testing.T) {
 		RepoName:     "test-repo",
 		Title:        "Feature session",
 	})
-	m.allSessionsOverlay.Show([]session.SessionInfo{sess}, m.width, m.height)
+	m.allSessionsOverlay.Show([]session.SessionInfo{sess}, "", m.width, m.height)
 	m.focus = FocusAllSessions
 
 	newModel, _ := m.handleAllSessionsOverlay(keyPress('b'))
@@ -258,7 +258,7 @@ func TestNewSessionFromOverlay_CrossRepoColdContext_ShowsSelectedSessionWorktree
 		RepoName:     "repoB",
 		Title:        "Repo B session",
 	})
-	m.allSessionsOverlay.Show([]session.SessionInfo{repoBSess}, m.width, m.height)
+	m.allSessionsOverlay.Show([]session.SessionInfo{repoBSess}, "", m.width, m.height)
 	m.focus = FocusAllSessions
 
 	newModel, _ := m.handleAllSessionsOverlay(keyPress('b'))
@@ -132,7 +132,7 @@ func TestAllSessionsOverlayNewSession_UsesCapturedSessionWorktreeAfterSelectionC
 			RepoName:     "repoA",
 		},
 	}
-	m.allSessionsOverlay.Show(sessions, m.width, m.height)
+	m.allSessionsOverlay.Show(sessions, "", m.width, m.height)
 	m.focus = FocusAllSessions
 
 	newModel, _ := m.handleAllSessionsOverlay(keyPress('b'))
@@ -174,9 +174,8 @@ func formatOutputLineWithStyles(line session.OutputLine, width int, s *Styles, m
 			durationStr := fmt.Sprintf("%.2fs", float64(line.DurationMs)/1000)
 			formatted = "✓ " + s.Dim.Render(toolDisplay+" ("+durationStr+")")
 		case session.ToolStateError:
-			// Show error indicator with duration
-			durationStr := fmt.Sprintf("%.2fs", float64(line.DurationMs)/1000)
-			formatted = s.Error.Render("✗ " + toolDisplay + " (" + durationStr + ")")
+			// Show a card with the tool and the tail of its error output
+			formatted = formatToolErrorCard(&line, width, "", s)
 		default:
 			// Fallback for legacy or unset state
 			formatted = "🔧 " + toolDisplay
@@ -206,8 +205,8 @@ func formatOutputLineWithStyles(line session.OutputLine, width int, s *Styles, m
 		formatted = line.Content
 	}
 
-	// Truncate if needed (skip for multi-line content: markdown text, thinking, plan)
-	if line.Type != session.OutputTypeText && line.Type != session.OutputTypePlanReady && line.Type != session.OutputTypeThinking && runewidth.StringWidth(stripAnsi(formatted)) > width-2 {
+	// Truncate if needed (skip for multi-line content: markdown text, thinking, plan, tool error cards)
+	if line.Type != session.OutputTypeText && line.Type != session.OutputTypePlanReady && line.Type != session.OutputTypeThinking && !isToolError(&line) && runewidth.StringWidth(stripAnsi(formatted)) > width-2 {
 		formatted = truncateVisual(formatted, width-2)
 	}
 
@@ -363,6 +363,13 @@ func TestFormatToolDisplay(t *testing.T) {
 			maxLen:   60,
 			want:     "[Task] Search for files",
 		},
+		{
+			name:     "web_search shows query",
+			toolName: "web_search",
+			input:    map[string]interface{}{"query": "golang generics"},
+			maxLen:   60,
+			want:     "🔎 web_search: golang generics",
+		},
 		{
 			name:     "nil input shows tool name only",
 			toolName: "Read",
@@ -974,6 +981,24 @@ func TestFormatWorktreeStatus(t *testing.T) {
 			},
 			want: []string{"clean", "PR#123 OPEN"},
 		},
+		{
+			name: "PR with failing checks",
+			status: &wt.WorktreeStatus{
+				PRNumber:      7,
+				PRState:       "OPEN",
+				PRChecksState: wt.ChecksFailing,
+			},
+			want: []string{"PR#7 OPEN ✗"},
+		},
+		{
+			name: "PR with pending checks",
+			status: &wt.WorktreeStatus{
+				PRNumber:      8,
+				PRState:       "OPEN",
+				PRChecksState: wt.ChecksPending,
+			},
+			want: []string{"PR#8 OPEN ◐"},
+		},
 		{
 			name: "dirty only",
 			status: &wt.WorktreeStatus{
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+func TestCoalesceOutputEvents_BatchesUntilNonOutputEvent(t *testing.T) {
+	events := make(chan repoSessionEvent, 8)
+	output := func(repo string) repoSessionEvent {
+		return repoSessionEvent{repoName: repo, event: session.SessionOutputEvent{SessionID: "s1"}}
+	}
+	events <- output("a")
+	events <- output("b")
+	events <- output("a")
+	state := session.SessionStateChangeEvent{SessionID: "s1", NewStatus: session.StatusIdle}
+	events <- repoSessionEvent{repoName: "a", event: state}
+	events <- output("c")
+
+	batch := coalesceOutputEvents(context.Background(), events, output("a"), time.Minute)
+
+	assert.Equal(t, []string{"a", "b"}, batch.repoNames)
+	require.NotNil(t, batch.next, "the state change should end the window")
+	assert.Equal(t, state, batch.next.event)
+	assert.Len(t, events, 1, "events after the window stay queued")
+}
+
+func TestCoalesceOutputEvents_FlushesAfterInterval(t *testing.T) {
+	events := make(chan repoSessionEvent, 1)
+	first := repoSessionEvent{repoName: "a", event: session.SessionOutputEvent{SessionID: "s1"}}
+
+	start := time.Now()
+	batch := coalesceOutputEvents(context.Background(), events, first, 10*time.Millisecond)
+
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	assert.Equal(t, []string{"a"}, batch.repoNames)
+	assert.Nil(t, batch.next)
+}
+
+func TestOutputBatchMsg_RefreshesSessionsAndHandlesTrailingEvent(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	m.sessions = []session.SessionInfo{{ID: "stale"}}
+
+	newModel, cmd := m.Update(outputBatchMsg{repoNames: []string{"test-repo"}})
+	m = newModel.(Model)
+	assert.Empty(t, m.sessions, "the batch should reload the session list")
+	assert.NotNil(t, cmd, "the listener should be re-armed")
+
+	// The event that ended the window is handled like any other.
+	newModel, _ = m.Update(outputBatchMsg{
+		repoNames: []string{"test-repo"},
+		next: &repoSessionEvent{repoName: "test-repo", event: session.SessionStateChangeEvent{
+			SessionID: "s1", NewStatus: session.StatusWaitingApproval,
+		}},
+	})
+	m = newModel.(Model)
+	require.True(t, m.toasts.HasToasts())
+	assert.Contains(t, m.toasts.toasts[0].Message, "waiting for command approval")
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// maxPinnedSessions bounds the pin strip so it stays a small monitoring aid
+// rather than a second session list.
+const maxPinnedSessions = 3
+
+// togglePin pins the selected session to the strip above the status bar,
+// or unpins it if it is already pinned. A pinned session stays in the strip
+// whichever worktree, session, or repo is in view.
+func (m Model) togglePin() (tea.Model, tea.Cmd) {
+	sess := m.sessionToPin()
+	if sess == nil {
+		toastCmd := m.addToast("No session selected", ToastInfo)
+		return m, toastCmd
+	}
+	if i := slices.Index(m.pinnedSessions, sess.ID); i >= 0 {
+		m.pinnedSessions = slices.Delete(slices.Clone(m.pinnedSessions), i, i+1)
+		toastCmd := m.addToast("Unpinned "+pinLabel(sess), ToastInfo)
+		return m, toastCmd
+	}
+	// Pins of sessions that have since been deleted don't count against
+	// the limit.
+	m.pinnedSessions = m.livePinnedSessions()
+	if len(m.pinnedSessions) >= maxPinnedSessions {
+		toastCmd := m.addToast(fmt.Sprintf("At most %d sessions can be pinned; unpin one first", maxPinnedSessions), ToastInfo)
+		return m, toastCmd
+	}
+	m.pinnedSessions = append(slices.Clone(m.pinnedSessions), sess.ID)
+	toastCmd := m.addToast("Pinned "+pinLabel(sess)+" (Alt-P to jump to it)", ToastSuccess)
+	return m, toastCmd
+}
+
+// sessionToPin returns the session P acts on: the viewed session, or in tmux
+// mode the one highlighted in the session list. History sessions have no
+// live output to follow and cannot be pinned.
+func (m *Model) sessionToPin() *session.SessionInfo {
+	if m.sessionManager.IsInTmuxMode() {
+		sessions := m.visibleSessions()
+		if m.selectedSessionIndex < 0 || m.selectedSessionIndex >= len(sessions) {
+			return nil
+		}
+		return &sessions[m.selectedSessionIndex]
+	}
+	if m.viewingSessionID == "" {
+		return nil
+	}
+	if info, ok := m.sessionManager.GetSessionInfo(m.viewingSessionID); ok {
+		return &info
+	}
+	return nil
+}
+
+// pinnedSessionInfos looks up the pinned sessions across every opened repo,
+// in pin order. Sessions that no longer exist are left out.
+func (m *Model) pinnedSessionInfos() []session.SessionInfo {
+	if len(m.pinnedSessions) == 0 {
+		return nil
+	}
+	managers := m.allSessionManagers()
+	infos := make([]session.SessionInfo, 0, len(m.pinnedSessions))
+	for _, id := range m.pinnedSessions {
+		for _, mgr := range managers {
+			if info, ok := mgr.GetSessionInfo(id); ok {
+				if info.RepoName == "" {
+					info.RepoName = mgr.RepoName()
+				}
+				infos = append(infos, info)
+				break
+			}
+		}
+	}
+	return infos
+}
+
+// livePinnedSessions returns the pinned session IDs that still exist, in
+// pin order.
+func (m *Model) livePinnedSessions() []session.SessionID {
+	infos := m.pinnedSessionInfos()
+	ids := make([]session.SessionID, len(infos))
+	for i := range infos {
+		ids[i] = infos[i].ID
+	}
+	return ids
+}
+
+// jumpToPinnedSession switches to the pinned session after the one in view,
+// so repeated presses cycle through the pins. In tmux mode nothing is viewed
+// in bramble, so the cycle continues from the pin last jumped to.
+func (m Model) jumpToPinnedSession() (tea.Model, tea.Cmd) {
+	pinned := m.pinnedSessionInfos()
+	if len(pinned) == 0 {
+		toastCmd := m.addToast("No pinned sessions (P to pin one)", ToastInfo)
+		return m, toastCmd
+	}
+	current := m.viewingSessionID
+	if m.sessionManager.IsInTmuxMode() {
+		current = m.pinJumpID
+	}
+	next := 0
+	for i := range pinned {
+		if pinned[i].ID == current {
+			next = (i + 1) % len(pinned)
+			break
+		}
+	}
+	if m.sessionManager.IsInTmuxMode() {
+		m.pinJumpID = pinned[next].ID
+	}
+	return m.switchToSession(&pinned[next])
+}
+
+// pinStripHeight returns the number of lines the pin strip occupies.
+func (m Model) pinStripHeight() int {
+	return len(m.pinnedSessionInfos())
+}
+
+// renderPinStrip renders one line per pinned session: status, name, cost,
+// and the session's latest output line.
+func (m Model) renderPinStrip(width int) string {
+	pinned := m.pinnedSessionInfos()
+	if len(pinned) == 0 {
+		return ""
+	}
+	lines := make([]string, len(pinned))
+	for i := range pinned {
+		sess := &pinned[i]
+		marker, style := "  ", m.styles.Dim
+		if sess.ID == m.viewingSessionID {
+			marker, style = "▸ ", m.styles.Title
+		}
+		line := fmt.Sprintf("%spin %s %s  $%.4f", marker, statusIconPlain(sess.Status), pinLabel(sess), sess.Progress.TotalCostUSD)
+		if last := pinLastLine(sess); last != "" {
+			line += "  " + last
+		}
+		lines[i] = style.Render(truncate(line, width))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// pinLabel names a session by its worktree and title.
+func pinLabel(sess *session.SessionInfo) string {
+	title := sess.Title
+	if title == "" {
+		title = truncateSessionID(sess.ID)
+	}
+	if sess.WorktreeName == "" {
+		return title
+	}
+	return sess.WorktreeName + ": " + title
+}
+
+// pinLastLine returns the most recent thing a pinned session did: its
+// latest output line, falling back to the running tool or phase.
+func pinLastLine(sess *session.SessionInfo) string {
+	if n := len(sess.Progress.RecentOutput); n > 0 {
+		last, _, _ := strings.Cut(strings.TrimSpace(sess.Progress.RecentOutput[n-1]), "\n")
+		return last
+	}
+	switch {
+	case sess.Progress.CurrentTool != "":
+		return "[" + sess.Progress.CurrentTool + "]"
+	case sess.Progress.CurrentPhase != "":
+		return sess.Progress.CurrentPhase
+	default:
+		return statusText(sess.Status)
+	}
+}
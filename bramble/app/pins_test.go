@@ -0,0 +1,158 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+// setupPinModel returns a model viewing the first of n live sessions.
+func setupPinModel(t *testing.T, n int) (Model, []session.SessionID) {
+	t.Helper()
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "main", Path: "/tmp/wt/main"},
+	}, "test-repo")
+	m.worktreeDropdown.SelectIndex(0)
+
+	ids := make([]session.SessionID, n)
+	for i := range ids {
+		id, err := m.sessionManager.StartSession(session.SessionTypePlanner, "/tmp/wt/main", "prompt", "")
+		require.NoError(t, err)
+		ids[i] = id
+	}
+	m.sessions = m.sessionManager.GetAllSessions()
+	m.updateSessionDropdown()
+	m.viewingSessionID = ids[0]
+	return m, ids
+}
+
+func TestTogglePin_PinsAndUnpinsViewedSession(t *testing.T) {
+	m, ids := setupPinModel(t, 1)
+
+	newModel, _ := m.handleKeyPress(keyPress('P'))
+	m2 := newModel.(Model)
+	assert.Equal(t, []session.SessionID{ids[0]}, m2.pinnedSessions)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Pinned")
+
+	newModel, _ = m2.handleKeyPress(keyPress('P'))
+	m3 := newModel.(Model)
+	assert.Empty(t, m3.pinnedSessions)
+	assert.Empty(t, m3.renderPinStrip(80))
+}
+
+func TestTogglePin_NoSession(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, _ := m.handleKeyPress(keyPress('P'))
+	m2 := newModel.(Model)
+	assert.Empty(t, m2.pinnedSessions)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "No session selected")
+}
+
+func TestTogglePin_EnforcesLimit(t *testing.T) {
+	m, ids := setupPinModel(t, maxPinnedSessions+1)
+	m.pinnedSessions = ids[1:]
+	m.viewingSessionID = ids[0]
+
+	newModel, _ := m.handleKeyPress(keyPress('P'))
+	m2 := newModel.(Model)
+	assert.Equal(t, ids[1:], m2.pinnedSessions)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "unpin one first")
+}
+
+func TestTogglePin_DeletedSessionsFreeTheirSlot(t *testing.T) {
+	m, ids := setupPinModel(t, maxPinnedSessions)
+	m.pinnedSessions = append([]session.SessionID{"gone"}, ids[1:]...)
+	m.viewingSessionID = ids[0]
+
+	newModel, _ := m.handleKeyPress(keyPress('P'))
+	m2 := newModel.(Model)
+	assert.Equal(t, append(ids[1:], ids[0]), m2.pinnedSessions)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Pinned")
+}
+
+func TestPinStrip_StaysVisibleWhileViewingAnotherSession(t *testing.T) {
+	m, ids := setupPinModel(t, 2)
+	m.pinnedSessions = []session.SessionID{ids[0]}
+	m.viewingSessionID = ids[1]
+
+	strip := m.renderPinStrip(80)
+	assert.Contains(t, strip, "pin ")
+	assert.Contains(t, strip, "main: ")
+	assert.Equal(t, 1, m.pinStripHeight())
+
+	// The strip takes its lines from the center pane.
+	withPin, _ := m.layoutHeights()
+	m.pinnedSessions = nil
+	withoutPin, _ := m.layoutHeights()
+	assert.Equal(t, withoutPin-1, withPin)
+}
+
+func TestPinStrip_DropsSessionsThatNoLongerExist(t *testing.T) {
+	m, ids := setupPinModel(t, 1)
+	m.pinnedSessions = []session.SessionID{"gone", ids[0]}
+
+	infos := m.pinnedSessionInfos()
+	require.Len(t, infos, 1)
+	assert.Equal(t, ids[0], infos[0].ID)
+}
+
+func TestJumpToPinnedSession_CyclesThroughPins(t *testing.T) {
+	m, ids := setupPinModel(t, 3)
+	m.pinnedSessions = []session.SessionID{ids[1], ids[2]}
+	m.viewingSessionID = ids[0]
+
+	altP := tea.KeyPressMsg{Code: 'p', Mod: tea.ModAlt}
+	newModel, _ := m.handleKeyPress(altP)
+	m2 := newModel.(Model)
+	assert.Equal(t, ids[1], m2.viewingSessionID)
+
+	newModel, _ = m2.handleKeyPress(altP)
+	m3 := newModel.(Model)
+	assert.Equal(t, ids[2], m3.viewingSessionID)
+
+	newModel, _ = m3.handleKeyPress(altP)
+	m4 := newModel.(Model)
+	assert.Equal(t, ids[1], m4.viewingSessionID)
+}
+
+func TestJumpToPinnedSession_CyclesInTmuxMode(t *testing.T) {
+	m := setupModel(t, session.SessionModeTmux, nil, "test-repo")
+	ids := []session.SessionID{"s1", "s2", "s3"}
+	for i, id := range ids {
+		m.sessionManager.AddSession(&session.Session{
+			ID: id, Status: session.StatusRunning, Progress: &session.SessionProgress{},
+			TmuxWindowID: fmt.Sprintf("@%d", i+1),
+		})
+	}
+	m.pinnedSessions = []session.SessionID{ids[1], ids[2]}
+
+	altP := tea.KeyPressMsg{Code: 'p', Mod: tea.ModAlt}
+	var jumped []session.SessionID
+	for range 3 {
+		newModel, cmd := m.handleKeyPress(altP)
+		m = newModel.(Model)
+		require.NotNil(t, cmd, "Alt-P selects the pinned session's tmux window")
+		jumped = append(jumped, m.pinJumpID)
+	}
+	assert.Equal(t, []session.SessionID{ids[1], ids[2], ids[1]}, jumped)
+}
+
+func TestJumpToPinnedSession_NoPins(t *testing.T) {
+	m, _ := setupPinModel(t, 1)
+
+	newModel, _ := m.handleKeyPress(tea.KeyPressMsg{Code: 'p', Mod: tea.ModAlt})
+	m2 := newModel.(Model)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "No pinned sessions")
+}
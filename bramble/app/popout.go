@@ -0,0 +1,79 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bazelment/yoloswe/bramble/ipc"
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// popOutSession opens a tmux window that follows the viewed live session's
+// output with "bramble follow-session". The session keeps running in the
+// TUI; the window is only a viewer. Popping out the same session again
+// selects its existing window instead of opening another.
+//
+// The window is not registered with TrackTmuxWindow: that is for tmux-mode
+// sessions and would list the viewer as a second session.
+func (m Model) popOutSession() (tea.Model, tea.Cmd) {
+	if m.sessionManager.IsInTmuxMode() {
+		toastCmd := m.addToast("Sessions already run in their own tmux windows", ToastInfo)
+		return m, toastCmd
+	}
+	if !session.IsInsideTmux() || !session.IsTmuxAvailable() {
+		toastCmd := m.addToast("Not inside tmux", ToastInfo)
+		return m, toastCmd
+	}
+	sess := m.selectedSession()
+	if sess == nil || sess.Status.IsTerminal() || m.viewingHistoryData != nil {
+		toastCmd := m.addToast("No live session to pop out (Alt-S to select)", ToastInfo)
+		return m, toastCmd
+	}
+	sock := m.sessionManager.IPCSockPath()
+	if sock == "" {
+		toastCmd := m.addToast("Bramble IPC socket is not running; cannot follow the session", ToastError)
+		return m, toastCmd
+	}
+
+	sessID := sess.ID
+	dir := sess.WorktreePath
+	existing := m.popOutWindows[sessID]
+	return m, func() tea.Msg {
+		if existing != "" {
+			// Reuse the viewer unless it has been closed since.
+			if err := exec.Command("tmux", "select-window", "-t", existing).Run(); err == nil {
+				return popOutMsg{sessionID: sessID, windowID: existing}
+			}
+		}
+		self, err := os.Executable()
+		if err != nil {
+			return popOutMsg{sessionID: sessID, err: err}
+		}
+		out, err := exec.Command("tmux", followSessionTmuxArgs(self, sock, dir, sessID)...).CombinedOutput()
+		if err != nil {
+			if detail := strings.TrimSpace(string(out)); detail != "" {
+				return popOutMsg{sessionID: sessID, err: fmt.Errorf("%w: %s", err, detail)}
+			}
+			return popOutMsg{sessionID: sessID, err: err}
+		}
+		return popOutMsg{sessionID: sessID, windowID: strings.TrimSpace(string(out))}
+	}
+}
+
+// followSessionTmuxArgs returns the tmux arguments that open a window in dir
+// running "<bramble> follow-session" for id, printing the new window's ID.
+func followSessionTmuxArgs(bramble, sock, dir string, id session.SessionID) []string {
+	name := "follow-" + string(id)
+	if len(name) > 24 {
+		name = name[:24]
+	}
+	args := []string{"new-window", "-e", ipc.SockEnvVar + "=" + sock, "-n", name}
+	if dir != "" {
+		args = append(args, "-c", dir)
+	}
+	return append(args, "-P", "-F", "#{window_id}", bramble, "follow-session", "--session-id", string(id))
+}
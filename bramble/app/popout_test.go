@@ -0,0 +1,63 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+func TestPopOut_TmuxModeAlreadyHasWindows(t *testing.T) {
+	m := setupModel(t, session.SessionModeTmux, nil, "test-repo")
+
+	m2 := pressKey(m, 'O')
+
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "own tmux windows")
+}
+
+func TestPopOut_NotInsideTmux(t *testing.T) {
+	t.Setenv("TMUX", "")
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	m2 := pressKey(m, 'O')
+
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Not inside tmux")
+}
+
+func TestPopOutMsg_RemembersWindow(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, _ := m.Update(popOutMsg{sessionID: "sess-1", windowID: "@7"})
+	m2 := newModel.(Model)
+
+	assert.Equal(t, "@7", m2.popOutWindows["sess-1"])
+}
+
+func TestPopOutMsg_ErrorToast(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, _ := m.Update(popOutMsg{sessionID: "sess-1", err: assert.AnError})
+	m2 := newModel.(Model)
+
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Failed to pop out session")
+	assert.Empty(t, m2.popOutWindows)
+}
+
+func TestFollowSessionTmuxArgs(t *testing.T) {
+	args := followSessionTmuxArgs("/usr/bin/bramble", "/tmp/b.sock", "/wt/feature", "abcdef0123456789abcdef0123")
+
+	assert.Equal(t, []string{
+		"new-window", "-e", "BRAMBLE_SOCK=/tmp/b.sock", "-n", "follow-abcdef0123456789a",
+		"-c", "/wt/feature",
+		"-P", "-F", "#{window_id}",
+		"/usr/bin/bramble", "follow-session", "--session-id", "abcdef0123456789abcdef0123",
+	}, args)
+
+	noDir := followSessionTmuxArgs("bramble", "/s", "", "x")
+	assert.NotContains(t, noDir, "-c")
+}
@@ -0,0 +1,57 @@
+package app
+
+// maxPromptHistory caps how many submitted prompts are kept per worktree.
+const maxPromptHistory = 50
+
+// promptHistory is a shell-style cursor over previously submitted prompts.
+// Entries are ordered oldest to newest; pos == len(entries) means the user
+// is editing their own draft rather than a recalled entry.
+type promptHistory struct {
+	draft   string
+	entries []string
+	pos     int
+}
+
+func newPromptHistory(entries []string) *promptHistory {
+	return &promptHistory{
+		entries: append([]string(nil), entries...),
+		pos:     len(entries),
+	}
+}
+
+// Prev steps back to the next-older entry. current is the text area content,
+// saved as the draft when leaving it. Returns false at the oldest entry.
+func (h *promptHistory) Prev(current string) (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	if h.pos == len(h.entries) {
+		h.draft = current
+	}
+	h.pos--
+	return h.entries[h.pos], true
+}
+
+// Next steps forward to the next-newer entry, ending at the saved draft.
+// Returns false when already on the draft.
+func (h *promptHistory) Next() (string, bool) {
+	if h.pos >= len(h.entries) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.pos], true
+}
+
+// Recalling reports whether a history entry (not the draft) is shown.
+func (h *promptHistory) Recalling() bool {
+	return h.pos < len(h.entries)
+}
+
+// Restore leaves history navigation and returns the saved draft.
+func (h *promptHistory) Restore() string {
+	h.pos = len(h.entries)
+	return h.draft
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+func TestPromptHistoryNavigation(t *testing.T) {
+	h := newPromptHistory([]string{"one", "two"})
+	assert.False(t, h.Recalling())
+
+	_, ok := h.Next()
+	assert.False(t, ok, "Next on the draft does nothing")
+
+	v, ok := h.Prev("draft")
+	require.True(t, ok)
+	assert.Equal(t, "two", v)
+	v, _ = h.Prev("ignored")
+	assert.Equal(t, "one", v)
+	_, ok = h.Prev("ignored")
+	assert.False(t, ok, "Prev stops at the oldest entry")
+	assert.True(t, h.Recalling())
+
+	v, _ = h.Next()
+	assert.Equal(t, "two", v)
+	v, _ = h.Next()
+	assert.Equal(t, "draft", v)
+	assert.False(t, h.Recalling())
+
+	h.Prev("draft")
+	assert.Equal(t, "draft", h.Restore())
+	assert.False(t, h.Recalling())
+}
+
+func TestInputModeRecallsWorktreeHistory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m := NewModel(context.Background(), "", "", "", session.NewManager(), nil, nil, 80, 24, nil, nil, session.ManagerConfig{}, nil)
+	m.settings.PromptHistory = map[string][]string{"/wt/a": {"old", "newer"}}
+
+	var submitted string
+	handler := func(value, _ string, _ session.SessionType) tea.Cmd {
+		submitted = value
+		return nil
+	}
+	next, _ := m.promptInputWithHistory("/wt/a", "Follow-up: ", handler)
+	m = next.(Model)
+	m.inputArea.SetValue("draft")
+
+	press := func(code rune) {
+		t.Helper()
+		next, _ := m.handleInputMode(specialKey(code))
+		m = next.(Model)
+	}
+
+	press(tea.KeyUp)
+	assert.Equal(t, "newer", m.inputArea.Value())
+	press(tea.KeyUp)
+	assert.Equal(t, "old", m.inputArea.Value())
+	press(tea.KeyDown)
+	assert.Equal(t, "newer", m.inputArea.Value())
+
+	// Esc returns to the draft without leaving input mode.
+	press(tea.KeyEscape)
+	assert.Equal(t, "draft", m.inputArea.Value())
+	assert.True(t, m.inputMode)
+
+	next, cmd := m.handleInputMode(specialKey(tea.KeyEnter))
+	m = next.(Model)
+	require.NotNil(t, cmd)
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	assert.Equal(t, "draft", submitted)
+	assert.Nil(t, m.inputHistory, "history is cleared with the prompt")
+	assert.Equal(t, []string{"old", "newer", "draft"}, m.settings.PromptHistoryFor("/wt/a"))
+
+	// The submitted prompt is persisted for the next launch.
+	_, err := os.Stat(filepath.Join(home, ".bramble", "settings.json"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old", "newer", "draft"}, LoadSettings().PromptHistoryFor("/wt/a"))
+}
+
+func TestInputModeWithoutHistoryKeepsArrowKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := NewModel(context.Background(), "", "", "", session.NewManager(), nil, nil, 80, 24, nil, nil, session.ManagerConfig{}, nil)
+	next, _ := m.promptInput("Branch name: ", func(string, string, session.SessionType) tea.Cmd { return nil })
+	m = next.(Model)
+	m.inputArea.SetValue("feature")
+
+	next, _ = m.handleInputMode(specialKey(tea.KeyUp))
+	m = next.(Model)
+	assert.Equal(t, "feature", m.inputArea.Value())
+
+	next, _ = m.handleInputMode(specialKey(tea.KeyEscape))
+	m = next.(Model)
+	assert.False(t, m.inputMode, "Esc cancels input when nothing is recalled")
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
+)
+
+// providerInstallHints is how to install each provider's CLI, shown when the
+// default model's provider is missing.
+var providerInstallHints = map[string]string{
+	agent.ProviderClaude: "npm install -g @anthropic-ai/claude-code",
+	agent.ProviderCodex:  "npm install -g @openai/codex",
+	agent.ProviderGemini: "npm install -g @google/gemini-cli",
+}
+
+// providerStartupNotice explains, once at startup, why the default models
+// can't run: the providers of the repo's saved (or built-in) plan, build and
+// codetalk models that are missing or disabled, how to install them, and
+// which model is used instead. picked is the build model actually selected.
+// Returns "" when every default model's provider is usable.
+func providerStartupNotice(availability *agent.ProviderAvailability, registry *agent.ModelRegistry, cfg RepoSettings, picked string) string {
+	if availability == nil || registry == nil {
+		return ""
+	}
+
+	var missing []string
+	wanted := []string{cmp.Or(cfg.PlanModel, "opus"), cmp.Or(cfg.BuildModel, "sonnet"), cmp.Or(cfg.CodeTalkModel, "opus")}
+	for _, id := range wanted {
+		if _, ok := registry.ModelByID(id); ok {
+			continue
+		}
+		if provider, ok := agent.ProviderForModelID(id); ok && !slices.Contains(missing, provider) {
+			missing = append(missing, provider)
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+
+	var problems []string
+	for _, provider := range missing {
+		switch {
+		case availability.IsInstalled(provider):
+			problems = append(problems, provider+" is disabled in settings")
+		case providerInstallHints[provider] != "":
+			problems = append(problems, fmt.Sprintf("%s not found in PATH (install: %s)", provider, providerInstallHints[provider]))
+		default:
+			problems = append(problems, provider+" not found in PATH")
+		}
+	}
+	notice := strings.Join(problems, "; ")
+
+	if _, ok := registry.FirstAvailableModel(); !ok {
+		return notice + ". No agent provider is available, so sessions cannot start."
+	}
+	return fmt.Sprintf("%s. Using %s instead.", notice, picked)
+}
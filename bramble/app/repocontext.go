@@ -20,6 +20,7 @@ type RepoContext struct {
 	taskRouter           *taskrouter.Router
 	fsWatcher            *fsnotify.Watcher
 	scrollPositions      map[session.SessionID]int
+	lastSeenOutput       map[session.SessionID]int
 	worktreeStatuses     map[string]*wt.WorktreeStatus
 	dirtyWorktrees       map[string]struct{}
 	watchedGitPaths      map[string]string
@@ -83,6 +84,7 @@ func (m *Model) saveActiveContext() {
 	rc.selectedSessionIndex = m.selectedSessionIndex
 	rc.scrollOffset = m.scrollOffset
 	rc.scrollPositions = m.scrollPositions
+	rc.lastSeenOutput = m.lastSeenOutput
 }
 
 // managerForSession returns the session manager that owns the given session.
@@ -120,4 +122,6 @@ func (m *Model) loadContext(repoName string) {
 	m.selectedSessionIndex = rc.selectedSessionIndex
 	m.scrollOffset = rc.scrollOffset
 	m.scrollPositions = rc.scrollPositions
+	m.lastSeenOutput = rc.lastSeenOutput
+	m.resolveDefaultModels()
 }
@@ -1,29 +1,85 @@
 package app
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/bazelment/yoloswe/wt"
 )
 
 func TestRunRepoHookCommandsSuccess(t *testing.T) {
 	tmp := t.TempDir()
 	var messages []string
-	err := runRepoHookCommands([]string{"echo ok > /dev/null"}, tmp, "feature/test", &messages)
+	results, err := runRepoHookCommands(context.Background(), tmp, []string{"echo ok"}, wt.HookEnv{Branch: "feature/test", Path: tmp}, &messages)
 	if err != nil {
 		t.Fatalf("runRepoHookCommands() error = %v", err)
 	}
 	if len(messages) == 0 {
 		t.Fatal("expected messages to include executed command")
 	}
+	if len(results) != 1 || results[0].ExitCode != 0 || strings.TrimSpace(results[0].Output) != "ok" {
+		t.Fatalf("results = %+v, want one successful result with output", results)
+	}
 }
 
 func TestRunRepoHookCommandsFailure(t *testing.T) {
 	tmp := t.TempDir()
 	var messages []string
-	err := runRepoHookCommands([]string{"false"}, tmp, "feature/test", &messages)
+	_, err := runRepoHookCommands(context.Background(), tmp, []string{"false"}, wt.HookEnv{Branch: "feature/test", Path: tmp}, &messages)
 	if err == nil {
 		t.Fatal("expected error for failing command")
 	}
 	if len(messages) < 2 {
 		t.Fatalf("expected running+failed messages, got %v", messages)
 	}
+	if got := repoHookWarning("Worktree created", "on-worktree-create", err); got != "Worktree created, but on-worktree-create command failed" {
+		t.Errorf("repoHookWarning() = %q", got)
+	}
+}
+
+func TestRunRepoHookCommandsTimeout(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmp, ".wt.yaml"), []byte("hook_timeout: 200ms\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	results, err := runRepoHookCommands(context.Background(), tmp, []string{"sleep 30"}, wt.HookEnv{Branch: "feature/test", Path: tmp}, &messages)
+	if err == nil {
+		t.Fatal("expected error for hung command")
+	}
+	if len(results) != 1 || !results[0].TimedOut {
+		t.Fatalf("results = %+v, want one timed-out result", results)
+	}
+	if !strings.Contains(strings.Join(messages, "\n"), "Command timed out after 200ms: sleep 30") {
+		t.Errorf("messages = %v, want timeout line", messages)
+	}
+	if got := repoHookWarning("Worktree created", "on-worktree-create", err); got != "Worktree created, but on-worktree-create command timed out after 200ms" {
+		t.Errorf("repoHookWarning() = %q", got)
+	}
+}
+
+func TestRunRepoHookCommandsUsesGlobalTimeout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".wt.yaml"), []byte("hook_timeout: 200ms\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	worktreePath := filepath.Join(root, "repo", "feature")
+	if err := os.MkdirAll(worktreePath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	results, err := runRepoHookCommands(context.Background(), root, []string{"sleep 30"}, wt.HookEnv{Branch: "feature", Path: worktreePath}, &messages)
+	if err == nil {
+		t.Fatal("expected error for hung command")
+	}
+	if len(results) != 1 || !results[0].TimedOut {
+		t.Fatalf("results = %+v, want one timed-out result", results)
+	}
+	if !strings.Contains(strings.Join(messages, "\n"), "Command timed out after 200ms: sleep 30") {
+		t.Errorf("messages = %v, want the global hook_timeout", messages)
+	}
 }
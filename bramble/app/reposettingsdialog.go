@@ -17,8 +17,11 @@ import (
 type RepoSettingsDialogFocus int
 
 const (
-	RepoSettingsFocusTheme     RepoSettingsDialogFocus = iota
-	RepoSettingsFocusProviders                         // Provider toggle section
+	RepoSettingsFocusTheme        RepoSettingsDialogFocus = iota
+	RepoSettingsFocusProviders                            // Provider toggle section
+	RepoSettingsFocusCodexEffort                          // Codex reasoning effort selector
+	RepoSettingsFocusCodexSandbox                         // Codex builder sandbox selector
+	RepoSettingsFocusAutoReview                           // Auto-review on builder idle toggle
 	RepoSettingsFocusCreate
 	RepoSettingsFocusDelete
 	RepoSettingsFocusSave
@@ -42,14 +45,26 @@ type RepoSettingsDialog struct {
 	enabledProviders map[string]bool
 	repoName         string
 	original         string
+	// planModel, buildModel, codeTalkModel and reviewModel carry the saved
+	// default models, which the dialog doesn't edit, through a save unchanged.
+	planModel        string
+	buildModel       string
+	codeTalkModel    string
+	reviewModel      string
 	providerStatuses []agent.ProviderStatus
 	themes           []ColorPalette
 	width            int
 	height           int
 	selectedIdx      int
 	providerCursor   int
+	effortIdx        int // index into codexEffortChoices
+	sandboxIdx       int // index into codexSandboxChoices
 	focus            RepoSettingsDialogFocus
 	visible          bool
+	// allowFailingMerge carries RepoSettings.AllowMergeWithFailingChecks,
+	// which the dialog doesn't edit, through a save unchanged.
+	allowFailingMerge bool
+	autoReview        bool
 }
 
 // NewRepoSettingsDialog creates a new repo settings dialog.
@@ -57,7 +72,7 @@ func NewRepoSettingsDialog() *RepoSettingsDialog {
 	return &RepoSettingsDialog{
 		createInput: newRepoSettingsTextArea(),
 		deleteInput: newRepoSettingsTextArea(),
-		themes:      BuiltinThemes,
+		themes:      AvailableThemes(),
 		focus:       RepoSettingsFocusTheme,
 	}
 }
@@ -133,6 +148,24 @@ func (d *RepoSettingsDialog) Show(repoName string, cfg RepoSettings, currentThem
 		}
 	}
 
+	d.allowFailingMerge = cfg.AllowMergeWithFailingChecks
+	d.planModel, d.buildModel, d.codeTalkModel = cfg.PlanModel, cfg.BuildModel, cfg.CodeTalkModel
+	d.autoReview, d.reviewModel = cfg.AutoReview, cfg.ReviewModel
+	d.effortIdx = 0
+	for i, c := range codexEffortChoices {
+		if c == cfg.CodexEffort {
+			d.effortIdx = i
+			break
+		}
+	}
+	d.sandboxIdx = 0
+	for i, c := range codexSandboxChoices {
+		if c == cfg.CodexSandbox {
+			d.sandboxIdx = i
+			break
+		}
+	}
+
 	d.createInput.SetValue(strings.Join(cfg.OnWorktreeCreate, "\n"))
 	d.deleteInput.SetValue(strings.Join(cfg.OnWorktreeDelete, "\n"))
 	d.createInput.Placeholder = "One shell command per line"
@@ -194,11 +227,31 @@ func (d *RepoSettingsDialog) SetSize(w, h int) {
 // RepoSettings returns the current normalized settings from the dialog.
 func (d *RepoSettingsDialog) RepoSettings() RepoSettings {
 	return RepoSettings{
-		OnWorktreeCreate: parseCommandLines(d.createInput.Value()),
-		OnWorktreeDelete: parseCommandLines(d.deleteInput.Value()),
+		OnWorktreeCreate:            parseCommandLines(d.createInput.Value()),
+		OnWorktreeDelete:            parseCommandLines(d.deleteInput.Value()),
+		CodexEffort:                 codexEffortChoices[d.effortIdx],
+		CodexSandbox:                codexSandboxChoices[d.sandboxIdx],
+		AllowMergeWithFailingChecks: d.allowFailingMerge,
+		PlanModel:                   d.planModel,
+		BuildModel:                  d.buildModel,
+		CodeTalkModel:               d.codeTalkModel,
+		AutoReview:                  d.autoReview,
+		ReviewModel:                 d.reviewModel,
 	}
 }
 
+// cycleEffort moves the codex effort selection by delta, wrapping around.
+func (d *RepoSettingsDialog) cycleEffort(delta int) {
+	n := len(codexEffortChoices)
+	d.effortIdx = ((d.effortIdx+delta)%n + n) % n
+}
+
+// cycleSandbox moves the codex sandbox selection by delta, wrapping around.
+func (d *RepoSettingsDialog) cycleSandbox(delta int) {
+	n := len(codexSandboxChoices)
+	d.sandboxIdx = ((d.sandboxIdx+delta)%n + n) % n
+}
+
 // SelectedTheme returns the currently highlighted theme.
 func (d *RepoSettingsDialog) SelectedTheme() ColorPalette {
 	if d.selectedIdx >= 0 && d.selectedIdx < len(d.themes) {
@@ -231,7 +284,7 @@ func parseCommandLines(in string) []string {
 func (d *RepoSettingsDialog) setFocus(f RepoSettingsDialogFocus) {
 	d.focus = f
 	switch f {
-	case RepoSettingsFocusTheme, RepoSettingsFocusProviders:
+	case RepoSettingsFocusTheme, RepoSettingsFocusProviders, RepoSettingsFocusCodexEffort, RepoSettingsFocusCodexSandbox, RepoSettingsFocusAutoReview:
 		d.createInput.Blur()
 		d.deleteInput.Blur()
 	case RepoSettingsFocusCreate:
@@ -301,9 +354,13 @@ func (d *RepoSettingsDialog) Update(msg tea.KeyPressMsg) (RepoSettingsDialogActi
 			}
 			return RepoSettingsActionNone, nil
 		}
+		if d.focus == RepoSettingsFocusAutoReview {
+			d.autoReview = !d.autoReview
+			return RepoSettingsActionNone, nil
+		}
 	case "enter":
 		switch d.focus {
-		case RepoSettingsFocusTheme:
+		case RepoSettingsFocusTheme, RepoSettingsFocusCodexEffort, RepoSettingsFocusCodexSandbox:
 			d.moveFocus(1)
 			return RepoSettingsActionNone, nil
 		case RepoSettingsFocusProviders:
@@ -315,6 +372,9 @@ func (d *RepoSettingsDialog) Update(msg tea.KeyPressMsg) (RepoSettingsDialogActi
 				}
 			}
 			return RepoSettingsActionNone, nil
+		case RepoSettingsFocusAutoReview:
+			d.autoReview = !d.autoReview
+			return RepoSettingsActionNone, nil
 		case RepoSettingsFocusSave:
 			return RepoSettingsActionSave, nil
 		case RepoSettingsFocusCancel:
@@ -325,11 +385,35 @@ func (d *RepoSettingsDialog) Update(msg tea.KeyPressMsg) (RepoSettingsDialogActi
 			d.moveThemeGrid(0, -1)
 			return RepoSettingsActionNone, nil
 		}
+		if d.focus == RepoSettingsFocusCodexEffort {
+			d.cycleEffort(-1)
+			return RepoSettingsActionNone, nil
+		}
+		if d.focus == RepoSettingsFocusCodexSandbox {
+			d.cycleSandbox(-1)
+			return RepoSettingsActionNone, nil
+		}
+		if d.focus == RepoSettingsFocusAutoReview {
+			d.autoReview = !d.autoReview
+			return RepoSettingsActionNone, nil
+		}
 	case "right", "l":
 		if d.focus == RepoSettingsFocusTheme {
 			d.moveThemeGrid(0, 1)
 			return RepoSettingsActionNone, nil
 		}
+		if d.focus == RepoSettingsFocusCodexEffort {
+			d.cycleEffort(1)
+			return RepoSettingsActionNone, nil
+		}
+		if d.focus == RepoSettingsFocusCodexSandbox {
+			d.cycleSandbox(1)
+			return RepoSettingsActionNone, nil
+		}
+		if d.focus == RepoSettingsFocusAutoReview {
+			d.autoReview = !d.autoReview
+			return RepoSettingsActionNone, nil
+		}
 	case "up":
 		if d.focus == RepoSettingsFocusTheme {
 			d.moveThemeGrid(-1, 0)
@@ -343,7 +427,7 @@ func (d *RepoSettingsDialog) Update(msg tea.KeyPressMsg) (RepoSettingsDialogActi
 			}
 			return RepoSettingsActionNone, nil
 		}
-		if d.focus == RepoSettingsFocusSave || d.focus == RepoSettingsFocusCancel {
+		if d.focus == RepoSettingsFocusCodexEffort || d.focus == RepoSettingsFocusCodexSandbox || d.focus == RepoSettingsFocusAutoReview || d.focus == RepoSettingsFocusSave || d.focus == RepoSettingsFocusCancel {
 			d.moveFocus(-1)
 			return RepoSettingsActionNone, nil
 		}
@@ -360,7 +444,7 @@ func (d *RepoSettingsDialog) Update(msg tea.KeyPressMsg) (RepoSettingsDialogActi
 			}
 			return RepoSettingsActionNone, nil
 		}
-		if d.focus == RepoSettingsFocusSave || d.focus == RepoSettingsFocusCancel {
+		if d.focus == RepoSettingsFocusCodexEffort || d.focus == RepoSettingsFocusCodexSandbox || d.focus == RepoSettingsFocusAutoReview || d.focus == RepoSettingsFocusSave || d.focus == RepoSettingsFocusCancel {
 			d.moveFocus(1)
 			return RepoSettingsActionNone, nil
 		}
@@ -517,6 +601,57 @@ func (d *RepoSettingsDialog) renderThemeGrid(boxWidth int, styles *Styles) strin
 	return lipgloss.JoinVertical(lipgloss.Left, rowStrings...)
 }
 
+// renderCodexEffort renders the codex reasoning effort selector line.
+func (d *RepoSettingsDialog) renderCodexEffort(styles *Styles) string {
+	label := "Codex Reasoning Effort"
+	if d.focus == RepoSettingsFocusCodexEffort {
+		label = styles.Selected.Render(" " + label + " ")
+	}
+	value := codexEffortChoices[d.effortIdx]
+	if value == "" {
+		value = "model default"
+	}
+	line := label + "  < " + value + " >"
+	if d.focus == RepoSettingsFocusCodexEffort {
+		line += "  " + styles.Dim.Render("[Left/Right] change")
+	}
+	return line
+}
+
+// renderCodexSandbox renders the codex builder sandbox selector line.
+func (d *RepoSettingsDialog) renderCodexSandbox(styles *Styles) string {
+	label := "Codex Builder Sandbox"
+	if d.focus == RepoSettingsFocusCodexSandbox {
+		label = styles.Selected.Render(" " + label + " ")
+	}
+	value := codexSandboxChoices[d.sandboxIdx]
+	if value == "" {
+		value = "default"
+	}
+	line := label + "  < " + value + " >"
+	if d.focus == RepoSettingsFocusCodexSandbox {
+		line += "  " + styles.Dim.Render("[Left/Right] change  planners always read-only")
+	}
+	return line
+}
+
+// renderAutoReview renders the auto-review on builder idle toggle line.
+func (d *RepoSettingsDialog) renderAutoReview(styles *Styles) string {
+	label := "Auto-review on Builder Idle"
+	if d.focus == RepoSettingsFocusAutoReview {
+		label = styles.Selected.Render(" " + label + " ")
+	}
+	checkbox := "[ ]"
+	if d.autoReview {
+		checkbox = "[x]"
+	}
+	line := label + "  " + checkbox
+	if d.focus == RepoSettingsFocusAutoReview {
+		line += "  " + styles.Dim.Render("[Space] toggle  starts a read-only reviewer when a builder goes idle")
+	}
+	return line
+}
+
 // View renders the dialog.
 func (d *RepoSettingsDialog) View(styles *Styles) string {
 	title := styles.Title.Render("Repo Settings")
@@ -617,7 +752,12 @@ func (d *RepoSettingsDialog) View(styles *Styles) string {
 		b.WriteString(styles.Dim.Render("  [Space/Enter] toggle  [Up/Down] navigate"))
 		b.WriteString("\n")
 	}
+	b.WriteString(d.renderCodexEffort(styles))
 	b.WriteString("\n")
+	b.WriteString(d.renderCodexSandbox(styles))
+	b.WriteString("\n")
+	b.WriteString(d.renderAutoReview(styles))
+	b.WriteString("\n\n")
 	b.WriteString(createLabel)
 	b.WriteString("\n")
 	b.WriteString(styles.InputBox.Width(inputWidth + 2).Render(d.createInput.View()))
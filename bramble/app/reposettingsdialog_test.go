@@ -27,6 +27,16 @@ func TestRepoSettingsDialogRoundTrip(t *testing.T) {
 	}
 }
 
+func TestRepoSettingsDialogKeepsDefaultModels(t *testing.T) {
+	d := NewRepoSettingsDialog()
+	d.Show("repo-a", RepoSettings{PlanModel: "gpt-5.5", BuildModel: "haiku"}, "dark", 100, 40, lipgloss.Color("245"), nil, nil)
+
+	got := d.RepoSettings()
+	if got.PlanModel != "gpt-5.5" || got.BuildModel != "haiku" {
+		t.Fatalf("RepoSettings() models = %q/%q, want gpt-5.5/haiku", got.PlanModel, got.BuildModel)
+	}
+}
+
 func TestRepoSettingsDialogParseCommandLines(t *testing.T) {
 	d := NewRepoSettingsDialog()
 	d.Show("repo-a", RepoSettings{}, "dark", 100, 40, lipgloss.Color("245"), nil, nil)
@@ -50,7 +60,10 @@ func TestRepoSettingsDialogSaveShortcut(t *testing.T) {
 	d.Show("repo-a", RepoSettings{}, "dark", 100, 40, lipgloss.Color("245"), nil, nil)
 
 	_, _ = d.Update(specialKey(tea.KeyTab)) // Theme → Providers
-	_, _ = d.Update(specialKey(tea.KeyTab)) // Providers → Create
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Providers → Codex effort
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Codex effort → Codex sandbox
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Codex sandbox → Auto-review
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Auto-review → Create
 	_, _ = d.Update(specialKey(tea.KeyTab)) // Create → Delete
 	_, _ = d.Update(specialKey(tea.KeyTab)) // Delete → Save
 	action, _ := d.Update(specialKey(tea.KeyEnter))
@@ -59,6 +72,81 @@ func TestRepoSettingsDialogSaveShortcut(t *testing.T) {
 	}
 }
 
+func TestRepoSettingsDialogAutoReview(t *testing.T) {
+	d := NewRepoSettingsDialog()
+	d.Show("repo-a", RepoSettings{ReviewModel: "sonnet"}, "dark", 100, 40, lipgloss.Color("245"), nil, nil)
+	d.setFocus(RepoSettingsFocusAutoReview)
+
+	_, _ = d.Update(keyPress(' '))
+	got := d.RepoSettings()
+	if !got.AutoReview {
+		t.Fatal("AutoReview = false after toggling, want true")
+	}
+	if got.ReviewModel != "sonnet" {
+		t.Fatalf("ReviewModel = %q, want it carried through", got.ReviewModel)
+	}
+	if !strings.Contains(d.View(NewStyles(Dark)), "Auto-review on Builder Idle") {
+		t.Fatal("view missing the auto-review toggle")
+	}
+
+	_, _ = d.Update(specialKey(tea.KeyEnter))
+	if d.RepoSettings().AutoReview {
+		t.Fatal("AutoReview = true after toggling back, want false")
+	}
+}
+
+func TestRepoSettingsDialogCodexEffort(t *testing.T) {
+	d := NewRepoSettingsDialog()
+	d.Show("repo-a", RepoSettings{CodexEffort: "medium"}, "dark", 100, 40, lipgloss.Color("245"), nil, nil)
+	if got := d.RepoSettings().CodexEffort; got != "medium" {
+		t.Fatalf("CodexEffort = %q, want medium", got)
+	}
+
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Theme → Providers
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Providers → Codex effort
+	if !strings.Contains(stripAnsi(d.View(NewStyles(Dark))), "< medium >") {
+		t.Fatal("view should show the selected effort")
+	}
+	_, _ = d.Update(specialKey(tea.KeyRight))
+	if got := d.RepoSettings().CodexEffort; got != "high" {
+		t.Fatalf("after right: CodexEffort = %q, want high", got)
+	}
+	_, _ = d.Update(specialKey(tea.KeyRight)) // wraps to model default
+	if got := d.RepoSettings().CodexEffort; got != "" {
+		t.Fatalf("after wrap: CodexEffort = %q, want empty", got)
+	}
+	_, _ = d.Update(specialKey(tea.KeyLeft))
+	if got := d.RepoSettings().CodexEffort; got != "high" {
+		t.Fatalf("after left: CodexEffort = %q, want high", got)
+	}
+}
+
+func TestRepoSettingsDialogCodexSandbox(t *testing.T) {
+	d := NewRepoSettingsDialog()
+	d.Show("repo-a", RepoSettings{CodexSandbox: "workspace-write"}, "dark", 100, 40, lipgloss.Color("245"), nil, nil)
+	if got := d.RepoSettings().CodexSandbox; got != "workspace-write" {
+		t.Fatalf("CodexSandbox = %q, want workspace-write", got)
+	}
+
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Theme → Providers
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Providers → Codex effort
+	_, _ = d.Update(specialKey(tea.KeyTab)) // Codex effort → Codex sandbox
+	if !strings.Contains(stripAnsi(d.View(NewStyles(Dark))), "< workspace-write >") {
+		t.Fatal("view should show the selected sandbox")
+	}
+	_, _ = d.Update(specialKey(tea.KeyRight))
+	if got := d.RepoSettings().CodexSandbox; got != "danger-full-access" {
+		t.Fatalf("after right: CodexSandbox = %q, want danger-full-access", got)
+	}
+	_, _ = d.Update(specialKey(tea.KeyRight)) // wraps to the default
+	if got := d.RepoSettings().CodexSandbox; got != "" {
+		t.Fatalf("after wrap: CodexSandbox = %q, want empty", got)
+	}
+	if got := d.RepoSettings().CodexEffort; got != "" {
+		t.Fatalf("CodexEffort = %q, want it untouched", got)
+	}
+}
+
 func TestRepoSettingsDialogThemeSelection(t *testing.T) {
 	d := NewRepoSettingsDialog()
 	d.Show("repo-a", RepoSettings{}, "dark", 100, 40, lipgloss.Color("245"), nil, nil)
@@ -0,0 +1,226 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// outputSearch is the state of a "/" search over the viewed session's
+// output. Matches are recomputed from the live output on every step, so
+// lines appended after the search started are found too.
+type outputSearch struct {
+	sessionID session.SessionID
+	query     string
+	// current is the OutputLine index of the match last jumped to, or -1
+	// when the query has no match.
+	current int
+}
+
+// searchActive reports whether a search is in effect for the viewed session.
+func (m *Model) searchActive() bool {
+	return m.search.query != "" && m.search.sessionID == m.viewingSessionID
+}
+
+// outputLineMatches reports whether line's content, tool name, or tool input
+// contains query, which must already be lowercased.
+func outputLineMatches(line *session.OutputLine, query string) bool {
+	if strings.Contains(strings.ToLower(line.Content), query) ||
+		strings.Contains(strings.ToLower(line.ToolName), query) {
+		return true
+	}
+	for _, v := range line.ToolInput {
+		if strings.Contains(strings.ToLower(fmt.Sprint(v)), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchMatches returns the indexes of the viewed session's output lines
+// that match the search query, oldest first.
+func (m *Model) searchMatches() []int {
+	if m.search.query == "" {
+		return nil
+	}
+	query := strings.ToLower(m.search.query)
+	lines := m.sessionManager.GetSessionOutput(m.search.sessionID)
+	var matches []int
+	for i := range lines {
+		if outputLineMatches(&lines[i], query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// startSearch opens the search query input for the viewed session.
+func (m Model) startSearch() (tea.Model, tea.Cmd) {
+	if m.viewingSessionID == "" || m.viewingHistoryData != nil || m.sessionManager.IsInTmuxMode() {
+		toastCmd := m.addToast("Select a live session to search its output", ToastInfo)
+		return m, toastCmd
+	}
+	m.search = outputSearch{sessionID: m.viewingSessionID, current: -1}
+	m.focus = FocusSearch
+	return m, nil
+}
+
+// handleSearchInput handles key presses while the search query is typed.
+// The view jumps to the newest match as the query changes.
+func (m Model) handleSearchInput(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.search = outputSearch{}
+		m.focus = FocusOutput
+		return m, nil
+
+	case "enter":
+		m.focus = FocusOutput
+		if m.search.query == "" {
+			m.search = outputSearch{}
+			return m, nil
+		}
+		if m.search.current < 0 {
+			toastCmd := m.addToast(fmt.Sprintf("No matches for %q", m.search.query), ToastInfo)
+			m.search = outputSearch{}
+			return m, toastCmd
+		}
+		return m, nil
+
+	case "backspace":
+		if runes := []rune(m.search.query); len(runes) > 0 {
+			m.search.query = string(runes[:len(runes)-1])
+			m.jumpToNewestMatch()
+		}
+		return m, nil
+	}
+
+	if r, ok := printableRune(msg); ok {
+		m.search.query += string(r)
+		m.jumpToNewestMatch()
+	}
+	return m, nil
+}
+
+// handleSearchKey handles n/N/esc while a search is active in the output
+// pane. It returns false for keys that are not search navigation.
+func (m Model) handleSearchKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "n":
+		m.stepSearch(-1)
+	case "N":
+		m.stepSearch(1)
+	case "esc":
+		m.search = outputSearch{}
+		m.scrollOffset = 0
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// jumpToNewestMatch scrolls to the newest match of the current query.
+func (m *Model) jumpToNewestMatch() {
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		m.search.current = -1
+		return
+	}
+	m.jumpToOutputLine(matches[len(matches)-1])
+}
+
+// stepSearch moves to the previous (dir < 0, older) or next (dir > 0,
+// newer) match, wrapping around at either end.
+func (m *Model) stepSearch(dir int) {
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		m.search.current = -1
+		return
+	}
+	pos := -1
+	for i, idx := range matches {
+		if idx == m.search.current {
+			pos = i
+			break
+		}
+	}
+	switch {
+	case pos < 0:
+		pos = len(matches) - 1
+	case dir < 0:
+		pos = (pos - 1 + len(matches)) % len(matches)
+	default:
+		pos = (pos + 1) % len(matches)
+	}
+	m.jumpToOutputLine(matches[pos])
+}
+
+// jumpToOutputLine makes the match at idx current and scrolls to it.
+func (m *Model) jumpToOutputLine(idx int) {
+	m.search.current = idx
+	m.scrollToOutputLine(m.search.sessionID, idx)
+}
+
+// scrollToOutputLine sets scrollOffset so the output line at idx of the
+// session's output is the first line shown in the output pane.
+func (m *Model) scrollToOutputLine(sessionID session.SessionID, idx int) {
+	width, outputHeight := m.outputPaneSize()
+	lines := m.sessionManager.GetSessionOutput(sessionID)
+	visual, starts := m.outputVisualLines(lines, width)
+	visual, starts = m.insertNewOutputDivider(sessionID, visual, starts, width)
+	if idx >= len(starts) {
+		return
+	}
+	// Scrolled views reserve two lines for the above/below indicators.
+	offset := len(visual) - starts[idx] - (outputHeight - 2)
+	if offset < 0 {
+		offset = 0
+	}
+	m.scrollOffset = offset
+}
+
+// searchStatus renders the search state for the status bar, e.g.
+// "/npm▏" while typing or "/npm  3/12" once the query is set.
+func (m *Model) searchStatus() string {
+	text := "/" + m.search.query
+	if m.focus == FocusSearch {
+		text += "▏"
+	}
+	matches := m.searchMatches()
+	if m.search.query == "" {
+		return text
+	}
+	if len(matches) == 0 {
+		return text + "  no matches"
+	}
+	pos := 0
+	for i, idx := range matches {
+		if idx == m.search.current {
+			pos = i + 1
+			break
+		}
+	}
+	return fmt.Sprintf("%s  %d/%d", text, pos, len(matches))
+}
+
+// markSearchMatch replaces the first indentation column of a rendered line
+// with marker, so matches are flagged without widening the line. Lines
+// that do not start with a space (after any ANSI styling) get the marker
+// prepended instead.
+func markSearchMatch(line, marker string) string {
+	i := 0
+	for i < len(line) && line[i] == '\x1b' {
+		end := strings.IndexByte(line[i:], 'm')
+		if end < 0 {
+			break
+		}
+		i += end + 1
+	}
+	if i < len(line) && line[i] == ' ' {
+		return marker + line[:i] + line[i+1:]
+	}
+	return marker + line
+}
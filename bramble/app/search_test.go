@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// newSearchModel returns a model viewing a session with 60 status lines,
+// two tool calls, and an error, on a 20-line terminal.
+func newSearchModel(t *testing.T) Model {
+	t.Helper()
+	mgr := session.NewManagerWithConfig(session.ManagerConfig{SessionMode: session.SessionModeTUI})
+	t.Cleanup(mgr.Close)
+
+	sessID := session.SessionID("search-session")
+	mgr.AddSession(&session.Session{ID: sessID, Type: session.SessionTypeBuilder, Status: session.StatusRunning, Progress: &session.SessionProgress{}})
+	mgr.InitOutputBuffer(sessID)
+	for i := 0; i < 60; i++ {
+		line := session.OutputLine{Type: session.OutputTypeStatus, Content: fmt.Sprintf("Line-%03d", i)}
+		switch i {
+		case 5:
+			line = session.OutputLine{Type: session.OutputTypeToolStart, ToolName: "Bash", ToolInput: map[string]interface{}{"command": "npm install"}, ToolState: session.ToolStateComplete}
+		case 20:
+			line = session.OutputLine{Type: session.OutputTypeError, Content: "npm ERR! missing script"}
+		case 40:
+			line = session.OutputLine{Type: session.OutputTypeToolStart, ToolName: "Read", ToolInput: map[string]interface{}{"file_path": "/repo/go.mod"}, ToolState: session.ToolStateComplete}
+		}
+		mgr.AddOutputLine(sessID, line)
+	}
+
+	m := NewModel(context.Background(), "/tmp/wt", "test-repo", "", mgr, nil, nil, 80, 20, nil, nil, session.ManagerConfig{}, nil)
+	m.viewingSessionID = sessID
+	return m
+}
+
+func typeSearch(t *testing.T, m Model, query string) Model {
+	t.Helper()
+	next, _ := m.Update(keyPress('/'))
+	m = next.(Model)
+	require.Equal(t, FocusSearch, m.focus)
+	for _, r := range query {
+		next, _ = m.Update(keyPress(r))
+		m = next.(Model)
+	}
+	return m
+}
+
+func TestOutputLineMatches(t *testing.T) {
+	tool := session.OutputLine{ToolName: "Bash", ToolInput: map[string]interface{}{"command": "go test ./..."}}
+	assert.True(t, outputLineMatches(&tool, "bash"), "tool name")
+	assert.True(t, outputLineMatches(&tool, "go test"), "tool input")
+	assert.False(t, outputLineMatches(&tool, "npm"))
+
+	text := session.OutputLine{Content: "Build FAILED"}
+	assert.True(t, outputLineMatches(&text, "failed"), "case-insensitive content")
+}
+
+func TestOutputSearchJumpsBetweenMatches(t *testing.T) {
+	m := typeSearch(t, newSearchModel(t), "npm")
+
+	// Typing jumps to the newest match.
+	assert.Equal(t, 20, m.search.current)
+	assert.Positive(t, m.scrollOffset)
+	_, outputHeight := m.outputPaneSize()
+	center := m.renderCenter(80, outputHeight+5)
+	assert.Contains(t, center, "npm ERR! missing script", "the match is scrolled into view")
+	assert.Contains(t, center, "▶", "the current match is marked")
+
+	next, _ := m.Update(specialKey(tea.KeyEnter))
+	m = next.(Model)
+	assert.Equal(t, FocusOutput, m.focus)
+	assert.True(t, m.searchActive())
+	assert.Contains(t, stripAnsi(m.renderStatusBar()), "/npm  2/2")
+
+	// n goes to the older match and wraps; N goes back.
+	next, _ = m.Update(keyPress('n'))
+	m = next.(Model)
+	assert.Equal(t, 5, m.search.current)
+	next, _ = m.Update(keyPress('n'))
+	m = next.(Model)
+	assert.Equal(t, 20, m.search.current)
+	next, _ = m.Update(keyPress('N'))
+	m = next.(Model)
+	assert.Equal(t, 5, m.search.current)
+	assert.Nil(t, m.confirmPrompt)
+	assert.False(t, m.inputMode, "n is search navigation, not new worktree")
+
+	next, _ = m.Update(specialKey(tea.KeyEscape))
+	m = next.(Model)
+	assert.False(t, m.searchActive())
+	assert.Zero(t, m.scrollOffset)
+}
+
+func TestOutputSearchNoMatches(t *testing.T) {
+	m := typeSearch(t, newSearchModel(t), "zzz")
+	assert.Equal(t, -1, m.search.current)
+	assert.Contains(t, stripAnsi(m.renderStatusBar()), "no matches")
+
+	next, _ := m.Update(specialKey(tea.KeyEnter))
+	m = next.(Model)
+	assert.False(t, m.searchActive())
+	require.True(t, m.toasts.HasToasts())
+	assert.Contains(t, m.toasts.toasts[0].Message, "No matches")
+}
+
+func TestOutputSearchBackspaceAndCancel(t *testing.T) {
+	m := typeSearch(t, newSearchModel(t), "go.modx")
+	assert.Equal(t, -1, m.search.current)
+
+	next, _ := m.Update(specialKey(tea.KeyBackspace))
+	m = next.(Model)
+	assert.Equal(t, "go.mod", m.search.query)
+	assert.Equal(t, 40, m.search.current, "tool input matches")
+
+	next, _ = m.Update(specialKey(tea.KeyEscape))
+	m = next.(Model)
+	assert.Equal(t, FocusOutput, m.focus)
+	assert.False(t, m.searchActive())
+}
+
+func TestOutputSearchRequiresLiveSession(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	next, _ := m.Update(keyPress('/'))
+	m = next.(Model)
+	assert.Equal(t, FocusOutput, m.focus)
+	assert.True(t, m.toasts.HasToasts())
+}
+
+func TestMarkSearchMatch(t *testing.T) {
+	assert.Equal(t, "> foo", markSearchMatch("  foo", ">"))
+	assert.Equal(t, ">\x1b[31m ✗ x", markSearchMatch("\x1b[31m  ✗ x", ">"))
+	assert.Equal(t, ">foo", markSearchMatch("foo", ">"))
+}
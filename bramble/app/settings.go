@@ -5,19 +5,73 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
+	"github.com/bazelment/yoloswe/multiagent/agent"
 )
 
 // RepoSettings holds per-repository Bramble settings.
 type RepoSettings struct {
+	// CodexEffort is the reasoning effort ("low", "medium", "high") for
+	// codex sessions in this repo. Empty leaves the model default.
+	CodexEffort string `json:"codex_effort,omitempty"`
+	// CodexSandbox is the sandbox level ("read-only", "workspace-write",
+	// "danger-full-access") for codex builder sessions in this repo. Empty
+	// keeps the default: workspace-write, or full access in yolo mode.
+	// Planner and codetalk sessions are always read-only.
+	CodexSandbox string `json:"codex_sandbox,omitempty"`
+	// PlanModel, BuildModel and CodeTalkModel are the model IDs last chosen
+	// for each session type in this repo, restored as the prompt defaults.
+	PlanModel        string   `json:"plan_model,omitempty"`
+	BuildModel       string   `json:"build_model,omitempty"`
+	CodeTalkModel    string   `json:"codetalk_model,omitempty"`
+	ReviewModel      string   `json:"review_model,omitempty"` // auto-review sessions; empty uses the codetalk default
 	OnWorktreeCreate []string `json:"on_worktree_create,omitempty"`
 	OnWorktreeDelete []string `json:"on_worktree_delete,omitempty"`
+	// AllowMergeWithFailingChecks restores the one-key merge for PRs whose
+	// CI checks are failing or still running, for teams that intentionally
+	// merge around checks. By default such merges need an extra key.
+	AllowMergeWithFailingChecks bool `json:"allow_merge_with_failing_checks,omitempty"`
+	// AutoReview starts a read-only reviewer session on a builder's
+	// worktree each time a builder in this repo goes idle.
+	AutoReview bool `json:"auto_review,omitempty"`
 }
 
+// codexEffortChoices are the reasoning efforts offered for codex sessions,
+// in the order the repo settings dialog cycles through them. The empty
+// choice keeps the model default.
+var codexEffortChoices = []string{"", string(agent.EffortLow), string(agent.EffortMedium), string(agent.EffortHigh)}
+
+// codexSandboxChoices are the sandbox levels offered for codex builder
+// sessions, in the order the repo settings dialog cycles through them. The
+// empty choice keeps the default.
+var codexSandboxChoices = []string{"", codex.SandboxReadOnly, codex.SandboxWorkspaceWrite, codex.SandboxDangerFullAccess}
+
 // Settings holds persistent user preferences.
 type Settings struct {
-	EnabledProviders *[]string               `json:"enabled_providers,omitempty"`
-	Repos            map[string]RepoSettings `json:"repos,omitempty"`
-	ThemeName        string                  `json:"theme_name"`
+	EnabledProviders *[]string `json:"enabled_providers,omitempty"`
+	// OutputFlushMs is how long the TUI gathers session output events
+	// before refreshing once. Nil uses defaultOutputFlushInterval; 0
+	// refreshes on every event.
+	OutputFlushMs *int                    `json:"output_flush_ms,omitempty"`
+	Repos         map[string]RepoSettings `json:"repos,omitempty"`
+	// PromptHistory holds recently submitted prompts per worktree path,
+	// oldest first, for up/down recall in the input area.
+	PromptHistory map[string][]string `json:"prompt_history,omitempty"`
+	// LastSelection holds the worktree and session selected in each repo
+	// when Bramble last exited, so a relaunch picks up where it left off.
+	LastSelection map[string]RepoSelection `json:"last_selection,omitempty"`
+	ThemeName     string                   `json:"theme_name"`
+}
+
+// RepoSelection is the worktree and session selected in one repository.
+type RepoSelection struct {
+	// Worktree is the branch of the selected worktree.
+	Worktree string `json:"worktree,omitempty"`
+	// Session is the viewed session ID; it is only restored while the
+	// session is still live.
+	Session string `json:"session,omitempty"`
 }
 
 // GetEnabledProviders returns the enabled providers slice for use with model registry.
@@ -57,6 +111,22 @@ func (s *Settings) SetEnabledProviders(providers []string) {
 	s.EnabledProviders = &copied
 }
 
+// defaultOutputFlushInterval is the output coalescing window when
+// OutputFlushMs is unset: about one frame at 60Hz.
+const defaultOutputFlushInterval = 16 * time.Millisecond
+
+// OutputFlushInterval returns the window over which session output events
+// are coalesced into one refresh. Zero disables coalescing.
+func (s Settings) OutputFlushInterval() time.Duration {
+	if s.OutputFlushMs == nil {
+		return defaultOutputFlushInterval
+	}
+	if *s.OutputFlushMs <= 0 {
+		return 0
+	}
+	return time.Duration(*s.OutputFlushMs) * time.Millisecond
+}
+
 // RepoSettingsFor returns settings for one repository.
 func (s Settings) RepoSettingsFor(repo string) RepoSettings {
 	if s.Repos == nil {
@@ -72,7 +142,9 @@ func (s *Settings) SetRepoSettings(repo string, cfg RepoSettings) {
 		return
 	}
 	cfg = normalizeRepoSettings(cfg)
-	if len(cfg.OnWorktreeCreate) == 0 && len(cfg.OnWorktreeDelete) == 0 {
+	if len(cfg.OnWorktreeCreate) == 0 && len(cfg.OnWorktreeDelete) == 0 && cfg.CodexEffort == "" && cfg.CodexSandbox == "" && !cfg.AllowMergeWithFailingChecks &&
+		!cfg.AutoReview && cfg.ReviewModel == "" &&
+		cfg.PlanModel == "" && cfg.BuildModel == "" && cfg.CodeTalkModel == "" {
 		if s.Repos != nil {
 			delete(s.Repos, repo)
 			if len(s.Repos) == 0 {
@@ -87,12 +159,104 @@ func (s *Settings) SetRepoSettings(repo string, cfg RepoSettings) {
 	s.Repos[repo] = cfg
 }
 
+// PromptHistoryFor returns the saved prompts for a worktree, oldest first.
+func (s Settings) PromptHistoryFor(worktreePath string) []string {
+	if s.PromptHistory == nil {
+		return nil
+	}
+	return s.PromptHistory[worktreePath]
+}
+
+// AddPromptHistory records a submitted prompt for a worktree. A repeated
+// prompt moves to the newest position, and only the last maxPromptHistory
+// prompts are kept.
+func (s *Settings) AddPromptHistory(worktreePath, prompt string) {
+	if worktreePath == "" || strings.TrimSpace(prompt) == "" {
+		return
+	}
+	prev := s.PromptHistoryFor(worktreePath)
+	entries := make([]string, 0, len(prev)+1)
+	for _, p := range prev {
+		if p != prompt {
+			entries = append(entries, p)
+		}
+	}
+	entries = append(entries, prompt)
+	if len(entries) > maxPromptHistory {
+		entries = entries[len(entries)-maxPromptHistory:]
+	}
+	if s.PromptHistory == nil {
+		s.PromptHistory = make(map[string][]string)
+	}
+	s.PromptHistory[worktreePath] = entries
+}
+
+// LastSelectionFor returns the selection saved for a repository.
+func (s Settings) LastSelectionFor(repo string) RepoSelection {
+	if s.LastSelection == nil {
+		return RepoSelection{}
+	}
+	return s.LastSelection[repo]
+}
+
+// SetLastSelection records the selection for a repository. An empty
+// selection removes the entry.
+func (s *Settings) SetLastSelection(repo string, sel RepoSelection) {
+	if repo == "" {
+		return
+	}
+	if sel == (RepoSelection{}) {
+		if s.LastSelection != nil {
+			delete(s.LastSelection, repo)
+			if len(s.LastSelection) == 0 {
+				s.LastSelection = nil
+			}
+		}
+		return
+	}
+	if s.LastSelection == nil {
+		s.LastSelection = make(map[string]RepoSelection)
+	}
+	s.LastSelection[repo] = sel
+}
+
 func normalizeRepoSettings(cfg RepoSettings) RepoSettings {
 	cfg.OnWorktreeCreate = normalizeCommands(cfg.OnWorktreeCreate)
 	cfg.OnWorktreeDelete = normalizeCommands(cfg.OnWorktreeDelete)
+	cfg.CodexEffort = normalizeCodexEffort(cfg.CodexEffort)
+	cfg.CodexSandbox = normalizeCodexSandbox(cfg.CodexSandbox)
+	cfg.PlanModel = strings.TrimSpace(cfg.PlanModel)
+	cfg.BuildModel = strings.TrimSpace(cfg.BuildModel)
+	cfg.CodeTalkModel = strings.TrimSpace(cfg.CodeTalkModel)
+	cfg.ReviewModel = strings.TrimSpace(cfg.ReviewModel)
 	return cfg
 }
 
+// normalizeCodexEffort lowercases effort and drops values outside
+// codexEffortChoices, so a hand-edited settings file can't send codex an
+// effort it rejects.
+func normalizeCodexEffort(effort string) string {
+	effort = strings.ToLower(strings.TrimSpace(effort))
+	for _, c := range codexEffortChoices {
+		if effort == c {
+			return effort
+		}
+	}
+	return ""
+}
+
+// normalizeCodexSandbox lowercases level and drops values outside
+// codexSandboxChoices, like normalizeCodexEffort.
+func normalizeCodexSandbox(level string) string {
+	level = strings.ToLower(strings.TrimSpace(level))
+	for _, c := range codexSandboxChoices {
+		if level == c {
+			return level
+		}
+	}
+	return ""
+}
+
 func normalizeCommands(commands []string) []string {
 	if len(commands) == 0 {
 		return nil
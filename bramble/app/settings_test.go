@@ -1,6 +1,10 @@
 package app
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+	"time"
+)
 
 func TestSettingsSetRepoSettingsNormalizesValues(t *testing.T) {
 	var s Settings
@@ -40,3 +44,96 @@ func TestSettingsSetRepoSettingsRemovesEmptyRepoConfig(t *testing.T) {
 		t.Fatalf("Repos map should be nil after removing last repo, got %+v", s.Repos)
 	}
 }
+
+func TestSettingsAddPromptHistory(t *testing.T) {
+	var s Settings
+	s.AddPromptHistory("/wt/a", "first")
+	s.AddPromptHistory("/wt/a", "second")
+	s.AddPromptHistory("/wt/a", "first") // repeat moves to newest
+	s.AddPromptHistory("/wt/a", "   ")   // blank ignored
+	s.AddPromptHistory("", "no worktree")
+	s.AddPromptHistory("/wt/b", "other")
+
+	got := s.PromptHistoryFor("/wt/a")
+	if len(got) != 2 || got[0] != "second" || got[1] != "first" {
+		t.Fatalf("PromptHistoryFor(/wt/a) = %v, want [second first]", got)
+	}
+	if got := s.PromptHistoryFor("/wt/b"); len(got) != 1 {
+		t.Fatalf("PromptHistoryFor(/wt/b) = %v, want 1 entry", got)
+	}
+	if _, ok := s.PromptHistory[""]; ok {
+		t.Fatal("prompt without a worktree should not be recorded")
+	}
+
+	for i := 0; i < maxPromptHistory+5; i++ {
+		s.AddPromptHistory("/wt/c", fmt.Sprintf("p%d", i))
+	}
+	got = s.PromptHistoryFor("/wt/c")
+	if len(got) != maxPromptHistory {
+		t.Fatalf("len = %d, want %d", len(got), maxPromptHistory)
+	}
+	if got[0] != "p5" || got[len(got)-1] != fmt.Sprintf("p%d", maxPromptHistory+4) {
+		t.Fatalf("kept %q..%q, want the newest entries", got[0], got[len(got)-1])
+	}
+}
+
+func TestSettingsSetRepoSettingsCodexEffort(t *testing.T) {
+	var s Settings
+
+	s.SetRepoSettings("my-repo", RepoSettings{CodexEffort: " HIGH "})
+	if got := s.RepoSettingsFor("my-repo").CodexEffort; got != "high" {
+		t.Fatalf("CodexEffort = %q, want high", got)
+	}
+
+	for _, invalid := range []string{"max", "auto", "extreme"} {
+		s.SetRepoSettings("my-repo", RepoSettings{CodexEffort: invalid})
+		if s.Repos != nil {
+			t.Fatalf("CodexEffort %q should be dropped, got %+v", invalid, s.Repos)
+		}
+	}
+}
+
+func TestSettingsSetRepoSettingsCodexSandbox(t *testing.T) {
+	var s Settings
+
+	s.SetRepoSettings("my-repo", RepoSettings{CodexSandbox: " Read-Only "})
+	if got := s.RepoSettingsFor("my-repo").CodexSandbox; got != "read-only" {
+		t.Fatalf("CodexSandbox = %q, want read-only", got)
+	}
+
+	s.SetRepoSettings("my-repo", RepoSettings{CodexSandbox: "full"})
+	if s.Repos != nil {
+		t.Fatalf("CodexSandbox %q should be dropped, got %+v", "full", s.Repos)
+	}
+}
+
+func TestSettingsSetLastSelection(t *testing.T) {
+	var s Settings
+
+	s.SetLastSelection("repo", RepoSelection{Worktree: "feature", Session: "sess-1"})
+	if got := s.LastSelectionFor("repo"); got.Worktree != "feature" || got.Session != "sess-1" {
+		t.Fatalf("LastSelectionFor() = %+v", got)
+	}
+	if got := s.LastSelectionFor("other"); got != (RepoSelection{}) {
+		t.Fatalf("LastSelectionFor(other) = %+v, want empty", got)
+	}
+
+	s.SetLastSelection("repo", RepoSelection{})
+	if s.LastSelection != nil {
+		t.Fatalf("LastSelection = %+v, want nil after clearing the only repo", s.LastSelection)
+	}
+}
+
+func TestSettingsOutputFlushInterval(t *testing.T) {
+	var s Settings
+	if got := s.OutputFlushInterval(); got != defaultOutputFlushInterval {
+		t.Fatalf("unset OutputFlushInterval() = %v, want %v", got, defaultOutputFlushInterval)
+	}
+	for ms, want := range map[int]time.Duration{0: 0, -5: 0, 50: 50 * time.Millisecond} {
+		ms := ms
+		s.OutputFlushMs = &ms
+		if got := s.OutputFlushInterval(); got != want {
+			t.Errorf("OutputFlushInterval() with %dms = %v, want %v", ms, got, want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
@@ -57,7 +59,7 @@ func TestAllSessionsOverlay_Navigate(t *testing.T) {
 		{ID: "s1", Status: session.StatusRunning, WorktreePath: "/tmp/wt/main"},
 		{ID: "s2", Status: session.StatusRunning, WorktreePath: "/tmp/wt/feature"},
 	}
-	m.allSessionsOverlay.Show(sessions, m.width, m.height)
+	m.allSessionsOverlay.Show(sessions, "", m.width, m.height)
 	m.focus = FocusAllSessions
 	m2 := m
 
@@ -92,7 +94,7 @@ func TestAllSessionsOverlay_Select_TUIMode(t *testing.T) {
 		{ID: "s1", Status: session.StatusRunning, WorktreePath: "/tmp/wt/main"},
 		{ID: "s2", Status: session.StatusRunning, WorktreePath: "/tmp/wt/feature"},
 	}
-	m.allSessionsOverlay.Show(sessions, m.width, m.height)
+	m.allSessionsOverlay.Show(sessions, "", m.width, m.height)
 	m.focus = FocusAllSessions
 	m2 := m
 	require.True(t, m2.allSessionsOverlay.IsVisible())
@@ -226,7 +228,7 @@ func TestAllSessionsOverlay_FilterTerminal(t *testing.T) {
 			active = append(active, mixed[i])
 		}
 	}
-	m2.allSessionsOverlay.Show(active, m2.width, m2.height)
+	m2.allSessionsOverlay.Show(active, "", m2.width, m2.height)
 	assert.Len(t, m2.allSessionsOverlay.Sessions(), 2, "should filter out completed session")
 }
 
@@ -312,7 +314,7 @@ func TestAllSessionsOverlay_NewSession_PBC(t *testing.T) {
 			sessions := []session.SessionInfo{
 				{ID: "s1", Status: session.StatusRunning, WorktreePath: "/tmp/wt/main", WorktreeName: "main"},
 			}
-			m.allSessionsOverlay.Show(sessions, m.width, m.height)
+			m.allSessionsOverlay.Show(sessions, "", m.width, m.height)
 			m.focus = FocusAllSessions
 
 			newModel, _ := m.handleAllSessionsOverlay(keyPress(tc.key))
@@ -354,7 +356,7 @@ func TestAllSessionsOverlay_NewSession_TmuxModeShowsSelectedSessionContext(t *te
 		Title:        "Session B",
 	})
 	m.switchViewingSession(sessionA.ID)
-	m.allSessionsOverlay.Show([]session.SessionInfo{sessionA, sessionB}, m.width, m.height)
+	m.allSessionsOverlay.Show([]session.SessionInfo{sessionA, sessionB}, "", m.width, m.height)
 	m.focus = FocusAllSessions
 	require.True(t, m.allSessionsOverlay.SelectByNumber(2))
 
@@ -377,7 +379,7 @@ func TestAllSessionsOverlay_NewSession_NoSession(t *testing.T) {
 	m.worktreeDropdown.SelectIndex(0)
 
 	// Show overlay with no sessions
-	m.allSessionsOverlay.Show(nil, m.width, m.height)
+	m.allSessionsOverlay.Show(nil, "", m.width, m.height)
 	m.focus = FocusAllSessions
 
 	newModel, _ := m.handleAllSessionsOverlay(keyPress('p'))
@@ -400,7 +402,7 @@ func TestAllSessionsOverlay_NewSession_NoWorktree(t *testing.T) {
 	sessions := []session.SessionInfo{
 		{ID: "s1", Status: session.StatusRunning, WorktreePath: ""},
 	}
-	m.allSessionsOverlay.Show(sessions, m.width, m.height)
+	m.allSessionsOverlay.Show(sessions, "", m.width, m.height)
 	m.focus = FocusAllSessions
 
 	newModel, _ := m.handleAllSessionsOverlay(keyPress('b'))
@@ -411,3 +413,48 @@ func TestAllSessionsOverlay_NewSession_NoWorktree(t *testing.T) {
 	// Overlay should NOT have been hidden
 	assert.True(t, m2.allSessionsOverlay.IsVisible())
 }
+
+func TestAllSessionsOverlay_GroupsByWorktree(t *testing.T) {
+	o := NewAllSessionsOverlay()
+	o.Show([]session.SessionInfo{
+		{ID: "z1", Status: session.StatusRunning, WorktreePath: "/wt/zeta", WorktreeName: "zeta"},
+		{ID: "a1", Status: session.StatusRunning, WorktreePath: "/wt/alpha", WorktreeName: "alpha"},
+		{ID: "c1", Status: session.StatusIdle, WorktreePath: "/wt/cur", WorktreeName: "cur"},
+		{ID: "z2", Status: session.StatusIdle, WorktreePath: "/wt/zeta", WorktreeName: "zeta"},
+		{ID: "a2", Status: session.StatusRunning, WorktreePath: "/wt/alpha", WorktreeName: "alpha"},
+	}, "/wt/cur", 120, 40)
+
+	var ids []session.SessionID
+	for _, sess := range o.Sessions() {
+		ids = append(ids, sess.ID)
+	}
+	// Current worktree first, then the rest sorted, order kept within a group.
+	assert.Equal(t, []session.SessionID{"c1", "a1", "a2", "z1", "z2"}, ids)
+
+	// Number keys index the flattened, grouped order.
+	require.True(t, o.SelectByNumber(4))
+	assert.Equal(t, session.SessionID("z1"), o.SelectedSession().ID)
+
+	view := stripAnsi(o.View(NewStyles(Dark)))
+	assert.Contains(t, view, "cur (current) (1)")
+	assert.Contains(t, view, "alpha (2)")
+	assert.Contains(t, view, "zeta (2)")
+	assert.Less(t, strings.Index(view, "cur (current)"), strings.Index(view, "alpha (2)"))
+	assert.Less(t, strings.Index(view, "alpha (2)"), strings.Index(view, "zeta (2)"))
+}
+
+func TestAllSessionsOverlay_GroupHeadersFitWindow(t *testing.T) {
+	o := NewAllSessionsOverlay()
+	var sessions []session.SessionInfo
+	for i := 0; i < 12; i++ {
+		path := fmt.Sprintf("/wt/b%02d", i)
+		sessions = append(sessions, session.SessionInfo{ID: session.SessionID(fmt.Sprintf("s%02d", i)), WorktreePath: path})
+	}
+	o.Show(sessions, "", 120, 40)
+	o.selectedIdx = 11
+
+	// Each session is its own group, so every row costs a header too.
+	start, end := o.visibleRows(10)
+	assert.Equal(t, 5, end-start)
+	assert.Equal(t, 12, end)
+}
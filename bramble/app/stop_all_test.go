@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+func TestStopAllKey_TmuxModeShowsHint(t *testing.T) {
+	m := setupModel(t, session.SessionModeTmux, nil, "test-repo")
+
+	m2 := pressKey(m, 'X')
+
+	assert.Nil(t, m2.confirmPrompt)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "disabled in tmux mode")
+}
+
+func TestStopAllKey_NoActiveSessions(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	m.sessionManager.AddSession(&session.Session{ID: "done", Status: session.StatusCompleted})
+
+	m2 := pressKey(m, 'X')
+
+	assert.Nil(t, m2.confirmPrompt)
+	require.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "No active sessions")
+}
+
+func TestStopAllKey_ConfirmsAndStops(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	m.sessionManager.AddSession(&session.Session{ID: "s1", Status: session.StatusRunning, Progress: &session.SessionProgress{}})
+	m.sessionManager.AddSession(&session.Session{ID: "s2", Status: session.StatusIdle, Progress: &session.SessionProgress{}})
+	m.sessionManager.AddSession(&session.Session{ID: "s3", Status: session.StatusCompleted, Progress: &session.SessionProgress{}})
+
+	m2 := pressKey(m, 'X')
+	require.Equal(t, FocusConfirm, m2.focus)
+	assert.Contains(t, m2.confirmPrompt.message, "Stop all 2 active session(s)?")
+
+	newModel, cmd := m2.Update(keyPress('y'))
+	require.NotNil(t, cmd)
+	msg := cmd()
+	stopped, ok := msg.(allSessionsStoppedMsg)
+	require.True(t, ok, "expected allSessionsStoppedMsg, got %T", msg)
+	assert.Equal(t, 2, stopped.stopped)
+	assert.Empty(t, stopped.errs)
+
+	newModel, _ = newModel.(Model).Update(stopped)
+	m3 := newModel.(Model)
+	require.True(t, m3.toasts.HasToasts())
+	assert.Equal(t, ToastSuccess, m3.toasts.toasts[0].Level)
+	assert.Contains(t, m3.toasts.toasts[0].Message, "Stopped 2 session(s)")
+}
+
+func TestAllSessionsStoppedMsg_ReportsFailures(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, _ := m.Update(allSessionsStoppedMsg{stopped: 1, errs: []error{fmt.Errorf("session not active: s2")}})
+	m2 := newModel.(Model)
+
+	require.True(t, m2.toasts.HasToasts())
+	assert.Equal(t, ToastError, m2.toasts.toasts[0].Level)
+	assert.Contains(t, m2.toasts.toasts[0].Message, "1 failed")
+}
@@ -29,6 +29,7 @@ type TaskModal struct {
 	proposal       *taskrouter.RouteProposal
 	adjustWorktree string
 	adjustParent   string
+	stackParent    string // selected worktree's branch, offered as a parent in adjust
 	state          TaskModalState
 	width          int
 	height         int
@@ -146,6 +147,33 @@ func (m *TaskModal) StartAdjust() {
 	}
 }
 
+// SetStackCandidate records the branch of the currently selected worktree,
+// which the adjust state offers as the parent of a new worktree.
+func (m *TaskModal) SetStackCandidate(branch string) {
+	m.stackParent = branch
+}
+
+// ToggleStack switches the parent of a new worktree between the proposed
+// parent and the selected worktree's branch. It returns false when there is
+// no different branch to stack on.
+func (m *TaskModal) ToggleStack() bool {
+	if m.proposal == nil || m.stackParent == "" || m.stackParent == m.proposal.Parent {
+		return false
+	}
+	if m.adjustParent == m.stackParent {
+		m.adjustParent = m.proposal.Parent
+	} else {
+		m.adjustParent = m.stackParent
+	}
+	return true
+}
+
+// StackedOnCurrent reports whether the new worktree is set to stack on the
+// selected worktree's branch rather than the proposed parent.
+func (m *TaskModal) StackedOnCurrent() bool {
+	return m.proposal != nil && m.stackParent != "" && m.stackParent != m.proposal.Parent && m.adjustParent == m.stackParent
+}
+
 // AdjustTextArea returns the text area for the adjust state.
 func (m *TaskModal) AdjustTextArea() *TextArea {
 	return m.adjustTextArea
@@ -239,7 +267,18 @@ func (m *TaskModal) View(s *Styles) string {
 			m.adjustTextArea.SetPrompt("")
 			content.WriteString(m.adjustTextArea.View(s))
 			content.WriteString("\n")
-			content.WriteString("  Parent: " + s.Dim.Render(m.adjustParent))
+			content.WriteString("  Parent: " + s.Selected.Render(m.adjustParent))
+			if m.StackedOnCurrent() {
+				content.WriteString(s.Dim.Render(" (stacked on the current worktree)"))
+			}
+			if m.stackParent != "" && m.proposal != nil && m.stackParent != m.proposal.Parent {
+				content.WriteString("\n")
+				hint := formatKeyHints("Ctrl+S", "stack on "+m.stackParent)
+				if m.StackedOnCurrent() {
+					hint = formatKeyHints("Ctrl+S", "branch from "+m.proposal.Parent)
+				}
+				content.WriteString(s.Dim.Render("  " + hint))
+			}
 		}
 	}
 
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/bramble/taskrouter"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestTaskModal_ToggleStack(t *testing.T) {
+	tm := NewTaskModal()
+	tm.SetProposal(&taskrouter.RouteProposal{Action: taskrouter.ActionCreateNew, Worktree: "feature-b", Parent: "main"})
+
+	// Nothing to stack on, or the same branch as the proposed parent.
+	tm.SetStackCandidate("")
+	tm.StartAdjust()
+	assert.False(t, tm.ToggleStack())
+	tm.SetStackCandidate("main")
+	assert.False(t, tm.ToggleStack())
+	assert.Equal(t, "main", tm.AdjustedParent())
+
+	tm.SetStackCandidate("feature-a")
+	require.True(t, tm.ToggleStack())
+	assert.Equal(t, "feature-a", tm.AdjustedParent())
+	assert.True(t, tm.StackedOnCurrent())
+
+	require.True(t, tm.ToggleStack())
+	assert.Equal(t, "main", tm.AdjustedParent())
+	assert.False(t, tm.StackedOnCurrent())
+}
+
+func TestTaskModal_StackOnSelectedWorktree(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, []wt.Worktree{
+		{Branch: "main", Path: "/tmp/wt/main"},
+		{Branch: "feature-a", Path: "/tmp/wt/feature-a"},
+	}, "test-repo")
+	m.worktreeDropdown.SelectIndex(1)
+	m.taskModal.Show()
+	m.taskModal.SetPrompt("build the follow-up")
+	m.taskModal.SetProposal(&taskrouter.RouteProposal{Action: taskrouter.ActionCreateNew, Worktree: "feature-b", Parent: "main"})
+
+	newM, _ := m.handleTaskModal(keyPress('a'))
+	m = newM.(Model)
+	require.Equal(t, TaskModalAdjust, m.taskModal.State())
+	assert.Contains(t, m.taskModal.View(m.styles), "stack on feature-a")
+
+	newM, _ = m.handleTaskModal(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	m = newM.(Model)
+	assert.Equal(t, "feature-a", m.taskModal.AdjustedParent())
+	assert.Contains(t, m.taskModal.View(m.styles), "stacked on the current worktree")
+
+	_, cmd := m.handleTaskModal(specialKey(tea.KeyEnter))
+	require.NotNil(t, cmd)
+	confirm, ok := cmd().(taskConfirmMsg)
+	require.True(t, ok)
+	assert.Equal(t, "feature-b", confirm.worktree)
+	assert.Equal(t, "feature-a", confirm.parent)
+	assert.True(t, confirm.isNew)
+}
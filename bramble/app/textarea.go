@@ -204,6 +204,16 @@ func (t *TextArea) LineCount() int {
 	return t.inner.LineCount()
 }
 
+// OnFirstLine reports whether the cursor is on the first line.
+func (t *TextArea) OnFirstLine() bool {
+	return t.inner.Line() == 0
+}
+
+// OnLastLine reports whether the cursor is on the last line.
+func (t *TextArea) OnLastLine() bool {
+	return t.inner.Line() >= t.inner.LineCount()-1
+}
+
 // Cmd returns and clears any pending tea.Cmd produced by the last HandleKey
 // call (e.g. cursor blink scheduling from the inner bubbles textarea).
 // Callers should batch this into their returned command.
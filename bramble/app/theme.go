@@ -74,6 +74,9 @@ type Styles struct {
 	// All sessions overlay
 	AllSessionsBox lipgloss.Style
 
+	// Errored tool call card in the output pane
+	ToolErrorCard lipgloss.Style
+
 	// Split pane divider
 	Divider lipgloss.Style
 
@@ -208,6 +211,12 @@ func NewStyles(p ColorPalette) *Styles {
 			BorderForeground(border).
 			Padding(1, 2),
 
+		// Errored tool call card in the output pane
+		ToolErrorCard: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(errorC).
+			Padding(0, 1),
+
 		// Split pane divider
 		Divider: lipgloss.NewStyle().
 			Foreground(border),
@@ -372,11 +381,12 @@ var (
 	BuiltinThemes = []ColorPalette{Dark, Light, DarkDaltonized, LightDaltonized, DarkAnsi, LightAnsi}
 )
 
-// ThemeByName looks up a built-in theme by name.
+// ThemeByName looks up a built-in or custom theme by name.
 func ThemeByName(name string) (ColorPalette, bool) {
-	for i := range BuiltinThemes {
-		if BuiltinThemes[i].Name == name {
-			return BuiltinThemes[i], true
+	themes := AvailableThemes()
+	for i := range themes {
+		if themes[i].Name == name {
+			return themes[i], true
 		}
 	}
 	return ColorPalette{}, false
@@ -19,7 +19,7 @@ type ThemePicker struct {
 // NewThemePicker creates a new theme picker.
 func NewThemePicker() *ThemePicker {
 	return &ThemePicker{
-		themes: BuiltinThemes,
+		themes: AvailableThemes(),
 	}
 }
 
@@ -24,11 +24,12 @@ type Toast struct {
 	Duration  time.Duration // auto-dismiss after this duration
 	ID        int           // monotonic ID for dismissal targeting
 	Level     ToastLevel
+	Sticky    bool // never expires; cleared by DismissSticky on the next key press
 }
 
 // IsExpired returns true if the toast has exceeded its duration.
 func (t Toast) IsExpired(now time.Time) bool {
-	return now.After(t.CreatedAt.Add(t.Duration))
+	return !t.Sticky && now.After(t.CreatedAt.Add(t.Duration))
 }
 
 // maxToasts is the maximum number of visible toasts.
@@ -80,6 +81,35 @@ func (tm *ToastManager) Add(message string, level ToastLevel) {
 	}
 }
 
+// AddSticky adds a toast that stays up until DismissSticky, for problems the
+// user must see before doing anything else.
+func (tm *ToastManager) AddSticky(message string, level ToastLevel) {
+	tm.toasts = append(tm.toasts, Toast{
+		Message:   message,
+		Level:     level,
+		CreatedAt: time.Now(),
+		ID:        tm.nextID,
+		Sticky:    true,
+	})
+	tm.nextID++
+	if len(tm.toasts) > maxToasts {
+		tm.toasts = tm.toasts[len(tm.toasts)-maxToasts:]
+	}
+}
+
+// DismissSticky removes sticky toasts. Returns true if any were removed.
+func (tm *ToastManager) DismissSticky() bool {
+	var remaining []Toast
+	for _, t := range tm.toasts {
+		if !t.Sticky {
+			remaining = append(remaining, t)
+		}
+	}
+	changed := len(remaining) != len(tm.toasts)
+	tm.toasts = remaining
+	return changed
+}
+
 // Tick removes expired toasts. Returns true if any were removed
 // (caller should schedule next tick if toasts remain).
 func (tm *ToastManager) Tick(now time.Time) bool {
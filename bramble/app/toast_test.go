@@ -66,6 +66,18 @@ func TestToastIsExpired(t *testing.T) {
 	assert.False(t, toast2.IsExpired(time.Now()))
 }
 
+func TestToastSticky(t *testing.T) {
+	tm := NewToastManager()
+	tm.AddSticky("codex not found in PATH", ToastError)
+	tm.Add("hello", ToastInfo)
+
+	tm.Tick(time.Now().Add(time.Hour))
+	assert.Equal(t, 1, tm.Count(), "sticky toast outlives expiry")
+	assert.True(t, tm.DismissSticky())
+	assert.False(t, tm.HasToasts())
+	assert.False(t, tm.DismissSticky(), "nothing left to dismiss")
+}
+
 func TestToastHeight(t *testing.T) {
 	tm := NewToastManager()
 	assert.Equal(t, 0, tm.Height())
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// toolErrorTailLines is how many trailing lines of a failed tool's output
+// the error card shows.
+const toolErrorTailLines = 3
+
+// isToolError reports whether line is a tool call that finished with an
+// error.
+func isToolError(line *session.OutputLine) bool {
+	return line.Type == session.OutputTypeToolStart && line.ToolState == session.ToolStateError
+}
+
+// countToolErrors returns the number of errored tool calls in lines.
+func countToolErrors(lines []session.OutputLine) int {
+	n := 0
+	for i := range lines {
+		if isToolError(&lines[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+// toolErrorBadge renders the header badge for n errored tool calls, e.g.
+// "2 tool errors", or "" when there are none.
+func toolErrorBadge(n int) string {
+	switch n {
+	case 0:
+		return ""
+	case 1:
+		return "1 tool error"
+	default:
+		return fmt.Sprintf("%d tool errors", n)
+	}
+}
+
+// formatToolErrorCard renders an errored tool call as a red-bordered card:
+// the tool and its input on the first row, then the tail of the error
+// output. Every card line is prefixed with indent.
+func formatToolErrorCard(line *session.OutputLine, width int, indent string, s *Styles) string {
+	// Border and padding take two columns on each side.
+	inner := width - len(indent) - 6
+	if inner < 10 {
+		inner = 10
+	}
+	durationStr := fmt.Sprintf("%.2fs", float64(line.DurationMs)/1000)
+	rows := []string{s.Error.Render(truncate("✗ "+formatToolDisplay(line.ToolName, line.ToolInput, inner-12)+" ("+durationStr+")", inner))}
+	for _, tail := range lastLines(toolResultText(line.ToolResult), toolErrorTailLines) {
+		rows = append(rows, truncate(strings.ReplaceAll(tail, "\t", "    "), inner))
+	}
+
+	card := s.ToolErrorCard.Render(strings.Join(rows, "\n"))
+	if indent == "" {
+		return card
+	}
+	return indent + strings.ReplaceAll(card, "\n", "\n"+indent)
+}
+
+// toolResultText flattens a tool result into plain text. Results are a
+// string, a list of content blocks ({"type":"text","text":...}), or a
+// structured value, which is shown as JSON.
+func toolResultText(v interface{}) string {
+	switch r := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return r
+	case []interface{}:
+		var parts []string
+		for _, item := range r {
+			if block, ok := item.(map[string]interface{}); ok {
+				if text, ok := block["text"].(string); ok {
+					parts = append(parts, text)
+					continue
+				}
+			}
+			parts = append(parts, toolResultText(item))
+		}
+		return strings.Join(parts, "\n")
+	case map[string]interface{}:
+		for _, key := range []string{"stderr", "error", "text", "output"} {
+			if text, ok := r[key].(string); ok && strings.TrimSpace(text) != "" {
+				return text
+			}
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// lastLines returns the last n non-blank lines of text.
+func lastLines(text string, n int) []string {
+	var out []string
+	lines := strings.Split(text, "\n")
+	for i := len(lines) - 1; i >= 0 && len(out) < n; i-- {
+		if l := strings.TrimRight(lines[i], " \t\r"); strings.TrimSpace(l) != "" {
+			out = append(out, l)
+		}
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// jumpToNextToolError scrolls the output pane to the next errored tool call
+// after the one last jumped to, wrapping around to the oldest.
+func (m Model) jumpToNextToolError() (tea.Model, tea.Cmd) {
+	if m.viewingSessionID == "" || m.viewingHistoryData != nil || m.sessionManager.IsInTmuxMode() {
+		toastCmd := m.addToast("Select a live session to jump to its tool errors", ToastInfo)
+		return m, toastCmd
+	}
+	lines := m.sessionManager.GetSessionOutput(m.viewingSessionID)
+	var errs []int
+	for i := range lines {
+		if isToolError(&lines[i]) {
+			errs = append(errs, i)
+		}
+	}
+	if len(errs) == 0 {
+		toastCmd := m.addToast("No tool errors in this session", ToastInfo)
+		return m, toastCmd
+	}
+
+	next := errs[0]
+	if m.toolErrorJump.sessionID == m.viewingSessionID {
+		for _, idx := range errs {
+			if idx > m.toolErrorJump.current {
+				next = idx
+				break
+			}
+		}
+	}
+	m.toolErrorJump = toolErrorJump{sessionID: m.viewingSessionID, current: next}
+	m.scrollToOutputLine(m.viewingSessionID, next)
+	return m, nil
+}
+
+// toolErrorJump remembers the errored output line last jumped to, so
+// repeated presses walk through the session's errors in order.
+type toolErrorJump struct {
+	sessionID session.SessionID
+	current   int
+}
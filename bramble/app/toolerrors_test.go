@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+)
+
+// newToolErrorModel returns a model viewing a session with 60 status lines
+// and failed Bash calls at lines 10 and 45, on a 20-line terminal.
+func newToolErrorModel(t *testing.T) Model {
+	t.Helper()
+	mgr := session.NewManagerWithConfig(session.ManagerConfig{SessionMode: session.SessionModeTUI})
+	t.Cleanup(mgr.Close)
+
+	sessID := session.SessionID("tool-error-session")
+	mgr.AddSession(&session.Session{ID: sessID, Type: session.SessionTypeBuilder, Status: session.StatusRunning, Progress: &session.SessionProgress{}})
+	mgr.InitOutputBuffer(sessID)
+	for i := 0; i < 60; i++ {
+		line := session.OutputLine{Type: session.OutputTypeStatus, Content: fmt.Sprintf("Line-%03d", i)}
+		if i == 10 || i == 45 {
+			line = session.OutputLine{
+				Type:       session.OutputTypeToolStart,
+				ToolName:   "Bash",
+				ToolInput:  map[string]interface{}{"command": fmt.Sprintf("go test ./pkg%d", i)},
+				ToolState:  session.ToolStateError,
+				ToolResult: "ok  pkg/a\n--- FAIL: TestB\nFAIL pkg/b\nexit status 1\n",
+				DurationMs: 1200,
+				IsError:    true,
+			}
+		}
+		mgr.AddOutputLine(sessID, line)
+	}
+
+	m := NewModel(context.Background(), "/tmp/wt", "test-repo", "", mgr, nil, nil, 80, 20, nil, nil, session.ManagerConfig{}, nil)
+	m.viewingSessionID = sessID
+	return m
+}
+
+func TestFormatToolErrorCard(t *testing.T) {
+	line := session.OutputLine{
+		Type:       session.OutputTypeToolStart,
+		ToolName:   "Bash",
+		ToolInput:  map[string]interface{}{"command": "go build ./..."},
+		ToolState:  session.ToolStateError,
+		ToolResult: []interface{}{map[string]interface{}{"type": "text", "text": "line 1\nline 2\nmain.go:3: undefined: foo\nmain.go:4: undefined: bar\nexit status 1"}},
+		DurationMs: 2500,
+	}
+	card := stripAnsi(formatToolErrorCard(&line, 80, "  ", NewStyles(Dark)))
+
+	assert.Contains(t, card, "╭", "rendered as a bordered card")
+	assert.Contains(t, card, "✗")
+	assert.Contains(t, card, "go build ./...")
+	assert.Contains(t, card, "2.50s")
+	assert.Contains(t, card, "undefined: bar", "shows the error tail")
+	assert.Contains(t, card, "exit status 1")
+	assert.NotContains(t, card, "line 2", "only the last lines are shown")
+}
+
+func TestToolResultText(t *testing.T) {
+	assert.Equal(t, "", toolResultText(nil))
+	assert.Equal(t, "boom", toolResultText("boom"))
+	assert.Equal(t, "a\nb", toolResultText([]interface{}{
+		map[string]interface{}{"type": "text", "text": "a"},
+		map[string]interface{}{"type": "text", "text": "b"},
+	}))
+	assert.Equal(t, "permission denied", toolResultText(map[string]interface{}{"stdout": "", "stderr": "permission denied"}))
+	assert.Equal(t, `{"code":2}`, toolResultText(map[string]interface{}{"code": 2}))
+}
+
+func TestToolErrorBadgeInHeader(t *testing.T) {
+	m := newToolErrorModel(t)
+	_, outputHeight := m.outputPaneSize()
+	center := stripAnsi(m.renderCenter(80, outputHeight+5))
+	assert.Contains(t, center, "2 tool errors")
+
+	assert.Equal(t, "", toolErrorBadge(0))
+	assert.Equal(t, "1 tool error", toolErrorBadge(1))
+}
+
+func TestJumpToNextToolError(t *testing.T) {
+	m := newToolErrorModel(t)
+	_, outputHeight := m.outputPaneSize()
+
+	next, _ := m.handleKeyPress(keyPress('x'))
+	m = next.(Model)
+	assert.Equal(t, 10, m.toolErrorJump.current)
+	assert.Positive(t, m.scrollOffset)
+	assert.Contains(t, stripAnsi(m.renderCenter(80, outputHeight+5)), "go test ./pkg10")
+
+	next, _ = m.handleKeyPress(keyPress('x'))
+	m = next.(Model)
+	assert.Equal(t, 45, m.toolErrorJump.current)
+	assert.Contains(t, stripAnsi(m.renderCenter(80, outputHeight+5)), "go test ./pkg45")
+
+	// Wraps back to the oldest error.
+	next, _ = m.handleKeyPress(keyPress('x'))
+	m = next.(Model)
+	assert.Equal(t, 10, m.toolErrorJump.current)
+}
+
+func TestJumpToNextToolErrorWithoutErrors(t *testing.T) {
+	m := newSearchModel(t)
+	next, cmd := m.handleKeyPress(keyPress('x'))
+	m = next.(Model)
+	require.NotNil(t, cmd)
+	assert.Equal(t, 0, m.scrollOffset)
+	require.NotEmpty(t, m.toasts.toasts)
+	assert.Contains(t, m.toasts.toasts[len(m.toasts.toasts)-1].Message, "No tool errors")
+}
@@ -2,7 +2,10 @@ package app
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,6 +15,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
 	"github.com/bazelment/yoloswe/bramble/session"
 	"github.com/bazelment/yoloswe/bramble/taskrouter"
 	"github.com/bazelment/yoloswe/wt"
@@ -24,6 +28,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
 		m.lastUserInputAt = time.Now()
+		// A sticky toast (e.g. a missing provider at startup) swallows the
+		// first key press so it is read before anything else happens.
+		if m.toasts.DismissSticky() {
+			return m, nil
+		}
+		// The command palette sits above every other overlay.
+		if m.focus == FocusCommandPalette {
+			return m.handleCommandPalette(msg)
+		}
+		if msg.String() == "ctrl+p" && m.canOpenCommandPalette() {
+			return m.openCommandPalette()
+		}
 		// Handle help overlay first (highest visual priority)
 		if m.focus == FocusHelp {
 			return m.handleHelpOverlay(msg)
@@ -64,6 +80,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.focus == FocusRepoDropdown {
 			return m.handleRepoDropdownMode(msg)
 		}
+		// Handle output search query input
+		if m.focus == FocusSearch {
+			return m.handleSearchInput(msg)
+		}
 		// Handle normal key presses
 		return m.handleKeyPress(msg)
 
@@ -92,6 +112,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.allSessionsOverlay.SetSize(msg.Width, msg.Height)
 		m.commandCenter.SetSize(msg.Width, msg.Height)
 		m.themePicker.SetSize(msg.Width, msg.Height)
+		m.commandPalette.SetSize(msg.Width, msg.Height)
 		m.repoSettingsDialog.SetSize(msg.Width, msg.Height)
 		// Update dropdown sizing to maximize visible menu space.
 		m.configureAllDropdownsForViewport()
@@ -114,6 +135,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		firstLoad := !m.worktreesLoaded
 		m.worktrees = msg.worktrees
 		m.worktreesLoaded = true
 		m.updateWorktreeDropdown()
@@ -152,8 +174,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(pendingCancelCmd, deferredRefreshCmd())
 		}
 
-		// Auto-select first worktree if none selected
-		if m.worktreeDropdown.SelectedItem() == nil && len(m.worktrees) > 0 {
+		// On the first load, restore the selection from the last launch;
+		// otherwise auto-select the first worktree if none is selected.
+		if firstLoad && !m.inputMode && len(m.worktrees) > 0 {
+			m.restoreLastSelection()
+		} else if m.worktreeDropdown.SelectedItem() == nil && len(m.worktrees) > 0 {
 			m.worktreeDropdown.SelectIndex(0)
 		}
 		// Update session dropdown with live sessions immediately;
@@ -168,7 +193,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		cmds = append(cmds,
 			m.fetchGitStatuses(RefreshAll),
-			m.fetchPRStatuses(), schedulePRStatusTick(),
+			m.fetchFullStatuses(), schedulePRStatusTick(),
 			m.refreshFileTree(), m.refreshHistorySessions(),
 		)
 		return m, tea.Batch(cmds...)
@@ -203,72 +228,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.applyBatchWorktreeStatuses(msg)
 		return m, tea.Batch(cmds...)
 
-	case batchPRInfoMsg:
+	case repoStatusesMsg:
 		// If this response is for a repo that is no longer the active one,
 		// save the data into the correct RepoContext and discard for current view.
 		if msg.repoName != m.repoName {
 			if rc, ok := m.repos[msg.repoName]; ok {
-				if rc.worktreeStatuses == nil {
-					rc.worktreeStatuses = make(map[string]*wt.WorktreeStatus)
-				}
-				prByBranch := make(map[string]*wt.PRInfo, len(msg.prs))
-				for i := range msg.prs {
-					prByBranch[msg.prs[i].HeadRefName] = &msg.prs[i]
-				}
-				for _, w := range rc.worktrees {
-					status := rc.worktreeStatuses[w.Branch]
-					if status == nil {
-						status = &wt.WorktreeStatus{}
-						rc.worktreeStatuses[w.Branch] = status
-					}
-					if pr, ok := prByBranch[w.Branch]; ok {
-						status.PRNumber = pr.Number
-						status.PRURL = pr.URL
-						status.PRState = pr.State
-						status.PRIsDraft = pr.IsDraft
-						status.PRReviewStatus = pr.ReviewDecision
-					} else {
-						status.PRNumber = 0
-						status.PRURL = ""
-						status.PRState = ""
-						status.PRIsDraft = false
-						status.PRReviewStatus = ""
-					}
-				}
+				rc.worktreeStatuses = replaceStatuses(rc.worktreeStatuses, msg.statuses, msg.known)
 			}
 			return m, nil
 		}
-		if m.worktreeStatuses == nil {
-			m.worktreeStatuses = make(map[string]*wt.WorktreeStatus)
-		}
-		// Build headRefName -> PRInfo map
-		prByBranch := make(map[string]*wt.PRInfo, len(msg.prs))
-		for i := range msg.prs {
-			prByBranch[msg.prs[i].HeadRefName] = &msg.prs[i]
-		}
-		// Apply to each worktree's status
-		for _, w := range m.worktrees {
-			status := m.worktreeStatuses[w.Branch]
-			if status == nil {
-				status = &wt.WorktreeStatus{}
-				m.worktreeStatuses[w.Branch] = status
-			}
-			if pr, ok := prByBranch[w.Branch]; ok {
-				status.PRNumber = pr.Number
-				status.PRURL = pr.URL
-				status.PRState = pr.State
-				status.PRIsDraft = pr.IsDraft
-				status.PRReviewStatus = pr.ReviewDecision
-			} else {
-				// No open PR for this branch — clear PR data so we don't
-				// show stale OPEN state after a PR is merged or closed.
-				status.PRNumber = 0
-				status.PRURL = ""
-				status.PRState = ""
-				status.PRIsDraft = false
-				status.PRReviewStatus = ""
-			}
-		}
+		m.worktreeStatuses = replaceStatuses(m.worktreeStatuses, msg.statuses, msg.known)
 		m.updateWorktreeDropdown()
 		return m, tea.Batch(cmds...)
 
@@ -301,7 +270,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.fetchDirtyGitStatuses()
 
 	case refreshPRStatusTickMsg:
-		return m, tea.Batch(m.fetchPRStatuses(), schedulePRStatusTick())
+		return m, tea.Batch(m.fetchFullStatuses(), schedulePRStatusTick())
 
 	case gitWorktreeInvalidation:
 		m.markWorktreeDirty(msg.repoName, msg.worktreePath)
@@ -312,15 +281,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
-	case repoSessionEventMsg:
-		// Update the correct RepoContext's sessions. If the event is for the
-		// active repo, also update Model's cached fields directly.
-		if msg.repoName == m.repoName {
-			m.sessions = m.sessionManager.GetAllSessions()
-			m.updateSessionDropdown()
-		} else if rc, ok := m.repos[msg.repoName]; ok {
-			rc.sessions = rc.sessionManager.GetAllSessions()
+	case outputBatchMsg:
+		for _, repoName := range msg.repoNames {
+			m.refreshRepoSessions(repoName)
+		}
+		if msg.next != nil {
+			return m.Update(repoSessionEventMsg{repoName: msg.next.repoName, event: msg.next.event})
 		}
+		m.refreshCommandCenter()
+		return m, m.listenForSessionEvents()
+
+	case repoSessionEventMsg:
+		m.refreshRepoSessions(msg.repoName)
 
 		// Trigger voice reporting on session completion.
 		if stateEvt, ok := msg.event.(session.SessionStateChangeEvent); ok {
@@ -349,6 +321,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Recount the session's changes each time a turn ends.
+		if stateEvt, ok := msg.event.(session.SessionStateChangeEvent); ok {
+			switch stateEvt.NewStatus {
+			case session.StatusIdle, session.StatusCompleted, session.StatusFailed, session.StatusStopped:
+				mgr := m.sessionManager
+				if rc, ok := m.repos[msg.repoName]; ok && msg.repoName != m.repoName {
+					mgr = rc.sessionManager
+				}
+				cmds = append(cmds, refreshSessionDiffStat(mgr, stateEvt.SessionID))
+			}
+		}
+
+		// Review a builder's work once its turn ends, if the repo opted in.
+		if stateEvt, ok := msg.event.(session.SessionStateChangeEvent); ok && stateEvt.NewStatus == session.StatusIdle {
+			mgr := m.sessionManager
+			if rc, ok := m.repos[msg.repoName]; ok && msg.repoName != m.repoName {
+				mgr = rc.sessionManager
+			}
+			cmds = append(cmds, m.onSessionIdle(msg.repoName, mgr, stateEvt.SessionID))
+		}
+
+		// Prompt right away when the session on screen asks to run a
+		// command; otherwise point the user at it.
+		if stateEvt, ok := msg.event.(session.SessionStateChangeEvent); ok &&
+			stateEvt.NewStatus == session.StatusWaitingApproval && msg.repoName == m.repoName {
+			if sess := m.selectedSession(); sess != nil && sess.ID == stateEvt.SessionID && m.focus == FocusOutput {
+				newM, cmd := m.showApprovalPrompt(sess)
+				m = newM.(Model)
+				cmds = append(cmds, cmd)
+			} else {
+				cmds = append(cmds, m.addToast("A session is waiting for command approval", ToastInfo))
+			}
+		}
+
 		// Auto-refresh command center if visible.
 		m.refreshCommandCenter()
 		cmds = append(cmds, m.listenForSessionEvents())
@@ -359,6 +365,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.populateRepoDropdown(msg.repos)
 		return m, nil
 
+	case allSessionsStoppedMsg:
+		m.sessions = m.sessionManager.GetAllSessions()
+		m.updateSessionDropdown()
+		m.refreshCommandCenter()
+		if len(msg.errs) > 0 {
+			toastCmd := m.addToast(fmt.Sprintf("Stopped %d session(s); %d failed: %v", msg.stopped, len(msg.errs), msg.errs[0]), ToastError)
+			return m, toastCmd
+		}
+		toastCmd := m.addToast(fmt.Sprintf("Stopped %d session(s)", msg.stopped), ToastSuccess)
+		return m, toastCmd
+
 	case sessionsUpdated:
 		m.sessions = m.sessionManager.GetAllSessions()
 		m.updateSessionDropdown()
@@ -366,6 +383,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.refreshCommandCenter()
 		return m, nil
 
+	case sessionRevivedMsg:
+		if m.viewingSessionID == msg.id {
+			// Swap the read-only replay for the live session's output.
+			m.viewingHistoryData = nil
+			m.scrollToBottom()
+		}
+		m.sessions = m.sessionManager.GetAllSessions()
+		m.updateSessionDropdown()
+		toastCmd := m.addToast("Session revived — press f to send a follow-up", ToastSuccess)
+		return m, toastCmd
+
 	case errMsg:
 		cmd := m.addToast(msg.Error(), ToastError)
 		return m, cmd
@@ -381,7 +409,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case startSessionMsg:
-		m.saveDefaultModel(msg.sessionType, msg.model)
+		// A failed write only loses the sticky default; the session still starts.
+		_ = m.saveDefaultModel(msg.sessionType, msg.model)
 		if msg.target.worktreePath != "" && !m.canLaunchSessionOnTarget(msg.target) {
 			toastCmd := m.addToast(errTargetWorktreeUnavailable, ToastError)
 			return m, toastCmd
@@ -421,6 +450,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case worktreeOpResultMsg:
 		if msg.err != nil {
 			cmds = append(cmds, m.addToast(msg.err.Error(), ToastError))
+		} else if msg.summary != "" {
+			cmds = append(cmds, m.addToast(msg.summary, ToastSuccess))
 		} else if len(msg.messages) > 0 {
 			cmds = append(cmds, m.addToast("Worktree operation completed", ToastSuccess))
 		}
@@ -434,7 +465,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.pendingPlannerPrompt = "" // clear any stale task prompt
 		}
 		// Refresh worktrees and one-shot PR fetch (no new timer)
-		cmds = append(cmds, m.refreshWorktrees(), m.fetchPRStatuses())
+		cmds = append(cmds, m.refreshWorktrees(), m.fetchFullStatuses())
 		return m, tea.Batch(cmds...)
 
 	case editorResultMsg:
@@ -443,6 +474,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case costReportMsg:
+		if msg.err != nil {
+			cmds = append(cmds, m.addToast("Failed to export cost report: "+msg.err.Error(), ToastError))
+		} else {
+			cmds = append(cmds, m.addToast("Cost report written to "+msg.path, ToastSuccess))
+		}
+		return m, tea.Batch(cmds...)
+
+	case sessionDiffStatMsg:
+		// Sessions without a recorded start commit simply get no badge.
+		if msg.err != nil {
+			return m, nil
+		}
+		if m.sessionDiffStats == nil {
+			m.sessionDiffStats = make(map[session.SessionID]session.DiffStat)
+		}
+		m.sessionDiffStats[msg.id] = msg.stat
+		m.updateSessionDropdown()
+		return m, nil
+
+	case popOutMsg:
+		if msg.err != nil {
+			cmds = append(cmds, m.addToast("Failed to pop out session: "+msg.err.Error(), ToastError))
+			return m, tea.Batch(cmds...)
+		}
+		if m.popOutWindows == nil {
+			m.popOutWindows = make(map[session.SessionID]string)
+		}
+		m.popOutWindows[msg.sessionID] = msg.windowID
+		return m, tea.Batch(cmds...)
+
 	case tmuxWindowMsg:
 		if msg.err != nil {
 			cmds = append(cmds, m.addToast("Failed to open tmux window: "+msg.err.Error(), ToastError))
@@ -472,10 +534,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 
 	case deleteWorktreeMsg:
-		return m.deleteWorktree(msg.branch, msg.deleteBranch)
+		return m.deleteWorktree(msg.branch, msg.deleteBranch, msg.force)
+
+	case worktreeDirtyMsg:
+		m.worktreeOpMessages = nil
+		return m.confirmDiscardWorktree(msg.branch, msg.deleteBranch)
+
+	case worktreeCleanMsg:
+		return m.removeWorktree(msg.branch, msg.deleteBranch, false)
+
+	case renameWorktreeMsg:
+		return m.renameWorktree(msg.branch, msg.newBranch)
+
+	case worktreeRenamedMsg:
+		return m.handleWorktreeRenamed(msg)
 
 	case mergePRMsg:
-		return m.mergePR(msg.branch, msg.mergeMethod)
+		return m.mergePR(msg.branch, msg.mergeMethod, msg.auto)
 
 	case mergePRDoneMsg:
 		return m.handleMergePRDone(msg)
@@ -528,6 +603,13 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// While a search is active, n/N step through matches and Esc clears it.
+	if m.searchActive() && m.focus == FocusOutput {
+		if next, cmd, handled := m.handleSearchKey(msg); handled {
+			return next, cmd
+		}
+	}
+
 	switch msg.String() {
 	case "?":
 		// Open help overlay
@@ -549,7 +631,7 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 				continue
 			}
 			counts := rc.sessionManager.CountByStatus()
-			activeCount += counts[session.StatusRunning] + counts[session.StatusIdle] + counts[session.StatusPending]
+			activeCount += counts[session.StatusRunning] + counts[session.StatusWaitingApproval] + counts[session.StatusIdle] + counts[session.StatusPending]
 		}
 		if activeCount > 0 {
 			m.confirmQuit = true
@@ -587,7 +669,7 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			// Tmux mode: open all sessions overlay so the user can switch
 			// to a session's tmux window via select-window.
 			activeSessions := m.gatherActiveSessions()
-			m.allSessionsOverlay.Show(activeSessions, m.width, m.height)
+			m.allSessionsOverlay.Show(activeSessions, m.selectedWorktreePath(), m.width, m.height)
 			m.focus = FocusAllSessions
 			return m, nil
 		}
@@ -689,6 +771,12 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.scrollToTop()
 		return m, nil
 
+	case "/":
+		return m.startSearch()
+
+	case "x":
+		return m.jumpToNextToolError()
+
 	case "end":
 		m.scrollToBottom()
 		return m, nil
@@ -720,6 +808,16 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		toastCmd := m.addToast("Select a worktree first (Alt-W)", ToastInfo)
 		return m, toastCmd
 
+	case "J":
+		return m.jumpToSessionWorktree()
+
+	case "P":
+		// Pin/unpin the session to the always-visible pin strip
+		return m.togglePin()
+
+	case "alt+p":
+		return m.jumpToPinnedSession()
+
 	case "e":
 		// Open editor for worktree
 		if wt := m.selectedWorktree(); wt != nil {
@@ -779,6 +877,10 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		toastCmd := m.addToast("Select a worktree first (Alt-W)", ToastInfo)
 		return m, toastCmd
 
+	case "O":
+		// Pop the viewed session out into a tmux window that follows it
+		return m.popOutSession()
+
 	case "s":
 		// Stop session with confirmation (TUI mode only)
 		if m.sessionManager.IsInTmuxMode() {
@@ -803,10 +905,45 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		toastCmd := m.addToast("No active session to stop (Alt-S to select)", ToastInfo)
 		return m, toastCmd
 
+	case "$":
+		// Export the current repo's per-session cost report.
+		if m.repoName == "" {
+			toastCmd := m.addToast("No repository loaded", ToastError)
+			return m, toastCmd
+		}
+		return m, m.exportCostReport()
+
+	case "X":
+		// Stop every active session across all opened repos (TUI mode only).
+		if m.sessionManager.IsInTmuxMode() {
+			toastCmd := m.addToast("Stop-all is disabled in tmux mode; close windows with prefix+& instead", ToastInfo)
+			return m, toastCmd
+		}
+		managers := m.allSessionManagers()
+		activeCount := 0
+		for _, mgr := range managers {
+			activeCount += mgr.ActiveSessionCount()
+		}
+		if activeCount == 0 {
+			toastCmd := m.addToast("No active sessions to stop", ToastInfo)
+			return m, toastCmd
+		}
+		return m.showConfirm(fmt.Sprintf("Stop all %d active session(s)?", activeCount), []ConfirmOption{
+			{Key: "y", Label: "yes"},
+		}, func(key string) tea.Cmd {
+			return func() tea.Msg {
+				var errs []error
+				for _, mgr := range managers {
+					errs = append(errs, mgr.StopAllSessions()...)
+				}
+				return allSessionsStoppedMsg{stopped: activeCount - len(errs), errs: errs}
+			}
+		})
+
 	case "S":
 		// Open all sessions overlay — aggregate across ALL opened repos.
 		activeSessions := m.gatherActiveSessions()
-		m.allSessionsOverlay.Show(activeSessions, m.width, m.height)
+		m.allSessionsOverlay.Show(activeSessions, m.selectedWorktreePath(), m.width, m.height)
 		m.focus = FocusAllSessions
 		return m, nil
 
@@ -858,9 +995,10 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			return m, toastCmd
 		}
 		if sess := m.selectedSession(); sess != nil {
+			sessWorktree := sess.WorktreePath
 			if sess.Status == session.StatusIdle {
 				sessID := sess.ID
-				return m.promptInput("Follow-up: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
+				next, cmd := m.promptInputWithHistory(sessWorktree, "Follow-up: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
 					return func() tea.Msg {
 						if err := m.sessionManager.SendFollowUp(sessID, message); err != nil {
 							return errMsg{err}
@@ -868,10 +1006,15 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 						return sessionsUpdated{}
 					}
 				}, "Type your follow-up message...")
+				nm := next.(Model)
+				if suggestion, ok := nm.autoReviews.takeSuggestion(sessID); ok {
+					nm.inputArea.SetValue(suggestion)
+				}
+				return nm, cmd
 			}
 			if sess.IsResumable() {
 				sessID := sess.ID
-				return m.promptInput("Resume: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
+				return m.promptInputWithHistory(sessWorktree, "Resume: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
 					return func() tea.Msg {
 						if err := m.sessionManager.ResumeSession(sessID, message); err != nil {
 							return errMsg{err}
@@ -884,9 +1027,33 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		toastCmd := m.addToast("Session not available for follow-up or resume", ToastInfo)
 		return m, toastCmd
 
+	case "v":
+		// Revive a history session as a live, idle session (TUI mode only)
+		if m.sessionManager.IsInTmuxMode() {
+			toastCmd := m.addToast("Reviving history sessions is not available in tmux mode", ToastInfo)
+			return m, toastCmd
+		}
+		if m.viewingHistoryData == nil {
+			toastCmd := m.addToast("Open a history session to revive it", ToastInfo)
+			return m, toastCmd
+		}
+		storedID := m.viewingHistoryData.ID
+		mgr := m.sessionManager
+		return m, func() tea.Msg {
+			id, err := mgr.ReviveSession(storedID)
+			if err != nil {
+				return errMsg{err}
+			}
+			return sessionRevivedMsg{id: id}
+		}
+
 	case "a":
-		// Approve plan and start builder session
+		// Answer a pending command approval, or approve plan and start
+		// builder session
 		sess := m.selectedSession()
+		if sess != nil && sess.Status == session.StatusWaitingApproval {
+			return m.showApprovalPrompt(sess)
+		}
 		if sess == nil || sess.Status != session.StatusIdle ||
 			sess.Type != session.SessionTypePlanner || sess.PlanFilePath == "" {
 			toastCmd := m.addToast("No plan ready to approve", ToastInfo)
@@ -916,9 +1083,13 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		toastCmd := m.addToast("Select a worktree first (Alt-W)", ToastInfo)
 		return m, toastCmd
 
+	case "N":
+		// Rename worktree (branch + directory)
+		return m.promptRenameWorktree()
+
 	case "r":
 		// Refresh (worktrees + one-shot PR fetch, no new timer)
-		return m, tea.Batch(m.refreshWorktrees(), m.fetchGitStatuses(RefreshAll), m.fetchPRStatuses())
+		return m, tea.Batch(m.refreshWorktrees(), m.fetchGitStatuses(RefreshAll), m.fetchFullStatuses())
 
 	case "g":
 		// Sync current worktree (fetch + rebase)
@@ -969,6 +1140,18 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// refreshRepoSessions reloads a repo's session list after its manager
+// emitted events. For the active repo the cached session fields and the
+// session dropdown are updated too.
+func (m *Model) refreshRepoSessions(repoName string) {
+	if repoName == m.repoName {
+		m.sessions = m.sessionManager.GetAllSessions()
+		m.updateSessionDropdown()
+	} else if rc, ok := m.repos[repoName]; ok {
+		rc.sessions = rc.sessionManager.GetAllSessions()
+	}
+}
+
 // refreshCommandCenter re-renders the command center with up-to-date session
 // data. UpdateSessions preserves the cursor's session by ID across re-sorts.
 func (m *Model) refreshCommandCenter() {
@@ -993,6 +1176,9 @@ func (m *Model) scrollOutput(delta int) {
 	if m.scrollOffset < 0 {
 		m.scrollOffset = 0
 	}
+	if delta < 0 && m.scrollOffset == 0 {
+		m.markOutputSeen()
+	}
 	// Max offset is clamped in renderCenter based on actual line count
 }
 
@@ -1005,15 +1191,41 @@ func (m *Model) scrollToTop() {
 // scrollToBottom scrolls to the end (latest) output.
 func (m *Model) scrollToBottom() {
 	m.scrollOffset = 0
+	m.markOutputSeen()
+}
+
+// markOutputSeen records that everything the viewed live session has output
+// so far has been seen, moving its "new since last view" divider past the
+// end. It is called when the user scrolls to the bottom or leaves the
+// session while at the bottom, not when output merely arrives.
+func (m *Model) markOutputSeen() {
+	if m.viewingSessionID == "" || m.viewingHistoryData != nil || m.sessionManager == nil {
+		return
+	}
+	if m.lastSeenOutput == nil {
+		m.lastSeenOutput = make(map[session.SessionID]int)
+	}
+	m.lastSeenOutput[m.viewingSessionID] = len(m.sessionManager.GetSessionOutput(m.viewingSessionID))
+}
+
+// leaveViewingSession saves the current session's scroll position before
+// another session is shown, marking its output seen if the user was at the
+// bottom.
+func (m *Model) leaveViewingSession() {
+	if m.viewingSessionID == "" {
+		return
+	}
+	m.scrollPositions[m.viewingSessionID] = m.scrollOffset
+	if m.scrollOffset == 0 {
+		m.markOutputSeen()
+	}
 }
 
 // switchViewingSession saves the scroll position for the current session,
 // sets the viewing session to newID, and restores the saved scroll position
 // (or 0 if none was saved).
 func (m *Model) switchViewingSession(newID session.SessionID) {
-	if m.viewingSessionID != "" {
-		m.scrollPositions[m.viewingSessionID] = m.scrollOffset
-	}
+	m.leaveViewingSession()
 	m.viewingSessionID = newID
 	m.scrollOffset = m.scrollPositions[newID] // zero-value (0) if not found
 	m.viewingHistoryData = nil
@@ -1169,9 +1381,38 @@ func (m Model) handleInputMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			prefix = "CodeTalk"
 		}
 		m.inputPrompt = fmt.Sprintf("%s prompt [%s]:", prefix, m.pendingModel)
+		if err := m.saveDefaultModel(m.pendingSessionType, m.pendingModel); err != nil {
+			toastCmd := m.addToast("Failed to save default model: "+err.Error(), ToastError)
+			return m, toastCmd
+		}
 		return m, nil
 	}
 
+	// Up/Down on the first/last line recall earlier prompts, shell-style;
+	// Esc returns from a recalled entry to the draft.
+	if h := m.inputHistory; h != nil && m.inputArea.Focus() == FocusTextInput {
+		switch msg.String() {
+		case "up":
+			if m.inputArea.OnFirstLine() {
+				if v, ok := h.Prev(m.inputArea.Value()); ok {
+					m.inputArea.SetValue(v)
+				}
+				return m, nil
+			}
+		case "down":
+			if m.inputArea.OnLastLine() && h.Recalling() {
+				v, _ := h.Next()
+				m.inputArea.SetValue(v)
+				return m, nil
+			}
+		case "esc":
+			if h.Recalling() {
+				m.inputArea.SetValue(h.Restore())
+				return m, nil
+			}
+		}
+	}
+
 	action := m.inputArea.HandleKey(msg)
 
 	switch action {
@@ -1181,9 +1422,16 @@ func (m Model) handleInputMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.inputArea.Reset()
-		return m, func() tea.Msg {
+		submit := func() tea.Msg {
 			return promptInputMsg{value}
 		}
+		if m.inputHistoryKey != "" {
+			m.settings.AddPromptHistory(m.inputHistoryKey, value)
+			if err := SaveSettings(m.settings); err != nil {
+				return m, tea.Batch(submit, m.addToast("Failed to save prompt history: "+err.Error(), ToastError))
+			}
+		}
+		return m, submit
 
 	case TextAreaCancel:
 		m.clearPendingPrompt()
@@ -1241,6 +1489,49 @@ func (m Model) showConfirm(message string, options []ConfirmOption, handler func
 	return m, nil
 }
 
+// showApprovalPrompt asks the user whether the command sess is waiting on
+// may run. Esc leaves the request pending; press a to answer it later.
+func (m Model) showApprovalPrompt(sess *session.SessionInfo) (tea.Model, tea.Cmd) {
+	if sess.PendingApproval == nil {
+		return m, nil
+	}
+	msg := "Run command?\n\n  " + sess.PendingApproval.Command
+	if sess.PendingApproval.Reason != "" {
+		msg += "\n\n" + sess.PendingApproval.Reason
+	}
+	sessID := sess.ID
+	mgr := m.sessionManager
+	return m.showConfirm(msg, []ConfirmOption{
+		{Key: "y", Label: "approve"},
+		{Key: "s", Label: "approve for session"},
+		{Key: "n", Label: "deny"},
+	}, func(key string) tea.Cmd {
+		decision := codex.ApprovalApprove
+		switch key {
+		case "s":
+			decision = codex.ApprovalApproveForSession
+		case "n":
+			decision = codex.ApprovalDeny
+		}
+		return func() tea.Msg {
+			if err := mgr.RespondToApproval(sessID, decision); err != nil {
+				return errMsg{err}
+			}
+			return sessionsUpdated{}
+		}
+	})
+}
+
+// promptInputWithHistory is promptInput with up/down recall of the prompts
+// previously submitted in worktreePath.
+func (m Model) promptInputWithHistory(worktreePath, prompt string, handler func(value, model string, sessionType session.SessionType) tea.Cmd, placeholder ...string) (tea.Model, tea.Cmd) {
+	if worktreePath != "" {
+		m.inputHistoryKey = worktreePath
+		m.inputHistory = newPromptHistory(m.settings.PromptHistoryFor(worktreePath))
+	}
+	return m.promptInput(prompt, handler, placeholder...)
+}
+
 // promptInput switches to input mode with an optional placeholder.
 func (m Model) promptInput(prompt string, handler func(value, model string, sessionType session.SessionType) tea.Cmd, placeholder ...string) (tea.Model, tea.Cmd) {
 	m.inputMode = true
@@ -1254,19 +1545,34 @@ func (m Model) promptInput(prompt string, handler func(value, model string, sess
 	return m, nil
 }
 
-// saveDefaultModel persists the user's model choice for future sessions of the same type.
-func (m *Model) saveDefaultModel(sessionType session.SessionType, model string) {
+// saveDefaultModel makes model the default for future sessions of the same
+// type and persists it in the current repo's settings, so the choice
+// survives a relaunch.
+func (m *Model) saveDefaultModel(sessionType session.SessionType, model string) error {
 	if model == "" {
-		return
+		return nil
 	}
+	cfg := m.settings.RepoSettingsFor(m.repoName)
+	var saved *string
 	switch sessionType {
 	case session.SessionTypePlanner:
 		m.defaultPlanModel = model
+		saved = &cfg.PlanModel
 	case session.SessionTypeBuilder:
 		m.defaultBuildModel = model
+		saved = &cfg.BuildModel
 	case session.SessionTypeCodeTalk:
 		m.defaultCodeTalkModel = model
+		saved = &cfg.CodeTalkModel
+	default:
+		return nil
+	}
+	if *saved == model {
+		return nil
 	}
+	*saved = model
+	m.settings.SetRepoSettings(m.repoName, cfg)
+	return SaveSettings(m.settings)
 }
 
 // sessionTypeFromKey maps a key press ("p", "b", "c") to a SessionType.
@@ -1418,14 +1724,32 @@ func (m Model) approveAndStartBuilder(sess *session.SessionInfo) (Model, tea.Cmd
 		toastCmd := m.addToast(err.Error(), ToastError)
 		return m, toastCmd, false
 	}
-	if m.viewingSessionID != "" {
-		m.scrollPositions[m.viewingSessionID] = m.scrollOffset
-	}
+	m.leaveViewingSession()
 	m.viewingSessionID = sessionID
 	m.scrollOffset = 0
 	m.sessions = m.sessionManager.GetAllSessions()
 	m.updateSessionDropdown()
-	return m, nil, true
+	return m, touchWorktreeActivity(m.wtRoot, m.repoName, worktreePath), true
+}
+
+// jumpToSessionWorktree selects the viewed session's worktree so worktree
+// actions (merge, diff, sync) apply to it, keeping the session in view.
+func (m Model) jumpToSessionWorktree() (tea.Model, tea.Cmd) {
+	sess := m.selectedSession()
+	if sess == nil {
+		toastCmd := m.addToast("No session selected", ToastInfo)
+		return m, toastCmd
+	}
+	if wt := m.selectedWorktree(); wt != nil && wt.Path == sess.WorktreePath {
+		toastCmd := m.addToast("Already on "+wt.Branch, ToastInfo)
+		return m, toastCmd
+	}
+	if !m.selectWorktreeByPath(sess.WorktreePath) {
+		toastCmd := m.addToast("Worktree "+sess.WorktreeName+" not found", ToastError)
+		return m, toastCmd
+	}
+	m.updateSessionDropdown()
+	return m, tea.Batch(m.refreshFileTree(), m.refreshHistorySessions())
 }
 
 func (m *Model) selectWorktreeByPath(path string) bool {
@@ -1446,6 +1770,8 @@ func (m *Model) clearPendingPrompt() {
 	m.pendingModel = ""
 	m.pendingSessionType = ""
 	m.pendingSessionTarget = sessionTarget{}
+	m.inputHistory = nil
+	m.inputHistoryKey = ""
 }
 
 func (m Model) cancelPendingSessionPrompt(message string) (Model, tea.Cmd) {
@@ -1476,15 +1802,13 @@ func (m Model) startSessionOnPath(sessionType session.SessionType, prompt, model
 		return m, toastCmd
 	}
 
-	if m.viewingSessionID != "" {
-		m.scrollPositions[m.viewingSessionID] = m.scrollOffset
-	}
+	m.leaveViewingSession()
 	m.viewingSessionID = sessionID
 	m.scrollOffset = 0 // New session starts at bottom
 	m.sessions = m.sessionManager.GetAllSessions()
 	m.updateSessionDropdown()
 	toastCmd := m.addToast("Session started: "+truncateSessionID(sessionID), ToastSuccess)
-	return m, toastCmd
+	return m, tea.Batch(toastCmd, touchWorktreeActivity(m.wtRoot, m.repoName, worktreePath))
 }
 
 // startSessionOnRepo starts a session on a different repo's manager without
@@ -1504,7 +1828,22 @@ func (m Model) startSessionOnRepo(repoName string, sessionType session.SessionTy
 		return m, toastCmd
 	}
 	toastCmd := m.addToast("Session started on "+repoName, ToastSuccess)
-	return m, toastCmd
+	return m, tea.Batch(toastCmd, touchWorktreeActivity(m.wtRoot, repoName, worktreePath))
+}
+
+// touchWorktreeActivity records a session start as activity on the
+// worktree, which `wt status --activity` shows as its last-active time.
+func touchWorktreeActivity(wtRoot, repoName, worktreePath string) tea.Cmd {
+	if wtRoot == "" || repoName == "" || worktreePath == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		manager := wt.NewManager(wtRoot, repoName)
+		if rel, err := filepath.Rel(manager.RepoDir(), worktreePath); err == nil && !strings.HasPrefix(rel, "..") {
+			_ = manager.TouchActivity(filepath.ToSlash(rel))
+		}
+		return nil
+	}
 }
 
 func (m Model) promptNewSession(sessionType session.SessionType, target sessionTarget) (tea.Model, tea.Cmd) {
@@ -1512,7 +1851,7 @@ func (m Model) promptNewSession(sessionType session.SessionType, target sessionT
 	m.pendingModel = defaultModel
 	m.pendingSessionType = sessionType
 	m.pendingSessionTarget = target
-	return m.promptInput(promptLabel, func(prompt, model string, _ session.SessionType) tea.Cmd {
+	return m.promptInputWithHistory(target.worktreePath, promptLabel, func(prompt, model string, _ session.SessionType) tea.Cmd {
 		return func() tea.Msg {
 			return startSessionMsg{
 				sessionType: sessionType,
@@ -1630,6 +1969,48 @@ func (m Model) showSessionContext(sess *session.SessionInfo) (Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// mergeFromOverlay starts the merge flow for the given session's worktree,
+// so a finished builder's PR can be merged without navigating back to it.
+// The same pre-flight checks as the m key run before hideOverlay is called;
+// a PR that isn't ready keeps the overlay open and says why.
+func (m Model) mergeFromOverlay(sess *session.SessionInfo, hideOverlay func()) (tea.Model, tea.Cmd) {
+	if sess == nil {
+		toastCmd := m.addToast("No session selected", ToastInfo)
+		return m, toastCmd
+	}
+	worktrees, statuses, mgr := m.worktrees, m.worktreeStatuses, m.sessionManager
+	if sess.RepoName != "" && sess.RepoName != m.repoName {
+		rc, ok := m.repos[sess.RepoName]
+		if !ok || rc.sessionManager == nil {
+			toastCmd := m.addToast(errTargetRepoUnavailable, ToastError)
+			return m, toastCmd
+		}
+		worktrees, statuses, mgr = rc.worktrees, rc.worktreeStatuses, rc.sessionManager
+	}
+	var w *wt.Worktree
+	for i := range worktrees {
+		if worktrees[i].Path == sess.WorktreePath {
+			w = &worktrees[i]
+			break
+		}
+	}
+	if w == nil {
+		toastCmd := m.addToast("Worktree "+sess.WorktreeName+" not found", ToastError)
+		return m, toastCmd
+	}
+	if reason := mergeBlocker(w, statuses[w.Branch], mgr); reason != "" {
+		toastCmd := m.addToast("Can't merge "+w.Branch+": "+reason, ToastInfo)
+		return m, toastCmd
+	}
+
+	hideOverlay()
+	m.focus = FocusOutput
+
+	m, contextCmd := m.showSessionContext(sess)
+	model, mergeCmd := m.handleMergeKey()
+	return model, tea.Batch(contextCmd, mergeCmd)
+}
+
 // startNewSessionFromOverlay prompts the user for input to start a session on
 // the given session's worktree. hideOverlay is called only after validation
 // succeeds, so the overlay stays visible on error (preventing focus-state bugs).
@@ -1671,15 +2052,24 @@ func (m Model) createWorktree(branch string) (tea.Model, tea.Cmd) {
 		output := wt.NewOutput(&buf, false) // No colors for captured output
 		manager := wt.NewManager(wtRoot, repoName, wt.WithOutput(output))
 
-		worktreePath, err := manager.NewAtomic(ctx, branch, "", "")
+		// NewAtomic normalizes the name too; do it up front so the result
+		// selects the branch that was actually created.
+		branch, err := manager.NormalizeBranchName(ctx, branch)
+		if err != nil {
+			return worktreeOpResultMsg{err: err}
+		}
+
+		// Roll back on hook failure so a failed setup hook (e.g. npm install)
+		// does not leave a half-initialized worktree behind.
+		worktreePath, err := manager.NewAtomic(ctx, branch, "", "", wt.NewOptions{RollbackOnHookFailure: true})
 		messages := parseHookOutput(buf.String())
 		if err != nil {
 			return worktreeOpResultMsg{messages: messages, err: err}
 		}
 
 		var warning string
-		if err := runRepoHookCommands(repoSettings.OnWorktreeCreate, worktreePath, branch, &messages); err != nil {
-			warning = "Worktree created, but on-worktree-create command failed"
+		if _, err := runRepoHookCommands(ctx, wtRoot, repoSettings.OnWorktreeCreate, manager.HookEnvFor(ctx, worktreePath, branch), &messages); err != nil {
+			warning = repoHookWarning("Worktree created", "on-worktree-create", err)
 			messages = append(messages, "Non-fatal: on-worktree-create command failed")
 		}
 		if warning == "" {
@@ -1690,12 +2080,40 @@ func (m Model) createWorktree(branch string) (tea.Model, tea.Cmd) {
 	}
 }
 
-// deleteWorktree deletes a worktree asynchronously.
-func (m Model) deleteWorktree(branch string, deleteBranch bool) (tea.Model, tea.Cmd) {
+// deleteWorktree deletes a worktree asynchronously. Unless force is set, a
+// worktree with uncommitted changes is not touched (not even by the delete
+// hooks) and a worktreeDirtyMsg asks the user whether to discard them; a
+// clean one comes back as a worktreeCleanMsg and is removed.
+func (m Model) deleteWorktree(branch string, deleteBranch, force bool) (tea.Model, tea.Cmd) {
 	if branch == "" || m.repoName == "" {
 		return m, nil
 	}
+	if force {
+		return m.removeWorktree(branch, deleteBranch, true)
+	}
+
+	// Show pending message
+	m.worktreeOpMessages = []string{"Deleting worktree " + branch + "..."}
+
+	wtRoot := m.wtRoot
+	repoName := m.repoName
+	worktree := m.worktreeByBranch(branch)
+	ctx := m.ctx
+	return m, func() tea.Msg {
+		// git worktree remove refuses a dirty worktree; ask first rather
+		// than surface its error. A failed check falls through to it.
+		manager := wt.NewManager(wtRoot, repoName)
+		if status, err := manager.GetGitStatus(ctx, worktree); err == nil && status.IsDirty {
+			return worktreeDirtyMsg{branch: branch, deleteBranch: deleteBranch}
+		}
+		return worktreeCleanMsg{branch: branch, deleteBranch: deleteBranch}
+	}
+}
 
+// removeWorktree runs the delete hooks and removes the worktree once the
+// delete is settled: the worktree was clean or the user chose to discard
+// its changes.
+func (m Model) removeWorktree(branch string, deleteBranch, force bool) (tea.Model, tea.Cmd) {
 	// Clear viewing session if it belongs to this worktree
 	if w := m.selectedWorktree(); w != nil && w.Branch == branch {
 		// Save scroll position before clearing (session being deleted,
@@ -1710,13 +2128,7 @@ func (m Model) deleteWorktree(branch string, deleteBranch bool) (tea.Model, tea.
 	wtRoot := m.wtRoot
 	repoName := m.repoName
 	repoSettings := m.settings.RepoSettingsFor(repoName)
-	worktreePath := filepath.Join(wtRoot, repoName, branch)
-	for _, w := range m.worktrees {
-		if w.Branch == branch {
-			worktreePath = w.Path
-			break
-		}
-	}
+	worktreePath := m.worktreeByBranch(branch).Path
 	ctx := m.ctx
 	return m, func() tea.Msg {
 		var buf bytes.Buffer
@@ -1725,12 +2137,12 @@ func (m Model) deleteWorktree(branch string, deleteBranch bool) (tea.Model, tea.
 
 		var warning string
 		var messages []string
-		if err := runRepoHookCommands(repoSettings.OnWorktreeDelete, worktreePath, branch, &messages); err != nil {
-			warning = "Worktree delete continued, but on-worktree-delete command failed"
+		if _, err := runRepoHookCommands(ctx, wtRoot, repoSettings.OnWorktreeDelete, manager.HookEnvFor(ctx, worktreePath, branch), &messages); err != nil {
+			warning = repoHookWarning("Worktree delete continued", "on-worktree-delete", err)
 			messages = append(messages, "Non-fatal: on-worktree-delete command failed")
 		}
 
-		err := manager.Remove(ctx, branch, deleteBranch, false)
+		err := manager.Remove(ctx, branch, deleteBranch, force)
 
 		messages = append(messages, parseHookOutput(buf.String())...)
 		if warning == "" {
@@ -1741,6 +2153,29 @@ func (m Model) deleteWorktree(branch string, deleteBranch bool) (tea.Model, tea.
 	}
 }
 
+// worktreeByBranch returns the listed worktree for branch, or where wt would
+// have put it when it isn't listed.
+func (m *Model) worktreeByBranch(branch string) wt.Worktree {
+	for _, w := range m.worktrees {
+		if w.Branch == branch {
+			return w
+		}
+	}
+	return wt.Worktree{Branch: branch, Path: filepath.Join(m.wtRoot, m.repoName, branch)}
+}
+
+// confirmDiscardWorktree asks whether to discard a dirty worktree's
+// uncommitted changes and remove it anyway. Esc keeps the worktree.
+func (m Model) confirmDiscardWorktree(branch string, deleteBranch bool) (tea.Model, tea.Cmd) {
+	return m.showConfirm("Worktree '"+branch+"' has uncommitted changes. Discard them and remove it?", []ConfirmOption{
+		{Key: "f", Label: "discard changes and remove"},
+	}, func(string) tea.Cmd {
+		return func() tea.Msg {
+			return deleteWorktreeMsg{branch: branch, deleteBranch: deleteBranch, force: true}
+		}
+	})
+}
+
 // handleMergeKey runs pre-flight checks and shows the merge confirmation prompt.
 func (m Model) handleMergeKey() (tea.Model, tea.Cmd) {
 	w := m.selectedWorktree()
@@ -1751,41 +2186,11 @@ func (m Model) handleMergeKey() (tea.Model, tea.Cmd) {
 
 	branch := w.Branch
 	status := m.worktreeStatuses[branch]
-
-	if status == nil {
-		toastCmd := m.addToast("Worktree status not loaded yet", ToastInfo)
-		return m, toastCmd
-	}
-
-	if status.PRNumber == 0 {
-		toastCmd := m.addToast(fmt.Sprintf("No PR found for %s", branch), ToastInfo)
-		return m, toastCmd
-	}
-
-	if status.PRState != "OPEN" {
-		toastCmd := m.addToast(fmt.Sprintf("PR #%d is %s", status.PRNumber, status.PRState), ToastInfo)
-		return m, toastCmd
-	}
-
-	if status.IsDirty {
-		toastCmd := m.addToast("Uncommitted changes. Commit or stash first.", ToastInfo)
-		return m, toastCmd
-	}
-
-	if status.Ahead > 0 {
-		toastCmd := m.addToast(fmt.Sprintf("%d unpushed commits. Push first.", status.Ahead), ToastInfo)
+	if reason := mergeBlocker(w, status, m.sessionManager); reason != "" {
+		toastCmd := m.addToast(reason, ToastInfo)
 		return m, toastCmd
 	}
 
-	// Check for running/pending sessions (idle is OK)
-	sessions := m.sessionManager.GetSessionsForWorktree(w.Path)
-	for i := range sessions {
-		if !sessions[i].Status.IsTerminal() && sessions[i].Status != session.StatusIdle {
-			toastCmd := m.addToast("Stop active sessions first.", ToastInfo)
-			return m, toastCmd
-		}
-	}
-
 	// Build confirmation message
 	msg := fmt.Sprintf("Merge PR #%d (%s)?", status.PRNumber, branch)
 	if status.PRReviewStatus != "" {
@@ -1794,21 +2199,84 @@ func (m Model) handleMergeKey() (tea.Model, tea.Cmd) {
 	if status.PRIsDraft {
 		msg += "\n[Draft PR]"
 	}
+	switch status.PRChecksState {
+	case wt.ChecksFailing:
+		msg += "\n✗ CI checks are failing"
+		if len(status.PRFailedChecks) > 0 {
+			msg += ": " + formatFailedChecks(status.PRFailedChecks)
+		}
+	case wt.ChecksPending:
+		msg += "\n◐ CI checks are still running"
+	}
 
-	return m.showConfirm(msg, []ConfirmOption{
+	options := []ConfirmOption{
 		{Key: "s", Label: "squash"},
 		{Key: "r", Label: "rebase"},
 		{Key: "m", Label: "merge commit"},
-	}, func(key string) tea.Cmd {
-		method := map[string]string{"s": "squash", "r": "rebase", "m": "merge"}[key]
+		{Key: "a", Label: "auto-merge when green (squash)"},
+	}
+	// Unless the repo opts out, a red or unfinished PR can't be merged with
+	// the usual one-key choices: pending checks go through auto-merge, and
+	// a merge now needs the deliberate F.
+	checksNotGreen := status.PRChecksState == wt.ChecksFailing || status.PRChecksState == wt.ChecksPending
+	if checksNotGreen && !m.settings.RepoSettingsFor(m.repoName).AllowMergeWithFailingChecks {
+		options = []ConfirmOption{
+			{Key: "a", Label: "auto-merge when green (squash)"},
+			{Key: "F", Label: "merge anyway (squash)"},
+		}
+	}
+
+	return m.showConfirm(msg, options, func(key string) tea.Cmd {
+		method := map[string]string{"s": "squash", "r": "rebase", "m": "merge", "a": "squash", "F": "squash"}[key]
+		auto := key == "a"
 		return func() tea.Msg {
-			return mergePRMsg{branch: branch, mergeMethod: method}
+			return mergePRMsg{branch: branch, mergeMethod: method, auto: auto}
 		}
 	})
 }
 
+// mergeBlocker returns why the PR of worktree w can't be merged yet, or ""
+// when the merge confirmation can be shown. mgr owns w's sessions.
+func mergeBlocker(w *wt.Worktree, status *wt.WorktreeStatus, mgr *session.Manager) string {
+	if status == nil {
+		return "Worktree status not loaded yet"
+	}
+	if status.PRNumber == 0 {
+		return fmt.Sprintf("No PR found for %s", w.Branch)
+	}
+	if status.PRState != "OPEN" {
+		return fmt.Sprintf("PR #%d is %s", status.PRNumber, status.PRState)
+	}
+	if status.IsDirty {
+		return "Uncommitted changes. Commit or stash first."
+	}
+	if status.Ahead > 0 {
+		return fmt.Sprintf("%d unpushed commits. Push first.", status.Ahead)
+	}
+	// Running or pending sessions block the merge; idle is OK.
+	sessions := mgr.GetSessionsForWorktree(w.Path)
+	for i := range sessions {
+		if !sessions[i].Status.IsTerminal() && sessions[i].Status != session.StatusIdle {
+			return "Stop active sessions first."
+		}
+	}
+	return ""
+}
+
+// maxListedFailedChecks caps how many failed check names the merge
+// confirmation lists before summarizing the rest.
+const maxListedFailedChecks = 3
+
+// formatFailedChecks joins failed check names for the merge confirmation.
+func formatFailedChecks(names []string) string {
+	if len(names) <= maxListedFailedChecks {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(names[:maxListedFailedChecks], ", "), len(names)-maxListedFailedChecks)
+}
+
 // mergePR runs the async merge operation.
-func (m Model) mergePR(branch, mergeMethod string) (tea.Model, tea.Cmd) {
+func (m Model) mergePR(branch, mergeMethod string, auto bool) (tea.Model, tea.Cmd) {
 	if branch == "" || m.repoName == "" {
 		return m, nil
 	}
@@ -1823,9 +2291,10 @@ func (m Model) mergePR(branch, mergeMethod string) (tea.Model, tea.Cmd) {
 		output := wt.NewOutput(&buf, false)
 		manager := wt.NewManager(wtRoot, repoName, wt.WithOutput(output))
 
-		prNumber, err := manager.MergePRForBranch(ctx, branch, wt.MergeOptions{
+		res, err := manager.MergePRForBranch(ctx, branch, wt.MergeOptions{
 			MergeMethod: mergeMethod,
 			Keep:        true,
+			Auto:        auto,
 		})
 
 		var messages []string
@@ -1836,7 +2305,14 @@ func (m Model) mergePR(branch, mergeMethod string) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		return mergePRDoneMsg{branch: branch, prNumber: prNumber, messages: messages, err: err}
+		done := mergePRDoneMsg{branch: branch, messages: messages, err: err}
+		if res != nil {
+			done.prNumber = res.PRNumber
+			done.autoMergeArmed = res.AutoMergeArmed
+			done.cascade = res.CascadeSummary()
+			done.cascadeFailed = len(res.Errors) > 0
+		}
+		return done
 	}
 }
 
@@ -1849,6 +2325,13 @@ func (m Model) handleMergePRDone(msg mergePRDoneMsg) (tea.Model, tea.Cmd) {
 		return m, toastCmd
 	}
 
+	// Nothing merged yet: GitHub will merge once checks pass, so there is no
+	// worktree to clean up and child branches are handled by a later sync.
+	if msg.autoMergeArmed {
+		toastCmd := m.addToast(fmt.Sprintf("Auto-merge enabled for PR #%d", msg.prNumber), ToastSuccess)
+		return m, tea.Batch(toastCmd, m.refreshWorktrees())
+	}
+
 	prompt := fmt.Sprintf("PR #%d merged! What to do with worktree '%s'?", msg.prNumber, msg.branch)
 	branch := msg.branch
 	newModel, cmd := m.showConfirm(prompt, []ConfirmOption{
@@ -1868,6 +2351,15 @@ func (m Model) handleMergePRDone(msg mergePRDoneMsg) (tea.Model, tea.Cmd) {
 			return postMergeActionMsg{branch: branch, action: "keep"}
 		}
 	}
+	// Surface what happened to stacked branches so the cascade isn't silent.
+	if msg.cascade != "" {
+		level := ToastSuccess
+		if msg.cascadeFailed {
+			level = ToastError
+		}
+		toastCmd := m2.addToast("Cascade: "+msg.cascade, level)
+		return m2, tea.Batch(cmd, toastCmd)
+	}
 	return m2, cmd
 }
 
@@ -1875,7 +2367,7 @@ func (m Model) handleMergePRDone(msg mergePRDoneMsg) (tea.Model, tea.Cmd) {
 func (m Model) handlePostMergeAction(msg postMergeActionMsg) (tea.Model, tea.Cmd) {
 	switch msg.action {
 	case "delete":
-		return m.deleteWorktree(msg.branch, true)
+		return m.deleteWorktree(msg.branch, true, false)
 
 	case "reset":
 		m.worktreeOpMessages = []string{fmt.Sprintf("Resetting %s to default branch...", msg.branch)}
@@ -1925,7 +2417,7 @@ func (m Model) handlePostMergeAction(msg postMergeActionMsg) (tea.Model, tea.Cmd
 		}
 
 	default: // "keep"
-		return m, tea.Batch(m.refreshWorktrees(), m.fetchPRStatuses())
+		return m, tea.Batch(m.refreshWorktrees(), m.fetchFullStatuses())
 	}
 }
 
@@ -1956,7 +2448,7 @@ func (m Model) syncWorktree(branch string) (tea.Model, tea.Cmd) {
 		output := wt.NewOutput(&buf, false)
 		manager := wt.NewManager(wtRoot, repoName, wt.WithOutput(output))
 
-		err := manager.Sync(ctx, branch)
+		report, err := manager.Sync(ctx, branch)
 
 		var messages []string
 		for _, line := range strings.Split(buf.String(), "\n") {
@@ -1966,10 +2458,28 @@ func (m Model) syncWorktree(branch string) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		return worktreeOpResultMsg{messages: messages, err: err}
+		result := worktreeOpResultMsg{messages: messages, err: err}
+		if report != nil {
+			result.summary, result.warning = syncReportToasts(report)
+		}
+		return result
 	}
 }
 
+// syncReportToasts renders a sync report as a summary toast and, when any
+// rebase stopped, a warning naming the branches to resolve.
+func syncReportToasts(report *wt.SyncReport) (summary, warning string) {
+	summary = "Synced: " + report.String()
+	if len(report.Conflicts) > 0 {
+		branches := make([]string, len(report.Conflicts))
+		for i, c := range report.Conflicts {
+			branches[i] = c.Branch
+		}
+		warning = fmt.Sprintf("Rebase stopped on %s; resolve it in the worktree", strings.Join(branches, ", "))
+	}
+	return summary, warning
+}
+
 // handleTaskModal handles key presses in the task modal.
 func (m Model) handleTaskModal(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	state := m.taskModal.State()
@@ -2036,7 +2546,12 @@ func (m Model) handleTaskModal(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "a":
-			// Adjust the proposal
+			// Adjust the proposal; a new worktree may stack on the selected one.
+			branch := ""
+			if w := m.selectedWorktree(); w != nil && !w.IsDetached {
+				branch = w.Branch
+			}
+			m.taskModal.SetStackCandidate(branch)
 			m.taskModal.StartAdjust()
 			return m, nil
 
@@ -2047,6 +2562,10 @@ func (m Model) handleTaskModal(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 	case TaskModalAdjust:
 		if m.taskModal.Proposal().Action == taskrouter.ActionCreateNew {
+			if msg.String() == "ctrl+s" {
+				m.taskModal.ToggleStack()
+				return m, nil
+			}
 			// Route key events to the adjust TextArea for branch name editing
 			ta := m.taskModal.AdjustTextArea()
 			action := ta.HandleKey(msg)
@@ -2206,7 +2725,11 @@ func (m Model) confirmTask(msg taskConfirmMsg) (tea.Model, tea.Cmd) {
 			output := wt.NewOutput(&buf, false)
 			manager := wt.NewManager(wtRoot, repoName, wt.WithOutput(output))
 
-			worktreePath, err := manager.NewAtomic(ctx, worktreeName, parent, "")
+			worktreeName, err := manager.NormalizeBranchName(ctx, worktreeName)
+			if err != nil {
+				return worktreeOpResultMsg{err: err}
+			}
+			worktreePath, err := manager.NewAtomic(ctx, worktreeName, parent, "", wt.NewOptions{RollbackOnHookFailure: true})
 			messages := parseHookOutput(buf.String())
 
 			if err != nil {
@@ -2215,8 +2738,8 @@ func (m Model) confirmTask(msg taskConfirmMsg) (tea.Model, tea.Cmd) {
 
 			// Run per-repo hook commands
 			var warning string
-			if err := runRepoHookCommands(repoSettings.OnWorktreeCreate, worktreePath, worktreeName, &messages); err != nil {
-				warning = "Worktree created, but on-worktree-create command failed"
+			if _, err := runRepoHookCommands(ctx, wtRoot, repoSettings.OnWorktreeCreate, manager.HookEnvFor(ctx, worktreePath, worktreeName), &messages); err != nil {
+				warning = repoHookWarning("Worktree created", "on-worktree-create", err)
 				messages = append(messages, "Non-fatal: on-worktree-create command failed")
 			}
 			if warning == "" {
@@ -2282,6 +2805,9 @@ func (m Model) handleAllSessionsOverlay(msg tea.KeyPressMsg) (tea.Model, tea.Cmd
 		st := sessionTypeFromKey(msg.String())
 		return m.startNewSessionFromOverlay(m.allSessionsOverlay.SelectedSession(), st, func() { m.allSessionsOverlay.Hide() })
 
+	case "m":
+		return m.mergeFromOverlay(m.allSessionsOverlay.SelectedSession(), func() { m.allSessionsOverlay.Hide() })
+
 	case "q", "ctrl+c":
 		return m, tea.Quit
 
@@ -2368,6 +2894,26 @@ func (m *Model) gatherActiveSessions() []session.SessionInfo {
 	return activeSessions
 }
 
+// allSessionManagers returns the session managers of every opened repo,
+// including the current one, without duplicates.
+func (m *Model) allSessionManagers() []*session.Manager {
+	var managers []*session.Manager
+	seen := make(map[*session.Manager]bool)
+	add := func(mgr *session.Manager) {
+		if mgr != nil && !seen[mgr] {
+			seen[mgr] = true
+			managers = append(managers, mgr)
+		}
+	}
+	add(m.sessionManager)
+	for _, repoName := range m.openedRepos {
+		if rc, ok := m.repos[repoName]; ok {
+			add(rc.sessionManager)
+		}
+	}
+	return managers
+}
+
 // handleCommandCenter handles key presses when the command center is visible.
 func (m Model) handleCommandCenter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -2408,6 +2954,7 @@ func (m Model) handleCommandCenter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 		sessID := sess.ID
 		sessRepoName := sess.RepoName
+		sessWorktree := sess.WorktreePath
 		isResumable := sess.IsResumable()
 		if sess.Status != session.StatusIdle && !isResumable {
 			toastCmd := m.addToast("Session not available for follow-up or resume", ToastInfo)
@@ -2424,7 +2971,7 @@ func (m Model) handleCommandCenter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 		m.switchViewingSession(sessID)
 		if isResumable {
-			return m.promptInput("Resume: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
+			return m.promptInputWithHistory(sessWorktree, "Resume: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
 				return func() tea.Msg {
 					if err := m.sessionManager.ResumeSession(sessID, message); err != nil {
 						return errMsg{err}
@@ -2433,7 +2980,7 @@ func (m Model) handleCommandCenter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 				}
 			}, "Type a message to resume the session...")
 		}
-		return m.promptInput("Follow-up: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
+		return m.promptInputWithHistory(sessWorktree, "Follow-up: ", func(message string, _ string, _ session.SessionType) tea.Cmd {
 			return func() tea.Msg {
 				if err := m.sessionManager.SendFollowUp(sessID, message); err != nil {
 					return errMsg{err}
@@ -2622,28 +3169,16 @@ func (m Model) handleRepoSettingsDialog(msg tea.KeyPressMsg) (tea.Model, tea.Cmd
 		m.applyTheme(selected)
 		m.settings.ThemeName = selected.Name
 		m.settings.SetRepoSettings(m.repoName, cfg)
+		applyRepoSessionSettings(m.sessionManager, m.settings.RepoSettingsFor(m.repoName))
 
 		// Save provider preferences and rebuild the model registry
 		enabledProviders := m.repoSettingsDialog.EnabledProviders()
 		m.settings.SetEnabledProviders(enabledProviders)
 		if m.modelRegistry != nil && m.providerAvailability != nil {
 			m.modelRegistry.Rebuild(m.providerAvailability, m.settings.GetEnabledProviders())
-			// Reset defaults if current defaults are no longer available
-			if _, ok := m.modelRegistry.ModelByID(m.defaultPlanModel); !ok {
-				if models := m.modelRegistry.Models(); len(models) > 0 {
-					m.defaultPlanModel = models[0].ID
-				}
-			}
-			if _, ok := m.modelRegistry.ModelByID(m.defaultCodeTalkModel); !ok {
-				if models := m.modelRegistry.Models(); len(models) > 0 {
-					m.defaultCodeTalkModel = models[0].ID
-				}
-			}
-			if _, ok := m.modelRegistry.ModelByID(m.defaultBuildModel); !ok {
-				if models := m.modelRegistry.Models(); len(models) > 0 {
-					m.defaultBuildModel = models[0].ID
-				}
-			}
+			// Fall back from saved defaults the registry no longer offers,
+			// and pick them up again once their provider is re-enabled.
+			m.resolveDefaultModels()
 		}
 
 		m.repoSettingsDialog.Hide()
@@ -2669,54 +3204,53 @@ func parseHookOutput(out string) []string {
 	return messages
 }
 
-func runRepoHookCommands(commands []string, worktreePath, branch string, messages *[]string) error {
-	env := os.Environ()
-	env = append(env, "WT_BRANCH="+branch, "WT_PATH="+worktreePath)
-
-	for _, cmdStr := range commands {
-		cmdStr = strings.TrimSpace(cmdStr)
-		if cmdStr == "" {
-			continue
-		}
-		*messages = append(*messages, "Running: "+cmdStr)
-		cmd := exec.Command("sh", "-c", cmdStr)
-		cmd.Dir = worktreePath
-		cmd.Env = env
-
-		// Capture output to buffer instead of writing to os.Stdout/Stderr
-		// to prevent TUI corruption
-		var outBuf, errBuf bytes.Buffer
-		cmd.Stdout = &outBuf
-		cmd.Stderr = &errBuf
-
-		if err := cmd.Run(); err != nil {
-			*messages = append(*messages, "Command failed: "+cmdStr)
-			// Include stderr output in messages for debugging
-			if errBuf.Len() > 0 {
-				for _, line := range strings.Split(strings.TrimSpace(errBuf.String()), "\n") {
-					if line != "" {
-						*messages = append(*messages, "  "+line)
-					}
-				}
+// runRepoHookCommands runs per-repo Bramble hook commands in the worktree
+// env describes, with the same WT_* environment as wt's own hooks, bounded
+// by the hook_timeout wt would use there (global config under wtRoot, then
+// the worktree's .wt.yaml), and appends their progress and output to
+// messages.
+func runRepoHookCommands(ctx context.Context, wtRoot string, commands []string, env wt.HookEnv, messages *[]string) ([]wt.HookResult, error) {
+	timeout := wt.DefaultHookTimeout
+	if cfg, err := wt.LoadConfig(wtRoot, env.Path); err == nil {
+		timeout = cfg.EffectiveHookTimeout()
+	}
+	// Output is discarded rather than written to os.Stdout/Stderr to prevent
+	// TUI corruption; each result carries its own captured output.
+	results, err := wt.RunHooks(ctx, commands, env, timeout, wt.NewOutput(io.Discard, false))
+	for i, r := range results {
+		*messages = append(*messages, "Running: "+r.Command)
+		if err != nil && i == len(results)-1 {
+			if r.TimedOut {
+				*messages = append(*messages, fmt.Sprintf("Command timed out after %s: %s", timeout, r.Command))
+			} else {
+				*messages = append(*messages, "Command failed: "+r.Command)
 			}
-			return err
 		}
-
-		// Include stdout output in messages
-		if outBuf.Len() > 0 {
-			for _, line := range strings.Split(strings.TrimSpace(outBuf.String()), "\n") {
-				if line != "" {
-					*messages = append(*messages, "  "+line)
-				}
+		for _, line := range strings.Split(strings.TrimSpace(r.Output), "\n") {
+			if line != "" {
+				*messages = append(*messages, "  "+line)
 			}
 		}
 	}
-	return nil
+	return results, err
+}
+
+// repoHookWarning is the toast shown when a per-repo hook fails; timeouts
+// are called out separately from non-zero exits.
+func repoHookWarning(done, hook string, err error) string {
+	var hookErr *wt.HookFailedError
+	if errors.As(err, &hookErr) && hookErr.TimedOut {
+		return fmt.Sprintf("%s, but %s command timed out after %s", done, hook, hookErr.Timeout)
+	}
+	return fmt.Sprintf("%s, but %s command failed", done, hook)
 }
 
 func extractHookWarning(messages []string) string {
 	for _, msg := range messages {
 		lower := strings.ToLower(msg)
+		if strings.Contains(lower, "hook timed out") || (strings.Contains(lower, "hook") && strings.Contains(lower, "timed out after")) {
+			return "Worktree operation completed, but hook command timed out"
+		}
 		if strings.Contains(lower, "hook failed") {
 			return "Worktree operation completed, but hook command failed"
 		}
@@ -2724,53 +3258,10 @@ func extractHookWarning(messages []string) string {
 	return ""
 }
 
-// editorCommand builds an exec.Cmd from an editor string that may contain
-// flags (e.g. "emacsclient -n" or "code --wait"). Quoted tokens are
-// respected so paths with spaces work (e.g. '"/path/to/My Editor" --wait').
+// editorCommand builds the command that opens path in editor; it shares
+// wt's parsing so "wt new --open-editor" and bramble launch editors alike.
 func editorCommand(editor, path string) *exec.Cmd {
-	parts := shellSplit(editor)
-	if len(parts) == 0 {
-		return exec.Command("code", path)
-	}
-	args := make([]string, len(parts)-1, len(parts))
-	copy(args, parts[1:])
-	args = append(args, path)
-	return exec.Command(parts[0], args...)
-}
-
-// shellSplit splits s into tokens using shell-like quoting rules.
-// Single and double quotes preserve spaces within a token; quotes are
-// stripped from the result. Backslash escaping is not supported.
-func shellSplit(s string) []string {
-	var parts []string
-	var current strings.Builder
-	inSingle := false
-	inDouble := false
-	hasToken := false
-
-	for _, r := range s {
-		switch {
-		case r == '\'' && !inDouble:
-			inSingle = !inSingle
-			hasToken = true
-		case r == '"' && !inSingle:
-			inDouble = !inDouble
-			hasToken = true
-		case (r == ' ' || r == '\t') && !inSingle && !inDouble:
-			if hasToken {
-				parts = append(parts, current.String())
-				current.Reset()
-				hasToken = false
-			}
-		default:
-			current.WriteRune(r)
-			hasToken = true
-		}
-	}
-	if hasToken {
-		parts = append(parts, current.String())
-	}
-	return parts
+	return wt.EditorCommand(editor, path)
 }
 
 // ---------- Multi-repo: dropdown handler, open, switch ----------
@@ -2854,7 +3345,7 @@ func (m *Model) populateRepoDropdown(allRepos []string) {
 		badge := ""
 		if rc, ok := m.repos[name]; ok && rc.sessionManager != nil {
 			counts := rc.sessionManager.CountByStatus()
-			active := counts[session.StatusRunning] + counts[session.StatusIdle] + counts[session.StatusPending]
+			active := counts[session.StatusRunning] + counts[session.StatusWaitingApproval] + counts[session.StatusIdle] + counts[session.StatusPending]
 			if active > 0 {
 				badge = fmt.Sprintf("%d active", active)
 			}
@@ -2913,6 +3404,7 @@ func (m Model) openRepo(repoName string) (tea.Model, tea.Cmd) {
 	cfg.RepoName = repoName
 	mgr := session.NewManagerWithConfig(cfg)
 	mgr.SetWorktreeDirtyCallback(makeGitDirtyCallback(m.sharedGitInvalidates))
+	applyRepoSessionSettings(mgr, m.settings.RepoSettingsFor(repoName))
 	if cfg.Registry != nil {
 		cfg.Registry.Register(mgr)
 	}
@@ -2934,6 +3426,7 @@ func (m Model) openRepo(repoName string) (tea.Model, tea.Cmd) {
 		sessionDropdown:  sessDropdown,
 		worktreeStatuses: make(map[string]*wt.WorktreeStatus),
 		scrollPositions:  make(map[session.SessionID]int),
+		lastSeenOutput:   make(map[session.SessionID]int),
 	}
 
 	m.repos[repoName] = rc
@@ -176,3 +176,29 @@ func TestTmuxWindowMsg_TracksWindowForSelectedWorktree(t *testing.T) {
 	assert.Equal(t, "scratch", sessions[0].TmuxWindowName)
 	assert.Equal(t, "@1", sessions[0].TmuxWindowID)
 }
+
+func TestKeyFeedback_V_NoHistorySession(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, cmd := m.handleKeyPress(keyPress('v'))
+	m2 := newModel.(Model)
+
+	assert.NotNil(t, cmd)
+	assert.True(t, m2.toasts.HasToasts())
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Open a history session")
+}
+
+func TestSessionRevivedMsg_SwitchesToLiveOutput(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	m.viewingSessionID = "hist-1"
+	m.viewingHistoryData = &session.StoredSession{ID: "hist-1"}
+	m.scrollOffset = 5
+
+	newModel, _ := m.Update(sessionRevivedMsg{id: "hist-1"})
+	m2 := newModel.(Model)
+
+	assert.Nil(t, m2.viewingHistoryData)
+	assert.Equal(t, session.SessionID("hist-1"), m2.viewingSessionID)
+	assert.Equal(t, 0, m2.scrollOffset)
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Session revived")
+}
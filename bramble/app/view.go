@@ -3,6 +3,7 @@ package app
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -34,31 +35,7 @@ func (m Model) View() tea.View {
 		m.height = 24
 	}
 
-	// Layout: top bar (1 line) + center + toast area (dynamic) + input area (dynamic) + status bar (1 line)
-	topBarHeight := 1
-	statusBarHeight := 1
-	toastHeight := m.toasts.Height()
-	confirmHeight := 0
-	if m.focus == FocusConfirm && m.confirmPrompt != nil {
-		confirmHeight = 5 // message + blank line + hints + top/bottom borders
-	}
-	inputHeight := 0
-	if m.inputMode {
-		// Dynamic input height based on content (min 5, max 12 lines including border and status)
-		lineCount := m.inputArea.LineCount()
-		inputHeight = lineCount + 4 // +4 for prompt, status line, and borders
-		if inputHeight < 5 {
-			inputHeight = 5
-		}
-		maxInputHeight := m.height * 40 / 100 // 40% of screen max
-		if maxInputHeight < 8 {
-			maxInputHeight = 8
-		}
-		if inputHeight > maxInputHeight {
-			inputHeight = maxInputHeight
-		}
-	}
-	centerHeight := m.height - topBarHeight - statusBarHeight - toastHeight - inputHeight - confirmHeight - 2 // borders
+	centerHeight, inputHeight := m.layoutHeights()
 
 	// Build components
 	topBar := m.renderTopBar()
@@ -90,6 +67,10 @@ func (m Model) View() tea.View {
 		parts = append(parts, m.confirmPrompt.View(m.styles))
 	}
 
+	if pins := m.renderPinStrip(m.width); pins != "" {
+		parts = append(parts, pins)
+	}
+
 	parts = append(parts, statusBar)
 
 	// Overlay dropdowns if open
@@ -117,6 +98,11 @@ func (m Model) View() tea.View {
 		content = overlayAt(content, overlay, overlayX, 1)
 	}
 
+	// Show command palette above everything else
+	if m.commandPalette.IsVisible() {
+		return newAppView(m.commandPalette.View(m.styles))
+	}
+
 	// Show help overlay if active
 	if m.focus == FocusHelp {
 		return newAppView(m.helpOverlay.View(m.styles))
@@ -216,6 +202,17 @@ func (m Model) renderTopBar() string {
 
 	// Combine with padding
 	padding := m.width - runewidth.StringWidth(stripAnsi(left)) - runewidth.StringWidth(stripAnsi(right)) - 4
+
+	// Repo-wide usage HUD, shown only when it fits. Tmux sessions run
+	// outside the process and have no accounting.
+	if !m.sessionManager.IsInTmuxMode() {
+		if hud := repoUsageHUD(m.repoUsage()); hud != "" {
+			if w := runewidth.StringWidth(hud) + 2; padding-w >= 1 {
+				right = s.Dim.Render(hud) + "  " + right
+				padding -= w
+			}
+		}
+	}
 	if padding < 1 {
 		padding = 1
 	}
@@ -308,6 +305,53 @@ func minInt(a, b int) int {
 	return b
 }
 
+// layoutHeights returns the height of the bordered center area and of the
+// input area for the current layout: top bar (1 line) + center + toast area
+// (dynamic) + input area (dynamic) + status bar (1 line).
+func (m Model) layoutHeights() (centerHeight, inputHeight int) {
+	topBarHeight := 1
+	statusBarHeight := 1
+	toastHeight := m.toasts.Height()
+	confirmHeight := 0
+	if m.focus == FocusConfirm && m.confirmPrompt != nil {
+		confirmHeight = 5 // message + blank line + hints + top/bottom borders
+	}
+	if m.inputMode {
+		// Dynamic input height based on content (min 5, max 12 lines including border and status)
+		lineCount := m.inputArea.LineCount()
+		inputHeight = lineCount + 4 // +4 for prompt, status line, and borders
+		if inputHeight < 5 {
+			inputHeight = 5
+		}
+		maxInputHeight := m.height * 40 / 100 // 40% of screen max
+		if maxInputHeight < 8 {
+			maxInputHeight = 8
+		}
+		if inputHeight > maxInputHeight {
+			inputHeight = maxInputHeight
+		}
+	}
+	centerHeight = m.height - topBarHeight - statusBarHeight - toastHeight - inputHeight - confirmHeight - m.pinStripHeight() - 2 // borders
+	return centerHeight, inputHeight
+}
+
+// outputPaneSize returns the width of the live session output pane and the
+// number of lines available to its output, matching renderOutputArea.
+func (m Model) outputPaneSize() (width, outputHeight int) {
+	if m.width == 0 {
+		m.width = 80
+	}
+	if m.height == 0 {
+		m.height = 24
+	}
+	centerHeight, _ := m.layoutHeights()
+	width = m.width
+	if m.splitPane.IsSplit() {
+		width = m.splitPane.RightWidth(width)
+	}
+	return width, centerHeight - 5 // header, prompt, separator
+}
+
 // renderCenter renders the main center area (session output + input).
 func (m Model) renderCenter(width, height int) string {
 	// In tmux mode, show session list (with optional file tree split)
@@ -368,6 +412,13 @@ func (m Model) renderOutputArea(width, height int) string {
 	if info.Progress.TurnCount > 0 || info.Progress.TotalCostUSD > 0 {
 		headerLine += "  " + s.Dim.Render(fmt.Sprintf("T:%d $%.4f", info.Progress.TurnCount, info.Progress.TotalCostUSD))
 	}
+	if stat, ok := m.sessionDiffStats[info.ID]; ok && !stat.IsZero() {
+		headerLine += "  " + s.Dim.Render(stat.String())
+	}
+	lines := m.sessionManager.GetSessionOutput(m.viewingSessionID)
+	if badge := toolErrorBadge(countToolErrors(lines)); badge != "" {
+		headerLine += "  " + s.Error.Render(badge)
+	}
 	// Add idle indicator with follow-up hint
 	if info.Status == session.StatusIdle {
 		if info.Type == session.SessionTypePlanner {
@@ -389,15 +440,25 @@ func (m Model) renderOutputArea(width, height int) string {
 	b.WriteString("\n")
 
 	// Output lines
-	lines := m.sessionManager.GetSessionOutput(m.viewingSessionID)
-
-	// Pre-render all output lines into visual lines for proper scrolling.
-	// Each OutputLine may produce multiple visual lines (e.g., markdown text).
-	var allVisualLines []string
-	for i := range lines {
-		formatted := m.formatOutputLine(lines[i], width)
-		visualLines := strings.Split(formatted, "\n")
-		allVisualLines = append(allVisualLines, visualLines...)
+	allVisualLines, starts := m.outputVisualLines(lines, width)
+	allVisualLines, starts = m.insertNewOutputDivider(info.ID, allVisualLines, starts, width)
+
+	// Flag every line of each search match in the gutter; the match last
+	// jumped to gets the stronger marker.
+	if m.searchActive() {
+		for _, idx := range m.searchMatches() {
+			marker := s.Pending.Render("▌")
+			if idx == m.search.current {
+				marker = s.Selected.Render("▶")
+			}
+			end := len(allVisualLines)
+			if idx+1 < len(starts) {
+				end = starts[idx+1]
+			}
+			for i := starts[idx]; i < end; i++ {
+				allVisualLines[i] = markSearchMatch(allVisualLines[i], marker)
+			}
+		}
 	}
 
 	// Scroll on visual lines, not logical OutputLine count
@@ -407,6 +468,38 @@ func (m Model) renderOutputArea(width, height int) string {
 	return b.String()
 }
 
+// insertNewOutputDivider inserts a dim "new since last view" rule into the
+// session's visual lines before the first output line added since the user
+// last saw the bottom of its output (see markOutputSeen), shifting starts to
+// match. A last-seen index at or past the end, including once the output
+// buffer is full and rotating, leaves the lines unchanged.
+func (m Model) insertNewOutputDivider(id session.SessionID, visual []string, starts []int, width int) ([]string, []int) {
+	seen, ok := m.lastSeenOutput[id]
+	if !ok || seen <= 0 || seen >= len(starts) {
+		return visual, starts
+	}
+	label := "── new since last view "
+	divider := m.styles.Dim.Render("  " + label + strings.Repeat("─", max(width-4-runewidth.StringWidth(label), 2)))
+	visual = slices.Insert(visual, starts[seen], divider)
+	for i := seen; i < len(starts); i++ {
+		starts[i]++
+	}
+	return visual, starts
+}
+
+// outputVisualLines pre-renders output lines into visual lines for proper
+// scrolling, since each OutputLine may produce several (e.g. markdown
+// text). starts[i] is the index of the first visual line of lines[i].
+func (m Model) outputVisualLines(lines []session.OutputLine, width int) (visual []string, starts []int) {
+	starts = make([]int, len(lines))
+	for i := range lines {
+		starts[i] = len(visual)
+		formatted := m.formatOutputLine(lines[i], width)
+		visual = append(visual, strings.Split(formatted, "\n")...)
+	}
+	return visual, starts
+}
+
 // renderScrollableLines renders a window of visual lines with scroll indicators.
 // scrollOffset=0 means "at bottom" (latest output visible).
 // Higher values scroll toward the top (older content).
@@ -508,8 +601,7 @@ func (m Model) formatOutputLine(line session.OutputLine, width int) string {
 			durationStr := fmt.Sprintf("%.2fs", float64(line.DurationMs)/1000)
 			formatted = "  ✓ " + s.Dim.Render(toolDisplay+" ("+durationStr+")")
 		case session.ToolStateError:
-			durationStr := fmt.Sprintf("%.2fs", float64(line.DurationMs)/1000)
-			formatted = "  " + s.Error.Render("✗ "+toolDisplay+" ("+durationStr+")")
+			formatted = formatToolErrorCard(&line, width, "  ", s)
 		default:
 			formatted = "  🔧 " + toolDisplay
 		}
@@ -550,8 +642,8 @@ func (m Model) formatOutputLine(line session.OutputLine, width int) string {
 		formatted = "  " + line.Content
 	}
 
-	// Truncate width if needed (skip for multi-line content: text, plan, thinking)
-	if line.Type != session.OutputTypeText && line.Type != session.OutputTypePlanReady && line.Type != session.OutputTypeThinking && runewidth.StringWidth(stripAnsi(formatted)) > width-2 {
+	// Truncate width if needed (skip for multi-line content: text, plan, thinking, tool error cards)
+	if line.Type != session.OutputTypeText && line.Type != session.OutputTypePlanReady && line.Type != session.OutputTypeThinking && !isToolError(&line) && runewidth.StringWidth(stripAnsi(formatted)) > width-2 {
 		formatted = truncateVisual(formatted, width-2)
 	}
 
@@ -617,6 +709,10 @@ func (m Model) renderStatusBar() string {
 			hints = append(hints, "[Alt+M] "+m.pendingModel)
 		}
 		hints = append(hints, "[?]help")
+	} else if m.focus == FocusSearch {
+		hints = []string{m.searchStatus(), "[Enter]done", "[Esc]cancel"}
+	} else if m.searchActive() {
+		hints = []string{m.searchStatus(), "[n]older", "[N]newer", "[Esc]clear search", "[?]help"}
 	} else if m.focus == FocusWorktreeDropdown || m.focus == FocusSessionDropdown {
 		hints = []string{"[↑/↓]select", "[Enter]choose", "[Esc]close", "[?]help", "[q]uit"}
 	} else if inTmuxMode {
@@ -629,13 +725,18 @@ func (m Model) renderStatusBar() string {
 	} else if m.viewingSessionID != "" {
 		// SDK mode: session is selected - show contextual actions
 		sess := m.selectedSession()
-		hints = []string{"[↑/↓]scroll"}
+		hints = []string{"[↑/↓]scroll", "[/]search"}
 		if sess != nil && sess.IsResumable() {
 			hints = append(hints, "[f]resume")
+			if m.viewingHistoryData != nil {
+				hints = append(hints, "re[v]ive")
+			}
 		} else if sess != nil && sess.Status == session.StatusIdle {
 			hints = append(hints, "[f]ollow-up")
+		} else if sess != nil && sess.Status == session.StatusWaitingApproval {
+			hints = append(hints, "[a]pprove command")
 		}
-		if sess != nil && (sess.Status == session.StatusRunning || sess.Status == session.StatusIdle) {
+		if sess != nil && (sess.Status == session.StatusRunning || sess.Status == session.StatusIdle || sess.Status == session.StatusWaitingApproval) {
 			hints = append(hints, "[s]top")
 		}
 		hints = append(hints, "[S]all sessions", "[Ctrl+L]settings", "[F2]split", "[Alt-W]worktree", "[Alt-S]session", "[?]help", "[q]uit")
@@ -658,6 +759,11 @@ func (m Model) renderStatusBar() string {
 	idle := counts[session.StatusIdle]
 	right := fmt.Sprintf("Running: %d  Idle: %d", running, idle)
 
+	// Per-session spend for the session being viewed
+	if sess := m.selectedSession(); sess != nil {
+		right = sessionUsageIndicator(sess, inTmuxMode) + "  " + right
+	}
+
 	// Aggregate cost
 	totalCost := m.aggregateCost()
 	if totalCost > 0 {
@@ -679,6 +785,53 @@ func (m Model) renderStatusBar() string {
 	return s.StatusBar.Width(m.width).Render(bar)
 }
 
+// sessionUsageIndicator renders the compact "$0.1234 · 12k↑ / 3k↓ · T4"
+// cost/token summary for a session. Providers that report reasoning tokens
+// get them broken out of the output count: "12k↑ / 3k↓ (2.1k reasoning)".
+// Tmux sessions run outside the process and have no accounting, so they
+// render as a dash.
+func sessionUsageIndicator(sess *session.SessionInfo, inTmuxMode bool) string {
+	if inTmuxMode || sess.RunnerType == session.RunnerTypeTmux || sess.RunnerType == session.RunnerTypeTmuxTracked {
+		return "-"
+	}
+	p := sess.Progress
+	output := formatTokenCount(p.OutputTokens) + "↓"
+	if p.ReasoningTokens > 0 {
+		output += fmt.Sprintf(" (%s reasoning)", formatTokenCount(p.ReasoningTokens))
+	}
+	return fmt.Sprintf("$%.4f · %s↑ / %s · T%d",
+		p.TotalCostUSD, formatTokenCount(p.InputTokens), output, p.TurnCount)
+}
+
+// repoUsageHUD renders the one-line repo total, e.g.
+// "repo total: $1.23 · 45k tokens · 6 sessions". It is empty when the repo
+// has no sessions.
+func repoUsageHUD(u repoUsage) string {
+	if u.Sessions == 0 {
+		return ""
+	}
+	noun := "sessions"
+	if u.Sessions == 1 {
+		noun = "session"
+	}
+	return fmt.Sprintf("repo total: $%.2f · %s tokens · %d %s",
+		u.CostUSD, formatTokenCount(u.Tokens), u.Sessions, noun)
+}
+
+// formatTokenCount abbreviates a token count: 950, 1.2k, 12k, 3.4M.
+func formatTokenCount(n int) string {
+	switch {
+	case n < 1000:
+		return fmt.Sprintf("%d", n)
+	case n < 10_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	case n < 1_000_000:
+		return fmt.Sprintf("%dk", n/1000)
+	default:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	}
+}
+
 // formatKeyHints formats a key-action pair as "[key] action".
 func formatKeyHints(key, action string) string {
 	return "[" + key + "] " + action
@@ -708,6 +861,8 @@ func statusIcon(status session.SessionStatus, s *Styles) string {
 		return s.Running.Render("●")
 	case session.StatusIdle:
 		return s.Idle.Render("◐")
+	case session.StatusWaitingApproval:
+		return s.Idle.Render("!")
 	case session.StatusCompleted:
 		return s.Completed.Render("✓")
 	case session.StatusFailed:
@@ -787,6 +942,10 @@ func formatToolDisplay(toolName string, input map[string]interface{}, maxLen int
 		if desc, ok := input["description"].(string); ok {
 			detail = desc
 		}
+	case "web_search":
+		if query, ok := input["query"].(string); ok {
+			return "🔎 web_search: " + truncate(query, maxLen-16)
+		}
 	}
 
 	if detail != "" {
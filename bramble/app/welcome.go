@@ -234,6 +234,8 @@ func sessionStatusEvent(status session.SessionStatus) string {
 		return "Running"
 	case session.StatusIdle:
 		return "Idle"
+	case session.StatusWaitingApproval:
+		return "Needs approval"
 	case session.StatusPending:
 		return "Pending"
 	case session.StatusCompleted:
@@ -352,8 +354,11 @@ func renderWorktreeSummary(m Model, w *wt.Worktree, s *Styles) string {
 				details = append(details, s.Pending.Render(fmt.Sprintf("↓%d behind", status.Behind)))
 			}
 			if status.PRNumber > 0 {
-				prText := fmt.Sprintf("PR#%d %s", status.PRNumber, status.PRState)
-				details = append(details, s.Dim.Render(prText))
+				prText := s.Dim.Render(fmt.Sprintf("PR#%d %s", status.PRNumber, status.PRState))
+				if checks := prChecksIndicator(status.PRChecksState, s); checks != "" {
+					prText += " " + checks
+				}
+				details = append(details, prText)
 			}
 			if len(details) > 0 {
 				b.WriteString(" (")
@@ -2,9 +2,11 @@ package app
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
 )
 
 func TestWorktreeOpResultWarningShowsToast(t *testing.T) {
@@ -36,6 +38,25 @@ func TestWorktreeOpResultWarningShowsToast(t *testing.T) {
 	}
 }
 
+func TestSyncReportToasts(t *testing.T) {
+	summary, warning := syncReportToasts(&wt.SyncReport{
+		Rebased:   []string{"a", "b"},
+		Conflicts: []wt.RebaseConflictError{{Branch: "c", Onto: "origin/main", Err: errors.New("conflict")}},
+		Skipped:   map[string]string{"d": "ancestor branch c failed to rebase"},
+	})
+	if summary != "Synced: 2 rebased, 1 conflict, 1 skipped" {
+		t.Errorf("summary = %q", summary)
+	}
+	if warning != "Rebase stopped on c; resolve it in the worktree" {
+		t.Errorf("warning = %q", warning)
+	}
+
+	_, warning = syncReportToasts(&wt.SyncReport{Rebased: []string{"a"}})
+	if warning != "" {
+		t.Errorf("a clean sync should not warn, got %q", warning)
+	}
+}
+
 func TestExtractHookWarningDetectsHookFailure(t *testing.T) {
 	got := extractHookWarning([]string{
 		"→ Removing worktree feature...",
@@ -45,3 +66,13 @@ func TestExtractHookWarningDetectsHookFailure(t *testing.T) {
 		t.Fatal("expected warning when hook failure message is present")
 	}
 }
+
+func TestExtractHookWarningDetectsHookTimeout(t *testing.T) {
+	got := extractHookWarning([]string{
+		"→ Running: npm install",
+		"✗ Hook timed out after 30m0s: npm install",
+	})
+	if got != "Worktree operation completed, but hook command timed out" {
+		t.Fatalf("extractHookWarning() = %q, want timeout warning", got)
+	}
+}
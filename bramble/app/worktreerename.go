@@ -0,0 +1,99 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+// promptRenameWorktree asks for a new branch name for the selected
+// worktree, prefilled with the current one so a typo is a quick edit.
+func (m Model) promptRenameWorktree() (tea.Model, tea.Cmd) {
+	w := m.selectedWorktree()
+	if w == nil {
+		toastCmd := m.addToast("Select a worktree first (Alt-W)", ToastInfo)
+		return m, toastCmd
+	}
+	branch := w.Branch
+	next, cmd := m.promptInput("Rename "+branch+" to: ", func(newBranch string, _ string, _ session.SessionType) tea.Cmd {
+		return func() tea.Msg {
+			return renameWorktreeMsg{branch: branch, newBranch: strings.TrimSpace(newBranch)}
+		}
+	})
+	nm := next.(Model)
+	nm.inputArea.SetValue(branch)
+	return nm, cmd
+}
+
+// renameWorktree renames a worktree's branch and directory asynchronously,
+// then re-points the sessions running in it at the new path.
+func (m Model) renameWorktree(branch, newBranch string) (tea.Model, tea.Cmd) {
+	if m.repoName == "" {
+		toastCmd := m.addToast("No repository selected", ToastError)
+		return m, toastCmd
+	}
+	if newBranch == "" || newBranch == branch {
+		toastCmd := m.addToast("Worktree name unchanged", ToastInfo)
+		return m, toastCmd
+	}
+
+	m.worktreeOpMessages = []string{"Renaming worktree " + branch + "..."}
+
+	wtRoot := m.wtRoot
+	repoName := m.repoName
+	mgr := m.sessionManager
+	oldPath := filepath.Join(wtRoot, repoName, branch)
+	for _, w := range m.worktrees {
+		if w.Branch == branch {
+			oldPath = w.Path
+			break
+		}
+	}
+	ctx := m.ctx
+	return m, func() tea.Msg {
+		var buf bytes.Buffer
+		manager := wt.NewManager(wtRoot, repoName, wt.WithOutput(wt.NewOutput(&buf, false)))
+		newPath, err := manager.Rename(ctx, branch, newBranch)
+		if err != nil {
+			return worktreeRenamedMsg{err: err, oldBranch: branch}
+		}
+		// Rename normalizes the name; the directory under the repo is the
+		// branch that was actually created.
+		if rel, relErr := filepath.Rel(manager.RepoDir(), newPath); relErr == nil {
+			newBranch = filepath.ToSlash(rel)
+		}
+		return worktreeRenamedMsg{
+			oldBranch: branch,
+			newBranch: newBranch,
+			relocated: mgr.RelocateWorktree(oldPath, newPath),
+		}
+	}
+}
+
+// handleWorktreeRenamed reports a rename and reselects the worktree under
+// its new name once the list refreshes.
+func (m Model) handleWorktreeRenamed(msg worktreeRenamedMsg) (tea.Model, tea.Cmd) {
+	m.worktreeOpMessages = nil
+	if msg.err != nil {
+		toastCmd := m.addToast(fmt.Sprintf("Rename %s failed: %v", msg.oldBranch, msg.err), ToastError)
+		return m, toastCmd
+	}
+
+	delete(m.worktreeStatuses, msg.oldBranch)
+	m.sessions = m.sessionManager.GetAllSessions()
+	m.pendingWorktreeSelect = msg.newBranch
+	m.pendingPlannerPrompt = ""
+
+	text := fmt.Sprintf("Renamed %s to %s", msg.oldBranch, msg.newBranch)
+	if msg.relocated > 0 {
+		text += fmt.Sprintf(" (%d session(s) moved)", msg.relocated)
+	}
+	toastCmd := m.addToast(text, ToastSuccess)
+	return m, tea.Batch(toastCmd, m.refreshWorktrees(), m.fetchFullStatuses())
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestRenameWorktreeKeyPrefillsBranch(t *testing.T) {
+	worktrees := []wt.Worktree{{Path: "/tmp/wt/test-repo/fix-tpyo", Branch: "fix-tpyo"}}
+	m := setupModel(t, session.SessionModeTUI, worktrees, "test-repo")
+	m.worktreeDropdown.SelectByID("fix-tpyo")
+
+	newModel, _ := m.handleKeyPress(keyPress('N'))
+	m2 := newModel.(Model)
+
+	require.True(t, m2.inputMode)
+	assert.Equal(t, FocusInput, m2.focus)
+	assert.Equal(t, "Rename fix-tpyo to: ", m2.inputPrompt)
+	assert.Equal(t, "fix-tpyo", m2.inputArea.Value())
+
+	cmd := m2.inputHandler(" fix-typo ", "", "")
+	require.NotNil(t, cmd)
+	assert.Equal(t, renameWorktreeMsg{branch: "fix-tpyo", newBranch: "fix-typo"}, cmd())
+}
+
+func TestRenameWorktreeKeyWithoutSelection(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, _ := m.handleKeyPress(keyPress('N'))
+	m2 := newModel.(Model)
+
+	assert.False(t, m2.inputMode)
+	assert.Contains(t, m2.toasts.toasts[0].Message, "Select a worktree first")
+}
+
+func TestRenameWorktreeUnchangedName(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+
+	newModel, _ := m.Update(renameWorktreeMsg{branch: "main", newBranch: "main"})
+	m2 := newModel.(Model)
+
+	assert.Empty(t, m2.worktreeOpMessages)
+	assert.Contains(t, m2.toasts.toasts[0].Message, "unchanged")
+}
+
+func TestWorktreeRenamedSelectsNewBranch(t *testing.T) {
+	m := setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	m.worktreeOpMessages = []string{"Renaming worktree fix-tpyo..."}
+
+	newModel, cmd := m.Update(worktreeRenamedMsg{oldBranch: "fix-tpyo", newBranch: "fix-typo", relocated: 2})
+	m2 := newModel.(Model)
+
+	assert.NotNil(t, cmd)
+	assert.Empty(t, m2.worktreeOpMessages)
+	assert.Equal(t, "fix-typo", m2.pendingWorktreeSelect)
+	assert.Equal(t, "Renamed fix-tpyo to fix-typo (2 session(s) moved)", m2.toasts.toasts[0].Message)
+
+	m = setupModel(t, session.SessionModeTUI, nil, "test-repo")
+	newModel, _ = m.Update(worktreeRenamedMsg{oldBranch: "fix-tpyo", err: errors.New("worktree already exists")})
+	m3 := newModel.(Model)
+	assert.Empty(t, m3.pendingWorktreeSelect)
+	assert.Equal(t, ToastError, m3.toasts.toasts[0].Level)
+}
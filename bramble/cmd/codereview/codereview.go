@@ -52,7 +52,7 @@ var Cmd = &cobra.Command{
 	Short:        "Run a one-shot code review using an agent backend",
 	Long: `Run a one-shot code review using an agent backend.
 
-Supported backends: cursor, codex, gemini.
+Supported backends: cursor, codex, gemini, claude.
 
 Output:
   Default:         NDJSON progress events on stdout, final envelope also on stdout
@@ -72,9 +72,9 @@ analysis. Set $BRAMBLE_RUN_TAG to tag the log with an external run id.`,
 }
 
 func init() {
-	Cmd.Flags().StringVar(&backend, "backend", "cursor", "Backend: cursor, codex, or gemini")
+	Cmd.Flags().StringVar(&backend, "backend", "cursor", "Backend: cursor, codex, gemini, or claude")
 	Cmd.Flags().StringVar(&model, "model", "", "Model override (default: backend-specific)")
-	Cmd.Flags().StringVar(&effort, "effort", "", "Reasoning effort level for codex (low, medium, high)")
+	Cmd.Flags().StringVar(&effort, "effort", "", "Reasoning effort level for codex (low, medium, high) or claude (low, medium, high, max)")
 	Cmd.Flags().StringVar(&sandbox, "sandbox", "", "Codex sandbox mode: read-only, workspace-write, danger-full-access (default: danger-full-access)")
 	Cmd.Flags().BoolVar(&readOnly, "read-only", true, "Deny file writes via approval handler (Codex only; default true)")
 	Cmd.Flags().BoolVar(&verbose, "verbose", false, "Show tool call details")
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -171,6 +172,34 @@ func TestParseCodexProtocolLog_TurnCompletionPreservesOtherThreadApprovals(t *te
 	assert.Equal(t, session.StatusIdle, result.Status)
 }
 
+func TestParseCodexProtocolLog_WebSearchRenderedAsTool(t *testing.T) {
+	logPath := writeLog(t, []string{
+		`{"format":"codex","version":"1.0","client":"test","timestamp":"2026-02-12T00:00:00Z"}`,
+		`{"timestamp":"2026-02-12T00:00:01Z","direction":"sent","message":{"method":"turn/start","params":{"threadId":"t1","input":[{"type":"text","text":"search it"}]}}}`,
+		`{"timestamp":"2026-02-12T00:00:02Z","direction":"received","message":{"method":"codex/event/web_search_begin","params":{"id":"0","conversationId":"t1","msg":{"type":"web_search_begin","call_id":"ws_1"}}}}`,
+		`{"timestamp":"2026-02-12T00:00:03Z","direction":"received","message":{"method":"codex/event/web_search_end","params":{"id":"0","conversationId":"t1","msg":{"type":"web_search_end","call_id":"ws_1","query":"codex release notes","results":[{"title":"Releases","url":"https://example.com/releases"}]}}}}`,
+		`{"timestamp":"2026-02-12T00:00:04Z","direction":"received","message":{"method":"turn/completed","params":{"threadId":"t1","turn":{"id":"turn-1","status":"completed","error":null,"items":[]}}}}`,
+	})
+
+	result, err := replay.Parse(logPath)
+	require.NoError(t, err)
+
+	var search *session.OutputLine
+	for i := range result.Lines {
+		if result.Lines[i].ToolName == "web_search" {
+			search = &result.Lines[i]
+		}
+	}
+	require.NotNil(t, search, "expected a web_search tool line")
+	assert.Equal(t, session.ToolStateComplete, search.ToolState)
+	assert.Equal(t, "codex release notes", search.ToolInput["query"])
+	assert.Contains(t, fmt.Sprint(search.ToolResult), "https://example.com/releases")
+
+	rendered, err := renderLog(logPath, cliConfig{width: 100, height: 40})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "🔎 web_search: codex release notes")
+}
+
 func writeLog(t *testing.T, lines []string) string {
 	t.Helper()
 	path := filepath.Join(t.TempDir(), "session.jsonl")
@@ -10,11 +10,18 @@
 //
 // Agents connect to ws(s)://<host>/agent; users open http(s)://<host>/ and log
 // in with the browser secret. Run behind TLS / a private network (Tailscale).
+//
+// BRAMBLE_HUB_SPECTATOR_SECRET (or --spectator, which generates one and prints
+// it) enables a second, read-only login: spectators can watch sessions but not
+// send input, keys, or stop/kill requests.
 package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -29,6 +36,7 @@ import (
 
 func main() {
 	var addr string
+	var spectator bool
 	root := &cobra.Command{
 		Use:   "bramble-hub",
 		Short: "Relay hub + web UI for remote bramble tmux sessions",
@@ -42,7 +50,17 @@ func main() {
 				return errors.New("BRAMBLE_HUB_AGENT_TOKEN must be set (agent access token)")
 			}
 
-			h := hub.NewHub(agentToken, hub.NewAuthenticator(secret))
+			spectatorSecret := os.Getenv("BRAMBLE_HUB_SPECTATOR_SECRET")
+			if spectatorSecret == "" && spectator {
+				spectatorSecret = newSecret()
+				fmt.Fprintf(os.Stderr, "spectator (read-only) secret: %s\n", spectatorSecret)
+			}
+			if spectatorSecret == secret {
+				return errors.New("spectator secret must differ from BRAMBLE_HUB_SECRET")
+			}
+
+			auth := hub.NewAuthenticator(secret).WithSpectatorSecret(spectatorSecret)
+			h := hub.NewHub(agentToken, auth)
 			srv := &http.Server{
 				Addr:              addr,
 				Handler:           h.Handler(),
@@ -58,7 +76,7 @@ func main() {
 				_ = srv.Shutdown(shutCtx)
 			}()
 
-			slog.Info("bramble hub listening", "addr", addr)
+			slog.Info("bramble hub listening", "addr", addr, "spectator", spectatorSecret != "")
 			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				return err
 			}
@@ -66,8 +84,17 @@ func main() {
 		},
 	}
 	root.Flags().StringVar(&addr, "addr", ":8787", "HTTP listen address")
+	root.Flags().BoolVar(&spectator, "spectator", false,
+		"Enable read-only spectator logins, generating a secret unless BRAMBLE_HUB_SPECTATOR_SECRET is set")
 	if err := root.Execute(); err != nil {
 		slog.Error("hub exited", "err", err)
 		os.Exit(1)
 	}
 }
+
+// newSecret returns a random hex secret for a generated spectator login.
+func newSecret() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
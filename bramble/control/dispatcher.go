@@ -3,7 +3,11 @@ package control
 import (
 	"context"
 	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
 
+	"github.com/bazelment/yoloswe/bramble/service"
 	"github.com/bazelment/yoloswe/bramble/session"
 	"github.com/bazelment/yoloswe/bramble/tmuxctl"
 )
@@ -16,6 +20,8 @@ type Registry interface {
 	ResolveTmuxTarget(id session.SessionID) (string, error)
 	CapturePaneText(id session.SessionID, n int) ([]string, error)
 	StopSession(id session.SessionID) error
+	StopAllSessions() []error
+	ExportCostCSV(w io.Writer) error
 }
 
 // Dispatcher handles control protocol requests against a registry (session
@@ -24,11 +30,13 @@ type Registry interface {
 type Dispatcher struct {
 	reg Registry
 	ctl tmuxctl.Controller
+	wts service.WorktreeService
 }
 
-// NewDispatcher constructs a Dispatcher.
+// NewDispatcher constructs a Dispatcher. Worktree requests are served from the
+// local filesystem.
 func NewDispatcher(reg Registry, ctl tmuxctl.Controller) *Dispatcher {
-	return &Dispatcher{reg: reg, ctl: ctl}
+	return &Dispatcher{reg: reg, ctl: ctl, wts: &service.LocalWorktreeService{}}
 }
 
 // Handle processes one request Msg and returns a response Msg. It never returns
@@ -59,6 +67,10 @@ func (d *Dispatcher) dispatch(ctx context.Context, req *Msg) (any, error) {
 		return d.sessionSelect(ctx, req)
 	case TypeSessionStop:
 		return d.sessionStop(req)
+	case TypeSessionStopAll:
+		return d.sessionStopAll(), nil
+	case TypeSessionCostReport:
+		return d.sessionCostReport()
 
 	case TypeTmuxListSessions:
 		return d.ctl.ListSessions(ctx)
@@ -100,6 +112,27 @@ func (d *Dispatcher) dispatch(ctx context.Context, req *Msg) (any, error) {
 		}
 		return OKResult{OK: true}, nil
 
+	case TypeWorktreeListTree:
+		var r WorktreeReq
+		if err := req.decode(&r); err != nil {
+			return nil, err
+		}
+		root, err := d.knownWorktree(r.WorktreePath)
+		if err != nil {
+			return nil, err
+		}
+		return d.wts.ListTree(ctx, root)
+	case TypeWorktreeReadFile:
+		var r WorktreeReq
+		if err := req.decode(&r); err != nil {
+			return nil, err
+		}
+		root, err := d.knownWorktree(r.WorktreePath)
+		if err != nil {
+			return nil, err
+		}
+		return d.wts.ReadFile(ctx, root, r.Path)
+
 	default:
 		return nil, fmt.Errorf("control: unsupported request type %q", req.Type)
 	}
@@ -219,6 +252,44 @@ func (d *Dispatcher) sessionStop(req *Msg) (OKResult, error) {
 	return OKResult{OK: true}, nil
 }
 
+// sessionStopAll stops every active session. Partial failure is reported in
+// the result rather than as a request error, since the other sessions did stop.
+func (d *Dispatcher) sessionStopAll() StopAllResult {
+	errs := d.reg.StopAllSessions()
+	out := StopAllResult{Errors: make([]string, 0, len(errs))}
+	for _, err := range errs {
+		out.Errors = append(out.Errors, err.Error())
+	}
+	return out
+}
+
+// sessionCostReport renders the registry's cost report as CSV. Remote mode
+// reaches it through the hub like every other control request.
+func (d *Dispatcher) sessionCostReport() (CostReportResult, error) {
+	var b strings.Builder
+	if err := d.reg.ExportCostCSV(&b); err != nil {
+		return CostReportResult{}, err
+	}
+	return CostReportResult{CSV: b.String()}, nil
+}
+
+// knownWorktree admits a worktree path only if a registered session runs in
+// it. Without this guard a remote peer could name any directory on the machine
+// as a "worktree" and read it; the per-file traversal checks alone only keep
+// reads inside whatever root was given.
+func (d *Dispatcher) knownWorktree(worktreePath string) (string, error) {
+	if worktreePath == "" {
+		return "", fmt.Errorf("control: worktree_path required")
+	}
+	want := filepath.Clean(worktreePath)
+	for _, s := range d.reg.GetAllSessions() {
+		if s.WorktreePath != "" && filepath.Clean(s.WorktreePath) == want {
+			return want, nil
+		}
+	}
+	return "", fmt.Errorf("control: %s is not a worktree with a bramble session", worktreePath)
+}
+
 // resolveTarget extracts a SessionRef from the request and resolves it.
 func (d *Dispatcher) resolveTarget(req *Msg) (string, error) {
 	var r SessionRef
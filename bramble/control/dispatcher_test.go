@@ -3,6 +3,7 @@ package control
 import (
 	"context"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,13 +15,15 @@ import (
 
 // fakeRegistry is a hand fake of the Registry interface for dispatcher tests.
 type fakeRegistry struct {
-	targets    map[string]string // sessionID -> tmux target
-	resolveErr error
-	captureErr error
-	stopErr    error
-	sessions   []session.SessionInfo
-	captured   []string
-	stopped    []string
+	targets     map[string]string // sessionID -> tmux target
+	resolveErr  error
+	captureErr  error
+	stopErr     error
+	sessions    []session.SessionInfo
+	captured    []string
+	stopAllErrs []error
+	stopped     []string
+	stopAll     int
 }
 
 func (f *fakeRegistry) GetAllSessions() []session.SessionInfo { return f.sessions }
@@ -51,6 +54,16 @@ func (f *fakeRegistry) StopSession(id session.SessionID) error {
 	return nil
 }
 
+func (f *fakeRegistry) StopAllSessions() []error {
+	f.stopAll++
+	return f.stopAllErrs
+}
+
+func (f *fakeRegistry) ExportCostCSV(w io.Writer) error {
+	_, err := io.WriteString(w, "session_id\ns1\n")
+	return err
+}
+
 func newDispatcher(reg *fakeRegistry) (*Dispatcher, *tmuxctl.FakeController) {
 	ctl := tmuxctl.NewFake()
 	return NewDispatcher(reg, ctl), ctl
@@ -218,3 +231,25 @@ func TestUnsupportedTypeErrors(t *testing.T) {
 
 // compile-time: the real registry satisfies the narrow Registry interface.
 var _ Registry = (*session.SessionRegistry)(nil)
+
+func TestSessionStopAll(t *testing.T) {
+	t.Parallel()
+	reg := &fakeRegistry{stopAllErrs: []error{fmt.Errorf("session not active: s2")}}
+	d, _ := newDispatcher(reg)
+	resp := d.Handle(context.Background(), req(t, TypeSessionStopAll, nil))
+
+	var res StopAllResult
+	require.NoError(t, resp.DecodeResponse(&res))
+	assert.Equal(t, 1, reg.stopAll)
+	assert.Equal(t, []string{"session not active: s2"}, res.Errors)
+}
+
+func TestSessionCostReport(t *testing.T) {
+	t.Parallel()
+	d, _ := newDispatcher(&fakeRegistry{})
+	resp := d.Handle(context.Background(), req(t, TypeSessionCostReport, nil))
+
+	var res CostReportResult
+	require.NoError(t, resp.DecodeResponse(&res))
+	assert.Equal(t, "session_id\ns1\n", res.CSV)
+}
@@ -29,6 +29,10 @@ const (
 	TypeSessionSendKey   MsgType = "session.send_key"
 	TypeSessionSelect    MsgType = "session.select"
 	TypeSessionStop      MsgType = "session.stop"
+	TypeSessionStopAll   MsgType = "session.stop_all"
+	// TypeSessionCostReport returns a CSV cost report covering live and
+	// stored sessions of every repo the agent has open.
+	TypeSessionCostReport MsgType = "session.cost_report"
 
 	// Raw-pane: address a tmux target (window/pane id) directly. Broader surface
 	// for power use; still constrained by the tmuxctl allowlist.
@@ -41,6 +45,11 @@ const (
 	TypePaneNewWindow    MsgType = "pane.new_window"
 	TypePaneKill         MsgType = "pane.kill"
 
+	// Worktree inspection: read-only file access inside a worktree that has a
+	// registered session. Served by a service.WorktreeService.
+	TypeWorktreeListTree MsgType = "worktree.list_tree"
+	TypeWorktreeReadFile MsgType = "worktree.read_file"
+
 	// Streaming: subscribe to live pane output. The server pushes TypePaneDelta
 	// frames (correlated by SubID) until TypePaneUnsubscribe. A terminal
 	// TypePaneError frame (also SubID-correlated) ends a subscription when the
@@ -107,6 +116,13 @@ type NewWindowReq struct {
 	Cmd  string `json:"cmd,omitempty"`
 }
 
+// WorktreeReq addresses a worktree, and optionally a file inside it (Path is
+// relative to WorktreePath).
+type WorktreeReq struct {
+	WorktreePath string `json:"worktree_path"`
+	Path         string `json:"path,omitempty"`
+}
+
 // SubscribeReq starts a live pane subscription. IntervalMS bounds how often the
 // server samples the pane (clamped server-side to a sane floor).
 type SubscribeReq struct {
@@ -133,6 +149,17 @@ type SessionSummary struct {
 	TmuxTarget   string `json:"tmux_target"`
 }
 
+// StopAllResult reports sessions that could not be stopped by a stop-all.
+type StopAllResult struct {
+	Errors []string `json:"errors"`
+}
+
+// CostReportResult carries a cost report in the CSV format written by
+// session.Manager.ExportCostCSV.
+type CostReportResult struct {
+	CSV string `json:"csv"`
+}
+
 // CaptureResult holds captured pane lines.
 type CaptureResult struct {
 	Lines []string `json:"lines"`
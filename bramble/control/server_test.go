@@ -3,12 +3,15 @@ package control
 import (
 	"context"
 	"net"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bazelment/yoloswe/bramble/service"
+	"github.com/bazelment/yoloswe/bramble/session"
 	"github.com/bazelment/yoloswe/bramble/tmuxctl"
 )
 
@@ -95,3 +98,47 @@ func TestJSONConnFraming(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "b", m2.ID)
 }
+
+// TestWorktreeRPCRoundTrip reads a worktree through the list-tree and
+// read-file requests over a real Unix socket, and checks that the dispatcher
+// refuses worktrees without a session and paths that escape the worktree.
+func TestWorktreeRPCRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	wtPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "main.go"), []byte("package main\n"), 0o644))
+	reg := &fakeRegistry{sessions: []session.SessionInfo{{ID: "s1", WorktreePath: wtPath}}}
+
+	sock := filepath.Join(t.TempDir(), "c.sock")
+	srv := NewUnixServer(sock, NewDispatcher(reg, tmuxctl.NewFake()))
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Close() })
+
+	ctx := context.Background()
+	call := func(typ MsgType, payload WorktreeReq, v any) error {
+		req, err := NewRequest(typ, "worktree", payload)
+		require.NoError(t, err)
+		resp, err := Request(ctx, sock, req)
+		if err != nil {
+			return err
+		}
+		return resp.DecodeResponse(v)
+	}
+
+	var tree service.Tree
+	require.NoError(t, call(TypeWorktreeListTree, WorktreeReq{WorktreePath: wtPath}, &tree))
+	assert.Equal(t, []service.TreeEntry{{Path: "main.go", Size: 13}}, tree.Entries)
+
+	var fc service.FileContent
+	require.NoError(t, call(TypeWorktreeReadFile, WorktreeReq{WorktreePath: wtPath, Path: "main.go"}, &fc))
+	assert.Equal(t, "package main\n", string(fc.Content))
+
+	err := call(TypeWorktreeReadFile, WorktreeReq{WorktreePath: wtPath, Path: "../../etc/passwd"}, &fc)
+	var remote *RemoteError
+	require.ErrorAs(t, err, &remote)
+	assert.Contains(t, remote.Message, "escapes worktree")
+
+	err = call(TypeWorktreeListTree, WorktreeReq{WorktreePath: t.TempDir()}, &tree)
+	require.ErrorAs(t, err, &remote)
+	assert.Contains(t, remote.Message, "not a worktree with a bramble session")
+}
@@ -1,6 +1,7 @@
 package hub
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/hex"
@@ -12,16 +13,36 @@ import (
 
 var errUnknownMachine = errors.New("unknown or disconnected machine")
 
+// Role is the access level a browser session was granted at login.
+type Role string
+
+const (
+	// RoleAdmin may view and drive sessions.
+	RoleAdmin Role = "admin"
+	// RoleSpectator may list machines and sessions and watch pane output, but
+	// every request that would change agent or tmux state is refused.
+	RoleSpectator Role = "spectator"
+)
+
 // Authenticator implements simple shared-secret browser auth: POST /login with
 // the secret mints a random session cookie held in memory. This mirrors
 // tmux-mobile's network-boundary + token trust model (intended to run behind
 // Tailscale/TLS), kept deliberately minimal and pluggable.
+//
+// An optional second secret logs in as RoleSpectator, for sharing a read-only
+// view of running sessions.
 type Authenticator struct {
-	sessions   map[string]time.Time // token -> expiry
-	secret     string
-	cookieName string
-	ttl        time.Duration
-	mu         sync.Mutex
+	sessions        map[string]authSession // token -> session
+	secret          string
+	spectatorSecret string
+	cookieName      string
+	ttl             time.Duration
+	mu              sync.Mutex
+}
+
+type authSession struct {
+	expiry time.Time
+	role   Role
 }
 
 // NewAuthenticator creates a shared-secret browser Authenticator.
@@ -29,11 +50,34 @@ func NewAuthenticator(secret string) *Authenticator {
 	return &Authenticator{
 		secret:     secret,
 		cookieName: "bramble_hub_session",
-		sessions:   make(map[string]time.Time),
+		sessions:   make(map[string]authSession),
 		ttl:        24 * time.Hour,
 	}
 }
 
+// WithSpectatorSecret enables read-only logins: the given secret mints a
+// RoleSpectator session. An empty secret leaves spectator access disabled.
+// It returns a for chaining.
+func (a *Authenticator) WithSpectatorSecret(secret string) *Authenticator {
+	a.spectatorSecret = secret
+	return a
+}
+
+// roleForSecret maps a presented login secret to its role. Both secrets are
+// always compared so the check does not reveal which one was close.
+func (a *Authenticator) roleForSecret(secret string) (Role, bool) {
+	admin := subtle.ConstantTimeCompare([]byte(secret), []byte(a.secret)) == 1
+	spectator := a.spectatorSecret != "" &&
+		subtle.ConstantTimeCompare([]byte(secret), []byte(a.spectatorSecret)) == 1
+	switch {
+	case admin && a.secret != "":
+		return RoleAdmin, true
+	case spectator:
+		return RoleSpectator, true
+	}
+	return "", false
+}
+
 // handleLogin accepts the shared secret (form field or query "secret") and sets
 // a session cookie. A GET renders a minimal login form.
 func (a *Authenticator) handleLogin(w http.ResponseWriter, r *http.Request) {
@@ -42,14 +86,14 @@ func (a *Authenticator) handleLogin(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(loginHTML))
 		return
 	}
-	secret := r.FormValue("secret")
-	if subtle.ConstantTimeCompare([]byte(secret), []byte(a.secret)) != 1 {
+	role, ok := a.roleForSecret(r.FormValue("secret"))
+	if !ok {
 		http.Error(w, "invalid secret", http.StatusUnauthorized)
 		return
 	}
 	tok := randomToken()
 	a.mu.Lock()
-	a.sessions[tok] = nowUTC().Add(a.ttl)
+	a.sessions[tok] = authSession{expiry: nowUTC().Add(a.ttl), role: role}
 	a.mu.Unlock()
 	http.SetCookie(w, &http.Cookie{
 		Name:     a.cookieName,
@@ -66,40 +110,67 @@ func (a *Authenticator) handleLogin(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
-// valid reports whether the request carries a live session cookie.
-func (a *Authenticator) valid(r *http.Request) bool {
+// valid reports whether the request carries a live session cookie, and the
+// role that session was granted.
+func (a *Authenticator) valid(r *http.Request) (Role, bool) {
 	c, err := r.Cookie(a.cookieName)
 	if err != nil {
-		return false
+		return "", false
 	}
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	exp, ok := a.sessions[c.Value]
+	sess, ok := a.sessions[c.Value]
 	if !ok {
-		return false
+		return "", false
 	}
-	if nowUTC().After(exp) {
+	if nowUTC().After(sess.expiry) {
 		delete(a.sessions, c.Value)
-		return false
+		return "", false
+	}
+	return sess.role, true
+}
+
+// LoginHandler serves the login form and exchanges a secret for a session
+// cookie, for other gateways that share this Authenticator's logins.
+func (a *Authenticator) LoginHandler() http.Handler {
+	return http.HandlerFunc(a.handleLogin)
+}
+
+// Authenticate reports whether the request carries a live session cookie,
+// and the role that session was granted.
+func (a *Authenticator) Authenticate(r *http.Request) (Role, bool) {
+	return a.valid(r)
+}
+
+type roleKey struct{}
+
+// roleFrom returns the role requireAuth attached to the request context.
+// Requests that never passed requireAuth get RoleSpectator, so a handler
+// mounted without the middleware fails closed.
+func roleFrom(ctx context.Context) Role {
+	if role, ok := ctx.Value(roleKey{}).(Role); ok {
+		return role
 	}
-	return true
+	return RoleSpectator
 }
 
 // requireAuth wraps a JSON API handler, returning 401 when unauthenticated.
+// The session's role is attached to the request context for roleFrom.
 func (h *Hub) requireAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !h.auth.valid(r) {
+		role, ok := h.auth.valid(r)
+		if !ok {
 			writeJSON(w, http.StatusUnauthorized, errBody(errors.New("unauthenticated")))
 			return
 		}
-		next(w, r)
+		next(w, r.WithContext(context.WithValue(r.Context(), roleKey{}, role)))
 	}
 }
 
 // requireAuthPage wraps a page handler, redirecting to /login when unauthed.
 func (h *Hub) requireAuthPage(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !h.auth.valid(r) {
+		if _, ok := h.auth.valid(r); !ok {
 			http.Redirect(w, r, "/login", http.StatusSeeOther)
 			return
 		}
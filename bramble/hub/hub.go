@@ -3,6 +3,7 @@ package hub
 import (
 	"crypto/subtle"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -39,6 +40,7 @@ func (h *Hub) Handler() http.Handler {
 	mux.HandleFunc("/agent", h.handleAgent)
 	// Browser API (cookie-authenticated).
 	mux.HandleFunc("/login", h.auth.handleLogin)
+	mux.HandleFunc("/api/whoami", h.requireAuth(h.handleWhoami))
 	mux.HandleFunc("/api/machines", h.requireAuth(h.handleMachines))
 	mux.HandleFunc("/api/control", h.requireAuth(h.handleControl))
 	mux.HandleFunc("/api/stream", h.requireAuth(h.handleStream))
@@ -109,6 +111,40 @@ func (h *Hub) handleMachines(w http.ResponseWriter, _ *http.Request) {
 	writeJSON(w, http.StatusOK, h.reg.list())
 }
 
+// handleWhoami reports the caller's role so the web UI can hide the controls a
+// spectator is not allowed to use.
+func (h *Hub) handleWhoami(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]Role{"role": roleFrom(r.Context())})
+}
+
+// spectatorTypes lists the control requests a RoleSpectator may send: listing,
+// status, capture, read-only worktree access, and pane subscriptions. Anything
+// not listed — input, keys, stop, new window, kill, and types added later — is
+// refused, so the allowlist fails closed.
+var spectatorTypes = map[control.MsgType]bool{
+	control.TypeSessionList:      true,
+	control.TypeSessionStatus:    true,
+	control.TypeSessionCapture:   true,
+	control.TypeTmuxListSessions: true,
+	control.TypeTmuxListWindows:  true,
+	control.TypeTmuxListPanes:    true,
+	control.TypePaneCapture:      true,
+	control.TypeWorktreeListTree: true,
+	control.TypeWorktreeReadFile: true,
+	control.TypePaneSubscribe:    true,
+	control.TypePaneUnsubscribe:  true,
+}
+
+// permitted reports whether role may send a control request of type t.
+func permitted(role Role, t control.MsgType) bool {
+	return role == RoleAdmin || spectatorTypes[t]
+}
+
+// errReadOnly is returned to a spectator that sends a mutating request.
+func errReadOnly(t control.MsgType) error {
+	return fmt.Errorf("read-only: spectators cannot send %s", t)
+}
+
 // handleControl forwards a one-shot control request to a machine and returns
 // the agent's response verbatim. Body: {"machine_id": "...", "msg": {control.Msg}}.
 func (h *Hub) handleControl(w http.ResponseWriter, r *http.Request) {
@@ -130,6 +166,10 @@ func (h *Hub) handleControl(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusBadRequest, errBody(err))
 		return
 	}
+	if !permitted(roleFrom(r.Context()), req.Type) {
+		writeJSON(w, http.StatusForbidden, errBody(errReadOnly(req.Type)))
+		return
+	}
 	resp, err := m.request(&req)
 	if err != nil {
 		writeJSON(w, http.StatusBadGateway, errBody(err))
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/cookiejar"
 	"net/http/httptest"
@@ -50,13 +51,20 @@ func (f *fakeRegistry) ResolveTmuxTarget(id session.SessionID) (string, error) {
 }
 func (f *fakeRegistry) CapturePaneText(session.SessionID, int) ([]string, error) { return nil, nil }
 func (f *fakeRegistry) StopSession(session.SessionID) error                      { return nil }
+func (f *fakeRegistry) StopAllSessions() []error                                 { return nil }
+func (f *fakeRegistry) ExportCostCSV(io.Writer) error                            { return nil }
 
 // startTestHub starts an httptest hub and connects an in-process agent to it,
 // returning the hub server and the fake controller the agent drives. The agent
 // uses control.Serve so the full agent dispatch path is exercised.
 func startTestHub(t *testing.T, agentToken, browserSecret string) (*httptest.Server, *tmuxctl.FakeController) {
 	t.Helper()
-	hub := NewHub(agentToken, NewAuthenticator(browserSecret))
+	return startTestHubWith(t, NewHub(agentToken, NewAuthenticator(browserSecret)), agentToken)
+}
+
+// startTestHubWith is startTestHub for a caller-configured hub.
+func startTestHubWith(t *testing.T, hub *Hub, agentToken string) (*httptest.Server, *tmuxctl.FakeController) {
+	t.Helper()
 	srv := httptest.NewServer(hub.Handler())
 	t.Cleanup(srv.Close)
 
@@ -270,3 +278,90 @@ func TestBrowserStreamSendInputAndDelta(t *testing.T) {
 	assert.Equal(t, "@3", ctl.CallsFor("Paste")[0].Target)
 	assert.Equal(t, "do it", ctl.CallsFor("Paste")[0].Text)
 }
+
+func TestSpectatorLoginReadOnly(t *testing.T) {
+	t.Parallel()
+	hub := NewHub("atok", NewAuthenticator("browser-secret").WithSpectatorSecret("watch-secret"))
+	srv := httptest.NewServer(hub.Handler())
+	t.Cleanup(srv.Close)
+
+	whoami := func(c *http.Client) Role {
+		resp, err := c.Get(srv.URL + "/api/whoami")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var out map[string]Role
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return out["role"]
+	}
+	assert.Equal(t, RoleAdmin, whoami(login(t, srv, "browser-secret")))
+	assert.Equal(t, RoleSpectator, whoami(login(t, srv, "watch-secret")))
+}
+
+func TestSpectatorSecretDisabledByDefault(t *testing.T) {
+	t.Parallel()
+	srv, _ := startTestHub(t, "atok", "browser-secret")
+
+	resp, err := http.PostForm(srv.URL+"/login", url.Values{"secret": {""}})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestPermitted(t *testing.T) {
+	t.Parallel()
+	for _, typ := range []control.MsgType{
+		control.TypeSessionList, control.TypeSessionCapture, control.TypePaneSubscribe, control.TypeWorktreeReadFile,
+	} {
+		assert.True(t, permitted(RoleSpectator, typ), typ)
+	}
+	for _, typ := range []control.MsgType{
+		control.TypeSessionSendInput, control.TypeSessionSendKey, control.TypeSessionStop,
+		control.TypeSessionStopAll, control.TypePaneNewWindow, control.TypePaneKill, "future.op",
+	} {
+		assert.False(t, permitted(RoleSpectator, typ), typ)
+		assert.True(t, permitted(RoleAdmin, typ), typ)
+	}
+}
+
+// TestSpectatorControlRejectsMutations checks that a spectator can list
+// sessions over /api/control and the stream socket but that input and stop
+// requests are refused before they reach the agent.
+func TestSpectatorControlRejectsMutations(t *testing.T) {
+	t.Parallel()
+	hub := NewHub("atok", NewAuthenticator("browser-secret").WithSpectatorSecret("watch-secret"))
+	srv, ctl := startTestHubWith(t, hub, "atok")
+	c := login(t, srv, "watch-secret")
+
+	post := func(msg *control.Msg) int {
+		body, _ := json.Marshal(map[string]any{"machine_id": "m1", "msg": msg})
+		resp, err := c.Post(srv.URL+"/api/control", "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+	list, _ := control.NewRequest(control.TypeSessionList, "", nil)
+	assert.Equal(t, http.StatusOK, post(list))
+	stop, _ := control.NewRequest(control.TypeSessionStop, "", control.SessionRef{SessionID: "s1"})
+	assert.Equal(t, http.StatusForbidden, post(stop))
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/stream?machine=m1"
+	header := http.Header{}
+	for _, ck := range c.Jar.Cookies(mustParseURL(t, srv.URL)) {
+		header.Add("Cookie", ck.Name+"="+ck.Value)
+	}
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+	require.NoError(t, err)
+	defer ws.Close()
+	conn := control.NewWSConn(ws)
+
+	in, _ := control.NewRequest(control.TypeSessionSendInput, "b1",
+		control.SendInputReq{SessionID: "s1", Text: "rm -rf", Submit: true})
+	require.NoError(t, conn.WriteMsg(in))
+	resp, err := conn.ReadMsg()
+	require.NoError(t, err)
+	assert.Equal(t, "b1", resp.ID)
+	err = resp.DecodeResponse(&control.OKResult{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read-only")
+	assert.Empty(t, ctl.CallsFor("Paste"))
+}
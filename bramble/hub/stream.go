@@ -30,7 +30,7 @@ func (h *Hub) handleStream(w http.ResponseWriter, r *http.Request) {
 	conn := control.NewWSConn(ws)
 	defer conn.Close()
 
-	b := &browserBridge{conn: conn, machine: m, prefix: m.nextID() + ":", subs: make(map[string]struct{})}
+	b := &browserBridge{conn: conn, machine: m, prefix: m.nextID() + ":", subs: make(map[string]struct{}), role: roleFrom(r.Context())}
 	defer b.closeSubs()
 
 	for {
@@ -51,15 +51,23 @@ func (h *Hub) handleStream(w http.ResponseWriter, r *http.Request) {
 // reaches the machine. Two tabs that both pick "s1" thus get distinct
 // machine-side keys and cannot clobber or unsubscribe each other's stream; the
 // prefix is stripped off delta/error frames so the browser still sees its own id.
+//
+// Requests the connection's role may not send are answered with an error and
+// never reach the machine.
 type browserBridge struct {
 	conn    control.Conn
 	machine *machine
 	subs    map[string]struct{}
 	prefix  string
+	role    Role
 	mu      sync.Mutex
 }
 
 func (b *browserBridge) handle(msg *control.Msg) {
+	if !permitted(b.role, msg.Type) {
+		b.reply(control.NewErr(msg.ID, errReadOnly(msg.Type).Error()))
+		return
+	}
 	switch msg.Type {
 	case control.TypePaneSubscribe:
 		// Snapshot the browser's correlation ID: machine.subscribe forwards msg and
@@ -60,3 +60,23 @@ func (c *Client) Ping() error {
 	}
 	return nil
 }
+
+// SessionOutput fetches a session's rendered output.
+func (c *Client) SessionOutput(params *SessionOutputParams) (*SessionOutputResult, error) {
+	resp, err := c.Send(&Request{Type: RequestSessionOutput, ID: "session-output", Params: params})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("server error: %s", resp.Error)
+	}
+	raw, err := json.Marshal(resp.Result)
+	if err != nil {
+		return nil, err
+	}
+	var result SessionOutputResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode session output: %w", err)
+	}
+	return &result, nil
+}
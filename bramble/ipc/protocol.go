@@ -11,6 +11,9 @@ const (
 	RequestListSessions RequestType = "list-sessions"
 	RequestNotify       RequestType = "notify"
 	RequestCapturePane  RequestType = "capture-pane"
+	// RequestSessionOutput returns a session's output rendered as the TUI
+	// shows it, for viewers such as "bramble follow-session".
+	RequestSessionOutput RequestType = "session-output"
 )
 
 // Request is the envelope sent by the client to the server.
@@ -78,5 +81,19 @@ type CapturePaneResult struct {
 	Lines []string `json:"lines"`
 }
 
+// SessionOutputParams are the parameters for a session-output request.
+type SessionOutputParams struct {
+	SessionID string `json:"session_id"`
+	Width     int    `json:"width,omitempty"`  // render width in columns (default: 100)
+	Height    int    `json:"height,omitempty"` // render height in rows (default: 30)
+}
+
+// SessionOutputResult is the result of a successful session-output request.
+type SessionOutputResult struct {
+	Status string   `json:"status"`
+	Lines  []string `json:"lines"`
+	Done   bool     `json:"done"` // the session reached a terminal status
+}
+
 // SockEnvVar is the environment variable name used to discover the socket path.
 const SockEnvVar = "BRAMBLE_SOCK"
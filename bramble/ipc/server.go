@@ -160,6 +160,12 @@ func (s *Server) decodeParams(req *Request) error {
 			return err
 		}
 		req.Params = &p
+	case RequestSessionOutput:
+		var p SessionOutputParams
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return err
+		}
+		req.Params = &p
 	default:
 		// No typed params needed
 	}
@@ -170,3 +170,26 @@ func TestHandlerError(t *testing.T) {
 	require.Equal(t, "req-err", resp.ID)
 	require.Contains(t, resp.Error, "worktree not found")
 }
+
+func TestSessionOutputRoundTrip(t *testing.T) {
+	t.Parallel()
+	sockPath := filepath.Join(t.TempDir(), "test.sock")
+
+	srv := NewServer(sockPath)
+	srv.Handle(RequestSessionOutput, func(_ context.Context, req *Request) (any, error) {
+		params, ok := req.Params.(*SessionOutputParams)
+		if !ok {
+			return nil, fmt.Errorf("invalid params type")
+		}
+		return &SessionOutputResult{
+			Status: "running",
+			Lines:  []string{params.SessionID, fmt.Sprintf("%dx%d", params.Width, params.Height)},
+		}, nil
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Close()
+
+	result, err := NewClient(sockPath).SessionOutput(&SessionOutputParams{SessionID: "s1", Width: 80, Height: 24})
+	require.NoError(t, err)
+	require.Equal(t, &SessionOutputResult{Status: "running", Lines: []string{"s1", "80x24"}}, result)
+}
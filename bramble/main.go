@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"expvar"
 	"fmt"
 	"io"
@@ -31,11 +32,13 @@ import (
 	"github.com/bazelment/yoloswe/bramble/cmd/meetingbot"
 	"github.com/bazelment/yoloswe/bramble/cmd/speak"
 	"github.com/bazelment/yoloswe/bramble/control"
+	"github.com/bazelment/yoloswe/bramble/hub"
 	"github.com/bazelment/yoloswe/bramble/ipc"
 	"github.com/bazelment/yoloswe/bramble/remote"
 	"github.com/bazelment/yoloswe/bramble/session"
 	"github.com/bazelment/yoloswe/bramble/taskrouter"
 	"github.com/bazelment/yoloswe/bramble/tmuxctl"
+	"github.com/bazelment/yoloswe/bramble/webview"
 	"github.com/bazelment/yoloswe/multiagent/agent"
 	"github.com/bazelment/yoloswe/wt"
 	"github.com/bazelment/yoloswe/yoloswe"
@@ -75,7 +78,8 @@ all opened repos are visible in the Shift-S overlay.
 Environment:
   WT_ROOT     Base directory for worktrees (default: ~/worktrees)
   EDITOR      Editor command for [e]dit (default: code)
-  BRAMBLE_PROTOCOL_LOG_DIR  Directory for Codex/Gemini protocol logs`,
+  BRAMBLE_PROTOCOL_LOG_DIR  Directory for Codex/Gemini protocol logs
+  BRAMBLE_PROTOCOL_LOG_RAW  Set to 1 to disable secret redaction in Gemini protocol logs`,
 	RunE: runTUI,
 }
 
@@ -124,6 +128,11 @@ func runTUI(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
+	// Register user-defined themes before anything resolves settings.ThemeName.
+	for _, err := range app.LoadCustomThemes() {
+		slog.Warn("skipping custom theme", "err", err)
+	}
+
 	// Get WT_ROOT (same as wt command)
 	wtRoot, err := resolveWTRoot()
 	if err != nil {
@@ -197,6 +206,7 @@ func runTUI(cmd *cobra.Command, args []string) error {
 			}
 			return os.Getenv("BRAMBLE_PROTOCOL_LOG_DIR")
 		}(),
+		RawProtocolLogs: os.Getenv("BRAMBLE_PROTOCOL_LOG_RAW") == "1",
 	}
 
 	// Initialize session manager for the initial repo.
@@ -292,6 +302,9 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	// opened repos are cleaned up properly.
 	if m, ok := finalModel.(app.Model); ok {
 		m.CloseSecondaryManagers(repoName)
+		if err := m.SaveLastSelection(); err != nil {
+			slog.Warn("failed to save last selection", "err", err)
+		}
 	}
 
 	return nil
@@ -425,6 +438,14 @@ func startIPCServer(registry *session.SessionRegistry, wtRoot, repoName string)
 		return &ipc.CapturePaneResult{Lines: lines}, nil
 	})
 
+	srv.Handle(ipc.RequestSessionOutput, func(_ context.Context, req *ipc.Request) (any, error) {
+		params, ok := req.Params.(*ipc.SessionOutputParams)
+		if !ok {
+			return nil, fmt.Errorf("invalid params")
+		}
+		return handleSessionOutput(registry, params)
+	})
+
 	srv.Handle(ipc.RequestNotify, func(_ context.Context, req *ipc.Request) (any, error) {
 		params, ok := req.Params.(*ipc.NotifyParams)
 		if !ok {
@@ -568,6 +589,30 @@ func handleListSessions(registry *session.SessionRegistry) *ipc.ListSessionsResu
 	return &ipc.ListSessionsResult{Sessions: summaries}
 }
 
+// handleSessionOutput renders a session's output the way the TUI's output
+// pane does, sized to the viewer's terminal.
+func handleSessionOutput(registry *session.SessionRegistry, params *ipc.SessionOutputParams) (*ipc.SessionOutputResult, error) {
+	sid := session.SessionID(params.SessionID)
+	info, mgr, ok := registry.GetSessionInfo(sid)
+	if !ok {
+		return nil, fmt.Errorf("session not found: %s", params.SessionID)
+	}
+	width, height := params.Width, params.Height
+	if width <= 0 {
+		width = 100
+	}
+	if height <= 0 {
+		height = 30
+	}
+	model := app.NewOutputModelWithMarkdown(&info, mgr.GetSessionOutput(sid), width)
+	model.SetSize(width, height)
+	return &ipc.SessionOutputResult{
+		Status: string(info.Status),
+		Lines:  strings.Split(strings.TrimRight(model.View().Content, "\n"), "\n"),
+		Done:   info.Status.IsTerminal(),
+	}, nil
+}
+
 // --- CLI subcommands (client mode) -------------------------------------------
 
 var pingCmd = &cobra.Command{
@@ -735,6 +780,63 @@ var listSessionsCmd = &cobra.Command{
 	},
 }
 
+var followSessionCmd = &cobra.Command{
+	Use:   "follow-session",
+	Short: "Follow a session's output from the running bramble TUI",
+	Long: `Follow-session redraws a session's output, rendered as the TUI shows it,
+whenever it changes, and exits once the session finishes. It only views the
+session, which keeps running in the TUI. The TUI's pop-out key (O) runs it in
+a new tmux window.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := ipc.NewClientFromEnv()
+		if err != nil {
+			return err
+		}
+		sessionID, _ := cmd.Flags().GetString("session-id")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			interval = time.Second
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return followSession(ctx, client, sessionID, interval, os.Stdout)
+	},
+}
+
+// followSession polls a session's rendered output every interval and
+// redraws out whenever it changes, until the session finishes or ctx ends.
+func followSession(ctx context.Context, client *ipc.Client, sessionID string, interval time.Duration, out io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last string
+	for {
+		width, height := 100, 30
+		if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			// Leave a row for the final status line.
+			width, height = w, h-1
+		}
+		res, err := client.SessionOutput(&ipc.SessionOutputParams{SessionID: sessionID, Width: width, Height: height})
+		if err != nil {
+			return err
+		}
+		if frame := strings.Join(res.Lines, "\n"); frame != last {
+			last = frame
+			// Home the cursor and clear the screen before each redraw.
+			fmt.Fprint(out, "\x1b[H\x1b[2J"+frame+"\n")
+		}
+		if res.Done {
+			fmt.Fprintf(out, "Session %s %s.\n", sessionID, res.Status)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 // runControl performs a one-shot control request against the running bramble's
 // control socket and decodes the result into v (v may be nil).
 func runControl(typ control.MsgType, payload, v any) error {
@@ -792,6 +894,67 @@ var sendKeyCmd = &cobra.Command{
 	},
 }
 
+var costReportCmd = &cobra.Command{
+	Use:   "cost-report",
+	Short: "Print a CSV cost report of live and stored sessions",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		var res control.CostReportResult
+		if err := runControl(control.TypeSessionCostReport, nil, &res); err != nil {
+			return err
+		}
+		fmt.Print(res.CSV)
+		return nil
+	},
+}
+
+var webCmd = &cobra.Command{
+	Use:   "web",
+	Short: "Serve a read-only web view of the running bramble's sessions",
+	Long: `Web serves a browser view of the running bramble TUI: a page listing its
+sessions by worktree, and each session's pane output streamed live with
+Server-Sent Events. It talks to the TUI over its control socket, the same
+one send-input uses, and is read-only: nothing it serves can drive a session.
+
+Viewers log in the way hub spectators do: with the secret in
+$BRAMBLE_HUB_SPECTATOR_SECRET, entered on the login page, which sets a session
+cookie. Bind to localhost or put it behind TLS / a private network (Tailscale)
+to share it.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		sock, _ := cmd.Flags().GetString("control-sock")
+		secret := os.Getenv("BRAMBLE_HUB_SPECTATOR_SECRET")
+		if secret == "" {
+			return errors.New("BRAMBLE_HUB_SPECTATOR_SECRET must be set (spectator login secret)")
+		}
+		if sock == "" {
+			sock = os.Getenv(control.SockEnvVar)
+		}
+		if sock == "" {
+			return fmt.Errorf("--control-sock is required when $%s is not set", control.SockEnvVar)
+		}
+
+		srv := &http.Server{
+			Addr:              addr,
+			Handler:           webview.New(webview.UnixDialer(sock), hub.NewAuthenticator("").WithSpectatorSecret(secret)).Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = srv.Shutdown(shutCtx)
+		}()
+
+		fmt.Fprintf(os.Stderr, "bramble web view on http://%s/\n", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
 var codetalkCmd = &cobra.Command{
 	Use:   "codetalk [flags] <prompt>",
 	Short: "Start a code understanding session",
@@ -914,6 +1077,10 @@ func init() {
 	capturePaneCmd.Flags().Int("lines", 10, "Number of lines to capture")
 	_ = capturePaneCmd.MarkFlagRequired("session-id")
 
+	followSessionCmd.Flags().String("session-id", "", "Session ID to follow")
+	followSessionCmd.Flags().Duration("interval", time.Second, "How often to poll for new output")
+	_ = followSessionCmd.MarkFlagRequired("session-id")
+
 	sendInputCmd.Flags().String("session-id", "", "Target bramble session ID (session-centric)")
 	sendInputCmd.Flags().String("target", "", "Raw tmux target (window/pane id) instead of a session")
 	sendInputCmd.Flags().String("text", "", "Text to deliver to the pane")
@@ -925,6 +1092,9 @@ func init() {
 	sendKeyCmd.Flags().String("key", "", "Named key: Enter, Escape, C-c, C-d, Tab, BSpace, Up, Down, Left, Right")
 	_ = sendKeyCmd.MarkFlagRequired("key")
 
+	webCmd.Flags().String("addr", "127.0.0.1:8788", "Address to serve the web view on")
+	webCmd.Flags().String("control-sock", "", "Control socket of the bramble to view (defaults to $"+control.SockEnvVar+")")
+
 	codetalkCmd.Flags().StringP("model", "m", "opus", "Model to use (e.g. opus, sonnet)")
 	codetalkCmd.Flags().String("dir", "", "Working directory (defaults to current directory)")
 	codetalkCmd.Flags().String("record", "", "Directory for session recordings (defaults to ~/.yoloswe)")
@@ -936,8 +1106,11 @@ func init() {
 	rootCmd.AddCommand(listSessionsCmd)
 	rootCmd.AddCommand(notifyCmd)
 	rootCmd.AddCommand(capturePaneCmd)
+	rootCmd.AddCommand(followSessionCmd)
 	rootCmd.AddCommand(sendInputCmd)
 	rootCmd.AddCommand(sendKeyCmd)
+	rootCmd.AddCommand(costReportCmd)
+	rootCmd.AddCommand(webCmd)
 	rootCmd.AddCommand(codereview.Cmd)
 	rootCmd.AddCommand(delegator.Cmd)
 	rootCmd.AddCommand(codetalkCmd)
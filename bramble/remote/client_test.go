@@ -2,6 +2,7 @@ package remote
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -31,6 +32,8 @@ func (f *fakeRegistry) ResolveTmuxTarget(id session.SessionID) (string, error) {
 }
 func (f *fakeRegistry) CapturePaneText(session.SessionID, int) ([]string, error) { return nil, nil }
 func (f *fakeRegistry) StopSession(session.SessionID) error                      { return nil }
+func (f *fakeRegistry) StopAllSessions() []error                                 { return nil }
+func (f *fakeRegistry) ExportCostCSV(io.Writer) error                            { return nil }
 
 func assertNotFound(id session.SessionID) error {
 	return &control.RemoteError{Message: "not found: " + string(id)}
@@ -1,74 +1,12 @@
 package replay
 
 import (
-	"bufio"
-	"encoding/json"
 	"os"
-	"strings"
-	"time"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
 	"github.com/bazelment/yoloswe/bramble/session"
 )
 
-// codexReplayParser accumulates state while parsing a Codex protocol log.
-type codexReplayParser struct { //nolint:govet // fieldalignment: readability over packing
-	lines            []session.OutputLine
-	itemTextLine     map[string]int
-	threadActiveItem map[string]string
-	toolLineIndex    map[string]int
-	threadTokenUsage map[string]codex.TokenUsage
-	// threadCumulativeBaseline holds the cumulative TotalTokenUsage seen
-	// at the previous TurnCompleted on threads whose Codex protocol
-	// version omits per-turn LastTokenUsage. We subtract it from the
-	// next cumulative reading to recover a per-turn delta. Empty for
-	// modern Codex logs (LastTokenUsage is per-turn already).
-	threadCumulativeBaseline map[string]codex.TokenUsage
-	// threadUsageCumulative is true when the most recent token-usage
-	// event on this thread was a cumulative-only fallback. Replay must
-	// label such usage so it isn't read as a per-turn delta.
-	threadUsageCumulative map[string]bool
-	threadReasoning       map[string]bool
-	threadText            map[string]*strings.Builder
-	threadFailures        map[string]struct{}
-	pendingApprovals      map[string]map[string]struct{}
-	emittedApprovals      map[string]map[string]struct{}
-	prompt                string
-	turnCount             int
-	turnStarts            int
-	turnCompletions       int
-	hadProviderErrors     bool
-}
-
-type codexExecApprovalRequest struct { //nolint:govet // fieldalignment: readability over packing
-	Command []string `json:"command"`
-	CallID  string   `json:"call_id"`
-	Reason  string   `json:"reason"`
-}
-
-type codexItemApprovalRequest struct {
-	ThreadID string `json:"threadId"`
-	ItemID   string `json:"itemId"`
-	Reason   string `json:"reason"`
-	Command  string `json:"command"`
-}
-
-func newCodexReplayParser() *codexReplayParser {
-	return &codexReplayParser{
-		itemTextLine:             make(map[string]int),
-		threadActiveItem:         make(map[string]string),
-		toolLineIndex:            make(map[string]int),
-		threadTokenUsage:         make(map[string]codex.TokenUsage),
-		threadCumulativeBaseline: make(map[string]codex.TokenUsage),
-		threadUsageCumulative:    make(map[string]bool),
-		threadReasoning:          make(map[string]bool),
-		threadText:               make(map[string]*strings.Builder),
-		threadFailures:           make(map[string]struct{}),
-		pendingApprovals:         make(map[string]map[string]struct{}),
-		emittedApprovals:         make(map[string]map[string]struct{}),
-	}
-}
-
 func parseCodexLog(path string) (*Result, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -76,500 +14,51 @@ func parseCodexLog(path string) (*Result, error) {
 	}
 	defer f.Close()
 
-	p := newCodexReplayParser()
-	scanner := bufio.NewScanner(f)
-	buf := make([]byte, 0, 1024*1024)
-	scanner.Buffer(buf, 10*1024*1024)
-
-	for scanner.Scan() {
-		var entry codex.SessionLogEntry
-		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
-			continue
-		}
-		if entry.Direction == "" {
-			continue // header
-		}
-		ts := parseTimestamp(entry.Timestamp)
-		switch entry.Direction {
-		case "sent":
-			p.handleSent(entry.Message, ts)
-		case "received":
-			p.handleReceived(entry.Message, ts)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
+	replay, err := codex.ParseProtocolLog(f)
+	if err != nil {
 		return nil, err
 	}
 
+	lines := make([]session.OutputLine, len(replay.Lines))
+	for i := range replay.Lines {
+		lines[i] = outputLineFromCodex(&replay.Lines[i])
+	}
 	return &Result{
-		Lines:  p.lines,
-		Prompt: p.prompt,
-		Status: p.deriveStatus(),
+		Lines:  lines,
+		Prompt: replay.Prompt,
+		Status: sessionStatusFromCodex(replay.Status),
 		Format: FormatCodex,
 	}, nil
 }
 
-func (p *codexReplayParser) handleSent(raw json.RawMessage, ts time.Time) {
-	var msg struct {
-		Method string          `json:"method"`
-		Params json.RawMessage `json:"params"`
-	}
-	if err := json.Unmarshal(raw, &msg); err != nil {
-		return
-	}
-	if msg.Method != "turn/start" {
-		return
-	}
-
-	var params codex.TurnStartParams
-	if err := json.Unmarshal(msg.Params, &params); err != nil {
-		return
-	}
-
-	p.turnStarts++
-
-	text := strings.TrimSpace(firstTurnText(params.Input))
-	if text == "" {
-		return
-	}
-
-	if p.prompt == "" {
-		p.prompt = text
-	} else {
-		p.lines = append(p.lines,
-			session.OutputLine{
-				Timestamp: ts,
-				Type:      session.OutputTypeStatus,
-				Content:   "Follow-up prompt:",
-			},
-			session.OutputLine{
-				Timestamp: ts,
-				Type:      session.OutputTypeText,
-				Content:   text,
-			},
-		)
-	}
-
-	p.threadText[params.ThreadID] = &strings.Builder{}
-	p.threadReasoning[params.ThreadID] = false
-	p.threadActiveItem[params.ThreadID] = ""
-}
-
-func (p *codexReplayParser) handleReceived(raw json.RawMessage, ts time.Time) {
-	var msg struct {
-		Method string          `json:"method"`
-		Params json.RawMessage `json:"params"`
-	}
-	if err := json.Unmarshal(raw, &msg); err != nil {
-		return
-	}
-
-	if mapped, ok := codex.ParseMappedNotification(msg.Method, msg.Params); ok {
-		p.handleMappedEvent(mapped, ts)
-		return
-	}
-
-	switch msg.Method {
-	case "item/reasoning/summaryTextDelta":
-		var notif struct {
-			ThreadID string `json:"threadId"`
-			Delta    string `json:"delta"`
-		}
-		if err := json.Unmarshal(msg.Params, &notif); err != nil {
-			return
-		}
-		if p.threadReasoning[notif.ThreadID] {
-			return
-		}
-		p.appendOrAddThinking(ts, notif.Delta)
-
-	case "codex/event/reasoning_content_delta":
-		var notif codex.CodexEventNotification
-		if err := json.Unmarshal(msg.Params, &notif); err != nil {
-			return
-		}
-		var reasoning struct {
-			Delta string `json:"delta"`
-		}
-		if err := json.Unmarshal(notif.Msg, &reasoning); err != nil {
-			return
-		}
-		if p.threadReasoning[notif.ConversationID] {
-			return
-		}
-		p.appendOrAddThinking(ts, reasoning.Delta)
-
-	case codex.NotifyCodexEventTaskComplete:
-		var notif codex.CodexEventNotification
-		if err := json.Unmarshal(msg.Params, &notif); err != nil {
-			return
-		}
-		var taskComplete codex.TaskCompleteMsg
-		if err := json.Unmarshal(notif.Msg, &taskComplete); err != nil {
-			return
-		}
-		last := strings.TrimSpace(taskComplete.LastAgentMessage)
-		if last == "" {
-			return
-		}
-		threadText := p.threadText[notif.ConversationID]
-		if threadText == nil || threadText.Len() == 0 {
-			p.appendOrAddText(ts, last)
-		}
-
-	case codex.NotifyItemCompleted:
-		var notif struct {
-			ThreadID string `json:"threadId"`
-			Item     struct {
-				Type string `json:"type"`
-				ID   string `json:"id"`
-				Text string `json:"text"`
-			} `json:"item"`
-		}
-		if err := json.Unmarshal(msg.Params, &notif); err != nil {
-			return
-		}
-		if !strings.EqualFold(notif.Item.Type, "agentMessage") || strings.TrimSpace(notif.Item.Text) == "" {
-			return
-		}
-		p.setFinalItemText(ts, notif.ThreadID, notif.Item.ID, notif.Item.Text)
-
-	case "codex/event/exec_approval_request":
-		var notif codex.CodexEventNotification
-		if err := json.Unmarshal(msg.Params, &notif); err != nil {
-			return
-		}
-		var req codexExecApprovalRequest
-		if err := json.Unmarshal(notif.Msg, &req); err != nil {
-			return
-		}
-		p.recordApprovalRequest(
-			ts,
-			notif.ConversationID,
-			req.CallID,
-			strings.TrimSpace(strings.Join(req.Command, " ")),
-			req.Reason,
-		)
-
-	case "item/commandExecution/requestApproval":
-		var req codexItemApprovalRequest
-		if err := json.Unmarshal(msg.Params, &req); err != nil {
-			return
-		}
-		p.recordApprovalRequest(ts, req.ThreadID, req.ItemID, req.Command, req.Reason)
-	}
-}
-
-func (p *codexReplayParser) handleMappedEvent(ev codex.MappedEvent, ts time.Time) {
-	switch ev.Kind {
-	case codex.MappedEventTextDelta:
-		p.appendTextDelta(ts, ev.ThreadID, ev.ItemID, ev.Delta)
-		p.appendThreadText(ev.ThreadID, ev.Delta)
-
-	case codex.MappedEventReasoningDelta:
-		p.threadReasoning[ev.ThreadID] = true
-		p.appendOrAddThinking(ts, ev.Delta)
-
-	case codex.MappedEventCommandStart:
-		input := map[string]interface{}{}
-		if ev.Command != "" {
-			input["command"] = ev.Command
-		}
-		if ev.CWD != "" {
-			input["cwd"] = ev.CWD
-		}
-		p.lines = append(p.lines, session.OutputLine{
-			Timestamp: ts,
-			Type:      session.OutputTypeToolStart,
-			Content:   "Bash: " + ev.Command,
-			ToolName:  "Bash",
-			ToolID:    ev.CallID,
-			ToolInput: input,
-			ToolState: session.ToolStateRunning,
-			StartTime: ts,
-		})
-		p.toolLineIndex[ev.CallID] = len(p.lines) - 1
-		p.clearPendingApproval(ev.ThreadID, ev.CallID)
-
-	case codex.MappedEventCommandEnd:
-		p.updateToolCompletion(ev, ts)
-
-	case codex.MappedEventTokenUsage:
-		p.threadTokenUsage[ev.ThreadID] = codex.TokenUsage{
-			InputTokens:           ev.Usage.InputTokens,
-			CachedInputTokens:     ev.Usage.CachedInputTokens,
-			OutputTokens:          ev.Usage.OutputTokens,
-			ReasoningOutputTokens: ev.Usage.ReasoningOutputTokens,
-			TotalTokens:           ev.Usage.TotalTokens,
-		}
-		p.threadUsageCumulative[ev.ThreadID] = ev.UsageIsCumulative
-
-	case codex.MappedEventTurnCompleted:
-		p.turnCount++
-		p.turnCompletions++
-		p.clearThreadApprovals(ev.ThreadID)
-		usage := p.threadTokenUsage[ev.ThreadID]
-		// On older Codex protocol versions that emit only TotalTokenUsage
-		// (cumulative across the thread), recover a per-turn delta by
-		// subtracting the prior turn's cumulative baseline. Falls back
-		// to the cumulative value itself for the very first turn.
-		// Each per-field subtraction is clamped at zero — if cumulative
-		// totals ever decrease (mid-log session reset, replay of
-		// concatenated logs) negative deltas would surface as confusing
-		// near-MAX_INT values once cast or as nonsensical "negative
-		// tokens" lines; clamping prefers a transient zero-delta.
-		if p.threadUsageCumulative[ev.ThreadID] {
-			baseline := p.threadCumulativeBaseline[ev.ThreadID]
-			delta := codex.TokenUsage{
-				InputTokens:           clampSubInt64(usage.InputTokens, baseline.InputTokens),
-				CachedInputTokens:     clampSubInt64(usage.CachedInputTokens, baseline.CachedInputTokens),
-				OutputTokens:          clampSubInt64(usage.OutputTokens, baseline.OutputTokens),
-				ReasoningOutputTokens: clampSubInt64(usage.ReasoningOutputTokens, baseline.ReasoningOutputTokens),
-				TotalTokens:           clampSubInt64(usage.TotalTokens, baseline.TotalTokens),
-			}
-			p.threadCumulativeBaseline[ev.ThreadID] = usage
-			usage = delta
-		}
-		lineType := session.OutputTypeTurnEnd
-		content := "Turn complete"
-		if !ev.Success || ev.Error != nil {
-			lineType = session.OutputTypeError
-			content = "turn failed"
-			if ev.Error != nil {
-				content = strings.TrimSpace(ev.Error.Error())
-			}
-			if content == "" {
-				content = "turn failed"
-			}
-			p.threadFailures[ev.ThreadID] = struct{}{}
-		} else {
-			delete(p.threadFailures, ev.ThreadID)
-		}
-		p.lines = append(p.lines, session.OutputLine{
-			Timestamp:  ts,
-			Type:       lineType,
-			Content:    content,
-			TurnNumber: p.turnCount,
-			DurationMs: ev.DurationMs,
-			CostUSD:    0,
-		})
-		if usage.InputTokens > 0 || usage.OutputTokens > 0 {
-			p.lines = append(p.lines, session.OutputLine{
-				Timestamp: ts,
-				Type:      session.OutputTypeStatus,
-				Content:   tokenSummaryContent(usage),
-			})
-		}
-		p.threadText[ev.ThreadID] = &strings.Builder{}
-		p.threadReasoning[ev.ThreadID] = false
-		p.threadActiveItem[ev.ThreadID] = ""
-
-	case codex.MappedEventError:
-		content := "provider error"
-		if ev.Error != nil {
-			content = strings.TrimSpace(ev.Error.Error())
-		}
-		if content == "" {
-			content = "provider error"
-		}
-		p.lines = append(p.lines, session.OutputLine{
-			Timestamp: ts,
-			Type:      session.OutputTypeError,
-			Content:   content,
-		})
-		p.hadProviderErrors = true
-	}
-}
-
-func (p *codexReplayParser) deriveStatus() session.SessionStatus {
-	if p.hadProviderErrors || len(p.threadFailures) > 0 {
+// outputLineFromCodex converts a codex replay line. Line types and tool
+// states share their string values with bramble's.
+func outputLineFromCodex(l *codex.ReplayLine) session.OutputLine {
+	return session.OutputLine{
+		Timestamp:  l.Timestamp,
+		StartTime:  l.StartTime,
+		Type:       session.OutputLineType(l.Type),
+		Content:    l.Content,
+		ToolName:   l.ToolName,
+		ToolID:     l.ToolID,
+		ToolInput:  l.ToolInput,
+		ToolResult: l.ToolResult,
+		ToolState:  session.ToolState(l.ToolState),
+		TurnNumber: l.TurnNumber,
+		DurationMs: l.DurationMs,
+		IsError:    l.IsError,
+	}
+}
+
+func sessionStatusFromCodex(s codex.ReplayStatus) session.SessionStatus {
+	switch s {
+	case codex.ReplayStatusFailed:
 		return session.StatusFailed
-	}
-	for _, byKey := range p.pendingApprovals {
-		if len(byKey) > 0 {
-			return session.StatusIdle
-		}
-	}
-	if p.turnStarts > p.turnCompletions {
+	case codex.ReplayStatusAwaitingApproval:
+		return session.StatusIdle
+	case codex.ReplayStatusRunning:
 		return session.StatusRunning
+	default:
+		return session.StatusCompleted
 	}
-	return session.StatusCompleted
-}
-
-func (p *codexReplayParser) recordApprovalRequest(ts time.Time, threadID, callID, command, reason string) {
-	key := approvalKey(callID, command, reason)
-	if key == "" {
-		return
-	}
-	threadID = normalizeThreadKey(threadID)
-	if _, ok := p.emittedApprovals[threadID]; !ok {
-		p.emittedApprovals[threadID] = make(map[string]struct{})
-	}
-	if _, ok := p.pendingApprovals[threadID]; !ok {
-		p.pendingApprovals[threadID] = make(map[string]struct{})
-	}
-	if _, seen := p.emittedApprovals[threadID][key]; seen {
-		return
-	}
-	p.emittedApprovals[threadID][key] = struct{}{}
-	p.pendingApprovals[threadID][key] = struct{}{}
-
-	p.lines = append(p.lines, session.OutputLine{
-		Timestamp: ts,
-		Type:      session.OutputTypeStatus,
-		Content:   "Approval required before command execution",
-	})
-
-	details := strings.TrimSpace(command)
-	if details == "" {
-		details = "(command unavailable)"
-	}
-	reason = strings.TrimSpace(reason)
-	if reason != "" {
-		details += "\n\nReason: " + reason
-	}
-	p.lines = append(p.lines, session.OutputLine{
-		Timestamp: ts,
-		Type:      session.OutputTypeText,
-		Content:   details,
-	})
-}
-
-func (p *codexReplayParser) clearPendingApproval(threadID, callID string) {
-	key := approvalKey(callID, "", "")
-	if key == "" {
-		return
-	}
-	threadID = normalizeThreadKey(threadID)
-	if threadPending, ok := p.pendingApprovals[threadID]; ok {
-		delete(threadPending, key)
-		if len(threadPending) == 0 {
-			delete(p.pendingApprovals, threadID)
-		}
-	}
-}
-
-func (p *codexReplayParser) clearThreadApprovals(threadID string) {
-	threadID = normalizeThreadKey(threadID)
-	delete(p.pendingApprovals, threadID)
-	delete(p.emittedApprovals, threadID)
-}
-
-func (p *codexReplayParser) appendTextDelta(ts time.Time, threadID, itemID, delta string) {
-	if delta == "" {
-		return
-	}
-	if threadID == "" || itemID == "" {
-		p.appendOrAddText(ts, delta)
-		return
-	}
-
-	if p.threadActiveItem[threadID] != itemID {
-		p.lines = append(p.lines, session.OutputLine{
-			Timestamp: ts,
-			Type:      session.OutputTypeText,
-			Content:   delta,
-		})
-		idx := len(p.lines) - 1
-		p.threadActiveItem[threadID] = itemID
-		p.itemTextLine[itemID] = idx
-		return
-	}
-
-	if idx, ok := p.itemTextLine[itemID]; ok && idx >= 0 && idx < len(p.lines) {
-		p.lines[idx].Content = session.AppendStreamingDelta(p.lines[idx].Content, delta)
-		return
-	}
-
-	p.appendOrAddText(ts, delta)
-}
-
-func (p *codexReplayParser) setFinalItemText(ts time.Time, threadID, itemID, text string) {
-	if idx, ok := p.itemTextLine[itemID]; ok && idx >= 0 && idx < len(p.lines) {
-		p.lines[idx].Content = text
-	} else {
-		p.lines = append(p.lines, session.OutputLine{
-			Timestamp: ts,
-			Type:      session.OutputTypeText,
-			Content:   text,
-		})
-		p.itemTextLine[itemID] = len(p.lines) - 1
-	}
-	p.threadActiveItem[threadID] = ""
-}
-
-func (p *codexReplayParser) updateToolCompletion(ev codex.MappedEvent, ts time.Time) {
-	idx, ok := p.toolLineIndex[ev.CallID]
-	if !ok || idx < 0 || idx >= len(p.lines) {
-		return
-	}
-	line := p.lines[idx]
-	if line.ToolInput == nil {
-		line.ToolInput = map[string]interface{}{}
-	}
-	if _, ok := line.ToolInput["command"]; !ok && ev.Command != "" {
-		line.ToolInput["command"] = ev.Command
-	}
-	if _, ok := line.ToolInput["cwd"]; !ok && ev.CWD != "" {
-		line.ToolInput["cwd"] = ev.CWD
-	}
-	line.ToolResult = map[string]interface{}{
-		"stdout":      ev.Stdout,
-		"stderr":      ev.Stderr,
-		"exit_code":   ev.ExitCode,
-		"duration_ms": ev.DurationMs,
-	}
-	line.ToolState = session.ToolStateComplete
-	line.IsError = ev.ExitCode != 0
-	if line.IsError {
-		line.ToolState = session.ToolStateError
-	}
-	line.DurationMs = ev.DurationMs
-	if line.DurationMs == 0 && !line.StartTime.IsZero() {
-		line.DurationMs = ts.Sub(line.StartTime).Milliseconds()
-	}
-	p.lines[idx] = line
-}
-
-func (p *codexReplayParser) appendOrAddText(ts time.Time, text string) {
-	if text == "" {
-		return
-	}
-	if len(p.lines) > 0 && p.lines[len(p.lines)-1].Type == session.OutputTypeText {
-		p.lines[len(p.lines)-1].Content = session.AppendStreamingDelta(p.lines[len(p.lines)-1].Content, text)
-		return
-	}
-	p.lines = append(p.lines, session.OutputLine{
-		Timestamp: ts,
-		Type:      session.OutputTypeText,
-		Content:   text,
-	})
-}
-
-func (p *codexReplayParser) appendOrAddThinking(ts time.Time, text string) {
-	if strings.TrimSpace(text) == "" {
-		return
-	}
-	if len(p.lines) > 0 && p.lines[len(p.lines)-1].Type == session.OutputTypeThinking {
-		p.lines[len(p.lines)-1].Content = session.AppendStreamingDelta(p.lines[len(p.lines)-1].Content, text)
-		return
-	}
-	p.lines = append(p.lines, session.OutputLine{
-		Timestamp: ts,
-		Type:      session.OutputTypeThinking,
-		Content:   text,
-	})
-}
-
-func (p *codexReplayParser) appendThreadText(threadID, delta string) {
-	if threadID == "" || delta == "" {
-		return
-	}
-	b, ok := p.threadText[threadID]
-	if !ok {
-		b = &strings.Builder{}
-		p.threadText[threadID] = b
-	}
-	b.WriteString(delta)
 }
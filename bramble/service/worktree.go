@@ -0,0 +1,198 @@
+// Package service defines the worktree inspection surface bramble exposes to
+// its UIs: listing a worktree's files and reading one of them. The local
+// implementation reads the filesystem directly; the control package serves it
+// over the control protocol, so hub clients get the same answers as local ones.
+//
+// Every path is resolved relative to a worktree root and refused if it escapes
+// that root, either lexically ("../") or through a symlink. Reads and listings
+// are size-capped so a remote peer cannot pull an unbounded payload.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultMaxFileBytes caps a single ReadFile. Larger files are truncated
+	// and reported with FileContent.Truncated set.
+	DefaultMaxFileBytes = 1 << 20
+	// DefaultMaxTreeEntries caps a single ListTree.
+	DefaultMaxTreeEntries = 10000
+)
+
+// ErrPathEscapesWorktree is returned when a path resolves outside its worktree.
+var ErrPathEscapesWorktree = errors.New("path escapes worktree")
+
+// WorktreeService inspects the files of a worktree.
+type WorktreeService interface {
+	// ReadFile returns the content of relPath inside worktreePath.
+	ReadFile(ctx context.Context, worktreePath, relPath string) (*FileContent, error)
+	// ListTree returns the files and directories under worktreePath.
+	ListTree(ctx context.Context, worktreePath string) (*Tree, error)
+}
+
+// FileContent is the result of ReadFile.
+type FileContent struct {
+	Path      string `json:"path"`
+	Content   []byte `json:"content"`
+	Size      int64  `json:"size"` // size on disk, even when Truncated
+	Truncated bool   `json:"truncated"`
+}
+
+// TreeEntry is one file or directory in a worktree, relative to its root and
+// slash-separated.
+type TreeEntry struct {
+	Path  string `json:"path"`
+	Size  int64  `json:"size,omitempty"`
+	IsDir bool   `json:"is_dir,omitempty"`
+}
+
+// Tree is the result of ListTree. Entries are in lexical walk order.
+type Tree struct {
+	Entries   []TreeEntry `json:"entries"`
+	Truncated bool        `json:"truncated"`
+}
+
+// ResolvePath joins relPath onto worktreePath and returns the cleaned absolute
+// path, or ErrPathEscapesWorktree if the result lies outside the worktree.
+// The check is lexical; LocalWorktreeService additionally rejects symlinks
+// that point out of the worktree.
+func ResolvePath(worktreePath, relPath string) (string, error) {
+	if worktreePath == "" {
+		return "", fmt.Errorf("worktree path is empty")
+	}
+	if relPath == "" || filepath.IsAbs(relPath) {
+		return "", fmt.Errorf("%w: %q", ErrPathEscapesWorktree, relPath)
+	}
+	root := filepath.Clean(worktreePath)
+	abs := filepath.Join(root, relPath)
+	if abs != root && !strings.HasPrefix(abs, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q", ErrPathEscapesWorktree, relPath)
+	}
+	return abs, nil
+}
+
+// LocalWorktreeService implements WorktreeService against the local
+// filesystem. The zero value uses the default limits.
+type LocalWorktreeService struct {
+	// MaxFileBytes caps ReadFile (defaults to DefaultMaxFileBytes).
+	MaxFileBytes int64
+	// MaxTreeEntries caps ListTree (defaults to DefaultMaxTreeEntries).
+	MaxTreeEntries int
+}
+
+// ReadFile implements WorktreeService.
+func (s *LocalWorktreeService) ReadFile(ctx context.Context, worktreePath, relPath string) (*FileContent, error) {
+	abs, err := s.resolve(worktreePath, relPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(abs)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", relPath)
+	}
+
+	limit := s.MaxFileBytes
+	if limit <= 0 {
+		limit = DefaultMaxFileBytes
+	}
+	content, err := io.ReadAll(io.LimitReader(f, limit))
+	if err != nil {
+		return nil, err
+	}
+	return &FileContent{
+		Path:      filepath.ToSlash(relPath),
+		Content:   content,
+		Size:      info.Size(),
+		Truncated: info.Size() > int64(len(content)),
+	}, nil
+}
+
+// ListTree implements WorktreeService. The .git entry is skipped; symlinks
+// are listed but not followed.
+func (s *LocalWorktreeService) ListTree(ctx context.Context, worktreePath string) (*Tree, error) {
+	if worktreePath == "" {
+		return nil, fmt.Errorf("worktree path is empty")
+	}
+	root := filepath.Clean(worktreePath)
+	limit := s.MaxTreeEntries
+	if limit <= 0 {
+		limit = DefaultMaxTreeEntries
+	}
+
+	tree := &Tree{}
+	errLimit := errors.New("limit reached")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if path == root {
+			return nil
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if len(tree.Entries) >= limit {
+			tree.Truncated = true
+			return errLimit
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		entry := TreeEntry{Path: filepath.ToSlash(rel), IsDir: d.IsDir()}
+		if !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				entry.Size = info.Size()
+			}
+		}
+		tree.Entries = append(tree.Entries, entry)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimit) {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// resolve applies ResolvePath and then rejects symlinks that leave the
+// worktree, comparing fully evaluated paths.
+func (s *LocalWorktreeService) resolve(worktreePath, relPath string) (string, error) {
+	abs, err := ResolvePath(worktreePath, relPath)
+	if err != nil {
+		return "", err
+	}
+	realRoot, err := filepath.EvalSymlinks(filepath.Clean(worktreePath))
+	if err != nil {
+		return "", err
+	}
+	realPath, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", err
+	}
+	if realPath != realRoot && !strings.HasPrefix(realPath, realRoot+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q", ErrPathEscapesWorktree, relPath)
+	}
+	return abs, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestResolvePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		rel     string
+		want    string
+		wantErr bool
+	}{
+		{name: "file", rel: "pkg/a.go", want: "/wt/pkg/a.go"},
+		{name: "inner dotdot", rel: "pkg/../a.go", want: "/wt/a.go"},
+		{name: "escape", rel: "../etc/passwd", wantErr: true},
+		{name: "sibling prefix", rel: "../wt2/a.go", wantErr: true},
+		{name: "absolute", rel: "/etc/passwd", wantErr: true},
+		{name: "empty", rel: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ResolvePath("/wt", tt.rel)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrPathEscapesWorktree)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLocalReadFile(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "pkg", "a.go"), "package pkg\n")
+	writeFile(t, filepath.Join(root, "big.txt"), strings.Repeat("x", 100))
+
+	svc := &LocalWorktreeService{MaxFileBytes: 10}
+	ctx := context.Background()
+
+	fc, err := svc.ReadFile(ctx, root, "pkg/a.go")
+	require.NoError(t, err)
+	assert.Equal(t, "pkg/a.go", fc.Path)
+	assert.Equal(t, "package pk", string(fc.Content))
+	assert.True(t, fc.Truncated)
+
+	fc, err = svc.ReadFile(ctx, root, "big.txt")
+	require.NoError(t, err)
+	assert.Len(t, fc.Content, 10)
+	assert.Equal(t, int64(100), fc.Size)
+
+	_, err = svc.ReadFile(ctx, root, "pkg")
+	assert.Error(t, err, "directories are not readable")
+
+	_, err = svc.ReadFile(ctx, root, "../outside")
+	assert.True(t, errors.Is(err, ErrPathEscapesWorktree))
+}
+
+func TestLocalReadFileRejectsEscapingSymlink(t *testing.T) {
+	t.Parallel()
+	outside := t.TempDir()
+	writeFile(t, filepath.Join(outside, "secret"), "s3cret")
+	root := t.TempDir()
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret"), filepath.Join(root, "link")))
+	writeFile(t, filepath.Join(root, "real"), "ok")
+	require.NoError(t, os.Symlink("real", filepath.Join(root, "inner")))
+
+	svc := &LocalWorktreeService{}
+	_, err := svc.ReadFile(context.Background(), root, "link")
+	assert.ErrorIs(t, err, ErrPathEscapesWorktree)
+
+	fc, err := svc.ReadFile(context.Background(), root, "inner")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(fc.Content))
+}
+
+func TestLocalListTree(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.go"), "a")
+	writeFile(t, filepath.Join(root, "pkg", "b.go"), "bb")
+	writeFile(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/main")
+
+	tree, err := (&LocalWorktreeService{}).ListTree(context.Background(), root)
+	require.NoError(t, err)
+	assert.False(t, tree.Truncated)
+	assert.Equal(t, []TreeEntry{
+		{Path: "a.go", Size: 1},
+		{Path: "pkg", IsDir: true},
+		{Path: "pkg/b.go", Size: 2},
+	}, tree.Entries)
+
+	tree, err = (&LocalWorktreeService{MaxTreeEntries: 2}).ListTree(context.Background(), root)
+	require.NoError(t, err)
+	assert.True(t, tree.Truncated)
+	assert.Len(t, tree.Entries, 2)
+}
@@ -0,0 +1,117 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
+)
+
+// ApprovalPrompt is a command an agent is waiting for the user to approve.
+type ApprovalPrompt struct {
+	Command string
+	CWD     string
+	Reason  string
+}
+
+// pendingApproval is an ApprovalPrompt together with the channel its answer
+// is delivered on.
+type pendingApproval struct {
+	reply  chan codex.ApprovalDecision
+	prompt ApprovalPrompt
+}
+
+// codexApprovalHandler answers codex exec approval requests for a session.
+// In yolo mode every request is approved; otherwise the session moves to
+// StatusWaitingApproval until the user answers with RespondToApproval.
+func (m *Manager) codexApprovalHandler(id SessionID) codex.ApprovalHandler {
+	return codex.ApprovalHandlerFunc(func(ctx context.Context, req *codex.ApprovalRequest) (*codex.ApprovalResponse, error) {
+		if m.config.YoloMode {
+			return &codex.ApprovalResponse{Approved: true}, nil
+		}
+		prompt := ApprovalPrompt{}
+		prompt.Command, _ = req.Input["command"].(string)
+		prompt.CWD, _ = req.Input["cwd"].(string)
+		prompt.Reason, _ = req.Input["reason"].(string)
+		decision, err := m.awaitApproval(ctx, id, prompt)
+		if err != nil {
+			return nil, err
+		}
+		return &codex.ApprovalResponse{
+			Approved:   decision != codex.ApprovalDeny,
+			ForSession: decision == codex.ApprovalApproveForSession,
+		}, nil
+	})
+}
+
+// awaitApproval parks the session in StatusWaitingApproval until the user
+// answers prompt or ctx ends. Concurrent requests are queued so the user
+// sees one prompt at a time.
+func (m *Manager) awaitApproval(ctx context.Context, id SessionID, prompt ApprovalPrompt) (codex.ApprovalDecision, error) {
+	session, ok := m.GetSession(id)
+	if !ok {
+		return codex.ApprovalDeny, fmt.Errorf("session not found: %s", id)
+	}
+
+	session.approvalMu.Lock()
+	defer session.approvalMu.Unlock()
+
+	p := &pendingApproval{prompt: prompt, reply: make(chan codex.ApprovalDecision, 1)}
+	session.mu.Lock()
+	session.pendingApproval = p
+	session.mu.Unlock()
+	defer func() {
+		session.mu.Lock()
+		if session.pendingApproval == p {
+			session.pendingApproval = nil
+		}
+		session.mu.Unlock()
+		m.tryUpdateSessionStatus(session, StatusWaitingApproval, StatusRunning)
+	}()
+
+	m.addOutput(id, OutputLine{
+		Timestamp: time.Now(),
+		Type:      OutputTypeStatus,
+		Content:   "Waiting for approval: " + prompt.Command,
+	})
+	m.tryUpdateSessionStatus(session, StatusRunning, StatusWaitingApproval)
+
+	select {
+	case decision := <-p.reply:
+		return decision, nil
+	case <-ctx.Done():
+		return codex.ApprovalDeny, ctx.Err()
+	}
+}
+
+// RespondToApproval answers the command session id is waiting on.
+func (m *Manager) RespondToApproval(id SessionID, decision codex.ApprovalDecision) error {
+	session, ok := m.GetSession(id)
+	if !ok {
+		return fmt.Errorf("session not found: %s", id)
+	}
+
+	session.mu.Lock()
+	p := session.pendingApproval
+	session.pendingApproval = nil
+	session.mu.Unlock()
+	if p == nil {
+		return fmt.Errorf("session %s is not waiting for approval", id)
+	}
+	p.reply <- decision
+
+	verb := "Approved"
+	switch decision {
+	case codex.ApprovalApproveForSession:
+		verb = "Approved for session"
+	case codex.ApprovalDeny:
+		verb = "Denied"
+	}
+	m.addOutput(id, OutputLine{
+		Timestamp: time.Now(),
+		Type:      OutputTypeStatus,
+		Content:   verb + ": " + p.prompt.Command,
+	})
+	return nil
+}
@@ -0,0 +1,103 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
+)
+
+func newApprovalTestSession(t *testing.T, m *Manager) *Session {
+	t.Helper()
+	session := &Session{
+		ID:        "approval-session",
+		Type:      SessionTypeBuilder,
+		Status:    StatusRunning,
+		CreatedAt: time.Now(),
+		Progress:  &SessionProgress{},
+	}
+	m.mu.Lock()
+	m.sessions[session.ID] = session
+	m.mu.Unlock()
+	return session
+}
+
+func codexApprovalRequest(command string) *codex.ApprovalRequest {
+	return &codex.ApprovalRequest{
+		ToolName: "Bash",
+		Input:    map[string]interface{}{"command": command, "cwd": "/repo", "reason": "needs network"},
+	}
+}
+
+func TestCodexApprovalHandlerWaitsForUser(t *testing.T) {
+	m := newTestManager(t, "repo")
+	session := newApprovalTestSession(t, m)
+	handler := m.codexApprovalHandler(session.ID)
+
+	type answer struct {
+		resp *codex.ApprovalResponse
+		err  error
+	}
+	done := make(chan answer, 1)
+	go func() {
+		resp, err := handler.HandleApproval(context.Background(), codexApprovalRequest("npm install"))
+		done <- answer{resp, err}
+	}()
+
+	require.Eventually(t, func() bool {
+		info, _ := m.GetSessionInfo(session.ID)
+		return info.Status == StatusWaitingApproval && info.PendingApproval != nil
+	}, time.Second, 5*time.Millisecond)
+	info, _ := m.GetSessionInfo(session.ID)
+	assert.Equal(t, ApprovalPrompt{Command: "npm install", CWD: "/repo", Reason: "needs network"}, *info.PendingApproval)
+
+	require.NoError(t, m.RespondToApproval(session.ID, codex.ApprovalApproveForSession))
+	got := <-done
+	require.NoError(t, got.err)
+	assert.True(t, got.resp.Approved)
+	assert.True(t, got.resp.ForSession)
+
+	info, _ = m.GetSessionInfo(session.ID)
+	assert.Equal(t, StatusRunning, info.Status)
+	assert.Nil(t, info.PendingApproval)
+	assert.Error(t, m.RespondToApproval(session.ID, codex.ApprovalDeny), "nothing left to answer")
+}
+
+func TestCodexApprovalHandlerDenyAndCancel(t *testing.T) {
+	m := newTestManager(t, "repo")
+	session := newApprovalTestSession(t, m)
+	handler := m.codexApprovalHandler(session.ID)
+
+	go func() {
+		assert.Eventually(t, func() bool {
+			return m.RespondToApproval(session.ID, codex.ApprovalDeny) == nil
+		}, time.Second, 5*time.Millisecond)
+	}()
+	resp, err := handler.HandleApproval(context.Background(), codexApprovalRequest("rm -rf /"))
+	require.NoError(t, err)
+	assert.False(t, resp.Approved)
+
+	// A stopped session cancels the request instead of leaving it pending.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = handler.HandleApproval(ctx, codexApprovalRequest("make"))
+	assert.ErrorIs(t, err, context.Canceled)
+	info, _ := m.GetSessionInfo(session.ID)
+	assert.Equal(t, StatusRunning, info.Status)
+}
+
+func TestCodexApprovalHandlerYoloApproves(t *testing.T) {
+	m := newTestManager(t, "repo")
+	m.config.YoloMode = true
+	session := newApprovalTestSession(t, m)
+
+	resp, err := m.codexApprovalHandler(session.ID).HandleApproval(context.Background(), codexApprovalRequest("make"))
+	require.NoError(t, err)
+	assert.True(t, resp.Approved)
+	info, _ := m.GetSessionInfo(session.ID)
+	assert.Equal(t, StatusRunning, info.Status)
+}
@@ -0,0 +1,109 @@
+package session
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// costCSVHeader is the column order written by ExportCostCSV.
+var costCSVHeader = []string{
+	"session_id", "worktree", "type", "model", "turns",
+	"input_tokens", "output_tokens", "cost_usd", "status",
+}
+
+// ExportCostCSV writes a cost report with one row per session this manager
+// knows about: live sessions first (oldest first), then sessions stored in
+// history for the manager's repo that are no longer live. Columns are
+// session_id,worktree,type,model,turns,input_tokens,output_tokens,cost_usd,status.
+func (m *Manager) ExportCostCSV(w io.Writer) error {
+	rows, err := m.costRows()
+	if err != nil {
+		return err
+	}
+	return writeCostCSV(w, rows)
+}
+
+// ExportCostCSV writes one cost report covering every registered manager.
+func (r *SessionRegistry) ExportCostCSV(w io.Writer) error {
+	r.mu.RLock()
+	managers := append([]*Manager(nil), r.managers...)
+	r.mu.RUnlock()
+
+	var rows [][]string
+	for _, mgr := range managers {
+		mgrRows, err := mgr.costRows()
+		if err != nil {
+			return fmt.Errorf("%s: %w", mgr.RepoName(), err)
+		}
+		rows = append(rows, mgrRows...)
+	}
+	return writeCostCSV(w, rows)
+}
+
+// costRows builds the cost report rows for ExportCostCSV.
+func (m *Manager) costRows() ([][]string, error) {
+	live := m.GetAllSessions()
+	sort.SliceStable(live, func(i, j int) bool {
+		return live[i].CreatedAt.Before(live[j].CreatedAt)
+	})
+
+	seen := make(map[SessionID]bool, len(live))
+	rows := make([][]string, 0, len(live))
+	for i := range live {
+		info := &live[i]
+		seen[info.ID] = true
+		p := &info.Progress
+		rows = append(rows, costRecord(info.ID, info.WorktreeName, info.Type, info.Model,
+			p.TurnCount, p.InputTokens, p.OutputTokens, p.TotalCostUSD, info.Status))
+	}
+
+	store, repoName := m.config.Store, m.config.RepoName
+	if store == nil || repoName == "" {
+		return rows, nil
+	}
+	worktrees, err := store.ListWorktrees(repoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stored worktrees: %w", err)
+	}
+	for _, worktree := range worktrees {
+		metas, err := store.ListSessions(repoName, worktree)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stored sessions for %s: %w", worktree, err)
+		}
+		for _, meta := range metas {
+			if seen[meta.ID] {
+				continue
+			}
+			seen[meta.ID] = true
+			var p StoredProgress
+			if meta.Progress != nil {
+				p = *meta.Progress
+			}
+			rows = append(rows, costRecord(meta.ID, meta.WorktreeName, meta.Type, meta.Model,
+				p.TurnCount, p.InputTokens, p.OutputTokens, p.TotalCostUSD, meta.Status))
+		}
+	}
+	return rows, nil
+}
+
+func costRecord(id SessionID, worktree string, typ SessionType, model string, turns, inputTokens, outputTokens int, costUSD float64, status SessionStatus) []string {
+	return []string{
+		string(id), worktree, string(typ), model,
+		strconv.Itoa(turns), strconv.Itoa(inputTokens), strconv.Itoa(outputTokens),
+		strconv.FormatFloat(costUSD, 'f', 6, 64), string(status),
+	}
+}
+
+func writeCostCSV(w io.Writer, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(costCSVHeader); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write cost report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package session
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerExportCostCSV(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Now()
+	// A stored session no longer live, and a stored copy of the live one
+	// that must not be reported twice.
+	require.NoError(t, store.SaveSession(&StoredSession{
+		ID:           "old",
+		Type:         SessionTypeBuilder,
+		Status:       StatusCompleted,
+		RepoName:     "test-repo",
+		WorktreeName: "feature",
+		Model:        "sonnet",
+		CreatedAt:    now.Add(-time.Hour),
+		Progress:     &StoredProgress{TurnCount: 2, TotalCostUSD: 0.5, InputTokens: 100, OutputTokens: 20},
+	}))
+	require.NoError(t, store.SaveSession(&StoredSession{
+		ID:           "live",
+		Type:         SessionTypePlanner,
+		Status:       StatusRunning,
+		RepoName:     "test-repo",
+		WorktreeName: "main",
+		CreatedAt:    now,
+	}))
+
+	m := NewManagerWithConfig(ManagerConfig{RepoName: "test-repo", Store: store})
+	defer m.Close()
+	m.AddSession(&Session{
+		ID:           "live",
+		Type:         SessionTypePlanner,
+		Status:       StatusIdle,
+		WorktreeName: "main",
+		Model:        "opus, 1m",
+		CreatedAt:    now,
+		Progress:     &SessionProgress{TurnCount: 3, TotalCostUSD: 1.25, InputTokens: 4000, OutputTokens: 300},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, m.ExportCostCSV(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"session_id,worktree,type,model,turns,input_tokens,output_tokens,cost_usd,status",
+		`live,main,planner,"opus, 1m",3,4000,300,1.250000,idle`,
+		"old,feature,builder,sonnet,2,100,20,0.500000,completed",
+	}, lines)
+}
+
+func TestSessionRegistryExportCostCSV(t *testing.T) {
+	reg := NewSessionRegistry()
+	for _, repo := range []string{"a", "b"} {
+		m := NewManagerWithConfig(ManagerConfig{RepoName: repo})
+		defer m.Close()
+		m.AddSession(&Session{ID: SessionID(repo + "-1"), Type: SessionTypeBuilder, Status: StatusRunning, WorktreeName: "main"})
+		reg.Register(m)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, reg.ExportCostCSV(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3, "one header and a row per manager")
+	assert.True(t, strings.HasPrefix(lines[1], "a-1,"))
+	assert.True(t, strings.HasPrefix(lines[2], "b-1,"))
+}
@@ -0,0 +1,167 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoDiffBase is returned by SessionDiffStat when the worktree HEAD was
+// not recorded at session start (not a git worktree, or a tracked tmux
+// window).
+var ErrNoDiffBase = errors.New("session has no recorded start commit")
+
+// diffStatTimeout bounds the git commands behind SessionDiffStat.
+const diffStatTimeout = 10 * time.Second
+
+// maxUntrackedDiffBytes caps how much of an untracked file is read to count
+// its lines; larger files count as changed with no line counts.
+const maxUntrackedDiffBytes = 1 << 20
+
+// DiffStat summarizes how much a session changed its worktree.
+type DiffStat struct {
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
+// IsZero reports whether the session changed nothing.
+func (d DiffStat) IsZero() bool {
+	return d == DiffStat{}
+}
+
+// String formats the stat as "+120 −30 across 4 files".
+func (d DiffStat) String() string {
+	files := "files"
+	if d.FilesChanged == 1 {
+		files = "file"
+	}
+	return fmt.Sprintf("+%d −%d across %d %s", d.Insertions, d.Deletions, d.FilesChanged, files)
+}
+
+// SessionDiffStat returns what changed in a session's worktree since the
+// session started: every tracked change against the HEAD recorded at start,
+// committed or not, plus untracked files counted as all-added. Untracked
+// files that predate the session are counted too. Returns ErrNoDiffBase if
+// no start commit was recorded.
+func (m *Manager) SessionDiffStat(id SessionID) (DiffStat, error) {
+	sess, ok := m.GetSession(id)
+	if !ok {
+		return DiffStat{}, fmt.Errorf("session not found: %s", id)
+	}
+	sess.mu.RLock()
+	dir, base := sess.WorktreePath, sess.StartSHA
+	sess.mu.RUnlock()
+	if base == "" {
+		return DiffStat{}, ErrNoDiffBase
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), diffStatTimeout)
+	defer cancel()
+	return diffStatSince(ctx, dir, base)
+}
+
+// worktreeHead returns the commit checked out in dir, or "" when dir is not
+// a git worktree.
+func worktreeHead(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), diffStatTimeout)
+	defer cancel()
+	out, err := gitOutput(ctx, dir, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// diffStatSince totals `git diff --numstat base` and the untracked files in
+// dir.
+func diffStatSince(ctx context.Context, dir, base string) (DiffStat, error) {
+	out, err := gitOutput(ctx, dir, "diff", "--numstat", "-z", "-M", base)
+	if err != nil {
+		return DiffStat{}, err
+	}
+	stat := parseNumstat(out)
+
+	untracked, err := gitOutput(ctx, dir, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return DiffStat{}, err
+	}
+	for _, name := range strings.Split(untracked, "\x00") {
+		if name == "" {
+			continue
+		}
+		stat.FilesChanged++
+		stat.Insertions += countUntrackedLines(filepath.Join(dir, name))
+	}
+	return stat, nil
+}
+
+// parseNumstat totals `git diff --numstat -z` output. Binary files ("-"
+// counts) count as changed with no lines. Renames carry two extra
+// NUL-terminated paths after the counts.
+func parseNumstat(out string) DiffStat {
+	var stat DiffStat
+	fields := strings.Split(out, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		stat.FilesChanged++
+		if n, err := strconv.Atoi(parts[0]); err == nil {
+			stat.Insertions += n
+		}
+		if n, err := strconv.Atoi(parts[1]); err == nil {
+			stat.Deletions += n
+		}
+		if parts[2] == "" {
+			// Rename: the old and new paths follow as separate fields.
+			i += 2
+		}
+	}
+	return stat
+}
+
+// countUntrackedLines counts the lines in a new file the way git would show
+// them added, or 0 for binary and oversized files.
+func countUntrackedLines(path string) int {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxUntrackedDiffBytes {
+		return 0
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 || bytes.IndexByte(data, 0) >= 0 {
+		return 0
+	}
+	lines := bytes.Count(data, []byte{'\n'})
+	if data[len(data)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// gitOutput runs git in dir and returns its stdout.
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runGitForTest(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+}
+
+func TestSessionDiffStat(t *testing.T) {
+	dir := t.TempDir()
+	runGitForTest(t, dir, "init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\ntwo\nthree\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old.txt"), []byte("moved\n"), 0o644))
+	runGitForTest(t, dir, "add", ".")
+	runGitForTest(t, dir, "commit", "-qm", "initial")
+
+	m := NewManager()
+	defer m.Close()
+	sess := &Session{ID: "diff-1", WorktreePath: dir, StartSHA: worktreeHead(dir)}
+	require.NotEmpty(t, sess.StartSHA)
+	m.AddSession(sess)
+
+	stat, err := m.SessionDiffStat("diff-1")
+	require.NoError(t, err)
+	assert.True(t, stat.IsZero())
+
+	// A committed edit, a staged rename, and an untracked file all count.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\n2\nthree\nfour\n"), 0o644))
+	runGitForTest(t, dir, "commit", "-qam", "edit")
+	runGitForTest(t, dir, "mv", "old.txt", "new.txt")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "added.go"), []byte("package x\n\nfunc f() {}"), 0o644))
+
+	stat, err = m.SessionDiffStat("diff-1")
+	require.NoError(t, err)
+	assert.Equal(t, DiffStat{FilesChanged: 3, Insertions: 5, Deletions: 1}, stat)
+	assert.Equal(t, "+5 −1 across 3 files", stat.String())
+}
+
+func TestSessionDiffStatWithoutBase(t *testing.T) {
+	m := NewManager()
+	defer m.Close()
+	m.AddSession(&Session{ID: "no-base", WorktreePath: t.TempDir()})
+
+	_, err := m.SessionDiffStat("no-base")
+	assert.ErrorIs(t, err, ErrNoDiffBase)
+
+	_, err = m.SessionDiffStat("missing")
+	assert.Error(t, err)
+}
+
+func TestParseNumstat(t *testing.T) {
+	out := "3\t1\ta.go\x00-\t-\timage.png\x000\t0\t\x00old.go\x00new.go\x00"
+	assert.Equal(t, DiffStat{FilesChanged: 3, Insertions: 3, Deletions: 1}, parseNumstat(out))
+	assert.Equal(t, "+0 −0 across 1 file", DiffStat{FilesChanged: 1}.String())
+}
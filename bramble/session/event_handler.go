@@ -2,23 +2,32 @@ package session
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude/render"
 	"github.com/bazelment/yoloswe/bramble/sessionmodel"
+	"github.com/bazelment/yoloswe/multiagent/agent"
 )
 
 // sessionEventHandler implements render.EventHandler for TUI capture.
 // It converts semantic events from the renderer into structured OutputLine
 // entries that the TUI can display.
 type sessionEventHandler struct {
-	manager         *Manager
+	manager *Manager
+	// inputProgress holds the last progress label shown per streaming tool
+	// call so repeated deltas only emit an update when the label changes.
+	inputProgress   map[string]string
 	sessionID       SessionID
+	progressMu      sync.Mutex
 	suppressTurnEnd bool // true when manager emits TurnEnd synchronously after RunTurn
 }
 
 // Ensure interface compliance at compile time
-var _ render.EventHandler = (*sessionEventHandler)(nil)
+var (
+	_ render.EventHandler         = (*sessionEventHandler)(nil)
+	_ agent.ToolInputDeltaHandler = (*sessionEventHandler)(nil)
+)
 
 // newSessionEventHandler creates a new event handler for a session.
 func newSessionEventHandler(manager *Manager, sessionID SessionID) *sessionEventHandler {
@@ -72,8 +81,37 @@ func (h *sessionEventHandler) OnToolStart(name, id string, input map[string]inte
 	})
 }
 
+// OnToolInputDelta shows how much of a still-streaming tool input has
+// arrived (e.g. "Write: writing 2.1KB to main.go…") on the running tool
+// line. The parsed input replaces it once the tool completes.
+func (h *sessionEventHandler) OnToolInputDelta(name, id, partialJSON string) {
+	if id == "" {
+		return
+	}
+	label := sessionmodel.FormatToolInputProgress(name, partialJSON)
+	h.progressMu.Lock()
+	if h.inputProgress[id] == label {
+		h.progressMu.Unlock()
+		return
+	}
+	if h.inputProgress == nil {
+		h.inputProgress = make(map[string]string)
+	}
+	h.inputProgress[id] = label
+	h.progressMu.Unlock()
+
+	h.manager.updateToolOutput(h.sessionID, id, func(line *OutputLine) {
+		if line.ToolInput == nil && line.ToolState == ToolStateRunning {
+			line.Content = label
+		}
+	})
+}
+
 func (h *sessionEventHandler) OnToolComplete(name, id string, input map[string]interface{}, result interface{}, isError bool) {
 	now := time.Now()
+	h.progressMu.Lock()
+	delete(h.inputProgress, id)
+	h.progressMu.Unlock()
 
 	h.updateToolLine(name, id, input, result, isError, now, false)
 	if isMutatingTool(name) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -34,6 +35,13 @@ type sessionRunner interface {
 	CLISessionID() string
 }
 
+// servedModelReporter is implemented by runners whose provider reports the
+// model and reasoning effort that actually served the last turn, which can
+// differ from the requested ones when a provider downgrades or clamps.
+type servedModelReporter interface {
+	servedModel() (model, effort string)
+}
+
 // plannerRunner adapts PlannerWrapper to the sessionRunner interface.
 // The first turn uses Run() to handle planning until ExitPlanMode.
 // Subsequent turns use RunTurn() for plan iteration.
@@ -77,13 +85,30 @@ type providerRunner struct { //nolint:govet // fieldalignment: keep related life
 	model           string // model ID for provider (e.g. "gpt-5.5")
 	permissionMode  string // execution permissions (e.g. "bypass", "plan")
 	workDir         string // working directory for provider
-	eventBridgeWg   sync.WaitGroup
-	turnObsMu       sync.Mutex
-	turnObsSeq      uint64
-	sawText         bool
-	sawThinking     bool
-	turnDone        bool
-	turnDoneCh      chan struct{}
+	// effort is the reasoning effort for each turn; it is dropped for
+	// providers without an effort knob (see agent.ProviderSupportsEffort).
+	effort agent.EffortLevel
+	// sandbox is the sandbox level passed to providers that sandbox the
+	// agent's commands (codex); empty leaves the provider default.
+	sandbox string
+	// sessionID is the provider's conversation ID (the codex thread ID) from
+	// the last turn. When resumable is set, each turn resumes it so follow-ups
+	// keep context, and it is persisted as the session's CLISessionID so a
+	// relaunch can reattach.
+	sessionID string
+	// servedModelID and servedEffort are what the provider reported serving
+	// the last turn with (see agent.AgentResult.Model); empty when it does
+	// not report them. Guarded by turnObsMu.
+	servedModelID string
+	servedEffort  string
+	eventBridgeWg sync.WaitGroup
+	turnObsMu     sync.Mutex
+	turnObsSeq    uint64
+	sawText       bool
+	sawThinking   bool
+	turnDone      bool
+	resumable     bool
+	turnDoneCh    chan struct{}
 }
 
 // trackingEventHandler wraps provider callbacks to record observed event types
@@ -115,6 +140,15 @@ func (h *trackingEventHandler) OnToolStart(name, id string, input map[string]int
 	h.next.OnToolStart(name, id, input)
 }
 
+func (h *trackingEventHandler) OnToolInputDelta(name, id, partialJSON string) {
+	if !h.runner.acceptTurnEvent(h.turnObsSeq) {
+		return
+	}
+	if dh, ok := h.next.(agent.ToolInputDeltaHandler); ok {
+		dh.OnToolInputDelta(name, id, partialJSON)
+	}
+}
+
 func (h *trackingEventHandler) OnToolComplete(name, id string, input map[string]interface{}, result interface{}, isError bool) {
 	if !h.runner.acceptTurnEvent(h.turnObsSeq) {
 		return
@@ -187,10 +221,15 @@ func (r *providerRunner) bridgeProviderEvents() {
 				}
 			case agent.ToolStartAgentEvent:
 				r.eventHandler.OnToolStart(e.Name, e.ID, e.Input)
+			case agent.ToolInputDeltaAgentEvent:
+				r.eventHandler.OnToolInputDelta(e.Name, e.ToolID, e.PartialJSON)
 			case agent.ToolCompleteAgentEvent:
 				r.eventHandler.OnToolComplete(e.Name, e.ID, e.Input, e.Result, e.IsError)
 			case agent.TurnCompleteAgentEvent:
 				r.markTurnDone(turnObsSeq)
+				if e.StopReason != "" && e.StopReason != acp.StopReasonEndTurn {
+					log.Printf("%s turn %d ended with stop reason %q", r.provider.Name(), e.TurnNumber, e.StopReason)
+				}
 				// TurnEnd is emitted synchronously by the manager after
 				// RunTurn returns, so we skip OnTurnComplete here to
 				// avoid duplicates. See the addOutput call after RunTurn.
@@ -221,6 +260,12 @@ func (r *providerRunner) RunTurn(ctx context.Context, message string) (*claude.T
 	if r.workDir != "" {
 		opts = append(opts, agent.WithProviderWorkDir(r.workDir))
 	}
+	if r.effort != "" && agent.ProviderSupportsEffort(r.provider.Name()) {
+		opts = append(opts, agent.WithProviderEffort(r.effort))
+	}
+	if r.sandbox != "" {
+		opts = append(opts, agent.WithProviderSandbox(r.sandbox))
+	}
 
 	var result *agent.AgentResult
 
@@ -234,18 +279,45 @@ func (r *providerRunner) RunTurn(ctx context.Context, message string) (*claude.T
 		// Give bridged events a brief window to flush before fallback synthesis.
 		r.waitForTurnDone(turnObsSeq, 150*time.Millisecond)
 	} else {
-		// Ephemeral providers create a fresh session each turn
+		// Ephemeral providers create a fresh session each turn unless they
+		// can resume the previous one.
+		resumeID := r.CLISessionID()
+		turnOpts := opts
+		if resumeID != "" {
+			turnOpts = append(append([]agent.ExecuteOption{}, opts...), agent.WithProviderResumeSessionID(resumeID))
+		}
 		var err error
-		result, err = r.provider.Execute(ctx, message, nil, opts...)
+		result, err = r.provider.Execute(ctx, message, nil, turnOpts...)
+		if resumeID != "" && errors.Is(err, codex.ErrThreadNotFound) {
+			// The saved thread is gone (e.g. codex state was wiped); start
+			// over rather than failing the session.
+			log.Printf("Warning: provider session %s not found, starting a fresh one: %v", resumeID, err)
+			result, err = r.provider.Execute(ctx, message, nil, opts...)
+		}
 		if err != nil {
 			return nil, err
 		}
+		if r.resumable && result.SessionID != "" {
+			r.turnObsMu.Lock()
+			r.sessionID = result.SessionID
+			r.turnObsMu.Unlock()
+		}
 	}
 
+	r.turnObsMu.Lock()
+	r.servedModelID, r.servedEffort = result.Model, result.Effort
+	r.turnObsMu.Unlock()
+
 	r.emitFallbackFromResult(turnObsSeq, result)
 	return agentUsageToTurnUsage(result.Usage), nil
 }
 
+func (r *providerRunner) servedModel() (model, effort string) {
+	r.turnObsMu.Lock()
+	defer r.turnObsMu.Unlock()
+	return r.servedModelID, r.servedEffort
+}
+
 func (r *providerRunner) beginTurnObservation() uint64 {
 	r.turnObsMu.Lock()
 	defer r.turnObsMu.Unlock()
@@ -351,9 +423,37 @@ func (r *providerRunner) emitFallbackFromResult(turnObsSeq uint64, result *agent
 	}
 }
 
-func (r *providerRunner) CLISessionID() string { return "" }
+func (r *providerRunner) CLISessionID() string {
+	if !r.resumable {
+		return ""
+	}
+	r.turnObsMu.Lock()
+	defer r.turnObsMu.Unlock()
+	return r.sessionID
+}
+
+// providerDrainTimeout bounds how long Stop waits for a provider that
+// supports it to flush its final output before the process is killed.
+const providerDrainTimeout = 3 * time.Second
+
+// gracefulStopper is implemented by providers that can let an in-flight turn
+// flush its output before shutting down (e.g. agent.GeminiLongRunningProvider).
+type gracefulStopper interface {
+	StopWithTimeout(ctx context.Context) (drained bool, err error)
+}
 
 func (r *providerRunner) Stop() error {
+	// Drain before closing the event bridge so the agent's last updates still
+	// reach the session output and the persisted transcript.
+	if gs, ok := r.provider.(gracefulStopper); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), providerDrainTimeout)
+		drained, err := gs.StopWithTimeout(ctx)
+		cancel()
+		if err == nil && !drained {
+			log.Printf("Warning: %s session did not drain within %s; output may be truncated", r.provider.Name(), providerDrainTimeout)
+		}
+	}
+
 	// Stop event bridge
 	if r.eventBridgeDone != nil {
 		close(r.eventBridgeDone)
@@ -376,6 +476,7 @@ func agentUsageToTurnUsage(u agent.AgentUsage) *claude.TurnUsage {
 		InputTokens:     u.InputTokens,
 		OutputTokens:    u.OutputTokens,
 		CacheReadTokens: u.CacheReadTokens,
+		ReasoningTokens: u.ReasoningTokens,
 	}
 }
 
@@ -402,9 +503,17 @@ type ManagerConfig struct { //nolint:govet // fieldalignment: readability over p
 	// TmuxExitOnQuit controls whether Bramble should kill tmux windows it started
 	// when a session is stopped (including app quit). Default is false.
 	TmuxExitOnQuit bool
+	// RawProtocolLogs disables secret redaction in Gemini protocol logs, for
+	// debugging exact payloads. By default API keys and tokens are masked.
+	RawProtocolLogs bool
 	// ProtocolLogDir captures provider protocol/session logs for debugging.
 	// If empty, protocol logging is disabled.
 	ProtocolLogDir string
+	// MaxProtocolLogBytes caps each file under ProtocolLogDir. A write that
+	// would push a file past the cap first rotates it to "<name>.1", keeping
+	// protocolLogGenerations old files. Zero uses DefaultMaxProtocolLogBytes;
+	// a negative value disables rotation.
+	MaxProtocolLogBytes int64
 	// RecordingDir enables JSONL session recording for all sessions.
 	// If empty, recording is disabled.
 	RecordingDir string
@@ -443,6 +552,11 @@ type Manager struct { //nolint:govet // fieldalignment: readability over packing
 	stateSubscribersMu sync.Mutex
 	worktreeDirtyMu    sync.RWMutex
 	onWorktreeDirty    func(repoName, worktreePath string)
+	// codexEffort is the reasoning effort for codex sessions, guarded by mu.
+	codexEffort agent.EffortLevel
+	// codexSandbox is the sandbox level for codex builder sessions, guarded
+	// by mu. Empty picks the default (see codexSandboxFor).
+	codexSandbox string
 }
 
 // RepoName returns the repo name this manager is configured for.
@@ -871,6 +985,7 @@ func (m *Manager) startSessionWithID(sessionID SessionID, sessionType SessionTyp
 		Title:        generateTitle(prompt, 20),
 		Model:        model,
 		RepoName:     m.config.RepoName,
+		StartSHA:     worktreeHead(worktreePath),
 		Progress:     &SessionProgress{LastActivity: time.Now()},
 		CreatedAt:    time.Now(),
 		ctx:          ctx,
@@ -887,7 +1002,7 @@ func (m *Manager) startSessionWithID(sessionID SessionID, sessionType SessionTyp
 	m.outputsMu.Unlock()
 
 	m.wg.Add(1)
-	go m.runSession(session, prompt)
+	go m.runSession(session, prompt, false)
 
 	return sessionID, nil
 }
@@ -969,7 +1084,7 @@ func (m *Manager) ResumeSession(id SessionID, prompt string) error {
 	})
 
 	m.wg.Add(1)
-	go m.runSession(session, prompt)
+	go m.runSession(session, prompt, false)
 
 	return nil
 }
@@ -977,12 +1092,32 @@ func (m *Manager) ResumeSession(id SessionID, prompt string) error {
 // rehydrateSession loads a session from the store and adds it back to the
 // in-memory sessions map. Returns the session and true if found.
 func (m *Manager) rehydrateSession(id SessionID) (*Session, bool) {
+	stored, ok := m.findStoredSession(id)
+	if !ok {
+		return nil, false
+	}
+
+	// Re-create the live session from stored data.
+	// Do not allocate a context here — the session is in a terminal state
+	// (completed/failed/stopped) and ResumeSession will set ctx/cancel
+	// before running. Allocating one here would leak it immediately.
+	session := sessionFromStored(stored)
+	session.Status = stored.Status
+
+	m.mu.Lock()
+	m.sessions[id] = session
+	m.mu.Unlock()
+
+	return session, true
+}
+
+// findStoredSession looks up a session in the store. The worktree it was
+// saved under is not known, so every worktree of the repo is searched.
+func (m *Manager) findStoredSession(id SessionID) (*StoredSession, bool) {
 	if m.config.Store == nil || m.config.RepoName == "" {
 		return nil, false
 	}
 
-	// We need the worktree name to load from the store.
-	// Try all worktrees to find the session.
 	worktrees, err := m.config.Store.ListWorktrees(m.config.RepoName)
 	if err != nil {
 		return nil, false
@@ -993,36 +1128,121 @@ func (m *Manager) rehydrateSession(id SessionID) (*Session, bool) {
 		if err != nil {
 			continue
 		}
+		return stored, true
+	}
+
+	return nil, false
+}
 
-		// Re-create the live session from stored data.
-		// Do not allocate a context here — the session is in a terminal state
-		// (completed/failed/stopped) and ResumeSession will set ctx/cancel
-		// before running. Allocating one here would leak it immediately.
-		session := &Session{
-			ID:           stored.ID,
-			Type:         stored.Type,
-			Status:       stored.Status,
-			WorktreePath: stored.WorktreePath,
-			WorktreeName: stored.WorktreeName,
-			Prompt:       stored.Prompt,
-			Title:        stored.Title,
-			Model:        stored.Model,
-			RepoName:     stored.RepoName,
-			CLISessionID: stored.CLISessionID,
-			CreatedAt:    stored.CreatedAt,
-			StartedAt:    stored.StartedAt,
-			CompletedAt:  stored.CompletedAt,
-			Progress:     &SessionProgress{LastActivity: time.Now()},
+// sessionFromStored builds an in-memory Session from stored data. The
+// caller sets Status and, if the session will run, ctx/cancel.
+func sessionFromStored(stored *StoredSession) *Session {
+	progress := &SessionProgress{LastActivity: time.Now()}
+	if stored.Progress != nil {
+		progress.TurnCount = stored.Progress.TurnCount
+		progress.TotalCostUSD = stored.Progress.TotalCostUSD
+		progress.InputTokens = stored.Progress.InputTokens
+		progress.OutputTokens = stored.Progress.OutputTokens
+		progress.ReasoningTokens = stored.Progress.ReasoningTokens
+	}
+	return &Session{
+		ID:           stored.ID,
+		Type:         stored.Type,
+		WorktreePath: stored.WorktreePath,
+		WorktreeName: stored.WorktreeName,
+		Prompt:       stored.Prompt,
+		Title:        stored.Title,
+		Model:        stored.Model,
+		RepoName:     stored.RepoName,
+		CLISessionID: stored.CLISessionID,
+		StartSHA:     stored.StartSHA,
+		CreatedAt:    stored.CreatedAt,
+		StartedAt:    stored.StartedAt,
+		CompletedAt:  stored.CompletedAt,
+		Progress:     progress,
+	}
+}
+
+// ReviveSession turns a stored history session back into a live one. The
+// provider's resume support (claude --resume, codex thread resume) reattaches
+// to the recorded conversation, the output buffer is seeded with the stored
+// lines, and the session is left idle so the next SendFollowUp continues
+// where it left off. The session keeps its ID, which is returned.
+//
+// Reviving fails in tmux mode, when the session is already live, when it
+// has no recorded CLI session ID, or when its model or provider is no longer
+// available.
+func (m *Manager) ReviveSession(storedID SessionID) (SessionID, error) {
+	if m.config.SessionMode == SessionModeTmux {
+		return "", fmt.Errorf("reviving history sessions is only available in TUI mode")
+	}
+
+	m.mu.RLock()
+	live, ok := m.sessions[storedID]
+	m.mu.RUnlock()
+	if ok {
+		live.mu.RLock()
+		status := live.Status
+		live.mu.RUnlock()
+		switch status {
+		case StatusCompleted, StatusFailed, StatusStopped:
+		default:
+			return "", fmt.Errorf("session %s is already live (%s)", storedID, status)
 		}
+	}
 
-		m.mu.Lock()
-		m.sessions[id] = session
-		m.mu.Unlock()
+	stored, ok := m.findStoredSession(storedID)
+	if !ok {
+		return "", fmt.Errorf("session %s not found in history", storedID)
+	}
+	if stored.CLISessionID == "" {
+		return "", fmt.Errorf("session %s has no CLI session ID — cannot resume", storedID)
+	}
+	agentModel, err := resolveAgentModel(stored.Model, m.config.ModelRegistry)
+	if err != nil {
+		return "", fmt.Errorf("cannot revive session %s: %w", storedID, err)
+	}
+	if m.config.ModelRegistry != nil && !m.config.ModelRegistry.HasProvider(agentModel.Provider) {
+		return "", fmt.Errorf("cannot revive session %s: provider %q is not available (not installed or disabled in settings)", storedID, agentModel.Provider)
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	session := sessionFromStored(stored)
+	session.Status = StatusPending
+	session.ctx = ctx
+	session.cancel = cancel
 
-		return session, true
+	output := stored.Output
+	if len(output) > 1000 {
+		output = output[len(output)-1000:]
 	}
+	seeded := make([]OutputLine, len(output), 1000)
+	copy(seeded, output)
 
-	return nil, false
+	m.mu.Lock()
+	m.sessions[storedID] = session
+	m.models[storedID] = sessionmodel.NewSessionModel(1000)
+	m.mu.Unlock()
+
+	m.outputsMu.Lock()
+	m.outputs[storedID] = seeded
+	m.outputsMu.Unlock()
+
+	// Truncate to 12 chars for display only; avoid slicing short IDs.
+	displayID := stored.CLISessionID
+	if len(displayID) > 12 {
+		displayID = displayID[:12]
+	}
+	m.addOutput(storedID, OutputLine{
+		Timestamp: time.Now(),
+		Type:      OutputTypeStatus,
+		Content:   fmt.Sprintf("Revived from history (CLI session: %s) — send a follow-up to continue.", displayID),
+	})
+
+	m.wg.Add(1)
+	go m.runSession(session, stored.Prompt, true)
+
+	return storedID, nil
 }
 
 // StartPlannerSession creates and starts a new planner session.
@@ -1334,7 +1554,9 @@ func resolveAgentModel(modelID string, registry *agent.ModelRegistry) (agent.Age
 
 // runSession runs a session in a goroutine, handling both planner and builder types.
 // Both types follow the same lifecycle: start → run turns → idle → follow-up → ...
-func (m *Manager) runSession(session *Session, prompt string) {
+// When startIdle is set (TUI mode only), the runner is started but no turn
+// runs until the first follow-up arrives.
+func (m *Manager) runSession(session *Session, prompt string, startIdle bool) {
 	defer m.wg.Done()
 	m.updateSessionStatus(session, StatusRunning)
 
@@ -1414,6 +1636,10 @@ func (m *Manager) runSession(session *Session, prompt string) {
 		if brambleBin == "" {
 			brambleBin = "bramble" // fallback to PATH lookup
 		}
+		sandbox := ""
+		if agentModel.Provider == ProviderCodex {
+			sandbox = m.codexSandboxFor(session.Type)
+		}
 		runner = &tmuxRunner{
 			windowName:      tmuxName,
 			workDir:         session.WorktreePath,
@@ -1425,6 +1651,7 @@ func (m *Manager) runSession(session *Session, prompt string) {
 			sessionID:       string(session.ID),
 			brambleBin:      brambleBin,
 			brambleSock:     m.config.IPCSockPath,
+			sandbox:         sandbox,
 			yoloMode:        m.config.YoloMode,
 			killOnStop:      false, // Never kill on Stop(); cleanup happens in Close() if TmuxExitOnQuit is set
 		}
@@ -1446,6 +1673,7 @@ func (m *Manager) runSession(session *Session, prompt string) {
 		} else if agentModel.Provider == ProviderCodex {
 			// Codex provider backend
 			codexOpts, codexLogHint, codexStderrHint := m.codexProviderOptions(session.ID)
+			codexOpts = append(codexOpts, codex.WithApprovalHandler(m.codexApprovalHandler(session.ID)))
 			if codexLogHint != "" {
 				m.addOutput(session.ID, OutputLine{
 					Timestamp: time.Now(),
@@ -1464,11 +1692,20 @@ func (m *Manager) runSession(session *Session, prompt string) {
 				provider:     agent.NewCodexProvider(codexOpts...),
 				eventHandler: eventHandler,
 				model:        session.Model,
+				effort:       m.CodexEffort(),
+				sandbox:      m.codexSandboxFor(session.Type),
+				sessionID:    session.CLISessionID,
+				resumable:    true,
 				permissionMode: func() string {
 					if session.Type == SessionTypePlanner || session.Type == SessionTypeCodeTalk {
 						return "plan"
 					}
-					return "bypass"
+					if m.config.YoloMode {
+						return "bypass"
+					}
+					// Leave codex's configured approval policy in place;
+					// gated commands are approved from the TUI.
+					return ""
 				}(),
 				workDir: session.WorktreePath,
 			}
@@ -1805,82 +2042,14 @@ func (m *Manager) runSession(session *Session, prompt string) {
 
 	currentPrompt := prompt
 	for {
-		turnStart := time.Now()
-		usage, err := runner.RunTurn(session.ctx, currentPrompt)
-		turnDurationMs := time.Since(turnStart).Milliseconds()
-		if err != nil {
-			if session.ctx.Err() != nil {
-				m.updateSessionStatus(session, StatusStopped)
-			} else {
-				m.failSession(session, err)
-				m.addOutput(session.ID, OutputLine{
-					Timestamp: time.Now(),
-					Type:      OutputTypeError,
-					Content:   fmt.Sprintf("Session error: %v", err),
-				})
-			}
+		if startIdle {
+			// A revived session has nothing to run yet; wait for the
+			// first follow-up like any idle session.
+			startIdle = false
+		} else if !m.runSessionTurn(session, runner, currentPrompt) {
 			return
 		}
 
-		if usage != nil {
-			var turnCount int
-			session.Progress.Update(func(p *SessionProgress) {
-				p.TurnCount++
-				turnCount = p.TurnCount
-				p.TotalCostUSD += usage.CostUSD
-				p.InputTokens += usage.InputTokens
-				p.OutputTokens += usage.OutputTokens
-				if usage.ContextWindow > 0 {
-					p.ContextWindow = usage.ContextWindow
-				}
-				// Store last turn's total input for context utilization.
-				// TotalInputTokens() = InputTokens + CacheCreationTokens + CacheReadTokens,
-				// representing the full prompt size sent for this turn.
-				if total := usage.TotalInputTokens(); total > 0 {
-					p.LastTurnInputTotal = total
-				}
-			})
-
-			// Emit TurnEnd synchronously so it's guaranteed to be in the
-			// output buffer before StatusIdle. The async forwardEvents
-			// goroutine may still be processing events from the SDK's
-			// channel when RunTurn returns, causing a race where StatusIdle
-			// arrives at consumers before TurnEnd.
-			m.addOutput(session.ID, OutputLine{
-				Timestamp:  time.Now(),
-				Type:       OutputTypeTurnEnd,
-				Content:    fmt.Sprintf("Turn %d complete", turnCount),
-				TurnNumber: turnCount,
-				CostUSD:    usage.CostUSD,
-				DurationMs: turnDurationMs,
-				IsError:    false,
-			})
-		}
-
-		// After planner's first turn: read plan file and add to output
-		if pr, ok := runner.(*plannerRunner); ok && pr.PlanFilePath != "" {
-			session.mu.Lock()
-			session.PlanFilePath = pr.PlanFilePath
-			session.mu.Unlock()
-
-			if planContent, readErr := os.ReadFile(pr.PlanFilePath); readErr == nil {
-				m.addOutput(session.ID, OutputLine{
-					Timestamp: time.Now(),
-					Type:      OutputTypePlanReady,
-					Content:   string(planContent),
-				})
-			}
-		}
-
-		// After codetalk turn: write research output to a file for delegator consumption.
-		if session.Type == SessionTypeCodeTalk {
-			if researchPath, err := m.writeResearchFile(session); err == nil {
-				session.mu.Lock()
-				session.ResearchFilePath = researchPath
-				session.mu.Unlock()
-			}
-		}
-
 		m.updateSessionStatus(session, StatusIdle)
 
 		// Prioritize child notifications over user follow-ups. When rapid
@@ -1950,6 +2119,111 @@ func (m *Manager) runSession(session *Session, prompt string) {
 	}
 }
 
+// runSessionTurn runs one turn of a TUI-mode session and records its usage
+// and type-specific artifacts (plan file, research file). It returns false
+// when the turn ended the session (error or cancellation).
+func (m *Manager) runSessionTurn(session *Session, runner sessionRunner, prompt string) bool {
+	if u, ok := runner.(workDirUpdater); ok {
+		// Pick up a worktree moved since the last turn (RelocateWorktree).
+		session.mu.RLock()
+		u.setWorkDir(session.WorktreePath)
+		session.mu.RUnlock()
+	}
+	turnStart := time.Now()
+	usage, err := runner.RunTurn(session.ctx, prompt)
+	turnDurationMs := time.Since(turnStart).Milliseconds()
+	if err != nil {
+		if session.ctx.Err() != nil {
+			m.updateSessionStatus(session, StatusStopped)
+		} else {
+			m.failSession(session, err)
+			m.addOutput(session.ID, OutputLine{
+				Timestamp: time.Now(),
+				Type:      OutputTypeError,
+				Content:   fmt.Sprintf("Session error: %v", err),
+			})
+		}
+		return false
+	}
+
+	// Providers such as codex only learn their conversation ID during
+	// the first turn; record it so a relaunch can resume the thread.
+	if cliID := runner.CLISessionID(); cliID != "" {
+		session.mu.Lock()
+		session.CLISessionID = cliID
+		session.mu.Unlock()
+	}
+
+	if usage != nil {
+		var servedModel, servedEffort string
+		if r, ok := runner.(servedModelReporter); ok {
+			servedModel, servedEffort = r.servedModel()
+		}
+		var turnCount int
+		session.Progress.Update(func(p *SessionProgress) {
+			p.TurnCount++
+			turnCount = p.TurnCount
+			if servedModel != "" {
+				p.ServedModel = servedModel
+				p.ServedEffort = servedEffort
+			}
+			p.TotalCostUSD += usage.CostUSD
+			p.InputTokens += usage.InputTokens
+			p.OutputTokens += usage.OutputTokens
+			p.ReasoningTokens += usage.ReasoningTokens
+			if usage.ContextWindow > 0 {
+				p.ContextWindow = usage.ContextWindow
+			}
+			// Store last turn's total input for context utilization.
+			// TotalInputTokens() = InputTokens + CacheCreationTokens + CacheReadTokens,
+			// representing the full prompt size sent for this turn.
+			if total := usage.TotalInputTokens(); total > 0 {
+				p.LastTurnInputTotal = total
+			}
+		})
+
+		// Emit TurnEnd synchronously so it's guaranteed to be in the
+		// output buffer before StatusIdle. The async forwardEvents
+		// goroutine may still be processing events from the SDK's
+		// channel when RunTurn returns, causing a race where StatusIdle
+		// arrives at consumers before TurnEnd.
+		m.addOutput(session.ID, OutputLine{
+			Timestamp:  time.Now(),
+			Type:       OutputTypeTurnEnd,
+			Content:    fmt.Sprintf("Turn %d complete", turnCount),
+			TurnNumber: turnCount,
+			CostUSD:    usage.CostUSD,
+			DurationMs: turnDurationMs,
+			IsError:    false,
+		})
+	}
+
+	// After planner's first turn: read plan file and add to output
+	if pr, ok := runner.(*plannerRunner); ok && pr.PlanFilePath != "" {
+		session.mu.Lock()
+		session.PlanFilePath = pr.PlanFilePath
+		session.mu.Unlock()
+
+		if planContent, readErr := os.ReadFile(pr.PlanFilePath); readErr == nil {
+			m.addOutput(session.ID, OutputLine{
+				Timestamp: time.Now(),
+				Type:      OutputTypePlanReady,
+				Content:   string(planContent),
+			})
+		}
+	}
+
+	// After codetalk turn: write research output to a file for delegator consumption.
+	if session.Type == SessionTypeCodeTalk {
+		if researchPath, err := m.writeResearchFile(session); err == nil {
+			session.mu.Lock()
+			session.ResearchFilePath = researchPath
+			session.mu.Unlock()
+		}
+	}
+	return true
+}
+
 type trackedTmuxCaptureState struct {
 	prevContentLines   []string
 	haveContentCapture bool
@@ -2192,7 +2466,7 @@ func (m *Manager) StopSession(id SessionID) error {
 	status := session.Status
 	session.mu.RUnlock()
 
-	if status != StatusRunning && status != StatusPending && status != StatusIdle {
+	if status != StatusRunning && status != StatusPending && status != StatusIdle && status != StatusWaitingApproval {
 		return fmt.Errorf("session not active: %s", id)
 	}
 
@@ -2203,6 +2477,50 @@ func (m *Manager) StopSession(id SessionID) error {
 	return nil
 }
 
+// ErrStopAllTmuxMode is returned by StopAllSessions in tmux mode, where the
+// sessions are the user's tmux windows and are closed from tmux instead.
+var ErrStopAllTmuxMode = errors.New("stop-all is disabled in tmux mode; close windows with prefix+& instead")
+
+// StopAllSessions cancels every non-terminal session and returns the errors
+// from sessions that could not be stopped. Sessions are snapshotted under
+// m.mu and cancelled after it is released, so this never holds a manager lock
+// while a session's goroutine may be taking one, and is safe to call
+// concurrently with Close. In tmux mode nothing is stopped and the result is
+// ErrStopAllTmuxMode.
+func (m *Manager) StopAllSessions() []error {
+	if m.IsInTmuxMode() {
+		return []error{ErrStopAllTmuxMode}
+	}
+	var errs []error
+	for _, id := range m.activeSessionIDs() {
+		if err := m.StopSession(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ActiveSessionCount returns the number of sessions StopAllSessions would stop.
+func (m *Manager) ActiveSessionCount() int {
+	return len(m.activeSessionIDs())
+}
+
+// activeSessionIDs snapshots the IDs of the non-terminal sessions.
+func (m *Manager) activeSessionIDs() []SessionID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]SessionID, 0, len(m.sessions))
+	for id, s := range m.sessions {
+		s.mu.RLock()
+		active := !s.Status.IsTerminal()
+		s.mu.RUnlock()
+		if active {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // GetSession returns a session by ID.
 func (m *Manager) GetSession(id SessionID) (*Session, bool) {
 	m.mu.RLock()
@@ -2231,8 +2549,8 @@ func (m *Manager) GetSessionsForWorktree(worktreePath string) []SessionInfo {
 
 	var result []SessionInfo
 	for _, s := range m.sessions {
-		if s.WorktreePath == worktreePath {
-			result = append(result, s.ToInfo())
+		if info := s.ToInfo(); info.WorktreePath == worktreePath {
+			result = append(result, info)
 		}
 	}
 	sortSessionsByTime(result)
@@ -2246,9 +2564,11 @@ func (m *Manager) ActiveWorktreePaths() map[string]struct{} {
 
 	paths := make(map[string]struct{})
 	for _, s := range m.sessions {
+		s.mu.RLock()
 		if s.WorktreePath != "" && !s.Status.IsTerminal() {
 			paths[s.WorktreePath] = struct{}{}
 		}
+		s.mu.RUnlock()
 	}
 	return paths
 }
@@ -2645,6 +2965,21 @@ func (m *Manager) HasFollowUpChan(sessionID SessionID) bool {
 	return exists
 }
 
+// DefaultMaxProtocolLogBytes is the per-file protocol log cap used when
+// ManagerConfig.MaxProtocolLogBytes is zero.
+const DefaultMaxProtocolLogBytes = 50 << 20
+
+// protocolLogGenerations is how many rotated files ("<name>.1" through
+// "<name>.N") are kept alongside the live protocol log.
+const protocolLogGenerations = 3
+
+func (m *Manager) protocolLogMaxBytes() int64 {
+	if m.config.MaxProtocolLogBytes == 0 {
+		return DefaultMaxProtocolLogBytes
+	}
+	return m.config.MaxProtocolLogBytes
+}
+
 func (m *Manager) protocolLogPath(sessionID SessionID, suffix string) (string, bool) {
 	logDir := strings.TrimSpace(m.config.ProtocolLogDir)
 	if logDir == "" {
@@ -2657,6 +2992,68 @@ func (m *Manager) protocolLogPath(sessionID SessionID, suffix string) (string, b
 	return filepath.Join(logDir, fmt.Sprintf("%s-%s", sessionID, suffix)), true
 }
 
+// SetCodexEffort sets the reasoning effort (e.g. "low", "high") used by codex
+// sessions started from now on. An empty level or agent.EffortAuto leaves the
+// model default; any other level must be accepted by agent.ParseEffort.
+func (m *Manager) SetCodexEffort(level agent.EffortLevel) error {
+	if level != "" {
+		if _, err := agent.ParseEffort(string(level)); err != nil {
+			return err
+		}
+		if level == agent.EffortAuto {
+			level = ""
+		}
+	}
+	m.mu.Lock()
+	m.codexEffort = level
+	m.mu.Unlock()
+	return nil
+}
+
+// CodexEffort returns the reasoning effort applied to new codex sessions.
+func (m *Manager) CodexEffort() agent.EffortLevel {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.codexEffort
+}
+
+// SetCodexSandbox sets the sandbox level (e.g. "workspace-write") used by
+// codex builder sessions started from now on. An empty level restores the
+// default; any other level must be accepted by codex.ValidateSandboxLevel.
+func (m *Manager) SetCodexSandbox(level string) error {
+	if level != "" {
+		if err := codex.ValidateSandboxLevel(level); err != nil {
+			return err
+		}
+	}
+	m.mu.Lock()
+	m.codexSandbox = level
+	m.mu.Unlock()
+	return nil
+}
+
+// codexSandboxFor returns the codex sandbox level for a new session of the
+// given type. Planner and codetalk sessions only read, so they run
+// read-only. Builders use the level set with SetCodexSandbox, else full
+// access in yolo mode (matching its skipped approvals), else
+// workspace-write.
+func (m *Manager) codexSandboxFor(sessionType SessionType) string {
+	if sessionType == SessionTypePlanner || sessionType == SessionTypeCodeTalk {
+		return codex.SandboxReadOnly
+	}
+	m.mu.RLock()
+	level := m.codexSandbox
+	m.mu.RUnlock()
+	switch {
+	case level != "":
+		return level
+	case m.config.YoloMode:
+		return codex.SandboxDangerFullAccess
+	default:
+		return codex.SandboxWorkspaceWrite
+	}
+}
+
 func (m *Manager) codexProviderOptions(sessionID SessionID) ([]codex.ClientOption, string, string) {
 	sessionLogPath, ok := m.protocolLogPath(sessionID, "codex.protocol.jsonl")
 	if !ok {
@@ -2670,7 +3067,7 @@ func (m *Manager) codexProviderOptions(sessionID SessionID) ([]codex.ClientOptio
 	}
 
 	if stderrLogPath != "" {
-		opts = append(opts, codex.WithStderrHandler(newFileAppendHandler(stderrLogPath)))
+		opts = append(opts, codex.WithStderrHandler(newFileAppendHandler(stderrLogPath, m.protocolLogMaxBytes())))
 	}
 
 	return opts,
@@ -2687,12 +3084,15 @@ func (m *Manager) geminiProviderOptions(sessionID SessionID) ([]acp.ClientOption
 	protocolLogPath, _ := m.protocolLogPath(sessionID, "gemini.protocol.jsonl")
 
 	opts := []acp.ClientOption{
-		acp.WithStderrHandler(newFileAppendHandler(stderrLogPath)),
+		acp.WithStderrHandler(newFileAppendHandler(stderrLogPath, m.protocolLogMaxBytes())),
 	}
 
 	var protocolLogHint string
 	if protocolLogPath != "" {
-		opts = append(opts, acp.WithProtocolLogger(newFileAppendWriter(protocolLogPath)))
+		opts = append(opts, acp.WithProtocolLogger(newFileAppendWriter(protocolLogPath, m.protocolLogMaxBytes())))
+		if !m.config.RawProtocolLogs {
+			opts = append(opts, acp.WithProtocolLoggerRedactor(acp.DefaultProtocolLogRedactor))
+		}
 		protocolLogHint = fmt.Sprintf("Gemini protocol log: %s", protocolLogPath)
 	}
 
@@ -2701,14 +3101,18 @@ func (m *Manager) geminiProviderOptions(sessionID SessionID) ([]acp.ClientOption
 		fmt.Sprintf("Gemini stderr log: %s", stderrLogPath)
 }
 
-// fileAppendWriter implements io.Writer by appending to a file.
+// fileAppendWriter implements io.Writer by appending to a file. When maxBytes
+// is positive, a write that would grow the file past it first rotates the file
+// (see rotate). Rotation happens under mu between writes, so a single Write is
+// never split across generations.
 type fileAppendWriter struct {
-	path string
-	mu   sync.Mutex
+	path     string
+	maxBytes int64
+	mu       sync.Mutex
 }
 
-func newFileAppendWriter(path string) *fileAppendWriter {
-	return &fileAppendWriter{path: path}
+func newFileAppendWriter(path string, maxBytes int64) *fileAppendWriter {
+	return &fileAppendWriter{path: path, maxBytes: maxBytes}
 }
 
 func (w *fileAppendWriter) Write(p []byte) (int, error) {
@@ -2719,6 +3123,14 @@ func (w *fileAppendWriter) Write(p []byte) (int, error) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	if w.maxBytes > 0 {
+		if info, err := os.Stat(w.path); err == nil && info.Size() > 0 && info.Size()+int64(len(p)) > w.maxBytes {
+			if err := w.rotate(); err != nil {
+				log.Printf("WARNING: failed to rotate log %q: %v", w.path, err)
+			}
+		}
+	}
+
 	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 	if err != nil {
 		return 0, err
@@ -2730,8 +3142,24 @@ func (w *fileAppendWriter) Write(p []byte) (int, error) {
 	return n, writeErr
 }
 
-func newFileAppendHandler(path string) func([]byte) {
-	w := newFileAppendWriter(path)
+// rotate shifts <path>.i to <path>.i+1, dropping the oldest generation, and
+// moves the live file to <path>.1. The caller must hold mu.
+func (w *fileAppendWriter) rotate() error {
+	oldest := fmt.Sprintf("%s.%d", w.path, protocolLogGenerations)
+	if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := protocolLogGenerations - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", w.path, i)
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", w.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(w.path, w.path+".1")
+}
+
+func newFileAppendHandler(path string, maxBytes int64) func([]byte) {
+	w := newFileAppendWriter(path, maxBytes)
 	return func(data []byte) {
 		if _, err := w.Write(data); err != nil {
 			log.Printf("WARNING: failed to write log %q: %v", path, err)
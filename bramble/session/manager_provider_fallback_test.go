@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
 	"github.com/bazelment/yoloswe/multiagent/agent"
 	"github.com/bazelment/yoloswe/wt"
 )
@@ -311,3 +312,151 @@ func TestProviderRunner_RunTurnFallsBackToResultTextForSilentLongRunningProvider
 	assert.Equal(t, OutputTypeText, lines[0].Type)
 	assert.Equal(t, "long-running response: follow-up", lines[0].Content)
 }
+
+// drainingProvider is a long-running provider that supports graceful stop.
+type drainingProvider struct {
+	*silentLongRunningProvider
+	deadline time.Time
+	drains   int
+}
+
+func (p *drainingProvider) StopWithTimeout(ctx context.Context) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.drains++
+	p.deadline, _ = ctx.Deadline()
+	return true, nil
+}
+
+func TestProviderRunner_StopDrainsGracefulProvider(t *testing.T) {
+	t.Parallel()
+
+	provider := &drainingProvider{silentLongRunningProvider: newSilentLongRunningProvider()}
+	_, _, handler := setupProviderRunnerHarness(t)
+	runner := &providerRunner{provider: provider, eventHandler: handler}
+	require.NoError(t, runner.Start(context.Background()))
+
+	start := time.Now()
+	require.NoError(t, runner.Stop())
+	assert.Equal(t, 1, provider.drains)
+	assert.WithinDuration(t, start.Add(providerDrainTimeout), provider.deadline, time.Second)
+	assert.True(t, provider.stopped, "Stop still runs after the drain")
+}
+
+// effortRecordingProvider records the reasoning effort each turn was run with.
+type effortRecordingProvider struct {
+	name    string
+	effort  agent.EffortLevel
+	sandbox string
+}
+
+func (p *effortRecordingProvider) Name() string                    { return p.name }
+func (p *effortRecordingProvider) Events() <-chan agent.AgentEvent { return nil }
+func (p *effortRecordingProvider) Close() error                    { return nil }
+func (p *effortRecordingProvider) Execute(_ context.Context, _ string, _ *wt.WorktreeContext, opts ...agent.ExecuteOption) (*agent.AgentResult, error) {
+	var cfg agent.ExecuteConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p.effort = cfg.Effort
+	p.sandbox = cfg.Sandbox
+	return &agent.AgentResult{Text: "ok", Success: true}, nil
+}
+
+func TestProviderRunner_RunTurnPassesEffortOnlyToSupportingProviders(t *testing.T) {
+	t.Parallel()
+
+	_, _, handler := setupProviderRunnerHarness(t)
+	codexProvider := &effortRecordingProvider{name: agent.ProviderCodex}
+	runner := &providerRunner{provider: codexProvider, eventHandler: handler, effort: agent.EffortHigh}
+	_, err := runner.RunTurn(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, agent.EffortHigh, codexProvider.effort)
+
+	cursorProvider := &effortRecordingProvider{name: agent.ProviderCursor}
+	runner = &providerRunner{provider: cursorProvider, eventHandler: handler, effort: agent.EffortHigh}
+	_, err = runner.RunTurn(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.Empty(t, cursorProvider.effort, "effort must be dropped for providers without an effort knob")
+}
+
+func TestManager_SetCodexEffort(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager()
+	t.Cleanup(manager.Close)
+
+	require.NoError(t, manager.SetCodexEffort(agent.EffortLow))
+	assert.Equal(t, agent.EffortLow, manager.CodexEffort())
+	require.NoError(t, manager.SetCodexEffort(agent.EffortAuto))
+	assert.Empty(t, manager.CodexEffort(), "auto means the model default")
+	require.ErrorIs(t, manager.SetCodexEffort("extreme"), agent.ErrInvalidEffort)
+	assert.Empty(t, manager.CodexEffort())
+}
+
+func TestProviderRunner_RunTurnPassesSandbox(t *testing.T) {
+	t.Parallel()
+
+	_, _, handler := setupProviderRunnerHarness(t)
+	provider := &effortRecordingProvider{name: agent.ProviderCodex}
+	runner := &providerRunner{provider: provider, eventHandler: handler, sandbox: codex.SandboxReadOnly}
+	_, err := runner.RunTurn(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, codex.SandboxReadOnly, provider.sandbox)
+}
+
+func TestManager_CodexSandboxFor(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager()
+	t.Cleanup(manager.Close)
+
+	assert.Equal(t, codex.SandboxReadOnly, manager.codexSandboxFor(SessionTypePlanner))
+	assert.Equal(t, codex.SandboxReadOnly, manager.codexSandboxFor(SessionTypeCodeTalk))
+	assert.Equal(t, codex.SandboxWorkspaceWrite, manager.codexSandboxFor(SessionTypeBuilder))
+
+	require.NoError(t, manager.SetCodexSandbox(codex.SandboxDangerFullAccess))
+	assert.Equal(t, codex.SandboxDangerFullAccess, manager.codexSandboxFor(SessionTypeBuilder))
+	assert.Equal(t, codex.SandboxReadOnly, manager.codexSandboxFor(SessionTypePlanner), "planners stay read-only")
+
+	require.Error(t, manager.SetCodexSandbox("full"))
+	assert.Equal(t, codex.SandboxDangerFullAccess, manager.codexSandboxFor(SessionTypeBuilder), "a rejected level leaves the setting alone")
+
+	yolo := NewManagerWithConfig(ManagerConfig{YoloMode: true})
+	t.Cleanup(yolo.Close)
+	assert.Equal(t, codex.SandboxDangerFullAccess, yolo.codexSandboxFor(SessionTypeBuilder))
+}
+
+type servedModelProvider struct{ silentEphemeralProvider }
+
+func (p *servedModelProvider) Execute(context.Context, string, *wt.WorktreeContext, ...agent.ExecuteOption) (*agent.AgentResult, error) {
+	return &agent.AgentResult{Text: "done", Success: true, Model: "gpt-5.4-mini", Effort: "low"}, nil
+}
+
+func TestRunSessionTurn_RecordsServedModel(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager()
+	t.Cleanup(manager.Close)
+	sess := &Session{
+		ID:       "served-model-test",
+		Status:   StatusRunning,
+		Model:    "gpt-5.4",
+		Progress: &SessionProgress{},
+		ctx:      context.Background(),
+	}
+	manager.AddSession(sess)
+	manager.InitOutputBuffer(sess.ID)
+	runner := &providerRunner{
+		provider:     &servedModelProvider{},
+		eventHandler: newSessionEventHandler(manager, sess.ID),
+	}
+
+	require.True(t, manager.runSessionTurn(sess, runner, "hello"))
+
+	info, ok := manager.GetSessionInfo(sess.ID)
+	require.True(t, ok)
+	assert.Equal(t, "gpt-5.4", info.Model, "the requested model is kept")
+	assert.Equal(t, "gpt-5.4-mini", info.Progress.ServedModel)
+	assert.Equal(t, "low", info.Progress.ServedEffort)
+}
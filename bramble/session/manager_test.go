@@ -2,6 +2,9 @@ package session
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -9,7 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/acp"
 	"github.com/bazelment/yoloswe/bramble/sessionmodel"
+	"github.com/bazelment/yoloswe/multiagent/agent"
 )
 
 func TestNewManager(t *testing.T) {
@@ -1012,6 +1017,128 @@ func TestRehydrateSession_FoundInStore(t *testing.T) {
 	assert.True(t, inMap, "rehydrated session should be in manager's sessions map")
 }
 
+// --- ReviveSession tests ---
+
+func saveRevivableSession(t *testing.T, store *Store, id SessionID, model, cliSessionID string) {
+	t.Helper()
+	require.NoError(t, store.SaveSession(&StoredSession{
+		ID:           id,
+		Type:         SessionTypeBuilder,
+		Status:       StatusCompleted,
+		RepoName:     "test-repo",
+		WorktreePath: "/path/wt",
+		WorktreeName: "feature",
+		Prompt:       "do the thing",
+		Model:        model,
+		CLISessionID: cliSessionID,
+		CreatedAt:    time.Now(),
+		Progress:     &StoredProgress{TurnCount: 2, TotalCostUSD: 0.5},
+		Output: []OutputLine{
+			{Type: OutputTypeText, Content: "do the thing", IsUserPrompt: true},
+			{Type: OutputTypeText, Content: "done"},
+		},
+	}))
+}
+
+func TestReviveSession_SeedsOutputAndWaitsForFollowUp(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	saveRevivableSession(t, store, "stored-sess", "sonnet", "clisessid123")
+
+	m := NewManagerWithConfig(ManagerConfig{
+		RepoName:    "test-repo",
+		Store:       store,
+		Provider:    &silentEphemeralProvider{},
+		SessionMode: SessionModeTUI,
+	})
+	defer m.Close()
+
+	id, err := m.ReviveSession("stored-sess")
+	require.NoError(t, err)
+	assert.Equal(t, SessionID("stored-sess"), id)
+
+	require.Eventually(t, func() bool {
+		info, ok := m.GetSessionInfo(id)
+		return ok && info.Status == StatusIdle
+	}, 2*time.Second, 10*time.Millisecond)
+
+	info, _ := m.GetSessionInfo(id)
+	assert.Equal(t, 2, info.Progress.TurnCount, "no turn runs until a follow-up arrives")
+	assert.Equal(t, "clisessid123", info.CLISessionID)
+
+	output := m.GetSessionOutput(id)
+	require.Len(t, output, 3)
+	assert.Equal(t, "done", output[1].Content)
+	assert.Contains(t, output[2].Content, "Revived from history")
+
+	require.NoError(t, m.SendFollowUp(id, "and then?"))
+	require.Eventually(t, func() bool {
+		for _, line := range m.GetSessionOutput(id) {
+			if strings.Contains(line.Content, "response: and then?") {
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestReviveSession_Guards(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	saveRevivableSession(t, store, "no-cli-id", "sonnet", "")
+	saveRevivableSession(t, store, "unknown-model", "foo-bar", "clisessid123")
+	saveRevivableSession(t, store, "codex-sess", "gpt-5.5", "thread-1")
+
+	avail := agent.NewProviderAvailabilityFromMap(map[string]agent.ProviderStatus{
+		agent.ProviderClaude: {Provider: agent.ProviderClaude, Installed: true},
+		agent.ProviderCodex:  {Provider: agent.ProviderCodex, Installed: false},
+	})
+	m := NewManagerWithConfig(ManagerConfig{
+		RepoName:      "test-repo",
+		Store:         store,
+		ModelRegistry: agent.NewModelRegistry(avail, nil),
+		SessionMode:   SessionModeTUI,
+	})
+	defer m.Close()
+
+	tests := []struct {
+		id   SessionID
+		want string
+	}{
+		{"missing", "not found"},
+		{"no-cli-id", "no CLI session ID"},
+		{"unknown-model", "unknown model"},
+		{"codex-sess", `provider "codex" is not available`},
+	}
+	for _, tt := range tests {
+		_, err := m.ReviveSession(tt.id)
+		require.Error(t, err, tt.id)
+		assert.Contains(t, err.Error(), tt.want, tt.id)
+		_, live := m.GetSession(tt.id)
+		assert.False(t, live, "%s must not be added to live sessions", tt.id)
+	}
+
+	tmux := NewManagerWithConfig(ManagerConfig{RepoName: "test-repo", Store: store, SessionMode: SessionModeTmux})
+	defer tmux.Close()
+	_, err = tmux.ReviveSession("codex-sess")
+	assert.ErrorContains(t, err, "TUI mode")
+}
+
+func TestReviveSession_RejectsLiveSession(t *testing.T) {
+	t.Parallel()
+
+	m := NewManagerWithConfig(ManagerConfig{SessionMode: SessionModeTUI})
+	defer m.Close()
+	m.AddSession(&Session{ID: "live", Status: StatusIdle, Progress: &SessionProgress{}})
+
+	_, err := m.ReviveSession("live")
+	assert.ErrorContains(t, err, "already live")
+}
+
 func TestSessionInfoIsResumable(t *testing.T) {
 	t.Parallel()
 
@@ -1523,3 +1650,69 @@ func TestReposWithLiveTmuxSessions_NilStore(t *testing.T) {
 	liveRepos := ReposWithLiveTmuxSessions(nil, "active-repo")
 	assert.Nil(t, liveRepos)
 }
+
+func TestFileAppendWriterRotatesBySize(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "s1-gemini.protocol.jsonl")
+	w := newFileAppendWriter(path, 10)
+
+	// Each write is 6 bytes, so every write after the first rotates.
+	for _, line := range []string{"aaaaa\n", "bbbbb\n", "ccccc\n", "ddddd\n", "eeeee\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	read := func(p string) string {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "eeeee\n", read(path))
+	assert.Equal(t, "ddddd\n", read(path+".1"))
+	assert.Equal(t, "ccccc\n", read(path+".2"))
+	assert.Equal(t, "bbbbb\n", read(path+".3"))
+	_, err := os.Stat(path + fmt.Sprintf(".%d", protocolLogGenerations+1))
+	assert.True(t, os.IsNotExist(err), "only protocolLogGenerations rotated files are kept")
+}
+
+func TestFileAppendWriterNoRotationWhenDisabled(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "log")
+	w := newFileAppendWriter(path, 0)
+	for i := 0; i < 3; i++ {
+		_, err := w.Write([]byte("0123456789\n"))
+		require.NoError(t, err)
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, data, 33)
+	_, err = os.Stat(path + ".1")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestProtocolLogMaxBytesDefault(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, int64(DefaultMaxProtocolLogBytes), NewManagerWithConfig(ManagerConfig{}).protocolLogMaxBytes())
+	assert.Equal(t, int64(-1), NewManagerWithConfig(ManagerConfig{MaxProtocolLogBytes: -1}).protocolLogMaxBytes())
+}
+
+func TestGeminiProviderOptionsRedactProtocolLog(t *testing.T) {
+	for _, raw := range []bool{false, true} {
+		m := NewManagerWithConfig(ManagerConfig{ProtocolLogDir: t.TempDir(), RawProtocolLogs: raw})
+		opts, logHint, _ := m.geminiProviderOptions("sess-1")
+		m.Close()
+		require.NotEmpty(t, logHint)
+
+		var cfg acp.ClientConfig
+		for _, opt := range opts {
+			opt(&cfg)
+		}
+		require.NotNil(t, cfg.ProtocolLogger)
+		if raw {
+			assert.Nil(t, cfg.ProtocolLogRedactor, "RawProtocolLogs should log verbatim")
+		} else {
+			require.NotNil(t, cfg.ProtocolLogRedactor)
+			assert.NotContains(t, string(cfg.ProtocolLogRedactor([]byte(`"key":"sk-abcdefghijklmnop1234"`))), "sk-abcdefghijklmnop1234")
+		}
+	}
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
 	"github.com/bazelment/yoloswe/multiagent/agent"
 	"github.com/bazelment/yoloswe/wt"
 )
@@ -162,6 +164,67 @@ func TestProviderRunner_EventBridge(t *testing.T) {
 	mockProvider.mu.Unlock()
 }
 
+// Test that interleaved thinking and text from a Gemini-style provider land
+// in separate output lines instead of one merged text blob.
+func TestProviderRunner_EventBridgeSeparatesThinking(t *testing.T) {
+	mockProvider := newMockLongRunningProvider()
+	manager, sessionID, handler := setupProviderRunnerHarness(t)
+
+	runner := &providerRunner{
+		provider:     mockProvider,
+		eventHandler: handler,
+	}
+	require.NoError(t, runner.Start(context.Background()))
+	defer runner.Stop()
+
+	mockProvider.emitEvent(agent.ThinkingAgentEvent{Thinking: "**Locating the handler**\n\n"})
+	mockProvider.emitEvent(agent.ThinkingAgentEvent{Thinking: "I should read client.go first."})
+	mockProvider.emitEvent(agent.TextAgentEvent{Text: "Let me look at "})
+	mockProvider.emitEvent(agent.TextAgentEvent{Text: "the client."})
+	mockProvider.emitEvent(agent.ThinkingAgentEvent{Thinking: "The delay doubles each attempt."})
+	mockProvider.emitEvent(agent.TextAgentEvent{Text: " Retries back off exponentially."})
+
+	output := requireSessionOutputEventually(t, manager, sessionID, func(output []OutputLine) bool {
+		return outputContainsText(output, " Retries back off exponentially.")
+	})
+	require.Len(t, output, 4)
+	assert.Equal(t, OutputTypeThinking, output[0].Type)
+	assert.Equal(t, "**Locating the handler**\n\nI should read client.go first.", output[0].Content)
+	assert.Equal(t, OutputTypeText, output[1].Type)
+	assert.Equal(t, "Let me look at the client.", output[1].Content)
+	assert.Equal(t, OutputTypeThinking, output[2].Type)
+	assert.Equal(t, "The delay doubles each attempt.", output[2].Content)
+	assert.Equal(t, OutputTypeText, output[3].Type)
+}
+
+func TestProviderRunner_EventBridgeShowsToolInputProgress(t *testing.T) {
+	mockProvider := newMockLongRunningProvider()
+	manager, sessionID, handler := setupProviderRunnerHarness(t)
+
+	runner := &providerRunner{
+		provider:     mockProvider,
+		eventHandler: handler,
+	}
+	require.NoError(t, runner.Start(context.Background()))
+	defer runner.Stop()
+
+	partial := `{"file_path":"/repo/main.go","content":"` + strings.Repeat("x", 2100)
+	mockProvider.emitEvent(agent.ToolStartAgentEvent{Name: "Write", ID: "toolu_1"})
+	mockProvider.emitEvent(agent.ToolInputDeltaAgentEvent{ToolID: "toolu_1", Name: "Write", PartialJSON: partial})
+
+	output := requireSessionOutputEventually(t, manager, sessionID, func(output []OutputLine) bool {
+		return len(output) == 1 && output[0].Content != "Write"
+	})
+	assert.Equal(t, "Write: writing 2.1KB to main.go…", output[0].Content)
+
+	input := map[string]interface{}{"file_path": "/repo/main.go", "content": "package main"}
+	mockProvider.emitEvent(agent.ToolCompleteAgentEvent{Name: "Write", ID: "toolu_1", Input: input})
+	output = requireSessionOutputEventually(t, manager, sessionID, func(output []OutputLine) bool {
+		return len(output) == 1 && output[0].ToolState == ToolStateComplete
+	})
+	assert.Equal(t, "Write → /repo/main.go", output[0].Content)
+}
+
 // Test that providerRunner cleans up the event bridge on Stop.
 func TestProviderRunner_EventBridgeCleanup(t *testing.T) {
 	mockProvider := newMockLongRunningProvider()
@@ -481,3 +544,84 @@ func TestProviderRunner_EventBridgeGracefulShutdown(t *testing.T) {
 	// We can't directly check this, but we can verify Stop() completed without hanging
 	assert.Nil(t, runner.eventBridgeDone, "eventBridgeDone should be nil after Stop")
 }
+
+// resumingProvider records the resume session ID of each Execute call and
+// reports a fixed thread ID, like the codex provider.
+type resumingProvider struct {
+	mockEphemeralProvider
+	err      error // returned once when a resume is requested
+	threadID string
+	resumes  []string
+}
+
+func (p *resumingProvider) Execute(ctx context.Context, prompt string, wtCtx *wt.WorktreeContext, opts ...agent.ExecuteOption) (*agent.AgentResult, error) {
+	var cfg agent.ExecuteConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p.resumes = append(p.resumes, cfg.ResumeSessionID)
+	if cfg.ResumeSessionID != "" && p.err != nil {
+		err := p.err
+		p.err = nil
+		return nil, err
+	}
+	return &agent.AgentResult{Text: "response", Success: true, SessionID: p.threadID}, nil
+}
+
+func TestProviderRunner_ResumesThreadAcrossTurns(t *testing.T) {
+	provider := &resumingProvider{
+		mockEphemeralProvider: mockEphemeralProvider{events: make(chan agent.AgentEvent, 10)},
+		threadID:              "thr_1",
+	}
+	runner := &providerRunner{provider: provider, resumable: true}
+
+	assert.Empty(t, runner.CLISessionID())
+	_, err := runner.RunTurn(context.Background(), "first")
+	require.NoError(t, err)
+	assert.Equal(t, "thr_1", runner.CLISessionID())
+
+	_, err = runner.RunTurn(context.Background(), "second")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "thr_1"}, provider.resumes)
+}
+
+func TestProviderRunner_ReattachesSavedThread(t *testing.T) {
+	provider := &resumingProvider{
+		mockEphemeralProvider: mockEphemeralProvider{events: make(chan agent.AgentEvent, 10)},
+		threadID:              "thr_saved",
+	}
+	runner := &providerRunner{provider: provider, resumable: true, sessionID: "thr_saved"}
+
+	_, err := runner.RunTurn(context.Background(), "continue")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"thr_saved"}, provider.resumes)
+}
+
+func TestProviderRunner_UnknownThreadStartsFresh(t *testing.T) {
+	provider := &resumingProvider{
+		mockEphemeralProvider: mockEphemeralProvider{events: make(chan agent.AgentEvent, 10)},
+		err:                   &codex.ThreadNotFoundError{ThreadID: "thr_gone"},
+		threadID:              "thr_new",
+	}
+	runner := &providerRunner{provider: provider, resumable: true, sessionID: "thr_gone"}
+
+	_, err := runner.RunTurn(context.Background(), "continue")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"thr_gone", ""}, provider.resumes)
+	assert.Equal(t, "thr_new", runner.CLISessionID())
+}
+
+func TestProviderRunner_NonResumableReportsNoSessionID(t *testing.T) {
+	provider := &resumingProvider{
+		mockEphemeralProvider: mockEphemeralProvider{events: make(chan agent.AgentEvent, 10)},
+		threadID:              "thr_1",
+	}
+	runner := &providerRunner{provider: provider}
+
+	_, err := runner.RunTurn(context.Background(), "first")
+	require.NoError(t, err)
+	_, err = runner.RunTurn(context.Background(), "second")
+	require.NoError(t, err)
+	assert.Empty(t, runner.CLISessionID())
+	assert.Equal(t, []string{"", ""}, provider.resumes)
+}
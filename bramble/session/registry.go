@@ -103,6 +103,21 @@ func (r *SessionRegistry) StopSession(id SessionID) error {
 	return mgr.StopSession(id)
 }
 
+// StopAllSessions stops every non-terminal session in all registered managers
+// and returns the errors from sessions that could not be stopped.
+func (r *SessionRegistry) StopAllSessions() []error {
+	r.mu.RLock()
+	managers := make([]*Manager, len(r.managers))
+	copy(managers, r.managers)
+	r.mu.RUnlock()
+
+	var errs []error
+	for _, mgr := range managers {
+		errs = append(errs, mgr.StopAllSessions()...)
+	}
+	return errs
+}
+
 // GetAllSessions returns sessions from all registered managers.
 func (r *SessionRegistry) GetAllSessions() []SessionInfo {
 	r.mu.RLock()
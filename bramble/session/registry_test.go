@@ -180,3 +180,69 @@ func TestRegistry_ConcurrentRegisterAndLookup(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestRegistry_StopAllSessions(t *testing.T) {
+	reg := NewSessionRegistry()
+	mgr1 := newTestManager(t, "repo-a")
+	mgr2 := newTestManager(t, "repo-b")
+	reg.Register(mgr1)
+	reg.Register(mgr2)
+
+	var cancelled []SessionID
+	var mu sync.Mutex
+	add := func(mgr *Manager, id SessionID, status SessionStatus) {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		mgr.sessions[id] = &Session{
+			ID:     id,
+			Status: status,
+			cancel: func() {
+				mu.Lock()
+				cancelled = append(cancelled, id)
+				mu.Unlock()
+			},
+		}
+	}
+	add(mgr1, "running", StatusRunning)
+	add(mgr1, "done", StatusCompleted)
+	add(mgr2, "idle", StatusIdle)
+	add(mgr2, "pending", StatusPending)
+
+	errs := reg.StopAllSessions()
+	assert.Empty(t, errs)
+	assert.ElementsMatch(t, []SessionID{"running", "idle", "pending"}, cancelled)
+}
+
+func TestManager_StopAllSessionsTmuxMode(t *testing.T) {
+	mgr := newTestManager(t, "repo-a")
+	mgr.config.SessionMode = SessionModeTmux
+	stopped := false
+	addFakeSession(mgr, "win", "p")
+	mgr.sessions["win"].cancel = func() { stopped = true }
+
+	assert.Equal(t, 1, mgr.ActiveSessionCount())
+	errs := mgr.StopAllSessions()
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrStopAllTmuxMode)
+	assert.False(t, stopped, "tmux windows must not be stopped")
+}
+
+func TestManager_StopAllSessionsConcurrentWithClose(t *testing.T) {
+	mgr := newTestManager(t, "repo-a")
+	for _, id := range []SessionID{"a", "b", "c"} {
+		addFakeSession(mgr, id, "p")
+		mgr.sessions[id].cancel = func() {}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		mgr.StopAllSessions()
+	}()
+	go func() {
+		defer wg.Done()
+		mgr.Close()
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,64 @@
+package session
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// workDirUpdater is implemented by runners that pass a working directory to
+// the agent on every turn, so a worktree moved between turns is picked up.
+type workDirUpdater interface {
+	setWorkDir(dir string)
+}
+
+func (r *providerRunner) setWorkDir(dir string) {
+	r.workDir = dir
+}
+
+// RelocateWorktree re-points every session in the worktree at oldPath to
+// newPath after the worktree directory was moved (e.g. a branch rename).
+// Live sessions keep running with their output buffer: a moved directory
+// stays the running agent's cwd, and runSessionTurn hands the runner newPath
+// before the next turn. Plan and research files inside the worktree are
+// re-pointed too. Returns the number of sessions updated.
+func (m *Manager) RelocateWorktree(oldPath, newPath string) int {
+	if oldPath == "" || newPath == "" || oldPath == newPath {
+		return 0
+	}
+	m.mu.RLock()
+	var moved []*Session
+	for _, s := range m.sessions {
+		s.mu.RLock()
+		if s.WorktreePath == oldPath {
+			moved = append(moved, s)
+		}
+		s.mu.RUnlock()
+	}
+	m.mu.RUnlock()
+
+	newName := filepath.Base(newPath)
+	for _, s := range moved {
+		s.mu.Lock()
+		oldName := s.WorktreeName
+		s.WorktreePath = newPath
+		s.WorktreeName = newName
+		s.PlanFilePath = relocatePath(s.PlanFilePath, oldPath, newPath)
+		s.ResearchFilePath = relocatePath(s.ResearchFilePath, oldPath, newPath)
+		s.mu.Unlock()
+
+		if m.config.Store != nil && m.config.RepoName != "" && oldName != newName {
+			_ = m.config.Store.DeleteSession(m.config.RepoName, oldName, s.ID)
+			m.persistSession(s)
+		}
+	}
+	return len(moved)
+}
+
+// relocatePath rewrites path to live under newDir when it is inside oldDir.
+func relocatePath(path, oldDir, newDir string) string {
+	rel, err := filepath.Rel(oldDir, path)
+	if path == "" || err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.Join(newDir, rel)
+}
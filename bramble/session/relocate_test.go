@@ -0,0 +1,103 @@
+package session
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+func TestRelocateWorktree(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	m := NewManagerWithConfig(ManagerConfig{RepoName: "repo", Store: store})
+	defer m.Close()
+
+	oldPath := filepath.Join("/wt", "repo", "fix-typo")
+	newPath := filepath.Join("/wt", "repo", "fix-type")
+	moved := &Session{
+		ID:           "moved",
+		Status:       StatusRunning,
+		WorktreePath: oldPath,
+		WorktreeName: "fix-typo",
+		PlanFilePath: filepath.Join(oldPath, "plan.md"),
+		Progress:     &SessionProgress{},
+		CreatedAt:    time.Now(),
+	}
+	other := &Session{ID: "other", WorktreePath: filepath.Join("/wt", "repo", "fix-typo-2"), WorktreeName: "fix-typo-2"}
+	m.AddSession(moved)
+	m.AddSession(other)
+	m.InitOutputBuffer("moved")
+	m.AddOutputLine("moved", OutputLine{Type: OutputTypeText, Content: "kept"})
+	m.persistSession(moved)
+
+	assert.Equal(t, 1, m.RelocateWorktree(oldPath, newPath))
+
+	info, ok := m.GetSessionInfo("moved")
+	require.True(t, ok)
+	assert.Equal(t, newPath, info.WorktreePath)
+	assert.Equal(t, "fix-type", info.WorktreeName)
+	assert.Equal(t, filepath.Join(newPath, "plan.md"), info.PlanFilePath)
+	assert.Equal(t, StatusRunning, info.Status)
+	assert.Len(t, m.GetSessionOutput("moved"), 1)
+
+	info, _ = m.GetSessionInfo("other")
+	assert.Equal(t, filepath.Join("/wt", "repo", "fix-typo-2"), info.WorktreePath)
+
+	_, err = store.LoadSession("repo", "fix-typo", "moved")
+	assert.Error(t, err, "record under the old worktree name should be gone")
+	stored, err := store.LoadSession("repo", "fix-type", "moved")
+	require.NoError(t, err)
+	assert.Equal(t, newPath, stored.WorktreePath)
+}
+
+type workDirProvider struct {
+	silentEphemeralProvider
+	workDirs []string
+}
+
+func (p *workDirProvider) Execute(ctx context.Context, prompt string, wtCtx *wt.WorktreeContext, opts ...agent.ExecuteOption) (*agent.AgentResult, error) {
+	var cfg agent.ExecuteConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p.workDirs = append(p.workDirs, cfg.WorkDir)
+	return p.silentEphemeralProvider.Execute(ctx, prompt, wtCtx, opts...)
+}
+
+func TestRelocateWorktreeLiveSessionNextTurn(t *testing.T) {
+	m := NewManager()
+	defer m.Close()
+
+	oldPath := filepath.Join("/wt", "repo", "fix-tpyo")
+	newPath := filepath.Join("/wt", "repo", "fix-typo")
+	sess := &Session{
+		ID:           "live",
+		Status:       StatusIdle,
+		WorktreePath: oldPath,
+		Progress:     &SessionProgress{},
+		ctx:          context.Background(),
+	}
+	m.AddSession(sess)
+	m.InitOutputBuffer(sess.ID)
+	provider := &workDirProvider{}
+	runner := &providerRunner{
+		provider:     provider,
+		eventHandler: newSessionEventHandler(m, sess.ID),
+		workDir:      oldPath,
+	}
+	require.True(t, m.runSessionTurn(sess, runner, "first"))
+	before := len(m.GetSessionOutput(sess.ID))
+
+	assert.Equal(t, 1, m.RelocateWorktree(oldPath, newPath))
+	require.True(t, m.runSessionTurn(sess, runner, "second"))
+
+	assert.Equal(t, []string{oldPath, newPath}, provider.workDirs)
+	assert.Greater(t, len(m.GetSessionOutput(sess.ID)), before, "output from before the rename is kept")
+}
@@ -37,34 +37,42 @@ type StoredSession struct {
 	TmuxWindowName string          `json:"tmux_window_name,omitempty"`
 	TmuxWindowID   string          `json:"tmux_window_id,omitempty"`
 	RunnerType     string          `json:"runner_type,omitempty"`
+	StartSHA       string          `json:"start_sha,omitempty"`
 	Progress       *StoredProgress `json:"progress,omitempty"`
 	Output         []OutputLine    `json:"output,omitempty"`
 }
 
 // StoredProgress is the serializable representation of session progress.
 type StoredProgress struct {
+	// ServedModel and ServedEffort record what served the last turn, for
+	// providers that report it (see SessionProgress.ServedModel).
+	ServedModel  string  `json:"served_model,omitempty"`
+	ServedEffort string  `json:"served_effort,omitempty"`
 	TurnCount    int     `json:"turn_count"`
 	TotalCostUSD float64 `json:"total_cost_usd"`
 	InputTokens  int     `json:"input_tokens"`
 	OutputTokens int     `json:"output_tokens"`
+	// ReasoningTokens is absent in sessions saved before it was tracked.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
 }
 
 // SessionMeta contains minimal session info for listing.
 type SessionMeta struct {
-	CreatedAt      time.Time     `json:"created_at"`
-	CompletedAt    *time.Time    `json:"completed_at,omitempty"`
-	ID             SessionID     `json:"id"`
-	Type           SessionType   `json:"type"`
-	Status         SessionStatus `json:"status"`
-	RepoName       string        `json:"repo_name"`
-	WorktreeName   string        `json:"worktree_name"`
-	Prompt         string        `json:"prompt"`
-	Title          string        `json:"title,omitempty"`
-	Model          string        `json:"model,omitempty"`
-	CLISessionID   string        `json:"cli_session_id,omitempty"`
-	TmuxWindowName string        `json:"tmux_window_name,omitempty"`
-	TmuxWindowID   string        `json:"tmux_window_id,omitempty"`
-	RunnerType     string        `json:"runner_type,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+	Progress       *StoredProgress `json:"progress,omitempty"`
+	ID             SessionID       `json:"id"`
+	Type           SessionType     `json:"type"`
+	Status         SessionStatus   `json:"status"`
+	RepoName       string          `json:"repo_name"`
+	WorktreeName   string          `json:"worktree_name"`
+	Prompt         string          `json:"prompt"`
+	Title          string          `json:"title,omitempty"`
+	Model          string          `json:"model,omitempty"`
+	CLISessionID   string          `json:"cli_session_id,omitempty"`
+	TmuxWindowName string          `json:"tmux_window_name,omitempty"`
+	TmuxWindowID   string          `json:"tmux_window_id,omitempty"`
+	RunnerType     string          `json:"runner_type,omitempty"`
 }
 
 // DefaultStoreDir returns the default store directory (~/.bramble/sessions).
@@ -363,6 +371,7 @@ func storedToMeta(stored *StoredSession) *SessionMeta {
 		RunnerType:     stored.RunnerType,
 		CreatedAt:      stored.CreatedAt,
 		CompletedAt:    stored.CompletedAt,
+		Progress:       stored.Progress,
 	}
 }
 
@@ -389,6 +398,7 @@ func SessionToStored(session *Session, repoName string, output []OutputLine) *St
 		TmuxWindowName: session.TmuxWindowName,
 		TmuxWindowID:   session.TmuxWindowID,
 		RunnerType:     session.RunnerType,
+		StartSHA:       session.StartSHA,
 		CreatedAt:      session.CreatedAt,
 		StartedAt:      session.StartedAt,
 		CompletedAt:    session.CompletedAt,
@@ -402,10 +412,13 @@ func SessionToStored(session *Session, repoName string, output []OutputLine) *St
 	if session.Progress != nil {
 		progress := session.Progress.Clone()
 		stored.Progress = &StoredProgress{
-			TurnCount:    progress.TurnCount,
-			TotalCostUSD: progress.TotalCostUSD,
-			InputTokens:  progress.InputTokens,
-			OutputTokens: progress.OutputTokens,
+			TurnCount:       progress.TurnCount,
+			TotalCostUSD:    progress.TotalCostUSD,
+			InputTokens:     progress.InputTokens,
+			OutputTokens:    progress.OutputTokens,
+			ReasoningTokens: progress.ReasoningTokens,
+			ServedModel:     progress.ServedModel,
+			ServedEffort:    progress.ServedEffort,
 		}
 	}
 
@@ -439,10 +452,13 @@ func StoredToSessionInfo(stored *StoredSession) SessionInfo {
 
 	if stored.Progress != nil {
 		info.Progress = SessionProgressSnapshot{
-			TurnCount:    stored.Progress.TurnCount,
-			TotalCostUSD: stored.Progress.TotalCostUSD,
-			InputTokens:  stored.Progress.InputTokens,
-			OutputTokens: stored.Progress.OutputTokens,
+			TurnCount:       stored.Progress.TurnCount,
+			TotalCostUSD:    stored.Progress.TotalCostUSD,
+			InputTokens:     stored.Progress.InputTokens,
+			OutputTokens:    stored.Progress.OutputTokens,
+			ReasoningTokens: stored.Progress.ReasoningTokens,
+			ServedModel:     stored.Progress.ServedModel,
+			ServedEffort:    stored.Progress.ServedEffort,
 		}
 	}
 
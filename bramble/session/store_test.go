@@ -60,6 +60,7 @@ func TestStoreSaveAndLoad(t *testing.T) {
 			TotalCostUSD: 0.0123,
 			InputTokens:  1000,
 			OutputTokens: 500,
+			ServedModel:  "gpt-5.4-mini",
 		},
 		Output: []OutputLine{
 			{Timestamp: now, Type: OutputTypeStatus, Content: "Starting"},
@@ -93,6 +94,7 @@ func TestStoreSaveAndLoad(t *testing.T) {
 	assert.Equal(t, session.Progress.TotalCostUSD, loaded.Progress.TotalCostUSD)
 	assert.Equal(t, session.Progress.InputTokens, loaded.Progress.InputTokens)
 	assert.Equal(t, session.Progress.OutputTokens, loaded.Progress.OutputTokens)
+	assert.Equal(t, session.Progress.ServedModel, loaded.Progress.ServedModel)
 
 	require.Len(t, loaded.Output, 2)
 	assert.Equal(t, session.Output[0].Content, loaded.Output[0].Content)
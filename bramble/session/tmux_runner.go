@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
 )
 
 // tmuxRunner implements sessionRunner by creating a tmux window that runs the agent CLI.
@@ -24,6 +25,7 @@ type tmuxRunner struct {
 	sessionID       string // bramble session ID for IPC notification hook
 	brambleBin      string // absolute path to the bramble binary for hook commands
 	brambleSock     string // IPC socket path to pass to hook commands
+	sandbox         string // codex sandbox level (e.g. "workspace-write"); empty keeps the CLI default
 	yoloMode        bool   // skip all permission prompts
 	killOnStop      bool   // kill tmux window on Stop()
 }
@@ -144,8 +146,13 @@ func (r *tmuxRunner) buildCommand() (binary string, args []string) {
 	switch binary {
 	case ProviderCodex:
 		// Codex-specific flags
-		if r.yoloMode {
+		switch {
+		case r.yoloMode && (r.sandbox == "" || r.sandbox == codex.SandboxDangerFullAccess):
 			args = append(args, "--dangerously-bypass-approvals-and-sandbox")
+		case r.yoloMode:
+			args = append(args, "--sandbox", r.sandbox, "--ask-for-approval", "never")
+		case r.sandbox != "":
+			args = append(args, "--sandbox", r.sandbox)
 		}
 	case ProviderGemini:
 		// Gemini-specific flags
@@ -129,6 +129,41 @@ func TestTmuxRunnerBuildCommand(t *testing.T) {
 			wantBin:  "codex",
 			wantArgs: []string{"--model", "gpt-5.4", "--dangerously-bypass-approvals-and-sandbox", "build it"},
 		},
+		{
+			name: "codex with sandbox",
+			runner: tmuxRunner{
+				model:    "gpt-5.5",
+				provider: ProviderCodex,
+				prompt:   "build it",
+				sandbox:  "workspace-write",
+			},
+			wantBin:  "codex",
+			wantArgs: []string{"--model", "gpt-5.5", "--sandbox", "workspace-write", "build it"},
+		},
+		{
+			name: "codex with yolo and an explicit sandbox",
+			runner: tmuxRunner{
+				model:    "gpt-5.5",
+				provider: ProviderCodex,
+				prompt:   "build it",
+				sandbox:  "read-only",
+				yoloMode: true,
+			},
+			wantBin:  "codex",
+			wantArgs: []string{"--model", "gpt-5.5", "--sandbox", "read-only", "--ask-for-approval", "never", "build it"},
+		},
+		{
+			name: "codex with yolo and full access",
+			runner: tmuxRunner{
+				model:    "gpt-5.5",
+				provider: ProviderCodex,
+				prompt:   "build it",
+				sandbox:  "danger-full-access",
+				yoloMode: true,
+			},
+			wantBin:  "codex",
+			wantArgs: []string{"--model", "gpt-5.5", "--dangerously-bypass-approvals-and-sandbox", "build it"},
+		},
 		{
 			name: "claude with yolo",
 			runner: tmuxRunner{
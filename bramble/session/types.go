@@ -77,6 +77,8 @@ const (
 	StatusFailed    = sessionmodel.StatusFailed
 	StatusStopped   = sessionmodel.StatusStopped
 
+	StatusWaitingApproval = sessionmodel.StatusWaitingApproval
+
 	ToolStateRunning  = sessionmodel.ToolStateRunning
 	ToolStateComplete = sessionmodel.ToolStateComplete
 	ToolStateError    = sessionmodel.ToolStateError
@@ -113,6 +115,7 @@ type Session struct {
 	StartedAt        *time.Time
 	CompletedAt      *time.Time
 	cancel           context.CancelFunc
+	pendingApproval  *pendingApproval // command the agent is waiting on the user for
 	WorktreeName     string
 	Prompt           string
 	Title            string
@@ -124,24 +127,32 @@ type Session struct {
 	RepoName         string // Repository this session belongs to
 	CLISessionID     string // CLI session ID (from system{init}), used for --resume
 	ResearchFilePath string // Path to research output file (codetalk sessions only)
+	StartSHA         string // worktree HEAD when the session started, the base for SessionDiffStat
 	ID               SessionID
 	WorktreePath     string
 	Status           SessionStatus
 	Type             SessionType
+	approvalMu       sync.Mutex // serializes approval prompts; held while one is pending
 	mu               sync.RWMutex
 }
 
 // SessionProgress tracks real-time progress.
 type SessionProgress struct {
-	LastActivity       time.Time
-	CurrentPhase       string
-	CurrentTool        string
-	StatusLine         string
+	LastActivity time.Time
+	CurrentPhase string
+	CurrentTool  string
+	StatusLine   string
+	// ServedModel and ServedEffort are the model and reasoning effort that
+	// served the last turn, for providers that report them (codex). They can
+	// differ from Session.Model when the provider downgrades or clamps.
+	ServedModel        string
+	ServedEffort       string
 	RecentOutput       []string // last N lines of assistant text for command center display
 	TurnCount          int
 	TotalCostUSD       float64
 	InputTokens        int
 	OutputTokens       int
+	ReasoningTokens    int // part of OutputTokens spent on reasoning (codex only)
 	ContextWindow      int // total context window size for the model (from protocol)
 	LastTurnInputTotal int // last turn's total input tokens (input + cache_creation + cache_read) for context utilization
 	mu                 sync.RWMutex
@@ -170,10 +181,13 @@ func (p *SessionProgress) Clone() SessionProgress {
 		TotalCostUSD:       p.TotalCostUSD,
 		InputTokens:        p.InputTokens,
 		OutputTokens:       p.OutputTokens,
+		ReasoningTokens:    p.ReasoningTokens,
 		ContextWindow:      p.ContextWindow,
 		LastTurnInputTotal: p.LastTurnInputTotal,
 		LastActivity:       p.LastActivity,
 		StatusLine:         p.StatusLine,
+		ServedModel:        p.ServedModel,
+		ServedEffort:       p.ServedEffort,
 		RecentOutput:       recentOutput,
 	}
 }
@@ -190,11 +204,14 @@ type SessionProgressSnapshot struct {
 	CurrentPhase       string
 	CurrentTool        string
 	StatusLine         string
+	ServedModel        string
+	ServedEffort       string
 	RecentOutput       []string // last N lines of assistant text for command center display
 	TurnCount          int
 	TotalCostUSD       float64
 	InputTokens        int
 	OutputTokens       int
+	ReasoningTokens    int
 	ContextWindow      int
 	LastTurnInputTotal int
 }
@@ -204,6 +221,7 @@ type SessionInfo struct {
 	CreatedAt        time.Time
 	CompletedAt      *time.Time
 	StartedAt        *time.Time
+	PendingApproval  *ApprovalPrompt // set while Status is StatusWaitingApproval
 	WorktreePath     string
 	WorktreeName     string
 	Prompt           string
@@ -249,6 +267,11 @@ func (s *Session) ToInfo() SessionInfo {
 		CompletedAt:      s.CompletedAt,
 	}
 
+	if s.pendingApproval != nil {
+		prompt := s.pendingApproval.prompt
+		info.PendingApproval = &prompt
+	}
+
 	if s.Progress != nil {
 		p := s.Progress.Clone()
 		info.Progress = SessionProgressSnapshot{
@@ -258,10 +281,13 @@ func (s *Session) ToInfo() SessionInfo {
 			TotalCostUSD:       p.TotalCostUSD,
 			InputTokens:        p.InputTokens,
 			OutputTokens:       p.OutputTokens,
+			ReasoningTokens:    p.ReasoningTokens,
 			ContextWindow:      p.ContextWindow,
 			LastTurnInputTotal: p.LastTurnInputTotal,
 			LastActivity:       p.LastActivity,
 			StatusLine:         p.StatusLine,
+			ServedModel:        p.ServedModel,
+			ServedEffort:       p.ServedEffort,
 			RecentOutput:       p.RecentOutput,
 		}
 	}
@@ -47,6 +47,13 @@ func FromClaudeEvent(model *SessionModel, event claude.Event) {
 			p.RecentOutput = recent
 		})
 
+	case claude.ToolProgressEvent:
+		model.UpdateTool(e.ID, func(line *OutputLine) {
+			if line.ToolInput == nil && line.ToolState == ToolStateRunning {
+				line.Content = FormatToolInputProgress(e.Name, e.PartialInput)
+			}
+		})
+
 	case claude.ToolCompleteEvent:
 		model.UpdateTool(e.ID, func(line *OutputLine) {
 			if e.Input != nil {
@@ -2,10 +2,17 @@ package sessionmodel
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/displaytext"
 )
 
+// partialPathField matches a complete path-valued string field in a tool
+// input that may still be streaming.
+var partialPathField = regexp.MustCompile(`"(?:file_path|notebook_path|path)"\s*:\s*"((?:[^"\\]|\\.)*)"`)
+
 // FormatToolContent creates a display-friendly content string for a tool call.
 // Ported from bramble/session/event_handler.go:formatToolContent.
 // This structured-output path includes the tool name and uses narrower persisted
@@ -43,3 +50,27 @@ func FormatToolContent(name string, input map[string]interface{}) string {
 	}
 	return name
 }
+
+// FormatToolInputProgress describes a tool call whose input is still
+// streaming, e.g. "Write: writing 2.1KB to main.go…". partialJSON is the
+// input received so far and is usually not valid JSON yet.
+func FormatToolInputProgress(name, partialJSON string) string {
+	size := formatByteSize(len(partialJSON))
+	if m := partialPathField.FindStringSubmatch(partialJSON); m != nil {
+		path := m[1]
+		if unquoted, err := strconv.Unquote(`"` + path + `"`); err == nil {
+			path = unquoted
+		}
+		if base := filepath.Base(path); base != "." && base != "/" {
+			return fmt.Sprintf("%s: writing %s to %s…", name, size, base)
+		}
+	}
+	return fmt.Sprintf("%s: receiving %s of input…", name, size)
+}
+
+func formatByteSize(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%dB", n)
+	}
+	return fmt.Sprintf("%.1fKB", float64(n)/1024)
+}
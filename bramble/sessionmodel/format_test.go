@@ -39,3 +39,15 @@ func TestFormatToolContentTruncatesNonReadBranches(t *testing.T) {
 		}, 46, "Task: summarize summarize summarize summari...")
 	})
 }
+
+func TestFormatToolInputProgress(t *testing.T) {
+	content := strings.Repeat("x", 2100)
+	assert.Equal(t, "Write: writing 2.1KB to main.go…",
+		FormatToolInputProgress("Write", `{"file_path":"/repo/cmd/main.go","content":"`+content))
+	assert.Equal(t, "Write: receiving 15B of input…",
+		FormatToolInputProgress("Write", `{"file_path":"/`))
+	assert.Equal(t, "Bash: receiving 18B of input…",
+		FormatToolInputProgress("Bash", `{"command":"go tes`))
+	assert.Equal(t, `Edit: writing 32B to a"b.go…`,
+		FormatToolInputProgress("Edit", `{"file_path":"/x/a\"b.go","old":`))
+}
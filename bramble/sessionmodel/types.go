@@ -132,6 +132,9 @@ const (
 	StatusCompleted SessionStatus = "completed"
 	StatusFailed    SessionStatus = "failed"
 	StatusStopped   SessionStatus = "stopped"
+	// StatusWaitingApproval is a running session blocked on the user
+	// approving a command the agent wants to run.
+	StatusWaitingApproval SessionStatus = "waiting_approval"
 )
 
 // IsTerminal returns true if the status is a terminal state.
@@ -0,0 +1,239 @@
+// Package webview is a read-only web gateway over a running bramble's control
+// socket, for teammates who would rather watch sessions from a browser than a
+// terminal. It serves an HTML page listing sessions grouped by worktree and a
+// Server-Sent Events stream of each session's pane output, backed by the
+// control protocol's session.list and pane.subscribe. It never sends anything
+// that would drive a session.
+package webview
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bazelment/yoloswe/bramble/control"
+	"github.com/bazelment/yoloswe/bramble/hub"
+)
+
+// requestTimeout bounds a one-shot control request such as session.list.
+const requestTimeout = 10 * time.Second
+
+//go:embed index.html
+var indexHTML string
+
+var indexTmpl = template.Must(template.New("index").Parse(indexHTML))
+
+// Dialer opens a fresh control connection to the bramble being viewed.
+type Dialer func() (control.Conn, error)
+
+// Gateway serves the web view. Browsers log in through the hub's
+// Authenticator, so access uses the same secrets and session cookie as the
+// hub's web UI; the gateway only ever reads.
+type Gateway struct {
+	dial Dialer
+	auth *hub.Authenticator
+}
+
+// New creates a Gateway that reaches bramble through dial and admits
+// browsers logged in through auth.
+func New(dial Dialer, auth *hub.Authenticator) *Gateway {
+	return &Gateway{dial: dial, auth: auth}
+}
+
+// Handler returns the gateway's HTTP handler.
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/login", g.auth.LoginHandler())
+	mux.HandleFunc("GET /{$}", g.requireLogin(g.handleIndex, true))
+	mux.HandleFunc("GET /events", g.requireLogin(g.handleEvents, false))
+	return mux
+}
+
+// requireLogin admits requests with a hub login session. Pages redirect to
+// the login form; the event stream gets 401.
+func (g *Gateway) requireLogin(next http.HandlerFunc, page bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := g.auth.Authenticate(r); ok {
+			next(w, r)
+			return
+		}
+		if page {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}
+}
+
+// worktreeGroup is one worktree's sessions on the index page.
+type worktreeGroup struct {
+	Name     string
+	Sessions []control.SessionSummary
+}
+
+// handleIndex renders the session list, and the live pane of the session
+// named by ?session= when one is selected.
+func (g *Gateway) handleIndex(w http.ResponseWriter, r *http.Request) {
+	var list control.SessionListResult
+	if err := g.request(r.Context(), control.TypeSessionList, nil, &list); err != nil {
+		http.Error(w, "bramble unreachable: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	data := struct {
+		Selected  string
+		Worktrees []worktreeGroup
+	}{
+		Selected:  r.URL.Query().Get("session"),
+		Worktrees: groupByWorktree(list.Sessions),
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTmpl.Execute(w, data); err != nil {
+		slog.Debug("webview: render index", "err", err)
+	}
+}
+
+// groupByWorktree groups sessions by worktree name, both sorted.
+func groupByWorktree(sessions []control.SessionSummary) []worktreeGroup {
+	byName := make(map[string][]control.SessionSummary)
+	for _, s := range sessions {
+		byName[s.WorktreeName] = append(byName[s.WorktreeName], s)
+	}
+	groups := make([]worktreeGroup, 0, len(byName))
+	for name, ss := range byName {
+		sort.Slice(ss, func(i, j int) bool { return ss[i].ID < ss[j].ID })
+		groups = append(groups, worktreeGroup{Name: name, Sessions: ss})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// handleEvents streams a session's pane as Server-Sent Events: a "delta"
+// event carrying each control.PaneDelta as JSON, and a final "failed" event
+// when the pane can no longer be streamed. The subscription lives on its own
+// control connection, closed when the browser goes away.
+func (g *Gateway) handleEvents(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session")
+	if sessionID == "" {
+		http.Error(w, "session is required", http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	conn, err := g.dial()
+	if err != nil {
+		http.Error(w, "bramble unreachable: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer conn.Close()
+
+	sub, err := control.NewRequest(control.TypePaneSubscribe, "sub", control.SubscribeReq{SessionID: sessionID})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sub.SubID = "web"
+	if err := conn.WriteMsg(sub); err != nil {
+		http.Error(w, "bramble unreachable: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// ReadMsg has no deadline; closing the conn is what unblocks it.
+	stop := context.AfterFunc(r.Context(), func() { _ = conn.Close() })
+	defer stop()
+	for {
+		msg, err := conn.ReadMsg()
+		if err != nil {
+			return
+		}
+		switch msg.Type {
+		case control.TypeResponse:
+			if err := msg.DecodeResponse(nil); err != nil {
+				writeEvent(w, "failed", err.Error())
+				flusher.Flush()
+				return
+			}
+		case control.TypePaneDelta:
+			var data bytes.Buffer
+			if err := json.Compact(&data, msg.Payload); err != nil {
+				continue
+			}
+			writeEvent(w, "delta", data.String())
+			flusher.Flush()
+		case control.TypePaneError:
+			var pe control.PaneError
+			_ = msg.DecodePayload(&pe)
+			writeEvent(w, "failed", pe.Error)
+			flusher.Flush()
+			return
+		}
+	}
+}
+
+// writeEvent writes one SSE event. data must not contain newlines.
+func writeEvent(w http.ResponseWriter, event, data string) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, strings.ReplaceAll(data, "\n", " "))
+}
+
+// request performs one control request on a fresh connection and decodes
+// its result into v.
+func (g *Gateway) request(ctx context.Context, typ control.MsgType, payload, v any) error {
+	conn, err := g.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	req, err := control.NewRequest(typ, "web", payload)
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteMsg(req); err != nil {
+		return err
+	}
+	for {
+		resp, err := conn.ReadMsg()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if resp.Type == control.TypeResponse && resp.ID == req.ID {
+			return resp.DecodeResponse(v)
+		}
+	}
+}
+
+// errNoSocket is returned by UnixDialer when no control socket is known.
+var errNoSocket = errors.New("no control socket: pass --control-sock or run inside a bramble session")
+
+// UnixDialer dials the control socket at path.
+func UnixDialer(path string) Dialer {
+	return func() (control.Conn, error) {
+		if path == "" {
+			return nil, errNoSocket
+		}
+		return control.DialUnix(path)
+	}
+}
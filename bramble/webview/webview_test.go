@@ -0,0 +1,187 @@
+package webview
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bazelment/yoloswe/bramble/control"
+	"github.com/bazelment/yoloswe/bramble/hub"
+	"github.com/bazelment/yoloswe/bramble/session"
+	"github.com/bazelment/yoloswe/bramble/tmuxctl"
+)
+
+// fakeRegistry implements control.Registry for an in-test bramble.
+type fakeRegistry struct {
+	targets  map[string]string
+	sessions []session.SessionInfo
+}
+
+func (f *fakeRegistry) GetAllSessions() []session.SessionInfo { return f.sessions }
+func (f *fakeRegistry) ResolveTmuxTarget(id session.SessionID) (string, error) {
+	if t, ok := f.targets[string(id)]; ok {
+		return t, nil
+	}
+	return "", &control.RemoteError{Message: "not found"}
+}
+func (f *fakeRegistry) CapturePaneText(session.SessionID, int) ([]string, error) { return nil, nil }
+func (f *fakeRegistry) StopSession(session.SessionID) error                      { return nil }
+func (f *fakeRegistry) StopAllSessions() []error                                 { return nil }
+func (f *fakeRegistry) ExportCostCSV(io.Writer) error                            { return nil }
+
+// startGateway serves a gateway whose control connections reach an
+// in-process dispatcher over pipes, so the real control.Serve path is used.
+func startGateway(t *testing.T, secret string) *httptest.Server {
+	t.Helper()
+	ctl := tmuxctl.NewFake()
+	ctl.CaptureLines = []string{"> building", "done"}
+	reg := &fakeRegistry{
+		targets: map[string]string{"s1": "@1"},
+		sessions: []session.SessionInfo{
+			{ID: "s1", Type: session.SessionTypeBuilder, Status: session.StatusRunning, WorktreeName: "feature-x"},
+			{ID: "s2", Type: session.SessionTypePlanner, Status: session.StatusIdle, WorktreeName: "main"},
+		},
+	}
+	disp := control.NewDispatcher(reg, ctl)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	dial := func() (control.Conn, error) {
+		server, client := net.Pipe()
+		go func() { _ = control.Serve(ctx, control.NewJSONConn(server), disp) }()
+		return control.NewJSONConn(client), nil
+	}
+	srv := httptest.NewServer(New(dial, hub.NewAuthenticator("").WithSpectatorSecret(secret)).Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// login posts the secret and returns a client holding the session cookie.
+func login(t *testing.T, srv *httptest.Server, secret string) *http.Client {
+	t.Helper()
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	c := &http.Client{Jar: jar}
+	resp, err := c.PostForm(srv.URL+"/login", url.Values{"secret": {secret}})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return c
+}
+
+func TestGatewayRequiresLogin(t *testing.T) {
+	srv := startGateway(t, "secret")
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := noRedirect.Get(srv.URL + "/?token=secret")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusSeeOther, resp.StatusCode, "a token in the URL is not accepted")
+	assert.Equal(t, "/login", resp.Header.Get("Location"))
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/events?session=s1", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = http.PostForm(srv.URL+"/login", url.Values{"secret": {"wrong"}})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = login(t, srv, "secret").Get(srv.URL + "/")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Only the spectator secret logs in; there is no admin login to guess.
+	resp, err = http.PostForm(srv.URL+"/login", url.Values{"secret": {""}})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGatewayIndexListsSessionsByWorktree(t *testing.T) {
+	srv := startGateway(t, "secret")
+
+	resp, err := login(t, srv, "secret").Get(srv.URL + "/?session=s1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	page := string(body)
+
+	feature, main := strings.Index(page, "feature-x"), strings.Index(page, "<h2>main</h2>")
+	require.NotEqual(t, -1, feature, page)
+	require.NotEqual(t, -1, main, page)
+	assert.Less(t, feature, main, "worktrees should be sorted by name")
+	assert.Contains(t, page, `href="/?session=s2"`)
+	assert.Contains(t, page, `new EventSource("/events?session="`)
+}
+
+func TestGatewayStreamsPaneDeltas(t *testing.T) {
+	srv := startGateway(t, "secret")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events?session=s1", nil)
+	require.NoError(t, err)
+	resp, err := login(t, srv, "secret").Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	event, data := readEvent(t, bufio.NewReader(resp.Body))
+	require.Equal(t, "delta", event)
+	var delta control.PaneDelta
+	require.NoError(t, json.Unmarshal([]byte(data), &delta))
+	assert.Equal(t, []string{"> building", "done"}, delta.Lines)
+}
+
+func TestGatewayStreamReportsUnknownSession(t *testing.T) {
+	srv := startGateway(t, "secret")
+
+	resp, err := login(t, srv, "secret").Get(srv.URL + "/events?session=nope")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	event, data := readEvent(t, bufio.NewReader(resp.Body))
+	assert.Equal(t, "failed", event)
+	assert.Contains(t, data, "not found")
+}
+
+// readEvent reads one SSE event, returning its name and data.
+func readEvent(t *testing.T, r *bufio.Reader) (event, data string) {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && event != "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
@@ -19,6 +19,7 @@ package cliapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -66,9 +67,27 @@ type App struct { //nolint:govet // fieldalignment: readability over packing
 }
 
 // RunFunc is the user's entry point. The returned error determines the exit
-// code: nil → 0, ctx.Err() → 130 (interrupted), anything else → 1.
+// code: nil → 0, ctx.Err() → 130 (interrupted), an *ExitError → its Code,
+// anything else → 1.
 type RunFunc func(ctx context.Context, app *App) error
 
+// ExitError carries a specific process exit code out of a RunFunc, so a tool
+// can tell scripts why it stopped (e.g. budget vs. timeout) rather than
+// collapsing every failure to 1.
+type ExitError struct {
+	Err  error
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
 // appKey is the context key for retrieving the App from a context. Used by
 // multi-subcommand CLIs that wrap cobra.ExecuteContext inside cliapp.Run.
 type appKey struct{}
@@ -109,7 +128,7 @@ func FromContext(ctx context.Context) *App {
 //  5. Logs an invocation banner with redacted os.Args[1:].
 //  6. Sets up a context with double-signal force-exit (Ctrl-C twice → 130).
 //  7. Invokes fn. nil → 0; ctx-cancellation → 130; otherwise logs the error
-//     via slog and returns 1.
+//     via slog and returns the *ExitError code if fn returned one, else 1.
 func Run(opts *Options, fn RunFunc) int {
 	if opts == nil {
 		fmt.Fprintln(os.Stderr, "cliapp: Options must not be nil")
@@ -185,6 +204,10 @@ func Run(opts *Options, fn RunFunc) int {
 		return 130
 	default:
 		logger.Error(opts.ToolName+" failed", "error", runErr)
+		var exitErr *ExitError
+		if errors.As(runErr, &exitErr) && exitErr.Code != 0 {
+			return exitErr.Code
+		}
 		return 1
 	}
 }
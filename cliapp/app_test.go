@@ -30,6 +30,7 @@ func TestRun_ExitCodes(t *testing.T) {
 	}{
 		{"success", 0},
 		{"plain_error", 1},
+		{"exit_error", 4},
 		{"ctx_cancelled_via_signal", 130},
 		{"bad_verbosity", 2},
 		{"bad_color", 2},
@@ -132,6 +133,11 @@ func runChildScenario(scenario string) {
 		code = Run(&opts, func(ctx context.Context, app *App) error {
 			return errors.New("scripted failure")
 		})
+	case "exit_error":
+		opts := Options{ToolName: "testtool"}
+		code = Run(&opts, func(ctx context.Context, app *App) error {
+			return fmt.Errorf("wrapped: %w", &ExitError{Code: 4, Err: errors.New("scripted stop")})
+		})
 	case "ctx_cancelled_via_signal":
 		opts := Options{ToolName: "testtool"}
 		code = Run(&opts, func(ctx context.Context, app *App) error {
@@ -4,13 +4,18 @@
 // - Calculator tool with enum operations
 // - Text manipulation tool with optional parameters
 // - Search tool with array filters and default values
+// - Chart tool returning a PNG image block via AddRichTool
 //
 // Run with: bazel run //examples/typed_tools:typed_tools
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"os"
 	"strings"
 
@@ -39,6 +44,12 @@ type SearchParams struct {
 	SortBy     string   `json:"sort_by,omitempty" jsonschema:"description=Sort order,enum=relevance,enum=date,enum=popularity,default=relevance"`
 }
 
+// ChartParams demonstrates a tool whose result is an image rather than text
+type ChartParams struct {
+	Title  string    `json:"title" jsonschema:"required,description=Chart title"`
+	Values []float64 `json:"values" jsonschema:"required,description=Bar heights (non-negative)"`
+}
+
 func calculatorTool(ctx context.Context, p CalculatorParams) (string, error) {
 	var result float64
 	var symbol string
@@ -117,15 +128,68 @@ func searchTool(ctx context.Context, p SearchParams) (string, error) {
 	return result, nil
 }
 
+const (
+	chartWidth  = 320
+	chartHeight = 200
+)
+
+// chartTool renders Values as a bar chart and returns it as a PNG image block
+// alongside a short text caption.
+func chartTool(ctx context.Context, p ChartParams) ([]claude.ToolContent, error) {
+	if len(p.Values) == 0 {
+		return nil, fmt.Errorf("values must not be empty")
+	}
+	maxValue := 0.0
+	for _, v := range p.Values {
+		if v < 0 {
+			return nil, fmt.Errorf("values must be non-negative, got %g", v)
+		}
+		maxValue = max(maxValue, v)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	background := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	bar := color.RGBA{R: 66, G: 133, B: 244, A: 255}
+	for y := 0; y < chartHeight; y++ {
+		for x := 0; x < chartWidth; x++ {
+			img.Set(x, y, background)
+		}
+	}
+
+	slot := chartWidth / len(p.Values)
+	for i, v := range p.Values {
+		height := 0
+		if maxValue > 0 {
+			height = int(v / maxValue * float64(chartHeight-10))
+		}
+		for x := i*slot + 2; x < (i+1)*slot-2; x++ {
+			for y := chartHeight - height; y < chartHeight; y++ {
+				img.Set(x, y, bar)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode chart: %w", err)
+	}
+
+	return []claude.ToolContent{
+		claude.TextContent(fmt.Sprintf("%s (%d bars, max %g)", p.Title, len(p.Values), maxValue)),
+		claude.ImageContent(buf.Bytes(), "image/png"),
+	}, nil
+}
+
 func main() {
 	fmt.Println("=== TypedToolRegistry Demo ===")
 	fmt.Println()
 	fmt.Println("This demo showcases type-safe tool registration using Go generics.")
-	fmt.Println("Three tools are registered with diverse parameter types:")
+	fmt.Println("Four tools are registered with diverse parameter types:")
 	fmt.Println()
 	fmt.Println("1. Calculator: add, subtract, multiply, divide (enum operations)")
 	fmt.Println("2. Text Manipulation: reverse, uppercase, lowercase, title (with optional prefix)")
 	fmt.Println("3. Search: query with max_results, filters array, and sort_by options")
+	fmt.Println("4. Chart: renders values as a bar chart and returns a PNG image")
 	fmt.Println()
 
 	// Create the TypedToolRegistry
@@ -146,7 +210,12 @@ func main() {
 		"Search with customizable parameters. Supports filters, result limits, and sorting options.",
 		searchTool)
 
-	fmt.Println("✅ Registered 3 typed tools successfully!")
+	// Register chart tool; AddRichTool lets the handler return image blocks
+	claude.AddRichTool(registry, "chart",
+		"Render a list of values as a bar chart. Returns a caption and a PNG image.",
+		chartTool)
+
+	fmt.Println("✅ Registered 4 typed tools successfully!")
 	fmt.Println()
 
 	// Display the tool definitions
@@ -207,6 +276,23 @@ func main() {
 	}
 	fmt.Println()
 
+	// Test chart
+	fmt.Println("4. Testing chart tool:")
+	chartResult, err := chartTool(ctx, ChartParams{Title: "Weekly commits", Values: []float64{3, 7, 2, 9, 5}})
+	if err != nil {
+		fmt.Printf("   ❌ Error: %v\n", err)
+	} else {
+		for _, block := range chartResult {
+			switch block.Type {
+			case "image":
+				fmt.Printf("   ✅ Image: %s (%d base64 chars)\n", block.MimeType, len(block.Data))
+			default:
+				fmt.Printf("   ✅ Text: %s\n", block.Text)
+			}
+		}
+	}
+	fmt.Println()
+
 	// Start an interactive session
 	fmt.Println("=== Starting Interactive Claude Session ===")
 	fmt.Println()
@@ -227,10 +313,11 @@ func main() {
 	fmt.Println("💬 Session started! Sending a test prompt...")
 	fmt.Println()
 
-	prompt := `Please demonstrate all three tools:
+	prompt := `Please demonstrate all four tools:
 1. Use calculator to add 42 and 58
 2. Use text_manip to reverse "TypeScript" with prefix "Result: "
 3. Use search to find "Go generics tutorial" with max 3 results and filters ["language:go", "beginner"]
+4. Use chart to plot the values [1, 4, 2, 8] titled "Demo" and describe the image
 
 Just show me the results of each tool.`
 
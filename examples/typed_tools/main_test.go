@@ -1,7 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"image/png"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -137,3 +140,32 @@ func TestSearchToolUsesDefaultsAndOptions(t *testing.T) {
 	require.Contains(t, withOptions, "Sort by: date")
 	require.Contains(t, withOptions, "Filters: language:go, kind:test")
 }
+
+func TestChartToolReturnsPNG(t *testing.T) {
+	t.Parallel()
+
+	blocks, err := chartTool(context.Background(), ChartParams{Title: "Demo", Values: []float64{1, 4, 2}})
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, "text", blocks[0].Type)
+	require.Equal(t, "Demo (3 bars, max 4)", blocks[0].Text)
+	require.Equal(t, "image", blocks[1].Type)
+	require.Equal(t, "image/png", blocks[1].MimeType)
+
+	raw, err := base64.StdEncoding.DecodeString(blocks[1].Data)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(raw))
+	require.NoError(t, err)
+	require.Equal(t, chartWidth, img.Bounds().Dx())
+	require.Equal(t, chartHeight, img.Bounds().Dy())
+}
+
+func TestChartToolRejectsInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	_, err := chartTool(context.Background(), ChartParams{Title: "Empty"})
+	require.ErrorContains(t, err, "must not be empty")
+
+	_, err = chartTool(context.Background(), ChartParams{Title: "Negative", Values: []float64{1, -2}})
+	require.ErrorContains(t, err, "non-negative")
+}
@@ -94,7 +94,12 @@ func runAgentCore(
 
 	// Use the first issue as the "leader" for branch naming
 	leader := issues[0]
-	r.Branch = fixBranchName(leader)
+	branch, err := wtManager.NormalizeBranchName(ctx, fixBranchName(leader))
+	if err != nil {
+		r.Error = fmt.Errorf("branch name: %w", err)
+		return r
+	}
+	r.Branch = branch
 
 	logger.Info("creating worktree",
 		"branch", r.Branch,
@@ -103,7 +108,6 @@ func runAgentCore(
 	)
 
 	// Create worktree (fetch is done once by launchAgents, skip here)
-	var err error
 	r.WorktreePath, err = wtManager.New(ctx, r.Branch, baseBranch, leader.Summary, wt.NewOptions{SkipFetch: true})
 	if err != nil {
 		r.Error = fmt.Errorf("create worktree: %w", err)
@@ -57,6 +57,22 @@ func dispatchStreamEvent(sev agentstream.Event, handler EventHandler, out chan<-
 			default:
 			}
 		}
+	case agentstream.KindToolInputDelta:
+		td := sev.(agentstream.ToolInputDelta)
+		name := td.StreamToolName()
+		callID := td.StreamToolCallID()
+		partial := td.StreamPartialInput()
+		if handler != nil {
+			if dh, ok := handler.(ToolInputDeltaHandler); ok {
+				dh.OnToolInputDelta(name, callID, partial)
+			}
+		}
+		if out != nil {
+			select {
+			case out <- ToolInputDeltaAgentEvent{ToolID: callID, Name: name, PartialJSON: partial}:
+			default:
+			}
+		}
 	case agentstream.KindToolEnd:
 		te := sev.(agentstream.ToolEnd)
 		name := te.StreamToolName()
@@ -85,6 +101,15 @@ func dispatchStreamEvent(sev agentstream.Event, handler EventHandler, out chan<-
 		success := tc.StreamIsSuccess()
 		duration := tc.StreamDuration()
 		cost := tc.StreamCost()
+		var usage AgentUsage
+		if u, ok := sev.(agentstream.Usage); ok {
+			usage = AgentUsage{
+				InputTokens:     int(u.StreamInputTokens()),
+				OutputTokens:    int(u.StreamOutputTokens()),
+				ReasoningTokens: int(u.StreamReasoningTokens()),
+				CostUSD:         cost,
+			}
+		}
 		if handler != nil {
 			handler.OnTurnComplete(turnNum, success, duration, cost)
 		}
@@ -95,6 +120,7 @@ func dispatchStreamEvent(sev agentstream.Event, handler EventHandler, out chan<-
 				Success:    success,
 				DurationMs: duration,
 				CostUSD:    cost,
+				Usage:      usage,
 			}:
 			default:
 			}
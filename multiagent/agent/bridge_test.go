@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
+)
+
+type toolInputDeltaRecorder struct {
+	partials []string
+}
+
+func (r *toolInputDeltaRecorder) OnText(string)                                      {}
+func (r *toolInputDeltaRecorder) OnThinking(string)                                  {}
+func (r *toolInputDeltaRecorder) OnToolStart(string, string, map[string]interface{}) {}
+func (r *toolInputDeltaRecorder) OnToolComplete(string, string, map[string]interface{}, interface{}, bool) {
+}
+func (r *toolInputDeltaRecorder) OnTurnComplete(int, bool, int64, float64) {}
+func (r *toolInputDeltaRecorder) OnError(error, string)                    {}
+
+func (r *toolInputDeltaRecorder) OnToolInputDelta(name, id, partialJSON string) {
+	r.partials = append(r.partials, name+"/"+id+":"+partialJSON)
+}
+
+func TestDispatchStreamEvent_ToolInputDelta(t *testing.T) {
+	handler := &toolInputDeltaRecorder{}
+	out := make(chan AgentEvent, 1)
+
+	ev := claude.ToolProgressEvent{
+		ID:           "toolu_1",
+		Name:         "Write",
+		PartialInput: `{"file_path":"main.go","content":"pack`,
+		InputChunk:   `"pack`,
+	}
+	if dispatchStreamEvent(ev, handler, out) {
+		t.Fatal("tool input delta reported as turn complete")
+	}
+
+	want := `Write/toolu_1:{"file_path":"main.go","content":"pack`
+	if len(handler.partials) != 1 || handler.partials[0] != want {
+		t.Errorf("handler partials = %q, want [%q]", handler.partials, want)
+	}
+	got, ok := (<-out).(ToolInputDeltaAgentEvent)
+	if !ok {
+		t.Fatal("expected ToolInputDeltaAgentEvent on out")
+	}
+	if got.ToolID != "toolu_1" || got.Name != "Write" || got.PartialJSON != ev.PartialInput {
+		t.Errorf("event = %+v", got)
+	}
+
+	// Handlers without the optional interface are unaffected.
+	dispatchStreamEvent(ev, &retryAbortRecorder{}, nil)
+}
+
+func TestDispatchStreamEvent_TurnCompleteUsage(t *testing.T) {
+	out := make(chan AgentEvent, 1)
+
+	ev := codex.TurnCompletedEvent{
+		TurnIndex: 2,
+		Success:   true,
+		Usage: codex.TurnUsage{
+			InputTokens:           900,
+			OutputTokens:          300,
+			ReasoningOutputTokens: 120,
+		},
+	}
+	if !dispatchStreamEvent(ev, nil, out) {
+		t.Fatal("turn complete not reported")
+	}
+
+	got, ok := (<-out).(TurnCompleteAgentEvent)
+	if !ok {
+		t.Fatal("expected TurnCompleteAgentEvent on out")
+	}
+	want := AgentUsage{InputTokens: 900, OutputTokens: 300, ReasoningTokens: 120}
+	if got.TurnNumber != 2 || got.Usage != want {
+		t.Errorf("event = %+v, want turn 2 with usage %+v", got, want)
+	}
+}
@@ -78,31 +78,7 @@ func (p *CodexProvider) Execute(ctx context.Context, prompt string, wtCtx *wt.Wo
 	}
 	p.mu.Unlock()
 
-	// Build thread options.
-	// Only pass an explicit model if the caller overrode the default;
-	// Claude-specific aliases (haiku, sonnet, opus, fable) are not valid for codex
-	// and should not be forwarded — let codex use its own configured default.
-	var threadOpts []codex.ThreadOption
-	if cfg.Model != "" && !isClaudeModelAlias(cfg.Model) {
-		threadOpts = append(threadOpts, codex.WithModel(cfg.Model))
-	}
-	if policy, ok := codexApprovalPolicyForPermissionMode(cfg.PermissionMode); ok {
-		threadOpts = append(threadOpts, codex.WithApprovalPolicy(policy))
-	}
-	// When no explicit permission mode is set (empty/"default"), don't override
-	// codex's own default approval policy — callers that need auto-approve should
-	// set PermissionMode to "bypass" explicitly.
-	if cfg.WorkDir != "" {
-		threadOpts = append(threadOpts, codex.WithWorkDir(cfg.WorkDir))
-	}
-	// For bypass mode (builders), disable sandboxing entirely so codex
-	// can write files and run commands. The "workspace-write" mode still
-	// uses bubblewrap, which may fail in container/VM environments that
-	// lack network namespace permissions. Since the delegator runs in a
-	// controlled environment, full access is appropriate.
-	if strings.ToLower(strings.TrimSpace(cfg.PermissionMode)) == "bypass" {
-		threadOpts = append(threadOpts, codex.WithSandbox("danger-full-access"))
-	}
+	threadOpts := codexThreadOptions(cfg)
 
 	// Create or resume thread and execute.
 	var thread *codex.Thread
@@ -164,6 +140,40 @@ func (p *CodexProvider) Close() error {
 	return nil
 }
 
+// codexThreadOptions builds the codex thread options derived from the
+// provider-neutral ExecuteConfig, so the sandbox and approval wiring can be
+// unit-tested without spawning the codex subprocess.
+func codexThreadOptions(cfg ExecuteConfig) []codex.ThreadOption {
+	// Only pass an explicit model if the caller overrode the default;
+	// Claude-specific aliases (haiku, sonnet, opus, fable) are not valid for codex
+	// and should not be forwarded — let codex use its own configured default.
+	var threadOpts []codex.ThreadOption
+	if cfg.Model != "" && !isClaudeModelAlias(cfg.Model) {
+		threadOpts = append(threadOpts, codex.WithModel(cfg.Model))
+	}
+	if policy, ok := codexApprovalPolicyForPermissionMode(cfg.PermissionMode); ok {
+		threadOpts = append(threadOpts, codex.WithApprovalPolicy(policy))
+	}
+	// When no explicit permission mode is set (empty/"default"), don't override
+	// codex's own default approval policy — callers that need auto-approve should
+	// set PermissionMode to "bypass" explicitly.
+	if cfg.WorkDir != "" {
+		threadOpts = append(threadOpts, codex.WithWorkDir(cfg.WorkDir))
+	}
+	// An explicit sandbox level wins. Otherwise, for bypass mode
+	// (builders), disable sandboxing entirely so codex can write files and
+	// run commands. The "workspace-write" mode still uses bubblewrap, which
+	// may fail in container/VM environments that lack network namespace
+	// permissions. Since the delegator runs in a controlled environment,
+	// full access is appropriate.
+	if cfg.Sandbox != "" {
+		threadOpts = append(threadOpts, codex.WithSandbox(cfg.Sandbox))
+	} else if strings.ToLower(strings.TrimSpace(cfg.PermissionMode)) == "bypass" {
+		threadOpts = append(threadOpts, codex.WithSandbox(codex.SandboxDangerFullAccess))
+	}
+	return threadOpts
+}
+
 // codexTurnOptions builds the per-turn codex options derived from the
 // provider-neutral ExecuteConfig. Extracted so the effort wiring can be
 // unit-tested without spawning the codex subprocess.
@@ -199,6 +209,8 @@ func codexResultToAgentResult(r *codex.TurnResult) *AgentResult {
 	}
 	return &AgentResult{
 		Text:       r.FullText,
+		Model:      r.Model,
+		Effort:     r.Effort,
 		Success:    r.Success,
 		Error:      r.Error,
 		DurationMs: r.DurationMs,
@@ -206,6 +218,7 @@ func codexResultToAgentResult(r *codex.TurnResult) *AgentResult {
 			InputTokens:     int(r.Usage.InputTokens),
 			OutputTokens:    int(r.Usage.OutputTokens),
 			CacheReadTokens: int(r.Usage.CachedInputTokens),
+			ReasoningTokens: int(r.Usage.ReasoningOutputTokens),
 		},
 	}
 }
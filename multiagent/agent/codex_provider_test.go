@@ -282,9 +282,10 @@ func TestCodexResultToAgentResult_MapsCachedInputTokens(t *testing.T) {
 		FullText: "ok",
 		Success:  true,
 		Usage: codex.TurnUsage{
-			InputTokens:       120,
-			CachedInputTokens: 33,
-			OutputTokens:      45,
+			InputTokens:           120,
+			CachedInputTokens:     33,
+			OutputTokens:          45,
+			ReasoningOutputTokens: 12,
 		},
 	})
 
@@ -300,6 +301,19 @@ func TestCodexResultToAgentResult_MapsCachedInputTokens(t *testing.T) {
 	if result.Usage.OutputTokens != 45 {
 		t.Fatalf("OutputTokens = %d, want 45", result.Usage.OutputTokens)
 	}
+	if result.Usage.ReasoningTokens != 12 {
+		t.Fatalf("ReasoningTokens = %d, want 12", result.Usage.ReasoningTokens)
+	}
+}
+
+func TestCodexResultToAgentResult_MapsServedModel(t *testing.T) {
+	t.Parallel()
+
+	result := codexResultToAgentResult(&codex.TurnResult{Model: "gpt-5.4-mini", Effort: "low"})
+
+	if result.Model != "gpt-5.4-mini" || result.Effort != "low" {
+		t.Fatalf("Model, Effort = %q, %q, want gpt-5.4-mini, low", result.Model, result.Effort)
+	}
 }
 
 func TestCodexTurnOptions_NoEffortYieldsNoOptions(t *testing.T) {
@@ -510,6 +524,33 @@ func TestProvider_ValidateGate(t *testing.T) {
 	}
 }
 
+func TestCodexThreadOptions_Sandbox(t *testing.T) {
+	t.Parallel()
+
+	sandboxOf := func(cfg ExecuteConfig) interface{} {
+		var tc codex.ThreadConfig
+		for _, opt := range codexThreadOptions(cfg) {
+			opt(&tc)
+		}
+		return tc.Sandbox
+	}
+	assert.Nil(t, sandboxOf(ExecuteConfig{}), "no sandbox and no bypass => codex default")
+	assert.Equal(t, codex.SandboxDangerFullAccess, sandboxOf(ExecuteConfig{PermissionMode: "bypass"}))
+	assert.Equal(t, codex.SandboxReadOnly, sandboxOf(ExecuteConfig{PermissionMode: "plan", Sandbox: codex.SandboxReadOnly}))
+	assert.Equal(t, codex.SandboxWorkspaceWrite, sandboxOf(ExecuteConfig{PermissionMode: "bypass", Sandbox: codex.SandboxWorkspaceWrite}),
+		"an explicit sandbox overrides the bypass default")
+}
+
+func TestCodexProvider_RejectsUnknownSandbox(t *testing.T) {
+	t.Parallel()
+
+	p := NewCodexProvider()
+	defer func() { _ = p.Close() }()
+	_, err := p.Execute(t.Context(), "irrelevant", nil, WithProviderSandbox("full"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown codex sandbox level")
+}
+
 func TestCodexApprovalPolicyForPermissionMode(t *testing.T) {
 	t.Parallel()
 
@@ -2,7 +2,9 @@
 package agent
 
 import (
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 )
 
@@ -29,17 +31,23 @@ func (r AgentRole) IsLongRunning() bool {
 
 // AgentConfig configures an agent instance.
 type AgentConfig struct {
-	Logger          *slog.Logger
+	Logger *slog.Logger
+	// OnRetry, if set, is called before each transient-error retry.
+	OnRetry         func(RetryAgentEvent)
 	Role            AgentRole
 	Model           string
 	SystemPrompt    string
 	WorkDir         string
 	SessionDir      string
 	AllowedTools    []string
+	RetryBackoff    BackoffPolicy
 	MaxTurnsPerTask int
 	TurnTimeout     time.Duration
 	BudgetUSD       float64
 	TaskTimeout     time.Duration
+	// TransientRetries is how many times an ephemeral task is retried after
+	// a transient provider error (overload, rate limit). Zero disables retry.
+	TransientRetries int
 }
 
 // DefaultConfig returns a config with sensible defaults.
@@ -84,12 +92,23 @@ type SwarmConfig struct {
 	BuilderModel      string
 	ReviewerModel     string
 
+	// Pipeline lists the sub-agent stages the Planner runs, in order
+	// (e.g. ["builder", "reviewer"] to skip design). Empty means
+	// DefaultPipeline. See ParsePipeline for the accepted shapes.
+	Pipeline []string
+
 	// TotalBudgetUSD is the total cost budget across all agents.
 	TotalBudgetUSD float64
 
 	// MaxIterations prevents infinite loops.
 	MaxIterations int
 
+	// MaxParallelBuilders bounds how many Builders run at once when the
+	// Planner hands off independent subtasks together. Each parallel
+	// Builder works in its own worktree. 0 or 1 builds one subtask at a
+	// time in WorkDir.
+	MaxParallelBuilders int
+
 	// EnableCheckpointing enables session state persistence for error recovery.
 	EnableCheckpointing bool
 
@@ -103,6 +122,45 @@ type SwarmConfig struct {
 	StallTimeout time.Duration
 }
 
+// DefaultPipeline is the stage order used when SwarmConfig.Pipeline is empty.
+var DefaultPipeline = []AgentRole{RoleDesigner, RoleBuilder, RoleReviewer}
+
+// ParsePipeline validates a stage list and returns it as roles. An empty
+// list yields DefaultPipeline. Stage names are the sub-agent roles
+// (designer, builder, reviewer); each may appear once, builder is required,
+// designer must come before builder, and reviewer must come after it.
+func ParsePipeline(stages []string) ([]AgentRole, error) {
+	if len(stages) == 0 {
+		return append([]AgentRole(nil), DefaultPipeline...), nil
+	}
+	pipeline := make([]AgentRole, 0, len(stages))
+	pos := make(map[AgentRole]int, len(stages))
+	for i, name := range stages {
+		role := AgentRole(strings.ToLower(strings.TrimSpace(name)))
+		switch role {
+		case RoleDesigner, RoleBuilder, RoleReviewer:
+		default:
+			return nil, fmt.Errorf("unknown pipeline stage %q (valid stages: designer, builder, reviewer)", name)
+		}
+		if _, dup := pos[role]; dup {
+			return nil, fmt.Errorf("pipeline stage %q listed more than once", role)
+		}
+		pos[role] = i
+		pipeline = append(pipeline, role)
+	}
+	build, ok := pos[RoleBuilder]
+	if !ok {
+		return nil, fmt.Errorf("pipeline must include the builder stage")
+	}
+	if d, ok := pos[RoleDesigner]; ok && d > build {
+		return nil, fmt.Errorf("pipeline stage designer must come before builder")
+	}
+	if r, ok := pos[RoleReviewer]; ok && r < build {
+		return nil, fmt.Errorf("pipeline stage reviewer must come after builder")
+	}
+	return pipeline, nil
+}
+
 // DefaultSwarmConfig returns a swarm config with sensible defaults.
 func DefaultSwarmConfig() SwarmConfig {
 	return SwarmConfig{
@@ -1,6 +1,8 @@
 package agent
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -132,3 +134,40 @@ func TestDefaultSwarmConfig(t *testing.T) {
 		t.Errorf("MaxIterations = %v, want 50", config.MaxIterations)
 	}
 }
+
+func TestParsePipeline(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		stages  []string
+		want    []AgentRole
+	}{
+		{name: "default", want: DefaultPipeline},
+		{name: "skip design", stages: []string{"builder", "reviewer"}, want: []AgentRole{RoleBuilder, RoleReviewer}},
+		{name: "builder only", stages: []string{"Builder"}, want: []AgentRole{RoleBuilder}},
+		{name: "full", stages: []string{"designer", " builder ", "reviewer"}, want: DefaultPipeline},
+		{name: "unknown", stages: []string{"builder", "tester"}, wantErr: `unknown pipeline stage "tester"`},
+		{name: "planner is not a stage", stages: []string{"planner", "builder"}, wantErr: "unknown pipeline stage"},
+		{name: "duplicate", stages: []string{"builder", "reviewer", "builder"}, wantErr: "more than once"},
+		{name: "no builder", stages: []string{"designer", "reviewer"}, wantErr: "must include the builder"},
+		{name: "review before build", stages: []string{"reviewer", "builder"}, wantErr: "reviewer must come after builder"},
+		{name: "design after build", stages: []string{"builder", "designer"}, wantErr: "designer must come before builder"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePipeline(tt.stages)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParsePipeline(%v) error = %v, want %q", tt.stages, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePipeline(%v) error = %v", tt.stages, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePipeline(%v) = %v, want %v", tt.stages, got, tt.want)
+			}
+		})
+	}
+}
@@ -322,6 +322,16 @@ func (p *GeminiLongRunningProvider) Stop() error {
 	return nil
 }
 
+// StopWithTimeout is Stop, except that an in-flight turn is cancelled and
+// allowed to flush its final output first; see acp.Client.StopWithTimeout.
+// drained reports whether that finished before ctx ended.
+func (p *GeminiLongRunningProvider) StopWithTimeout(ctx context.Context) (drained bool, err error) {
+	if p.longRunningClient != nil {
+		return p.longRunningClient.StopWithTimeout(ctx)
+	}
+	return true, nil
+}
+
 // Close stops the long-running provider's ACP client and closes the event channel.
 func (p *GeminiLongRunningProvider) Close() error {
 	p.mu.Lock()
@@ -378,6 +388,7 @@ func acpEventToAgentEvent(ev acp.Event) AgentEvent {
 		return nil
 	case acp.TurnCompleteEvent:
 		return TurnCompleteAgentEvent{
+			StopReason: e.StopReason,
 			TurnNumber: e.TurnNumber,
 			Success:    e.Success,
 			DurationMs: e.DurationMs,
@@ -415,6 +426,7 @@ func acpResultToAgentResult(r *acp.TurnResult) *AgentResult {
 	return &AgentResult{
 		Text:       r.FullText,
 		Thinking:   r.Thinking,
+		StopReason: r.StopReason,
 		Success:    r.Success,
 		Error:      r.Error,
 		DurationMs: r.DurationMs,
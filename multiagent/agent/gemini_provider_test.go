@@ -340,3 +340,21 @@ func TestGeminiLongRunningProvider_CloseCleanupBothClients(t *testing.T) {
 		t.Error("embedded GeminiProvider.client should be nil after Close()")
 	}
 }
+
+// TestACPStopReasonPropagates verifies the ACP stop reason reaches both the
+// turn-complete event and the AgentResult.
+func TestACPStopReasonPropagates(t *testing.T) {
+	ev := acpEventToAgentEvent(acp.TurnCompleteEvent{StopReason: acp.StopReasonMaxTokens, TurnNumber: 2})
+	tc, ok := ev.(TurnCompleteAgentEvent)
+	if !ok {
+		t.Fatalf("expected TurnCompleteAgentEvent, got %T", ev)
+	}
+	if tc.StopReason != acp.StopReasonMaxTokens || tc.TurnNumber != 2 {
+		t.Errorf("TurnCompleteAgentEvent = %+v", tc)
+	}
+
+	result := acpResultToAgentResult(&acp.TurnResult{StopReason: acp.StopReasonRefusal})
+	if result.StopReason != acp.StopReasonRefusal {
+		t.Errorf("AgentResult.StopReason = %q, want %q", result.StopReason, acp.StopReasonRefusal)
+	}
+}
@@ -204,6 +204,17 @@ func (r *ModelRegistry) NextModel(currentID string) AgentModel {
 	return r.filtered[0]
 }
 
+// FirstAvailableModel returns the first model whose provider is installed
+// and enabled, or false when no provider is usable.
+func (r *ModelRegistry) FirstAvailableModel() (AgentModel, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.filtered) == 0 {
+		return AgentModel{}, false
+	}
+	return r.filtered[0], true
+}
+
 // HasProvider returns true if at least one model from the given provider
 // is in the filtered list.
 func (r *ModelRegistry) HasProvider(provider string) bool {
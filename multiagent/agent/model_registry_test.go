@@ -178,6 +178,19 @@ func TestModelRegistry_FirstModelForProvider(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestModelRegistry_FirstAvailableModel(t *testing.T) {
+	avail := newTestAvailability(map[string]bool{ProviderCodex: true})
+	reg := NewModelRegistry(avail, nil)
+
+	m, ok := reg.FirstAvailableModel()
+	require.True(t, ok)
+	assert.Equal(t, ProviderCodex, m.Provider)
+
+	reg.Rebuild(newTestAvailability(nil), nil)
+	_, ok = reg.FirstAvailableModel()
+	assert.False(t, ok, "no installed provider => no available model")
+}
+
 func TestModelByID_Global(t *testing.T) {
 	m, ok := ModelByID("opus")
 	require.True(t, ok)
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/codex"
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/llmendpoint"
 	"github.com/bazelment/yoloswe/wt"
 )
@@ -17,6 +18,9 @@ type AgentResult struct {
 	Text                string
 	Thinking            string
 	SessionID           string
+	StopReason          string // why the turn ended, when the provider reports it (ACP: "end_turn", "max_tokens", ...)
+	Model               string // model that served the turn, when the provider reports it (codex); may differ from the requested one
+	Effort              string // reasoning effort that served the turn (codex); empty means the model default
 	ContentBlocks       []AgentContentBlock
 	Usage               AgentUsage
 	DurationMs          int64
@@ -52,6 +56,9 @@ type AgentUsage struct {
 	InputTokens     int
 	OutputTokens    int
 	CacheReadTokens int
+	// ReasoningTokens is the part of OutputTokens spent on reasoning. Only
+	// codex reports it; other providers leave it at 0.
+	ReasoningTokens int
 	CostUSD         float64
 }
 
@@ -59,12 +66,14 @@ type AgentUsage struct {
 type AgentEventType int
 
 const (
-	AgentEventText         AgentEventType = iota
-	AgentEventThinking                    // Chain-of-thought / reasoning
-	AgentEventToolStart                   // Tool invocation started
-	AgentEventToolComplete                // Tool invocation completed
-	AgentEventTurnComplete                // Turn finished
-	AgentEventError                       // Error occurred
+	AgentEventText           AgentEventType = iota
+	AgentEventThinking                      // Chain-of-thought / reasoning
+	AgentEventToolStart                     // Tool invocation started
+	AgentEventToolComplete                  // Tool invocation completed
+	AgentEventTurnComplete                  // Turn finished
+	AgentEventError                         // Error occurred
+	AgentEventRetry                         // Turn retried after a transient error
+	AgentEventToolInputDelta                // Tool input still streaming
 )
 
 // AgentEvent is the provider-agnostic event interface for streaming.
@@ -95,6 +104,18 @@ type ToolStartAgentEvent struct {
 
 func (e ToolStartAgentEvent) AgentEventType() AgentEventType { return AgentEventToolStart }
 
+// ToolInputDeltaAgentEvent is emitted while a tool call's input is still
+// streaming, for providers that expose it (claude). PartialJSON is the input
+// accumulated so far and is usually not valid JSON; the parsed input arrives
+// with ToolCompleteAgentEvent.
+type ToolInputDeltaAgentEvent struct {
+	ToolID      string
+	Name        string
+	PartialJSON string
+}
+
+func (e ToolInputDeltaAgentEvent) AgentEventType() AgentEventType { return AgentEventToolInputDelta }
+
 // ToolCompleteAgentEvent is emitted when a tool invocation finishes.
 type ToolCompleteAgentEvent struct {
 	Result  interface{}
@@ -108,6 +129,10 @@ func (e ToolCompleteAgentEvent) AgentEventType() AgentEventType { return AgentEv
 
 // TurnCompleteAgentEvent is emitted when a turn finishes.
 type TurnCompleteAgentEvent struct {
+	StopReason string // see AgentResult.StopReason
+	// Usage is the turn's token counts when the provider reports them on
+	// the turn-complete event (agentstream.Usage); zero otherwise.
+	Usage      AgentUsage
 	DurationMs int64
 	CostUSD    float64
 	TurnNumber int
@@ -142,6 +167,13 @@ type SessionInitHandler interface {
 	OnSessionInit(sessionID string)
 }
 
+// ToolInputDeltaHandler is an optional EventHandler extension that receives
+// the partial JSON input of a tool call while it streams, so UIs can show
+// progress on large inputs (e.g. a long file write).
+type ToolInputDeltaHandler interface {
+	OnToolInputDelta(name, id, partialJSON string)
+}
+
 // RetryHandler is an optional EventHandler extension fired before each
 // tool-error retry turn and when the retry loop stops with an
 // unresolved tool error still present.
@@ -193,6 +225,7 @@ type ExecuteOption func(*ExecuteConfig)
 // ExecuteConfig holds execution configuration.
 type ExecuteConfig struct {
 	EventHandler        EventHandler
+	OnTransientRetry    func(RetryAgentEvent)
 	LLMEndpoint         llmendpoint.Endpoint
 	Logger              *slog.Logger
 	Model               string
@@ -200,6 +233,7 @@ type ExecuteConfig struct {
 	WorkDir             string
 	SystemPrompt        string
 	PermissionMode      string
+	Sandbox             string
 	ResumeSessionID     string
 	MaxTurns            int
 	MaxToolErrorRetries int
@@ -248,6 +282,15 @@ func WithProviderPermissionMode(mode string) ExecuteOption {
 	return func(c *ExecuteConfig) { c.PermissionMode = mode }
 }
 
+// WithProviderSandbox sets the sandbox level ("read-only",
+// "workspace-write" or "danger-full-access") for providers that sandbox
+// the agent's commands. Codex honors it; other providers ignore it. An
+// explicit level takes precedence over the sandbox a permission mode
+// implies.
+func WithProviderSandbox(level string) ExecuteOption {
+	return func(c *ExecuteConfig) { c.Sandbox = level }
+}
+
 // WithProviderEventHandler sets the event handler for a provider execution.
 func WithProviderEventHandler(h EventHandler) ExecuteOption {
 	return func(c *ExecuteConfig) { c.EventHandler = h }
@@ -339,8 +382,13 @@ func applyOptions(opts []ExecuteOption) ExecuteConfig {
 // validate enforces invariants that every Provider.Execute call relies on,
 // regardless of whether the provider rebuilds the underlying session per
 // call (claude/cursor) or binds the endpoint at first Execute (codex/gemini).
-// Currently it only gates on LLMEndpoint, but the seam is intentional: any
-// future cross-provider invariant lands here once instead of in four places.
+// It gates on LLMEndpoint and the sandbox level; any future cross-provider
+// invariant lands here once instead of in four places.
 func (c ExecuteConfig) validate() error {
+	if c.Sandbox != "" {
+		if err := codex.ValidateSandboxLevel(c.Sandbox); err != nil {
+			return err
+		}
+	}
 	return c.LLMEndpoint.Validate()
 }
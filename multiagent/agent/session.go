@@ -532,11 +532,11 @@ func (e *EphemeralSession) executeWithClaude(ctx context.Context, prompt string)
 	if len(e.config.AllowedTools) > 0 {
 		opts = append(opts, claude.WithAllowedTools(e.config.AllowedTools...))
 	}
-	session := claude.NewSession(opts...)
 
-	runner := &claudeSessionRunner{session: session}
 	start := time.Now()
-	result, execResult, err := runSessionWithFileTracking(ctx, runner, prompt, log)
+	result, execResult, err := e.runClaudeWithRetry(ctx, func() sessionRunner {
+		return &claudeSessionRunner{session: claude.NewSession(opts...)}
+	}, prompt, log, taskID)
 	if err != nil {
 		log.Info("task failed",
 			"taskID", taskID,
@@ -563,6 +563,55 @@ func (e *EphemeralSession) executeWithClaude(ctx context.Context, prompt string)
 	return ClaudeResultToAgentResult(result), execResult, taskID, nil
 }
 
+// runClaudeWithRetry runs prompt on a fresh runner, retrying transient
+// failures. Each attempt gets a fresh session: the ephemeral task carries no
+// conversation state worth resuming. An attempt that already streamed output
+// is not retried, since resending the whole prompt would repeat work the
+// agent may have half-applied to the worktree.
+func (e *EphemeralSession) runClaudeWithRetry(ctx context.Context, newRunner func() sessionRunner, prompt string, log *slog.Logger, taskID string) (*claude.TurnResult, *ExecuteResult, error) {
+	var (
+		result     *claude.TurnResult
+		execResult *ExecuteResult
+		streamed   bool
+	)
+	retrier := e.retrier()
+	retrier.isRetryable = func(err error) bool { return !streamed && IsTransient(err) }
+	err := retrier.do(ctx, ProviderClaude, e.onRetry(log, taskID), func() error {
+		runner := &outputWatchRunner{sessionRunner: newRunner()}
+		var err error
+		result, execResult, err = runSessionWithFileTracking(ctx, runner, prompt, log)
+		streamed = runner.streamed.Load()
+		return err
+	})
+	return result, execResult, err
+}
+
+// outputWatchRunner records whether a turn streamed any output (text,
+// thinking, or a tool call) before it ended.
+type outputWatchRunner struct {
+	sessionRunner
+	streamed atomic.Bool
+}
+
+func (r *outputWatchRunner) Events() <-chan claude.Event {
+	in := r.sessionRunner.Events()
+	if in == nil {
+		return nil
+	}
+	out := make(chan claude.Event, cap(in))
+	go func() {
+		defer close(out)
+		for ev := range in {
+			switch ev.(type) {
+			case claude.TextEvent, claude.ThinkingEvent, claude.ToolStartEvent:
+				r.streamed.Store(true)
+			}
+			out <- ev
+		}
+	}()
+	return out
+}
+
 // executeWithProvider runs the prompt using a non-Claude provider
 // and detects file changes via git diff.
 func (e *EphemeralSession) executeWithProvider(ctx context.Context, prompt, providerName string) (*AgentResult, *ExecuteResult, string, error) {
@@ -584,6 +633,9 @@ func (e *EphemeralSession) executeWithProvider(ctx context.Context, prompt, prov
 	if err != nil {
 		return nil, nil, "", fmt.Errorf("create provider: %w", err)
 	}
+	if e.config.TransientRetries > 0 {
+		provider = WithRetry(e.config.TransientRetries, e.config.RetryBackoff, nil)(provider)
+	}
 	defer provider.Close()
 
 	log.Info("sending message",
@@ -599,6 +651,7 @@ func (e *EphemeralSession) executeWithProvider(ctx context.Context, prompt, prov
 		WithProviderModel(e.config.Model),
 		WithProviderWorkDir(e.config.WorkDir),
 		WithProviderPermissionMode("bypass"),
+		WithProviderRetryObserver(e.onRetry(log, taskID)),
 	)
 	if err != nil {
 		log.Info("task failed",
@@ -631,6 +684,32 @@ func (e *EphemeralSession) executeWithProvider(ctx context.Context, prompt, prov
 	return result, execResult, taskID, nil
 }
 
+// retrier returns the transient-error retry policy for this session's tasks.
+// A zero TransientRetries yields a retrier that never retries.
+func (e *EphemeralSession) retrier() transientRetrier {
+	return transientRetrier{
+		backoff:    e.config.RetryBackoff,
+		maxRetries: e.config.TransientRetries,
+	}
+}
+
+// onRetry logs a transient retry and forwards it to AgentConfig.OnRetry.
+func (e *EphemeralSession) onRetry(log *slog.Logger, taskID string) func(RetryAgentEvent) {
+	return func(ev RetryAgentEvent) {
+		log.Warn("transient error, retrying task",
+			"taskID", taskID,
+			"reason", ev.Reason,
+			"attempt", ev.Attempt,
+			"maxRetries", ev.MaxRetries,
+			"delay", ev.Delay,
+			"error", ev.Err,
+		)
+		if e.config.OnRetry != nil {
+			e.config.OnRetry(ev)
+		}
+	}
+}
+
 // detectFileChangesGit detects files created or modified by the agent
 // using both `git diff --name-status <baseRef>` (for tracked changes) and
 // `git ls-files --others --exclude-standard` (for untracked new files).
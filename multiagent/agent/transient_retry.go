@@ -0,0 +1,364 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bazelment/yoloswe/wt"
+)
+
+// BackoffPolicy computes how long to wait before each transient-error retry.
+// The delay starts at Initial and grows by Multiplier per attempt, capped at
+// Max.
+type BackoffPolicy struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// DefaultBackoffPolicy waits 2s, 4s, 8s, ... up to one minute between retries.
+var DefaultBackoffPolicy = BackoffPolicy{
+	Initial:    2 * time.Second,
+	Max:        time.Minute,
+	Multiplier: 2,
+}
+
+// Delay returns the wait before retry number attempt (1-based).
+func (b BackoffPolicy) Delay(attempt int) time.Duration {
+	if attempt < 1 || b.Initial <= 0 {
+		return 0
+	}
+	mult := b.Multiplier
+	if mult < 1 {
+		mult = 1
+	}
+	d := float64(b.Initial)
+	for i := 1; i < attempt; i++ {
+		d *= mult
+		if b.Max > 0 && d >= float64(b.Max) {
+			return b.Max
+		}
+	}
+	if b.Max > 0 && time.Duration(d) > b.Max {
+		return b.Max
+	}
+	return time.Duration(d)
+}
+
+// RetryAgentEvent is emitted before a turn is retried after a transient
+// provider error (overload, rate limit, dropped stream).
+type RetryAgentEvent struct {
+	Err        error
+	Provider   string
+	Reason     string // stable transient reason, see TransientReason
+	Attempt    int    // 1-based retry number
+	MaxRetries int
+	Delay      time.Duration
+}
+
+func (e RetryAgentEvent) AgentEventType() AgentEventType { return AgentEventRetry }
+
+// WithProviderRetryObserver registers a callback fired before each transient
+// retry performed by a WithRetry-wrapped provider during this execution.
+func WithProviderRetryObserver(fn func(RetryAgentEvent)) ExecuteOption {
+	return func(c *ExecuteConfig) { c.OnTransientRetry = fn }
+}
+
+// ProviderMiddleware wraps a Provider with additional behavior.
+type ProviderMiddleware func(Provider) Provider
+
+// WithRetry returns middleware that retries Execute (and SendMessage, for a
+// LongRunningProvider) when the error is transient, waiting per backoff
+// between attempts. isRetryable defaults to IsTransient, which covers the
+// claude, codex, and acp transient classifiers. When a failed Execute still
+// reported a session ID, the retry resumes that session instead of starting
+// over; SendMessage retries naturally continue the live session. An Execute
+// attempt that already streamed output (text, thinking, or a tool call) is
+// not retried, since resending the prompt would repeat work the agent may
+// have half-applied.
+//
+// Each retry emits a RetryAgentEvent on the wrapper's Events channel and to
+// the WithProviderRetryObserver callback, if set. Once maxRetries is spent
+// the last error is returned unchanged.
+func WithRetry(maxRetries int, backoff BackoffPolicy, isRetryable func(error) bool) ProviderMiddleware {
+	if isRetryable == nil {
+		isRetryable = IsTransient
+	}
+	return func(inner Provider) Provider {
+		rp := &retryProvider{
+			inner: inner,
+			retrier: transientRetrier{
+				backoff:     backoff,
+				isRetryable: isRetryable,
+				maxRetries:  maxRetries,
+			},
+			out: make(chan AgentEvent, 100),
+		}
+		rp.startForwarding()
+		if lr, ok := inner.(LongRunningProvider); ok {
+			return &retryLongRunningProvider{retryProvider: rp, lr: lr}
+		}
+		return rp
+	}
+}
+
+// transientRetrier runs a call, retrying it while it fails with a retryable
+// error and retries remain.
+type transientRetrier struct {
+	isRetryable func(error) bool
+	// sleep waits for d or until ctx is done; tests replace it to avoid
+	// real delays.
+	sleep      func(ctx context.Context, d time.Duration) error
+	backoff    BackoffPolicy
+	maxRetries int
+}
+
+func (r transientRetrier) do(ctx context.Context, provider string, onRetry func(RetryAgentEvent), call func() error) error {
+	sleep := r.sleep
+	if sleep == nil {
+		sleep = sleepCtx
+	}
+	isRetryable := r.isRetryable
+	if isRetryable == nil {
+		isRetryable = IsTransient
+	}
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt > r.maxRetries || !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+		ev := RetryAgentEvent{
+			Err:        err,
+			Provider:   provider,
+			Reason:     TransientReason(err),
+			Attempt:    attempt,
+			MaxRetries: r.maxRetries,
+			Delay:      r.backoff.Delay(attempt),
+		}
+		if onRetry != nil {
+			onRetry(ev)
+		}
+		if sleep(ctx, ev.Delay) != nil {
+			return err
+		}
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryProvider is the Provider returned by WithRetry.
+type retryProvider struct {
+	inner   Provider
+	out     chan AgentEvent
+	retrier transientRetrier
+	mu      sync.Mutex
+	closed  bool
+}
+
+// startForwarding copies the inner provider's events onto out so retry
+// events and inner events share one channel. out is closed once the inner
+// channel closes (or on Close when the inner provider does not stream).
+func (p *retryProvider) startForwarding() {
+	in := p.inner.Events()
+	if in == nil {
+		return
+	}
+	go func() {
+		for ev := range in {
+			p.emit(ev)
+		}
+		p.closeOut()
+	}()
+}
+
+func (p *retryProvider) emit(ev AgentEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.out <- ev:
+	default:
+	}
+}
+
+func (p *retryProvider) closeOut() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.out)
+	}
+}
+
+func (p *retryProvider) onRetry(cfg ExecuteConfig) func(RetryAgentEvent) {
+	return func(ev RetryAgentEvent) {
+		p.emit(ev)
+		if cfg.OnTransientRetry != nil {
+			cfg.OnTransientRetry(ev)
+		}
+	}
+}
+
+func (p *retryProvider) Name() string { return p.inner.Name() }
+
+func (p *retryProvider) Execute(ctx context.Context, prompt string, wtCtx *wt.WorktreeContext, opts ...ExecuteOption) (*AgentResult, error) {
+	cfg := applyOptions(opts)
+	var (
+		result       *AgentResult
+		resumeID     string
+		failedResult bool
+		streamed     atomic.Bool
+	)
+	retrier := p.retrier
+	isRetryable := retrier.isRetryable
+	if isRetryable == nil {
+		isRetryable = IsTransient
+	}
+	retrier.isRetryable = func(err error) bool { return !streamed.Load() && isRetryable(err) }
+	watch := WithProviderEventHandler(&outputWatchHandler{inner: cfg.EventHandler, streamed: &streamed})
+	err := retrier.do(ctx, p.inner.Name(), p.onRetry(cfg), func() error {
+		callOpts := append(append([]ExecuteOption{}, opts...), watch)
+		if resumeID != "" {
+			callOpts = append(callOpts, WithProviderResumeSessionID(resumeID))
+		}
+		var err error
+		result, err = p.inner.Execute(ctx, prompt, wtCtx, callOpts...)
+		if result != nil && result.SessionID != "" {
+			resumeID = result.SessionID
+		}
+		failedResult = err == nil && result != nil && !result.Success && result.Error != nil
+		if failedResult {
+			// Some providers report a failed turn in the result rather than
+			// as an error; surface it so a transient one is retried.
+			return result.Error
+		}
+		return err
+	})
+	if err != nil && !failedResult {
+		return nil, err
+	}
+	// A failed turn that was not retried (or ran out of retries) is handed
+	// back as the provider reported it.
+	return result, nil
+}
+
+func (p *retryProvider) Events() <-chan AgentEvent { return p.out }
+
+// outputWatchHandler records whether an Execute attempt streamed any output
+// (text, thinking, or a tool call) and passes every event on to inner.
+type outputWatchHandler struct {
+	inner    EventHandler
+	streamed *atomic.Bool
+}
+
+func (h *outputWatchHandler) OnText(text string) {
+	h.streamed.Store(true)
+	if h.inner != nil {
+		h.inner.OnText(text)
+	}
+}
+
+func (h *outputWatchHandler) OnThinking(thinking string) {
+	h.streamed.Store(true)
+	if h.inner != nil {
+		h.inner.OnThinking(thinking)
+	}
+}
+
+func (h *outputWatchHandler) OnToolStart(name, id string, input map[string]interface{}) {
+	h.streamed.Store(true)
+	if h.inner != nil {
+		h.inner.OnToolStart(name, id, input)
+	}
+}
+
+func (h *outputWatchHandler) OnToolComplete(name, id string, input map[string]interface{}, result interface{}, isError bool) {
+	if h.inner != nil {
+		h.inner.OnToolComplete(name, id, input, result, isError)
+	}
+}
+
+func (h *outputWatchHandler) OnTurnComplete(turnNumber int, success bool, durationMs int64, costUSD float64) {
+	if h.inner != nil {
+		h.inner.OnTurnComplete(turnNumber, success, durationMs, costUSD)
+	}
+}
+
+func (h *outputWatchHandler) OnError(err error, context string) {
+	if h.inner != nil {
+		h.inner.OnError(err, context)
+	}
+}
+
+// The optional handler extensions are forwarded only when inner has them.
+
+func (h *outputWatchHandler) OnSessionInit(sessionID string) {
+	if sh, ok := h.inner.(SessionInitHandler); ok {
+		sh.OnSessionInit(sessionID)
+	}
+}
+
+func (h *outputWatchHandler) OnToolInputDelta(name, id, partialJSON string) {
+	if dh, ok := h.inner.(ToolInputDeltaHandler); ok {
+		dh.OnToolInputDelta(name, id, partialJSON)
+	}
+}
+
+func (h *outputWatchHandler) OnRetry(attempt, max int, tool, excerpt string) {
+	if rh, ok := h.inner.(RetryHandler); ok {
+		rh.OnRetry(attempt, max, tool, excerpt)
+	}
+}
+
+func (h *outputWatchHandler) OnRetryAbort(reason, tool, excerpt string) {
+	if rh, ok := h.inner.(RetryHandler); ok {
+		rh.OnRetryAbort(reason, tool, excerpt)
+	}
+}
+
+func (p *retryProvider) Close() error {
+	err := p.inner.Close()
+	if p.inner.Events() == nil {
+		p.closeOut()
+	}
+	return err
+}
+
+// retryLongRunningProvider adds SendMessage retries for persistent sessions.
+type retryLongRunningProvider struct {
+	*retryProvider
+	lr LongRunningProvider
+}
+
+func (p *retryLongRunningProvider) Start(ctx context.Context) error { return p.lr.Start(ctx) }
+
+func (p *retryLongRunningProvider) SendMessage(ctx context.Context, message string) (*AgentResult, error) {
+	var result *AgentResult
+	err := p.retrier.do(ctx, p.lr.Name(), p.onRetry(ExecuteConfig{}), func() error {
+		var err error
+		result, err = p.lr.SendMessage(ctx, message)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *retryLongRunningProvider) Stop() error { return p.lr.Stop() }
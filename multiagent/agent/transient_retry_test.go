@@ -0,0 +1,299 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+// scriptedProvider returns one scripted (result, error) pair per Execute or
+// SendMessage call and records the options each call received.
+type scriptedProvider struct {
+	events  chan AgentEvent
+	results []*AgentResult
+	errs    []error
+	texts   []string // streamed through the event handler, one per call
+	configs []ExecuteConfig
+	calls   int
+}
+
+func newScriptedProvider(results []*AgentResult, errs []error) *scriptedProvider {
+	return &scriptedProvider{events: make(chan AgentEvent, 10), results: results, errs: errs}
+}
+
+func (p *scriptedProvider) Name() string { return "scripted" }
+
+func (p *scriptedProvider) Execute(_ context.Context, _ string, _ *wt.WorktreeContext, opts ...ExecuteOption) (*AgentResult, error) {
+	cfg := applyOptions(opts)
+	p.configs = append(p.configs, cfg)
+	if p.calls < len(p.texts) && p.texts[p.calls] != "" && cfg.EventHandler != nil {
+		cfg.EventHandler.OnText(p.texts[p.calls])
+	}
+	return p.next()
+}
+
+func (p *scriptedProvider) next() (*AgentResult, error) {
+	i := p.calls
+	p.calls++
+	var (
+		res *AgentResult
+		err error
+	)
+	if i < len(p.results) {
+		res = p.results[i]
+	}
+	if i < len(p.errs) {
+		err = p.errs[i]
+	}
+	return res, err
+}
+
+func (p *scriptedProvider) Events() <-chan AgentEvent { return p.events }
+
+func (p *scriptedProvider) Close() error {
+	close(p.events)
+	return nil
+}
+
+type scriptedLongRunningProvider struct {
+	*scriptedProvider
+	started bool
+}
+
+func (p *scriptedLongRunningProvider) Start(context.Context) error {
+	p.started = true
+	return nil
+}
+
+func (p *scriptedLongRunningProvider) SendMessage(context.Context, string) (*AgentResult, error) {
+	return p.next()
+}
+
+func (p *scriptedLongRunningProvider) Stop() error { return nil }
+
+var noBackoff = BackoffPolicy{}
+
+func overloaded() error { return &claude.TransientError{Message: "API Error: 529 overloaded"} }
+
+func TestBackoffPolicyDelay(t *testing.T) {
+	t.Parallel()
+	b := BackoffPolicy{Initial: time.Second, Max: 5 * time.Second, Multiplier: 2}
+	for attempt, want := range map[int]time.Duration{
+		0: 0,
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		if got := b.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	if got := (BackoffPolicy{Initial: time.Second}).Delay(3); got != time.Second {
+		t.Errorf("zero multiplier should keep a constant delay, got %v", got)
+	}
+}
+
+func TestWithRetryRetriesTransientErrors(t *testing.T) {
+	t.Parallel()
+	inner := newScriptedProvider(
+		[]*AgentResult{nil, nil, {Success: true, Text: "built"}},
+		[]error{overloaded(), overloaded(), nil},
+	)
+	var observed []RetryAgentEvent
+	p := WithRetry(3, noBackoff, nil)(inner)
+
+	res, err := p.Execute(context.Background(), "build it", nil,
+		WithProviderRetryObserver(func(ev RetryAgentEvent) { observed = append(observed, ev) }))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if res.Text != "built" {
+		t.Errorf("Execute() text = %q, want built", res.Text)
+	}
+	if inner.calls != 3 {
+		t.Errorf("inner Execute called %d times, want 3", inner.calls)
+	}
+	if len(observed) != 2 || observed[0].Attempt != 1 || observed[1].Attempt != 2 {
+		t.Fatalf("observed retries = %+v, want attempts 1 and 2", observed)
+	}
+	if observed[0].Provider != "scripted" || observed[0].MaxRetries != 3 {
+		t.Errorf("retry event = %+v", observed[0])
+	}
+
+	p.Close()
+	var fromChannel int
+	for ev := range p.Events() {
+		if _, ok := ev.(RetryAgentEvent); ok {
+			fromChannel++
+		}
+	}
+	if fromChannel != 2 {
+		t.Errorf("Events() carried %d RetryAgentEvents, want 2", fromChannel)
+	}
+}
+
+func TestWithRetryGivesUp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("non-retryable error", func(t *testing.T) {
+		t.Parallel()
+		permanent := errors.New("invalid model")
+		inner := newScriptedProvider(nil, []error{permanent})
+		_, err := WithRetry(3, noBackoff, nil)(inner).Execute(context.Background(), "x", nil)
+		if !errors.Is(err, permanent) || inner.calls != 1 {
+			t.Fatalf("err = %v after %d calls, want permanent error after 1", err, inner.calls)
+		}
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		t.Parallel()
+		last := overloaded()
+		inner := newScriptedProvider(nil, []error{overloaded(), overloaded(), last})
+		_, err := WithRetry(2, noBackoff, nil)(inner).Execute(context.Background(), "x", nil)
+		if err != last || inner.calls != 3 {
+			t.Fatalf("err = %v after %d calls, want last transient error after 3", err, inner.calls)
+		}
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		t.Parallel()
+		inner := newScriptedProvider(nil, []error{overloaded()})
+		_, err := WithRetry(3, noBackoff, func(error) bool { return false })(inner).Execute(context.Background(), "x", nil)
+		if err == nil || inner.calls != 1 {
+			t.Fatalf("err = %v after %d calls, want no retry", err, inner.calls)
+		}
+	})
+
+	t.Run("context cancelled during backoff", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		inner := newScriptedProvider(nil, []error{overloaded(), nil})
+		p := WithRetry(3, BackoffPolicy{Initial: time.Hour}, nil)(inner)
+		_, err := p.Execute(ctx, "x", nil, WithProviderRetryObserver(func(RetryAgentEvent) { cancel() }))
+		if err == nil || inner.calls != 1 {
+			t.Fatalf("err = %v after %d calls, want the transient error without waiting", err, inner.calls)
+		}
+	})
+}
+
+func TestWithRetryResumesSession(t *testing.T) {
+	t.Parallel()
+	inner := newScriptedProvider(
+		[]*AgentResult{{SessionID: "sess-1"}, {Success: true}},
+		[]error{overloaded(), nil},
+	)
+	if _, err := WithRetry(1, noBackoff, nil)(inner).Execute(context.Background(), "x", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := inner.configs[0].ResumeSessionID; got != "" {
+		t.Errorf("first attempt resumed %q, want a fresh session", got)
+	}
+	if got := inner.configs[1].ResumeSessionID; got != "sess-1" {
+		t.Errorf("retry resumed %q, want sess-1", got)
+	}
+}
+
+func TestWithRetryFailedResult(t *testing.T) {
+	t.Parallel()
+	inner := newScriptedProvider(
+		[]*AgentResult{{Error: overloaded()}, {Error: errors.New("tests failed")}},
+		nil,
+	)
+	res, err := WithRetry(3, noBackoff, nil)(inner).Execute(context.Background(), "x", nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v, want the failed result returned as-is", err)
+	}
+	if inner.calls != 2 || res.Error == nil || res.Error.Error() != "tests failed" {
+		t.Fatalf("got %+v after %d calls, want second (non-transient) failed result", res, inner.calls)
+	}
+}
+
+func TestWithRetrySkipsAttemptsThatStreamedOutput(t *testing.T) {
+	t.Parallel()
+	inner := newScriptedProvider(nil, []error{overloaded(), nil})
+	inner.texts = []string{"editing main.go"}
+	h := &recordingHandler{}
+	_, err := WithRetry(3, noBackoff, nil)(inner).Execute(context.Background(), "x", nil, WithProviderEventHandler(h))
+	if err == nil || inner.calls != 1 {
+		t.Fatalf("err = %v after %d calls, want the transient error without a retry", err, inner.calls)
+	}
+	if len(h.textCalls) != 1 || h.textCalls[0] != "editing main.go" {
+		t.Errorf("caller's handler saw %q, want the streamed text", h.textCalls)
+	}
+}
+
+func TestWithRetryLongRunningSendMessage(t *testing.T) {
+	t.Parallel()
+	inner := &scriptedLongRunningProvider{scriptedProvider: newScriptedProvider(
+		[]*AgentResult{nil, {Success: true}},
+		[]error{overloaded(), nil},
+	)}
+	p, ok := WithRetry(2, noBackoff, nil)(inner).(LongRunningProvider)
+	if !ok {
+		t.Fatal("wrapping a LongRunningProvider should keep the LongRunningProvider interface")
+	}
+	if err := p.Start(context.Background()); err != nil || !inner.started {
+		t.Fatalf("Start() = %v, started = %v", err, inner.started)
+	}
+	res, err := p.SendMessage(context.Background(), "continue")
+	if err != nil || !res.Success || inner.calls != 2 {
+		t.Fatalf("SendMessage() = %+v, %v after %d calls", res, err, inner.calls)
+	}
+}
+
+// scriptedRunner is a sessionRunner whose Ask streams events and then fails.
+type scriptedRunner struct {
+	events chan claude.Event
+	err    error
+	emit   []claude.Event
+}
+
+func (r *scriptedRunner) Start(context.Context) error { return nil }
+
+func (r *scriptedRunner) Ask(context.Context, string) (*claude.TurnResult, error) {
+	for _, ev := range r.emit {
+		r.events <- ev
+	}
+	return nil, r.err
+}
+
+func (r *scriptedRunner) Events() <-chan claude.Event { return r.events }
+
+func (r *scriptedRunner) Stop() error {
+	close(r.events)
+	return nil
+}
+
+func TestRunClaudeWithRetryOnlyRetriesSilentAttempts(t *testing.T) {
+	tests := []struct {
+		name      string
+		emit      []claude.Event
+		wantCalls int
+	}{
+		{name: "no output", wantCalls: 3},
+		{name: "text streamed", emit: []claude.Event{claude.TextEvent{Text: "partial"}}, wantCalls: 1},
+		{name: "tool started", emit: []claude.Event{claude.ToolStartEvent{Name: "Edit"}}, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEphemeralSession(AgentConfig{TransientRetries: 2, RetryBackoff: noBackoff}, "swarm")
+			calls := 0
+			_, _, err := e.runClaudeWithRetry(context.Background(), func() sessionRunner {
+				calls++
+				return &scriptedRunner{events: make(chan claude.Event, 10), err: overloaded(), emit: tt.emit}
+			}, "prompt", nopLogger, "task-1")
+			if err == nil {
+				t.Fatal("expected the transient error to be returned")
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("attempts = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -36,10 +36,21 @@ type Checkpoint struct {
 	LastError      string                   `json:"last_error,omitempty"`
 	FilesCreated   []string                 `json:"files_created"`
 	FilesModified  []string                 `json:"files_modified"`
+	Decisions      []Decision               `json:"decisions,omitempty"`
 	IterationCount int                      `json:"iteration_count"`
 	TotalCost      float64                  `json:"total_cost"`
 }
 
+// Decision records one routing choice made during a mission: which agent
+// took a stage, why, and which agents could have taken it instead.
+type Decision struct {
+	Time         time.Time `json:"time"`
+	Stage        string    `json:"stage"`
+	ChosenAgent  string    `json:"chosen_agent"`
+	Reasoning    string    `json:"reasoning,omitempty"`
+	Alternatives []string  `json:"alternatives,omitempty"`
+}
+
 // CheckpointFileName is the standard checkpoint file name.
 const CheckpointFileName = "checkpoint.json"
 
@@ -138,6 +149,13 @@ func (m *Manager) StartIteration() error {
 	return m.save()
 }
 
+// RecordDecision appends a routing decision to the mission's decision log.
+func (m *Manager) RecordDecision(d Decision) error {
+	m.current.Decisions = append(m.current.Decisions, d)
+	m.current.LastUpdated = m.currentTime()
+	return m.save()
+}
+
 // Complete marks the mission as successfully completed.
 func (m *Manager) Complete() error {
 	m.current.Phase = PhaseCompleted
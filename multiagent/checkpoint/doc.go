@@ -11,6 +11,7 @@
 //   - Design/Build/Review responses
 //   - Files created and modified
 //   - Accumulated cost
+//   - The decision log: which agent took each stage and why
 //   - Error information if failed
 //
 // # Usage
@@ -28,6 +28,8 @@ var (
 	designerModel     string
 	builderModel      string
 	reviewerModel     string
+	pipeline          []string
+	maxParallel       int
 	rootOpts          = cliapp.Options{ToolName: "swarm"}
 )
 
@@ -58,6 +60,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&designerModel, "designer-model", "sonnet", "Model for Designer")
 	rootCmd.PersistentFlags().StringVar(&builderModel, "builder-model", "sonnet", "Model for Builder")
 	rootCmd.PersistentFlags().StringVar(&reviewerModel, "reviewer-model", "haiku", "Model for Reviewer")
+	rootCmd.PersistentFlags().StringSliceVar(&pipeline, "pipeline", nil, "Sub-agent stages to run, in order (default: designer,builder,reviewer)")
+	rootCmd.PersistentFlags().IntVar(&maxParallel, "max-parallel-builders", 0, "Run up to N builders at once on independent subtasks, each in its own wt worktree (0 or 1: one at a time)")
 }
 
 func main() {
@@ -99,8 +103,10 @@ func createSwarmConfig(progressReporter agent.ProgressReporter) agent.SwarmConfi
 		DesignerModel:       designerModel,
 		BuilderModel:        builderModel,
 		ReviewerModel:       reviewerModel,
+		Pipeline:            pipeline,
 		TotalBudgetUSD:      budget,
 		MaxIterations:       maxIterations,
+		MaxParallelBuilders: maxParallel,
 		EnableCheckpointing: enableCheckpoint,
 		Progress:            progressReporter,
 	}
@@ -162,6 +168,16 @@ func printSummary(orch *orchestrator.Orchestrator) {
 	fmt.Printf("Total Cost: $%.4f\n", summary.TotalCost)
 	fmt.Printf("Orchestrator Turns: %d\n", summary.OrchestratorTurns)
 	fmt.Printf("Planner Turns: %d\n", summary.PlannerTurns)
+	if len(summary.Decisions) > 0 {
+		fmt.Println("\nDecisions:")
+		for _, d := range summary.Decisions {
+			fmt.Printf("  - %s: %s", d.Stage, d.ChosenAgent)
+			if d.Reasoning != "" {
+				fmt.Printf(" (%s)", d.Reasoning)
+			}
+			fmt.Println()
+		}
+	}
 }
 
 // printMissionResult prints the result of a mission execution
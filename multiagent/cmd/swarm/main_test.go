@@ -37,6 +37,8 @@ func TestCreateSwarmConfig(t *testing.T) {
 	budget = 12.5
 	maxIterations = 7
 	enableCheckpoint = false
+	pipeline = []string{"builder", "reviewer"}
+	maxParallel = 3
 
 	cfg := createSwarmConfig(nil)
 	if cfg.WorkDir != workDir || cfg.SessionDir != sessionDir {
@@ -46,7 +48,10 @@ func TestCreateSwarmConfig(t *testing.T) {
 		cfg.BuilderModel != "sonnet" || cfg.ReviewerModel != "gpt" {
 		t.Fatalf("config models = %+v", cfg)
 	}
-	if cfg.TotalBudgetUSD != 12.5 || cfg.MaxIterations != 7 || cfg.EnableCheckpointing {
+	if len(cfg.Pipeline) != 2 || cfg.Pipeline[0] != "builder" || cfg.Pipeline[1] != "reviewer" {
+		t.Fatalf("config Pipeline = %v, want [builder reviewer]", cfg.Pipeline)
+	}
+	if cfg.TotalBudgetUSD != 12.5 || cfg.MaxIterations != 7 || cfg.MaxParallelBuilders != 3 || cfg.EnableCheckpointing {
 		t.Fatalf("config limits = %+v", cfg)
 	}
 	if cfg.Progress != nil {
@@ -88,6 +93,8 @@ func snapshotGlobals() func() {
 	oldDesignerModel := designerModel
 	oldBuilderModel := builderModel
 	oldReviewerModel := reviewerModel
+	oldPipeline := pipeline
+	oldMaxParallel := maxParallel
 	oldBudget := budget
 	oldMaxIterations := maxIterations
 	oldTimeout := timeout
@@ -101,6 +108,8 @@ func snapshotGlobals() func() {
 		designerModel = oldDesignerModel
 		builderModel = oldBuilderModel
 		reviewerModel = oldReviewerModel
+		pipeline = oldPipeline
+		maxParallel = oldMaxParallel
 		budget = oldBudget
 		maxIterations = oldMaxIterations
 		timeout = oldTimeout
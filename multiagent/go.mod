@@ -6,6 +6,7 @@ require (
 	github.com/bazelment/yoloswe/agent-cli-wrapper v0.0.0
 	github.com/bazelment/yoloswe/cliapp v0.0.0
 	github.com/bazelment/yoloswe/wt v0.0.0-20260207203406-ad2b626fcc6a
+	github.com/invopop/jsonschema v0.13.0
 	github.com/spf13/cobra v1.10.2
 	github.com/stretchr/testify v1.11.1
 )
@@ -16,7 +17,6 @@ require (
 	github.com/buger/jsonparser v1.1.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/invopop/jsonschema v0.13.0 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
@@ -20,6 +20,10 @@ import (
 	"github.com/bazelment/yoloswe/multiagent/protocol"
 )
 
+// DefaultBuilderTransientRetries is how many times the Builder retries a task
+// after a transient provider error before the failure reaches the Planner.
+const DefaultBuilderTransientRetries = 3
+
 // ErrBudgetExceeded is returned when the total cost exceeds the configured budget.
 var ErrBudgetExceeded = errors.New("budget exceeded")
 
@@ -29,8 +33,8 @@ type Orchestrator struct {
 	planner        *planner.Planner
 	controller     *control.Controller
 	swarmSessionID string
-	config         agent.AgentConfig
 	swarmConfig    agent.SwarmConfig
+	config         agent.AgentConfig
 	totalCost      float64
 	mu             sync.Mutex
 	started        bool
@@ -38,6 +42,11 @@ type Orchestrator struct {
 
 // New creates a new Orchestrator agent.
 func New(swarmConfig agent.SwarmConfig) (*Orchestrator, error) {
+	pipeline, err := agent.ParsePipeline(swarmConfig.Pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pipeline: %w", err)
+	}
+
 	// Generate session ID if not provided
 	sessionID := swarmConfig.SessionID
 	if sessionID == "" {
@@ -78,6 +87,10 @@ func New(swarmConfig agent.SwarmConfig) (*Orchestrator, error) {
 			Model:      swarmConfig.BuilderModel,
 			WorkDir:    swarmConfig.WorkDir,
 			SessionDir: swarmConfig.SessionDir,
+			// A build is the most expensive step of an iteration; don't let a
+			// single overload or rate-limit response throw it away.
+			TransientRetries: DefaultBuilderTransientRetries,
+			RetryBackoff:     agent.DefaultBackoffPolicy,
 		},
 		ReviewerConfig: agent.AgentConfig{
 			Role:       agent.RoleReviewer,
@@ -85,12 +98,23 @@ func New(swarmConfig agent.SwarmConfig) (*Orchestrator, error) {
 			WorkDir:    swarmConfig.WorkDir,
 			SessionDir: swarmConfig.SessionDir,
 		},
+		Pipeline:            pipeline,
 		MaxIterations:       swarmConfig.MaxIterations,
+		MaxParallelBuilders: swarmConfig.MaxParallelBuilders,
 		EnableCheckpointing: swarmConfig.EnableCheckpointing,
 		SessionDir:          swarmConfig.SessionDir,
 		Progress:            convertProgressReporter(swarmConfig.Progress),
 	}
 
+	if swarmConfig.MaxParallelBuilders > 1 {
+		worktrees, err := worktreesForWorkDir(context.Background(), swarmConfig.WorkDir)
+		if err != nil {
+			fmt.Printf("Warning: parallel builders disabled: %v\n", err)
+		} else {
+			plannerCfg.Worktrees = worktrees
+		}
+	}
+
 	return &Orchestrator{
 		session:        agent.NewLongRunningSession(orchConfig, sessionID),
 		config:         orchConfig,
@@ -297,6 +321,9 @@ Original Mission: %s
 Please analyze this mission and continue from where we left off.`, cp.Mission)
 	}
 
+	o.planner.RecordDecision("resume", agent.RolePlanner,
+		fmt.Sprintf("resuming the checkpointed mission from the %s phase", resumePhase), nil)
+
 	// Send the resume message to the planner
 	result, err := o.planner.SendMessage(ctx, resumeMessage)
 	if err != nil {
@@ -337,20 +364,34 @@ func (o *Orchestrator) PlannerRecording() *claude.SessionRecording {
 
 // Summary generates a summary of the swarm session.
 type Summary struct {
-	AgentCosts        map[string]float64 `json:"agent_costs"`
-	SessionID         string             `json:"session_id"`
-	TotalCost         float64            `json:"total_cost"`
-	OrchestratorTurns int                `json:"orchestrator_turns"`
-	PlannerTurns      int                `json:"planner_turns"`
+	AgentCosts map[string]float64 `json:"agent_costs"`
+	SessionID  string             `json:"session_id"`
+	Pipeline   []string           `json:"pipeline"`
+	// Builders lists every Builder run with its cost and files, including
+	// each subtask of a parallel build.
+	Builders []planner.BuilderRun `json:"builders,omitempty"`
+	// Decisions is the decision log: which agent took each stage of the
+	// mission, why, and which agents were passed over.
+	Decisions         []checkpoint.Decision `json:"decisions,omitempty"`
+	TotalCost         float64               `json:"total_cost"`
+	OrchestratorTurns int                   `json:"orchestrator_turns"`
+	PlannerTurns      int                   `json:"planner_turns"`
 }
 
 // GetSummary returns a summary of the session.
 func (o *Orchestrator) GetSummary() *Summary {
+	var pipeline []string
+	for _, role := range o.planner.Pipeline() {
+		pipeline = append(pipeline, role.String())
+	}
 	return &Summary{
 		SessionID:         o.swarmSessionID,
+		Pipeline:          pipeline,
 		TotalCost:         o.TotalCost(),
 		OrchestratorTurns: o.session.TurnCount(),
 		PlannerTurns:      o.planner.TurnCount(),
+		Builders:          o.planner.BuilderRuns(),
+		Decisions:         o.planner.Decisions(),
 		AgentCosts: map[string]float64{
 			"orchestrator": o.session.TotalCost(),
 			"planner":      o.planner.TotalCost(),
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bazelment/yoloswe/multiagent/agent"
@@ -109,6 +110,40 @@ func TestGetSummary(t *testing.T) {
 	if _, ok := summary.AgentCosts["planner"]; !ok {
 		t.Error("expected planner cost in agent costs")
 	}
+
+	if got := strings.Join(summary.Pipeline, ","); got != "designer,builder,reviewer" {
+		t.Errorf("expected default pipeline in summary, got %q", got)
+	}
+}
+
+func TestNew_CustomPipeline(t *testing.T) {
+	config := agent.SwarmConfig{
+		SessionID:  "test-pipeline-session",
+		WorkDir:    "/tmp/test",
+		SessionDir: t.TempDir(),
+		Pipeline:   []string{"builder", "reviewer"},
+	}
+
+	orch, err := New(config)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	if got := strings.Join(orch.GetSummary().Pipeline, ","); got != "builder,reviewer" {
+		t.Errorf("expected pipeline builder,reviewer in summary, got %q", got)
+	}
+}
+
+func TestNew_InvalidPipeline(t *testing.T) {
+	config := agent.SwarmConfig{
+		WorkDir:    "/tmp/test",
+		SessionDir: t.TempDir(),
+		Pipeline:   []string{"builder", "tester"},
+	}
+
+	if _, err := New(config); err == nil || !strings.Contains(err.Error(), `unknown pipeline stage "tester"`) {
+		t.Fatalf("expected unknown stage error, got %v", err)
+	}
 }
 
 func TestDefaultSwarmConfig(t *testing.T) {
@@ -279,6 +314,7 @@ func TestWriteSummary(t *testing.T) {
 
 	// Simulate some cost
 	orch.totalCost = 0.05
+	orch.planner.RecordDecision("building", agent.RoleBuilder, "no design needed", []agent.AgentRole{agent.RoleDesigner})
 
 	// Write summary
 	if err := orch.WriteSummary(); err != nil {
@@ -309,4 +345,11 @@ func TestWriteSummary(t *testing.T) {
 	if summary.TotalCost != 0.05 {
 		t.Errorf("expected total cost 0.05, got %v", summary.TotalCost)
 	}
+
+	if len(summary.Decisions) != 1 {
+		t.Fatalf("expected 1 decision in summary, got %+v", summary.Decisions)
+	}
+	if d := summary.Decisions[0]; d.ChosenAgent != "builder" || d.Reasoning != "no design needed" || strings.Join(d.Alternatives, ",") != "designer" {
+		t.Errorf("unexpected decision %+v", d)
+	}
 }
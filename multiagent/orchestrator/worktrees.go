@@ -0,0 +1,207 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bazelment/yoloswe/multiagent/planner"
+	"github.com/bazelment/yoloswe/wt"
+)
+
+// wtWorktrees gives parallel Builders their own wt worktrees, branched from
+// a snapshot of the mission worktree, and merges their changes back.
+type wtWorktrees struct {
+	m     *wt.Manager
+	lanes map[string]wtLane // requested lane branch -> lane
+	base  string            // mission worktree's branch
+	dir   string            // mission worktree
+	// snapshot is the commit lanes start from: the mission worktree's
+	// HEAD plus its uncommitted changes. No branch points at it.
+	snapshot string
+	mu       sync.Mutex
+}
+
+// wtLane is a lane's worktree and the branch wt gave it, which differs from
+// the requested one when .wt.yaml sets branch_prefix or branch_lowercase.
+type wtLane struct {
+	dir    string
+	branch string
+}
+
+// Snapshot implements planner.WorktreeProvider. The mission worktree's
+// changes are committed to a dangling commit on top of HEAD, leaving its
+// branch and files as they were. The index is reset to HEAD, so staged
+// changes end up unstaged.
+func (w *wtWorktrees) Snapshot(ctx context.Context) error {
+	tree, err := w.stageTree(ctx)
+	w.git(ctx, w.dir, "reset", "-q")
+	if err != nil {
+		return err
+	}
+	// The snapshot only lives as long as the lanes, so it doesn't need the
+	// user's identity (which may not be configured).
+	res, err := w.git(ctx, w.dir, "-c", "user.name=swarm", "-c", "user.email=swarm@localhost",
+		"commit-tree", tree, "-p", "HEAD", "-m", "swarm: parallel build snapshot")
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", w.dir, err)
+	}
+	w.snapshot = strings.TrimSpace(res.Stdout)
+	return nil
+}
+
+// Create implements planner.WorktreeProvider.
+func (w *wtWorktrees) Create(ctx context.Context, branch, goal string) (string, error) {
+	if w.snapshot == "" {
+		return "", fmt.Errorf("create %s: no snapshot of %s", branch, w.dir)
+	}
+	dir, err := w.m.New(ctx, branch, w.base, goal, wt.NewOptions{StartPoint: w.snapshot})
+	if err != nil {
+		return "", err
+	}
+	// wt names the worktree directory after the branch it created.
+	rel, err := filepath.Rel(w.m.RepoDir(), dir)
+	if err != nil {
+		return "", fmt.Errorf("create %s: %w", branch, err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lanes[branch] = wtLane{dir: dir, branch: filepath.ToSlash(rel)}
+	return dir, nil
+}
+
+// Merge implements planner.WorktreeProvider. The lane's changes since the
+// snapshot are applied to the mission worktree with git apply --3way; on a
+// conflict the mission worktree is reset to the tree it had before.
+func (w *wtWorktrees) Merge(ctx context.Context, branch string) error {
+	w.mu.Lock()
+	laneDir := w.lanes[branch].dir
+	w.mu.Unlock()
+	if laneDir == "" {
+		return fmt.Errorf("merge %s: unknown lane", branch)
+	}
+
+	if _, err := w.git(ctx, laneDir, "add", "-A"); err != nil {
+		return fmt.Errorf("merge %s: %w", branch, err)
+	}
+	res, err := w.git(ctx, laneDir, "diff", "--cached", "--binary", w.snapshot)
+	if err != nil {
+		return fmt.Errorf("merge %s: %w", branch, err)
+	}
+	if res.Stdout == "" {
+		return nil
+	}
+	patch, err := os.CreateTemp("", "swarm-lane-*.patch")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(patch.Name())
+	_, err = patch.WriteString(res.Stdout)
+	if closeErr := patch.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// git apply --3way works on the index, so stage the mission worktree
+	// first; its tree is also what a failed apply rolls back to.
+	prev, err := w.stageTree(ctx)
+	if err != nil {
+		w.git(ctx, w.dir, "reset", "-q")
+		return fmt.Errorf("merge %s: %w", branch, err)
+	}
+	if _, err := w.git(ctx, w.dir, "apply", "--3way", patch.Name()); err != nil {
+		w.git(ctx, w.dir, "read-tree", "--reset", "-u", prev)
+		w.git(ctx, w.dir, "reset", "-q")
+		return fmt.Errorf("%w: %s: %v", planner.ErrMergeConflict, branch, err)
+	}
+	w.git(ctx, w.dir, "reset", "-q")
+	return nil
+}
+
+// Remove implements planner.WorktreeProvider. The lane branch was never
+// pushed, so only the local branch is deleted.
+func (w *wtWorktrees) Remove(ctx context.Context, branch string) error {
+	w.mu.Lock()
+	lane, ok := w.lanes[branch]
+	w.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("remove %s: unknown lane", branch)
+	}
+	if err := w.m.Remove(ctx, lane.branch, false, true); err != nil {
+		return err
+	}
+	if _, err := w.m.GitRunner().Run(ctx, []string{"branch", "-D", lane.branch}, w.m.BareDir()); err != nil {
+		return fmt.Errorf("delete branch %s: %w", lane.branch, err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.lanes, branch)
+	return nil
+}
+
+// stageTree stages every change in the mission worktree and returns the
+// resulting tree. The caller unstages with "git reset".
+func (w *wtWorktrees) stageTree(ctx context.Context) (string, error) {
+	if _, err := w.git(ctx, w.dir, "add", "-A"); err != nil {
+		return "", err
+	}
+	res, err := w.git(ctx, w.dir, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(res.Stdout), nil
+}
+
+func (w *wtWorktrees) git(ctx context.Context, dir string, args ...string) (*wt.CmdResult, error) {
+	res, err := w.m.GitRunner().Run(ctx, args, dir)
+	if err != nil && res != nil {
+		if stderr := strings.TrimSpace(res.Stderr); stderr != "" {
+			return res, fmt.Errorf("git %s: %s: %w", args[0], stderr, err)
+		}
+	}
+	return res, err
+}
+
+// worktreesForWorkDir returns a wt-backed WorktreeProvider when workDir is
+// inside a wt-managed repository (<root>/<repo>/.bare), branching lanes from
+// workDir's current branch.
+func worktreesForWorkDir(ctx context.Context, workDir string) (planner.WorktreeProvider, error) {
+	abs, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, err
+	}
+	repoDir := ""
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".bare")); err == nil {
+			repoDir = dir
+			break
+		}
+		if filepath.Dir(dir) == dir {
+			return nil, fmt.Errorf("%s is not inside a wt-managed repository", workDir)
+		}
+	}
+
+	m := wt.NewManager(filepath.Dir(repoDir), filepath.Base(repoDir), wt.WithOutput(wt.NewOutput(io.Discard, false)))
+	result, err := m.GitRunner().Run(ctx, []string{"branch", "--show-current"}, abs)
+	if err != nil {
+		return nil, fmt.Errorf("read current branch of %s: %w", workDir, err)
+	}
+	base := strings.TrimSpace(result.Stdout)
+	if base == "" {
+		return nil, fmt.Errorf("%s is not on a branch", workDir)
+	}
+	// git apply skips paths outside the directory it runs in, so work from
+	// the top of the mission worktree.
+	result, err = m.GitRunner().Run(ctx, []string{"rev-parse", "--show-toplevel"}, abs)
+	if err != nil {
+		return nil, fmt.Errorf("find worktree of %s: %w", workDir, err)
+	}
+	top := strings.TrimSpace(result.Stdout)
+	return &wtWorktrees{m: m, base: base, dir: top, lanes: make(map[string]wtLane)}, nil
+}
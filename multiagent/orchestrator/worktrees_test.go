@@ -0,0 +1,183 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bazelment/yoloswe/multiagent/planner"
+)
+
+// gitCmd runs git in dir and returns its trimmed output.
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// setupMissionRepo creates a wt-managed clone of a fresh origin with main
+// holding a.txt, and returns its mission worktree, on a branch that was
+// never pushed.
+func setupMissionRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for _, key := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(key, "test")
+	}
+	for _, key := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(key, "test@example.com")
+	}
+	root := t.TempDir()
+
+	origin := filepath.Join(root, "origin")
+	gitCmd(t, root, "init", "-q", "-b", "main", origin)
+	writeFile(t, filepath.Join(origin, "a.txt"), "one\ntwo\nthree\n")
+	gitCmd(t, origin, "add", "-A")
+	gitCmd(t, origin, "commit", "-q", "-m", "initial")
+
+	repoDir := filepath.Join(root, "wt", "repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	gitCmd(t, root, "clone", "-q", "--bare", origin, bareDir)
+	gitCmd(t, bareDir, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+	gitCmd(t, bareDir, "fetch", "-q", "origin")
+	mission := filepath.Join(repoDir, "mission")
+	gitCmd(t, bareDir, "worktree", "add", "-q", "-b", "mission", mission, "origin/main")
+	return mission
+}
+
+// TestWorktreesLanesStartFromLocalState checks that lanes see the mission
+// worktree's unpushed commits and uncommitted changes, that their changes
+// merge back three-way, and that a conflicting lane leaves the mission
+// worktree untouched.
+func TestWorktreesLanesStartFromLocalState(t *testing.T) {
+	ctx := context.Background()
+	mission := setupMissionRepo(t)
+
+	writeFile(t, filepath.Join(mission, "unpushed.txt"), "committed locally\n")
+	gitCmd(t, mission, "add", "-A")
+	gitCmd(t, mission, "commit", "-q", "-m", "unpushed")
+	head := gitCmd(t, mission, "rev-parse", "HEAD")
+	writeFile(t, filepath.Join(mission, "a.txt"), "one\nTWO\nthree\n")
+	writeFile(t, filepath.Join(mission, "untracked.txt"), "new\n")
+
+	provider, err := worktreesForWorkDir(ctx, mission)
+	if err != nil {
+		t.Fatalf("worktreesForWorkDir() error = %v", err)
+	}
+	if err := provider.Snapshot(ctx); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	lanes := make(map[string]string)
+	for _, branch := range []string{"lane-1", "lane-2", "lane-3"} {
+		dir, err := provider.Create(ctx, branch, "")
+		if err != nil {
+			t.Fatalf("Create(%s) error = %v", branch, err)
+		}
+		lanes[branch] = dir
+		defer provider.Remove(ctx, branch)
+	}
+
+	lane := lanes["lane-1"]
+	for name, want := range map[string]string{
+		"a.txt":         "one\nTWO\nthree\n",
+		"unpushed.txt":  "committed locally\n",
+		"untracked.txt": "new\n",
+	} {
+		if got := readFile(t, filepath.Join(lane, name)); got != want {
+			t.Errorf("lane %s = %q, want the mission worktree's %q", name, got, want)
+		}
+	}
+
+	writeFile(t, filepath.Join(lanes["lane-1"], "a.txt"), "ONE\nTWO\nthree\n")
+	writeFile(t, filepath.Join(lanes["lane-1"], "lane1.txt"), "from lane 1\n")
+	writeFile(t, filepath.Join(lanes["lane-2"], "a.txt"), "one\nTWO\nTHREE\n")
+	writeFile(t, filepath.Join(lanes["lane-3"], "a.txt"), "uno\nTWO\nthree\n")
+
+	for _, branch := range []string{"lane-1", "lane-2"} {
+		if err := provider.Merge(ctx, branch); err != nil {
+			t.Fatalf("Merge(%s) error = %v", branch, err)
+		}
+	}
+	if got := readFile(t, filepath.Join(mission, "a.txt")); got != "ONE\nTWO\nTHREE\n" {
+		t.Errorf("merged a.txt = %q, want both lanes' edits", got)
+	}
+	if got := readFile(t, filepath.Join(mission, "lane1.txt")); got != "from lane 1\n" {
+		t.Errorf("merged lane1.txt = %q", got)
+	}
+
+	if err := provider.Merge(ctx, "lane-3"); !errors.Is(err, planner.ErrMergeConflict) {
+		t.Fatalf("Merge(lane-3) error = %v, want ErrMergeConflict", err)
+	}
+	if got := readFile(t, filepath.Join(mission, "a.txt")); got != "ONE\nTWO\nTHREE\n" {
+		t.Errorf("a.txt after a conflicting merge = %q, want it unchanged", got)
+	}
+	if got := readFile(t, filepath.Join(mission, "untracked.txt")); got != "new\n" {
+		t.Errorf("untracked.txt = %q, want it kept", got)
+	}
+	if got := gitCmd(t, mission, "rev-parse", "HEAD"); got != head {
+		t.Errorf("mission HEAD moved to %s, want %s", got, head)
+	}
+	if staged := gitCmd(t, mission, "diff", "--cached", "--name-only"); staged != "" {
+		t.Errorf("mission worktree has staged changes: %s", staged)
+	}
+}
+
+// TestWorktreesRemoveRenamedLane checks that a lane whose branch wt renamed
+// (branch_prefix, branch_lowercase) is removed along with its branch.
+func TestWorktreesRemoveRenamedLane(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("HOME", t.TempDir())
+	mission := setupMissionRepo(t)
+	writeFile(t, filepath.Join(mission, ".wt.yaml"), "branch_prefix: swarm/\nbranch_lowercase: true\n")
+
+	provider, err := worktreesForWorkDir(ctx, mission)
+	if err != nil {
+		t.Fatalf("worktreesForWorkDir() error = %v", err)
+	}
+	if err := provider.Snapshot(ctx); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	dir, err := provider.Create(ctx, "Lane-1", "")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := filepath.Join(filepath.Dir(mission), "swarm", "lane-1"); dir != want {
+		t.Fatalf("Create() = %s, want %s", dir, want)
+	}
+	if err := provider.Remove(ctx, "Lane-1"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("lane worktree %s still exists (err = %v)", dir, err)
+	}
+	if branches := gitCmd(t, mission, "branch", "--list", "swarm/*"); branches != "" {
+		t.Errorf("lane branch left behind: %s", branches)
+	}
+}
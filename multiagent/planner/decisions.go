@@ -0,0 +1,76 @@
+package planner
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
+	"github.com/bazelment/yoloswe/multiagent/checkpoint"
+	"github.com/bazelment/yoloswe/multiagent/progress"
+)
+
+// RecordDecision appends a routing decision to the mission's decision log,
+// reports it as a progress event, and saves it with the checkpoint.
+func (p *Planner) RecordDecision(stage string, chosen agent.AgentRole, reasoning string, alternatives []agent.AgentRole) {
+	d := checkpoint.Decision{
+		Time:        time.Now(),
+		Stage:       stage,
+		ChosenAgent: chosen.String(),
+		Reasoning:   reasoning,
+	}
+	for _, alt := range alternatives {
+		d.Alternatives = append(d.Alternatives, alt.String())
+	}
+
+	p.mu.Lock()
+	p.decisions = append(p.decisions, d)
+	p.mu.Unlock()
+
+	if p.progress != nil {
+		p.progress.Event(progress.NewOrchestratorDecisionEvent(d))
+	}
+	if p.checkpointMgr != nil {
+		if err := p.checkpointMgr.RecordDecision(d); err != nil {
+			fmt.Printf("Warning: failed to save checkpoint: %v\n", err)
+		}
+	}
+}
+
+// Decisions returns the mission's decision log, oldest first.
+func (p *Planner) Decisions() []checkpoint.Decision {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]checkpoint.Decision(nil), p.decisions...)
+}
+
+// recordStageDecision logs the Planner's choice of a sub-agent tool. The
+// alternatives are the other stages of the pipeline.
+func (p *Planner) recordStageDecision(role agent.AgentRole, reason string) {
+	var alternatives []agent.AgentRole
+	for _, stage := range p.pipeline {
+		if stage != role {
+			alternatives = append(alternatives, stage)
+		}
+	}
+	p.RecordDecision(stageStates[role].String(), role, reason, alternatives)
+}
+
+// toolRole maps a sub-agent tool name to the role that runs it.
+func toolRole(name string) (agent.AgentRole, bool) {
+	if name == "build_parallel" {
+		return agent.RoleBuilder, true
+	}
+	role := agent.AgentRole(name)
+	_, ok := stageStates[role]
+	return role, ok
+}
+
+// toolReason extracts the reason argument from a tool call.
+func toolReason(args json.RawMessage) string {
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.Unmarshal(args, &input)
+	return input.Reason
+}
@@ -0,0 +1,84 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
+	"github.com/bazelment/yoloswe/multiagent/checkpoint"
+	"github.com/bazelment/yoloswe/multiagent/progress"
+)
+
+type decisionRecorder struct {
+	events []progress.OrchestratorDecisionEvent
+	mu     sync.Mutex
+}
+
+func (r *decisionRecorder) Event(e progress.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d, ok := e.(progress.OrchestratorDecisionEvent); ok {
+		r.events = append(r.events, d)
+	}
+}
+
+func (r *decisionRecorder) Close() {}
+
+func TestRecordStageDecision(t *testing.T) {
+	sessionDir := t.TempDir()
+	recorder := &decisionRecorder{}
+	p := New(Config{
+		PlannerConfig:       agent.AgentConfig{Model: "sonnet", WorkDir: ".", SessionDir: sessionDir},
+		Pipeline:            []agent.AgentRole{agent.RoleBuilder, agent.RoleReviewer},
+		SessionDir:          sessionDir,
+		EnableCheckpointing: true,
+		Progress:            recorder,
+	}, "test-session")
+
+	// A tool outside the pipeline is rejected and is not a decision.
+	handler := NewPlannerToolHandler(p)
+	if _, err := handler.HandleToolCall(context.Background(), "designer", json.RawMessage(`{"task":"x","reason":"r"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := p.Decisions(); len(got) != 0 {
+		t.Fatalf("rejected tool call was logged: %+v", got)
+	}
+
+	role, ok := toolRole("build_parallel")
+	if !ok || role != agent.RoleBuilder {
+		t.Fatalf("toolRole(build_parallel) = %v, %v", role, ok)
+	}
+	p.recordStageDecision(role, toolReason(json.RawMessage(`{"task":"x","reason":"the fix is one line, no design needed"}`)))
+
+	decisions := p.Decisions()
+	if len(decisions) != 1 {
+		t.Fatalf("expected 1 decision, got %+v", decisions)
+	}
+	d := decisions[0]
+	if d.Stage != "building" || d.ChosenAgent != "builder" || d.Reasoning != "the fix is one line, no design needed" {
+		t.Errorf("unexpected decision %+v", d)
+	}
+	if got := strings.Join(d.Alternatives, ","); got != "reviewer" {
+		t.Errorf("alternatives = %q, want the rest of the pipeline", got)
+	}
+
+	if len(recorder.events) != 1 || recorder.events[0].ChosenAgent != agent.RoleBuilder {
+		t.Errorf("expected one decision event for the builder, got %+v", recorder.events)
+	}
+
+	cp, err := checkpoint.Load(sessionDir, "test-session")
+	if err != nil || cp == nil {
+		t.Fatalf("Load() = %v, %v", cp, err)
+	}
+	if len(cp.Decisions) != 1 || cp.Decisions[0].Reasoning != d.Reasoning {
+		t.Errorf("checkpoint decisions = %+v", cp.Decisions)
+	}
+
+	restored := NewFromCheckpoint(Config{PlannerConfig: agent.AgentConfig{Model: "sonnet", WorkDir: "."}}, "test-session", cp)
+	if got := restored.Decisions(); len(got) != 1 {
+		t.Errorf("restored planner lost the decision log: %+v", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/bazelment/yoloswe/multiagent/agent"
 	"github.com/bazelment/yoloswe/multiagent/progress"
 	"github.com/bazelment/yoloswe/multiagent/protocol"
 )
@@ -82,6 +83,9 @@ type IterationResult struct {
 //  3. Run reviewer on builder output
 //  4. If approved, exit with success
 //  5. If rejected, update build request with feedback and continue
+//
+// When the pipeline has no reviewer stage, steps 3-5 are skipped and the
+// first successful build is accepted.
 func (p *Planner) RunIterationLoop(ctx context.Context, design *protocol.DesignResponse, buildReq *protocol.BuildRequest) (*IterationResult, error) {
 	startTime := time.Now()
 	result := &IterationResult{
@@ -160,6 +164,17 @@ func (p *Planner) RunIterationLoop(ctx context.Context, design *protocol.DesignR
 		result.FilesCreated = appendUnique(result.FilesCreated, buildResp.FilesCreated...)
 		result.FilesModified = appendUnique(result.FilesModified, buildResp.FilesModified...)
 
+		// Without a reviewer stage a successful build is accepted as-is.
+		if !p.hasStage(agent.RoleReviewer) {
+			result.ExitReason = ExitReasonAccepted
+			result.TotalDuration = time.Since(startTime)
+			result.TotalCostUSD = p.TotalCost()
+			if p.progress != nil {
+				p.progress.Event(progress.NewIterationEvent(iteration, p.GetIterationConfig().MaxIterations, "iteration_accepted"))
+			}
+			return result, nil
+		}
+
 		// === Reviewer Phase ===
 		if p.stateMachine != nil {
 			_ = p.stateMachine.Transition(StateReviewing, "iteration_review")
@@ -259,27 +274,31 @@ func (p *Planner) SetIterationConfig(cfg IterationConfig) {
 
 // RunDesignBuildReviewLoop runs a complete design-build-review workflow with iteration.
 // This is a convenience method that:
-//  1. Calls the designer to create a design
+//  1. Calls the designer to create a design (skipped when the pipeline has no designer)
 //  2. Runs the iteration loop with the design
 //  3. Returns the combined result
 func (p *Planner) RunDesignBuildReviewLoop(ctx context.Context, task string, workDir string) (*IterationResult, error) {
-	// === Design Phase ===
-	if p.stateMachine != nil {
-		_ = p.stateMachine.Transition(StateDesigning, "design_start")
-	}
+	var designResp *protocol.DesignResponse
+	if p.hasStage(agent.RoleDesigner) {
+		// === Design Phase ===
+		if p.stateMachine != nil {
+			_ = p.stateMachine.Transition(StateDesigning, "design_start")
+		}
 
-	designReq := &protocol.DesignRequest{
-		Task: task,
-	}
+		designReq := &protocol.DesignRequest{
+			Task: task,
+		}
 
-	designResp, err := p.CallDesigner(ctx, designReq)
-	if err != nil {
-		return nil, fmt.Errorf("design phase failed: %w", err)
-	}
+		var err error
+		designResp, err = p.CallDesigner(ctx, designReq)
+		if err != nil {
+			return nil, fmt.Errorf("design phase failed: %w", err)
+		}
 
-	// Transition to planning before iteration
-	if p.stateMachine != nil {
-		_ = p.stateMachine.Transition(StatePlanning, "design_complete")
+		// Transition to planning before iteration
+		if p.stateMachine != nil {
+			_ = p.stateMachine.Transition(StatePlanning, "design_complete")
+		}
 	}
 
 	// === Build-Review Iteration ===
@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/protocol"
+	"github.com/bazelment/yoloswe/multiagent/agent"
 	maprotocol "github.com/bazelment/yoloswe/multiagent/protocol"
 )
 
@@ -21,8 +23,69 @@ func NewPlannerToolHandler(planner *Planner) *PlannerToolHandler {
 	return &PlannerToolHandler{planner: planner}
 }
 
-// Tools returns the MCP tool definitions for the planner's sub-agents.
+// Tools returns the MCP tool definitions for the sub-agents in the planner's
+// pipeline, in pipeline order.
 func (h *PlannerToolHandler) Tools() []protocol.MCPToolDefinition {
+	byName := make(map[string]protocol.MCPToolDefinition, 3)
+	for _, def := range plannerToolDefinitions() {
+		byName[def.Name] = def
+	}
+	tools := make([]protocol.MCPToolDefinition, 0, len(h.planner.pipeline))
+	for _, role := range h.planner.pipeline {
+		if def, ok := byName[role.String()]; ok {
+			tools = append(tools, def)
+		}
+	}
+	if h.planner.parallelBuildsEnabled() {
+		tools = append(tools, buildParallelToolDefinition)
+	}
+	return tools
+}
+
+// reasonDescription describes the reason argument every sub-agent tool
+// takes; the answer goes into the swarm's decision log.
+const reasonDescription = "Why this step comes next and why the other available agents were not chosen (recorded in the swarm's decision log)"
+
+// buildParallelToolDefinition is the tool offered alongside builder when
+// parallel builds are enabled.
+var buildParallelToolDefinition = protocol.MCPToolDefinition{
+	Name:        "build_parallel",
+	Description: "Build several independent subtasks concurrently, each with its own builder in its own worktree, and merge the results. Subtasks that share a file are built one after another.",
+	InputSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"subtasks": {
+				"type": "array",
+				"description": "Independent implementation subtasks",
+				"items": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "string", "description": "Short identifier for the subtask"},
+						"task": {"type": "string", "description": "The implementation task to perform"},
+						"files": {
+							"type": "array",
+							"description": "Files the subtask is expected to create or modify",
+							"items": {"type": "string"}
+						}
+					},
+					"required": ["task"]
+				}
+			},
+			"design": {
+				"type": "string",
+				"description": "Design or architecture to follow (from designer)"
+			},
+			"reason": {
+				"type": "string",
+				"description": "` + reasonDescription + `"
+			}
+		},
+		"required": ["subtasks"]
+	}`),
+}
+
+// plannerToolDefinitions returns the definitions of every sub-agent tool.
+func plannerToolDefinitions() []protocol.MCPToolDefinition {
 	return []protocol.MCPToolDefinition{
 		{
 			Name:        "designer",
@@ -42,6 +105,10 @@ func (h *PlannerToolHandler) Tools() []protocol.MCPToolDefinition {
 						"type": "array",
 						"description": "Constraints or requirements to consider",
 						"items": {"type": "string"}
+					},
+					"reason": {
+						"type": "string",
+						"description": "` + reasonDescription + `"
 					}
 				},
 				"required": ["task"]
@@ -64,6 +131,10 @@ func (h *PlannerToolHandler) Tools() []protocol.MCPToolDefinition {
 					"design": {
 						"type": "string",
 						"description": "Design or architecture to follow (from designer)"
+					},
+					"reason": {
+						"type": "string",
+						"description": "` + reasonDescription + `"
 					}
 				},
 				"required": ["task"]
@@ -87,6 +158,10 @@ func (h *PlannerToolHandler) Tools() []protocol.MCPToolDefinition {
 					"design": {
 						"type": "string",
 						"description": "Original design to review against"
+					},
+					"reason": {
+						"type": "string",
+						"description": "` + reasonDescription + `"
 					}
 				},
 				"required": ["task"]
@@ -97,6 +172,25 @@ func (h *PlannerToolHandler) Tools() []protocol.MCPToolDefinition {
 
 // HandleToolCall dispatches a tool call to the appropriate Planner method.
 func (h *PlannerToolHandler) HandleToolCall(ctx context.Context, name string, args json.RawMessage) (*protocol.MCPToolCallResult, error) {
+	if role := agent.AgentRole(name); stageStates[role] != 0 && !h.planner.hasStage(role) {
+		return &protocol.MCPToolCallResult{
+			Content: []protocol.MCPContentItem{
+				{Type: "text", Text: fmt.Sprintf("Tool %s is not part of this swarm's pipeline", name)},
+			},
+			IsError: true,
+		}, nil
+	}
+	if name == "build_parallel" && !h.planner.parallelBuildsEnabled() {
+		return &protocol.MCPToolCallResult{
+			Content: []protocol.MCPContentItem{
+				{Type: "text", Text: "Tool build_parallel is not enabled for this swarm"},
+			},
+			IsError: true,
+		}, nil
+	}
+	if role, ok := toolRole(name); ok {
+		h.planner.recordStageDecision(role, toolReason(args))
+	}
 	switch name {
 	case "designer":
 		return h.callDesigner(ctx, args)
@@ -104,6 +198,8 @@ func (h *PlannerToolHandler) HandleToolCall(ctx context.Context, name string, ar
 		return h.callBuilder(ctx, args)
 	case "reviewer":
 		return h.callReviewer(ctx, args)
+	case "build_parallel":
+		return h.callBuildParallel(ctx, args)
 	default:
 		return &protocol.MCPToolCallResult{
 			Content: []protocol.MCPContentItem{
@@ -200,6 +296,60 @@ func (h *PlannerToolHandler) callBuilder(ctx context.Context, args json.RawMessa
 	}, nil
 }
 
+// callBuildParallel handles the build_parallel tool call.
+func (h *PlannerToolHandler) callBuildParallel(ctx context.Context, args json.RawMessage) (*protocol.MCPToolCallResult, error) {
+	var input struct {
+		Design   string               `json:"design"`
+		Subtasks []maprotocol.Subtask `json:"subtasks"`
+	}
+	if err := json.Unmarshal(args, &input); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	req := &maprotocol.ParallelBuildRequest{Subtasks: input.Subtasks}
+	if input.Design != "" {
+		req.Design = &maprotocol.DesignResponse{
+			Architecture: input.Design,
+		}
+	}
+
+	resp, err := h.planner.CallParallelBuilders(ctx, req)
+	if err != nil {
+		return &protocol.MCPToolCallResult{
+			Content: []protocol.MCPContentItem{
+				{Type: "text", Text: fmt.Sprintf("Parallel build failed: %v", err)},
+			},
+			IsError: true,
+		}, nil
+	}
+
+	return &protocol.MCPToolCallResult{
+		Content: []protocol.MCPContentItem{
+			{Type: "text", Text: formatParallelBuildResult(resp)},
+		},
+		IsError: len(resp.Failed()) == len(resp.Results),
+	}, nil
+}
+
+// formatParallelBuildResult renders a parallel build's results for the
+// Planner.
+func formatParallelBuildResult(resp *maprotocol.ParallelBuildResponse) string {
+	jsonBytes, _ := json.Marshal(resp)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Parallel build completed: %d of %d subtasks built.\n\n<parallel_build_json>\n%s\n</parallel_build_json>\n",
+		len(resp.Results)-len(resp.Failed()), len(resp.Results), jsonBytes)
+	for _, res := range resp.Results {
+		status := "ok"
+		if res.Error != "" {
+			status = "FAILED: " + res.Error
+		} else if res.Serialized {
+			status = "ok (rebuilt serially after a file overlap)"
+		}
+		fmt.Fprintf(&b, "\n- %s: %s\n  Files created: %v\n  Files modified: %v", res.ID, status, res.FilesCreated, res.FilesModified)
+	}
+	return b.String()
+}
+
 // callReviewer handles the reviewer tool call.
 func (h *PlannerToolHandler) callReviewer(ctx context.Context, args json.RawMessage) (*protocol.MCPToolCallResult, error) {
 	var input struct {
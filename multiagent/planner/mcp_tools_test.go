@@ -3,6 +3,7 @@ package planner
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/protocol"
@@ -67,6 +68,37 @@ func TestPlannerToolHandler_Tools(t *testing.T) {
 	}
 }
 
+func TestPlannerToolHandler_PipelineLimitsTools(t *testing.T) {
+	p := New(Config{
+		PlannerConfig: agent.AgentConfig{Model: "sonnet", WorkDir: ".", SessionDir: t.TempDir()},
+		Pipeline:      []agent.AgentRole{agent.RoleBuilder, agent.RoleReviewer},
+	}, "test-session")
+	handler := NewPlannerToolHandler(p)
+
+	var names []string
+	for _, tool := range handler.Tools() {
+		names = append(names, tool.Name)
+	}
+	if got := strings.Join(names, ","); got != "builder,reviewer" {
+		t.Errorf("expected tools builder,reviewer, got %q", got)
+	}
+
+	result, err := handler.HandleToolCall(context.Background(), "designer", json.RawMessage(`{"task":"x"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "not part of this swarm's pipeline") {
+		t.Errorf("expected pipeline error for designer, got %+v", result)
+	}
+
+	if strings.Contains(p.config.SystemPrompt, "**designer**") {
+		t.Error("system prompt should not describe the designer tool")
+	}
+	if !strings.Contains(p.config.SystemPrompt, "two tools") {
+		t.Error("system prompt should list two tools")
+	}
+}
+
 func TestPlannerToolHandler_UnknownTool(t *testing.T) {
 	p := newTestPlanner(t)
 	handler := NewPlannerToolHandler(p)
@@ -163,3 +195,26 @@ func TestPlannerToolHandler_ImplementsSDKToolHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestPlannerToolHandlers_ReasonDescription(t *testing.T) {
+	p := newTestPlanner(t)
+	handlers := map[string][]protocol.MCPToolDefinition{
+		"manual": NewPlannerToolHandler(p).Tools(),
+		"typed":  NewPlannerToolHandlerTyped(p).Tools(),
+	}
+	for kind, tools := range handlers {
+		for _, tool := range tools {
+			var schema struct {
+				Properties map[string]struct {
+					Description string `json:"description"`
+				} `json:"properties"`
+			}
+			if err := json.Unmarshal(tool.InputSchema, &schema); err != nil {
+				t.Fatalf("%s %q: failed to parse inputSchema: %v", kind, tool.Name, err)
+			}
+			if got := schema.Properties["reason"].Description; got != reasonDescription {
+				t.Errorf("%s %q: reason description = %q, want %q", kind, tool.Name, got, reasonDescription)
+			}
+		}
+	}
+}
@@ -6,13 +6,29 @@ import (
 	"fmt"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
+	"github.com/bazelment/yoloswe/multiagent/agent"
 	maprotocol "github.com/bazelment/yoloswe/multiagent/protocol"
+	"github.com/invopop/jsonschema"
 )
 
+// StepReason is the reason argument every sub-agent tool takes.
+type StepReason struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// JSONSchemaExtend describes the reason property with reasonDescription,
+// which a struct tag can't refer to.
+func (StepReason) JSONSchemaExtend(schema *jsonschema.Schema) {
+	if prop, ok := schema.Properties.Get("reason"); ok {
+		prop.Description = reasonDescription
+	}
+}
+
 // DesignerParams defines the parameters for the designer tool using typed approach.
 type DesignerParams struct {
-	Task        string   `json:"task" jsonschema:"required,description=The task to design a solution for"`
-	Context     string   `json:"context,omitempty" jsonschema:"description=Additional context about the codebase or requirements"`
+	Task    string `json:"task" jsonschema:"required,description=The task to design a solution for"`
+	Context string `json:"context,omitempty" jsonschema:"description=Additional context about the codebase or requirements"`
+	StepReason
 	Constraints []string `json:"constraints,omitempty" jsonschema:"description=Constraints or requirements to consider"`
 }
 
@@ -21,109 +37,149 @@ type BuilderParams struct {
 	Task    string `json:"task" jsonschema:"required,description=The implementation task to perform"`
 	WorkDir string `json:"workdir,omitempty" jsonschema:"description=Working directory for the implementation"`
 	Design  string `json:"design,omitempty" jsonschema:"description=Design or architecture to follow (from designer)"`
+	StepReason
+}
+
+// BuildParallelParams defines the parameters for the build_parallel tool
+// using typed approach.
+type BuildParallelParams struct {
+	Design string `json:"design,omitempty" jsonschema:"description=Design or architecture to follow (from designer)"`
+	StepReason
+	Subtasks []maprotocol.Subtask `json:"subtasks" jsonschema:"required,description=Independent implementation subtasks; list the files each will touch"`
 }
 
 // ReviewerParams defines the parameters for the reviewer tool using typed approach.
 type ReviewerParams struct {
-	Task   string   `json:"task" jsonschema:"required,description=Description of what to review"`
-	Design string   `json:"design,omitempty" jsonschema:"description=Original design to review against"`
-	Files  []string `json:"files,omitempty" jsonschema:"description=List of files that were changed"`
+	Task   string `json:"task" jsonschema:"required,description=Description of what to review"`
+	Design string `json:"design,omitempty" jsonschema:"description=Original design to review against"`
+	StepReason
+	Files []string `json:"files,omitempty" jsonschema:"description=List of files that were changed"`
 }
 
-// NewPlannerToolHandlerTyped creates a TypedToolRegistry-based handler for planner tools.
+// NewPlannerToolHandlerTyped creates a TypedToolRegistry-based handler for
+// the planner tools in the planner's pipeline.
 // This is a cleaner, type-safe alternative to the manual PlannerToolHandler implementation.
 func NewPlannerToolHandlerTyped(planner *Planner) *claude.TypedToolRegistry {
 	registry := claude.NewTypedToolRegistry()
 
 	// Register designer tool
-	claude.AddTool(registry, "designer",
-		"Create a technical design for a task. Use this to analyze requirements and produce an architecture/design document before building.",
-		func(ctx context.Context, params DesignerParams) (string, error) {
-			req := &maprotocol.DesignRequest{
-				Task:        params.Task,
-				Context:     params.Context,
-				Constraints: params.Constraints,
-			}
-
-			resp, err := planner.CallDesigner(ctx, req)
-			if err != nil {
-				return "", fmt.Errorf("designer failed: %w", err)
-			}
-
-			jsonBytes, _ := json.Marshal(resp)
-			text := fmt.Sprintf("Design completed.\n\n<design_json>\n%s\n</design_json>\n\nSummary:\n%s",
-				string(jsonBytes), resp.Architecture)
-
-			return text, nil
-		})
+	if planner.hasStage(agent.RoleDesigner) {
+		claude.AddTool(registry, "designer",
+			"Create a technical design for a task. Use this to analyze requirements and produce an architecture/design document before building.",
+			func(ctx context.Context, params DesignerParams) (string, error) {
+				planner.recordStageDecision(agent.RoleDesigner, params.Reason)
+				req := &maprotocol.DesignRequest{
+					Task:        params.Task,
+					Context:     params.Context,
+					Constraints: params.Constraints,
+				}
+
+				resp, err := planner.CallDesigner(ctx, req)
+				if err != nil {
+					return "", fmt.Errorf("designer failed: %w", err)
+				}
+
+				jsonBytes, _ := json.Marshal(resp)
+				text := fmt.Sprintf("Design completed.\n\n<design_json>\n%s\n</design_json>\n\nSummary:\n%s",
+					string(jsonBytes), resp.Architecture)
+
+				return text, nil
+			})
+	}
 
 	// Register builder tool
-	claude.AddTool(registry, "builder",
-		"Implement code changes based on a task and optional design. Use this to write, modify, or refactor code.",
-		func(ctx context.Context, params BuilderParams) (string, error) {
-			workDir := params.WorkDir
-			if workDir == "" {
-				workDir = planner.config.WorkDir
-			}
-
-			req := &maprotocol.BuildRequest{
-				Task:    params.Task,
-				WorkDir: workDir,
-			}
-
-			if params.Design != "" {
-				req.Design = &maprotocol.DesignResponse{
-					Architecture: params.Design,
+	if planner.hasStage(agent.RoleBuilder) {
+		claude.AddTool(registry, "builder",
+			"Implement code changes based on a task and optional design. Use this to write, modify, or refactor code.",
+			func(ctx context.Context, params BuilderParams) (string, error) {
+				planner.recordStageDecision(agent.RoleBuilder, params.Reason)
+				workDir := params.WorkDir
+				if workDir == "" {
+					workDir = planner.config.WorkDir
+				}
+
+				req := &maprotocol.BuildRequest{
+					Task:    params.Task,
+					WorkDir: workDir,
+				}
+
+				if params.Design != "" {
+					req.Design = &maprotocol.DesignResponse{
+						Architecture: params.Design,
+					}
 				}
-			}
 
-			resp, err := planner.CallBuilder(ctx, req)
-			if err != nil {
-				return "", fmt.Errorf("builder failed: %w", err)
-			}
+				resp, err := planner.CallBuilder(ctx, req)
+				if err != nil {
+					return "", fmt.Errorf("builder failed: %w", err)
+				}
 
-			jsonBytes, _ := json.Marshal(resp)
-			text := fmt.Sprintf("Build completed.\n\n<build_json>\n%s\n</build_json>\n\nFiles created: %v\nFiles modified: %v",
-				string(jsonBytes), resp.FilesCreated, resp.FilesModified)
+				jsonBytes, _ := json.Marshal(resp)
+				text := fmt.Sprintf("Build completed.\n\n<build_json>\n%s\n</build_json>\n\nFiles created: %v\nFiles modified: %v",
+					string(jsonBytes), resp.FilesCreated, resp.FilesModified)
+
+				return text, nil
+			})
+	}
+
+	// Register build_parallel tool
+	if planner.parallelBuildsEnabled() {
+		claude.AddTool(registry, buildParallelToolDefinition.Name, buildParallelToolDefinition.Description,
+			func(ctx context.Context, params BuildParallelParams) (string, error) {
+				planner.recordStageDecision(agent.RoleBuilder, params.Reason)
+				req := &maprotocol.ParallelBuildRequest{Subtasks: params.Subtasks}
+				if params.Design != "" {
+					req.Design = &maprotocol.DesignResponse{
+						Architecture: params.Design,
+					}
+				}
 
-			return text, nil
-		})
+				resp, err := planner.CallParallelBuilders(ctx, req)
+				if err != nil {
+					return "", fmt.Errorf("parallel build failed: %w", err)
+				}
+				return formatParallelBuildResult(resp), nil
+			})
+	}
 
 	// Register reviewer tool
-	claude.AddTool(registry, "reviewer",
-		"Review code changes for correctness, style, and adherence to design. Use this after building to verify the implementation.",
-		func(ctx context.Context, params ReviewerParams) (string, error) {
-			req := &maprotocol.ReviewRequest{
-				Task:         params.Task,
-				FilesChanged: params.Files,
-			}
-
-			if params.Design != "" {
-				req.OriginalDesign = &maprotocol.DesignResponse{
-					Architecture: params.Design,
+	if planner.hasStage(agent.RoleReviewer) {
+		claude.AddTool(registry, "reviewer",
+			"Review code changes for correctness, style, and adherence to design. Use this after building to verify the implementation.",
+			func(ctx context.Context, params ReviewerParams) (string, error) {
+				planner.recordStageDecision(agent.RoleReviewer, params.Reason)
+				req := &maprotocol.ReviewRequest{
+					Task:         params.Task,
+					FilesChanged: params.Files,
+				}
+
+				if params.Design != "" {
+					req.OriginalDesign = &maprotocol.DesignResponse{
+						Architecture: params.Design,
+					}
+				}
+
+				resp, err := planner.CallReviewer(ctx, req)
+				if err != nil {
+					return "", fmt.Errorf("reviewer failed: %w", err)
+				}
+
+				jsonBytes, _ := json.Marshal(resp)
+				approved := !resp.HasCriticalIssues()
+				text := fmt.Sprintf("Review completed.\n\n<review_json>\n%s\n</review_json>\n\nApproved: %v", string(jsonBytes), approved)
+				if resp.Summary != "" {
+					text += fmt.Sprintf("\nSummary: %s", resp.Summary)
 				}
-			}
-
-			resp, err := planner.CallReviewer(ctx, req)
-			if err != nil {
-				return "", fmt.Errorf("reviewer failed: %w", err)
-			}
-
-			jsonBytes, _ := json.Marshal(resp)
-			approved := !resp.HasCriticalIssues()
-			text := fmt.Sprintf("Review completed.\n\n<review_json>\n%s\n</review_json>\n\nApproved: %v", string(jsonBytes), approved)
-			if resp.Summary != "" {
-				text += fmt.Sprintf("\nSummary: %s", resp.Summary)
-			}
-			if len(resp.Issues) > 0 {
-				text += "\nIssues found:"
-				for i := range resp.Issues {
-					text += fmt.Sprintf("\n- [%s] %s: %s", resp.Issues[i].Severity, resp.Issues[i].File, resp.Issues[i].Message)
+				if len(resp.Issues) > 0 {
+					text += "\nIssues found:"
+					for i := range resp.Issues {
+						text += fmt.Sprintf("\n- [%s] %s: %s", resp.Issues[i].Severity, resp.Issues[i].File, resp.Issues[i].Message)
+					}
 				}
-			}
 
-			return text, nil
-		})
+				return text, nil
+			})
+	}
 
 	return registry
 }
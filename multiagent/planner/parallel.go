@@ -0,0 +1,384 @@
+package planner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
+	"github.com/bazelment/yoloswe/multiagent/checkpoint"
+	"github.com/bazelment/yoloswe/multiagent/progress"
+	"github.com/bazelment/yoloswe/multiagent/protocol"
+	"github.com/bazelment/yoloswe/multiagent/subagents/builder"
+)
+
+// WorktreeProvider gives each parallel Builder lane its own checkout of the
+// repository, so concurrent Builders never write to the same tree.
+type WorktreeProvider interface {
+	// Snapshot records the working directory as it is now, including
+	// unpushed commits and uncommitted changes, as the starting point of
+	// the lanes created after it.
+	Snapshot(ctx context.Context) error
+	// Create checks out a new worktree at the last snapshot on a new branch
+	// and returns its path.
+	Create(ctx context.Context, branch, goal string) (string, error)
+	// Merge applies the changes made in branch's worktree since the
+	// snapshot to the working directory with a three-way merge. When they
+	// conflict, the working directory is left as it was and the error wraps
+	// ErrMergeConflict.
+	Merge(ctx context.Context, branch string) error
+	// Remove deletes the worktree and its branch.
+	Remove(ctx context.Context, branch string) error
+}
+
+// ErrMergeConflict is returned by WorktreeProvider.Merge when a lane's
+// changes conflict with the working directory.
+var ErrMergeConflict = errors.New("merge conflict")
+
+// BuilderRun records one Builder invocation for the mission summary.
+type BuilderRun struct {
+	Subtask       string   `json:"subtask,omitempty"`
+	Task          string   `json:"task"`
+	WorkDir       string   `json:"work_dir"`
+	Error         string   `json:"error,omitempty"`
+	FilesCreated  []string `json:"files_created,omitempty"`
+	FilesModified []string `json:"files_modified,omitempty"`
+	Cost          float64  `json:"cost"`
+	DurationMs    int64    `json:"duration_ms"`
+}
+
+// builderFunc runs one Builder in cfg.WorkDir and returns the files it
+// touched and what it cost. It is a field on Planner so tests can stand in
+// for the Claude-backed Builder.
+type builderFunc func(ctx context.Context, cfg agent.AgentConfig, swarmSessionID, prompt string) (*agent.ExecuteResult, float64, error)
+
+// runBuilderAgent is the builderFunc backed by the Builder sub-agent.
+func runBuilderAgent(ctx context.Context, cfg agent.AgentConfig, swarmSessionID, prompt string) (*agent.ExecuteResult, float64, error) {
+	b := builder.New(cfg, swarmSessionID)
+	result, execResult, taskID, err := b.ExecuteWithFiles(ctx, prompt)
+	if err != nil {
+		return nil, b.TotalCost(), fmt.Errorf("builder failed (task %s): %w", taskID, err)
+	}
+	if !result.Success {
+		return execResult, b.TotalCost(), fmt.Errorf("builder task failed: %v", result.Error)
+	}
+	return execResult, b.TotalCost(), nil
+}
+
+// BuilderRuns returns every Builder invocation so far, in completion order.
+func (p *Planner) BuilderRuns() []BuilderRun {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]BuilderRun(nil), p.builderRuns...)
+}
+
+func (p *Planner) recordBuilderRun(run BuilderRun) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.builderRuns = append(p.builderRuns, run)
+}
+
+// parallelBuildsEnabled reports whether the Planner may fan subtasks out
+// to concurrent Builders.
+func (p *Planner) parallelBuildsEnabled() bool {
+	return p.maxParallelBuilders > 1 && p.worktrees != nil && p.hasStage(agent.RoleBuilder)
+}
+
+// CallParallelBuilders builds independent subtasks concurrently, at most
+// MaxParallelBuilders at a time. Subtasks that declare a shared file are
+// grouped into one lane and built in order; each lane gets its own
+// worktree, starting from a snapshot of the Planner's working directory.
+// When the lanes finish, their changes are merged into the working
+// directory with a three-way merge. A lane that conflicts with the lanes
+// merged before it is not merged; its subtasks are rebuilt one by one in
+// the working directory instead.
+//
+// Without parallel builds enabled, every subtask is built in turn in the
+// working directory. Failed subtasks are reported in the response rather
+// than as an error; an error means the build could not run at all.
+func (p *Planner) CallParallelBuilders(ctx context.Context, req *protocol.ParallelBuildRequest) (*protocol.ParallelBuildResponse, error) {
+	if len(req.Subtasks) == 0 {
+		return nil, fmt.Errorf("no subtasks to build")
+	}
+	subtasks := append([]protocol.Subtask(nil), req.Subtasks...)
+	for i := range subtasks {
+		if subtasks[i].ID == "" {
+			subtasks[i].ID = fmt.Sprintf("subtask-%d", i+1)
+		}
+	}
+	req = &protocol.ParallelBuildRequest{Design: req.Design, Subtasks: subtasks}
+	if err := p.checkIterations(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	iteration := p.iterationCount + 1
+	p.mu.Unlock()
+	if p.progress != nil {
+		p.progress.Event(progress.NewPhaseChangeEvent(checkpoint.PhaseDesigning, checkpoint.PhaseBuilding, iteration))
+	}
+	if p.checkpointMgr != nil {
+		if err := p.checkpointMgr.StartBuild(); err != nil {
+			fmt.Printf("Warning: failed to save checkpoint: %v\n", err)
+		}
+	}
+
+	results := make([]protocol.SubtaskResult, len(req.Subtasks))
+	lanes := planLanes(req.Subtasks)
+	parallel := p.parallelBuildsEnabled() && len(lanes) > 1
+	if parallel {
+		if err := p.worktrees.Snapshot(ctx); err != nil {
+			fmt.Printf("Warning: building subtasks serially: %v\n", err)
+			parallel = false
+		}
+	}
+	if parallel {
+		p.buildLanes(ctx, req, lanes, results)
+	} else {
+		for i := range req.Subtasks {
+			results[i] = p.buildSubtask(ctx, req.Subtasks[i], req.Design, p.config.WorkDir)
+		}
+	}
+	p.incrementIterations()
+
+	resp := &protocol.ParallelBuildResponse{Results: results}
+	merged := &protocol.BuildResponse{}
+	var cost float64
+	for _, res := range results {
+		cost += res.Cost
+		if res.Error == "" {
+			merged.FilesCreated = append(merged.FilesCreated, res.FilesCreated...)
+			merged.FilesModified = append(merged.FilesModified, res.FilesModified...)
+		}
+	}
+	p.mu.Lock()
+	p.filesCreated = append(p.filesCreated, merged.FilesCreated...)
+	p.filesModified = append(p.filesModified, merged.FilesModified...)
+	p.mu.Unlock()
+	if p.checkpointMgr != nil {
+		if failed := resp.Failed(); len(failed) > 0 {
+			_ = p.checkpointMgr.Fail(fmt.Errorf("subtask %s failed: %s", failed[0].ID, failed[0].Error))
+		} else if err := p.checkpointMgr.CompleteBuild(merged, cost); err != nil {
+			fmt.Printf("Warning: failed to save checkpoint: %v\n", err)
+		}
+	}
+	return resp, nil
+}
+
+// laneBuild is the outcome of building one lane in its own worktree.
+type laneBuild struct {
+	err    error
+	dir    string
+	branch string
+}
+
+// buildLanes runs each lane in its own worktree, bounded by
+// maxParallelBuilders, then merges the lanes into the working directory in
+// request order, filling results.
+func (p *Planner) buildLanes(ctx context.Context, req *protocol.ParallelBuildRequest, lanes [][]int, results []protocol.SubtaskResult) {
+	builds := make([]laneBuild, len(lanes))
+	sem := make(chan struct{}, p.maxParallelBuilders)
+	var wg sync.WaitGroup
+	for li, lane := range lanes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			branch := laneBranch(p.swarmSessionID, li)
+			dir, err := p.worktrees.Create(ctx, branch, req.Subtasks[lane[0]].Task)
+			if err != nil {
+				builds[li].err = fmt.Errorf("create worktree %s: %w", branch, err)
+				return
+			}
+			builds[li] = laneBuild{dir: dir, branch: branch}
+			for _, i := range lane {
+				results[i] = p.buildSubtask(ctx, req.Subtasks[i], req.Design, dir)
+				if results[i].Error != "" {
+					builds[li].err = fmt.Errorf("earlier subtask %s in its lane failed", req.Subtasks[i].ID)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Merge lanes in order. A lane that conflicts with the ones merged
+	// before it is rebuilt serially afterwards; one with a failed subtask
+	// is not merged at all, since its tree holds the failed build's edits.
+	var serialize [][]int
+	for li, lane := range lanes {
+		b := builds[li]
+		for _, i := range lane {
+			if results[i].ID == "" {
+				results[i] = protocol.SubtaskResult{ID: req.Subtasks[i].ID, Task: req.Subtasks[i].Task, Error: "not built: " + b.err.Error()}
+			}
+		}
+		if b.dir == "" {
+			continue
+		}
+		err := b.err
+		if err == nil {
+			err = p.worktrees.Merge(ctx, b.branch)
+			if errors.Is(err, ErrMergeConflict) {
+				serialize = append(serialize, lane)
+				continue
+			}
+		}
+		for _, i := range lane {
+			if results[i].Error != "" {
+				continue
+			}
+			if err != nil {
+				results[i].Error = fmt.Sprintf("not merged into %s: %v", p.config.WorkDir, err)
+				continue
+			}
+			results[i].FilesCreated = rebase(results[i].FilesCreated, b.dir, p.config.WorkDir)
+			results[i].FilesModified = rebase(results[i].FilesModified, b.dir, p.config.WorkDir)
+		}
+	}
+	for _, b := range builds {
+		if b.branch == "" {
+			continue
+		}
+		if err := p.worktrees.Remove(context.WithoutCancel(ctx), b.branch); err != nil {
+			fmt.Printf("Warning: failed to remove worktree %s: %v\n", b.branch, err)
+		}
+	}
+
+	// The lane's worktree is gone and its changes were not merged, so the
+	// subtasks after a failed rebuild have nothing to show for their lane
+	// results; only their cost is kept.
+	for _, lane := range serialize {
+		var failed string
+		for _, i := range lane {
+			if failed != "" {
+				results[i] = protocol.SubtaskResult{ID: req.Subtasks[i].ID, Task: req.Subtasks[i].Task, Cost: results[i].Cost,
+					Error: fmt.Sprintf("not built: earlier subtask %s in its lane failed", failed)}
+				continue
+			}
+			prevCost := results[i].Cost
+			results[i] = p.buildSubtask(ctx, req.Subtasks[i], req.Design, p.config.WorkDir)
+			results[i].Cost += prevCost
+			results[i].Serialized = true
+			if results[i].Error != "" {
+				failed = req.Subtasks[i].ID
+			}
+		}
+	}
+}
+
+// buildSubtask runs one Builder for st in dir and records the run.
+func (p *Planner) buildSubtask(ctx context.Context, st protocol.Subtask, design *protocol.DesignResponse, dir string) protocol.SubtaskResult {
+	start := time.Now()
+	if p.progress != nil {
+		p.progress.Event(progress.NewAgentStartEvent(agent.RoleBuilder, st.ID, st.Task))
+	}
+
+	cfg := p.builderConfig
+	cfg.WorkDir = dir
+	if p.progress != nil {
+		cfg.OnRetry = func(ev agent.RetryAgentEvent) {
+			p.progress.Event(progress.NewRetryEvent(agent.RoleBuilder, ev))
+		}
+	}
+	prompt := formatBuildPrompt(&protocol.BuildRequest{Task: st.Task, WorkDir: dir, Design: design})
+	execResult, cost, err := p.runBuilder(ctx, cfg, p.swarmSessionID, prompt)
+	duration := time.Since(start)
+
+	res := protocol.SubtaskResult{ID: st.ID, Task: st.Task, Cost: cost}
+	if execResult != nil {
+		res.FilesCreated = execResult.FilesCreated
+		res.FilesModified = execResult.FilesModified
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	p.mu.Lock()
+	p.totalCost += cost
+	p.mu.Unlock()
+	p.recordBuilderRun(BuilderRun{
+		Subtask:       st.ID,
+		Task:          st.Task,
+		WorkDir:       dir,
+		Error:         res.Error,
+		FilesCreated:  res.FilesCreated,
+		FilesModified: res.FilesModified,
+		Cost:          cost,
+		DurationMs:    duration.Milliseconds(),
+	})
+	if p.progress != nil {
+		p.progress.Event(progress.NewAgentCompleteEvent(agent.RoleBuilder, st.ID, err == nil, cost, duration, err))
+	}
+	return res
+}
+
+// planLanes groups subtasks that share a declared file, directly or through
+// other subtasks, into lanes. Lanes and the subtasks in them keep request
+// order. Subtasks that declare no files are assumed independent and get a
+// lane each.
+func planLanes(subtasks []protocol.Subtask) [][]int {
+	parent := make([]int, len(subtasks))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	owner := make(map[string]int)
+	for i, st := range subtasks {
+		for _, f := range st.Files {
+			f = filepath.Clean(f)
+			if j, ok := owner[f]; ok {
+				// Root at the earlier subtask so lanes keep request order.
+				ri, rj := find(i), find(j)
+				parent[max(ri, rj)] = min(ri, rj)
+			} else {
+				owner[f] = i
+			}
+		}
+	}
+	var lanes [][]int
+	laneOf := make(map[int]int)
+	for i := range subtasks {
+		root := find(i)
+		l, ok := laneOf[root]
+		if !ok {
+			l = len(lanes)
+			laneOf[root] = l
+			lanes = append(lanes, nil)
+		}
+		lanes[l] = append(lanes[l], i)
+	}
+	return lanes
+}
+
+var unsafeBranchChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// laneBranch names the worktree branch for one lane of a swarm session.
+func laneBranch(swarmSessionID string, lane int) string {
+	return fmt.Sprintf("%s-lane-%d", unsafeBranchChars.ReplaceAllString(swarmSessionID, "-"), lane+1)
+}
+
+// rebase rewrites absolute paths under from to the same paths under to.
+func rebase(paths []string, from, to string) []string {
+	out := make([]string, len(paths))
+	for i, f := range paths {
+		out[i] = f
+		if rel, err := filepath.Rel(from, f); err == nil && filepath.IsAbs(f) && !strings.HasPrefix(rel, "..") {
+			out[i] = filepath.Join(to, rel)
+		}
+	}
+	return out
+}
@@ -0,0 +1,362 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
+	"github.com/bazelment/yoloswe/multiagent/protocol"
+)
+
+// fakeWorktrees hands out temp directories as lane worktrees, seeded with
+// a copy of work at Snapshot time. Merge copies a lane's files into work and
+// reports a conflict for a file that work has changed since the snapshot.
+type fakeWorktrees struct {
+	dirs     map[string]string
+	snapshot map[string]string
+	root     string
+	work     string
+	removed  []string
+	mu       sync.Mutex
+}
+
+func (f *fakeWorktrees) Snapshot(_ context.Context) error {
+	files, err := readTree(f.work)
+	f.snapshot = files
+	return err
+}
+
+func (f *fakeWorktrees) Create(_ context.Context, branch, _ string) (string, error) {
+	dir := filepath.Join(f.root, branch)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	for name, data := range f.snapshot {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			return "", err
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.dirs == nil {
+		f.dirs = make(map[string]string)
+	}
+	f.dirs[branch] = dir
+	return dir, nil
+}
+
+func (f *fakeWorktrees) Merge(_ context.Context, branch string) error {
+	f.mu.Lock()
+	dir := f.dirs[branch]
+	f.mu.Unlock()
+	lane, err := readTree(dir)
+	if err != nil {
+		return err
+	}
+	work, err := readTree(f.work)
+	if err != nil {
+		return err
+	}
+	changed := make(map[string]string)
+	for name, data := range lane {
+		if base, ok := f.snapshot[name]; ok && base == data {
+			continue
+		}
+		if cur, ok := work[name]; ok && cur != f.snapshot[name] && cur != data {
+			return fmt.Errorf("%w: %s", ErrMergeConflict, name)
+		}
+		changed[name] = data
+	}
+	for name, data := range changed {
+		if err := os.WriteFile(filepath.Join(f.work, name), []byte(data), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeWorktrees) Remove(_ context.Context, branch string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removed = append(f.removed, branch)
+	return os.RemoveAll(f.dirs[branch])
+}
+
+// readTree returns the contents of the regular files directly in dir.
+func readTree(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files[e.Name()] = string(data)
+	}
+	return files, nil
+}
+
+// fakeBuilder writes "<task>.txt", or the file named after "writes:" in the
+// task, into the builder's work dir.
+func fakeBuilder(active, peak *int32) builderFunc {
+	return func(_ context.Context, cfg agent.AgentConfig, _, prompt string) (*agent.ExecuteResult, float64, error) {
+		n := atomic.AddInt32(active, 1)
+		defer atomic.AddInt32(active, -1)
+		for {
+			p := atomic.LoadInt32(peak)
+			if n <= p || atomic.CompareAndSwapInt32(peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		task := strings.TrimSpace(strings.SplitN(strings.TrimPrefix(prompt, "Task: "), "\n", 2)[0])
+		if task == "fail" {
+			return nil, 0.5, fmt.Errorf("builder task failed")
+		}
+		name := task + ".txt"
+		if _, f, ok := strings.Cut(task, "writes:"); ok {
+			name = f
+		}
+		path := filepath.Join(cfg.WorkDir, name)
+		if err := os.WriteFile(path, []byte(task), 0644); err != nil {
+			return nil, 0, err
+		}
+		return &agent.ExecuteResult{FilesCreated: []string{path}}, 1, nil
+	}
+}
+
+func newParallelTestPlanner(t *testing.T, maxParallel int, worktrees WorktreeProvider) *Planner {
+	t.Helper()
+	p := New(Config{
+		PlannerConfig:       agent.AgentConfig{WorkDir: t.TempDir(), SessionDir: t.TempDir()},
+		BuilderConfig:       agent.AgentConfig{SessionDir: t.TempDir()},
+		MaxParallelBuilders: maxParallel,
+		Worktrees:           worktrees,
+	}, "swarm-test")
+	if f, ok := worktrees.(*fakeWorktrees); ok {
+		f.work = p.config.WorkDir
+	}
+	return p
+}
+
+func TestPlanLanes(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtasks []protocol.Subtask
+		want     [][]int
+	}{
+		{
+			name:     "no declared files",
+			subtasks: []protocol.Subtask{{Task: "a"}, {Task: "b"}},
+			want:     [][]int{{0}, {1}},
+		},
+		{
+			name: "shared file joins a lane",
+			subtasks: []protocol.Subtask{
+				{Task: "a", Files: []string{"x.go"}},
+				{Task: "b", Files: []string{"y.go"}},
+				{Task: "c", Files: []string{"./x.go"}},
+			},
+			want: [][]int{{0, 2}, {1}},
+		},
+		{
+			name: "bridging subtask merges lanes",
+			subtasks: []protocol.Subtask{
+				{Task: "a", Files: []string{"x.go"}},
+				{Task: "b", Files: []string{"y.go"}},
+				{Task: "c", Files: []string{"y.go", "x.go"}},
+				{Task: "d"},
+			},
+			want: [][]int{{0, 1, 2}, {3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := planLanes(tt.subtasks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planLanes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallParallelBuildersMergesLanes(t *testing.T) {
+	wts := &fakeWorktrees{root: t.TempDir()}
+	p := newParallelTestPlanner(t, 2, wts)
+	var active, peak int32
+	p.runBuilder = fakeBuilder(&active, &peak)
+
+	resp, err := p.CallParallelBuilders(context.Background(), &protocol.ParallelBuildRequest{
+		Subtasks: []protocol.Subtask{{ID: "a", Task: "alpha"}, {Task: "beta"}, {Task: "gamma"}, {Task: "delta"}},
+	})
+	if err != nil {
+		t.Fatalf("CallParallelBuilders() error = %v", err)
+	}
+	if peak > 2 {
+		t.Errorf("peak concurrent builders = %d, want at most 2", peak)
+	}
+
+	workDir := p.config.WorkDir
+	for i, name := range []string{"alpha", "beta", "gamma", "delta"} {
+		res := resp.Results[i]
+		if res.Error != "" {
+			t.Fatalf("subtask %s error = %s", res.ID, res.Error)
+		}
+		want := filepath.Join(workDir, name+".txt")
+		if !reflect.DeepEqual(res.FilesCreated, []string{want}) {
+			t.Errorf("subtask %s FilesCreated = %v, want [%s]", res.ID, res.FilesCreated, want)
+		}
+		if data, err := os.ReadFile(want); err != nil || string(data) != name {
+			t.Errorf("merged %s = %q, %v", want, data, err)
+		}
+	}
+	if resp.Results[1].ID != "subtask-2" {
+		t.Errorf("default subtask ID = %q, want subtask-2", resp.Results[1].ID)
+	}
+	if len(wts.removed) != 4 {
+		t.Errorf("removed worktrees = %v, want all 4 lanes", wts.removed)
+	}
+	if got := len(p.BuilderRuns()); got != 4 {
+		t.Errorf("BuilderRuns() has %d runs, want 4", got)
+	}
+	if p.TotalCost() != 4 {
+		t.Errorf("TotalCost() = %v, want 4", p.TotalCost())
+	}
+	if p.IterationCount() != 1 {
+		t.Errorf("IterationCount() = %d, want 1", p.IterationCount())
+	}
+}
+
+func TestCallParallelBuildersSerializesOverlaps(t *testing.T) {
+	wts := &fakeWorktrees{root: t.TempDir()}
+	p := newParallelTestPlanner(t, 4, wts)
+	var active, peak int32
+	p.runBuilder = fakeBuilder(&active, &peak)
+
+	// Neither subtask declared the shared file, so they run in parallel
+	// and the second lane's overlap is only found at merge time.
+	resp, err := p.CallParallelBuilders(context.Background(), &protocol.ParallelBuildRequest{
+		Subtasks: []protocol.Subtask{{Task: "one writes:shared.txt"}, {Task: "two writes:shared.txt"}},
+	})
+	if err != nil {
+		t.Fatalf("CallParallelBuilders() error = %v", err)
+	}
+	if resp.Results[0].Serialized || !resp.Results[1].Serialized {
+		t.Errorf("Serialized = %v/%v, want only the second subtask rebuilt", resp.Results[0].Serialized, resp.Results[1].Serialized)
+	}
+	data, err := os.ReadFile(filepath.Join(p.config.WorkDir, "shared.txt"))
+	if err != nil || string(data) != "two writes:shared.txt" {
+		t.Errorf("shared.txt = %q, %v; want the serialized rebuild's content", data, err)
+	}
+	if resp.Results[1].Cost != 2 {
+		t.Errorf("serialized subtask cost = %v, want both runs (2)", resp.Results[1].Cost)
+	}
+	if got := len(p.BuilderRuns()); got != 3 {
+		t.Errorf("BuilderRuns() has %d runs, want 3", got)
+	}
+}
+
+func TestCallParallelBuildersSerializedLaneFailsPartway(t *testing.T) {
+	wts := &fakeWorktrees{root: t.TempDir()}
+	p := newParallelTestPlanner(t, 4, wts)
+	var active, peak int32
+	build := fakeBuilder(&active, &peak)
+	// "two" builds in its lane but fails when rebuilt in the mission
+	// worktree.
+	p.runBuilder = func(ctx context.Context, cfg agent.AgentConfig, swarmSessionID, prompt string) (*agent.ExecuteResult, float64, error) {
+		if cfg.WorkDir == p.config.WorkDir && strings.HasPrefix(prompt, "Task: two") {
+			return nil, 0.5, fmt.Errorf("builder task failed")
+		}
+		return build(ctx, cfg, swarmSessionID, prompt)
+	}
+
+	resp, err := p.CallParallelBuilders(context.Background(), &protocol.ParallelBuildRequest{
+		Subtasks: []protocol.Subtask{
+			{ID: "one", Task: "one writes:shared.txt"},
+			{ID: "two", Task: "two writes:shared.txt", Files: []string{"y.go"}},
+			{ID: "three", Task: "three", Files: []string{"y.go"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CallParallelBuilders() error = %v", err)
+	}
+	failed := resp.Failed()
+	if len(failed) != 2 || failed[0].ID != "two" || failed[1].ID != "three" {
+		t.Fatalf("Failed() = %+v, want two and the subtask after it in its lane", failed)
+	}
+	if !strings.Contains(failed[1].Error, "not built: earlier subtask two") {
+		t.Errorf("skipped subtask error = %q", failed[1].Error)
+	}
+	if len(failed[1].FilesCreated) != 0 {
+		t.Errorf("skipped subtask FilesCreated = %v, want none", failed[1].FilesCreated)
+	}
+	if _, err := os.Stat(filepath.Join(p.config.WorkDir, "three.txt")); !os.IsNotExist(err) {
+		t.Errorf("three.txt exists in the mission worktree (err = %v); its lane was not merged", err)
+	}
+}
+
+func TestCallParallelBuildersReportsFailures(t *testing.T) {
+	wts := &fakeWorktrees{root: t.TempDir()}
+	p := newParallelTestPlanner(t, 2, wts)
+	var active, peak int32
+	p.runBuilder = fakeBuilder(&active, &peak)
+
+	resp, err := p.CallParallelBuilders(context.Background(), &protocol.ParallelBuildRequest{
+		Subtasks: []protocol.Subtask{
+			{ID: "bad", Task: "fail", Files: []string{"x.go"}},
+			{ID: "after", Task: "after", Files: []string{"x.go"}},
+			{ID: "ok", Task: "ok"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CallParallelBuilders() error = %v", err)
+	}
+	failed := resp.Failed()
+	if len(failed) != 2 || failed[0].ID != "bad" || failed[1].ID != "after" {
+		t.Fatalf("Failed() = %+v, want bad and the skipped subtask after it", failed)
+	}
+	if !strings.Contains(failed[1].Error, "not built") {
+		t.Errorf("skipped subtask error = %q", failed[1].Error)
+	}
+	if _, err := os.Stat(filepath.Join(p.config.WorkDir, "ok.txt")); err != nil {
+		t.Errorf("independent lane was not merged: %v", err)
+	}
+}
+
+func TestCallParallelBuildersWithoutWorktreesRunsSerially(t *testing.T) {
+	p := newParallelTestPlanner(t, 4, nil)
+	var active, peak int32
+	p.runBuilder = fakeBuilder(&active, &peak)
+
+	resp, err := p.CallParallelBuilders(context.Background(), &protocol.ParallelBuildRequest{
+		Subtasks: []protocol.Subtask{{Task: "alpha"}, {Task: "beta"}},
+	})
+	if err != nil {
+		t.Fatalf("CallParallelBuilders() error = %v", err)
+	}
+	if peak != 1 {
+		t.Errorf("peak concurrent builders = %d, want 1", peak)
+	}
+	if len(resp.Failed()) != 0 {
+		t.Errorf("Failed() = %+v", resp.Failed())
+	}
+	for _, tool := range NewPlannerToolHandler(p).Tools() {
+		if tool.Name == "build_parallel" {
+			t.Error("build_parallel offered without a worktree provider")
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -29,15 +30,21 @@ type Planner struct {
 	stateMachine        *StateMachine
 	checkpointMgr       *checkpoint.Manager
 	toolHandler         *PlannerToolHandler
+	worktrees           WorktreeProvider
+	runBuilder          builderFunc
 	swarmSessionID      string
+	pipeline            []agent.AgentRole
 	filesModified       []string
 	filesCreated        []string
+	builderRuns         []BuilderRun
+	decisions           []checkpoint.Decision
 	reviewerConfig      agent.AgentConfig
 	config              agent.AgentConfig
 	designerConfig      agent.AgentConfig
 	builderConfig       agent.AgentConfig
 	phaseStats          PhaseStats
 	maxIterations       int
+	maxParallelBuilders int
 	totalCost           float64
 	iterationCount      int
 	mu                  sync.Mutex
@@ -49,35 +56,56 @@ type Planner struct {
 
 // Config holds configuration for the Planner and its sub-agents.
 type Config struct {
-	Progress            progress.Reporter
-	SessionDir          string
-	PlannerConfig       agent.AgentConfig
-	DesignerConfig      agent.AgentConfig
-	BuilderConfig       agent.AgentConfig
-	ReviewerConfig      agent.AgentConfig
-	MaxIterations       int
+	Progress progress.Reporter
+	// Worktrees provides a worktree per lane of a parallel build. Without
+	// it, subtasks handed off together are built one at a time.
+	Worktrees  WorktreeProvider
+	SessionDir string
+	// Pipeline is the ordered set of sub-agent stages the Planner may call
+	// (see agent.ParsePipeline). Empty means agent.DefaultPipeline.
+	Pipeline       []agent.AgentRole
+	PlannerConfig  agent.AgentConfig
+	DesignerConfig agent.AgentConfig
+	BuilderConfig  agent.AgentConfig
+	ReviewerConfig agent.AgentConfig
+	MaxIterations  int
+	// MaxParallelBuilders bounds concurrent Builders in a parallel build;
+	// 0 or 1 disables the build_parallel tool.
+	MaxParallelBuilders int
 	EnableCheckpointing bool
 }
 
 // New creates a new Planner agent.
 func New(cfg Config, swarmSessionID string) *Planner {
 	cfg.PlannerConfig.Role = agent.RolePlanner
+	pipeline := cfg.Pipeline
+	if len(pipeline) == 0 {
+		pipeline = agent.DefaultPipeline
+	}
+	parallel := cfg.MaxParallelBuilders > 1 && cfg.Worktrees != nil
 	if cfg.PlannerConfig.SystemPrompt == "" {
-		cfg.PlannerConfig.SystemPrompt = SystemPrompt
+		cfg.PlannerConfig.SystemPrompt = SystemPromptForPipeline(pipeline)
+		if parallel && slices.Contains(pipeline, agent.RoleBuilder) {
+			cfg.PlannerConfig.SystemPrompt += parallelBuildPrompt(cfg.MaxParallelBuilders)
+		}
 	}
 
 	p := &Planner{
-		session:           agent.NewLongRunningSession(cfg.PlannerConfig, swarmSessionID),
-		config:            cfg.PlannerConfig,
-		swarmSessionID:    swarmSessionID,
-		designerConfig:    cfg.DesignerConfig,
-		builderConfig:     cfg.BuilderConfig,
-		reviewerConfig:    cfg.ReviewerConfig,
-		maxIterations:     cfg.MaxIterations,
-		filesCreated:      make([]string, 0),
-		filesModified:     make([]string, 0),
-		checkpointEnabled: cfg.EnableCheckpointing,
-		stateMachine:      NewStateMachine(),
+		session:             agent.NewLongRunningSession(cfg.PlannerConfig, swarmSessionID),
+		config:              cfg.PlannerConfig,
+		swarmSessionID:      swarmSessionID,
+		pipeline:            append([]agent.AgentRole(nil), pipeline...),
+		designerConfig:      cfg.DesignerConfig,
+		builderConfig:       cfg.BuilderConfig,
+		reviewerConfig:      cfg.ReviewerConfig,
+		maxIterations:       cfg.MaxIterations,
+		maxParallelBuilders: cfg.MaxParallelBuilders,
+		worktrees:           cfg.Worktrees,
+		runBuilder:          runBuilderAgent,
+		filesCreated:        make([]string, 0),
+		filesModified:       make([]string, 0),
+		checkpointEnabled:   cfg.EnableCheckpointing,
+		stateMachine:        NewPipelineStateMachine(pipeline),
 	}
 
 	// Initialize checkpoint manager if enabled
@@ -101,6 +129,21 @@ func (p *Planner) Role() agent.AgentRole {
 	return agent.RolePlanner
 }
 
+// Pipeline returns the sub-agent stages this Planner runs, in order.
+func (p *Planner) Pipeline() []agent.AgentRole {
+	return append([]agent.AgentRole(nil), p.pipeline...)
+}
+
+// hasStage reports whether role is part of the Planner's pipeline.
+func (p *Planner) hasStage(role agent.AgentRole) bool {
+	for _, r := range p.pipeline {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 // SessionDir returns the session recording directory.
 func (p *Planner) SessionDir() string {
 	return p.session.SessionDir()
@@ -328,7 +371,13 @@ func (p *Planner) CallBuilder(ctx context.Context, req *protocol.BuildRequest) (
 		}
 	}
 
-	b := builder.New(p.builderConfig, p.swarmSessionID)
+	builderConfig := p.builderConfig
+	if p.progress != nil {
+		builderConfig.OnRetry = func(ev agent.RetryAgentEvent) {
+			p.progress.Event(progress.NewRetryEvent(agent.RoleBuilder, ev))
+		}
+	}
+	b := builder.New(builderConfig, p.swarmSessionID)
 
 	prompt := formatBuildPrompt(req)
 	result, execResult, taskID, err := b.ExecuteWithFiles(ctx, prompt)
@@ -339,6 +388,19 @@ func (p *Planner) CallBuilder(ctx context.Context, req *protocol.BuildRequest) (
 	duration := time.Since(startTime)
 	cost := b.TotalCost()
 
+	run := BuilderRun{Task: req.Task, WorkDir: req.WorkDir, Cost: cost, DurationMs: duration.Milliseconds()}
+	if execResult != nil {
+		run.FilesCreated = execResult.FilesCreated
+		run.FilesModified = execResult.FilesModified
+	}
+	switch {
+	case err != nil:
+		run.Error = err.Error()
+	case !result.Success:
+		run.Error = fmt.Sprint(result.Error)
+	}
+	p.recordBuilderRun(run)
+
 	if err != nil {
 		// Progress: agent failed
 		if p.progress != nil {
@@ -535,6 +597,7 @@ func (p *Planner) RestoreFromCheckpoint(cp *checkpoint.Checkpoint) {
 	p.filesCreated = cp.FilesCreated
 	p.filesModified = cp.FilesModified
 	p.totalCost = cp.TotalCost
+	p.decisions = cp.Decisions
 }
 
 // NewFromCheckpoint creates a Planner and restores its state from a checkpoint.
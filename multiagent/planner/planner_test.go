@@ -476,6 +476,26 @@ func TestStateMachine(t *testing.T) {
 	}
 }
 
+func TestPipelineStateMachine_BuilderOnly(t *testing.T) {
+	sm := NewPipelineStateMachine([]agent.AgentRole{agent.RoleBuilder})
+
+	for _, to := range []PlannerState{StatePlanning, StateBuilding, StateCompleted} {
+		if err := sm.Transition(to, "test"); err != nil {
+			t.Fatalf("expected valid transition to %v, got error: %v", to, err)
+		}
+	}
+
+	sm.Reset()
+	_ = sm.Transition(StatePlanning, "test")
+	if err := sm.Transition(StateDesigning, "test"); err == nil {
+		t.Error("expected Planning->Designing to be invalid without a designer stage")
+	}
+	_ = sm.Transition(StateBuilding, "test")
+	if err := sm.Transition(StateReviewing, "test"); err == nil {
+		t.Error("expected Building->Reviewing to be invalid without a reviewer stage")
+	}
+}
+
 func TestStateMachineFailureTransitions(t *testing.T) {
 	sm := NewStateMachine()
 
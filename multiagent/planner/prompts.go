@@ -1,52 +1,142 @@
 package planner
 
-// SystemPrompt is the system prompt for the Planner agent.
-const SystemPrompt = `You are the Planner agent in a software engineering swarm. You receive missions from
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
+)
+
+// SystemPrompt is the system prompt for the Planner agent running the
+// default designer → builder → reviewer pipeline.
+var SystemPrompt = SystemPromptForPipeline(agent.DefaultPipeline)
+
+// SystemPromptForPipeline returns the Planner system prompt describing only
+// the sub-agent tools in pipeline, in that order.
+func SystemPromptForPipeline(pipeline []agent.AgentRole) string {
+	has := make(map[agent.AgentRole]bool, len(pipeline))
+	for _, role := range pipeline {
+		has[role] = true
+	}
+
+	var b strings.Builder
+	b.WriteString(plannerPromptIntro)
+	b.WriteString("## Your Sub-Agents (Tools)\n\n")
+	b.WriteString(toolsIntro(len(pipeline)))
+	for i, role := range pipeline {
+		fmt.Fprintf(&b, "\n%d. ", i+1)
+		switch role {
+		case agent.RoleDesigner:
+			b.WriteString(designerToolPrompt)
+		case agent.RoleBuilder:
+			b.WriteString(builderToolPrompt(has[agent.RoleDesigner], has[agent.RoleReviewer]))
+		case agent.RoleReviewer:
+			b.WriteString(reviewerToolPrompt(has[agent.RoleDesigner]))
+		}
+	}
+
+	b.WriteString("\n## Your Workflow\n\n")
+	b.WriteString("1. **Analyze the Mission**: Break it into discrete tasks. Consider dependencies.\n\n")
+	b.WriteString("2. **For Each Task**:\n")
+	var steps []string
+	for _, role := range pipeline {
+		switch role {
+		case agent.RoleDesigner:
+			steps = append(steps, "Call designer() to get a technical design")
+		case agent.RoleBuilder:
+			if has[agent.RoleDesigner] {
+				steps = append(steps, "Call builder() to implement the design")
+			} else {
+				steps = append(steps, "Call builder() to implement the task")
+			}
+		case agent.RoleReviewer:
+			steps = append(steps,
+				"Call reviewer() to get feedback",
+				"If reviewer finds critical issues: call builder() again with the feedback",
+				"If reviewer finds only minor issues: decide if worth fixing or acceptable")
+		}
+	}
+	for i, step := range steps {
+		fmt.Fprintf(&b, "   %c. %s\n", 'a'+i, step)
+	}
+	b.WriteString(plannerPromptCompletion)
+
+	b.WriteString("## Decision Making\n\n")
+	if has[agent.RoleReviewer] {
+		b.WriteString(`- YOU decide when work is complete, not the reviewer
+- Reviewer provides feedback; you judge if issues are blockers or acceptable
+- Don't over-iterate: 2-3 build/review cycles max per task
+`)
+	} else {
+		b.WriteString(`- YOU decide when work is complete; there is no reviewer in this pipeline
+- Don't over-iterate: 2-3 build cycles max per task
+`)
+	}
+	b.WriteString("- If stuck, report partial progress with clear explanation\n\n")
+	b.WriteString(plannerPromptTail)
+	return b.String()
+}
+
+func toolsIntro(n int) string {
+	switch n {
+	case 1:
+		return "You have access to one tool that invokes a sub-agent:\n"
+	case 2:
+		return "You have access to two tools that invoke sub-agents:\n"
+	case 3:
+		return "You have access to three tools that invoke sub-agents:\n"
+	default:
+		return fmt.Sprintf("You have access to %d tools that invoke sub-agents:\n", n)
+	}
+}
+
+const plannerPromptIntro = `You are the Planner agent in a software engineering swarm. You receive missions from
 the Orchestrator and execute them by coordinating specialized sub-agents.
 
-## Your Sub-Agents (Tools)
+`
 
-You have access to three tools that invoke sub-agents:
-
-1. **designer**: Creates technical designs
+const designerToolPrompt = `**designer**: Creates technical designs
    - Call when: Starting a new feature, need to think through approach
    - Input: task (string), context (string), constraints (array of strings)
    - Output: Architecture, file specs, interfaces, implementation notes
-
-2. **builder**: Implements code
-   - Call when: Ready to write code, have a clear design
-   - Input: task (string), design (JSON from designer), work_dir (string), feedback (optional, from reviewer)
-   - Output: Files created/modified, test results, build output
-
-3. **reviewer**: Reviews implementation
-   - Call when: Builder has completed work, before marking task done
-   - Input: task (string), files_changed (array of strings), original_design (JSON)
-   - Output: Issues found (critical/minor/nitpick), suggestions
-
-## Your Workflow
-
-1. **Analyze the Mission**: Break it into discrete tasks. Consider dependencies.
-
-2. **For Each Task**:
-   a. Call designer() to get a technical design
-   b. Call builder() to implement the design
-   c. Call reviewer() to get feedback
-   d. If reviewer finds critical issues: call builder() again with the feedback
-   e. If reviewer finds only minor issues: decide if worth fixing or acceptable
-
+`
+
+func builderToolPrompt(withDesigner, withReviewer bool) string {
+	callWhen := "Ready to write code"
+	input := "task (string), work_dir (string)"
+	if withDesigner {
+		callWhen = "Ready to write code, have a clear design"
+		input = "task (string), design (JSON from designer), work_dir (string)"
+	}
+	if withReviewer {
+		input += ", feedback (optional, from reviewer)"
+	}
+	return "**builder**: Implements code\n" +
+		"   - Call when: " + callWhen + "\n" +
+		"   - Input: " + input + "\n" +
+		"   - Output: Files created/modified, test results, build output\n"
+}
+
+func reviewerToolPrompt(withDesigner bool) string {
+	input := "task (string), files_changed (array of strings)"
+	if withDesigner {
+		input += ", original_design (JSON)"
+	}
+	return "**reviewer**: Reviews implementation\n" +
+		"   - Call when: Builder has completed work, before marking task done\n" +
+		"   - Input: " + input + "\n" +
+		"   - Output: Issues found (critical/minor/nitpick), suggestions\n"
+}
+
+const plannerPromptCompletion = `
 3. **Completion**: When all tasks are done, provide a summary including:
    - What was accomplished
    - Files created/modified
    - Any remaining concerns
 
-## Decision Making
-
-- YOU decide when work is complete, not the reviewer
-- Reviewer provides feedback; you judge if issues are blockers or acceptable
-- Don't over-iterate: 2-3 build/review cycles max per task
-- If stuck, report partial progress with clear explanation
+`
 
-## State Management
+const plannerPromptTail = `## State Management
 
 Track across your turns:
 - Current task list and status
@@ -57,8 +147,25 @@ Track across your turns:
 
 Always structure your responses clearly:
 1. State what you're doing
-2. Call the appropriate tool
+2. Call the appropriate tool, passing a reason that says why this agent and
+   not the others (it is kept in the decision log)
 3. Analyze the result
 4. Decide next action
 
 When complete, end with a clear summary of what was accomplished.`
+
+// parallelBuildPrompt describes the build_parallel tool, offered when the
+// swarm may run up to n Builders at once.
+func parallelBuildPrompt(n int) string {
+	return fmt.Sprintf(`
+
+## Parallel Builds
+
+**build_parallel**: Builds several independent subtasks at once, up to %d at a time
+   - Call when: The mission splits into subtasks that don't depend on each other
+   - Input: subtasks (array of {id, task, files}), design (optional)
+   - Output: Per-subtask files created/modified, cost, and errors
+   - List the files each subtask will touch; subtasks sharing a file are built one after another
+   - After build_parallel, review all of its changes together in a single reviewer call
+`, n)
+}
@@ -4,6 +4,8 @@ package planner
 import (
 	"fmt"
 	"sync"
+
+	"github.com/bazelment/yoloswe/multiagent/agent"
 )
 
 // PlannerState represents the current execution state of the Planner.
@@ -69,35 +71,17 @@ type StateTransition struct {
 	To      PlannerState
 }
 
-// validTransitions defines the legal state transitions.
-// The map key is (from, to) encoded as from*100 + to.
-var validTransitions = map[int]bool{
+// baseTransitions are the legal state transitions that do not involve a
+// sub-agent stage. The map key is (from, to) encoded as from*100 + to.
+var baseTransitions = map[int]bool{
 	// From Idle
 	int(StateIdle)*100 + int(StatePlanning): true,
 
 	// From Planning
-	int(StatePlanning)*100 + int(StateDesigning):       true,
-	int(StatePlanning)*100 + int(StateBuilding):        true,
 	int(StatePlanning)*100 + int(StateWaitingForInput): true,
 	int(StatePlanning)*100 + int(StateCompleted):       true,
 	int(StatePlanning)*100 + int(StateFailed):          true,
 
-	// From Designing
-	int(StateDesigning)*100 + int(StatePlanning): true,
-	int(StateDesigning)*100 + int(StateBuilding): true,
-	int(StateDesigning)*100 + int(StateFailed):   true,
-
-	// From Building
-	int(StateBuilding)*100 + int(StatePlanning):  true,
-	int(StateBuilding)*100 + int(StateReviewing): true,
-	int(StateBuilding)*100 + int(StateFailed):    true,
-
-	// From Reviewing
-	int(StateReviewing)*100 + int(StatePlanning):  true,
-	int(StateReviewing)*100 + int(StateBuilding):  true,
-	int(StateReviewing)*100 + int(StateCompleted): true,
-	int(StateReviewing)*100 + int(StateFailed):    true,
-
 	// From WaitingForInput
 	int(StateWaitingForInput)*100 + int(StatePlanning): true,
 	int(StateWaitingForInput)*100 + int(StateFailed):   true,
@@ -107,23 +91,71 @@ var validTransitions = map[int]bool{
 	int(StateFailed)*100 + int(StateIdle):    true,
 }
 
-// isValidTransition checks if a state transition is allowed.
-func isValidTransition(from, to PlannerState) bool {
-	return validTransitions[int(from)*100+int(to)]
+// stageStates maps each pipeline stage to the state it runs in.
+var stageStates = map[agent.AgentRole]PlannerState{
+	agent.RoleDesigner: StateDesigning,
+	agent.RoleBuilder:  StateBuilding,
+	agent.RoleReviewer: StateReviewing,
+}
+
+// pipelineTransitions derives the legal transitions for a pipeline: Planning
+// may enter any stage up to and including the builder, each stage may hand
+// back to Planning, advance to the next stage, or fail, the last stage may
+// complete the mission, and a reviewer may send work back to the builder.
+func pipelineTransitions(pipeline []agent.AgentRole) map[int]bool {
+	transitions := make(map[int]bool, len(baseTransitions)+4*len(pipeline))
+	for k, v := range baseTransitions {
+		transitions[k] = v
+	}
+	edge := func(from, to PlannerState) { transitions[int(from)*100+int(to)] = true }
+
+	beforeBuild := true
+	for i, role := range pipeline {
+		state, ok := stageStates[role]
+		if !ok {
+			continue
+		}
+		if beforeBuild {
+			edge(StatePlanning, state)
+		}
+		if role == agent.RoleBuilder {
+			beforeBuild = false
+		}
+		edge(state, StatePlanning)
+		edge(state, StateFailed)
+		if i+1 < len(pipeline) {
+			edge(state, stageStates[pipeline[i+1]])
+		} else {
+			edge(state, StateCompleted)
+		}
+		if role == agent.RoleReviewer {
+			edge(state, StateBuilding)
+		}
+	}
+	return transitions
 }
 
 // StateMachine manages the Planner's state transitions.
 type StateMachine struct {
-	history []StateTransition
-	mu      sync.RWMutex
-	state   PlannerState
+	transitions map[int]bool
+	history     []StateTransition
+	mu          sync.RWMutex
+	state       PlannerState
 }
 
-// NewStateMachine creates a new state machine starting in Idle state.
+// NewStateMachine creates a new state machine starting in Idle state for
+// the default designer → builder → reviewer pipeline.
 func NewStateMachine() *StateMachine {
+	return NewPipelineStateMachine(agent.DefaultPipeline)
+}
+
+// NewPipelineStateMachine creates a state machine starting in Idle state
+// whose stage transitions follow pipeline.
+func NewPipelineStateMachine(pipeline []agent.AgentRole) *StateMachine {
 	return &StateMachine{
-		state:   StateIdle,
-		history: make([]StateTransition, 0, 16),
+		transitions: pipelineTransitions(pipeline),
+		state:       StateIdle,
+		history:     make([]StateTransition, 0, 16),
 	}
 }
 
@@ -140,7 +172,7 @@ func (sm *StateMachine) Transition(to PlannerState, trigger string) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	if !isValidTransition(sm.state, to) {
+	if !sm.transitions[int(sm.state)*100+int(to)] {
 		return fmt.Errorf("invalid state transition from %s to %s (trigger: %s)",
 			sm.state, to, trigger)
 	}
@@ -85,6 +85,10 @@ func (r *ConsoleReporter) Event(event Event) {
 		r.handleCostUpdate(e)
 	case ErrorEvent:
 		r.handleError(e)
+	case RetryEvent:
+		r.handleRetry(e)
+	case OrchestratorDecisionEvent:
+		r.handleDecision(e)
 	}
 }
 
@@ -177,6 +181,30 @@ func (r *ConsoleReporter) handleError(e ErrorEvent) {
 	fmt.Fprintf(r.out, "  [ERROR] %s: %v\n", e.Context, e.Err)
 }
 
+func (r *ConsoleReporter) handleRetry(e RetryEvent) {
+	fmt.Fprintf(r.out, "  [RETRY] %s hit a transient error (%s), retry %d/%d in %s: %v\n",
+		r.formatRole(e.Role), e.Reason, e.Attempt, e.MaxRetries, e.Delay.Round(time.Second), e.Err)
+}
+
+func (r *ConsoleReporter) handleDecision(e OrchestratorDecisionEvent) {
+	if r.mode < OutputNormal {
+		return
+	}
+
+	line := fmt.Sprintf("  [DECISION] %s: %s", e.Stage, r.formatRole(e.ChosenAgent))
+	if e.Reasoning != "" {
+		line += " - " + e.Reasoning
+	}
+	if len(e.Alternatives) > 0 {
+		alts := make([]string, len(e.Alternatives))
+		for i, alt := range e.Alternatives {
+			alts[i] = alt.String()
+		}
+		line += fmt.Sprintf(" (over %s)", strings.Join(alts, ", "))
+	}
+	fmt.Fprintln(r.out, line)
+}
+
 // Helper methods
 
 func (r *ConsoleReporter) phaseSymbol(phase checkpoint.Phase) string {
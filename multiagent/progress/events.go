@@ -22,6 +22,8 @@ const (
 	EventCostUpdate
 	EventFileChange
 	EventError
+	EventRetry
+	EventOrchestratorDecision
 )
 
 // Event is the interface for all progress events.
@@ -267,3 +269,68 @@ func NewFileChangeEvent(role agent.AgentRole, path, action string) FileChangeEve
 		Action:    action,
 	}
 }
+
+// RetryEvent fires when an agent task is retried after a transient provider
+// error (overload, rate limit, dropped stream).
+type RetryEvent struct {
+	ts         time.Time
+	Err        error
+	Role       agent.AgentRole
+	Provider   string
+	Reason     string
+	Attempt    int
+	MaxRetries int
+	Delay      time.Duration
+}
+
+// Type returns the event type.
+func (e RetryEvent) Type() EventType { return EventRetry }
+
+// Timestamp returns when the event occurred.
+func (e RetryEvent) Timestamp() time.Time { return e.ts }
+
+// NewRetryEvent creates a retry event from the agent-level retry notice.
+func NewRetryEvent(role agent.AgentRole, ev agent.RetryAgentEvent) RetryEvent {
+	return RetryEvent{
+		ts:         time.Now(),
+		Err:        ev.Err,
+		Role:       role,
+		Provider:   ev.Provider,
+		Reason:     ev.Reason,
+		Attempt:    ev.Attempt,
+		MaxRetries: ev.MaxRetries,
+		Delay:      ev.Delay,
+	}
+}
+
+// OrchestratorDecisionEvent fires when the swarm routes a stage of the
+// mission to an agent, recording why and what else it could have chosen.
+type OrchestratorDecisionEvent struct {
+	ts           time.Time
+	Stage        string
+	ChosenAgent  agent.AgentRole
+	Reasoning    string
+	Alternatives []agent.AgentRole
+}
+
+// Type returns the event type.
+func (e OrchestratorDecisionEvent) Type() EventType { return EventOrchestratorDecision }
+
+// Timestamp returns when the event occurred.
+func (e OrchestratorDecisionEvent) Timestamp() time.Time { return e.ts }
+
+// NewOrchestratorDecisionEvent creates a decision event from a decision log
+// entry.
+func NewOrchestratorDecisionEvent(d checkpoint.Decision) OrchestratorDecisionEvent {
+	alternatives := make([]agent.AgentRole, 0, len(d.Alternatives))
+	for _, alt := range d.Alternatives {
+		alternatives = append(alternatives, agent.AgentRole(alt))
+	}
+	return OrchestratorDecisionEvent{
+		ts:           d.Time,
+		Stage:        d.Stage,
+		ChosenAgent:  agent.AgentRole(d.ChosenAgent),
+		Reasoning:    d.Reasoning,
+		Alternatives: alternatives,
+	}
+}
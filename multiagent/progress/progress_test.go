@@ -76,6 +76,20 @@ func TestConsoleReporterEvents(t *testing.T) {
 		agent.RolePlanner: 1.25,
 	}))
 	reporter.Event(NewErrorEvent(errors.New("boom"), "planner"))
+	reporter.Event(NewRetryEvent(agent.RoleBuilder, agent.RetryAgentEvent{
+		Err:        errors.New("529 overloaded"),
+		Reason:     "http_5xx",
+		Attempt:    1,
+		MaxRetries: 3,
+		Delay:      2 * time.Second,
+	}))
+	reporter.Event(NewOrchestratorDecisionEvent(checkpoint.Decision{
+		Time:         time.Now(),
+		Stage:        "building",
+		ChosenAgent:  "builder",
+		Reasoning:    "the change is a one-line fix",
+		Alternatives: []string{"designer", "reviewer"},
+	}))
 
 	got := buf.String()
 	for _, want := range []string{
@@ -83,6 +97,8 @@ func TestConsoleReporterEvents(t *testing.T) {
 		"\n>>> Iteration 2/3: review requested changes\n",
 		"Cost: $1.2500 / $5.00 (25.0%)\n",
 		"  [ERROR] planner: boom\n",
+		"[RETRY] [Builder] hit a transient error (http_5xx), retry 1/3 in 2s: 529 overloaded\n",
+		"  [DECISION] building: [Builder] - the change is a one-line fix (over designer, reviewer)\n",
 	} {
 		if !strings.Contains(got, want) {
 			t.Fatalf("console output %q missing %q", got, want)
@@ -65,6 +65,52 @@ type BuildResponse struct {
 	TestsPassed   bool     `json:"tests_passed"`
 }
 
+// Subtask is one independently buildable piece of a parallel build.
+type Subtask struct {
+	ID   string `json:"id"`
+	Task string `json:"task"`
+	// Files lists the files the subtask is expected to touch. Subtasks
+	// that share a file are built one after another, never in parallel.
+	Files []string `json:"files,omitempty"`
+}
+
+// ParallelBuildRequest asks for several independent subtasks to be built
+// concurrently, each by its own Builder.
+type ParallelBuildRequest struct {
+	Design   *DesignResponse `json:"design,omitempty"`
+	Subtasks []Subtask       `json:"subtasks"`
+}
+
+// SubtaskResult is the outcome of one subtask of a parallel build.
+type SubtaskResult struct {
+	ID            string   `json:"id"`
+	Task          string   `json:"task"`
+	Error         string   `json:"error,omitempty"`
+	FilesCreated  []string `json:"files_created"`
+	FilesModified []string `json:"files_modified"`
+	Cost          float64  `json:"cost"`
+	// Serialized is set when the subtask was rebuilt in the main working
+	// directory because its changes overlapped another subtask's.
+	Serialized bool `json:"serialized,omitempty"`
+}
+
+// ParallelBuildResponse is the merged output of a parallel build, with
+// results in the order the subtasks were requested.
+type ParallelBuildResponse struct {
+	Results []SubtaskResult `json:"results"`
+}
+
+// Failed returns the results of subtasks that did not build.
+func (r *ParallelBuildResponse) Failed() []SubtaskResult {
+	var failed []SubtaskResult
+	for _, res := range r.Results {
+		if res.Error != "" {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
 // ReviewRequest is the input for the Reviewer agent.
 type ReviewRequest struct {
 	OriginalDesign *DesignResponse `json:"original_design"`
@@ -0,0 +1,151 @@
+package wt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// stateFileName is the per-repo state file, kept in the repo dir next to
+// the worktrees and the bare clone.
+const stateFileName = ".wt-state.json"
+
+// repoState is the on-disk form of the per-repo state file.
+type repoState struct {
+	// LastActivity maps a branch to the last time wt or a tool built on it
+	// (wt cd, hooks, agent sessions) touched its worktree.
+	LastActivity map[string]time.Time `json:"last_activity,omitempty"`
+}
+
+func (m *Manager) statePath() string {
+	return filepath.Join(m.RepoDir(), stateFileName)
+}
+
+// loadState reads the state file. A missing or unreadable file is an empty
+// state: activity is advisory and must never block a command.
+func (m *Manager) loadState() repoState {
+	var st repoState
+	if data, err := os.ReadFile(m.statePath()); err == nil {
+		_ = json.Unmarshal(data, &st)
+	}
+	if st.LastActivity == nil {
+		st.LastActivity = map[string]time.Time{}
+	}
+	return st
+}
+
+// saveState writes the state file through a temporary file so a reader
+// never sees a partial write.
+func (m *Manager) saveState(st repoState) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(m.RepoDir(), stateFileName+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), m.statePath())
+}
+
+// updateState applies fn to the state file. It is a no-op in dry-run mode
+// and when the repo has not been initialized. The read-modify-write runs
+// under the state lock, so concurrent wt processes (and bramble) don't drop
+// each other's updates.
+func (m *Manager) updateState(fn func(*repoState)) error {
+	if m.dryRun {
+		return nil
+	}
+	if _, err := os.Stat(m.RepoDir()); err != nil {
+		return err
+	}
+	unlock, err := m.lockState()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	st := m.loadState()
+	fn(&st)
+	return m.saveState(st)
+}
+
+const (
+	// stateLockWait bounds how long updateState waits for another process.
+	stateLockWait = 2 * time.Second
+	// stateLockStale is the age after which a lock is assumed to have been
+	// left behind by a process that died holding it.
+	stateLockStale = 10 * time.Second
+)
+
+// lockState takes the state lock: a lock file next to the state file,
+// created with O_EXCL. The returned func releases it.
+func (m *Manager) lockState() (func(), error) {
+	path := m.statePath() + ".lock"
+	deadline := time.Now().Add(stateLockWait)
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > stateLockStale {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s", path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TouchActivity records now as the last activity time of branch's worktree.
+func (m *Manager) TouchActivity(branch string) error {
+	if branch == "" {
+		return nil
+	}
+	return m.updateState(func(st *repoState) {
+		st.LastActivity[branch] = time.Now().UTC()
+	})
+}
+
+// LastActivity returns the last recorded activity time of branch's
+// worktree. It reports false when nothing has been recorded yet.
+func (m *Manager) LastActivity(branch string) (time.Time, bool) {
+	t, ok := m.loadState().LastActivity[branch]
+	return t, ok
+}
+
+// renameActivity moves branch's activity record to newBranch.
+func (m *Manager) renameActivity(branch, newBranch string) {
+	_ = m.updateState(func(st *repoState) {
+		if t, ok := st.LastActivity[branch]; ok {
+			st.LastActivity[newBranch] = t
+			delete(st.LastActivity, branch)
+		}
+	})
+}
+
+// forgetActivity drops branch's activity record once its worktree is gone.
+func (m *Manager) forgetActivity(branch string) {
+	if _, ok := m.LastActivity(branch); !ok {
+		return
+	}
+	_ = m.updateState(func(st *repoState) {
+		delete(st.LastActivity, branch)
+	})
+}
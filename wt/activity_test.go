@@ -0,0 +1,116 @@
+package wt
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLastActivity(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "repo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(root, "repo")
+
+	if _, ok := m.LastActivity("feature"); ok {
+		t.Fatal("LastActivity() reported activity before any was recorded")
+	}
+
+	before := time.Now().Add(-time.Second)
+	if err := m.TouchActivity("feature"); err != nil {
+		t.Fatalf("TouchActivity() error = %v", err)
+	}
+	got, ok := m.LastActivity("feature")
+	if !ok || got.Before(before) || got.After(time.Now().Add(time.Second)) {
+		t.Fatalf("LastActivity() = %v, %v; want a time around now", got, ok)
+	}
+
+	// A fresh manager reads the same state file.
+	if _, ok := NewManager(root, "repo").LastActivity("feature"); !ok {
+		t.Error("activity not persisted to the state file")
+	}
+
+	m.renameActivity("feature", "feature-v2")
+	if _, ok := m.LastActivity("feature"); ok {
+		t.Error("old branch still has activity after rename")
+	}
+	if moved, ok := m.LastActivity("feature-v2"); !ok || !moved.Equal(got) {
+		t.Errorf("LastActivity(feature-v2) = %v, %v; want %v", moved, ok, got)
+	}
+
+	m.forgetActivity("feature-v2")
+	if _, ok := m.LastActivity("feature-v2"); ok {
+		t.Error("activity kept after forgetActivity")
+	}
+}
+
+func TestTouchActivitySkippedInDryRun(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "repo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(root, "repo", WithDryRun(true))
+	if err := m.TouchActivity("feature"); err != nil {
+		t.Fatalf("TouchActivity() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "repo", stateFileName)); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote the state file (stat err = %v)", err)
+	}
+}
+
+func TestTouchActivityConcurrent(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "repo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Separate managers stand in for separate wt processes.
+	branches := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	var wg sync.WaitGroup
+	for _, b := range branches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := NewManager(root, "repo").TouchActivity(b); err != nil {
+				t.Errorf("TouchActivity(%s) error = %v", b, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	m := NewManager(root, "repo")
+	for _, b := range branches {
+		if _, ok := m.LastActivity(b); !ok {
+			t.Errorf("activity for %s lost to a concurrent update", b)
+		}
+	}
+	if _, err := os.Stat(m.statePath() + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("state lock left behind (stat err = %v)", err)
+	}
+}
+
+func TestLockStateBreaksStaleLock(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "repo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(root, "repo")
+	lock := m.statePath() + ".lock"
+	if err := os.WriteFile(lock, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * stateLockStale)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.TouchActivity("feature"); err != nil {
+		t.Fatalf("TouchActivity() error = %v", err)
+	}
+	if _, ok := m.LastActivity("feature"); !ok {
+		t.Error("activity not recorded after breaking a stale lock")
+	}
+}
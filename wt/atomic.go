@@ -51,13 +51,18 @@ func (op *AtomicOp) Rollback(ctx context.Context) error {
 // If any step fails, all previously completed steps are undone, leaving no
 // orphaned worktrees or branches.
 //
-// Note: post_create hook failures are non-fatal. The worktree remains created;
-// hooks are side-effectful by nature and may not be safely reversible.
+// Note: post_create hook failures are non-fatal by default. The worktree
+// remains created; hooks are side-effectful by nature and may not be safely
+// reversible. Set NewOptions.RollbackOnHookFailure to instead remove the
+// worktree and branch and return the *HookFailedError.
 func (m *Manager) NewAtomic(ctx context.Context, branch, baseBranch, goal string, opts ...NewOptions) (string, error) {
 	var o NewOptions
 	if len(opts) > 0 {
 		o = opts[0]
 	}
+	if err := checkTrackBase(o, baseBranch); err != nil {
+		return "", err
+	}
 	op := m.NewAtomicOp()
 	defer func() {
 		if !op.committed {
@@ -70,27 +75,26 @@ func (m *Manager) NewAtomic(ctx context.Context, branch, baseBranch, goal string
 		return "", ErrRepoNotInitialized
 	}
 
+	branch, err := m.NormalizeBranchName(ctx, branch)
+	if err != nil {
+		return "", err
+	}
+
 	worktreePath := filepath.Join(m.RepoDir(), branch)
 	if _, err := os.Stat(worktreePath); err == nil {
 		return "", ErrWorktreeExists
 	}
 
+	reuse, err := m.reuseLocalBranch(ctx, branch, o)
+	if err != nil {
+		return "", err
+	}
+
 	// Determine base branch (same logic as New)
+	explicitBase := baseBranch != ""
 	if baseBranch == "" {
-		entries, _ := os.ReadDir(m.RepoDir())
-		for _, entry := range entries {
-			if entry.IsDir() {
-				wtPath := filepath.Join(m.RepoDir(), entry.Name())
-				if _, err := os.Stat(filepath.Join(wtPath, ".git")); err == nil {
-					config, err := LoadRepoConfig(wtPath)
-					if err != nil {
-						// Config load failed, try next worktree
-						continue
-					}
-					baseBranch = config.DefaultBase
-					break
-				}
-			}
+		if config, err := m.EffectiveConfig(); err == nil {
+			baseBranch = config.DefaultBase
 		}
 		if baseBranch == "" {
 			baseBranch, _ = GetDefaultBranch(ctx, m.git, bareDir)
@@ -98,7 +102,17 @@ func (m *Manager) NewAtomic(ctx context.Context, branch, baseBranch, goal string
 	}
 
 	// Step 1: Fetch (unless caller already fetched)
-	if !o.SkipFetch {
+	startPoint := "origin/" + baseBranch
+	switch {
+	case reuse:
+		// The existing branch is checked out as is; nothing to fetch.
+	case o.StartPoint != "":
+		startPoint = o.StartPoint
+	case o.Track != "":
+		if startPoint, err = m.prepareTrackedBranch(ctx, o.Track, o.SkipFetch); err != nil {
+			return "", err
+		}
+	case !o.SkipFetch:
 		if err := m.FetchOrigin(ctx); err != nil {
 			return "", err
 		}
@@ -111,30 +125,56 @@ func (m *Manager) NewAtomic(ctx context.Context, branch, baseBranch, goal string
 	m.SyncDefaultBranch(ctx)
 
 	// Step 2: Create worktree + branch
-	m.output.Info(fmt.Sprintf("Creating worktree %s from %s...", branch, baseBranch))
-	if _, err := m.git.Run(ctx, []string{
-		"worktree", "add", "-b", branch, worktreePath, "origin/" + baseBranch,
-	}, bareDir); err != nil {
+	addArgs := []string{"worktree", "add", "-b", branch, worktreePath, startPoint}
+	switch {
+	case reuse:
+		m.output.Info(fmt.Sprintf("Creating worktree for existing branch %s...", branch))
+		addArgs = []string{"worktree", "add", worktreePath, branch}
+	case o.Track != "":
+		m.output.Info(fmt.Sprintf("Creating worktree %s tracking %s...", branch, startPoint))
+		addArgs = []string{"worktree", "add", "--track", "-b", branch, worktreePath, startPoint}
+	default:
+		m.output.Info(fmt.Sprintf("Creating worktree %s from %s...", branch, baseBranch))
+	}
+	if _, err := m.git.Run(ctx, addArgs, bareDir); err != nil {
 		return "", fmt.Errorf("failed to create worktree: %w", err)
 	}
 	op.AddUndo(func(ctx context.Context) error {
 		m.output.Info(fmt.Sprintf("Rolling back: removing worktree %s...", branch))
 		m.git.Run(ctx, []string{"worktree", "remove", "--force", worktreePath}, bareDir)
-		m.git.Run(ctx, []string{"branch", "-D", branch}, bareDir)
+		if !reuse {
+			// A reused branch predates this operation; keep its commits.
+			m.git.Run(ctx, []string{"branch", "-D", branch}, bareDir)
+		}
 		return nil
 	})
 
 	m.output.Success(fmt.Sprintf("Created worktree at %s", worktreePath))
 
-	// Step 3: Set branch description (parent tracking)
-	description := "parent:" + baseBranch
-	if err := SetBranchDescription(ctx, m.git, branch, description, worktreePath); err != nil {
-		return "", fmt.Errorf("failed to set branch description: %w", err)
+	// Step 3: Set branch description (parent tracking). A reused branch
+	// keeps the parent it has unless a base was given, and gets its old
+	// description back on rollback. A tracked branch records its remote
+	// branch instead of a parent.
+	if !reuse || explicitBase {
+		var prevDescription string
+		if reuse {
+			prevDescription, _ = GetBranchDescription(ctx, m.git, branch, worktreePath)
+		}
+		description := "parent:" + baseBranch
+		if o.Track != "" {
+			description = "track:" + o.Track
+		}
+		if err := SetBranchDescription(ctx, m.git, branch, description, worktreePath); err != nil {
+			return "", fmt.Errorf("failed to set branch description: %w", err)
+		}
+		op.AddUndo(func(ctx context.Context) error {
+			if prevDescription != "" {
+				return SetBranchDescription(ctx, m.git, branch, prevDescription, worktreePath)
+			}
+			m.git.Run(ctx, []string{"config", "--unset", "branch." + branch + ".description"}, worktreePath)
+			return nil
+		})
 	}
-	op.AddUndo(func(ctx context.Context) error {
-		m.git.Run(ctx, []string{"config", "--unset", "branch." + branch + ".description"}, worktreePath)
-		return nil
-	})
 
 	// Step 4: Set goal
 	if goal != "" {
@@ -147,18 +187,25 @@ func (m *Manager) NewAtomic(ctx context.Context, branch, baseBranch, goal string
 		})
 	}
 
-	// Step 5: Run post-create hooks (not reversed on failure -- hooks are side-effectful)
-	config, err := LoadRepoConfig(worktreePath)
+	// Step 5: Copy seed files and run post-create hooks. The hooks' own side
+	// effects are never reversed; with RollbackOnHookFailure the worktree
+	// (seed files included) and branch are.
+	config, err := m.loadConfig(worktreePath)
+	m.seedNewWorktree(ctx, worktreePath, o, config)
 	if err != nil {
 		m.output.Warn(fmt.Sprintf("Failed to load repo config, skipping hooks: %v", err))
 	} else {
 		createCommands := config.WorktreeCreateCommands()
 		if len(createCommands) > 0 {
-			if err := RunHooks(createCommands, worktreePath, branch, m.output); err != nil {
+			if _, err := RunHooks(ctx, createCommands, m.hookEnv(ctx, worktreePath, branch, nil), config.EffectiveHookTimeout(), m.output); err != nil {
+				if o.RollbackOnHookFailure {
+					return "", err
+				}
 				m.output.Warn(fmt.Sprintf("Post-create hook failed: %v", err))
 			}
 		}
 	}
+	_ = m.TouchActivity(branch)
 
 	op.Commit()
 	return worktreePath, nil
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -291,6 +292,40 @@ func TestNewAtomicRollbackOnDescriptionFailure(t *testing.T) {
 	}
 }
 
+func TestNewAtomicReuseRollbackKeepsBranch(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	if err := os.MkdirAll(filepath.Join(repoDir, ".bare"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	featurePath := filepath.Join(repoDir, "feature")
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["branch --list feature"] = &CmdResult{Stdout: "  feature\n"}
+	mockGit.Results["config branch.feature.description"] = &CmdResult{Stdout: "parent:main\n"}
+	mockGit.Errors["config branch.feature.goal ship it"] = errors.New("config failed")
+
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(NewMockGHRunner()), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	if _, err := m.NewAtomic(context.Background(), "feature", "develop", "ship it", NewOptions{Reuse: true}); err == nil {
+		t.Fatal("Expected error when goal config fails")
+	}
+
+	if !hasCall(mockGit.Calls, "worktree add "+featurePath+" feature") {
+		t.Errorf("NewAtomic did not check out the existing branch: %v", mockGit.Calls)
+	}
+	if !hasCall(mockGit.Calls, "worktree remove --force "+featurePath) {
+		t.Error("Rollback should remove the worktree")
+	}
+	if hasCall(mockGit.Calls, "branch -D feature") {
+		t.Error("Rollback must not delete a reused branch")
+	}
+	if !hasCall(mockGit.Calls, "config branch.feature.description parent:main") {
+		t.Error("Rollback should restore the reused branch's description")
+	}
+}
+
 func TestNewAtomicRollbackOnGoalFailure(t *testing.T) {
 	t.Parallel()
 
@@ -340,3 +375,87 @@ func TestNewAtomicRollbackOnGoalFailure(t *testing.T) {
 		t.Error("Rollback should remove worktree")
 	}
 }
+
+// hookWorktreeGitRunner wraps MockGitRunner and materializes the worktree
+// directory with a .wt.yaml on "worktree add", so NewAtomic runs real hooks.
+type hookWorktreeGitRunner struct {
+	*MockGitRunner
+	wtYAML string
+}
+
+func (r *hookWorktreeGitRunner) Run(ctx context.Context, args []string, dir string) (*CmdResult, error) {
+	if len(args) >= 5 && args[0] == "worktree" && args[1] == "add" {
+		path := args[4]
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(path, ".wt.yaml"), []byte(r.wtYAML), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return r.MockGitRunner.Run(ctx, args, dir)
+}
+
+func TestNewAtomicHookFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		rollback bool
+	}{
+		{name: "default keeps worktree", rollback: false},
+		{name: "rollback removes worktree", rollback: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			repoDir := filepath.Join(tmpDir, "test-repo")
+			bareDir := filepath.Join(repoDir, ".bare")
+			if err := os.MkdirAll(bareDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			git := &hookWorktreeGitRunner{
+				MockGitRunner: NewMockGitRunner(),
+				wtYAML:        "post_create:\n  - echo installing deps; exit 3\n",
+			}
+			output := NewOutput(&bytes.Buffer{}, false)
+			m := NewManager(tmpDir, "test-repo", WithGitRunner(git), WithGHRunner(NewMockGHRunner()), WithOutput(output))
+
+			path, err := m.NewAtomic(context.Background(), "feature", "main", "", NewOptions{RollbackOnHookFailure: tt.rollback})
+
+			removed := false
+			for _, call := range git.Calls {
+				if len(call) >= 3 && call[0] == "worktree" && call[1] == "remove" && call[2] == "--force" {
+					removed = true
+				}
+			}
+
+			if !tt.rollback {
+				if err != nil {
+					t.Fatalf("NewAtomic() error = %v, want nil (hook failure is non-fatal)", err)
+				}
+				if path == "" || removed {
+					t.Errorf("worktree should be kept: path=%q removed=%v", path, removed)
+				}
+				return
+			}
+
+			var hookErr *HookFailedError
+			if !errors.As(err, &hookErr) {
+				t.Fatalf("NewAtomic() error = %v, want *HookFailedError", err)
+			}
+			if hookErr.Command != "echo installing deps; exit 3" {
+				t.Errorf("Command = %q", hookErr.Command)
+			}
+			if !strings.Contains(hookErr.Output, "installing deps") {
+				t.Errorf("Output = %q, want hook output", hookErr.Output)
+			}
+			if !removed {
+				t.Error("Rollback should remove worktree")
+			}
+		})
+	}
+}
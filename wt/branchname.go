@@ -0,0 +1,156 @@
+package wt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidBranchName is returned (wrapped) when a branch name cannot be
+// turned into a ref git accepts.
+var ErrInvalidBranchName = errors.New("invalid branch name")
+
+var (
+	branchWhitespace = regexp.MustCompile(`\s+`)
+	// Characters git never allows in a ref name: ASCII control characters,
+	// DEL, and ~ ^ : ? * [ \.
+	branchInvalidChars = regexp.MustCompile("[\x00-\x1f\x7f~^:?*\\[\\\\]+")
+	branchRepeatedDash = regexp.MustCompile(`-{2,}`)
+)
+
+// NormalizeBranchName turns user input into a branch name that follows the
+// repository's naming rules and that git will accept:
+//
+//   - whitespace runs become a single dash ("My Branch" → "My-Branch")
+//   - characters git rejects (~ ^ : ? * [ \ and control characters) are
+//     dropped, as are "..", "@{", empty path components, and leading dots
+//     or trailing ".lock" in a component
+//   - the name is lowercased when .wt.yaml sets branch_lowercase: true
+//   - .wt.yaml branch_prefix (e.g. "alice/") is prepended unless present
+//
+// The result is then checked with `git check-ref-format --branch`. Names
+// that are empty after cleanup or that git still refuses return an error
+// wrapping ErrInvalidBranchName. Normalizing an already-normalized name
+// returns it unchanged.
+func (m *Manager) NormalizeBranchName(ctx context.Context, name string) (string, error) {
+	config, _ := m.EffectiveConfig()
+	branch := normalizeBranchName(name, config)
+	if branch == "" {
+		return "", fmt.Errorf("%w: %q has no usable characters", ErrInvalidBranchName, name)
+	}
+	if err := m.checkRefFormat(ctx, branch); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+// checkRefFormat asks git whether branch is a valid branch name.
+func (m *Manager) checkRefFormat(ctx context.Context, branch string) error {
+	dir := m.BareDir()
+	if _, err := os.Stat(dir); err != nil {
+		dir = ""
+	}
+	if _, err := m.git.Run(ctx, []string{"check-ref-format", "--branch", branch}, dir); err != nil {
+		return fmt.Errorf("%w: git rejects %q (see git check-ref-format)", ErrInvalidBranchName, branch)
+	}
+	return nil
+}
+
+// normalizeBranchName applies the rewrite rules of NormalizeBranchName
+// without consulting git. cfg may be nil.
+func normalizeBranchName(name string, cfg *RepoConfig) string {
+	var prefix string
+	lower := false
+	if cfg != nil {
+		prefix = cfg.BranchPrefix
+		lower = cfg.BranchLowercase
+	}
+
+	s := strings.TrimSpace(name)
+	s = branchWhitespace.ReplaceAllString(s, "-")
+	s = branchInvalidChars.ReplaceAllString(s, "")
+	for strings.Contains(s, "..") {
+		s = strings.ReplaceAll(s, "..", ".")
+	}
+	s = strings.ReplaceAll(s, "@{", "@")
+	s = branchRepeatedDash.ReplaceAllString(s, "-")
+	if lower {
+		s = strings.ToLower(s)
+	}
+
+	s = cleanBranchComponents(s)
+	if s == "" {
+		return ""
+	}
+	if prefix = cleanBranchComponents(prefix); prefix != "" {
+		if lower {
+			prefix = strings.ToLower(prefix)
+		}
+		if !strings.HasPrefix(s, prefix+"/") {
+			s = prefix + "/" + s
+		}
+	}
+	return s
+}
+
+// cleanBranchComponents drops empty path components, leading dots and
+// dashes, and trailing dots and ".lock" suffixes from each component.
+func cleanBranchComponents(s string) string {
+	var parts []string
+	for _, part := range strings.Split(s, "/") {
+		for {
+			trimmed := strings.TrimLeft(part, ".-")
+			trimmed = strings.TrimRight(trimmed, ".")
+			trimmed = strings.TrimSuffix(trimmed, ".lock")
+			if trimmed == part {
+				break
+			}
+			part = trimmed
+		}
+		if part != "" && part != "@" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// EffectiveConfig returns the repository's config as wt applies it: the
+// global config files (see GlobalConfigPaths) overlaid by the .wt.yaml of
+// the first worktree that has a loadable one. Before any worktree exists it
+// is the global config alone, and DefaultBase is empty unless a global file
+// sets it, so callers fall back to the remote's default branch.
+func (m *Manager) EffectiveConfig() (*RepoConfig, error) {
+	entries, _ := os.ReadDir(m.RepoDir())
+	var firstErr error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		wtPath := filepath.Join(m.RepoDir(), entry.Name())
+		if _, err := os.Stat(filepath.Join(wtPath, ".git")); err != nil {
+			continue
+		}
+		config, err := m.loadConfig(wtPath)
+		if err != nil {
+			// Config load failed, try next worktree
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		return config, nil
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return loadConfigFiles(GlobalConfigPaths(m.root)...)
+}
+
+// loadConfig loads worktreePath's .wt.yaml merged over the global config.
+func (m *Manager) loadConfig(worktreePath string) (*RepoConfig, error) {
+	return LoadConfig(m.root, worktreePath)
+}
@@ -0,0 +1,130 @@
+package wt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeBranchNameRules(t *testing.T) {
+	tests := []struct {
+		cfg  *RepoConfig
+		name string
+		in   string
+		want string
+	}{
+		{name: "already valid", in: "feature-x", want: "feature-x"},
+		{name: "spaces to dashes", in: "feature/My Branch", want: "feature/My-Branch"},
+		{name: "surrounding whitespace", in: "  fix  login \t bug ", want: "fix-login-bug"},
+		{name: "invalid characters dropped", in: "fix:crash?~^*[x]\\", want: "fixcrashx]"},
+		{name: "double dots collapsed", in: "a..b...c", want: "a.b.c"},
+		{name: "reflog syntax", in: "topic@{1}", want: "topic@1}"},
+		{name: "empty components", in: "/feature//x/", want: "feature/x"},
+		{name: "component dots and lock", in: ".hidden/x.lock/y.", want: "hidden/x/y"},
+		{name: "leading dash", in: "-rf", want: "rf"},
+		{name: "nothing usable", in: " ~^: ", want: ""},
+		{name: "lowercase", cfg: &RepoConfig{BranchLowercase: true}, in: "Feature/My Branch", want: "feature/my-branch"},
+		{name: "prefix added", cfg: &RepoConfig{BranchPrefix: "alice/"}, in: "feature-x", want: "alice/feature-x"},
+		{name: "prefix without slash", cfg: &RepoConfig{BranchPrefix: "alice"}, in: "feature-x", want: "alice/feature-x"},
+		{name: "prefix already present", cfg: &RepoConfig{BranchPrefix: "alice/"}, in: "alice/feature-x", want: "alice/feature-x"},
+		{name: "prefix on empty name", cfg: &RepoConfig{BranchPrefix: "alice/"}, in: "::", want: ""},
+		{name: "prefix and lowercase", cfg: &RepoConfig{BranchPrefix: "Alice/", BranchLowercase: true}, in: "Fix It", want: "alice/fix-it"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeBranchName(tt.in, tt.cfg)
+			if got != tt.want {
+				t.Errorf("normalizeBranchName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			// Normalization is idempotent so callers may pre-normalize.
+			if got != "" {
+				if again := normalizeBranchName(got, tt.cfg); again != got {
+					t.Errorf("normalizeBranchName(%q) = %q, not idempotent", got, again)
+				}
+			}
+		})
+	}
+}
+
+// newBranchNameFixture creates a repo layout with one worktree carrying the
+// given .wt.yaml content.
+func newBranchNameFixture(t *testing.T, wtYAML string) (*Manager, *MockGitRunner) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	mainDir := filepath.Join(repoDir, "main")
+	for _, dir := range []string{filepath.Join(repoDir, ".bare"), filepath.Join(mainDir, ".git")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if wtYAML != "" {
+		if err := os.WriteFile(filepath.Join(mainDir, ".wt.yaml"), []byte(wtYAML), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mockGit := NewMockGitRunner()
+	var buf strings.Builder
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(NewOutput(&buf, false)))
+	return m, mockGit
+}
+
+func TestManagerNormalizeBranchName(t *testing.T) {
+	m, mockGit := newBranchNameFixture(t, "branch_prefix: alice/\nbranch_lowercase: true\n")
+	ctx := context.Background()
+
+	got, err := m.NormalizeBranchName(ctx, "Feature/My Branch")
+	if err != nil {
+		t.Fatalf("NormalizeBranchName() error = %v", err)
+	}
+	if got != "alice/feature/my-branch" {
+		t.Errorf("NormalizeBranchName() = %q, want alice/feature/my-branch", got)
+	}
+	last := mockGit.Calls[len(mockGit.Calls)-1]
+	if strings.Join(last, " ") != "check-ref-format --branch alice/feature/my-branch" {
+		t.Errorf("last git call = %v, want check-ref-format", last)
+	}
+
+	if _, err := m.NormalizeBranchName(ctx, " :: "); !errors.Is(err, ErrInvalidBranchName) {
+		t.Errorf("empty name error = %v, want ErrInvalidBranchName", err)
+	}
+}
+
+func TestManagerNewRejectsInvalidBranchName(t *testing.T) {
+	m, mockGit := newBranchNameFixture(t, "")
+	mockGit.Errors["check-ref-format --branch HEAD"] = errors.New("exit status 1")
+
+	_, err := m.New(context.Background(), "HEAD", "main", "")
+	if !errors.Is(err, ErrInvalidBranchName) {
+		t.Fatalf("New() error = %v, want ErrInvalidBranchName", err)
+	}
+	for _, call := range mockGit.Calls {
+		if call[0] == "fetch" || call[0] == "worktree" {
+			t.Errorf("New ran %v before rejecting the branch name", call)
+		}
+	}
+}
+
+func TestManagerNewUsesNormalizedBranch(t *testing.T) {
+	m, mockGit := newBranchNameFixture(t, "branch_prefix: alice/\n")
+
+	path, err := m.New(context.Background(), "fix login", "main", "", NewOptions{SkipFetch: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if want := filepath.Join(m.RepoDir(), "alice", "fix-login"); path != want {
+		t.Errorf("New() path = %q, want %q", path, want)
+	}
+	found := false
+	for _, call := range mockGit.Calls {
+		if strings.Join(call, " ") == "worktree add -b alice/fix-login "+path+" origin/main" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected worktree add for alice/fix-login, calls: %v", mockGit.Calls)
+	}
+}
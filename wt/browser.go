@@ -0,0 +1,45 @@
+package wt
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// OpenURL opens url in the user's browser through the OS opener and
+// returns without waiting for the browser.
+func OpenURL(url string) error {
+	cmd := BrowserCommand(runtime.GOOS, url)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	return cmd.Process.Release()
+}
+
+// BrowserCommand builds the exec.Cmd that opens url on goos: "open" on
+// macOS, "cmd /c start" on Windows and "xdg-open" elsewhere.
+func BrowserCommand(goos, url string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		// start treats its first quoted argument as the window title.
+		return exec.Command("cmd", "/c", "start", "", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+// PRURL returns the URL of the PR for branch. It fails with a hint to run
+// wt pr when the branch has no PR.
+func (m *Manager) PRURL(ctx context.Context, branch string) (string, error) {
+	prInfo, err := GetPRByBranch(ctx, m.gh, branch, m.BareDir())
+	if err != nil {
+		return "", fmt.Errorf("no PR to open for branch %s (run wt pr to create one): %w", branch, err)
+	}
+	if prInfo.URL == "" {
+		return "", fmt.Errorf("PR #%d for branch %s has no URL", prInfo.Number, branch)
+	}
+	return prInfo.URL, nil
+}
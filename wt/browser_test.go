@@ -0,0 +1,51 @@
+package wt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	const url = "https://github.com/o/r/pull/7"
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{goos: "darwin", want: []string{"open", url}},
+		{goos: "linux", want: []string{"xdg-open", url}},
+		{goos: "freebsd", want: []string{"xdg-open", url}},
+		{goos: "windows", want: []string{"cmd", "/c", "start", "", url}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			got := BrowserCommand(tt.goos, url).Args
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("BrowserCommand(%q) args = %q, want %q", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPRURL(t *testing.T) {
+	const viewKey = "pr view feature --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"
+
+	mockGH := NewMockGHRunner()
+	mockGH.Results[viewKey] = &CmdResult{Stdout: `{"number":7,"url":"https://github.com/o/r/pull/7"}`}
+	m := NewManager(t.TempDir(), "repo", WithGHRunner(mockGH))
+	url, err := m.PRURL(context.Background(), "feature")
+	if err != nil {
+		t.Fatalf("PRURL() error = %v", err)
+	}
+	if url != "https://github.com/o/r/pull/7" {
+		t.Errorf("PRURL() = %q", url)
+	}
+
+	mockGH = NewMockGHRunner()
+	mockGH.Errors[viewKey] = errors.New("no pull requests found")
+	m = NewManager(t.TempDir(), "repo", WithGHRunner(mockGH))
+	if _, err := m.PRURL(context.Background(), "feature"); err == nil || !strings.Contains(err.Error(), "no PR to open for branch feature") {
+		t.Errorf("PRURL() error = %v, want no-PR error", err)
+	}
+}
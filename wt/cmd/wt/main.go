@@ -4,15 +4,18 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude/render"
 	"github.com/bazelment/yoloswe/cliapp"
@@ -79,11 +82,12 @@ func init() {
 	rootCmd.AddCommand(goalCmd)
 	rootCmd.AddCommand(pruneCmd)
 	rootCmd.AddCommand(gcCmd)
+	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(shellenvCmd)
 }
 
 // getManager creates a Manager, resolving repo from flag or cwd.
-func getManager() (*wt.Manager, error) {
+func getManager(opts ...wt.Option) (*wt.Manager, error) {
 	ctx := context.Background()
 	output := wt.DefaultOutput()
 
@@ -97,7 +101,7 @@ func getManager() (*wt.Manager, error) {
 			}
 			return nil, fmt.Errorf("repository not found")
 		}
-		return wt.NewManager(wtRoot, repoFlag), nil
+		return wt.NewManager(wtRoot, repoFlag, opts...), nil
 	}
 
 	repoName, err := wt.GetCurrentRepoName(ctx, &wt.DefaultGitRunner{}, wtRoot)
@@ -110,12 +114,26 @@ func getManager() (*wt.Manager, error) {
 		return nil, err
 	}
 
-	return wt.NewManager(wtRoot, repoName), nil
+	return wt.NewManager(wtRoot, repoName, opts...), nil
 }
 
-// initCmd: wt init <repo-url>
+// printDryRun lists, one per line on stdout, the commands a dry-run Manager
+// recorded instead of running.
+func printDryRun(m *wt.Manager) {
+	cmds := m.DryRunCommands()
+	if len(cmds) == 0 {
+		wt.DefaultOutput().Info("Dry run: no commands would change anything")
+		return
+	}
+	wt.DefaultOutput().Info("Dry run: these commands were not run:")
+	for _, c := range cmds {
+		fmt.Println(c.String())
+	}
+}
+
+// initCmd: wt init <repo-url> | wt init --from-local <path>
 var initCmd = &cobra.Command{
-	Use:   "init <repo-url>",
+	Use:   "init <repo-url> | --from-local <path>",
 	Short: "Initialize repo with bare clone",
 	Long: `Init creates a bare clone and sets up the default branch worktree.
 
@@ -123,13 +141,33 @@ Rough commands:
   git clone --bare <url> .bare/
   git config remote.origin.fetch "+refs/heads/*:refs/remotes/origin/*"
   git fetch origin
-  git worktree add <default-branch>/ <default-branch>`,
-	Args: cobra.ExactArgs(1),
+  git worktree add <default-branch>/ <default-branch>
+
+With --from-local, the bare clone is seeded from an existing checkout and
+repointed at its origin remote; the checkout's current branch is imported
+as a worktree alongside the default branch. The checkout is left untouched.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if fromLocal, _ := cmd.Flags().GetString("from-local"); fromLocal != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		if fromLocal, _ := cmd.Flags().GetString("from-local"); fromLocal != "" {
+			m := wt.NewManager(wtRoot, "")
+			path, err := m.Adopt(ctx, fromLocal)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("__WT_CD__:%s\n", path)
+			return nil
+		}
+
 		url := args[0]
 		repoName := wt.GetRepoNameFromURL(url)
 		m := wt.NewManager(wtRoot, repoName)
-		ctx := context.Background()
 
 		mainPath, err := m.Init(ctx, url)
 		if err != nil {
@@ -141,19 +179,60 @@ Rough commands:
 	},
 }
 
+func init() {
+	initCmd.Flags().String("from-local", "", "Adopt an existing local clone instead of cloning <repo-url>")
+}
+
 // newCmd: wt new <branch> [--from X] [--goal X]
 var newCmd = &cobra.Command{
 	Use:   "new <branch>",
 	Short: "Create new branch worktree",
 	Long: `New creates a worktree with a new branch from a base branch.
 
+The branch name is normalized first: whitespace becomes dashes, characters
+git rejects are dropped, and .wt.yaml branch_prefix / branch_lowercase are
+applied ("wt new 'My Branch'" creates My-Branch). Names git would still
+refuse are rejected before anything is created.
+
+Untracked files listed in .wt.yaml seed_paths (or --seed), such as .env or
+node_modules, are copied from the default branch's worktree (or --seed-from)
+before post-create hooks run. Missing paths are skipped and existing files
+in the new worktree are never overwritten.
+
+With --track <remote>/<branch> the new branch starts from, and tracks, that
+remote branch instead of origin/<base> (for multi-remote workflows). The
+remote branch must exist. A tracked branch has no parent, so --track cannot
+be combined with --from, and wt sync leaves it alone.
+
+If the branch already exists locally (e.g. created with git directly) but
+has no worktree, new fails unless --reuse is given, which checks the
+existing branch out with its commits instead of creating it. Its recorded
+parent is kept unless --from is given.
+
 Rough commands:
   git fetch origin
   git worktree add -b <branch> <path> origin/<base>
-  git config branch.<branch>.description "parent:<base>"`,
+  git config branch.<branch>.description "parent:<base>"
+
+  # with --track upstream/release
+  git fetch upstream +refs/heads/release:refs/remotes/upstream/release
+  git worktree add --track -b <branch> <path> upstream/release
+  git config branch.<branch>.description "track:upstream/release"
+
+  # with --reuse and an existing local branch
+  git worktree add <path> <branch>
+
+With --dry-run, the git commands that would change anything are printed
+instead of run (as "git -C <dir> ..." lines for scripts); read-only queries
+still run, and seeding and hooks are skipped. open, rm and merge accept
+--dry-run too.
+
+With --open-editor, the editor from .wt.yaml "editor" (else $EDITOR) is
+launched on the new worktree without waiting for it to exit.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		m, err := getManager()
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		m, err := getManager(wt.WithDryRun(dryRun))
 		if err != nil {
 			return err
 		}
@@ -161,14 +240,31 @@ Rough commands:
 		branch := args[0]
 		baseBranch, _ := cmd.Flags().GetString("from")
 		goal, _ := cmd.Flags().GetString("goal")
+		seedFrom, _ := cmd.Flags().GetString("seed-from")
+		seedPaths, _ := cmd.Flags().GetStringSlice("seed")
+		track, _ := cmd.Flags().GetString("track")
+		reuse, _ := cmd.Flags().GetBool("reuse")
 		ctx := context.Background()
 
-		path, err := m.New(ctx, branch, baseBranch, goal)
+		path, err := m.New(ctx, branch, baseBranch, goal, wt.NewOptions{
+			SeedFrom:  seedFrom,
+			SeedPaths: seedPaths,
+			Track:     track,
+			Reuse:     reuse,
+		})
+		if errors.Is(err, wt.ErrBranchExists) && !reuse {
+			err = fmt.Errorf("%w; pass --reuse to create a worktree for it", err)
+		}
+		if dryRun {
+			printDryRun(m)
+			return err
+		}
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("__WT_CD__:%s\n", path)
+		maybeOpenEditor(cmd, m, path)
 		return nil
 	},
 }
@@ -176,21 +272,62 @@ Rough commands:
 func init() {
 	newCmd.Flags().StringP("from", "f", "", "Base branch")
 	newCmd.Flags().StringP("goal", "g", "", "High-level goal for this worktree")
+	newCmd.Flags().String("seed-from", "", "Worktree (branch or path) to copy seed files from (default: default branch)")
+	newCmd.Flags().String("track", "", "Create the branch from and track a remote branch (<remote>/<branch>)")
+	newCmd.Flags().Bool("reuse", false, "Check out the branch if it already exists locally instead of failing")
+	newCmd.Flags().StringSlice("seed", nil, "Untracked paths to copy into the new worktree (default: .wt.yaml seed_paths)")
+	newCmd.Flags().Bool("dry-run", false, "Print the git commands instead of running them")
+	newCmd.Flags().Bool("open-editor", false, "Launch the editor (.wt.yaml editor, else $EDITOR) in the new worktree")
+}
+
+// maybeOpenEditor launches the editor on path when --open-editor is set. A
+// missing or failing editor only warns: the worktree already exists.
+func maybeOpenEditor(cmd *cobra.Command, m *wt.Manager, path string) {
+	if open, _ := cmd.Flags().GetBool("open-editor"); !open {
+		return
+	}
+	if err := m.OpenInEditor(path); err != nil {
+		wt.DefaultOutput().Warn(fmt.Sprintf("Not opening editor: %v", err))
+	}
 }
 
 // openCmd: wt open <branch> [--goal X]
 var openCmd = &cobra.Command{
 	Use:   "open <branch>",
 	Short: "Open existing remote branch",
-	Long: `Open creates a worktree for an existing remote branch.
+	Long: `Open creates a worktree for an existing remote branch. If the branch
+is not on the remote, the error lists the closest remote branch names.
 
 Rough commands:
   git fetch origin
   git worktree add <path> <branch>   # auto-tracks origin/<branch>
-  git config branch.<branch>.description "parent:<default-branch>"`,
+  git config branch.<branch>.description "parent:<default-branch>"
+
+With --open-editor, the editor is launched on the worktree as for wt new.`,
 	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
 		m, err := getManager()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		suggestions, err := m.SuggestBranches(context.Background(), toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var branches []string
+		for _, s := range suggestions {
+			if remote, branch, _ := strings.Cut(s, "/"); remote == "origin" {
+				branches = append(branches, branch)
+			}
+		}
+		return branches, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		m, err := getManager(wt.WithDryRun(dryRun))
 		if err != nil {
 			return err
 		}
@@ -200,27 +337,39 @@ Rough commands:
 		ctx := context.Background()
 
 		path, err := m.Open(ctx, branch, goal)
+		if dryRun {
+			printDryRun(m)
+			return err
+		}
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("__WT_CD__:%s\n", path)
+		maybeOpenEditor(cmd, m, path)
 		return nil
 	},
 }
 
 func init() {
 	openCmd.Flags().StringP("goal", "g", "", "High-level goal for this worktree")
+	openCmd.Flags().Bool("dry-run", false, "Print the git commands instead of running them")
+	openCmd.Flags().Bool("open-editor", false, "Launch the editor (.wt.yaml editor, else $EDITOR) in the worktree")
 }
 
-// lsCmd: wt ls [--json] [-a]
+// lsCmd: wt ls [--json] [-a] [--goals] [--sort name|commit-time|status]
 var lsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List all worktrees",
-	Long: `Ls lists all worktrees in the current repository.
+	Long: `Ls lists all worktrees in the current repository, in git's order by
+default. --sort name orders by branch, --sort commit-time puts the most
+recently committed-to worktrees first, and --sort status puts dirty
+worktrees first. The JSON output keeps the chosen order.
 
 Rough commands:
-  git worktree list --porcelain`,
+  git worktree list --porcelain
+  git status --porcelain=v2 --branch    (per worktree, with --sort commit-time|status)
+  git log -1 --format=%ct|%s            (per worktree, with --sort commit-time|status)`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		allRepos, _ := cmd.Flags().GetBool("all")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
@@ -255,6 +404,12 @@ Rough commands:
 			return nil
 		}
 
+		sortFlag, _ := cmd.Flags().GetString("sort")
+		sortBy, err := wt.ParseWorktreeSort(sortFlag)
+		if err != nil {
+			return err
+		}
+
 		// List worktrees for current repo
 		m, err := getManager()
 		if err != nil {
@@ -266,6 +421,10 @@ Rough commands:
 		if err != nil {
 			return err
 		}
+		statuses, err := m.SortWorktrees(ctx, worktrees, sortBy)
+		if err != nil {
+			return err
+		}
 
 		if jsonOutput {
 			cwd, _ := os.Getwd()
@@ -297,16 +456,13 @@ Rough commands:
 		// Get current working directory to mark current worktree
 		cwd, _ := os.Getwd()
 
-		// Check if any worktree has a goal
-		hasGoals := false
-		goals := make(map[string]string)
-		for _, w := range worktrees {
-			goal, _ := m.GetGoal(ctx, w.Branch, w.Path)
-			if goal != "" {
-				hasGoals = true
-				goals[w.Branch] = goal
-			}
+		// Show the goal column when any worktree has a goal, or always with --goals
+		showGoals, _ := cmd.Flags().GetBool("goals")
+		goals, err := m.GetAllGoals(ctx)
+		if err != nil {
+			return err
 		}
+		hasGoals := showGoals || len(goals) > 0
 
 		if hasGoals {
 			fmt.Printf("\n  %-25s %-40s %-8s %s\n", "Branch", "Path", "Status", "Goal")
@@ -317,8 +473,12 @@ Rough commands:
 		}
 
 		for _, w := range worktrees {
-			status, err := m.GetStatus(ctx, w)
-			statusStr := renderStatusColumn(output, status, err)
+			var statusErr error
+			status, ok := statuses[w.Path]
+			if !ok {
+				status, statusErr = m.GetStatus(ctx, w)
+			}
+			statusStr := renderStatusColumn(output, status, statusErr)
 
 			// Check if this is the current worktree
 			isCurrent := cwd == w.Path || strings.HasPrefix(cwd, w.Path+string(os.PathSeparator))
@@ -345,6 +505,8 @@ Rough commands:
 func init() {
 	lsCmd.Flags().BoolP("json", "j", false, "JSON output")
 	lsCmd.Flags().BoolP("all", "a", false, "List all repositories")
+	lsCmd.Flags().Bool("goals", false, "Always show the goal column")
+	lsCmd.Flags().String("sort", "", "Sort by name, commit-time, or status (default: git's order)")
 }
 
 // rmCmd: wt rm <branch> [-D]
@@ -359,7 +521,8 @@ Rough commands:
   git push origin --delete <branch>  # with -D flag`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		m, err := getManager()
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		m, err := getManager(wt.WithDryRun(dryRun))
 		if err != nil {
 			return err
 		}
@@ -368,15 +531,20 @@ Rough commands:
 		deleteBranch, _ := cmd.Flags().GetBool("delete-branch")
 		ctx := context.Background()
 
-		return m.Remove(ctx, branch, deleteBranch, false)
+		err = m.Remove(ctx, branch, deleteBranch, false)
+		if dryRun {
+			printDryRun(m)
+		}
+		return err
 	},
 }
 
 func init() {
 	rmCmd.Flags().BoolP("delete-branch", "D", false, "Delete branch too")
+	rmCmd.Flags().Bool("dry-run", false, "Print the git commands instead of running them")
 }
 
-// statusCmd: wt status [-a] [-w] [-i seconds]
+// statusCmd: wt status [-a] [-w] [-i seconds] [-j] [--activity]
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show status dashboard",
@@ -384,18 +552,36 @@ var statusCmd = &cobra.Command{
 
 Rough commands:
   git worktree list --porcelain
-  git status --porcelain              # per worktree
-  git rev-list --left-right --count   # ahead/behind
-  gh pr view --json ...               # PR info
+  git status --porcelain=v2 --branch  # per worktree, in parallel
+  gh pr list --state open --json ...  # open PRs, one call per repo
 
 Use -w/--watch to continuously refresh the status display.
-Use -i/--interval to set the refresh interval (default: 60 seconds).`,
+Use -i/--interval to set the refresh interval (default: 60 seconds).
+Use -j/--json for machine-readable output grouped by repository.
+Use --activity to add a "Last Active" column: the last time the worktree
+was entered with wt cd, created, or used by an agent session, which can
+be much later than its last commit.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		allRepos, _ := cmd.Flags().GetBool("all")
 		watchMode, _ := cmd.Flags().GetBool("watch")
 		interval, _ := cmd.Flags().GetInt("interval")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		showActivity, _ := cmd.Flags().GetBool("activity")
 		ctx := context.Background()
 
+		if jsonOutput {
+			if watchMode {
+				return fmt.Errorf("--json cannot be combined with --watch")
+			}
+			repos, err := statusRepos(allRepos)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(collectRepoStatuses(ctx, repos))
+		}
+
 		// Set up signal handling for graceful exit in watch mode
 		if watchMode {
 			ctx, cancel := context.WithCancel(ctx)
@@ -423,7 +609,7 @@ Use -i/--interval to set the refresh interval (default: 60 seconds).`,
 				fmt.Printf("Last updated: %s (refreshing every %ds, Ctrl+C to exit)\n\n",
 					time.Now().Format("15:04:05"), interval)
 
-				if err := displayStatus(ctx, allRepos); err != nil {
+				if err := displayStatus(ctx, allRepos, showActivity); err != nil {
 					return err
 				}
 
@@ -440,32 +626,170 @@ Use -i/--interval to set the refresh interval (default: 60 seconds).`,
 			}
 		}
 
-		return displayStatus(ctx, allRepos)
+		return displayStatus(ctx, allRepos, showActivity)
 	},
 }
 
-func displayStatus(ctx context.Context, allRepos bool) error {
-	output := wt.DefaultOutput()
-
-	// Get list of repos to process
-	var repos []string
+// statusRepos returns the repositories wt status reports on: every repo
+// under wtRoot with --all, otherwise the current one.
+func statusRepos(allRepos bool) ([]string, error) {
 	if allRepos {
-		var err error
-		repos, err = wt.ListAllRepos(wtRoot)
-		if err != nil {
-			return err
+		return wt.ListAllRepos(wtRoot)
+	}
+	m, err := getManager()
+	if err != nil {
+		return nil, err
+	}
+	repoName, _ := filepath.Rel(wtRoot, m.RepoDir())
+	return []string{repoName}, nil
+}
+
+// repoStatusJSON is one repository in `wt status --json` output.
+type repoStatusJSON struct {
+	Repo      string               `json:"repo"`
+	Worktrees []worktreeStatusJSON `json:"worktrees"`
+}
+
+// worktreeStatusJSON mirrors the columns of the status table. Error is set
+// (and the status fields left zero) when the worktree could not be read.
+type worktreeStatusJSON struct {
+	LastCommitTime   *time.Time `json:"last_commit_time,omitempty"`
+	LastActivityTime *time.Time `json:"last_activity_time,omitempty"`
+	Branch           string     `json:"branch"`
+	Path             string     `json:"path"`
+	PRState          string     `json:"pr_state,omitempty"`
+	PRReview         string     `json:"pr_review,omitempty"`
+	PRChecks         string     `json:"pr_checks,omitempty"`
+	Error            string     `json:"error,omitempty"`
+	Ahead            int        `json:"ahead"`
+	Behind           int        `json:"behind"`
+	PRNumber         int        `json:"pr_number,omitempty"`
+	Dirty            bool       `json:"dirty"`
+	PRDraft          bool       `json:"pr_draft,omitempty"`
+}
+
+// collectRepoStatuses gathers the status table's data for each repo.
+// Repos that cannot be listed or have no worktrees are omitted, as in the
+// table.
+func collectRepoStatuses(ctx context.Context, repos []string) []repoStatusJSON {
+	out := []repoStatusJSON{}
+	for _, repoName := range repos {
+		m := wt.NewManager(wtRoot, repoName)
+		worktrees, err := m.List(ctx)
+		if err != nil || len(worktrees) == 0 {
+			continue
 		}
-		if len(repos) == 0 {
-			output.Info("No repositories found")
-			return nil
+		repo := repoStatusJSON{Repo: repoName}
+		statuses := worktreeStatuses(ctx, m)
+		for _, w := range worktrees {
+			j := worktreeStatusToJSON(w, statuses[w.Path], nil)
+			if t, ok := m.LastActivity(w.Branch); ok {
+				j.LastActivityTime = &t
+			}
+			repo.Worktrees = append(repo.Worktrees, j)
 		}
-	} else {
-		m, err := getManager()
+		out = append(out, repo)
+	}
+	return out
+}
+
+// worktreeStatuses fetches every worktree's status with one StatusAll call,
+// keyed by worktree path. Worktrees whose git status could not be read are
+// missing; a failed PR fetch just leaves the PR fields empty, as the PR
+// column has always degraded to "-" without gh.
+//
+// StatusAll only sees open PRs, so branches without one look up their
+// latest PR individually, to show "#N merged" or "#N closed".
+func worktreeStatuses(ctx context.Context, m *wt.Manager) map[string]*wt.WorktreeStatus {
+	statuses, err := m.StatusAll(ctx)
+	byPath := make(map[string]*wt.WorktreeStatus, len(statuses))
+	var g errgroup.Group
+	g.SetLimit(4)
+	for i := range statuses {
+		status := &statuses[i]
+		byPath[status.Worktree.Path] = status
+		if errors.Is(err, wt.ErrPRInfoUnavailable) || status.PRNumber > 0 || status.Worktree.IsDetached {
+			continue
+		}
+		g.Go(func() error {
+			if pr, _ := m.FetchPRInfo(ctx, status.Worktree); pr != nil && pr.State != "OPEN" {
+				status.PRNumber = pr.Number
+				status.PRURL = pr.URL
+				status.PRState = pr.State
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return byPath
+}
+
+func worktreeStatusToJSON(w wt.Worktree, status *wt.WorktreeStatus, err error) worktreeStatusJSON {
+	j := worktreeStatusJSON{Branch: w.Branch, Path: w.Path}
+	if err != nil || status == nil {
+		j.Error = "unknown status"
 		if err != nil {
-			return err
+			j.Error = err.Error()
 		}
-		repoName, _ := filepath.Rel(wtRoot, m.RepoDir())
-		repos = []string{repoName}
+		return j
+	}
+	j.Ahead = status.Ahead
+	j.Behind = status.Behind
+	j.Dirty = status.IsDirty
+	if !status.LastCommitTime.IsZero() {
+		t := status.LastCommitTime
+		j.LastCommitTime = &t
+	}
+	j.PRNumber = status.PRNumber
+	j.PRState = status.PRState
+	j.PRReview = status.PRReviewStatus
+	j.PRChecks = status.PRChecksState
+	j.PRDraft = status.PRIsDraft
+	return j
+}
+
+// renderChecksIndicator renders a PR's CI state as ● (passing), ◐
+// (pending) or ✗ (failing); it is empty when the state is unknown.
+func renderChecksIndicator(output *wt.Output, state string) string {
+	switch state {
+	case wt.ChecksPassing:
+		return output.Colorize(wt.ColorGreen, "●")
+	case wt.ChecksPending:
+		return output.Colorize(wt.ColorYellow, "◐")
+	case wt.ChecksFailing:
+		return output.Colorize(wt.ColorRed, "✗")
+	}
+	return ""
+}
+
+// formatAgo renders t relative to now ("3d ago", "2h ago", "5m ago"), or
+// "-" when t is zero.
+func formatAgo(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	delta := time.Since(t)
+	switch {
+	case delta.Hours() >= 24:
+		return fmt.Sprintf("%dd ago", int(delta.Hours()/24))
+	case delta.Hours() >= 1:
+		return fmt.Sprintf("%dh ago", int(delta.Hours()))
+	default:
+		return fmt.Sprintf("%dm ago", int(delta.Minutes()))
+	}
+}
+
+func displayStatus(ctx context.Context, allRepos, showActivity bool) error {
+	output := wt.DefaultOutput()
+
+	// Get list of repos to process
+	repos, err := statusRepos(allRepos)
+	if err != nil {
+		return err
+	}
+	if allRepos && len(repos) == 0 {
+		output.Info("No repositories found")
+		return nil
 	}
 
 	first := true
@@ -487,22 +811,33 @@ func displayStatus(ctx context.Context, allRepos bool) error {
 		}
 		first = false
 
-		fmt.Printf("\n%s %s %s %s %s\n",
-			wt.Pad("Branch", 41), wt.Pad("Sync", 12), wt.Pad("Status", 8), wt.Pad("Last Commit", 12), "PR")
-		fmt.Println(strings.Repeat("-", 91))
+		activityHeader, ruleWidth := "", 91
+		if showActivity {
+			activityHeader, ruleWidth = wt.Pad("Last Active", 12)+" ", 104
+		}
+		fmt.Printf("\n%s %s %s %s %s%s\n",
+			wt.Pad("Branch", 41), wt.Pad("Sync", 12), wt.Pad("Status", 8), wt.Pad("Last Commit", 12), activityHeader, "PR")
+		fmt.Println(strings.Repeat("-", ruleWidth))
 
+		statuses := worktreeStatuses(ctx, m)
 		for _, w := range worktrees {
-			status, err := m.GetStatus(ctx, w)
+			status := statuses[w.Path]
+
+			activityStr := ""
+			if showActivity {
+				lastActive, _ := m.LastActivity(w.Branch)
+				activityStr = wt.Pad(formatAgo(lastActive), 12) + " "
+			}
 
 			// A single unreadable worktree (git status failure, stale/deleted
 			// directory, cancelled context) must not crash the listing or be
 			// rendered as healthy. Surface it as "unknown" across every column.
-			if err != nil || status == nil {
+			if status == nil {
 				syncStr := output.Colorize(wt.ColorYellow, "unknown")
 				statusStr := output.Colorize(wt.ColorYellow, "unknown")
 				branchStr := output.Colorize(wt.ColorCyan, truncate(w.Branch, 40))
-				fmt.Printf("%s %s %s %s %s\n",
-					wt.Pad(branchStr, 41), wt.Pad(syncStr, 12), wt.Pad(statusStr, 8), wt.Pad("-", 12), "-")
+				fmt.Printf("%s %s %s %s %s%s\n",
+					wt.Pad(branchStr, 41), wt.Pad(syncStr, 12), wt.Pad(statusStr, 8), wt.Pad("-", 12), activityStr, "-")
 				continue
 			}
 
@@ -513,19 +848,7 @@ func displayStatus(ctx context.Context, allRepos bool) error {
 			statusStr := renderStatusColumn(output, status, nil)
 
 			// Last commit time
-			var timeStr string
-			if !status.LastCommitTime.IsZero() {
-				delta := time.Since(status.LastCommitTime)
-				if delta.Hours() >= 24 {
-					timeStr = fmt.Sprintf("%dd ago", int(delta.Hours()/24))
-				} else if delta.Hours() >= 1 {
-					timeStr = fmt.Sprintf("%dh ago", int(delta.Hours()))
-				} else {
-					timeStr = fmt.Sprintf("%dm ago", int(delta.Minutes()))
-				}
-			} else {
-				timeStr = "-"
-			}
+			timeStr := formatAgo(status.LastCommitTime)
 
 			// PR with status
 			prStr := "-"
@@ -545,11 +868,24 @@ func displayStatus(ctx context.Context, allRepos bool) error {
 				default:
 					prStr = prNum
 				}
+				if checks := renderChecksIndicator(output, status.PRChecksState); checks != "" {
+					prStr += " " + checks
+				}
 			}
 
 			branchStr := output.Colorize(wt.ColorCyan, truncate(w.Branch, 40))
-			fmt.Printf("%s %s %s %s %s\n",
-				wt.Pad(branchStr, 41), wt.Pad(syncStr, 12), wt.Pad(statusStr, 8), wt.Pad(timeStr, 12), prStr)
+			fmt.Printf("%s %s %s %s %s%s\n",
+				wt.Pad(branchStr, 41), wt.Pad(syncStr, 12), wt.Pad(statusStr, 8), wt.Pad(timeStr, 12), activityStr, prStr)
+		}
+
+		// Stacked PRs whose base no longer matches their parent. A gh
+		// failure here already shows up in the PR column, so stay quiet.
+		if drift, err := m.CheckPRBaseDrift(ctx); err == nil && len(drift) > 0 {
+			fmt.Println()
+			for _, d := range drift {
+				output.Warn(d.String())
+			}
+			output.Info("Run `wt sync --fix-pr-base` to retarget these PRs")
 		}
 	}
 	fmt.Println()
@@ -561,6 +897,8 @@ func init() {
 	statusCmd.Flags().BoolP("all", "a", false, "Show status for all repositories")
 	statusCmd.Flags().BoolP("watch", "w", false, "Watch mode: refresh status periodically")
 	statusCmd.Flags().IntP("interval", "i", 60, "Refresh interval in seconds (used with --watch)")
+	statusCmd.Flags().BoolP("json", "j", false, "JSON output grouped by repository")
+	statusCmd.Flags().Bool("activity", false, "Show when each worktree was last active (wt cd, creation, agent sessions)")
 }
 
 // syncCmd: wt sync [-a]
@@ -573,16 +911,25 @@ By default, only the worktree you're currently in is synced.
 Use --all to sync all worktrees in the current repository.
 Use --all-repos to sync all worktrees across all repositories.
 
+Only the default branch, stack parents, and branches with worktrees are
+fetched, all in one git fetch. Use --fetch-all to fetch every remote branch,
+or --fetch-refspec to fetch an explicit list plus the stack parents instead.
+
 For cascading branches (created with --from), sync automatically detects
-when a parent branch has been merged and rebases onto the default branch.`,
+when a parent branch has been merged and rebases onto the default branch.
+
+Sync also warns when an open PR's base no longer matches the branch's
+recorded parent. Use --fix-pr-base to retarget those PRs.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		syncAll, _ := cmd.Flags().GetBool("all")
 		allRepos, _ := cmd.Flags().GetBool("all-repos")
 		fetchAll, _ := cmd.Flags().GetBool("fetch-all")
+		fetchRefspec, _ := cmd.Flags().GetString("fetch-refspec")
+		fixPRBase, _ := cmd.Flags().GetBool("fix-pr-base")
 		ctx := context.Background()
 		output := wt.DefaultOutput()
 
-		syncOpts := wt.SyncOptions{FetchAll: fetchAll}
+		syncOpts := wt.SyncOptions{FetchRefspec: fetchRefspec, FetchAll: fetchAll, FixPRBase: fixPRBase}
 
 		// --all-repos: sync every repo in wtRoot
 		if allRepos {
@@ -600,9 +947,12 @@ when a parent branch has been merged and rebases onto the default branch.`,
 				}
 				fmt.Printf("%s\n", output.Colorize(wt.ColorBold, repoName))
 				m := wt.NewManager(wtRoot, repoName)
-				if err := m.Sync(ctx, "", syncOpts); err != nil {
+				report, err := m.Sync(ctx, "", syncOpts)
+				if err != nil {
 					output.Error(fmt.Sprintf("Failed to sync %s: %v", repoName, err))
+					continue
 				}
+				printSyncReport(output, report)
 			}
 			return nil
 		}
@@ -614,7 +964,12 @@ when a parent branch has been merged and rebases onto the default branch.`,
 
 		// --all: sync all worktrees in the current repo
 		if syncAll {
-			return m.Sync(ctx, "", syncOpts)
+			report, err := m.Sync(ctx, "", syncOpts)
+			if err != nil {
+				return err
+			}
+			printSyncReport(output, report)
+			return nil
 		}
 
 		// Default: sync only the current worktree
@@ -632,14 +987,44 @@ when a parent branch has been merged and rebases onto the default branch.`,
 			return fmt.Errorf("not on a branch (detached HEAD?)")
 		}
 
-		return m.Sync(ctx, branch, syncOpts)
+		report, err := m.Sync(ctx, branch, syncOpts)
+		if err != nil {
+			return err
+		}
+		printSyncReport(output, report)
+		return nil
 	},
 }
 
+// printSyncReport prints a one-line summary of a sync followed by the
+// branches that need attention.
+func printSyncReport(output *wt.Output, report *wt.SyncReport) {
+	fmt.Println()
+	if len(report.Conflicts) > 0 {
+		output.Warn("Sync finished: " + report.String())
+	} else {
+		output.Success("Sync finished: " + report.String())
+	}
+	for _, c := range report.Conflicts {
+		output.Error(fmt.Sprintf("%s: rebase onto %s stopped in %s", c.Branch, c.Onto, c.Path))
+	}
+	skipped := make([]string, 0, len(report.Skipped))
+	for name := range report.Skipped {
+		skipped = append(skipped, name)
+	}
+	sort.Strings(skipped)
+	for _, name := range skipped {
+		output.Info(fmt.Sprintf("%s skipped: %s", name, report.Skipped[name]))
+	}
+}
+
 func init() {
 	syncCmd.Flags().BoolP("all", "a", false, "Sync all worktrees in the current repository")
 	syncCmd.Flags().Bool("all-repos", false, "Sync all worktrees across all repositories")
 	syncCmd.Flags().Bool("fetch-all", false, "Fetch all remote branches instead of only the default branch")
+	syncCmd.Flags().String("fetch-refspec", "", "Fetch these space-separated refspecs (plus stack parents) from origin instead")
+	syncCmd.MarkFlagsMutuallyExclusive("fetch-all", "fetch-refspec")
+	syncCmd.Flags().Bool("fix-pr-base", false, "Retarget open PRs whose base drifted from the branch's recorded parent")
 }
 
 // mergeCmd: wt merge [--keep] [--squash|--rebase|--merge]
@@ -650,14 +1035,28 @@ var mergeCmd = &cobra.Command{
 
 By default, this command will:
 1. Merge the PR using the repository's default merge method
-2. Remove the worktree and delete local/remote branches
+2. Remove the worktree and delete the local branch
 3. Find any branches that were based on this one
 4. Rebase those branches onto the default branch
 5. Update their PR base branches
 
-Use --keep to skip worktree/branch cleanup.`,
+Use --keep to skip worktree/branch cleanup.
+
+The PR's branch on GitHub is deleted or kept according to the repository's
+"Automatically delete head branches" setting. Pass --delete-remote to delete
+it regardless, or --keep-remote to not ask for deletion (GitHub still deletes
+it when that setting is on; wt warns).
+
+Use --auto to enable GitHub auto-merge when required checks are still
+running: the command returns immediately and GitHub merges the PR once checks
+pass. Worktree cleanup and child-branch rebasing only run for synchronous
+merges, so run 'wt sync' after an auto-merge lands.
+
+Use --dry-run to print the gh and git commands of the merge and cleanup
+without running them.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		m, err := getManager()
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		m, err := getManager(wt.WithDryRun(dryRun))
 		if err != nil {
 			return err
 		}
@@ -666,6 +1065,9 @@ Use --keep to skip worktree/branch cleanup.`,
 		squash, _ := cmd.Flags().GetBool("squash")
 		rebaseFlag, _ := cmd.Flags().GetBool("rebase")
 		mergeCommit, _ := cmd.Flags().GetBool("merge")
+		auto, _ := cmd.Flags().GetBool("auto")
+		deleteRemote, _ := cmd.Flags().GetBool("delete-remote")
+		keepRemote, _ := cmd.Flags().GetBool("keep-remote")
 
 		// Determine merge method
 		var mergeMethod string
@@ -681,9 +1083,27 @@ Use --keep to skip worktree/branch cleanup.`,
 		opts := wt.MergeOptions{
 			Keep:        keep,
 			MergeMethod: mergeMethod,
+			Auto:        auto,
+		}
+		if deleteRemote || keepRemote {
+			opts.DeleteRemoteBranch = &deleteRemote
 		}
 
-		return m.MergePR(ctx, opts)
+		res, err := m.MergePR(ctx, opts)
+		if dryRun {
+			printDryRun(m)
+		}
+		if err != nil {
+			return err
+		}
+		if summary := res.CascadeSummary(); summary != "" {
+			output := wt.DefaultOutput()
+			output.Info("Cascade: " + summary)
+			for _, cascadeErr := range res.Errors {
+				output.Warn(cascadeErr.Error())
+			}
+		}
+		return nil
 	},
 }
 
@@ -692,9 +1112,14 @@ func init() {
 	mergeCmd.Flags().Bool("squash", false, "Squash merge the PR")
 	mergeCmd.Flags().Bool("rebase", false, "Rebase merge the PR")
 	mergeCmd.Flags().Bool("merge", false, "Create a merge commit")
+	mergeCmd.Flags().Bool("auto", false, "Enable auto-merge if checks are still pending")
+	mergeCmd.Flags().Bool("delete-remote", false, "Delete the PR's branch on GitHub after merging (default: repository setting)")
+	mergeCmd.Flags().Bool("keep-remote", false, "Keep the PR's branch on GitHub after merging (default: repository setting)")
+	mergeCmd.MarkFlagsMutuallyExclusive("delete-remote", "keep-remote")
+	mergeCmd.Flags().Bool("dry-run", false, "Print the gh and git commands instead of running them")
 }
 
-// prCmd: wt pr [--title X] [--body X] [--base X] [--draft] [--no-push]
+// prCmd: wt pr [--title X] [--body X] [--base X] [--draft] [--no-push] [--web]
 var prCmd = &cobra.Command{
 	Use:   "pr",
 	Short: "Push and create a GitHub PR",
@@ -709,7 +1134,11 @@ Examples:
   wt pr                           # Auto-detect base
   wt pr --draft                   # Create draft PR
   wt pr --base develop            # Target develop
-  wt pr -t "Add feature X"        # With title`,
+  wt pr -t "Add feature X"        # With title
+  wt pr --draft --web             # Create a draft PR and open it
+  wt pr --web                     # Open the existing PR in a browser
+  wt pr ready                     # Mark the draft PR ready for review
+  wt pr draft                     # Convert the PR back to a draft`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		m, err := getManager()
 		if err != nil {
@@ -721,8 +1150,24 @@ Examples:
 		base, _ := cmd.Flags().GetString("base")
 		draft, _ := cmd.Flags().GetBool("draft")
 		noPush, _ := cmd.Flags().GetBool("no-push")
+		web, _ := cmd.Flags().GetBool("web")
 
 		ctx := context.Background()
+		if web && !cmd.Flags().Changed("title") && !cmd.Flags().Changed("body") &&
+			!cmd.Flags().Changed("base") && !cmd.Flags().Changed("draft") && !cmd.Flags().Changed("no-push") {
+			// Only --web: open the existing PR without pushing or creating.
+			branch, err := currentBranch(ctx)
+			if err != nil {
+				return err
+			}
+			url, err := m.PRURL(ctx, branch)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("  %s\n", url)
+			return wt.OpenURL(url)
+		}
+
 		result, err := m.CreatePR(ctx, wt.PROptions{
 			Title:  title,
 			Body:   body,
@@ -744,6 +1189,9 @@ Examples:
 		fmt.Printf("  %s -> %s\n", result.Branch, result.Base)
 		fmt.Printf("  %s\n", result.URL)
 
+		if web {
+			return wt.OpenURL(result.URL)
+		}
 		return nil
 	},
 }
@@ -754,6 +1202,66 @@ func init() {
 	prCmd.Flags().String("base", "", "Base branch (override auto-detection)")
 	prCmd.Flags().BoolP("draft", "d", false, "Create as draft PR")
 	prCmd.Flags().Bool("no-push", false, "Skip push if already pushed")
+	prCmd.Flags().Bool("web", false, "Open the PR in a browser (alone: open the existing PR)")
+	prCmd.AddCommand(prReadyCmd)
+	prCmd.AddCommand(prDraftCmd)
+}
+
+// prReadyCmd: wt pr ready
+var prReadyCmd = &cobra.Command{
+	Use:   "ready",
+	Short: "Mark the current branch's draft PR as ready for review",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPRDraftTransition(false)
+	},
+}
+
+// prDraftCmd: wt pr draft
+var prDraftCmd = &cobra.Command{
+	Use:   "draft",
+	Short: "Convert the current branch's PR back to a draft",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPRDraftTransition(true)
+	},
+}
+
+// runPRDraftTransition flips the current branch's PR between draft and ready.
+func runPRDraftTransition(draft bool) error {
+	m, err := getManager()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	branch, err := currentBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	if draft {
+		return m.MarkPRDraft(ctx, branch)
+	}
+	return m.MarkPRReady(ctx, branch)
+}
+
+// currentBranch returns the branch checked out in the current directory.
+func currentBranch(ctx context.Context) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	git := &wt.DefaultGitRunner{}
+	result, err := git.Run(ctx, []string{"branch", "--show-current"}, cwd)
+	if err != nil {
+		return "", fmt.Errorf("not in a git worktree: %w", err)
+	}
+	branch := strings.TrimSpace(result.Stdout)
+	if branch == "" {
+		return "", fmt.Errorf("not on a branch (detached HEAD?)")
+	}
+	return branch, nil
 }
 
 // cdCmd: wt cd [branch]
@@ -782,12 +1290,15 @@ shell's working directory. Without it, just prints the path.`,
 			return err
 		}
 
+		if rel, err := filepath.Rel(m.RepoDir(), path); err == nil {
+			_ = m.TouchActivity(filepath.ToSlash(rel))
+		}
 		fmt.Printf("__WT_CD__:%s\n", path)
 		return nil
 	},
 }
 
-// goalCmd: wt goal [goal-text]
+// goalCmd: wt goal [goal-text] [-a]
 var goalCmd = &cobra.Command{
 	Use:   "goal [goal-text]",
 	Short: "View or set worktree goal",
@@ -795,10 +1306,12 @@ var goalCmd = &cobra.Command{
 
 Without arguments, shows the current goal.
 With an argument, sets the goal.
+With --all, shows the goal of every worktree in the repository.
 
 Examples:
   wt goal                           # Show current goal
-  wt goal "Implement OAuth login"   # Set goal`,
+  wt goal "Implement OAuth login"   # Set goal
+  wt goal --all                     # Show all goals`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		m, err := getManager()
 		if err != nil {
@@ -806,27 +1319,53 @@ Examples:
 		}
 
 		ctx := context.Background()
+		output := wt.DefaultOutput()
+
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			if len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with goal text")
+			}
+			goals, err := m.GetAllGoals(ctx)
+			if err != nil {
+				return err
+			}
+			if len(goals) == 0 {
+				output.Info("No goals set")
+				return nil
+			}
+			branches := make([]string, 0, len(goals))
+			for branch := range goals {
+				branches = append(branches, branch)
+			}
+			sort.Strings(branches)
+
+			fmt.Printf("\n%-30s %s\n", "Branch", "Goal")
+			fmt.Println(strings.Repeat("-", 80))
+			for _, branch := range branches {
+				branchStr := output.Colorize(wt.ColorCyan, truncate(branch, 29))
+				fmt.Printf("%-39s %s\n", branchStr, goals[branch])
+			}
+			fmt.Println()
+			return nil
+		}
+
 		cwd, err := os.Getwd()
 		if err != nil {
 			return fmt.Errorf("failed to get current directory: %w", err)
 		}
 
-		// Get current branch using git directly
-		git := &wt.DefaultGitRunner{}
-		result, err := git.Run(ctx, []string{"branch", "--show-current"}, cwd)
+		current, err := m.CurrentWorktree(ctx, cwd)
 		if err != nil {
-			return fmt.Errorf("not in a git worktree: %w", err)
+			return fmt.Errorf("not in a worktree of %s: %w", m.RepoDir(), err)
 		}
-		branch := strings.TrimSpace(result.Stdout)
-		if branch == "" {
-			return fmt.Errorf("not on a branch (detached HEAD?)")
+		if current.IsDetached {
+			return fmt.Errorf("not on a branch (detached HEAD at %s)", current.Commit)
 		}
-
-		output := wt.DefaultOutput()
+		branch := current.Branch
 
 		if len(args) == 0 {
 			// Show current goal
-			goal, _ := m.GetGoal(ctx, branch, cwd)
+			goal, _ := m.GetGoal(ctx, branch, current.Path)
 			if goal == "" {
 				output.Info("No goal set for this worktree")
 			} else {
@@ -837,7 +1376,7 @@ Examples:
 
 		// Set goal
 		goal := strings.Join(args, " ")
-		if err := m.SetGoal(ctx, branch, goal, cwd); err != nil {
+		if err := m.SetGoal(ctx, branch, goal, current.Path); err != nil {
 			return fmt.Errorf("failed to set goal: %w", err)
 		}
 		output.Success(fmt.Sprintf("Goal set for %s", branch))
@@ -845,6 +1384,10 @@ Examples:
 	},
 }
 
+func init() {
+	goalCmd.Flags().BoolP("all", "a", false, "Show goals for all worktrees")
+}
+
 // pruneCmd: wt prune [--dry-run] [--merged]
 var pruneCmd = &cobra.Command{
 	Use:   "prune",
@@ -943,6 +1486,70 @@ func init() {
 	gcCmd.Flags().Bool("stale-locks", false, "Remove worktrees with stale (dead-PID) locks and no open PR (e.g. crashed agent worktrees)")
 }
 
+// doctorCmd: wt doctor [--fix]
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose a broken repo layout",
+	Long: `Doctor checks the current repo for problems that make other commands
+fail with confusing errors:
+
+- the bare clone (.bare) is missing
+- remote.origin.fetch is not configured
+- a registered worktree's directory is gone (prunable)
+- a worktree's .git file is missing or points elsewhere (moved directory)
+- a directory looks like a worktree but is not registered
+
+Each problem is printed with a suggested fix. With --fix, doctor runs
+git worktree prune/repair, restores the fetch refspec, and re-registers
+worktrees that were moved, then reports anything left to fix by hand.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		m, err := getManager()
+		if err != nil {
+			return err
+		}
+		fix, _ := cmd.Flags().GetBool("fix")
+		ctx := context.Background()
+		output := wt.DefaultOutput()
+
+		diags, err := m.Doctor(ctx)
+		if err != nil {
+			return err
+		}
+		if fix && len(diags) > 0 {
+			fixed, fixErr := m.FixDiagnostics(ctx, diags)
+			if fixErr != nil {
+				output.Error(fixErr.Error())
+			}
+			if fixed > 0 {
+				if diags, err = m.Doctor(ctx); err != nil {
+					return err
+				}
+			}
+		}
+		if len(diags) == 0 {
+			output.Success("No problems found")
+			return nil
+		}
+		for _, d := range diags {
+			output.Warn(fmt.Sprintf("%s: %s", d.Path, d.Message))
+			output.Info(fmt.Sprintf("  fix: %s", d.Fix))
+		}
+		if !fix {
+			for _, d := range diags {
+				if d.Fixable {
+					output.Info("Run 'wt doctor --fix' to repair the fixable problems")
+					break
+				}
+			}
+		}
+		return fmt.Errorf("%d problem(s) found", len(diags))
+	},
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "Repair fixable problems (prune, repair, restore fetch refspec)")
+}
+
 // shellenvCmd: wt shellenv
 var shellenvCmd = &cobra.Command{
 	Use:   "shellenv",
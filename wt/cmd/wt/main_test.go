@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude/render"
 	"github.com/bazelment/yoloswe/wt"
@@ -83,6 +88,39 @@ func TestRenderStatusColumnDegradesOnError(t *testing.T) {
 	}
 }
 
+func TestWorktreeStatusToJSON(t *testing.T) {
+	t.Parallel()
+
+	w := wt.Worktree{Branch: "feature-x", Path: "/wt/repo/feature-x"}
+	commit := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	got := worktreeStatusToJSON(w, &wt.WorktreeStatus{
+		LastCommitTime: commit,
+		PRState:        "OPEN",
+		PRReviewStatus: "APPROVED",
+		Ahead:          2,
+		Behind:         1,
+		PRNumber:       42,
+		IsDirty:        true,
+	}, nil)
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"last_commit_time":"2026-03-01T12:00:00Z","branch":"feature-x","path":"/wt/repo/feature-x","pr_state":"OPEN","pr_review":"APPROVED","ahead":2,"behind":1,"pr_number":42,"dirty":true}`
+	if string(data) != want {
+		t.Errorf("json = %s\nwant   %s", data, want)
+	}
+
+	// An unreadable worktree reports the error instead of looking clean.
+	failed := worktreeStatusToJSON(w, nil, errors.New("git boom"))
+	if failed.Error != "git boom" || failed.Dirty || failed.LastCommitTime != nil {
+		t.Errorf("failed status = %+v", failed)
+	}
+	if nilStatus := worktreeStatusToJSON(w, nil, nil); nilStatus.Error == "" {
+		t.Error("nil status without error should still report an error")
+	}
+}
+
 // TestRenderSyncColumn covers the `wt status` sync cell for a present status.
 // The failed-GetStatus path is handled before renderSyncColumn is reached (the
 // row is rendered entirely as "unknown" / "-"), so this exercises only the
@@ -131,3 +169,37 @@ func TestShortenHome(t *testing.T) {
 		t.Fatalf("shortenHome(%q) = %q, want original path", outside, got)
 	}
 }
+
+// fakeRunner answers git or gh commands from a table keyed by the joined
+// arguments; anything else succeeds with no output.
+type fakeRunner map[string]string
+
+func (f fakeRunner) Run(_ context.Context, args []string, _ string) (*wt.CmdResult, error) {
+	return &wt.CmdResult{Stdout: f[strings.Join(args, " ")]}, nil
+}
+
+func TestWorktreeStatusesShowsMergedPRs(t *testing.T) {
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "repo")
+	for _, dir := range []string{filepath.Join(repoDir, ".bare"), filepath.Join(repoDir, "open"), filepath.Join(repoDir, "done")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git := fakeRunner{"worktree list --porcelain": "worktree " + filepath.Join(repoDir, ".bare") + "\nbare\n\n" +
+		"worktree " + filepath.Join(repoDir, "open") + "\nHEAD abc\nbranch refs/heads/open\n\n" +
+		"worktree " + filepath.Join(repoDir, "done") + "\nHEAD def\nbranch refs/heads/done\n\n"}
+	gh := fakeRunner{
+		"pr list --json number,headRefName,baseRefName,state,isDraft,reviewDecision,url,statusCheckRollup --state open --limit 1000": `[{"number": 1, "headRefName": "open", "state": "OPEN"}]`,
+		"pr view --json number,url,state,isDraft,reviewDecision,statusCheckRollup":                                                   `{"number": 2, "state": "MERGED"}`,
+	}
+	m := wt.NewManager(root, "repo", wt.WithGitRunner(git), wt.WithGHRunner(gh), wt.WithOutput(wt.NewOutput(io.Discard, false)))
+
+	statuses := worktreeStatuses(context.Background(), m)
+	if s := statuses[filepath.Join(repoDir, "open")]; s == nil || s.PRNumber != 1 || s.PRState != "OPEN" {
+		t.Errorf("open status = %+v, want open PR #1", s)
+	}
+	if s := statuses[filepath.Join(repoDir, "done")]; s == nil || s.PRNumber != 2 || s.PRState != "MERGED" {
+		t.Errorf("done status = %+v, want merged PR #2", s)
+	}
+}
@@ -1,44 +1,115 @@
 package wt
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-// RepoConfig holds per-repository configuration from .wt.yaml.
+// DefaultHookTimeout bounds each hook command when .wt.yaml does not set
+// hook_timeout. It is generous so slow installs still finish, but a hung
+// hook no longer blocks worktree creation forever.
+const DefaultHookTimeout = 30 * time.Minute
+
+// hookWaitDelay is how long a killed hook may take to release its output
+// pipes before Wait gives up on it.
+const hookWaitDelay = 5 * time.Second
+
+// RepoConfig holds per-repository configuration from .wt.yaml, optionally
+// layered over the global config files (see LoadConfig).
 type RepoConfig struct {
-	DefaultBase      string   `yaml:"default_base"`
+	DefaultBase string `yaml:"default_base"`
+	// Editor is the command wt new/open --open-editor launch on the new
+	// worktree (e.g. "code" or "emacsclient -n"). Empty means $EDITOR.
+	Editor string `yaml:"editor"`
+	// BranchPrefix is prepended to new branch names (e.g. "alice/").
+	BranchPrefix     string   `yaml:"branch_prefix"`
 	PostCreate       []string `yaml:"post_create"`
 	PostRemove       []string `yaml:"post_remove"`
 	OnWorktreeCreate []string `yaml:"on_worktree_create"`
 	OnWorktreeDelete []string `yaml:"on_worktree_delete"`
+	// SeedPaths lists untracked paths (e.g. ".env", "node_modules") copied
+	// from the default branch's worktree into each new worktree.
+	SeedPaths []string `yaml:"seed_paths"`
+	// HookTimeout bounds each hook command (e.g. "10m"). Zero means
+	// DefaultHookTimeout.
+	HookTimeout time.Duration `yaml:"hook_timeout"`
+	// BranchLowercase lowercases new branch names.
+	BranchLowercase bool `yaml:"branch_lowercase"`
 }
 
-// LoadRepoConfig loads .wt.yaml from a repository path.
-// Returns a default config if the file doesn't exist.
+// LoadRepoConfig loads .wt.yaml from a repository path, ignoring the global
+// config (see LoadConfig). Returns a default config if the file doesn't exist.
 func LoadRepoConfig(repoPath string) (*RepoConfig, error) {
-	configPath := filepath.Join(repoPath, ".wt.yaml")
+	return loadConfigWithDefaults(filepath.Join(repoPath, ".wt.yaml"))
+}
 
-	data, err := os.ReadFile(configPath)
-	if os.IsNotExist(err) {
-		return &RepoConfig{DefaultBase: "main"}, nil
+// GlobalConfigPaths returns the global config files that apply to every
+// repository under root, lowest precedence first:
+//
+//  1. ~/.config/wt/config.yaml (per user)
+//  2. $WT_ROOT/.wt.yaml (per worktree root)
+//
+// Both use the .wt.yaml format and either may be missing.
+func GlobalConfigPaths(root string) []string {
+	var paths []string
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "wt", "config.yaml"))
 	}
-	if err != nil {
-		return nil, err
+	if root != "" {
+		paths = append(paths, filepath.Join(root, ".wt.yaml"))
 	}
+	return paths
+}
 
-	var config RepoConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+// LoadConfig loads the effective config for the worktree at repoPath: the
+// global config files from GlobalConfigPaths, then repoPath's .wt.yaml. Each
+// file overrides only the keys it sets, so a repo wins over the global
+// defaults, including setting branch_lowercase: false or clearing a hook
+// list with an empty one. Missing files are skipped.
+func LoadConfig(root, repoPath string) (*RepoConfig, error) {
+	return loadConfigWithDefaults(append(GlobalConfigPaths(root), filepath.Join(repoPath, ".wt.yaml"))...)
+}
+
+// loadConfigWithDefaults is loadConfigFiles with DefaultBase falling back to
+// "main".
+func loadConfigWithDefaults(paths ...string) (*RepoConfig, error) {
+	config, err := loadConfigFiles(paths...)
+	if err != nil {
 		return nil, err
 	}
-
 	if config.DefaultBase == "" {
 		config.DefaultBase = "main"
 	}
+	return config, nil
+}
 
+// loadConfigFiles decodes each existing file in paths over the same config,
+// so later files override the keys they set.
+func loadConfigFiles(paths ...string) (*RepoConfig, error) {
+	var config RepoConfig
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err == nil {
+			err = yaml.Unmarshal(data, &config)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
 	return &config, nil
 }
 
@@ -66,26 +137,160 @@ func (c *RepoConfig) WorktreeDeleteCommands() []string {
 	return cmds
 }
 
-// RunHooks executes hook commands in a worktree.
-func RunHooks(commands []string, worktreePath, branch string, output *Output) error {
+// EffectiveHookTimeout returns the per-command hook timeout, falling back
+// to DefaultHookTimeout.
+func (c *RepoConfig) EffectiveHookTimeout() time.Duration {
+	if c == nil || c.HookTimeout <= 0 {
+		return DefaultHookTimeout
+	}
+	return c.HookTimeout
+}
+
+// HookResult describes one hook command run by RunHooks.
+type HookResult struct {
+	Command  string
+	Output   string // combined stdout and stderr
+	Duration time.Duration
+	ExitCode int // -1 when the command did not exit normally (timeout, signal)
+	TimedOut bool
+}
+
+// HookFailedError is returned by RunHooks when a hook command exits non-zero
+// or times out. It carries the failing command and its combined output so
+// callers can show why the hook failed without re-parsing the Output stream.
+type HookFailedError struct {
+	Err      error
+	Command  string
+	Output   string
+	Timeout  time.Duration
+	TimedOut bool
+}
+
+func (e *HookFailedError) Error() string {
+	if e.TimedOut {
+		return fmt.Sprintf("hook %q timed out after %s", e.Command, e.Timeout)
+	}
+	return fmt.Sprintf("hook %q failed: %v", e.Command, e.Err)
+}
+
+func (e *HookFailedError) Unwrap() error {
+	return e.Err
+}
+
+// HookEnv describes the worktree a hook runs for. RunHooks passes it to each
+// command as environment variables, on top of wt's own environment:
+//
+//	WT_BRANCH     branch checked out in the worktree
+//	WT_PATH       worktree directory, also the hook's working directory
+//	WT_REPO       repository name (the directory under WT_ROOT)
+//	WT_ROOT       wt root directory holding all repositories
+//	WT_BASE       the branch's parent, or the default branch when untracked
+//	WT_PR_NUMBER  pull request number, on hooks run for a PR (e.g. removal
+//	              after a merge)
+//	WT_PR_URL     pull request URL, alongside WT_PR_NUMBER
+//
+// Variables whose value is unknown are left unset rather than set empty, so
+// hooks can test for them with ${VAR:-default}.
+type HookEnv struct {
+	Branch   string
+	Path     string
+	Repo     string
+	Root     string
+	Base     string
+	PRURL    string
+	PRNumber int
+}
+
+// environ returns os.Environ() extended with e's WT_* variables.
+func (e HookEnv) environ() []string {
 	env := os.Environ()
-	env = append(env, "WT_BRANCH="+branch, "WT_PATH="+worktreePath)
+	env = append(env, "WT_BRANCH="+e.Branch, "WT_PATH="+e.Path)
+	for _, kv := range []struct{ key, value string }{
+		{"WT_REPO", e.Repo},
+		{"WT_ROOT", e.Root},
+		{"WT_BASE", e.Base},
+		{"WT_PR_URL", e.PRURL},
+	} {
+		if kv.value != "" {
+			env = append(env, kv.key+"="+kv.value)
+		}
+	}
+	if e.PRNumber > 0 {
+		env = append(env, "WT_PR_NUMBER="+strconv.Itoa(e.PRNumber))
+	}
+	return env
+}
+
+// RunHooks executes hook commands in the worktree at env.Path, stopping at
+// the first failure. Each command sees env as WT_* variables (see HookEnv)
+// and is killed, along with any children it spawned, once it runs longer
+// than timeout (<= 0 means DefaultHookTimeout) or ctx is done. It returns
+// one HookResult per command that ran; on failure the last result is the
+// failing command and the error is a *HookFailedError.
+func RunHooks(ctx context.Context, commands []string, hookEnv HookEnv, timeout time.Duration, output *Output) ([]HookResult, error) {
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	env := hookEnv.environ()
 
+	var results []HookResult
 	for _, cmdStr := range commands {
+		if strings.TrimSpace(cmdStr) == "" {
+			continue
+		}
 		output.Info("Running: " + cmdStr)
 
-		cmd := exec.Command("sh", "-c", cmdStr)
-		cmd.Dir = worktreePath
-		cmd.Env = env
-		// Write hook output to the same writer as Output to prevent TUI corruption
-		cmd.Stdout = output.Writer()
-		cmd.Stderr = output.Writer()
-
-		if err := cmd.Run(); err != nil {
-			output.Error("Hook failed: " + cmdStr)
-			return err
+		result, err := runHook(ctx, cmdStr, hookEnv.Path, env, timeout, output.Writer())
+		results = append(results, result)
+		if err != nil {
+			hookErr := &HookFailedError{
+				Err:      err,
+				Command:  cmdStr,
+				Output:   result.Output,
+				Timeout:  timeout,
+				TimedOut: result.TimedOut,
+			}
+			if result.TimedOut {
+				output.Error(fmt.Sprintf("Hook timed out after %s: %s", timeout, cmdStr))
+			} else {
+				output.Error("Hook failed: " + cmdStr)
+			}
+			return results, hookErr
 		}
 	}
 
-	return nil
+	return results, nil
+}
+
+// runHook runs one hook command through sh in its own process group so a
+// timeout can kill everything it started.
+func runHook(ctx context.Context, cmdStr, dir string, env []string, timeout time.Duration, w io.Writer) (HookResult, error) {
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(hookCtx, "sh", "-c", cmdStr)
+	cmd.Dir = dir
+	cmd.Env = env
+	setHookProcessGroup(cmd)
+	cmd.WaitDelay = hookWaitDelay
+	// Write hook output to the same writer as Output to prevent TUI corruption,
+	// keeping a copy for the result.
+	var captured bytes.Buffer
+	mw := io.MultiWriter(w, &captured)
+	cmd.Stdout = mw
+	cmd.Stderr = mw
+
+	start := time.Now()
+	err := cmd.Run()
+	result := HookResult{
+		Command:  cmdStr,
+		Output:   captured.String(),
+		Duration: time.Since(start),
+		ExitCode: -1,
+		TimedOut: errors.Is(hookCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil,
+	}
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	return result, err
 }
@@ -1,9 +1,14 @@
 package wt
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadRepoConfig(t *testing.T) {
@@ -63,6 +68,39 @@ post_remove:
 		}
 	})
 
+	t.Run("hook_timeout", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, ".wt.yaml"), []byte("hook_timeout: 90s\n"), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+
+		config, err := LoadRepoConfig(tmpDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := config.EffectiveHookTimeout(); got != 90*time.Second {
+			t.Errorf("EffectiveHookTimeout() = %v, want 90s", got)
+		}
+		if got := (&RepoConfig{}).EffectiveHookTimeout(); got != DefaultHookTimeout {
+			t.Errorf("default EffectiveHookTimeout() = %v, want %v", got, DefaultHookTimeout)
+		}
+	})
+
+	t.Run("seed_paths", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, ".wt.yaml"), []byte("seed_paths:\n  - .env\n  - node_modules\n"), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+
+		config, err := LoadRepoConfig(tmpDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(config.SeedPaths) != 2 || config.SeedPaths[0] != ".env" || config.SeedPaths[1] != "node_modules" {
+			t.Errorf("SeedPaths = %v, want [.env node_modules]", config.SeedPaths)
+		}
+	})
+
 	t.Run("empty default_base uses main", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		configPath := filepath.Join(tmpDir, ".wt.yaml")
@@ -155,3 +193,154 @@ on_worktree_delete:
 		}
 	})
 }
+
+func TestLoadConfigMergesGlobalConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := t.TempDir()
+	repo := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(home, ".config", "wt", "config.yaml"), "default_base: develop\nbranch_prefix: user/\nhook_timeout: 5m\npost_create:\n  - make setup\n")
+	write(filepath.Join(root, ".wt.yaml"), "branch_prefix: org/\nbranch_lowercase: true\n")
+
+	t.Run("global only", func(t *testing.T) {
+		config, err := LoadConfig(root, repo)
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+		if config.DefaultBase != "develop" || config.BranchPrefix != "org/" || !config.BranchLowercase || config.HookTimeout != 5*time.Minute {
+			t.Errorf("LoadConfig() = %+v, want develop, org/, lowercase, 5m", config)
+		}
+		if len(config.PostCreate) != 1 || config.PostCreate[0] != "make setup" {
+			t.Errorf("PostCreate = %v, want [make setup]", config.PostCreate)
+		}
+	})
+
+	t.Run("repo wins", func(t *testing.T) {
+		write(filepath.Join(repo, ".wt.yaml"), "branch_prefix: team/\nbranch_lowercase: false\npost_create: []\n")
+		config, err := LoadConfig(root, repo)
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+		if config.BranchPrefix != "team/" || config.BranchLowercase || len(config.PostCreate) != 0 {
+			t.Errorf("LoadConfig() = %+v, want repo overrides", config)
+		}
+		if config.DefaultBase != "develop" {
+			t.Errorf("DefaultBase = %q, want the global develop", config.DefaultBase)
+		}
+
+		repoOnly, err := LoadRepoConfig(repo)
+		if err != nil {
+			t.Fatalf("LoadRepoConfig() error = %v", err)
+		}
+		if repoOnly.DefaultBase != "main" || repoOnly.HookTimeout != 0 {
+			t.Errorf("LoadRepoConfig() = %+v, want global config ignored", repoOnly)
+		}
+	})
+
+	t.Run("invalid global config names the file", func(t *testing.T) {
+		bad := filepath.Join(root, ".wt.yaml")
+		write(bad, "branch_prefix: [\n")
+		_, err := LoadConfig(root, repo)
+		if err == nil || !strings.Contains(err.Error(), bad) {
+			t.Errorf("LoadConfig() error = %v, want it to mention %s", err, bad)
+		}
+	})
+}
+
+func TestManagerEffectiveConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m, _ := newBranchNameFixture(t, "branch_prefix: repo/\n")
+	if err := os.WriteFile(filepath.Join(m.root, ".wt.yaml"), []byte("default_base: trunk\nbranch_prefix: global/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := m.EffectiveConfig()
+	if err != nil {
+		t.Fatalf("EffectiveConfig() error = %v", err)
+	}
+	if config.DefaultBase != "trunk" || config.BranchPrefix != "repo/" {
+		t.Errorf("EffectiveConfig() = %+v, want trunk base and repo/ prefix", config)
+	}
+
+	// Before any worktree exists only the global config applies.
+	if err := os.RemoveAll(filepath.Join(m.RepoDir(), "main")); err != nil {
+		t.Fatal(err)
+	}
+	config, err = m.EffectiveConfig()
+	if err != nil {
+		t.Fatalf("EffectiveConfig() error = %v", err)
+	}
+	if config.DefaultBase != "trunk" || config.BranchPrefix != "global/" {
+		t.Errorf("EffectiveConfig() without worktrees = %+v, want the global config", config)
+	}
+}
+
+func TestRunHooksResults(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	results, err := RunHooks(context.Background(), []string{"echo hello $WT_BRANCH", " ", "echo oops >&2; exit 3", "echo never"},
+		HookEnv{Branch: "feature-x", Path: dir}, time.Minute, NewOutput(&buf, false))
+
+	var hookErr *HookFailedError
+	if !errors.As(err, &hookErr) {
+		t.Fatalf("RunHooks() error = %v, want *HookFailedError", err)
+	}
+	if hookErr.TimedOut {
+		t.Error("HookFailedError.TimedOut = true for a non-zero exit")
+	}
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2 (blank skipped, stop at failure): %+v", len(results), results)
+	}
+	if results[0].ExitCode != 0 || strings.TrimSpace(results[0].Output) != "hello feature-x" {
+		t.Errorf("results[0] = %+v, want exit 0 with branch output", results[0])
+	}
+	if results[1].ExitCode != 3 || strings.TrimSpace(results[1].Output) != "oops" || results[1].TimedOut {
+		t.Errorf("results[1] = %+v, want exit 3 with stderr output", results[1])
+	}
+	if !strings.Contains(buf.String(), "Hook failed: echo oops") {
+		t.Errorf("output missing failure line: %q", buf.String())
+	}
+}
+
+func TestRunHooksEnv(t *testing.T) {
+	dir := t.TempDir()
+	env := HookEnv{
+		Branch:   "feature-x",
+		Path:     dir,
+		Repo:     "myrepo",
+		Root:     "/wt-root",
+		Base:     "main",
+		PRURL:    "https://github.com/o/myrepo/pull/42",
+		PRNumber: 42,
+	}
+	results, err := RunHooks(context.Background(),
+		[]string{`echo "$WT_BRANCH|$WT_PATH|$WT_REPO|$WT_ROOT|$WT_BASE|$WT_PR_NUMBER|$WT_PR_URL"`},
+		env, time.Minute, NewOutput(&bytes.Buffer{}, false))
+	if err != nil {
+		t.Fatalf("RunHooks() error = %v", err)
+	}
+	got := strings.TrimSpace(results[0].Output)
+	want := strings.Join([]string{"feature-x", dir, "myrepo", "/wt-root", "main", "42", env.PRURL}, "|")
+	if got != want {
+		t.Errorf("hook saw %q, want %q", got, want)
+	}
+
+	// Without a PR, the PR variables are unset rather than empty.
+	results, err = RunHooks(context.Background(), []string{`echo "${WT_PR_NUMBER-unset}"`},
+		HookEnv{Branch: "feature-x", Path: dir}, time.Minute, NewOutput(&bytes.Buffer{}, false))
+	if err != nil {
+		t.Fatalf("RunHooks() error = %v", err)
+	}
+	if got := strings.TrimSpace(results[0].Output); got != "unset" {
+		t.Errorf("WT_PR_NUMBER = %q without a PR, want unset", got)
+	}
+}
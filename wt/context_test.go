@@ -48,7 +48,7 @@ func TestGatherContext(t *testing.T) {
 	}
 
 	// PR info
-	mockGH.Results["pr view --json number,url,state,isDraft,reviewDecision"] = &CmdResult{
+	mockGH.Results["pr view --json number,url,state,isDraft,reviewDecision,statusCheckRollup"] = &CmdResult{
 		Stdout: `{"number":42,"url":"https://github.com/org/repo/pull/42","state":"OPEN","isDraft":false,"reviewDecision":"APPROVED"}`,
 	}
 
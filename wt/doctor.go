@@ -0,0 +1,344 @@
+package wt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultFetchRefspec is the refspec Init and Adopt configure on the bare
+// clone so remote branches land in refs/remotes/origin/*.
+const defaultFetchRefspec = "+refs/heads/*:refs/remotes/origin/*"
+
+// DiagnosticKind identifies a problem found by Doctor.
+type DiagnosticKind string
+
+const (
+	// DiagMissingBareDir means the repo has no .bare clone; nothing else can
+	// be checked.
+	DiagMissingBareDir DiagnosticKind = "missing_bare_dir"
+	// DiagMissingRefspec means remote.origin.fetch does not map branches to
+	// refs/remotes/origin/*, so fetches never update remote-tracking refs.
+	DiagMissingRefspec DiagnosticKind = "missing_fetch_refspec"
+	// DiagStaleWorktree means git has a worktree registered whose directory
+	// no longer exists.
+	DiagStaleWorktree DiagnosticKind = "stale_worktree"
+	// DiagBrokenGitFile means a registered worktree's .git file is missing or
+	// points at the wrong place, usually because the directory was moved.
+	DiagBrokenGitFile DiagnosticKind = "broken_git_file"
+	// DiagOrphanDirectory means a directory under the repo looks like a
+	// worktree but git has no registration for it.
+	DiagOrphanDirectory DiagnosticKind = "orphan_directory"
+)
+
+// Diagnostic is one problem found by Doctor, with a suggested fix.
+type Diagnostic struct {
+	Kind    DiagnosticKind
+	Path    string
+	Message string
+	Fix     string // command or action that resolves the problem
+	// Fixable is true when FixDiagnostics can resolve the problem on its own.
+	Fixable bool
+}
+
+// Doctor checks the repo layout for problems that make other commands fail
+// cryptically: a missing bare clone, a missing fetch refspec, registered
+// worktrees whose directory or .git file is gone, and directories that look
+// like worktrees but are not registered. It changes nothing; pass the
+// result to FixDiagnostics to repair what can be repaired.
+func (m *Manager) Doctor(ctx context.Context) ([]Diagnostic, error) {
+	bareDir := m.BareDir()
+	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
+		return []Diagnostic{{
+			Kind:    DiagMissingBareDir,
+			Path:    bareDir,
+			Message: "bare clone not found",
+			Fix:     "wt init <url>",
+		}}, nil
+	}
+
+	var diags []Diagnostic
+
+	if !hasBranchFetchRefspec(m.fetchRefspecs(ctx)) {
+		diags = append(diags, Diagnostic{
+			Kind:    DiagMissingRefspec,
+			Path:    bareDir,
+			Message: "remote.origin.fetch does not map all branches; fetches will not update origin/* branches",
+			Fix:     fmt.Sprintf("git config remote.origin.fetch %q", defaultFetchRefspec),
+			Fixable: true,
+		})
+	}
+
+	worktrees, err := m.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	registered := make(map[string]bool, len(worktrees))
+	for _, w := range worktrees {
+		registered[canonicalPath(w.Path)] = true
+		if w.IsGone {
+			diags = append(diags, Diagnostic{
+				Kind:    DiagStaleWorktree,
+				Path:    w.Path,
+				Message: fmt.Sprintf("worktree for %s is registered but its directory is gone", w.Branch),
+				Fix:     "git worktree prune",
+				Fixable: true,
+			})
+			continue
+		}
+		if problem := checkGitFile(w.Path, bareDir); problem != "" {
+			diags = append(diags, Diagnostic{
+				Kind:    DiagBrokenGitFile,
+				Path:    w.Path,
+				Message: problem,
+				Fix:     "git worktree repair " + w.Path,
+				Fixable: true,
+			})
+		}
+	}
+
+	orphans, err := m.findOrphanDirs(registered)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range orphans {
+		d := Diagnostic{
+			Kind:    DiagOrphanDirectory,
+			Path:    dir,
+			Message: "directory has a .git file but is not a registered worktree",
+			Fix:     "remove it, or recreate it with wt open <branch>",
+		}
+		// A worktree that was moved here still points at its admin dir;
+		// repair re-registers it at the new location.
+		if gitdir, ok := readGitFile(dir); ok && isUnder(gitdir, bareDir) && dirExists(gitdir) {
+			d.Message = "worktree was moved here without updating git"
+			d.Fix = "git worktree repair " + dir
+			d.Fixable = true
+		}
+		diags = append(diags, d)
+	}
+
+	return diags, nil
+}
+
+// FixDiagnostics repairs the fixable problems in diags and returns how many
+// it fixed. Unfixable diagnostics are skipped; run Doctor again afterwards
+// to see what remains.
+func (m *Manager) FixDiagnostics(ctx context.Context, diags []Diagnostic) (int, error) {
+	bareDir := m.BareDir()
+	fixed := 0
+	var errs []error
+	var prune bool
+	var repairPaths []string
+	for _, d := range diags {
+		if !d.Fixable {
+			continue
+		}
+		switch d.Kind {
+		case DiagMissingRefspec:
+			if err := m.setFetchRefspec(ctx); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			m.output.Success("Configured fetch refspec")
+			fixed++
+		case DiagStaleWorktree:
+			prune = true
+		case DiagBrokenGitFile, DiagOrphanDirectory:
+			repairPaths = append(repairPaths, d.Path)
+		}
+	}
+
+	// Repair before pruning: prune deletes the admin dir of a moved
+	// worktree, after which repair can no longer re-register it.
+	if len(repairPaths) > 0 {
+		args := append([]string{"worktree", "repair"}, repairPaths...)
+		if _, err := m.git.Run(ctx, args, bareDir); err != nil {
+			errs = append(errs, fmt.Errorf("git worktree repair failed: %w", err))
+		} else {
+			for _, p := range repairPaths {
+				m.output.Success(fmt.Sprintf("Repaired worktree %s", p))
+			}
+			fixed += len(repairPaths)
+		}
+	}
+
+	if prune {
+		if _, err := m.git.Run(ctx, []string{"worktree", "prune"}, bareDir); err != nil {
+			errs = append(errs, fmt.Errorf("git worktree prune failed: %w", err))
+		} else {
+			for _, d := range diags {
+				if d.Kind == DiagStaleWorktree {
+					m.output.Success(fmt.Sprintf("Pruned stale worktree %s", d.Path))
+					fixed++
+				}
+			}
+		}
+	}
+
+	return fixed, errors.Join(errs...)
+}
+
+// EnsureFetchRefspec checks that remote.origin.fetch maps every branch to
+// refs/remotes/origin/* and adds the default refspec when it does not, so
+// repos cloned or adopted outside wt can see their remote branches. It warns
+// when it repairs the config.
+func (m *Manager) EnsureFetchRefspec(ctx context.Context) error {
+	_, err := m.ensureFetchRefspec(ctx)
+	return err
+}
+
+// ensureFetchRefspec is EnsureFetchRefspec, also reporting whether the
+// refspec had to be repaired.
+func (m *Manager) ensureFetchRefspec(ctx context.Context) (bool, error) {
+	if _, err := os.Stat(m.BareDir()); os.IsNotExist(err) {
+		return false, ErrRepoNotInitialized
+	}
+	if hasBranchFetchRefspec(m.fetchRefspecs(ctx)) {
+		return false, nil
+	}
+	if err := m.setFetchRefspec(ctx); err != nil {
+		return false, err
+	}
+	m.output.Warn(fmt.Sprintf("remote.origin.fetch did not include %s; added it", defaultFetchRefspec))
+	return true, nil
+}
+
+// checkFetchRefspec is the cheap check List runs: only when origin/* holds
+// at most one branch, the sign of a clone made without a branch refspec,
+// does it look at the config and repair it. Failures are ignored; listing
+// must not fail over the refspec.
+func (m *Manager) checkFetchRefspec(ctx context.Context) {
+	if _, err := os.Stat(m.BareDir()); err != nil {
+		return
+	}
+	result, err := m.git.Run(ctx, []string{"for-each-ref", "--format=%(refname:short)", "refs/remotes/origin"}, m.BareDir())
+	if err != nil {
+		return
+	}
+	branches := 0
+	for _, ref := range strings.Fields(result.Stdout) {
+		if ref != "origin" && ref != "origin/HEAD" {
+			branches++
+		}
+	}
+	if branches > 1 {
+		return
+	}
+	// A repo without origin has nothing to repair.
+	if result, err := m.git.Run(ctx, []string{"config", "--get", "remote.origin.url"}, m.BareDir()); err != nil || strings.TrimSpace(result.Stdout) == "" {
+		return
+	}
+	_, _ = m.ensureFetchRefspec(ctx)
+}
+
+// fetchRefspecs returns the configured remote.origin.fetch values.
+func (m *Manager) fetchRefspecs(ctx context.Context) []string {
+	result, _ := m.git.Run(ctx, []string{"config", "--get-all", "remote.origin.fetch"}, m.BareDir())
+	if result == nil {
+		return nil
+	}
+	return strings.Fields(result.Stdout)
+}
+
+// hasBranchFetchRefspec reports whether specs map all branches to
+// refs/remotes/origin/*. A narrow refspec such as
+// +refs/heads/main:refs/remotes/origin/main does not count.
+func hasBranchFetchRefspec(specs []string) bool {
+	for _, spec := range specs {
+		if strings.TrimPrefix(spec, "+") == "refs/heads/*:refs/remotes/origin/*" {
+			return true
+		}
+	}
+	return false
+}
+
+// setFetchRefspec adds the default fetch refspec next to any configured
+// ones, so narrow or custom refspecs (e.g. for pull refs) are kept.
+func (m *Manager) setFetchRefspec(ctx context.Context) error {
+	if _, err := m.git.Run(ctx, []string{"config", "--add", "remote.origin.fetch", defaultFetchRefspec}, m.BareDir()); err != nil {
+		return fmt.Errorf("failed to configure fetch refspec: %w", err)
+	}
+	return nil
+}
+
+// findOrphanDirs returns directories under the repo dir that contain a .git
+// entry but are not registered worktrees. It does not descend into
+// registered worktrees, orphans, or hidden directories such as .bare.
+func (m *Manager) findOrphanDirs(registered map[string]bool) ([]string, error) {
+	repoDir := m.RepoDir()
+	var orphans []string
+	err := filepath.WalkDir(repoDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == repoDir {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() || path == repoDir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if registered[canonicalPath(path)] {
+			return filepath.SkipDir
+		}
+		if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+			orphans = append(orphans, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to scan %s: %w", repoDir, err)
+	}
+	return orphans, nil
+}
+
+// checkGitFile returns a description of what is wrong with the .git file of
+// the worktree at path, or "" if it points at an admin dir in bareDir.
+func checkGitFile(path, bareDir string) string {
+	gitdir, ok := readGitFile(path)
+	switch {
+	case !ok:
+		return ".git file is missing or unreadable"
+	case !dirExists(gitdir):
+		return fmt.Sprintf(".git points at %s, which does not exist", gitdir)
+	case !isUnder(gitdir, bareDir):
+		return fmt.Sprintf(".git points at %s, outside this repo's bare clone", gitdir)
+	}
+	return ""
+}
+
+// readGitFile returns the gitdir a worktree's .git file points at, resolved
+// to an absolute path.
+func readGitFile(dir string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return "", false
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", false
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(dir, gitdir)
+	}
+	return filepath.Clean(gitdir), true
+}
+
+func isUnder(path, dir string) bool {
+	rel, err := filepath.Rel(canonicalPath(dir), canonicalPath(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
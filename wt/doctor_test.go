@@ -0,0 +1,307 @@
+package wt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newDoctorFixture lays out a repo with one healthy worktree (main), one
+// registered worktree whose directory is gone (gone), one moved worktree
+// (moved, now at elsewhere/moved) and one unrelated directory with a .git
+// file (stray).
+func newDoctorFixture(t *testing.T) (*Manager, *MockGitRunner, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+
+	writeGitFile := func(dir, gitdir string) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: "+gitdir+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"main", "moved"} {
+		if err := os.MkdirAll(filepath.Join(bareDir, "worktrees", name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeGitFile(filepath.Join(repoDir, "main"), filepath.Join(bareDir, "worktrees", "main"))
+	writeGitFile(filepath.Join(repoDir, "elsewhere", "moved"), filepath.Join(bareDir, "worktrees", "moved"))
+	writeGitFile(filepath.Join(repoDir, "stray"), "/nonexistent/worktrees/stray")
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{Stdout: "worktree " + bareDir + "\nbare\n\n" +
+		"worktree " + filepath.Join(repoDir, "main") + "\nHEAD abc1234567890\nbranch refs/heads/main\n\n" +
+		"worktree " + filepath.Join(repoDir, "gone") + "\nHEAD abc1234567890\nbranch refs/heads/gone\nprunable gitdir file points to non-existent location\n\n" +
+		"worktree " + filepath.Join(repoDir, "moved") + "\nHEAD abc1234567890\nbranch refs/heads/moved\nprunable gitdir file points to non-existent location\n\n"}
+
+	var buf bytes.Buffer
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(NewOutput(&buf, false)))
+	return m, mockGit, repoDir
+}
+
+func TestDoctor(t *testing.T) {
+	t.Parallel()
+	m, _, repoDir := newDoctorFixture(t)
+
+	diags, err := m.Doctor(context.Background())
+	if err != nil {
+		t.Fatalf("Doctor() error = %v", err)
+	}
+
+	got := make(map[string]Diagnostic, len(diags))
+	for _, d := range diags {
+		rel, _ := filepath.Rel(repoDir, d.Path)
+		got[string(d.Kind)+" "+rel] = d
+	}
+	want := map[string]bool{
+		"missing_fetch_refspec .bare":      true,
+		"stale_worktree gone":              true,
+		"stale_worktree moved":             true,
+		"orphan_directory elsewhere/moved": true,
+		"orphan_directory stray":           false,
+	}
+	if len(got) != len(want) {
+		t.Errorf("Doctor() found %d problems, want %d: %+v", len(got), len(want), diags)
+	}
+	for key, fixable := range want {
+		d, ok := got[key]
+		if !ok {
+			t.Errorf("missing diagnostic %q", key)
+			continue
+		}
+		if d.Fixable != fixable {
+			t.Errorf("%q Fixable = %v, want %v", key, d.Fixable, fixable)
+		}
+		if d.Fix == "" {
+			t.Errorf("%q has no suggested fix", key)
+		}
+	}
+}
+
+func TestDoctorMissingBareDir(t *testing.T) {
+	t.Parallel()
+	m := NewManager(t.TempDir(), "test-repo", WithGitRunner(NewMockGitRunner()))
+
+	diags, err := m.Doctor(context.Background())
+	if err != nil {
+		t.Fatalf("Doctor() error = %v", err)
+	}
+	if len(diags) != 1 || diags[0].Kind != DiagMissingBareDir {
+		t.Errorf("Doctor() = %+v, want a single missing_bare_dir diagnostic", diags)
+	}
+}
+
+func TestFixDiagnostics(t *testing.T) {
+	t.Parallel()
+	m, mockGit, repoDir := newDoctorFixture(t)
+
+	diags, err := m.Doctor(context.Background())
+	if err != nil {
+		t.Fatalf("Doctor() error = %v", err)
+	}
+	fixed, err := m.FixDiagnostics(context.Background(), diags)
+	if err != nil {
+		t.Fatalf("FixDiagnostics() error = %v", err)
+	}
+	if fixed != 4 {
+		t.Errorf("FixDiagnostics() fixed %d, want 4 (refspec, two prunes, one repair)", fixed)
+	}
+
+	var calls []string
+	for _, c := range mockGit.Calls {
+		if c[0] == "config" || c[0] == "worktree" && c[1] != "list" {
+			calls = append(calls, strings.Join(c, " "))
+		}
+	}
+	want := []string{
+		"config --get-all remote.origin.fetch",
+		"config --add remote.origin.fetch " + defaultFetchRefspec,
+		"worktree repair " + filepath.Join(repoDir, "elsewhere", "moved"),
+		"worktree prune",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("git calls:\n%s\nwant:\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestHasBranchFetchRefspec(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		specs []string
+		want  bool
+	}{
+		{name: "none", want: false},
+		{name: "default", specs: []string{defaultFetchRefspec}, want: true},
+		{name: "non-forced", specs: []string{"refs/heads/*:refs/remotes/origin/*"}, want: true},
+		{name: "narrow", specs: []string{"+refs/heads/main:refs/remotes/origin/main"}, want: false},
+		{name: "narrow plus default", specs: []string{"+refs/heads/main:refs/remotes/origin/main", defaultFetchRefspec}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasBranchFetchRefspec(tt.specs); got != tt.want {
+				t.Errorf("hasBranchFetchRefspec(%q) = %v, want %v", tt.specs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureFetchRefspec(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name       string
+		configured string
+		wantAdd    bool
+	}{
+		{name: "narrow refspec is repaired", configured: "+refs/heads/main:refs/remotes/origin/main\n", wantAdd: true},
+		{name: "default refspec is kept", configured: defaultFetchRefspec + "\n", wantAdd: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(tmpDir, "test-repo", ".bare"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			mockGit := NewMockGitRunner()
+			mockGit.Results["config --get-all remote.origin.fetch"] = &CmdResult{Stdout: tc.configured}
+			var buf bytes.Buffer
+			m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(NewOutput(&buf, false)))
+
+			if err := m.EnsureFetchRefspec(context.Background()); err != nil {
+				t.Fatalf("EnsureFetchRefspec() error = %v", err)
+			}
+			added := false
+			for _, c := range mockGit.Calls {
+				if strings.Join(c, " ") == "config --add remote.origin.fetch "+defaultFetchRefspec {
+					added = true
+				}
+			}
+			if added != tc.wantAdd {
+				t.Errorf("added default refspec = %v, want %v (calls %v)", added, tc.wantAdd, mockGit.Calls)
+			}
+			if warned := strings.Contains(buf.String(), "added it"); warned != tc.wantAdd {
+				t.Errorf("warning printed = %v, want %v: %q", warned, tc.wantAdd, buf.String())
+			}
+		})
+	}
+}
+
+// refspecGitRunner resolves refs/remotes/origin/<branch> only once the
+// default fetch refspec has been added, like a bare clone with a narrow
+// refspec.
+type refspecGitRunner struct {
+	*MockGitRunner
+	added bool
+}
+
+func (r *refspecGitRunner) Run(ctx context.Context, args []string, dir string) (*CmdResult, error) {
+	key := strings.Join(args, " ")
+	if key == "config --add remote.origin.fetch "+defaultFetchRefspec {
+		r.added = true
+	}
+	if strings.HasPrefix(key, "rev-parse refs/remotes/origin/") && !r.added {
+		r.MockGitRunner.Run(ctx, args, dir)
+		return &CmdResult{ExitCode: 128}, errors.New("unknown revision")
+	}
+	return r.MockGitRunner.Run(ctx, args, dir)
+}
+
+func TestOpenRepairsNarrowFetchRefspec(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "test-repo", ".bare"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	git := &refspecGitRunner{MockGitRunner: NewMockGitRunner()}
+	git.Results["config --get-all remote.origin.fetch"] = &CmdResult{Stdout: "+refs/heads/main:refs/remotes/origin/main\n"}
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(git), WithGHRunner(NewMockGHRunner()),
+		WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	if _, err := m.Open(context.Background(), "feature", ""); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	fetches := 0
+	for _, c := range git.Calls {
+		if strings.Join(c, " ") == "fetch origin feature" {
+			fetches++
+		}
+	}
+	if !git.added || fetches != 2 {
+		t.Errorf("refspec added = %v, fetches = %d; want the refspec repaired and the branch fetched again", git.added, fetches)
+	}
+}
+
+func TestListRepairsNarrowFetchRefspec(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name       string
+		remoteRefs string
+		url        string
+		wantRead   bool
+		wantAdd    bool
+	}{
+		{name: "only the default branch is repaired", remoteRefs: "origin\norigin/main\n", url: "git@github.com:o/r.git\n", wantRead: true, wantAdd: true},
+		{name: "remote branches present skips the check", remoteRefs: "origin/main\norigin/feature\n", url: "git@github.com:o/r.git\n"},
+		{name: "no origin is left alone", remoteRefs: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			bareDir := filepath.Join(tmpDir, "test-repo", ".bare")
+			if err := os.MkdirAll(bareDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			mockGit := NewMockGitRunner()
+			mockGit.Results["worktree list --porcelain"] = &CmdResult{Stdout: "worktree " + bareDir + "\nbare\n\n"}
+			mockGit.Results["for-each-ref --format=%(refname:short) refs/remotes/origin"] = &CmdResult{Stdout: tc.remoteRefs}
+			mockGit.Results["config --get remote.origin.url"] = &CmdResult{Stdout: tc.url}
+			mockGit.Results["config --get-all remote.origin.fetch"] = &CmdResult{Stdout: "+refs/heads/main:refs/remotes/origin/main\n"}
+			var buf bytes.Buffer
+			m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(NewOutput(&buf, false)))
+
+			if _, err := m.List(context.Background()); err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			read, added := false, false
+			for _, c := range mockGit.Calls {
+				switch strings.Join(c, " ") {
+				case "config --get-all remote.origin.fetch":
+					read = true
+				case "config --add remote.origin.fetch " + defaultFetchRefspec:
+					added = true
+				}
+			}
+			if read != tc.wantRead || added != tc.wantAdd {
+				t.Errorf("refspec read = %v, added = %v; want %v, %v (calls %v)", read, added, tc.wantRead, tc.wantAdd, mockGit.Calls)
+			}
+		})
+	}
+}
+
+func TestDoctorReportsNarrowRefspecWithoutRepairing(t *testing.T) {
+	t.Parallel()
+	m, mockGit, _ := newDoctorFixture(t)
+	mockGit.Results["for-each-ref --format=%(refname:short) refs/remotes/origin"] = &CmdResult{Stdout: "origin/main\n"}
+	mockGit.Results["config --get remote.origin.url"] = &CmdResult{Stdout: "git@github.com:o/r.git\n"}
+
+	diags, err := m.Doctor(context.Background())
+	if err != nil {
+		t.Fatalf("Doctor() error = %v", err)
+	}
+	if len(diags) == 0 || diags[0].Kind != DiagMissingRefspec {
+		t.Errorf("Doctor() = %+v, want a missing_refspec diagnostic first", diags)
+	}
+	for _, c := range mockGit.Calls {
+		if c[0] == "config" && c[1] == "--add" {
+			t.Errorf("Doctor() changed the config: %v", c)
+		}
+	}
+}
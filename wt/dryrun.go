@@ -0,0 +1,190 @@
+package wt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DryRunCommand is a git or gh command a dry-run Manager recorded instead
+// of running.
+type DryRunCommand struct {
+	Tool string // "git" or "gh"
+	Dir  string
+	Args []string
+}
+
+// String renders the command as a shell line that can be pasted into a
+// script: git commands pin their directory with -C, gh commands are
+// prefixed with a cd.
+func (c DryRunCommand) String() string {
+	quoted := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		quoted[i] = shellQuote(a)
+	}
+	args := strings.Join(quoted, " ")
+	switch {
+	case c.Dir == "":
+		return c.Tool + " " + args
+	case c.Tool == "git":
+		return "git -C " + shellQuote(c.Dir) + " " + args
+	default:
+		return "cd " + shellQuote(c.Dir) + " && " + c.Tool + " " + args
+	}
+}
+
+// shellQuote single-quotes s when it contains characters a POSIX shell
+// would interpret.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=+@%,", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// WithDryRun makes the Manager record, rather than run, every git and gh
+// command that would change the repository or GitHub, and skip seed
+// copying and hooks. Read-only queries still run so the recorded commands
+// match what a real run would do. DryRunCommands returns the record.
+func WithDryRun(enabled bool) Option {
+	return func(m *Manager) { m.dryRun = enabled }
+}
+
+// DryRunCommands returns the commands a dry-run Manager skipped, in the
+// order it would have run them. It is nil unless WithDryRun(true) was set.
+func (m *Manager) DryRunCommands() []DryRunCommand {
+	if m.dryRunLog == nil {
+		return nil
+	}
+	return m.dryRunLog.commands()
+}
+
+// wouldFetch reports, in dry-run mode, whether remote has branch. A dry run
+// skips fetches, so a remote-tracking ref the fetch would have created is
+// still missing; this asks the remote instead so the branch isn't reported
+// as not found.
+func (m *Manager) wouldFetch(ctx context.Context, remote, branch string) bool {
+	if !m.dryRun {
+		return false
+	}
+	if _, err := m.git.Run(ctx, []string{
+		"ls-remote", "--exit-code", remote, "refs/heads/" + branch,
+	}, m.BareDir()); err != nil {
+		return false
+	}
+	m.output.Info(fmt.Sprintf("Dry run: would fetch %s/%s", remote, branch))
+	return true
+}
+
+// dryRunLog collects the commands skipped by the dry-run runners of one
+// Manager.
+type dryRunLog struct {
+	cmds []DryRunCommand
+	mu   sync.Mutex
+}
+
+func (l *dryRunLog) record(c DryRunCommand) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cmds = append(l.cmds, c)
+}
+
+func (l *dryRunLog) commands() []DryRunCommand {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.cmds)
+}
+
+// dryRunRunner wraps a GitRunner or GHRunner (they share a signature),
+// passing read-only commands through and recording the rest as succeeded
+// with empty output.
+type dryRunRunner struct {
+	inner    GitRunner
+	log      *dryRunLog
+	readOnly func(args []string) bool
+	tool     string
+}
+
+// Run implements GitRunner and GHRunner.
+func (r *dryRunRunner) Run(ctx context.Context, args []string, dir string) (*CmdResult, error) {
+	if r.readOnly(args) {
+		return r.inner.Run(ctx, args, dir)
+	}
+	r.log.record(DryRunCommand{Tool: r.tool, Dir: dir, Args: slices.Clone(args)})
+	return &CmdResult{}, nil
+}
+
+// readOnlyDryRunGitSubcmds are git subcommands that never change refs,
+// config or worktrees, beyond readOnlyGitSubcmds.
+var readOnlyDryRunGitSubcmds = map[string]bool{
+	"merge-base":       true,
+	"for-each-ref":     true,
+	"show-ref":         true,
+	"check-ref-format": true,
+	"cat-file":         true,
+	"describe":         true,
+}
+
+// gitArgsReadOnly reports whether a git command only reads state. Unknown
+// subcommands count as writes, so a dry run errs toward not running them.
+func gitArgsReadOnly(args []string) bool {
+	if len(args) == 0 {
+		return true
+	}
+	rest := args[1:]
+	switch sub := args[0]; {
+	case readOnlyGitSubcmds[sub], readOnlyDryRunGitSubcmds[sub]:
+		return true
+	case sub == "worktree":
+		return len(rest) > 0 && rest[0] == "list"
+	case sub == "config":
+		if slices.ContainsFunc(rest, func(a string) bool {
+			return a == "--get" || a == "--get-all" || a == "--get-regexp" || a == "--list" || a == "-l"
+		}) {
+			return true
+		}
+		// "git config <key>" reads; "git config <key> <value>" writes.
+		return len(rest) == 1 && !strings.HasPrefix(rest[0], "-")
+	case sub == "branch":
+		for _, a := range rest {
+			switch a {
+			case "--show-current", "-r", "--remotes", "-a", "--all", "--list", "--contains", "--merged", "--no-merged":
+				return true
+			}
+			if strings.HasPrefix(a, "--format") {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// ghArgsReadOnly reports whether a gh command only reads state.
+func ghArgsReadOnly(args []string) bool {
+	if len(args) == 0 {
+		return true
+	}
+	switch args[0] {
+	case "auth":
+		return len(args) > 1 && args[1] == "status"
+	case "api":
+		return !slices.ContainsFunc(args[1:], func(a string) bool {
+			return a == "-X" || a == "--method" || a == "-f" || a == "-F" || a == "--field" || a == "--raw-field" || a == "--input"
+		})
+	case "pr", "repo", "run", "issue":
+		if len(args) < 2 {
+			return false
+		}
+		switch args[1] {
+		case "view", "list", "checks", "status", "diff":
+			return true
+		}
+	}
+	return false
+}
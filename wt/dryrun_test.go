@@ -0,0 +1,194 @@
+package wt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDryRunCommandString(t *testing.T) {
+	tests := []struct {
+		want string
+		cmd  DryRunCommand
+	}{
+		{
+			cmd:  DryRunCommand{Tool: "git", Dir: "/wt/repo/.bare", Args: []string{"worktree", "add", "-b", "feature", "/wt/repo/feature", "origin/main"}},
+			want: "git -C /wt/repo/.bare worktree add -b feature /wt/repo/feature origin/main",
+		},
+		{
+			cmd:  DryRunCommand{Tool: "git", Dir: "/wt/my repo", Args: []string{"config", "branch.x.goal", "fix it's bug"}},
+			want: `git -C '/wt/my repo' config branch.x.goal 'fix it'\''s bug'`,
+		},
+		{
+			cmd:  DryRunCommand{Tool: "gh", Dir: "/wt/repo/feature", Args: []string{"pr", "merge", "42", "--squash"}},
+			want: "cd /wt/repo/feature && gh pr merge 42 --squash",
+		},
+		{
+			cmd:  DryRunCommand{Tool: "git", Args: []string{"push", "origin", "--delete", ""}},
+			want: "git push origin --delete ''",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.cmd.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGitArgsReadOnly(t *testing.T) {
+	tests := []struct {
+		args string
+		want bool
+	}{
+		{"rev-parse --verify --quiet refs/remotes/origin/x", true},
+		{"status --porcelain", true},
+		{"worktree list --porcelain", true},
+		{"worktree add -b x /p origin/main", false},
+		{"worktree prune", false},
+		{"config --get-all remote.origin.fetch", true},
+		{"config branch.x.description", true},
+		{"config branch.x.description parent:main", false},
+		{"config --unset branch.x.goal", false},
+		{"branch --show-current", true},
+		{"branch -r --format=%(refname:short)", true},
+		{"branch -D x", false},
+		{"fetch origin", false},
+		{"push origin --delete x", false},
+		{"rebase origin/main", false},
+	}
+	for _, tt := range tests {
+		if got := gitArgsReadOnly(strings.Fields(tt.args)); got != tt.want {
+			t.Errorf("gitArgsReadOnly(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestGHArgsReadOnly(t *testing.T) {
+	tests := []struct {
+		args string
+		want bool
+	}{
+		{"pr view feature --json number", true},
+		{"pr list --state open", true},
+		{"pr checks 42", true},
+		{"auth status", true},
+		{"api repos/o/r/pulls", true},
+		{"api -X PATCH repos/o/r/pulls/1", false},
+		{"pr merge 42 --squash", false},
+		{"pr edit 42 --base main", false},
+	}
+	for _, tt := range tests {
+		if got := ghArgsReadOnly(strings.Fields(tt.args)); got != tt.want {
+			t.Errorf("ghArgsReadOnly(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestManagerNewDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	if err := os.MkdirAll(bareDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGit := NewMockGitRunner()
+	output := NewOutput(&bytes.Buffer{}, false)
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(NewMockGHRunner()), WithOutput(output), WithDryRun(true))
+
+	path, err := m.New(context.Background(), "feature", "main", "ship it")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if want := filepath.Join(repoDir, "feature"); path != want {
+		t.Errorf("New() path = %q, want %q", path, want)
+	}
+
+	for _, call := range mockGit.Calls {
+		if !gitArgsReadOnly(call) {
+			t.Errorf("dry run executed %q", strings.Join(call, " "))
+		}
+	}
+
+	var recorded []string
+	for _, c := range m.DryRunCommands() {
+		recorded = append(recorded, strings.Join(c.Args, " "))
+	}
+	for _, want := range []string{
+		"fetch origin",
+		"worktree add -b feature " + path + " origin/main",
+		"config branch.feature.description parent:main",
+		"config branch.feature.goal ship it",
+	} {
+		found := false
+		for _, r := range recorded {
+			if strings.HasPrefix(r, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("DryRunCommands() = %q, missing %q", recorded, want)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("dry run created %s", path)
+	}
+}
+
+// TestManagerDryRunMissingRemoteRef checks that a remote-tracking ref the
+// skipped fetch would have created counts as "would fetch" when the remote
+// has the branch, and as not found when it doesn't.
+func TestManagerDryRunMissingRemoteRef(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "test-repo", ".bare"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mockGit := NewMockGitRunner()
+	mockGit.Errors["rev-parse refs/remotes/origin/feature"] = errors.New("exit status 128")
+	mockGit.Errors["rev-parse --verify --quiet refs/remotes/upstream/release"] = errors.New("exit status 1")
+	mockGit.Errors["rev-parse refs/remotes/origin/gone"] = errors.New("exit status 128")
+	mockGit.Errors["ls-remote --exit-code origin refs/heads/gone"] = errors.New("exit status 2")
+	var buf bytes.Buffer
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(NewMockGHRunner()), WithOutput(NewOutput(&buf, false)), WithDryRun(true))
+	ctx := context.Background()
+
+	if _, err := m.Open(ctx, "feature", ""); err != nil {
+		t.Errorf("Open() error = %v, want the branch to be fetched", err)
+	}
+	if _, err := m.New(ctx, "fix", "", "", NewOptions{Track: "upstream/release"}); err != nil {
+		t.Errorf("New(--track) error = %v, want the branch to be fetched", err)
+	}
+	if !strings.Contains(buf.String(), "would fetch origin/feature") || !strings.Contains(buf.String(), "would fetch upstream/release") {
+		t.Errorf("output does not say what would be fetched:\n%s", buf.String())
+	}
+	if _, err := m.Open(ctx, "gone", ""); !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("Open() of a missing branch error = %v, want ErrBranchNotFound", err)
+	}
+}
+
+func TestMergePRDryRunSaysWouldMerge(t *testing.T) {
+	mockGH := NewMockGHRunner()
+	mockGH.Results["pr view feature --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"] = &CmdResult{Stdout: `{"number":7,"state":"OPEN"}`}
+	mockGH.Result = &CmdResult{Stdout: "[]"}
+	var out bytes.Buffer
+	m := NewManager(t.TempDir(), "test-repo", WithGitRunner(NewMockGitRunner()), WithGHRunner(mockGH), WithOutput(NewOutput(&out, false)), WithDryRun(true))
+
+	if _, err := m.MergePRForBranch(context.Background(), "feature", MergeOptions{Keep: true}); err != nil {
+		t.Fatalf("MergePRForBranch() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Dry run: would merge PR #7") || strings.Contains(out.String(), "Merged PR") {
+		t.Errorf("dry-run merge output:\n%s", out.String())
+	}
+}
+
+func TestManagerDryRunCommandsNilWithoutDryRun(t *testing.T) {
+	m := NewManager(t.TempDir(), "test-repo", WithGitRunner(NewMockGitRunner()))
+	if cmds := m.DryRunCommands(); cmds != nil {
+		t.Errorf("DryRunCommands() = %v, want nil", cmds)
+	}
+}
@@ -0,0 +1,87 @@
+package wt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoEditor is returned by OpenInEditor when neither .wt.yaml nor
+// $EDITOR names an editor.
+var ErrNoEditor = errors.New("no editor configured: set $EDITOR or editor in .wt.yaml")
+
+// OpenInEditor launches the editor on the worktree at path and returns
+// without waiting for it, so it suits GUI editors and clients such as
+// "code" or "emacsclient -n". The editor is .wt.yaml's editor (merged with
+// the global config), else $EDITOR.
+func (m *Manager) OpenInEditor(path string) error {
+	var editor string
+	if cfg, err := m.loadConfig(path); err == nil {
+		editor = cfg.Editor
+	}
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if strings.TrimSpace(editor) == "" {
+		return ErrNoEditor
+	}
+
+	cmd := EditorCommand(editor, path)
+	cmd.Dir = path
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to launch editor %q: %w", editor, err)
+	}
+	return cmd.Process.Release()
+}
+
+// EditorCommand builds an exec.Cmd that opens path in editor, a command
+// string that may carry flags (e.g. "emacsclient -n" or "code --wait").
+// Quoted tokens are respected so paths with spaces work (e.g.
+// '"/path/to/My Editor" --wait'). An empty editor falls back to "code".
+func EditorCommand(editor, path string) *exec.Cmd {
+	parts := shellSplit(editor)
+	if len(parts) == 0 {
+		return exec.Command("code", path)
+	}
+	args := make([]string, len(parts)-1, len(parts))
+	copy(args, parts[1:])
+	args = append(args, path)
+	return exec.Command(parts[0], args...)
+}
+
+// shellSplit splits s into tokens using shell-like quoting rules.
+// Single and double quotes preserve spaces within a token; quotes are
+// stripped from the result. Backslash escaping is not supported.
+func shellSplit(s string) []string {
+	var parts []string
+	var current strings.Builder
+	inSingle := false
+	inDouble := false
+	hasToken := false
+
+	for _, r := range s {
+		switch {
+		case r == '\'' && !inDouble:
+			inSingle = !inSingle
+			hasToken = true
+		case r == '"' && !inSingle:
+			inDouble = !inDouble
+			hasToken = true
+		case (r == ' ' || r == '\t') && !inSingle && !inDouble:
+			if hasToken {
+				parts = append(parts, current.String())
+				current.Reset()
+				hasToken = false
+			}
+		default:
+			current.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if hasToken {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
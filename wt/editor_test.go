@@ -0,0 +1,72 @@
+package wt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestShellSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"simple command", "code", []string{"code"}},
+		{"command with flag", "emacsclient -n", []string{"emacsclient", "-n"}},
+		{"command with multiple flags", "code --wait --new-window", []string{"code", "--wait", "--new-window"}},
+		{"double-quoted path", `"/path/to/My Editor" --wait`, []string{"/path/to/My Editor", "--wait"}},
+		{"single-quoted path", `'/path/to/My Editor' --wait`, []string{"/path/to/My Editor", "--wait"}},
+		{"empty string", "", nil},
+		{"only spaces", "   ", nil},
+		{"extra whitespace", "  code   --wait  ", []string{"code", "--wait"}},
+		{"quoted flag value", `editor "--flag=hello world"`, []string{"editor", "--flag=hello world"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shellSplit(tt.input); !slices.Equal(got, tt.want) {
+				t.Errorf("shellSplit(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenInEditor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	path := filepath.Join(root, "repo", "feature")
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(root, "repo")
+
+	t.Setenv("EDITOR", "")
+	if err := m.OpenInEditor(path); !errors.Is(err, ErrNoEditor) {
+		t.Fatalf("OpenInEditor() with no editor = %v, want ErrNoEditor", err)
+	}
+
+	// .wt.yaml editor wins over $EDITOR.
+	t.Setenv("EDITOR", "false")
+	config := "editor: sh -c 'touch \"$0\"/opened'\n"
+	if err := os.WriteFile(filepath.Join(path, ".wt.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.OpenInEditor(path); err != nil {
+		t.Fatalf("OpenInEditor() error = %v", err)
+	}
+	marker := filepath.Join(path, "opened")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(marker); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("editor was not launched on the worktree")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -196,6 +196,36 @@ func RemoteBranchExists(ctx context.Context, runner GitRunner, branch, dir strin
 	return strings.TrimSpace(result.Stdout) != "", nil
 }
 
+// LocalBranchExists checks if a branch exists in the local repository.
+func LocalBranchExists(ctx context.Context, runner GitRunner, branch, dir string) (bool, error) {
+	result, err := runner.Run(ctx, []string{"branch", "--list", branch}, dir)
+	if err != nil {
+		return false, err
+	}
+	// Listed as "  <branch>", with "*" or "+" when checked out somewhere.
+	return strings.TrimSpace(result.Stdout) != "", nil
+}
+
+// RemoteBranches returns the names of all branches on origin, listed with a
+// single ls-remote so callers can avoid one network round trip per branch.
+func RemoteBranches(ctx context.Context, runner GitRunner, dir string) (map[string]bool, error) {
+	result, err := runner.Run(ctx, []string{"ls-remote", "--heads", "origin"}, dir)
+	if err != nil {
+		return nil, err
+	}
+	branches := make(map[string]bool)
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if name, ok := strings.CutPrefix(fields[1], "refs/heads/"); ok {
+			branches[name] = true
+		}
+	}
+	return branches, nil
+}
+
 // SetBranchDescription sets the description for a branch.
 func SetBranchDescription(ctx context.Context, runner GitRunner, branch, description, dir string) error {
 	_, err := runner.Run(ctx, []string{
@@ -88,10 +88,25 @@ type PRInfo struct {
 	BaseRefName    string `json:"baseRefName"`
 	State          string `json:"state"` // OPEN, CLOSED, MERGED
 	ReviewDecision string `json:"reviewDecision"`
-	Number         int    `json:"number"`
-	IsDraft        bool   `json:"isDraft"`
+	// ChecksState summarizes the PR's CI checks (ChecksPending,
+	// ChecksPassing, ChecksFailing); empty when unknown or no checks ran.
+	// Only ListOpenPRsWithChecks fills it in.
+	ChecksState string `json:"checksState,omitempty"`
+	// FailedChecks names the checks that failed, in rollup order. Like
+	// ChecksState, only ListOpenPRsWithChecks fills it in.
+	FailedChecks []string `json:"failedChecks,omitempty"`
+	Number       int      `json:"number"`
+	IsDraft      bool     `json:"isDraft"`
 }
 
+// CI check states reported in PRInfo.ChecksState and
+// WorktreeStatus.PRChecksState.
+const (
+	ChecksPending = "PENDING"
+	ChecksPassing = "PASSING"
+	ChecksFailing = "FAILING"
+)
+
 // StatusCheck represents a CI status check.
 type StatusCheck struct {
 	State string `json:"state"` // SUCCESS, FAILURE, PENDING
@@ -124,7 +139,7 @@ func GetPRForBranch(ctx context.Context, runner GHRunner, dir string) (*PRInfo,
 func GetPRByBranch(ctx context.Context, runner GHRunner, branch, dir string) (*PRInfo, error) {
 	result, err := runner.Run(ctx, []string{
 		"pr", "view", branch,
-		"--json", "number,url,headRefName,baseRefName,state,reviewDecision",
+		"--json", "number,url,headRefName,baseRefName,state,isDraft,reviewDecision",
 	}, dir)
 	if err != nil {
 		if result != nil && result.Stderr != "" {
@@ -179,6 +194,39 @@ func ListOpenPRs(ctx context.Context, runner GHRunner, dir string) ([]PRInfo, er
 	return prs, nil
 }
 
+// ListOpenPRsWithChecks is ListOpenPRs plus each PR's CI check rollup,
+// summarized into PRInfo.ChecksState. It costs one gh call like ListOpenPRs,
+// so status views can show check state without a call per PR.
+func ListOpenPRsWithChecks(ctx context.Context, runner GHRunner, dir string) ([]PRInfo, error) {
+	result, err := runner.Run(ctx, []string{
+		"pr", "list",
+		"--json", "number,headRefName,baseRefName,state,isDraft,reviewDecision,url,statusCheckRollup",
+		"--state", "open",
+		"--limit", strconv.Itoa(prListLimit),
+	}, dir)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || result.Stdout == "" {
+		return nil, nil
+	}
+
+	var rows []struct {
+		StatusCheckRollup []statusCheck `json:"statusCheckRollup"`
+		PRInfo
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &rows); err != nil {
+		return nil, err
+	}
+	prs := make([]PRInfo, len(rows))
+	for i, row := range rows {
+		prs[i] = row.PRInfo
+		prs[i].ChecksState = checksStateFromRollup(row.StatusCheckRollup)
+		prs[i].FailedChecks = failedChecksFromRollup(row.StatusCheckRollup)
+	}
+	return prs, nil
+}
+
 // ListMergedPRs returns recently merged PRs in the repository.
 // Uses --limit prListLimit (matching ListOpenPRs) to cover busy repos; the caller
 // still warns via PRListTruncated so older merged-PR worktrees aren't silently missed.
@@ -213,6 +261,135 @@ func UpdatePRBase(ctx context.Context, runner GHRunner, prNumber int, newBase, d
 	return err
 }
 
+// SetPRDraft converts a PR to a draft (draft=true) or marks it ready for
+// review (draft=false) via `gh pr ready`.
+func SetPRDraft(ctx context.Context, runner GHRunner, prNumber int, draft bool, dir string) error {
+	args := []string{"pr", "ready", strconv.Itoa(prNumber)}
+	if draft {
+		args = append(args, "--undo")
+	}
+	result, err := runner.Run(ctx, args, dir)
+	if err != nil {
+		if result != nil && result.Stderr != "" {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(result.Stderr))
+		}
+		return err
+	}
+	return nil
+}
+
+// statusCheck is one entry of a PR's statusCheckRollup. Check runs report
+// Name and Status/Conclusion; legacy commit statuses report Context and
+// State.
+type statusCheck struct {
+	Typename   string `json:"__typename"`
+	Name       string `json:"name"`
+	Context    string `json:"context"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	State      string `json:"state"`
+}
+
+// PRChecksPending reports whether any status check on the PR has not finished.
+func PRChecksPending(ctx context.Context, runner GHRunner, prNumber int, dir string) (bool, error) {
+	result, err := runner.Run(ctx, []string{
+		"pr", "view", strconv.Itoa(prNumber),
+		"--json", "statusCheckRollup",
+	}, dir)
+	if err != nil {
+		return false, err
+	}
+
+	var resp struct {
+		StatusCheckRollup []statusCheck `json:"statusCheckRollup"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &resp); err != nil {
+		return false, err
+	}
+	for _, c := range resp.StatusCheckRollup {
+		if c.pending() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RepoDeletesBranchOnMerge reports whether the repository has GitHub's
+// "Automatically delete head branches" setting on, in which case a merged
+// PR's head branch is deleted whatever gh pr merge is told.
+func RepoDeletesBranchOnMerge(ctx context.Context, runner GHRunner, dir string) (bool, error) {
+	result, err := runner.Run(ctx, []string{"repo", "view", "--json", "deleteBranchOnMerge"}, dir)
+	if err != nil {
+		return false, err
+	}
+	var resp struct {
+		DeleteBranchOnMerge bool `json:"deleteBranchOnMerge"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &resp); err != nil {
+		return false, err
+	}
+	return resp.DeleteBranchOnMerge, nil
+}
+
+// checksStateFromRollup summarizes a statusCheckRollup: any failed check
+// makes the PR FAILING, otherwise any unfinished check makes it PENDING.
+// Skipped and neutral checks count as passing. An empty rollup yields "".
+func checksStateFromRollup(checks []statusCheck) string {
+	if len(checks) == 0 {
+		return ""
+	}
+	pending := false
+	for _, c := range checks {
+		if c.failed() {
+			return ChecksFailing
+		}
+		if c.pending() {
+			pending = true
+		}
+	}
+	if pending {
+		return ChecksPending
+	}
+	return ChecksPassing
+}
+
+// failedChecksFromRollup returns the names of the failed checks in a
+// statusCheckRollup, or nil when none failed.
+func failedChecksFromRollup(checks []statusCheck) []string {
+	var names []string
+	for _, c := range checks {
+		if !c.failed() {
+			continue
+		}
+		name := c.Name
+		if c.Typename == "StatusContext" {
+			name = c.Context
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// pending reports whether the check has not finished yet.
+func (c statusCheck) pending() bool {
+	if c.Typename == "StatusContext" {
+		return c.State == "PENDING" || c.State == "EXPECTED"
+	}
+	return c.Status != "" && c.Status != "COMPLETED"
+}
+
+// failed reports whether the check finished unsuccessfully.
+func (c statusCheck) failed() bool {
+	if c.Typename == "StatusContext" {
+		return c.State == "FAILURE" || c.State == "ERROR"
+	}
+	switch c.Conclusion {
+	case "FAILURE", "TIMED_OUT", "CANCELLED", "ACTION_REQUIRED", "STARTUP_FAILURE":
+		return true
+	}
+	return false
+}
+
 // IsPRMerged checks if the PR for a branch is merged.
 func IsPRMerged(ctx context.Context, runner GHRunner, branch, dir string) (bool, error) {
 	info, err := GetPRByBranch(ctx, runner, branch, dir)
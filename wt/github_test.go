@@ -3,7 +3,9 @@ package wt
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -15,6 +17,7 @@ type MockGHRunner struct {
 	Errors  map[string]error
 	Args    []string
 	Calls   [][]string
+	mu      sync.Mutex
 }
 
 func NewMockGHRunner() *MockGHRunner {
@@ -25,6 +28,8 @@ func NewMockGHRunner() *MockGHRunner {
 }
 
 func (m *MockGHRunner) Run(ctx context.Context, args []string, dir string) (*CmdResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.Args = args
 	m.Calls = append(m.Calls, args)
 	key := strings.Join(args, " ")
@@ -325,3 +330,72 @@ func TestListMergedPRs_Empty(t *testing.T) {
 		t.Errorf("expected 0 PRs, got %d", len(prs))
 	}
 }
+
+func TestPRChecksPending(t *testing.T) {
+	tests := []struct {
+		name   string
+		rollup string
+		want   bool
+	}{
+		{name: "no checks", rollup: `[]`, want: false},
+		{name: "all completed", rollup: `[{"__typename":"CheckRun","status":"COMPLETED","conclusion":"SUCCESS"}]`, want: false},
+		{name: "check run in progress", rollup: `[{"__typename":"CheckRun","status":"COMPLETED"},{"__typename":"CheckRun","status":"IN_PROGRESS"}]`, want: true},
+		{name: "check run queued", rollup: `[{"__typename":"CheckRun","status":"QUEUED"}]`, want: true},
+		{name: "status context pending", rollup: `[{"__typename":"StatusContext","state":"PENDING"}]`, want: true},
+		{name: "status context done", rollup: `[{"__typename":"StatusContext","state":"FAILURE"}]`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewMockGHRunner()
+			mock.Results["pr view 7 --json statusCheckRollup"] = &CmdResult{
+				Stdout: `{"statusCheckRollup":` + tt.rollup + `}`,
+			}
+			got, err := PRChecksPending(context.Background(), mock, 7, "/tmp")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PRChecksPending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListOpenPRsWithChecks(t *testing.T) {
+	mock := NewMockGHRunner()
+	mock.Results["pr list --json number,headRefName,baseRefName,state,isDraft,reviewDecision,url,statusCheckRollup --state open --limit 1000"] = &CmdResult{
+		Stdout: `[
+			{"number": 1, "headRefName": "green", "state": "OPEN", "statusCheckRollup": [{"__typename":"CheckRun","status":"COMPLETED","conclusion":"SUCCESS"},{"__typename":"CheckRun","status":"COMPLETED","conclusion":"SKIPPED"}]},
+			{"number": 2, "headRefName": "running", "state": "OPEN", "statusCheckRollup": [{"__typename":"CheckRun","status":"IN_PROGRESS"},{"__typename":"StatusContext","state":"SUCCESS"}]},
+			{"number": 3, "headRefName": "red", "state": "OPEN", "statusCheckRollup": [{"__typename":"CheckRun","status":"IN_PROGRESS"},{"__typename":"CheckRun","name":"test","status":"COMPLETED","conclusion":"FAILURE"}]},
+			{"number": 4, "headRefName": "legacy-red", "state": "OPEN", "statusCheckRollup": [{"__typename":"StatusContext","context":"ci/jenkins","state":"ERROR"}]},
+			{"number": 5, "headRefName": "no-ci", "state": "OPEN", "statusCheckRollup": []}
+		]`,
+	}
+
+	prs, err := ListOpenPRsWithChecks(context.Background(), mock, "/tmp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"green":      ChecksPassing,
+		"running":    ChecksPending,
+		"red":        ChecksFailing,
+		"legacy-red": ChecksFailing,
+		"no-ci":      "",
+	}
+	if len(prs) != len(want) {
+		t.Fatalf("expected %d PRs, got %d", len(want), len(prs))
+	}
+	for _, pr := range prs {
+		if pr.ChecksState != want[pr.HeadRefName] {
+			t.Errorf("%s: ChecksState = %q, want %q", pr.HeadRefName, pr.ChecksState, want[pr.HeadRefName])
+		}
+	}
+	wantFailed := map[string][]string{"red": {"test"}, "legacy-red": {"ci/jenkins"}}
+	for _, pr := range prs {
+		if !reflect.DeepEqual(pr.FailedChecks, wantFailed[pr.HeadRefName]) {
+			t.Errorf("%s: FailedChecks = %v, want %v", pr.HeadRefName, pr.FailedChecks, wantFailed[pr.HeadRefName])
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package wt
+
+import "os/exec"
+
+// setHookProcessGroup is a no-op where process groups are unavailable;
+// cancellation kills only the hook's shell.
+func setHookProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package wt
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setHookProcessGroup starts the hook in its own process group and, on
+// cancellation, kills the whole group so children such as a package
+// manager's workers do not outlive the hook.
+func setHookProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
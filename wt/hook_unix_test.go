@@ -0,0 +1,59 @@
+//go:build unix
+
+package wt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunHooksTimeoutKillsProcessGroup(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "child.pid")
+	var buf bytes.Buffer
+
+	start := time.Now()
+	results, err := RunHooks(context.Background(), []string{"sleep 30 & echo $! > " + pidFile + "; wait"},
+		HookEnv{Branch: "b", Path: dir}, 300*time.Millisecond, NewOutput(&buf, false))
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("RunHooks took %v, want it bounded by the timeout", elapsed)
+	}
+
+	var hookErr *HookFailedError
+	if !errors.As(err, &hookErr) || !hookErr.TimedOut {
+		t.Fatalf("RunHooks() error = %v, want timed-out *HookFailedError", err)
+	}
+	if !strings.Contains(err.Error(), "timed out after 300ms") {
+		t.Errorf("error = %q, want timeout message", err)
+	}
+	if len(results) != 1 || !results[0].TimedOut || results[0].ExitCode != -1 {
+		t.Fatalf("results = %+v, want one timed-out result", results)
+	}
+	if !strings.Contains(buf.String(), "Hook timed out after 300ms") {
+		t.Errorf("output missing timeout line: %q", buf.String())
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("reading child pid: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("parsing child pid: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for syscall.Kill(pid, 0) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("background child %d still running after hook timeout", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
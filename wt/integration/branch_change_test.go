@@ -115,7 +115,7 @@ func TestSyncAfterBranchChange(t *testing.T) {
 
 	// Sync all worktrees — the new-branch worktree should still be rebased
 	// because GetParentBranch falls back to directory name "feature-a" -> parent:main
-	err = repo.manager.Sync(repo.ctx, "")
+	_, err = repo.manager.Sync(repo.ctx, "")
 	require.NoError(t, err)
 
 	// After sync: the worktree should have the main update
@@ -146,11 +146,11 @@ func TestSyncSingleBranchAfterChange(t *testing.T) {
 	repo.addRemoteCommit("main", "main-update.txt", "main update\n", "update main")
 
 	// Sync with old directory name should fail (no worktree has branch "feature-a" anymore)
-	err = repo.manager.Sync(repo.ctx, "feature-a")
+	_, err = repo.manager.Sync(repo.ctx, "feature-a")
 	require.Error(t, err, "Sync with old branch name should fail")
 
 	// Sync with new branch name should succeed
-	err = repo.manager.Sync(repo.ctx, "new-branch")
+	_, err = repo.manager.Sync(repo.ctx, "new-branch")
 	require.NoError(t, err, "Sync with new branch name should succeed")
 
 	require.True(t, fileExists(filepath.Join(featureAPath, "main-update.txt")),
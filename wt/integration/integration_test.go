@@ -306,6 +306,33 @@ func TestOpenExistingBranch(t *testing.T) {
 	require.Equal(t, "origin", strings.TrimSpace(result.Stdout))
 }
 
+// TestAdoptLocalClone tests converting an existing checkout into the bare
+// layout, carrying over a local-only branch and repointing origin.
+func TestAdoptLocalClone(t *testing.T) {
+	repo := newTestRepo(t)
+
+	cloneDir := filepath.Join(t.TempDir(), "checkout")
+	_, err := repo.git.Run(repo.ctx, []string{"clone", repo.remoteDir, cloneDir}, "")
+	require.NoError(t, err)
+	repo.checkoutBranch(cloneDir, "local-feature", true)
+	repo.commitInWorktree(cloneDir, "local.txt", "unpushed\n", "local only")
+
+	adoptedPath, err := repo.manager.Adopt(repo.ctx, cloneDir)
+	require.NoError(t, err)
+
+	repoDir := filepath.Join(repo.root, wt.GetRepoNameFromURL(repo.remoteDir))
+	require.Equal(t, filepath.Join(repoDir, "local-feature"), adoptedPath)
+	require.FileExists(t, filepath.Join(adoptedPath, "local.txt"), "unpushed commit should be carried over")
+	require.FileExists(t, filepath.Join(repoDir, "main", "README.md"))
+
+	result, err := repo.git.Run(repo.ctx, []string{"remote", "get-url", "origin"}, filepath.Join(repoDir, ".bare"))
+	require.NoError(t, err)
+	require.Equal(t, repo.remoteDir, strings.TrimSpace(result.Stdout))
+
+	_, err = repo.manager.Adopt(repo.ctx, cloneDir)
+	require.ErrorContains(t, err, "already exists")
+}
+
 // TestErrorCases tests various error conditions.
 func TestErrorCases(t *testing.T) {
 	t.Run("repo not initialized", func(t *testing.T) {
@@ -362,7 +389,7 @@ func TestSyncRebasesWorktrees(t *testing.T) {
 
 	// Sync - should rebase local onto origin/main
 	t.Log("Calling Sync() to rebase worktrees onto origin/main")
-	err = repo.manager.Sync(repo.ctx, "")
+	_, err = repo.manager.Sync(repo.ctx, "")
 	require.NoError(t, err)
 
 	// After sync: feature-a should have the main update (rebased onto origin/main)
@@ -404,7 +431,7 @@ func TestSyncMultipleWorktrees(t *testing.T) {
 
 	// Sync - feature-a rebases onto origin/main, feature-b rebases onto feature-a
 	t.Log("Calling Sync() to rebase all worktrees")
-	err = repo.manager.Sync(repo.ctx, "")
+	_, err = repo.manager.Sync(repo.ctx, "")
 	require.NoError(t, err)
 
 	// After sync:
@@ -451,7 +478,7 @@ func TestSyncConflictHandling(t *testing.T) {
 
 	// Sync should not panic - it handles the conflict gracefully
 	t.Log("Calling Sync() - expecting conflict during rebase onto origin/main")
-	_ = repo.manager.Sync(repo.ctx, "")
+	_, _ = repo.manager.Sync(repo.ctx, "")
 
 	// Worktree should be in rebase state (conflict needs manual resolution)
 	t.Log("Verifying feature-a is in rebase state due to conflict")
@@ -498,7 +525,7 @@ func TestSyncSkipsChildrenOfFailedBranch(t *testing.T) {
 
 	// Sync - feature-a will fail due to conflict with main, feature-b should be skipped
 	t.Log("Calling Sync() - feature-a will conflict with main, feature-b should be skipped")
-	_ = repo.manager.Sync(repo.ctx, "")
+	_, _ = repo.manager.Sync(repo.ctx, "")
 
 	// Feature-a should be in rebase state (conflict)
 	t.Log("Verifying feature-a is in rebase state")
@@ -555,7 +582,7 @@ func TestSyncParentBranchDeleted(t *testing.T) {
 
 	// Sync - feature-b should detect parent is gone and rebase onto main
 	t.Log("Calling Sync() - feature-b should rebase onto main (parent gone)")
-	err = repo.manager.Sync(repo.ctx, "")
+	_, err = repo.manager.Sync(repo.ctx, "")
 	require.NoError(t, err)
 
 	// Feature-b should have the main update (rebased onto main, not feature-a)
@@ -601,7 +628,7 @@ func TestSyncOpenedBranchRebasesOntoMain(t *testing.T) {
 
 	// Sync - should rebase foo onto origin/main
 	t.Log("Calling Sync() to rebase foo onto origin/main")
-	err = repo.manager.Sync(repo.ctx, "")
+	_, err = repo.manager.Sync(repo.ctx, "")
 	require.NoError(t, err)
 
 	// After sync: foo should have the main update (rebased onto origin/main)
@@ -646,7 +673,7 @@ func TestSyncCascadingWithRemovedParentWorktree(t *testing.T) {
 
 	// Sync - feature-b should rebase onto origin/feature-a (not stale local ref)
 	t.Log("Calling Sync() - feature-b should rebase onto origin/feature-a")
-	err = repo.manager.Sync(repo.ctx, "")
+	_, err = repo.manager.Sync(repo.ctx, "")
 	require.NoError(t, err)
 
 	// After sync: feature-b should have the parent update from origin/feature-a
@@ -52,6 +52,12 @@ var defaultColorMode = ColorAuto
 // Intended to be called once from main.
 func SetDefaultColorMode(m ColorMode) { defaultColorMode = m }
 
+// withWriter returns an Output with the same color setting that writes to w,
+// e.g. a buffer for output that is flushed later.
+func (o *Output) withWriter(w io.Writer) *Output {
+	return &Output{w: w, colorized: o.colorized}
+}
+
 // DefaultOutput creates an Output for stdout, honoring the color mode set
 // via SetDefaultColorMode (ColorAuto by default).
 func DefaultOutput() *Output {
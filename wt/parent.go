@@ -0,0 +1,66 @@
+package wt
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// inferParentBranch guesses the parent of a branch created outside wt,
+// which has no parent: description. Candidates are the branches checked out
+// in worktrees plus the default branch, each compared through its
+// origin/<candidate> ref when that exists (so a stale local default branch
+// doesn't skew the guess) and its local branch otherwise. The parent is the
+// candidate whose fork point is closest to branch: the one with the fewest
+// commits in candidate..branch. Candidates that contain branch (its
+// children, or copies of it) are skipped, and ties go to the default branch,
+// then to the alphabetically first candidate. It returns "" for the default
+// branch itself and when no candidate qualifies. The guess is never
+// recorded: it is recomputed on each lookup, and only wt itself writes
+// parent: descriptions.
+func (m *Manager) inferParentBranch(ctx context.Context, branch, dir string) string {
+	defaultBranch, _ := GetDefaultBranch(ctx, m.git, m.BareDir())
+	if branch == "" || branch == defaultBranch {
+		return ""
+	}
+	worktrees, err := m.list(ctx)
+	if err != nil {
+		return ""
+	}
+	seen := map[string]bool{branch: true}
+	var candidates []string
+	add := func(b string) {
+		if b != "" && !seen[b] {
+			seen[b] = true
+			candidates = append(candidates, b)
+		}
+	}
+	add(defaultBranch)
+	for _, wt := range worktrees {
+		if !wt.IsDetached {
+			add(wt.Branch)
+		}
+	}
+	sort.Strings(candidates)
+
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		ref := c
+		if _, err := m.git.Run(ctx, []string{"rev-parse", "--verify", "--quiet", "refs/remotes/origin/" + c}, dir); err == nil {
+			ref = "origin/" + c
+		}
+		res, err := m.git.Run(ctx, []string{"rev-list", "--count", ref + ".." + branch}, dir)
+		if err != nil {
+			continue
+		}
+		dist, err := strconv.Atoi(strings.TrimSpace(res.Stdout))
+		if err != nil || dist == 0 {
+			continue
+		}
+		if bestDist < 0 || dist < bestDist || (dist == bestDist && c == defaultBranch) {
+			best, bestDist = c, dist
+		}
+	}
+	return best
+}
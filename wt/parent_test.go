@@ -0,0 +1,161 @@
+package wt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newExternalStackFixture lays out a wt repo whose branches were created
+// with plain git, so none has a parent: description: ext-a and ext-c fork
+// from main, and ext-b is stacked on ext-a.
+func newExternalStackFixture(t *testing.T) (*Manager, string) {
+	t.Helper()
+	src := initTempRepo(t)
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@test.com", "-c", "user.name=Test"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run(src, "branch", "-M", "main")
+
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	run(root, "clone", "--bare", "-q", src, bareDir)
+	run(bareDir, "worktree", "add", "-q", filepath.Join(repoDir, "main"), "main")
+	for _, b := range []struct{ branch, from string }{
+		{"ext-a", "main"},
+		{"ext-b", "ext-a"},
+		{"ext-c", "main"},
+	} {
+		dir := filepath.Join(repoDir, b.branch)
+		run(bareDir, "worktree", "add", "-q", "-b", b.branch, dir, b.from)
+		run(dir, "commit", "-q", "--allow-empty", "-m", b.branch)
+	}
+
+	m := NewManager(root, "test-repo", WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	return m, repoDir
+}
+
+func TestGetParentBranchInfersExternalBranches(t *testing.T) {
+	t.Parallel()
+	m, repoDir := newExternalStackFixture(t)
+	ctx := context.Background()
+
+	// A local branch without a worktree is not a candidate, even though it
+	// would win ext-b's tie with ext-a alphabetically.
+	cmd := exec.Command("git", "branch", "aaa-scratch", "ext-a")
+	cmd.Dir = filepath.Join(repoDir, ".bare")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git branch: %v\n%s", err, out)
+	}
+
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"main", ""},
+		{"ext-a", "main"},
+		{"ext-b", "ext-a"},
+		// ext-a forks from the same commit; the default branch wins the tie.
+		{"ext-c", "main"},
+	}
+	for _, tt := range tests {
+		dir := filepath.Join(repoDir, tt.branch)
+		got, err := m.GetParentBranch(ctx, tt.branch, dir)
+		if err != nil {
+			t.Fatalf("GetParentBranch(%s) error = %v", tt.branch, err)
+		}
+		if got != tt.want {
+			t.Errorf("GetParentBranch(%s) = %q, want %q", tt.branch, got, tt.want)
+		}
+		if desc, _ := GetBranchDescription(ctx, m.git, tt.branch, dir); desc != "" {
+			t.Errorf("%s description = %q, want the inferred parent left unrecorded", tt.branch, desc)
+		}
+	}
+}
+
+func TestGetParentBranchPrefersDescription(t *testing.T) {
+	t.Parallel()
+	m, repoDir := newExternalStackFixture(t)
+	ctx := context.Background()
+	dir := filepath.Join(repoDir, "ext-b")
+
+	if err := SetBranchDescription(ctx, m.git, "ext-b", "parent:main", dir); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.GetParentBranch(ctx, "ext-b", dir); got != "main" {
+		t.Errorf("GetParentBranch(ext-b) = %q, want the recorded main", got)
+	}
+
+	// A description that is not a parent entry is not overwritten.
+	if err := SetBranchDescription(ctx, m.git, "ext-c", "spike", filepath.Join(repoDir, "ext-c")); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.GetParentBranch(ctx, "ext-c", filepath.Join(repoDir, "ext-c")); got != "" {
+		t.Errorf("GetParentBranch(ext-c) = %q, want none", got)
+	}
+}
+
+func TestBuildDependencyOrderInfersExternalBranches(t *testing.T) {
+	t.Parallel()
+	m, _ := newExternalStackFixture(t)
+	ctx := context.Background()
+
+	worktrees, err := m.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Put the child first so only the inferred parent can reorder it.
+	for i, j := 0, len(worktrees)-1; i < j; i, j = i+1, j-1 {
+		worktrees[i], worktrees[j] = worktrees[j], worktrees[i]
+	}
+	pos := map[string]int{}
+	for i, wt := range m.buildDependencyOrder(ctx, worktrees) {
+		pos[wt.Branch] = i
+	}
+	if pos["ext-a"] > pos["ext-b"] {
+		t.Errorf("ext-b ordered before its inferred parent ext-a: %v", pos)
+	}
+	if pos["main"] > pos["ext-a"] {
+		t.Errorf("ext-a ordered before main: %v", pos)
+	}
+}
+
+func TestInferParentBranchComparesRemoteRefs(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	if err := os.MkdirAll(bareDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mockGit := NewMockGitRunner()
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{
+		Stdout: "worktree " + bareDir + "\nbare\n\n" +
+			"worktree " + filepath.Join(repoDir, "feature") + "\nHEAD abc1234567890\nbranch refs/heads/feature\n\n" +
+			"worktree " + filepath.Join(repoDir, "unpushed") + "\nHEAD abc1234567890\nbranch refs/heads/unpushed\n\n",
+	}
+	mockGit.Errors["rev-parse --verify --quiet refs/remotes/origin/unpushed"] = errors.New("exit status 1")
+	mockGit.Results["rev-list --count origin/main..feature"] = &CmdResult{Stdout: "3\n"}
+	mockGit.Results["rev-list --count unpushed..feature"] = &CmdResult{Stdout: "1\n"}
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	if got := m.inferParentBranch(context.Background(), "feature", filepath.Join(repoDir, "feature")); got != "unpushed" {
+		t.Errorf("inferParentBranch(feature) = %q, want unpushed", got)
+	}
+	for _, c := range mockGit.Calls {
+		if c[0] == "rev-list" && c[2] == "main..feature" {
+			t.Errorf("compared against the local default branch: %v", c)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package wt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrRenameDefaultBranch is returned by Rename for the default branch's
+// worktree.
+var ErrRenameDefaultBranch = errors.New("cannot rename the default branch")
+
+// Rename renames the branch of the worktree named nameOrBranch (directory
+// name relative to the repo dir, or branch) to newBranch and moves the
+// worktree directory to match, returning the new path. Branch config (goal,
+// parent, upstream) moves with the branch, and local worktrees stacked on
+// the old branch are re-pointed at the new name. The remote branch and any
+// open PR are left as they are.
+func (m *Manager) Rename(ctx context.Context, nameOrBranch, newBranch string) (string, error) {
+	bareDir := m.BareDir()
+	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
+		return "", ErrRepoNotInitialized
+	}
+
+	newBranch, err := m.NormalizeBranchName(ctx, newBranch)
+	if err != nil {
+		return "", err
+	}
+
+	worktrees, err := m.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	var target *Worktree
+	byPath := filepath.Join(m.RepoDir(), nameOrBranch)
+	for i := range worktrees {
+		if worktrees[i].Branch == nameOrBranch || worktrees[i].Path == byPath {
+			target = &worktrees[i]
+			break
+		}
+	}
+	if target == nil || target.IsDetached {
+		return "", ErrWorktreeNotFound
+	}
+	oldBranch := target.Branch
+	if newBranch == oldBranch {
+		return target.Path, nil
+	}
+	if defaultBranch, _ := GetDefaultBranch(ctx, m.git, bareDir); oldBranch == defaultBranch {
+		return "", ErrRenameDefaultBranch
+	}
+
+	newPath := filepath.Join(m.RepoDir(), newBranch)
+	if _, err := os.Stat(newPath); err == nil {
+		return "", ErrWorktreeExists
+	}
+
+	if result, err := m.git.Run(ctx, []string{"branch", "-m", oldBranch, newBranch}, target.Path); err != nil {
+		if result != nil {
+			if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+				return "", fmt.Errorf("failed to rename branch %s: %s: %w", oldBranch, stderr, err)
+			}
+		}
+		return "", fmt.Errorf("failed to rename branch %s: %w", oldBranch, err)
+	}
+	if err := m.moveWorktree(ctx, target.Path, newPath); err != nil {
+		m.undoBranchRename(ctx, newBranch, oldBranch, target.Path)
+		return "", err
+	}
+
+	for _, wt := range worktrees {
+		if wt.IsDetached || wt.Branch == oldBranch {
+			continue
+		}
+		if parent, _ := m.GetParentBranch(ctx, wt.Branch, wt.Path); parent == oldBranch {
+			if err := SetBranchDescription(ctx, m.git, wt.Branch, "parent:"+newBranch, bareDir); err != nil {
+				m.output.Warn(fmt.Sprintf("Failed to re-point %s at %s: %v", wt.Branch, newBranch, err))
+			}
+		}
+	}
+
+	m.renameActivity(oldBranch, newBranch)
+	m.output.Success(fmt.Sprintf("Renamed %s to %s", oldBranch, newBranch))
+	return newPath, nil
+}
+
+// moveWorktree moves the worktree at from to to, creating to's parent
+// directories. A destination nested inside from (feature -> feature/v2) is
+// reached through a temporary sibling, since a directory cannot be moved
+// into itself.
+func (m *Manager) moveWorktree(ctx context.Context, from, to string) error {
+	bareDir := m.BareDir()
+	move := func(src, dst string) error {
+		if result, err := m.git.Run(ctx, []string{"worktree", "move", src, dst}, bareDir); err != nil {
+			if result != nil {
+				if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+					return fmt.Errorf("failed to move worktree: %s: %w", stderr, err)
+				}
+			}
+			return fmt.Errorf("failed to move worktree: %w", err)
+		}
+		return nil
+	}
+
+	src := from
+	if rel, err := filepath.Rel(from, to); err == nil && !strings.HasPrefix(rel, "..") {
+		src = from + ".wt-rename"
+		if err := move(from, src); err != nil {
+			return err
+		}
+	}
+	err := os.MkdirAll(filepath.Dir(to), 0755)
+	if err != nil {
+		err = fmt.Errorf("failed to move worktree: %w", err)
+	} else {
+		err = move(src, to)
+	}
+	if err != nil && src != from {
+		_ = move(src, from)
+	}
+	return err
+}
+
+// undoBranchRename restores a branch renamed by Rename when the worktree
+// move that follows it fails.
+func (m *Manager) undoBranchRename(ctx context.Context, from, to, dir string) {
+	if _, err := m.git.Run(ctx, []string{"branch", "-m", from, to}, dir); err != nil {
+		m.output.Warn(fmt.Sprintf("Failed to restore branch name %s: %v", to, err))
+	}
+}
@@ -0,0 +1,101 @@
+package wt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRenameFixture lays out a real wt repo: a bare clone with worktrees for
+// main, feature and a child stacked on feature.
+func newRenameFixture(t *testing.T) (*Manager, string) {
+	t.Helper()
+	src := initTempRepo(t)
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run(src, "branch", "-M", "main")
+
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	run(root, "clone", "--bare", "-q", src, bareDir)
+	run(bareDir, "worktree", "add", "-q", filepath.Join(repoDir, "main"), "main")
+	run(bareDir, "worktree", "add", "-q", "-b", "feature", filepath.Join(repoDir, "feature"), "main")
+	run(bareDir, "worktree", "add", "-q", "-b", "child", filepath.Join(repoDir, "child"), "feature")
+	run(bareDir, "config", "branch.feature.description", "goal")
+	run(bareDir, "config", "branch.child.description", "parent:feature")
+
+	m := NewManager(root, "test-repo", WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	return m, repoDir
+}
+
+func TestRename(t *testing.T) {
+	t.Parallel()
+	m, repoDir := newRenameFixture(t)
+	ctx := context.Background()
+
+	newPath, err := m.Rename(ctx, "feature", "feature/renamed")
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if want := filepath.Join(repoDir, "feature", "renamed"); newPath != want {
+		t.Errorf("Rename() path = %q, want %q", newPath, want)
+	}
+	wt, err := m.GetWorktreeByBranch(ctx, "feature/renamed")
+	if err != nil {
+		t.Fatalf("GetWorktreeByBranch() error = %v", err)
+	}
+	if wt.Path != newPath {
+		t.Errorf("worktree path = %q, want %q", wt.Path, newPath)
+	}
+	if desc, _ := GetBranchDescription(ctx, m.git, "feature/renamed", newPath); desc != "goal" {
+		t.Errorf("renamed branch description = %q, want it carried over", desc)
+	}
+	if parent, _ := m.GetParentBranch(ctx, "child", filepath.Join(repoDir, "child")); parent != "feature/renamed" {
+		t.Errorf("child parent = %q, want feature/renamed", parent)
+	}
+}
+
+func TestRenameRefusals(t *testing.T) {
+	t.Parallel()
+	m, repoDir := newRenameFixture(t)
+	ctx := context.Background()
+
+	if _, err := m.Rename(ctx, "main", "trunk"); !errors.Is(err, ErrRenameDefaultBranch) {
+		t.Errorf("Rename(main) error = %v, want ErrRenameDefaultBranch", err)
+	}
+	if _, err := m.Rename(ctx, "missing", "other"); !errors.Is(err, ErrWorktreeNotFound) {
+		t.Errorf("Rename(missing) error = %v, want ErrWorktreeNotFound", err)
+	}
+	if _, err := m.Rename(ctx, "feature", "child"); !errors.Is(err, ErrWorktreeExists) {
+		t.Errorf("Rename(feature, child) error = %v, want ErrWorktreeExists", err)
+	}
+
+	// A failed move restores the branch name.
+	if err := os.MkdirAll(filepath.Join(repoDir, "blocked"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "blocked", "x"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Rename(ctx, "feature", "blocked/sub"); err != nil {
+		t.Fatalf("Rename() into a new subdirectory error = %v", err)
+	}
+	if _, err := m.Rename(ctx, "blocked/sub", "blocked/x/y"); err == nil || !strings.Contains(err.Error(), "failed to") {
+		t.Errorf("Rename() under a file error = %v, want a failure", err)
+	}
+	if _, err := m.GetWorktreeByBranch(ctx, "blocked/sub"); err != nil {
+		t.Errorf("branch not restored after failed rename: %v", err)
+	}
+}
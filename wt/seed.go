@@ -0,0 +1,173 @@
+package wt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NewWithSeed creates a worktree like New and then copies seedPaths (relative
+// paths such as ".env" or "node_modules", typically gitignored) from the
+// existing worktree seedFrom into it. seedFrom may be a worktree path or a
+// branch name; when empty the default branch's worktree is used. When
+// seedPaths is empty, .wt.yaml seed_paths applies.
+//
+// Paths missing from seedFrom are skipped, and paths that already exist in
+// the new worktree — tracked files included — are never overwritten. Seeding
+// happens before post-create hooks run, so hooks can rely on the copied files.
+func (m *Manager) NewWithSeed(ctx context.Context, branch, baseBranch, goal, seedFrom string, seedPaths []string) (string, error) {
+	return m.New(ctx, branch, baseBranch, goal, NewOptions{SeedFrom: seedFrom, SeedPaths: seedPaths})
+}
+
+// seedNewWorktree copies the configured seed paths into a freshly created
+// worktree. Problems are reported as warnings; seeding never fails New.
+func (m *Manager) seedNewWorktree(ctx context.Context, worktreePath string, o NewOptions, config *RepoConfig) {
+	paths := o.SeedPaths
+	if len(paths) == 0 && config != nil {
+		paths = config.SeedPaths
+	}
+	if len(paths) == 0 {
+		return
+	}
+	src, err := m.resolveSeedSource(ctx, o.SeedFrom)
+	if err != nil {
+		m.output.Warn(fmt.Sprintf("Skipping seed files: %v", err))
+		return
+	}
+	if canonicalPath(src) == canonicalPath(worktreePath) {
+		return
+	}
+	copied, err := seedWorktree(src, worktreePath, paths)
+	if err != nil {
+		m.output.Warn(fmt.Sprintf("Failed to seed files from %s: %v", src, err))
+	}
+	if len(copied) > 0 {
+		m.output.Info(fmt.Sprintf("Seeded %s from %s", strings.Join(copied, ", "), src))
+	}
+}
+
+// resolveSeedSource maps seedFrom (a path, a branch name, or empty for the
+// default branch) to an existing worktree directory.
+func (m *Manager) resolveSeedSource(ctx context.Context, seedFrom string) (string, error) {
+	if seedFrom == "" {
+		defaultBranch, err := GetDefaultBranch(ctx, m.git, m.BareDir())
+		if err != nil {
+			return "", fmt.Errorf("could not determine default branch: %w", err)
+		}
+		if defaultBranch == "" {
+			return "", fmt.Errorf("could not determine default branch")
+		}
+		seedFrom = defaultBranch
+	}
+	if filepath.IsAbs(seedFrom) {
+		if info, err := os.Stat(seedFrom); err == nil && info.IsDir() {
+			return seedFrom, nil
+		}
+		return "", fmt.Errorf("seed worktree %s does not exist", seedFrom)
+	}
+	path := filepath.Join(m.RepoDir(), seedFrom)
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return path, nil
+	}
+	if wt, err := m.GetWorktreeByBranch(ctx, seedFrom); err == nil {
+		return wt.Path, nil
+	}
+	return "", fmt.Errorf("no worktree found for %q", seedFrom)
+}
+
+// seedWorktree copies each relative path from src into dst. Paths that are
+// absolute or escape the worktree are rejected; paths missing from src or
+// already present in dst are skipped. It returns the paths copied and the
+// first copy error, continuing past failures.
+func seedWorktree(src, dst string, paths []string) ([]string, error) {
+	var copied []string
+	var firstErr error
+	for _, p := range paths {
+		rel := filepath.Clean(strings.TrimSpace(p))
+		if rel == "." || rel == "" {
+			continue
+		}
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("seed path %q must be relative to the worktree", p)
+			}
+			continue
+		}
+		from := filepath.Join(src, rel)
+		to := filepath.Join(dst, rel)
+		if _, err := os.Lstat(from); err != nil {
+			continue
+		}
+		if _, err := os.Lstat(to); err == nil {
+			continue
+		}
+		if err := copyPath(from, to); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("copy %s: %w", rel, err)
+			}
+			continue
+		}
+		copied = append(copied, rel)
+	}
+	return copied, firstErr
+}
+
+// copyPath copies a file, directory tree, or symlink. Symlinks are recreated
+// rather than followed, so node_modules-style links keep pointing where they
+// did.
+func copyPath(from, to string) error {
+	info, err := os.Lstat(from)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return err
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(from)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, to)
+	case info.IsDir():
+		if err := os.Mkdir(to, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(from)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := copyPath(filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	case info.Mode().IsRegular():
+		return copyFile(from, to, info.Mode().Perm())
+	default:
+		// Sockets, pipes and devices are not worth carrying over.
+		return nil
+	}
+}
+
+func copyFile(from, to string, perm os.FileMode) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
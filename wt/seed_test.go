@@ -0,0 +1,96 @@
+package wt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSeedWorktree(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	write := func(root, rel, content string) {
+		t.Helper()
+		p := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(src, ".env", "SECRET=1")
+	write(src, "config/local.yaml", "debug: true")
+	write(src, "tracked.txt", "from seed")
+	write(dst, "tracked.txt", "checked out")
+	if err := os.MkdirAll(filepath.Join(src, "vendor-store", "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("vendor-store", filepath.Join(src, "node_modules")); err != nil {
+		t.Fatal(err)
+	}
+
+	copied, err := seedWorktree(src, dst, []string{".env", "config/local.yaml", "node_modules", "tracked.txt", "missing", "../escape"})
+	if err == nil {
+		t.Error("seedWorktree() error = nil, want error for ../escape")
+	}
+	want := []string{".env", "config/local.yaml", "node_modules"}
+	if len(copied) != len(want) {
+		t.Fatalf("copied = %v, want %v", copied, want)
+	}
+	for i := range want {
+		if copied[i] != want[i] {
+			t.Errorf("copied[%d] = %q, want %q", i, copied[i], want[i])
+		}
+	}
+
+	if got, _ := os.ReadFile(filepath.Join(dst, ".env")); string(got) != "SECRET=1" {
+		t.Errorf(".env = %q, want SECRET=1", got)
+	}
+	if info, err := os.Stat(filepath.Join(dst, ".env")); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf(".env mode = %v (err %v), want 0600", info.Mode().Perm(), err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dst, "config", "local.yaml")); string(got) != "debug: true" {
+		t.Errorf("config/local.yaml = %q", got)
+	}
+	if target, err := os.Readlink(filepath.Join(dst, "node_modules")); err != nil || target != "vendor-store" {
+		t.Errorf("node_modules link = %q (err %v), want vendor-store", target, err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dst, "tracked.txt")); string(got) != "checked out" {
+		t.Errorf("tracked.txt overwritten: %q", got)
+	}
+}
+
+func TestManagerNewSeedsFromWorktree(t *testing.T) {
+	m, mockGit := newBranchNameFixture(t, "")
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mainDir := filepath.Join(m.RepoDir(), "main")
+	for _, name := range []string{".env", ".npmrc"} {
+		if err := os.WriteFile(filepath.Join(mainDir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := context.Background()
+
+	// An empty source means the default branch's worktree.
+	path, err := m.New(ctx, "feature-a", "main", "", NewOptions{SkipFetch: true, SeedPaths: []string{".env", "missing"}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(path, ".env")); string(got) != ".env" {
+		t.Errorf(".env = %q, want seeded copy", got)
+	}
+	if _, err := os.Stat(filepath.Join(path, ".npmrc")); !os.IsNotExist(err) {
+		t.Errorf(".npmrc seeded without being listed (err %v)", err)
+	}
+
+	// A relative source names a worktree directory under the repo.
+	path, err = m.New(ctx, "feature-b", "main", "", NewOptions{SkipFetch: true, SeedFrom: "feature-a", SeedPaths: []string{".env"}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(path, ".env")); string(got) != ".env" {
+		t.Errorf(".env = %q, want copy from feature-a", got)
+	}
+}
@@ -0,0 +1,83 @@
+package wt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WorktreeSort selects the order SortWorktrees puts worktrees in.
+type WorktreeSort string
+
+const (
+	// SortDefault keeps git's worktree list order.
+	SortDefault WorktreeSort = ""
+	// SortByName orders by branch name, then path.
+	SortByName WorktreeSort = "name"
+	// SortByCommitTime puts the most recently committed-to worktrees first.
+	SortByCommitTime WorktreeSort = "commit-time"
+	// SortByStatus puts dirty worktrees first, each group by commit time.
+	SortByStatus WorktreeSort = "status"
+)
+
+// WorktreeSorts lists the accepted non-default sort orders.
+var WorktreeSorts = []WorktreeSort{SortByName, SortByCommitTime, SortByStatus}
+
+// ParseWorktreeSort validates a sort order name. The empty string selects
+// SortDefault.
+func ParseWorktreeSort(s string) (WorktreeSort, error) {
+	by := WorktreeSort(s)
+	if by == SortDefault {
+		return by, nil
+	}
+	for _, valid := range WorktreeSorts {
+		if by == valid {
+			return by, nil
+		}
+	}
+	names := make([]string, len(WorktreeSorts))
+	for i, valid := range WorktreeSorts {
+		names[i] = string(valid)
+	}
+	return "", fmt.Errorf("invalid sort %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// SortWorktrees sorts worktrees in place by the given order and returns the
+// local git statuses it used, keyed like GetAllGitStatuses, so callers need
+// not collect them again. Orders that need no status return nil. Worktrees
+// whose status cannot be read sort last; ties keep git's order.
+func (m *Manager) SortWorktrees(ctx context.Context, worktrees []Worktree, by WorktreeSort) (map[string]*WorktreeStatus, error) {
+	switch by {
+	case SortDefault:
+		return nil, nil
+	case SortByName:
+		sort.SliceStable(worktrees, func(i, j int) bool {
+			if worktrees[i].Branch != worktrees[j].Branch {
+				return worktrees[i].Branch < worktrees[j].Branch
+			}
+			return worktrees[i].Path < worktrees[j].Path
+		})
+		return nil, nil
+	case SortByCommitTime, SortByStatus:
+	default:
+		return nil, fmt.Errorf("invalid sort %q", by)
+	}
+
+	// A failed worktree only loses its place in the order.
+	statuses, err := m.GetAllGitStatuses(ctx, worktrees)
+	if err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	sort.SliceStable(worktrees, func(i, j int) bool {
+		a, b := statuses[worktrees[i].Path], statuses[worktrees[j].Path]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		if by == SortByStatus && a.IsDirty != b.IsDirty {
+			return a.IsDirty
+		}
+		return a.LastCommitTime.After(b.LastCommitTime)
+	})
+	return statuses, nil
+}
@@ -0,0 +1,136 @@
+package wt
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// dirGitRunner answers git commands per worktree directory, keyed by
+// "<dir>: <args>".
+type dirGitRunner struct {
+	*MockGitRunner
+}
+
+func (r *dirGitRunner) Run(ctx context.Context, args []string, dir string) (*CmdResult, error) {
+	if result, ok := r.Results[dir+": "+strings.Join(args, " ")]; ok {
+		return result, nil
+	}
+	return r.MockGitRunner.Run(ctx, args, dir)
+}
+
+func TestSortWorktrees(t *testing.T) {
+	t.Parallel()
+	git := &dirGitRunner{MockGitRunner: NewMockGitRunner()}
+	for _, w := range []struct {
+		path, status string
+		commitTime   string
+	}{
+		{"/wt/main", "", "1700000100"},
+		{"/wt/beta", "1 .M N... 100644 100644 100644 abc abc a.go\n", "1700000050"},
+		{"/wt/alpha", "", "1700000300"},
+		{"/wt/gamma", "? b.go\n", "1700000200"},
+	} {
+		git.Results[w.path+": status --porcelain=v2 --branch"] = &CmdResult{Stdout: w.status}
+		git.Results[w.path+": log -1 --format=%ct|%s"] = &CmdResult{Stdout: w.commitTime + "|msg\n"}
+	}
+	m := NewManager(t.TempDir(), "test-repo", WithGitRunner(git), WithGHRunner(NewMockGHRunner()),
+		WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	listed := []Worktree{
+		{Path: "/wt/main", Branch: "main"},
+		{Path: "/wt/beta", Branch: "beta"},
+		{Path: "/wt/alpha", Branch: "alpha"},
+		{Path: "/wt/gamma", Branch: "gamma"},
+	}
+	tests := []struct {
+		by   WorktreeSort
+		want []string
+	}{
+		{SortDefault, []string{"main", "beta", "alpha", "gamma"}},
+		{SortByName, []string{"alpha", "beta", "gamma", "main"}},
+		{SortByCommitTime, []string{"alpha", "gamma", "main", "beta"}},
+		{SortByStatus, []string{"gamma", "beta", "alpha", "main"}},
+	}
+	for _, tt := range tests {
+		worktrees := append([]Worktree(nil), listed...)
+		statuses, err := m.SortWorktrees(context.Background(), worktrees, tt.by)
+		if err != nil {
+			t.Fatalf("SortWorktrees(%q) error = %v", tt.by, err)
+		}
+		var got []string
+		for _, w := range worktrees {
+			got = append(got, w.Branch)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("SortWorktrees(%q) = %v, want %v", tt.by, got, tt.want)
+		}
+		if tt.by == SortByStatus && !statuses["/wt/gamma"].IsDirty {
+			t.Errorf("SortWorktrees(%q) should return the statuses it sorted by", tt.by)
+		}
+	}
+}
+
+func TestParseWorktreeSort(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"", "name", "commit-time", "status"} {
+		if _, err := ParseWorktreeSort(s); err != nil {
+			t.Errorf("ParseWorktreeSort(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseWorktreeSort("size"); err == nil || !strings.Contains(err.Error(), "commit-time") {
+		t.Errorf("ParseWorktreeSort(size) error = %v, want one listing the valid orders", err)
+	}
+}
+
+func TestParseLastCommit(t *testing.T) {
+	t.Parallel()
+	var status WorktreeStatus
+	parseLastCommit("1700000000|Fix: a|b\n", &status)
+	if status.LastCommitTime.Unix() != 1700000000 || status.LastCommitMsg != "Fix: a|b" {
+		t.Errorf("parseLastCommit() = %v %q", status.LastCommitTime, status.LastCommitMsg)
+	}
+}
+
+func TestGitStatusRunsLogOncePerHead(t *testing.T) {
+	t.Parallel()
+	git := NewMockGitRunner()
+	m := NewManager(t.TempDir(), "test-repo", WithGitRunner(git), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	w := Worktree{Path: "/wt/feature", Branch: "feature"}
+	logCalls := func() int {
+		n := 0
+		for _, call := range git.Calls {
+			if len(call) > 0 && call[0] == "log" {
+				n++
+			}
+		}
+		return n
+	}
+
+	git.Results["status --porcelain=v2 --branch"] = &CmdResult{Stdout: "# branch.oid 5e1f0c4cache1\n# branch.head feature\n"}
+	git.Results["log -1 --format=%ct|%s"] = &CmdResult{Stdout: "1700000000|First\n"}
+	for i := 0; i < 2; i++ {
+		status, err := m.GetGitStatus(context.Background(), w)
+		if err != nil {
+			t.Fatalf("GetGitStatus() error = %v", err)
+		}
+		if status.LastCommitMsg != "First" || status.LastCommitTime.Unix() != 1700000000 {
+			t.Errorf("GetGitStatus() last commit = %v %q, want First", status.LastCommitTime, status.LastCommitMsg)
+		}
+	}
+	if n := logCalls(); n != 1 {
+		t.Errorf("git log ran %d times for one HEAD, want 1", n)
+	}
+
+	// A new HEAD runs git log again.
+	git.Results["status --porcelain=v2 --branch"] = &CmdResult{Stdout: "# branch.oid 5e1f0c4cache2\n# branch.head feature\n"}
+	git.Results["log -1 --format=%ct|%s"] = &CmdResult{Stdout: "1700000100|Second\n"}
+	status, err := m.GetGitStatus(context.Background(), w)
+	if err != nil {
+		t.Fatalf("GetGitStatus() error = %v", err)
+	}
+	if status.LastCommitMsg != "Second" || logCalls() != 2 {
+		t.Errorf("after HEAD moved: last commit %q, %d git log calls; want Second, 2", status.LastCommitMsg, logCalls())
+	}
+}
@@ -0,0 +1,132 @@
+package wt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// maxBranchSuggestions caps how many names a "did you mean" error lists.
+const maxBranchSuggestions = 3
+
+// SuggestBranches returns the remote-tracking branches (e.g.
+// "origin/feature/login") that look like query, closest first: names that
+// contain query, then names within a small edit distance of it. The remote
+// prefix is ignored when comparing, so "login" matches
+// "origin/feature/login". An empty query returns every remote branch.
+func (m *Manager) SuggestBranches(ctx context.Context, query string) ([]string, error) {
+	bareDir := m.BareDir()
+	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
+		return nil, ErrRepoNotInitialized
+	}
+	result, err := m.git.Run(ctx, []string{"branch", "-r", "--format=%(refname:short)"}, bareDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %w", err)
+	}
+	var names []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		name := strings.TrimSpace(line)
+		// <remote>/HEAD is a symref to the default branch; some git versions
+		// shorten it to the bare remote name.
+		if name == "" || !strings.Contains(name, "/") || strings.HasSuffix(name, "/HEAD") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return rankBranchSuggestions(query, names), nil
+}
+
+// branchNotFoundError returns ErrBranchNotFound for branch, listing the
+// closest remote branches when there are any.
+func (m *Manager) branchNotFoundError(ctx context.Context, branch string) error {
+	suggestions, err := m.SuggestBranches(ctx, branch)
+	if err != nil {
+		return ErrBranchNotFound
+	}
+	// A stale remote-tracking ref for the branch itself is not a useful hint.
+	filtered := suggestions[:0]
+	for _, s := range suggestions {
+		if _, short, _ := strings.Cut(s, "/"); short != branch {
+			filtered = append(filtered, s)
+		}
+	}
+	if len(filtered) == 0 {
+		return ErrBranchNotFound
+	}
+	if len(filtered) > maxBranchSuggestions {
+		filtered = filtered[:maxBranchSuggestions]
+	}
+	return fmt.Errorf("%w: %s (did you mean %s?)", ErrBranchNotFound, branch, strings.Join(filtered, ", "))
+}
+
+// rankBranchSuggestions filters names to those resembling query and orders
+// them best first. Substring matches rank ahead of edit-distance matches;
+// within each group shorter differences come first, then names sort
+// alphabetically.
+func rankBranchSuggestions(query string, names []string) []string {
+	type candidate struct {
+		name   string
+		score  int
+		substr bool
+	}
+	q := strings.ToLower(strings.TrimSpace(query))
+	maxDist := max(2, len(q)/3)
+
+	var candidates []candidate
+	for _, name := range names {
+		full := strings.ToLower(name)
+		_, short, _ := strings.Cut(full, "/")
+		switch {
+		case q == "":
+			candidates = append(candidates, candidate{name: name})
+		case strings.Contains(short, q) || strings.Contains(full, q):
+			candidates = append(candidates, candidate{name: name, score: len(short) - len(q), substr: true})
+		default:
+			d := min(editDistance(q, short), editDistance(q, path.Base(short)))
+			if d <= maxDist {
+				candidates = append(candidates, candidate{name: name, score: d})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.substr != b.substr {
+			return a.substr
+		}
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		return a.name < b.name
+	})
+
+	out := make([]string, len(candidates))
+	for i, c := range candidates {
+		out[i] = c.name
+	}
+	return out
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,150 @@
+package wt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRankBranchSuggestions(t *testing.T) {
+	names := []string{
+		"origin/main",
+		"origin/feature/login",
+		"origin/feature/logout",
+		"origin/fix/login-redirect",
+		"upstream/feature/login",
+		"origin/release",
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "substring ranks shortest difference first",
+			query: "login",
+			want:  []string{"origin/feature/login", "upstream/feature/login", "origin/fix/login-redirect"},
+		},
+		{
+			name:  "typo matches by edit distance",
+			query: "feature/logn",
+			want:  []string{"origin/feature/login", "upstream/feature/login", "origin/feature/logout"},
+		},
+		{
+			name:  "typo in last component",
+			query: "relase",
+			want:  []string{"origin/release"},
+		},
+		{
+			name:  "case insensitive",
+			query: "MAIN",
+			want:  []string{"origin/main"},
+		},
+		{
+			name:  "remote prefix in query",
+			query: "upstream/feature",
+			want:  []string{"upstream/feature/login"},
+		},
+		{
+			name:  "nothing close",
+			query: "totally-unrelated",
+			want:  []string{},
+		},
+		{
+			name:  "empty query returns all sorted",
+			query: "",
+			want: []string{
+				"origin/feature/login", "origin/feature/logout", "origin/fix/login-redirect",
+				"origin/main", "origin/release", "upstream/feature/login",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankBranchSuggestions(tt.query, names)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankBranchSuggestions(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"login", "logn", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestManagerOpenSuggestsCloseBranches(t *testing.T) {
+	tmpDir := t.TempDir()
+	bareDir := filepath.Join(tmpDir, "test-repo", ".bare")
+	if err := os.MkdirAll(bareDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Errors["fetch origin feature/logn"] = errors.New("couldn't find remote ref")
+	mockGit.Errors["ls-remote --exit-code origin refs/heads/feature/logn"] = errors.New("exit status 2")
+	mockGit.Results["branch -r --format=%(refname:short)"] = &CmdResult{
+		Stdout: "origin\norigin/HEAD\norigin/main\norigin/feature/login\norigin/feature/signup\n",
+	}
+
+	output := NewOutput(&bytes.Buffer{}, false)
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(NewMockGHRunner()), WithOutput(output))
+
+	ctx := context.Background()
+	_, err := m.Open(ctx, "feature/logn", "")
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Fatalf("Open() error = %v, want ErrBranchNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "did you mean origin/feature/login") {
+		t.Errorf("Open() error = %q, want a suggestion for origin/feature/login", err)
+	}
+
+	got, err := m.SuggestBranches(ctx, "")
+	if err != nil {
+		t.Fatalf("SuggestBranches() error = %v", err)
+	}
+	want := []string{"origin/feature/login", "origin/feature/signup", "origin/main"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SuggestBranches(\"\") = %v, want %v (HEAD symrefs skipped)", got, want)
+	}
+}
+
+func TestManagerOpenWithoutSuggestions(t *testing.T) {
+	tmpDir := t.TempDir()
+	bareDir := filepath.Join(tmpDir, "test-repo", ".bare")
+	if err := os.MkdirAll(bareDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Errors["rev-parse refs/remotes/origin/zzz"] = errors.New("unknown revision")
+	mockGit.Results["branch -r --format=%(refname:short)"] = &CmdResult{Stdout: "origin/main\norigin/zzz\n"}
+
+	output := NewOutput(&bytes.Buffer{}, false)
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(NewMockGHRunner()), WithOutput(output))
+
+	// The only close name is the stale ref for the branch itself, which is
+	// not offered back as a suggestion.
+	_, err := m.Open(context.Background(), "zzz", "")
+	if err != ErrBranchNotFound {
+		t.Errorf("Open() error = %v, want bare ErrBranchNotFound", err)
+	}
+}
@@ -22,6 +22,10 @@ own working directory.
 	wt init git@github.com:user/repo.git
 	cd ~/worktrees/repo/main
 
+	   Or adopt a clone you already have:
+
+		wt init --from-local ~/src/repo
+
 2. Creating and maintaining a feature branch:
 
 		wt new feature-x              # Creates from default base (main)
@@ -55,6 +59,11 @@ own working directory.
 		git rebase main               # Rebase onto main (parent is now in main)
 		git push --force-with-lease   # Update remote
 
+	   wt status and wt sync warn when a stacked PR's base no longer matches
+	   its parent (e.g. the parent merged); fix it with:
+
+		wt sync --fix-pr-base         # Retarget drifted PR bases
+
 3. Opening an existing remote branch:
 
 	wt open existing-branch
@@ -85,6 +94,9 @@ This enables `wt cd` to change your shell's working directory.
 Create .wt.yaml in your repository root:
 
 	default_base: main
+	# Branch naming for wt new: prepend a prefix, lowercase names
+	branch_prefix: alice/
+	branch_lowercase: true
 	# Legacy names
 	post_create:
 	  - npm install
@@ -121,12 +133,14 @@ execute arbitrary shell commands on your machine.
 package wt
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -142,6 +156,9 @@ var (
 	ErrWorktreeExists     = errors.New("worktree already exists")
 	ErrWorktreeNotFound   = errors.New("worktree not found")
 	ErrBranchNotFound     = errors.New("branch not found on remote")
+	ErrBranchExists       = errors.New("branch already exists locally")
+	ErrPRStateUnchanged   = errors.New("PR is already in the requested state")
+	ErrPRInfoUnavailable  = errors.New("PR info unavailable")
 )
 
 // Worktree represents a Git worktree.
@@ -173,8 +190,10 @@ type WorktreeStatus struct {
 	LastCommitTime time.Time
 	LastCommitMsg  string
 	PRURL          string
-	PRState        string // OPEN, MERGED, CLOSED
-	PRReviewStatus string // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, etc.
+	PRState        string   // OPEN, MERGED, CLOSED
+	PRReviewStatus string   // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, etc.
+	PRChecksState  string   // ChecksPending, ChecksPassing, ChecksFailing; empty when unknown
+	PRFailedChecks []string // names of failed checks; only filled from ListOpenPRsWithChecks
 	Worktree       Worktree
 	Ahead          int
 	Behind         int
@@ -188,11 +207,14 @@ type Manager struct {
 	git    GitRunner
 	gh     GHRunner
 	output *Output
+	// dryRunLog records the commands skipped in dry-run mode (see WithDryRun).
+	dryRunLog *dryRunLog
 	// processAlive reports whether a PID is currently running. Injectable for
 	// tests; defaults to defaultProcessAlive.
 	processAlive func(pid int) bool
 	root         string
 	repoName     string
+	dryRun       bool
 }
 
 // Option configures a Manager.
@@ -231,6 +253,11 @@ func NewManager(root, repoName string, opts ...Option) *Manager {
 	for _, opt := range opts {
 		opt(m)
 	}
+	if m.dryRun {
+		m.dryRunLog = &dryRunLog{}
+		m.git = &dryRunRunner{inner: m.git, log: m.dryRunLog, readOnly: gitArgsReadOnly, tool: "git"}
+		m.gh = &dryRunRunner{inner: m.gh, log: m.dryRunLog, readOnly: ghArgsReadOnly, tool: "gh"}
+	}
 	return m
 }
 
@@ -299,7 +326,7 @@ func (m *Manager) Init(ctx context.Context, url string) (string, error) {
 
 	// Configure fetch refspec
 	if _, err := m.git.Run(ctx, []string{
-		"config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*",
+		"config", "remote.origin.fetch", defaultFetchRefspec,
 	}, bareDir); err != nil {
 		return "", err
 	}
@@ -321,20 +348,166 @@ func (m *Manager) Init(ctx context.Context, url string) (string, error) {
 	m.output.Success(fmt.Sprintf("Initialized %s at %s", repoName, repoDir))
 	m.output.Success(fmt.Sprintf("Main worktree: %s", mainPath))
 
-	// Run post-create hooks
-	config, err := LoadRepoConfig(mainPath)
+	m.runCreateHooks(ctx, mainPath, defaultBranch)
+
+	return mainPath, nil
+}
+
+// Adopt initializes the bare-clone layout from a repository that is already
+// cloned at existingRepoPath, instead of cloning fresh from the network. The
+// bare clone is seeded from the local clone (so local branches and unpushed
+// commits come along), then repointed at the clone's origin remote and
+// fetched. A worktree is created for the default branch and, when the
+// existing clone has a different branch checked out, for that branch too.
+//
+// The existing clone is left untouched; uncommitted changes in it are not
+// carried over. Adopt refuses to run if WT_ROOT/<repo> already exists.
+// Returns the path of the worktree for the adopted branch.
+func (m *Manager) Adopt(ctx context.Context, existingRepoPath string) (string, error) {
+	srcPath, err := filepath.Abs(existingRepoPath)
+	if err != nil {
+		return "", err
+	}
+	result, err := m.git.Run(ctx, []string{"rev-parse", "--show-toplevel"}, srcPath)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a git repository: %w", existingRepoPath, err)
+	}
+	if top := strings.TrimSpace(result.Stdout); top != "" {
+		srcPath = top
+	}
+
+	result, err = m.git.Run(ctx, []string{"remote", "get-url", "origin"}, srcPath)
+	if err != nil || strings.TrimSpace(result.Stdout) == "" {
+		return "", fmt.Errorf("%s has no origin remote", srcPath)
+	}
+	url := strings.TrimSpace(result.Stdout)
+
+	m.repoName = GetRepoNameFromURL(url)
+	repoDir := m.RepoDir()
+	bareDir := m.BareDir()
+	if _, err := os.Stat(repoDir); err == nil {
+		return "", fmt.Errorf("%s already exists", repoDir)
+	}
+
+	var currentBranch string
+	if result, err := m.git.Run(ctx, []string{"branch", "--show-current"}, srcPath); err == nil {
+		currentBranch = strings.TrimSpace(result.Stdout)
+	}
+	if result, err := m.git.Run(ctx, []string{"status", "--porcelain"}, srcPath); err == nil && strings.TrimSpace(result.Stdout) != "" {
+		m.output.Warn(fmt.Sprintf("%s has uncommitted changes; they will not be carried over", srcPath))
+	}
+
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		return "", err
+	}
+	// A half-built layout would make every retry fail the "already exists"
+	// check, so remove what we created if any later step fails.
+	adopted := false
+	defer func() {
+		if !adopted {
+			os.RemoveAll(repoDir)
+		}
+	}()
+
+	m.output.Info(fmt.Sprintf("Creating bare repository from %s...", srcPath))
+	if _, err := m.git.Run(ctx, []string{"clone", "--bare", srcPath, bareDir}, ""); err != nil {
+		return "", fmt.Errorf("failed to clone: %w", err)
+	}
+
+	// Point origin back at the real remote rather than the local clone.
+	if _, err := m.git.Run(ctx, []string{"remote", "set-url", "origin", url}, bareDir); err != nil {
+		return "", err
+	}
+	if _, err := m.git.Run(ctx, []string{
+		"config", "remote.origin.fetch", defaultFetchRefspec,
+	}, bareDir); err != nil {
+		return "", err
+	}
+
+	m.output.Info(fmt.Sprintf("Fetching %s...", url))
+	if result, err := m.git.Run(ctx, []string{"fetch", "origin"}, bareDir); err != nil {
+		return "", fmt.Errorf("failed to fetch from origin: %w", wrapAuthError(err, result))
+	}
+	// Without origin/HEAD, GetDefaultBranch would fall back to the bare HEAD,
+	// which mirrors whatever branch the local clone had checked out.
+	if _, err := m.git.Run(ctx, []string{"remote", "set-head", "origin", "--auto"}, bareDir); err != nil {
+		m.output.Warn(fmt.Sprintf("Could not determine origin's default branch: %v", err))
+	}
+
+	defaultBranch, _ := GetDefaultBranch(ctx, m.git, bareDir)
+	mainPath := filepath.Join(repoDir, defaultBranch)
+	m.output.Info(fmt.Sprintf("Creating main worktree at %s...", mainPath))
+	if _, err := m.git.Run(ctx, []string{"worktree", "add", mainPath, defaultBranch}, bareDir); err != nil {
+		return "", fmt.Errorf("failed to create main worktree: %w", err)
+	}
+
+	adoptedPath := mainPath
+	if currentBranch != "" && currentBranch != defaultBranch {
+		adoptedPath = filepath.Join(repoDir, currentBranch)
+		m.output.Info(fmt.Sprintf("Importing %s as worktree at %s...", currentBranch, adoptedPath))
+		if _, err := m.git.Run(ctx, []string{"worktree", "add", adoptedPath, currentBranch}, bareDir); err != nil {
+			return "", fmt.Errorf("failed to create worktree for %s: %w", currentBranch, err)
+		}
+	}
+	adopted = true
+
+	m.output.Success(fmt.Sprintf("Adopted %s from %s at %s", m.repoName, srcPath, repoDir))
+	m.output.Success(fmt.Sprintf("Main worktree: %s", mainPath))
+
+	m.runCreateHooks(ctx, mainPath, defaultBranch)
+	if adoptedPath != mainPath {
+		m.runCreateHooks(ctx, adoptedPath, currentBranch)
+	}
+
+	return adoptedPath, nil
+}
+
+// runCreateHooks runs the repo's post-create hooks in worktreePath. Failures
+// are reported as warnings; the worktree is kept.
+func (m *Manager) runCreateHooks(ctx context.Context, worktreePath, branch string) {
+	config, err := m.loadConfig(worktreePath)
 	if err != nil {
 		m.output.Warn(fmt.Sprintf("Failed to load repo config, skipping hooks: %v", err))
-	} else {
-		createCommands := config.WorktreeCreateCommands()
-		if len(createCommands) > 0 {
-			if err := RunHooks(createCommands, mainPath, defaultBranch, m.output); err != nil {
-				m.output.Warn(fmt.Sprintf("Post-create hook failed: %v", err))
-			}
+		return
+	}
+	createCommands := config.WorktreeCreateCommands()
+	if len(createCommands) > 0 {
+		if _, err := RunHooks(ctx, createCommands, m.hookEnv(ctx, worktreePath, branch, nil), config.EffectiveHookTimeout(), m.output); err != nil {
+			m.output.Warn(fmt.Sprintf("Post-create hook failed: %v", err))
 		}
 	}
+	_ = m.TouchActivity(branch)
+}
 
-	return mainPath, nil
+// HookEnvFor describes the worktree at worktreePath to hooks run outside
+// wt's own create and remove paths (e.g. Bramble's per-repo hooks), so they
+// see the same WT_* variables as .wt.yaml hooks.
+func (m *Manager) HookEnvFor(ctx context.Context, worktreePath, branch string) HookEnv {
+	return m.hookEnv(ctx, worktreePath, branch, nil)
+}
+
+// hookEnv describes the worktree at worktreePath to its hooks. WT_BASE is
+// the PR's base when pr is set, else the branch's parent, else the default
+// branch.
+func (m *Manager) hookEnv(ctx context.Context, worktreePath, branch string, pr *PRInfo) HookEnv {
+	env := HookEnv{
+		Branch: branch,
+		Path:   worktreePath,
+		Repo:   m.repoName,
+		Root:   m.root,
+	}
+	if pr != nil {
+		env.PRNumber = pr.Number
+		env.PRURL = pr.URL
+		env.Base = pr.BaseRefName
+	}
+	if env.Base == "" {
+		env.Base, _ = m.GetParentBranch(ctx, branch, worktreePath)
+	}
+	if env.Base == "" {
+		env.Base, _ = GetDefaultBranch(ctx, m.git, m.BareDir())
+	}
+	return env
 }
 
 // FetchOrigin fetches the default branch from origin for this repo's bare clone.
@@ -377,12 +550,117 @@ func (m *Manager) fetchBaseBranchIfStacked(ctx context.Context, baseBranch strin
 
 // SyncOptions configures optional behavior for Sync.
 type SyncOptions struct {
-	FetchAll bool // fetch all remote branches instead of only the default branch
+	// FetchRefspec, when set, replaces the default branch and worktree
+	// branches in the fetch with FetchRefspec (split on whitespace), e.g.
+	// "main release-*:refs/remotes/origin/release-*". Stack parents are
+	// still fetched, since the rebases need them.
+	FetchRefspec string
+	// FetchAll fetches every remote branch (git fetch --all --prune) instead
+	// of only the default branch, stack parents, and worktree branches.
+	FetchAll bool
+	// FixPRBase retargets open PRs whose base drifted from the branch's
+	// recorded parent (see CheckPRBaseDrift). Without it drift is only
+	// reported.
+	FixPRBase bool
+}
+
+// SyncReport summarizes the per-branch outcome of a Sync.
+type SyncReport struct {
+	// Skipped maps each worktree that was not rebased to the reason, e.g. a
+	// detached HEAD or an ancestor that failed to rebase.
+	Skipped map[string]string
+	// Rebased lists the branches rebased successfully, in sync order.
+	Rebased []string
+	// Conflicts lists the branches whose rebase stopped, in sync order.
+	Conflicts []RebaseConflictError
+}
+
+// String renders the report as counts, e.g. "5 rebased, 1 conflict,
+// 2 skipped". Empty categories are left out.
+func (r *SyncReport) String() string {
+	var parts []string
+	if n := len(r.Rebased); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d rebased", n))
+	}
+	switch n := len(r.Conflicts); {
+	case n == 1:
+		parts = append(parts, "1 conflict")
+	case n > 1:
+		parts = append(parts, fmt.Sprintf("%d conflicts", n))
+	}
+	if n := len(r.Skipped); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", n))
+	}
+	if len(parts) == 0 {
+		return "nothing to sync"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// RebaseConflictError describes a branch whose rebase during Sync failed,
+// usually on conflicts. The worktree is left mid-rebase for the user to
+// continue or abort.
+type RebaseConflictError struct {
+	Err    error
+	Branch string
+	Path   string
+	Onto   string
+}
+
+func (e RebaseConflictError) Error() string {
+	return fmt.Sprintf("failed to rebase %s onto %s: %v", e.Branch, e.Onto, e.Err)
+}
+
+func (e RebaseConflictError) Unwrap() error {
+	return e.Err
 }
 
 // NewOptions configures optional behavior for New.
 type NewOptions struct {
+	// SeedFrom names the worktree (path or branch) to copy SeedPaths from;
+	// empty means the default branch's worktree. See NewWithSeed.
+	SeedFrom string
+	// Track creates the branch from, and sets its upstream to, an arbitrary
+	// remote branch given as "<remote>/<branch>" (e.g. "upstream/release-2")
+	// instead of origin/<base>. The remote branch must exist, and the
+	// branch records it as "track:<remote>/<branch>" rather than a parent,
+	// so it cannot be combined with a base branch.
+	Track string
+	// StartPoint creates the branch from this commit instead of
+	// origin/<base>, and nothing is fetched. The branch still records
+	// <base> as its parent.
+	StartPoint string
+	// SeedPaths overrides .wt.yaml seed_paths for this worktree.
+	SeedPaths []string
 	SkipFetch bool // skip git-fetch (caller already fetched)
+	// Reuse creates the worktree for a local branch that already exists
+	// (e.g. one made with git directly) instead of failing with
+	// ErrBranchExists. The branch keeps its commits; its recorded parent is
+	// replaced only when a base branch is given.
+	Reuse bool
+	// RollbackOnHookFailure makes NewAtomic remove the worktree and delete the
+	// branch when a post-create hook fails, returning a *HookFailedError.
+	// By default hook failures are logged and the worktree is kept.
+	RollbackOnHookFailure bool
+}
+
+// reuseLocalBranch reports whether New should check out branch as an
+// existing local branch rather than create it. A branch that already exists
+// locally is reused only when o.Reuse is set; otherwise it is an
+// ErrBranchExists, since creating it with -b would fail anyway.
+func (m *Manager) reuseLocalBranch(ctx context.Context, branch string, o NewOptions) (bool, error) {
+	exists, err := LocalBranchExists(ctx, m.git, branch, m.BareDir())
+	if err != nil || !exists {
+		// On error let worktree add report the problem.
+		return false, nil
+	}
+	if !o.Reuse {
+		return false, fmt.Errorf("%w: %s", ErrBranchExists, branch)
+	}
+	if o.Track != "" {
+		return false, fmt.Errorf("cannot track %s: %w: %s", o.Track, ErrBranchExists, branch)
+	}
+	return true, nil
 }
 
 // SyncDefaultBranch fast-forwards the local default branch to match origin.
@@ -426,17 +704,27 @@ func (m *Manager) SyncDefaultBranch(ctx context.Context) {
 	m.output.Success(fmt.Sprintf("Updated %s to latest", defaultBranch))
 }
 
-// New creates a new worktree with a new branch.
+// New creates a new worktree with a new branch. The branch name is first
+// passed through NormalizeBranchName; the returned path reflects the
+// normalized name.
 func (m *Manager) New(ctx context.Context, branch, baseBranch, goal string, opts ...NewOptions) (string, error) {
 	var o NewOptions
 	if len(opts) > 0 {
 		o = opts[0]
 	}
+	if err := checkTrackBase(o, baseBranch); err != nil {
+		return "", err
+	}
 	bareDir := m.BareDir()
 	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
 		return "", ErrRepoNotInitialized
 	}
 
+	branch, err := m.NormalizeBranchName(ctx, branch)
+	if err != nil {
+		return "", err
+	}
+
 	worktreePath := filepath.Join(m.RepoDir(), branch)
 	if _, err := os.Stat(worktreePath); err == nil {
 		// If the existing worktree already has the requested branch, reuse it.
@@ -453,30 +741,34 @@ func (m *Manager) New(ctx context.Context, branch, baseBranch, goal string, opts
 		return "", ErrWorktreeExists
 	}
 
+	reuse, err := m.reuseLocalBranch(ctx, branch, o)
+	if err != nil {
+		return "", err
+	}
+
 	// Determine base branch
+	explicitBase := baseBranch != ""
 	if baseBranch == "" {
 		// Try to get from config in any existing worktree
-		entries, _ := os.ReadDir(m.RepoDir())
-		for _, entry := range entries {
-			if entry.IsDir() {
-				wtPath := filepath.Join(m.RepoDir(), entry.Name())
-				if _, err := os.Stat(filepath.Join(wtPath, ".git")); err == nil {
-					config, err := LoadRepoConfig(wtPath)
-					if err != nil {
-						// Config load failed, try next worktree
-						continue
-					}
-					baseBranch = config.DefaultBase
-					break
-				}
-			}
+		if config, err := m.EffectiveConfig(); err == nil {
+			baseBranch = config.DefaultBase
 		}
 		if baseBranch == "" {
 			baseBranch, _ = GetDefaultBranch(ctx, m.git, bareDir)
 		}
 	}
 
-	if !o.SkipFetch {
+	startPoint := "origin/" + baseBranch
+	switch {
+	case reuse:
+		// The existing branch is checked out as is; nothing to fetch.
+	case o.StartPoint != "":
+		startPoint = o.StartPoint
+	case o.Track != "":
+		if startPoint, err = m.prepareTrackedBranch(ctx, o.Track, o.SkipFetch); err != nil {
+			return "", err
+		}
+	case !o.SkipFetch:
 		if err := m.FetchOrigin(ctx); err != nil {
 			return "", err
 		}
@@ -491,10 +783,18 @@ func (m *Manager) New(ctx context.Context, branch, baseBranch, goal string, opts
 	// Prune stale worktree metadata (prevents exit 128 from deleted worktrees).
 	m.git.Run(ctx, []string{"worktree", "prune"}, bareDir)
 
-	m.output.Info(fmt.Sprintf("Creating worktree %s from %s...", branch, baseBranch))
-	if result, err := m.git.Run(ctx, []string{
-		"worktree", "add", "-b", branch, worktreePath, "origin/" + baseBranch,
-	}, bareDir); err != nil {
+	addArgs := []string{"worktree", "add", "-b", branch, worktreePath, startPoint}
+	switch {
+	case reuse:
+		m.output.Info(fmt.Sprintf("Creating worktree for existing branch %s...", branch))
+		addArgs = []string{"worktree", "add", worktreePath, branch}
+	case o.Track != "":
+		m.output.Info(fmt.Sprintf("Creating worktree %s tracking %s...", branch, startPoint))
+		addArgs = []string{"worktree", "add", "--track", "-b", branch, worktreePath, startPoint}
+	default:
+		m.output.Info(fmt.Sprintf("Creating worktree %s from %s...", branch, baseBranch))
+	}
+	if result, err := m.git.Run(ctx, addArgs, bareDir); err != nil {
 		if result != nil {
 			if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
 				return "", fmt.Errorf("failed to create worktree: %s: %w", stderr, err)
@@ -505,14 +805,22 @@ func (m *Manager) New(ctx context.Context, branch, baseBranch, goal string, opts
 
 	m.output.Success(fmt.Sprintf("Created worktree at %s", worktreePath))
 
-	// Always track parent branch for proper sync behavior
-	description := "parent:" + baseBranch
-	if err := SetBranchDescription(ctx, m.git, branch, description, worktreePath); err != nil {
-		m.output.Warn(fmt.Sprintf("Failed to track parent branch: %v", err))
-	} else {
-		defaultBranch, _ := GetDefaultBranch(ctx, m.git, bareDir)
-		if baseBranch != defaultBranch {
-			m.output.Info(fmt.Sprintf("Tracking parent branch: %s", baseBranch))
+	// Always track parent branch for proper sync behavior. A reused branch
+	// keeps the parent it has (or GetParentBranch infers one) unless a base
+	// was given. A tracked branch has no parent, only its remote branch.
+	if o.Track != "" {
+		if err := SetBranchDescription(ctx, m.git, branch, "track:"+o.Track, worktreePath); err != nil {
+			m.output.Warn(fmt.Sprintf("Failed to record tracked branch: %v", err))
+		}
+	} else if !reuse || explicitBase {
+		description := "parent:" + baseBranch
+		if err := SetBranchDescription(ctx, m.git, branch, description, worktreePath); err != nil {
+			m.output.Warn(fmt.Sprintf("Failed to track parent branch: %v", err))
+		} else {
+			defaultBranch, _ := GetDefaultBranch(ctx, m.git, bareDir)
+			if baseBranch != defaultBranch {
+				m.output.Info(fmt.Sprintf("Tracking parent branch: %s", baseBranch))
+			}
 		}
 	}
 
@@ -523,29 +831,83 @@ func (m *Manager) New(ctx context.Context, branch, baseBranch, goal string, opts
 		}
 	}
 
-	// Run post-create hooks
-	config, err := LoadRepoConfig(worktreePath)
+	if m.dryRun {
+		m.output.Info("Dry run: skipping seed files and post-create hooks")
+		return worktreePath, nil
+	}
+
+	// Copy seed files, then run post-create hooks
+	config, err := m.loadConfig(worktreePath)
+	m.seedNewWorktree(ctx, worktreePath, o, config)
 	if err != nil {
 		m.output.Warn(fmt.Sprintf("Failed to load repo config, skipping hooks: %v", err))
 	} else {
 		createCommands := config.WorktreeCreateCommands()
 		if len(createCommands) > 0 {
-			if err := RunHooks(createCommands, worktreePath, branch, m.output); err != nil {
+			if _, err := RunHooks(ctx, createCommands, m.hookEnv(ctx, worktreePath, branch, nil), config.EffectiveHookTimeout(), m.output); err != nil {
 				m.output.Warn(fmt.Sprintf("Post-create hook failed: %v", err))
 			}
 		}
 	}
+	_ = m.TouchActivity(branch)
 
 	return worktreePath, nil
 }
 
-// Open creates a worktree for an existing remote branch.
+// checkTrackBase rejects a base branch or start point given together with
+// o.Track: a tracked branch starts from its remote branch and has no parent.
+func checkTrackBase(o NewOptions, baseBranch string) error {
+	if o.Track != "" && baseBranch != "" {
+		return fmt.Errorf("cannot track %s from %s: a tracked branch starts from its remote branch", o.Track, baseBranch)
+	}
+	if o.Track != "" && o.StartPoint != "" {
+		return fmt.Errorf("cannot track %s from %s: a tracked branch starts from its remote branch", o.Track, o.StartPoint)
+	}
+	return nil
+}
+
+// prepareTrackedBranch validates a "<remote>/<branch>" tracking target,
+// fetching it first unless skipFetch is set, and returns the remote-tracking
+// ref to branch from. A target missing after the fetch yields an error
+// wrapping ErrBranchNotFound.
+func (m *Manager) prepareTrackedBranch(ctx context.Context, track string, skipFetch bool) (string, error) {
+	remote, remoteBranch, ok := strings.Cut(track, "/")
+	if !ok || remote == "" || remoteBranch == "" {
+		return "", fmt.Errorf("invalid track target %q: want <remote>/<branch>", track)
+	}
+	bareDir := m.BareDir()
+	if !skipFetch {
+		// An explicit refspec updates refs/remotes/<remote>/<branch> even when
+		// the remote has no fetch refspec configured.
+		m.output.Info(fmt.Sprintf("Fetching %s...", track))
+		refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", remoteBranch, remote, remoteBranch)
+		if result, err := m.git.Run(ctx, []string{"fetch", remote, refspec}, bareDir); err != nil {
+			m.output.Warn(fmt.Sprintf("Failed to fetch %s: %v", track, wrapAuthError(err, result)))
+		}
+	}
+	if _, err := m.git.Run(ctx, []string{
+		"rev-parse", "--verify", "--quiet", "refs/remotes/" + track,
+	}, bareDir); err != nil && (skipFetch || !m.wouldFetch(ctx, remote, remoteBranch)) {
+		return "", fmt.Errorf("%w: %s", ErrBranchNotFound, track)
+	}
+	return track, nil
+}
+
+// Open creates a worktree for an existing remote branch. When the branch is
+// not on the remote, the returned ErrBranchNotFound names the closest remote
+// branches (see SuggestBranches).
 func (m *Manager) Open(ctx context.Context, branch, goal string) (string, error) {
 	bareDir := m.BareDir()
 	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
 		return "", ErrRepoNotInitialized
 	}
 
+	// The branch already exists on the remote, so it is validated but not
+	// rewritten.
+	if err := m.checkRefFormat(ctx, branch); err != nil {
+		return "", err
+	}
+
 	worktreePath := filepath.Join(m.RepoDir(), branch)
 	if _, err := os.Stat(worktreePath); err == nil {
 		return "", ErrWorktreeExists
@@ -558,16 +920,28 @@ func (m *Manager) Open(ctx context.Context, branch, goal string) (string, error)
 		if _, revErr := m.git.Run(ctx, []string{
 			"ls-remote", "--exit-code", "origin", "refs/heads/" + branch,
 		}, bareDir); revErr != nil {
-			return "", ErrBranchNotFound
+			return "", m.branchNotFoundError(ctx, branch)
 		}
 		return "", fmt.Errorf("failed to fetch %s from origin: %w", branch, fetchErr)
 	}
 
-	// Confirm the ref landed locally after a successful fetch
+	// Confirm the ref landed locally after a successful fetch. A narrow
+	// fetch refspec leaves it in FETCH_HEAD only; repair it and fetch again.
 	if _, err := m.git.Run(ctx, []string{
 		"rev-parse", "refs/remotes/origin/" + branch,
-	}, bareDir); err != nil {
-		return "", ErrBranchNotFound
+	}, bareDir); err != nil && !m.wouldFetch(ctx, "origin", branch) {
+		repaired, _ := m.ensureFetchRefspec(ctx)
+		if !repaired {
+			return "", m.branchNotFoundError(ctx, branch)
+		}
+		if _, err := m.git.Run(ctx, []string{"fetch", "origin", branch}, bareDir); err != nil {
+			return "", fmt.Errorf("failed to fetch %s from origin: %w", branch, err)
+		}
+		if _, err := m.git.Run(ctx, []string{
+			"rev-parse", "refs/remotes/origin/" + branch,
+		}, bareDir); err != nil {
+			return "", m.branchNotFoundError(ctx, branch)
+		}
 	}
 
 	m.output.Info(fmt.Sprintf("Creating worktree for %s...", branch))
@@ -599,24 +973,41 @@ func (m *Manager) Open(ctx context.Context, branch, goal string) (string, error)
 		}
 	}
 
+	if m.dryRun {
+		m.output.Info("Dry run: skipping post-create hooks")
+		return worktreePath, nil
+	}
+
 	// Run post-create hooks
-	config, err := LoadRepoConfig(worktreePath)
+	config, err := m.loadConfig(worktreePath)
 	if err != nil {
 		m.output.Warn(fmt.Sprintf("Failed to load repo config, skipping hooks: %v", err))
 	} else {
 		createCommands := config.WorktreeCreateCommands()
 		if len(createCommands) > 0 {
-			if err := RunHooks(createCommands, worktreePath, branch, m.output); err != nil {
+			if _, err := RunHooks(ctx, createCommands, m.hookEnv(ctx, worktreePath, branch, nil), config.EffectiveHookTimeout(), m.output); err != nil {
 				m.output.Warn(fmt.Sprintf("Post-create hook failed: %v", err))
 			}
 		}
 	}
+	_ = m.TouchActivity(branch)
 
 	return worktreePath, nil
 }
 
-// List returns all worktrees for the repository.
+// List returns all worktrees for the repository. Along the way it repairs
+// a narrow fetch refspec (see checkFetchRefspec).
 func (m *Manager) List(ctx context.Context) ([]Worktree, error) {
+	worktrees, err := m.list(ctx)
+	if err == nil {
+		m.checkFetchRefspec(ctx)
+	}
+	return worktrees, err
+}
+
+// list is List without the refspec check, for Doctor, which reports the
+// refspec instead of repairing it.
+func (m *Manager) list(ctx context.Context) ([]Worktree, error) {
 	bareDir := m.BareDir()
 	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
 		return nil, nil
@@ -748,7 +1139,8 @@ func (m *Manager) GetGitStatus(ctx context.Context, wt Worktree) (*WorktreeStatu
 }
 
 // GetAllGitStatuses returns local git status for worktrees with bounded
-// subprocess concurrency. Each worktree uses a single git status invocation.
+// subprocess concurrency. Each worktree uses one git status invocation, plus
+// a git log the first time its HEAD commit is seen.
 func (m *Manager) GetAllGitStatuses(ctx context.Context, worktrees []Worktree) (map[string]*WorktreeStatus, error) {
 	statuses := make(map[string]*WorktreeStatus, len(worktrees))
 	var mu sync.Mutex
@@ -782,16 +1174,90 @@ func (m *Manager) gitStatusFromPorcelainV2(ctx context.Context, wt Worktree) (*W
 	if err != nil {
 		return status, err
 	}
-	parsePorcelainV2Status(result.Stdout, status)
+	head := parsePorcelainV2Status(result.Stdout, status)
+	if commit, ok := lastCommits.get(head); ok {
+		status.LastCommitTime, status.LastCommitMsg = commit.time, commit.msg
+		return status, nil
+	}
+	// Best-effort: a worktree on an unborn branch has no commit to report.
+	if head == "(initial)" {
+		return status, nil
+	}
+	if result, err := m.git.Run(ctx, []string{"log", "-1", "--format=%ct|%s"}, wt.Path); err == nil {
+		parseLastCommit(result.Stdout, status)
+		if head != "" && !status.LastCommitTime.IsZero() {
+			lastCommits.put(head, lastCommit{time: status.LastCommitTime, msg: status.LastCommitMsg})
+		}
+	}
 	return status, nil
 }
 
-func parsePorcelainV2Status(output string, status *WorktreeStatus) {
+// lastCommit is the commit time and subject reported by
+// `git log -1 --format=%ct|%s`.
+type lastCommit struct {
+	time time.Time
+	msg  string
+}
+
+// lastCommitCache remembers lastCommit by commit ID. A commit's time and
+// subject never change, so status refreshes (bramble polls every worktree)
+// only run git log when a worktree's HEAD moves.
+type lastCommitCache struct {
+	commits map[string]lastCommit
+	mu      sync.Mutex
+}
+
+// maxCachedCommits bounds lastCommitCache; it is cleared when full.
+const maxCachedCommits = 1024
+
+var lastCommits = &lastCommitCache{commits: make(map[string]lastCommit)}
+
+func (c *lastCommitCache) get(oid string) (lastCommit, bool) {
+	if oid == "" {
+		return lastCommit{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	commit, ok := c.commits[oid]
+	return commit, ok
+}
+
+func (c *lastCommitCache) put(oid string, commit lastCommit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.commits) >= maxCachedCommits {
+		clear(c.commits)
+	}
+	c.commits[oid] = commit
+}
+
+// parseLastCommit parses `git log -1 --format=%ct|%s` output into status.
+func parseLastCommit(output string, status *WorktreeStatus) {
+	ts, msg, ok := strings.Cut(strings.TrimSpace(output), "|")
+	if !ok {
+		return
+	}
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return
+	}
+	status.LastCommitTime = time.Unix(secs, 0)
+	status.LastCommitMsg = msg
+}
+
+// parsePorcelainV2Status fills status from `git status --porcelain=v2
+// --branch` output and returns the HEAD commit ID ("(initial)" on an unborn
+// branch, empty if not reported).
+func parsePorcelainV2Status(output string, status *WorktreeStatus) (head string) {
 	for _, line := range strings.Split(output, "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
+		if oid, ok := strings.CutPrefix(line, "# branch.oid "); ok {
+			head = oid
+			continue
+		}
 		if strings.HasPrefix(line, "# branch.ab ") {
 			parseBranchAheadBehind(line, status)
 			continue
@@ -801,6 +1267,7 @@ func parsePorcelainV2Status(output string, status *WorktreeStatus) {
 		}
 		status.IsDirty = true
 	}
+	return head
 }
 
 func parseBranchAheadBehind(line string, status *WorktreeStatus) {
@@ -832,17 +1299,18 @@ func (m *Manager) FetchPRInfo(ctx context.Context, wt Worktree) (*PRInfo, error)
 		return nil, nil
 	}
 
-	result, err := m.gh.Run(ctx, []string{"pr", "view", "--json", "number,url,state,isDraft,reviewDecision"}, wt.Path)
+	result, err := m.gh.Run(ctx, []string{"pr", "view", "--json", "number,url,state,isDraft,reviewDecision,statusCheckRollup"}, wt.Path)
 	if err != nil || result.Stdout == "" {
 		return nil, err
 	}
 
 	var prData struct {
-		URL            string `json:"url"`
-		State          string `json:"state"`
-		ReviewDecision string `json:"reviewDecision"`
-		Number         int    `json:"number"`
-		IsDraft        bool   `json:"isDraft"`
+		URL               string        `json:"url"`
+		State             string        `json:"state"`
+		ReviewDecision    string        `json:"reviewDecision"`
+		StatusCheckRollup []statusCheck `json:"statusCheckRollup"`
+		Number            int           `json:"number"`
+		IsDraft           bool          `json:"isDraft"`
 	}
 	if err := json.Unmarshal([]byte(result.Stdout), &prData); err != nil {
 		return nil, err
@@ -854,14 +1322,16 @@ func (m *Manager) FetchPRInfo(ctx context.Context, wt Worktree) (*PRInfo, error)
 		State:          prData.State,
 		IsDraft:        prData.IsDraft,
 		ReviewDecision: prData.ReviewDecision,
+		ChecksState:    checksStateFromRollup(prData.StatusCheckRollup),
+		FailedChecks:   failedChecksFromRollup(prData.StatusCheckRollup),
 	}, nil
 }
 
-// FetchAllPRInfo fetches all open PRs in a single API call.
-// dir must be a valid Git worktree path (not the bare repo parent)
-// because gh requires a Git repository context.
+// FetchAllPRInfo fetches all open PRs, including their CI check state, in a
+// single API call. dir must be a valid Git worktree path (not the bare repo
+// parent) because gh requires a Git repository context.
 func (m *Manager) FetchAllPRInfo(ctx context.Context, dir string) ([]PRInfo, error) {
-	return ListOpenPRs(ctx, m.gh, dir)
+	return ListOpenPRsWithChecks(ctx, m.gh, dir)
 }
 
 // GetStatus returns extended status for a worktree including PR info.
@@ -879,11 +1349,104 @@ func (m *Manager) GetStatus(ctx context.Context, wt Worktree) (*WorktreeStatus,
 		status.PRState = pr.State
 		status.PRIsDraft = pr.IsDraft
 		status.PRReviewStatus = pr.ReviewDecision
+		if pr.State == "OPEN" {
+			status.PRChecksState = pr.ChecksState
+		}
 	}
 
 	return status, nil
 }
 
+// StatusAllOptions configures optional behavior for StatusAll.
+type StatusAllOptions struct {
+	// Known holds git statuses the caller already has, keyed by worktree
+	// path. Those worktrees skip git status and only get fresh PR fields,
+	// so a periodic PR refresh doesn't re-read every worktree.
+	Known map[string]WorktreeStatus
+}
+
+// StatusAll returns the status of every worktree, in List order: local git
+// status, collected in parallel, and PR fields from a single FetchAllPRInfo
+// call, so only open PRs are reflected. Worktrees whose git status cannot
+// be read are left out, with their errors joined into the returned error.
+// A failed PR fetch leaves the PR fields empty and is reported wrapped in
+// ErrPRInfoUnavailable; the statuses gathered are returned either way.
+func (m *Manager) StatusAll(ctx context.Context, opts ...StatusAllOptions) ([]WorktreeStatus, error) {
+	var o StatusAllOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	worktrees, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*WorktreeStatus, len(worktrees))
+	errs := make([]error, len(worktrees)+1)
+	var g errgroup.Group
+	g.SetLimit(4)
+	for i, w := range worktrees {
+		if known, ok := o.Known[w.Path]; ok {
+			known.Worktree = w
+			results[i] = &known
+			continue
+		}
+		g.Go(func() error {
+			status, err := m.gitStatusFromPorcelainV2(ctx, w)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", w.Path, err)
+				return nil
+			}
+			results[i] = status
+			return nil
+		})
+	}
+
+	prDir := ""
+	for _, w := range worktrees {
+		if !w.IsGone {
+			prDir = w.Path
+			break
+		}
+	}
+	var prs map[string]*PRInfo
+	if prDir != "" {
+		list, err := m.FetchAllPRInfo(ctx, prDir)
+		if err != nil {
+			errs[len(worktrees)] = fmt.Errorf("%w: %w", ErrPRInfoUnavailable, err)
+		}
+		prs = prsByHeadRef(list)
+	}
+	_ = g.Wait()
+
+	statuses := make([]WorktreeStatus, 0, len(worktrees))
+	for _, status := range results {
+		if status == nil {
+			continue
+		}
+		if !status.Worktree.IsDetached {
+			status.applyPR(prs[status.Worktree.Branch])
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, errors.Join(errs...)
+}
+
+// applyPR copies pr into the status's PR fields. A nil pr clears them, so a
+// merged or closed PR doesn't keep showing as open.
+func (s *WorktreeStatus) applyPR(pr *PRInfo) {
+	if pr == nil {
+		pr = &PRInfo{}
+	}
+	s.PRNumber = pr.Number
+	s.PRURL = pr.URL
+	s.PRState = pr.State
+	s.PRIsDraft = pr.IsDraft
+	s.PRReviewStatus = pr.ReviewDecision
+	s.PRChecksState = pr.ChecksState
+	s.PRFailedChecks = pr.FailedChecks
+}
+
 // Remove removes a worktree by name (directory) or branch name.
 // If deleteBranch is true, the local and remote branch are deleted after the worktree is removed.
 // If force is true, passes a single --force to git worktree remove, allowing removal of worktrees
@@ -891,6 +1454,12 @@ func (m *Manager) GetStatus(ctx context.Context, wt Worktree) (*WorktreeStatus,
 // still refuses a locked worktree; callers that must remove locked worktrees use removeResolved
 // with forceLocked=true.
 func (m *Manager) Remove(ctx context.Context, nameOrBranch string, deleteBranch bool, force bool) error {
+	return m.remove(ctx, nameOrBranch, nil, deleteBranch, force)
+}
+
+// remove is Remove for a worktree whose PR, when non-nil, is passed to its
+// post-remove hooks.
+func (m *Manager) remove(ctx context.Context, nameOrBranch string, pr *PRInfo, deleteBranch, force bool) error {
 	// First try as directory name
 	worktreePath := filepath.Join(m.RepoDir(), nameOrBranch)
 	branchName := nameOrBranch
@@ -922,7 +1491,7 @@ func (m *Manager) Remove(ctx context.Context, nameOrBranch string, deleteBranch
 		}
 	}
 
-	return m.removeResolved(ctx, worktreePath, branchName, deleteBranch, force, false)
+	return m.removeResolved(ctx, worktreePath, branchName, pr, deleteBranch, force, false)
 }
 
 // removeResolved runs post-remove hooks, removes the worktree at worktreePath,
@@ -932,17 +1501,19 @@ func (m *Manager) Remove(ctx context.Context, nameOrBranch string, deleteBranch
 // refuses a single --force on a locked working tree. Only the stale-lock GC path
 // sets forceLocked, so an intentionally locked worktree is never silently
 // force-removed by the merged-PR path.
-func (m *Manager) removeResolved(ctx context.Context, worktreePath, branchName string, deleteBranch, force, forceLocked bool) error {
+func (m *Manager) removeResolved(ctx context.Context, worktreePath, branchName string, pr *PRInfo, deleteBranch, force, forceLocked bool) error {
 	bareDir := m.BareDir()
 
 	// Run post-remove hooks first
-	config, err := LoadRepoConfig(worktreePath)
-	if err != nil {
+	config, err := m.loadConfig(worktreePath)
+	if m.dryRun {
+		m.output.Info("Dry run: skipping post-remove hooks")
+	} else if err != nil {
 		m.output.Warn(fmt.Sprintf("Failed to load repo config, skipping hooks: %v", err))
 	} else {
 		deleteCommands := config.WorktreeDeleteCommands()
 		if len(deleteCommands) > 0 {
-			if err := RunHooks(deleteCommands, worktreePath, branchName, m.output); err != nil {
+			if _, err := RunHooks(ctx, deleteCommands, m.hookEnv(ctx, worktreePath, branchName, pr), config.EffectiveHookTimeout(), m.output); err != nil {
 				m.output.Warn(fmt.Sprintf("Post-remove hook failed: %v", err))
 			}
 		}
@@ -968,6 +1539,7 @@ func (m *Manager) removeResolved(ctx context.Context, worktreePath, branchName s
 		return fmt.Errorf("failed to remove worktree: %w", err)
 	}
 	m.output.Success(fmt.Sprintf("Removed worktree %s", branchName))
+	m.forgetActivity(branchName)
 
 	if deleteBranch {
 		// Prune stale worktree metadata so git doesn't think the branch
@@ -1014,8 +1586,15 @@ func (m *Manager) removeResolved(ctx context.Context, worktreePath, branchName s
 
 // Sync fetches the latest changes and rebases worktrees.
 // If branch is non-empty, only that worktree is synced.
-// If branch is empty, all worktrees in the repo are synced.
-func (m *Manager) Sync(ctx context.Context, branch string, opts ...SyncOptions) error {
+// If branch is empty, all worktrees in the repo are synced. Branches at
+// the same depth of the stack are rebased concurrently; a child waits for
+// its parent, and each branch's output is flushed in order once its level
+// finishes.
+//
+// A failed rebase does not fail the sync: it is recorded in the returned
+// report, and the error is reserved for problems that stop the sync as a
+// whole, such as a failed fetch.
+func (m *Manager) Sync(ctx context.Context, branch string, opts ...SyncOptions) (*SyncReport, error) {
 	var o SyncOptions
 	if len(opts) > 0 {
 		o = opts[0]
@@ -1023,83 +1602,64 @@ func (m *Manager) Sync(ctx context.Context, branch string, opts ...SyncOptions)
 
 	bareDir := m.BareDir()
 	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
-		return ErrRepoNotInitialized
+		return nil, ErrRepoNotInitialized
 	}
 
 	if err := CheckGitHubAuth(ctx, m.gh); err != nil {
-		return err
+		return nil, err
+	}
+
+	// Without a branch refspec the fetches below never update origin/*, and
+	// the rebases would run against stale or missing remote branches.
+	if _, err := m.ensureFetchRefspec(ctx); err != nil {
+		m.output.Warn(err.Error())
 	}
 
 	worktrees, err := m.List(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defaultBranch, _ := GetDefaultBranch(ctx, m.git, bareDir)
 
-	if o.FetchAll {
+	// When syncing a single branch, only its parent chain needs fetching.
+	worktreesToCheck := worktrees
+	if branch != "" {
+		worktreesToCheck = nil
+		for _, wt := range worktrees {
+			if wt.Branch == branch {
+				worktreesToCheck = []Worktree{wt}
+				break
+			}
+		}
+	}
+
+	switch {
+	case o.FetchRefspec != "":
+		m.output.Info(fmt.Sprintf("Fetching %s from origin...", o.FetchRefspec))
+		parents := m.stackParents(ctx, worktreesToCheck, defaultBranch)
+		if err := m.syncFetch(ctx, strings.Fields(o.FetchRefspec), parents, nil); err != nil {
+			return nil, err
+		}
+	case o.FetchAll:
 		m.output.Info("Fetching all branches from origin...")
 		result, err := m.git.Run(ctx, []string{"fetch", "--all", "--prune"}, bareDir)
 		if err != nil {
-			return fmt.Errorf("failed to fetch: %w", wrapAuthError(err, result))
+			return nil, fmt.Errorf("failed to fetch: %w", wrapAuthError(err, result))
 		}
-	} else {
-		// Fetch only the default branch and any non-merged parent branches needed for stacked worktrees
+	default:
+		// Fetch the default branch, the stack parents, and the worktree
+		// branches already on origin in one round trip.
 		m.output.Info(fmt.Sprintf("Fetching %s from origin...", defaultBranch))
-		result, err := m.git.Run(ctx, []string{"fetch", "origin", defaultBranch}, bareDir)
-		if err != nil {
-			return fmt.Errorf("failed to fetch %s: %w", defaultBranch, wrapAuthError(err, result))
-		}
-
-		// Collect unique parent branches that need fetching (non-default, non-local-only).
-		// When syncing a single branch, only fetch that branch's parent chain.
-		// When syncing all branches, fetch parents for all worktrees.
-		var worktreesToCheck []Worktree
-		if branch != "" {
-			for _, wt := range worktrees {
-				if wt.Branch == branch {
-					worktreesToCheck = []Worktree{wt}
-					break
-				}
-			}
-		} else {
-			worktreesToCheck = worktrees
-		}
-		fetched := map[string]bool{defaultBranch: true}
-		for _, wt := range worktreesToCheck {
-			if wt.IsDetached {
-				continue
-			}
-			parent, _ := m.GetParentBranch(ctx, wt.Branch, wt.Path)
-			if parent != "" && parent != defaultBranch && !fetched[parent] {
-				fetched[parent] = true
-				m.output.Info(fmt.Sprintf("Fetching parent branch %s...", parent))
-				if result, err := m.git.Run(ctx, []string{"fetch", "origin", parent}, bareDir); err != nil {
-					// Check if the branch was deleted/merged on remote (non-fatal) vs a real
-					// network/auth error (fatal: continuing would rebase onto stale refs).
-					exists, existsErr := RemoteBranchExists(ctx, m.git, parent, bareDir)
-					if existsErr == nil && !exists {
-						m.output.Warn(fmt.Sprintf("Skipping %s: branch no longer exists on remote (merged?)", parent))
-						continue
-					}
-					return fmt.Errorf("failed to fetch parent branch %s: %w", parent, wrapAuthError(err, result))
-				}
-			}
+		parents := m.stackParents(ctx, worktreesToCheck, defaultBranch)
+		pushed := m.pushedWorktreeBranches(ctx, worktreesToCheck, defaultBranch, parents)
+		if err := m.syncFetch(ctx, []string{defaultBranch}, parents, pushed); err != nil {
+			return nil, err
 		}
 	}
 	m.output.Success("Fetched latest changes")
 
-	// Find a worktree to run gh commands from
-	var ghDir string
-	for _, wt := range worktrees {
-		if !wt.IsDetached {
-			ghDir = wt.Path
-			break
-		}
-	}
-	if ghDir == "" {
-		ghDir = bareDir
-	}
+	ghDir := m.ghDir(worktrees)
 
 	// Build dependency graph and sort topologically
 	orderedWorktrees := m.buildDependencyOrder(ctx, worktrees)
@@ -1114,71 +1674,410 @@ func (m *Manager) Sync(ctx context.Context, branch string, opts ...SyncOptions)
 			}
 		}
 		if len(filtered) == 0 {
-			return fmt.Errorf("worktree for branch %q not found", branch)
+			return nil, fmt.Errorf("worktree for branch %q not found", branch)
 		}
 		orderedWorktrees = filtered
 	}
 
-	// Track failed branches to skip their children
+	// Rebase level by level: a level's branches only depend on branches in
+	// earlier levels, so they run concurrently. Each branch logs into its own
+	// buffer, flushed in dependency order once the level finishes.
+	parents := make(map[string]string, len(orderedWorktrees))
+	for _, wt := range orderedWorktrees {
+		if !wt.IsDetached {
+			parents[wt.Branch], _ = m.GetParentBranch(ctx, wt.Branch, wt.Path)
+		}
+	}
+	report := &SyncReport{Skipped: make(map[string]string)}
 	failedBranches := make(map[string]bool)
+	for _, level := range syncLevels(orderedWorktrees, parents) {
+		logs := make([]bytes.Buffer, len(level))
+		results := make([]branchSync, len(level))
+		var g errgroup.Group
+		g.SetLimit(syncConcurrency)
+		for i, wt := range level {
+			i, wt := i, wt
+			g.Go(func() error {
+				out := m.output.withWriter(&logs[i])
+				results[i] = m.syncWorktree(ctx, wt, parents[wt.Branch], out, defaultBranch, ghDir, failedBranches)
+				return nil
+			})
+		}
+		_ = g.Wait()
+		for i, wt := range level {
+			_, _ = m.output.Writer().Write(logs[i].Bytes())
+			res := results[i]
+			switch {
+			case res.conflict != nil:
+				report.Conflicts = append(report.Conflicts, *res.conflict)
+			case res.skipped != "":
+				report.Skipped[wt.Name()] = res.skipped
+			default:
+				report.Rebased = append(report.Rebased, wt.Branch)
+			}
+			if res.failed {
+				failedBranches[wt.Branch] = true
+			}
+		}
+	}
 
-	for _, wt := range orderedWorktrees {
+	m.reportPRBaseDrift(ctx, branch, o.FixPRBase)
+	return report, nil
+}
+
+// stackParents returns the recorded parents of worktrees, excluding the
+// default branch, in first-seen order.
+func (m *Manager) stackParents(ctx context.Context, worktrees []Worktree, defaultBranch string) []string {
+	seen := map[string]bool{defaultBranch: true}
+	var parents []string
+	for _, wt := range worktrees {
 		if wt.IsDetached {
-			m.output.Info(fmt.Sprintf("Skipping detached worktree %s", wt.Name()))
 			continue
 		}
+		parent, _ := m.GetParentBranch(ctx, wt.Branch, wt.Path)
+		if parent != "" && !seen[parent] {
+			seen[parent] = true
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
 
-		// Check if any ancestor failed
-		parentBranch, _ := m.GetParentBranch(ctx, wt.Branch, wt.Path)
-		if parentBranch != "" && failedBranches[parentBranch] {
-			m.output.Warn(fmt.Sprintf("Skipping %s - ancestor branch %s failed to rebase", wt.Branch, parentBranch))
-			failedBranches[wt.Branch] = true
+// pushedWorktreeBranches returns the worktree branches that already have a
+// remote-tracking branch, read from local refs so no network call is made.
+// Branches never pushed are left out, as are the default branch and parents.
+func (m *Manager) pushedWorktreeBranches(ctx context.Context, worktrees []Worktree, defaultBranch string, parents []string) []string {
+	result, err := m.git.Run(ctx, []string{"for-each-ref", "--format=%(refname:short)", "refs/remotes/origin"}, m.BareDir())
+	if err != nil {
+		return nil
+	}
+	tracked := make(map[string]bool)
+	for _, ref := range strings.Fields(result.Stdout) {
+		if b, ok := strings.CutPrefix(ref, "origin/"); ok {
+			tracked[b] = true
+		}
+	}
+	skip := map[string]bool{defaultBranch: true}
+	for _, p := range parents {
+		skip[p] = true
+	}
+	var branches []string
+	for _, wt := range worktrees {
+		if wt.IsDetached || wt.Branch == "" || skip[wt.Branch] || !tracked[wt.Branch] {
 			continue
 		}
+		skip[wt.Branch] = true
+		branches = append(branches, wt.Branch)
+	}
+	return branches
+}
 
-		// Determine rebase target based on parent branch
-		var rebaseTarget string
+// syncFetch fetches refspecs, parents, and branches from origin in a single
+// git fetch. Only when that fails does it ask origin which branches still
+// exist: parents deleted on origin (merged?) are skipped with a warning, and
+// worktree branches deleted on origin are dropped, before one retry. Any
+// other failure is fatal, since rebasing onto stale refs would be wrong.
+func (m *Manager) syncFetch(ctx context.Context, refspecs, parents, branches []string) error {
+	bareDir := m.BareDir()
+	args := []string{"fetch", "origin"}
+	seen := make(map[string]bool)
+	for _, list := range [][]string{refspecs, parents, branches} {
+		for _, ref := range list {
+			if !seen[ref] {
+				seen[ref] = true
+				args = append(args, ref)
+			}
+		}
+	}
+	result, err := m.git.Run(ctx, args, bareDir)
+	if err == nil {
+		return nil
+	}
+	fetchErr := fmt.Errorf("failed to fetch %s: %w", strings.Join(args[2:], " "), wrapAuthError(err, result))
 
-		if parentBranch == "" || parentBranch == defaultBranch {
-			// No parent or parent is default branch: rebase onto default branch
-			rebaseTarget = "origin/" + defaultBranch
-		} else {
-			// Cascading branch: check if parent was merged
-			if m.isParentBranchMerged(ctx, parentBranch, ghDir) {
-				m.output.Info(fmt.Sprintf("Parent branch %s was merged, rebasing %s onto %s...",
-					parentBranch, wt.Branch, defaultBranch))
-				rebaseTarget = "origin/" + defaultBranch
-
-				// Update PR base branch if PR exists
-				prInfo, err := GetPRByBranch(ctx, m.gh, wt.Branch, ghDir)
-				if err == nil && prInfo != nil && prInfo.Number > 0 {
-					m.output.Info(fmt.Sprintf("Updating PR #%d base to %s...", prInfo.Number, defaultBranch))
-					if err := UpdatePRBase(ctx, m.gh, prInfo.Number, defaultBranch, ghDir); err != nil {
-						m.output.Warn(fmt.Sprintf("Failed to update PR base: %v", err))
-					}
-				}
+	remote, lsErr := RemoteBranches(ctx, m.git, bareDir)
+	if lsErr != nil {
+		return fetchErr
+	}
+	gone := make(map[string]bool)
+	for _, p := range parents {
+		if !remote[p] {
+			m.output.Warn(fmt.Sprintf("Skipping %s: branch no longer exists on remote (merged?)", p))
+			gone[p] = true
+		}
+	}
+	for _, b := range branches {
+		if !remote[b] {
+			gone[b] = true
+		}
+	}
+	if len(gone) == 0 {
+		return fetchErr
+	}
+	retry := args[:2:2]
+	for _, ref := range args[2:] {
+		if !gone[ref] {
+			retry = append(retry, ref)
+		}
+	}
+	result, err = m.git.Run(ctx, retry, bareDir)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", strings.Join(retry[2:], " "), wrapAuthError(err, result))
+	}
+	return nil
+}
 
-				// Update branch description
-				if err := SetBranchDescription(ctx, m.git, wt.Branch, "parent:"+defaultBranch, wt.Path); err != nil {
-					m.output.Warn(fmt.Sprintf("Failed to update branch description: %v", err))
+// syncConcurrency bounds how many independent branches Sync rebases at once.
+const syncConcurrency = 4
+
+// syncLevels groups worktrees, already in dependency order, into levels: a
+// worktree whose parent (per parents, keyed by branch) is in the set lands
+// one level below it, all others in level 0. Worktrees whose parent has not been placed yet
+// (a cycle) each get a level of their own after the rest, so they stay
+// serial.
+func syncLevels(ordered []Worktree, parents map[string]string) [][]Worktree {
+	inSet := make(map[string]bool, len(ordered))
+	for _, wt := range ordered {
+		inSet[wt.Branch] = true
+	}
+	levelOf := make(map[string]int, len(ordered))
+	var levels [][]Worktree
+	var unplaced []Worktree
+	for _, wt := range ordered {
+		level := 0
+		if !wt.IsDetached {
+			if parent := parents[wt.Branch]; parent != "" && inSet[parent] {
+				parentLevel, placed := levelOf[parent]
+				if !placed {
+					unplaced = append(unplaced, wt)
+					continue
 				}
-			} else {
-				// Parent not merged: rebase onto remote parent branch
-				rebaseTarget = "origin/" + parentBranch
+				level = parentLevel + 1
 			}
 		}
+		levelOf[wt.Branch] = level
+		for len(levels) <= level {
+			levels = append(levels, nil)
+		}
+		levels[level] = append(levels[level], wt)
+	}
+	for _, wt := range unplaced {
+		levels = append(levels, []Worktree{wt})
+	}
+	return levels
+}
+
+// branchSync is the outcome of syncing one worktree. A zero value means the
+// branch was rebased.
+type branchSync struct {
+	conflict *RebaseConflictError
+	skipped  string
+	// failed marks a branch its children must not be rebased onto.
+	failed bool
+}
+
+// syncWorktree rebases one worktree onto parentBranch (or the default branch
+// when it has none or it has merged), logging to out. failedBranches holds branches
+// that failed in earlier levels and is only read.
+func (m *Manager) syncWorktree(ctx context.Context, wt Worktree, parentBranch string, out *Output, defaultBranch, ghDir string, failedBranches map[string]bool) branchSync {
+	if wt.IsDetached {
+		out.Info(fmt.Sprintf("Skipping detached worktree %s", wt.Name()))
+		return branchSync{skipped: "detached HEAD"}
+	}
 
-		m.output.Info(fmt.Sprintf("Rebasing %s onto %s...", wt.Branch, rebaseTarget))
-		if _, err := m.git.Run(ctx, []string{"rebase", "--autostash", rebaseTarget}, wt.Path); err != nil {
-			m.output.Error(fmt.Sprintf("Failed to rebase %s - resolve conflicts manually:\n  cd %s\n  git rebase --continue  # after fixing conflicts\n  git rebase --abort      # to cancel",
-				wt.Branch, wt.Path))
-			failedBranches[wt.Branch] = true
+	// Check if any ancestor failed
+	if parentBranch != "" && failedBranches[parentBranch] {
+		out.Warn(fmt.Sprintf("Skipping %s - ancestor branch %s failed to rebase", wt.Branch, parentBranch))
+		return branchSync{skipped: fmt.Sprintf("ancestor branch %s failed to rebase", parentBranch), failed: true}
+	}
+
+	// A branch made with --track follows its own remote branch; rebasing
+	// it onto the default branch would pull in unrelated history.
+	if parentBranch == "" {
+		if track := m.trackedBranch(ctx, wt.Branch, wt.Path); track != "" {
+			out.Info(fmt.Sprintf("Skipping %s - tracks %s", wt.Branch, track))
+			return branchSync{skipped: "tracks " + track}
+		}
+	}
+
+	// Determine rebase target based on parent branch
+	var rebaseTarget string
+
+	if parentBranch == "" || parentBranch == defaultBranch {
+		// No parent or parent is default branch: rebase onto default branch
+		rebaseTarget = "origin/" + defaultBranch
+	} else {
+		// Cascading branch: check if parent was merged
+		if m.isParentBranchMerged(ctx, parentBranch, ghDir) {
+			out.Info(fmt.Sprintf("Parent branch %s was merged, rebasing %s onto %s...",
+				parentBranch, wt.Branch, defaultBranch))
+			rebaseTarget = "origin/" + defaultBranch
+
+			// Update PR base branch if PR exists
+			prInfo, err := GetPRByBranch(ctx, m.gh, wt.Branch, ghDir)
+			if err == nil && prInfo != nil && prInfo.Number > 0 {
+				out.Info(fmt.Sprintf("Updating PR #%d base to %s...", prInfo.Number, defaultBranch))
+				if err := UpdatePRBase(ctx, m.gh, prInfo.Number, defaultBranch, ghDir); err != nil {
+					out.Warn(fmt.Sprintf("Failed to update PR base: %v", err))
+				}
+			}
+
+			// Update branch description
+			if err := SetBranchDescription(ctx, m.git, wt.Branch, "parent:"+defaultBranch, wt.Path); err != nil {
+				out.Warn(fmt.Sprintf("Failed to update branch description: %v", err))
+			}
 		} else {
-			m.output.Success(fmt.Sprintf("Rebased %s", wt.Branch))
+			// Parent not merged: rebase onto remote parent branch
+			rebaseTarget = "origin/" + parentBranch
 		}
 	}
 
-	return nil
+	out.Info(fmt.Sprintf("Rebasing %s onto %s...", wt.Branch, rebaseTarget))
+	if _, err := m.git.Run(ctx, []string{"rebase", "--autostash", rebaseTarget}, wt.Path); err != nil {
+		out.Error(fmt.Sprintf("Failed to rebase %s - resolve conflicts manually:\n  cd %s\n  git rebase --continue  # after fixing conflicts\n  git rebase --abort      # to cancel",
+			wt.Branch, wt.Path))
+		return branchSync{
+			conflict: &RebaseConflictError{Err: err, Branch: wt.Branch, Path: wt.Path, Onto: rebaseTarget},
+			failed:   true,
+		}
+	}
+	out.Success(fmt.Sprintf("Rebased %s", wt.Branch))
+	return branchSync{}
+}
+
+// ghDir picks a directory to run gh commands from: the first attached
+// worktree, falling back to the bare repo.
+func (m *Manager) ghDir(worktrees []Worktree) string {
+	for _, wt := range worktrees {
+		if !wt.IsDetached {
+			return wt.Path
+		}
+	}
+	return m.BareDir()
+}
+
+// BaseDriftWarning describes an open PR whose base branch no longer matches
+// where its branch is stacked.
+type BaseDriftWarning struct {
+	Branch string
+	// CurrentBase is the PR's base branch on GitHub.
+	CurrentBase string
+	// ExpectedBase is the recorded parent, or the default branch once that
+	// parent has merged.
+	ExpectedBase string
+	// Parent is the branch's recorded parent (the "parent:" description).
+	Parent       string
+	PRNumber     int
+	ParentMerged bool
+}
+
+// String renders the warning, e.g.
+// "PR #42 (feature-b) base should be main (parent feature-a merged)".
+func (w BaseDriftWarning) String() string {
+	reason := "recorded parent"
+	if w.ParentMerged {
+		reason = fmt.Sprintf("parent %s merged", w.Parent)
+	}
+	return fmt.Sprintf("PR #%d (%s) base should be %s, not %s (%s)",
+		w.PRNumber, w.Branch, w.ExpectedBase, w.CurrentBase, reason)
+}
+
+// CheckPRBaseDrift compares each open PR's base branch against the branch's
+// recorded parent. A stacked PR should target its parent until the parent
+// merges, and the default branch afterwards; anything else is returned as a
+// warning, sorted by branch. Branches without a recorded parent are skipped.
+// Use UpdatePRBase (or Sync with FixPRBase) to correct them.
+func (m *Manager) CheckPRBaseDrift(ctx context.Context) ([]BaseDriftWarning, error) {
+	bareDir := m.BareDir()
+	if _, err := os.Stat(bareDir); os.IsNotExist(err) {
+		return nil, ErrRepoNotInitialized
+	}
+	worktrees, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ghDir := m.ghDir(worktrees)
+	openPRs, err := ListOpenPRs(ctx, m.gh, ghDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open PRs: %w", err)
+	}
+	if len(openPRs) == 0 {
+		return nil, nil
+	}
+	prs := prsByHeadRef(openPRs)
+	defaultBranch, _ := GetDefaultBranch(ctx, m.git, bareDir)
+
+	merged := make(map[string]bool)
+	var warnings []BaseDriftWarning
+	for _, wt := range worktrees {
+		if wt.IsDetached || wt.IsGone {
+			continue
+		}
+		pr := prs[wt.Branch]
+		if pr == nil || pr.BaseRefName == "" {
+			continue
+		}
+		parent, _ := m.GetParentBranch(ctx, wt.Branch, wt.Path)
+		if parent == "" {
+			continue
+		}
+		expected := parent
+		parentMerged := false
+		if parent != defaultBranch && defaultBranch != "" {
+			// A parent with an open PR of its own is by definition unmerged.
+			if prs[parent] == nil {
+				isMerged, seen := merged[parent]
+				if !seen {
+					isMerged = m.isParentBranchMerged(ctx, parent, ghDir)
+					merged[parent] = isMerged
+				}
+				if isMerged {
+					expected = defaultBranch
+					parentMerged = true
+				}
+			}
+		}
+		if pr.BaseRefName == expected {
+			continue
+		}
+		warnings = append(warnings, BaseDriftWarning{
+			Branch:       wt.Branch,
+			CurrentBase:  pr.BaseRefName,
+			ExpectedBase: expected,
+			Parent:       parent,
+			PRNumber:     pr.Number,
+			ParentMerged: parentMerged,
+		})
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Branch < warnings[j].Branch })
+	return warnings, nil
+}
+
+// reportPRBaseDrift warns about drifted PR bases after a sync, limited to
+// branch when non-empty, and retargets them when fix is set.
+func (m *Manager) reportPRBaseDrift(ctx context.Context, branch string, fix bool) {
+	warnings, err := m.CheckPRBaseDrift(ctx)
+	if err != nil {
+		m.output.Warn(fmt.Sprintf("Could not check PR bases: %v", err))
+		return
+	}
+	worktrees, _ := m.List(ctx)
+	ghDir := m.ghDir(worktrees)
+	for _, w := range warnings {
+		if branch != "" && w.Branch != branch {
+			continue
+		}
+		if !fix {
+			m.output.Warn(w.String() + " (run `wt sync --fix-pr-base` to retarget)")
+			continue
+		}
+		m.output.Info(fmt.Sprintf("Updating PR #%d base to %s...", w.PRNumber, w.ExpectedBase))
+		if err := UpdatePRBase(ctx, m.gh, w.PRNumber, w.ExpectedBase, ghDir); err != nil {
+			m.output.Warn(fmt.Sprintf("Failed to update PR #%d base: %v", w.PRNumber, err))
+			continue
+		}
+		m.output.Success(fmt.Sprintf("PR #%d now targets %s", w.PRNumber, w.ExpectedBase))
+	}
 }
 
 // isParentBranchMerged checks if a parent branch has been merged to default.
@@ -1268,8 +2167,67 @@ func (m *Manager) buildDependencyOrder(ctx context.Context, worktrees []Worktree
 
 // MergeOptions configures the merge operation.
 type MergeOptions struct {
-	MergeMethod string
-	Keep        bool
+	// DeleteRemoteBranch controls deleting the PR's head branch on GitHub
+	// after the merge. nil leaves it to the repository's "Automatically
+	// delete head branches" setting, true passes gh pr merge
+	// --delete-branch. false does not ask for deletion, but gh cannot
+	// override that setting, so wt only warns when the repository will
+	// delete the branch anyway. Child branches are found before the merge
+	// either way.
+	DeleteRemoteBranch *bool
+	MergeMethod        string
+	Keep               bool
+	// Auto arms GitHub auto-merge (gh pr merge --auto) instead of merging
+	// when the PR's checks are still pending. The merge then lands later on
+	// GitHub, so child-branch cascade handling and worktree cleanup are
+	// skipped; run `wt sync` after it lands. With checks already finished the
+	// PR is merged synchronously as usual.
+	Auto bool
+}
+
+// MergeResult describes the outcome of a PR merge, including what the
+// cascade did to branches stacked on the merged one.
+type MergeResult struct {
+	// PRURL is the merged (or auto-merge armed) PR's URL.
+	PRURL string
+	// UpdatedBases maps each child branch whose PR was retargeted to its
+	// new base branch.
+	UpdatedBases map[string]string
+	// RebasedBranches lists the child branches rebased and force-pushed
+	// onto the new base, in the order they were handled.
+	RebasedBranches []string
+	// Errors holds one error per child branch the cascade could not handle.
+	// They do not fail the merge itself, which has already happened.
+	Errors   []error
+	PRNumber int
+	// AutoMergeArmed is true when the PR was not merged yet but GitHub
+	// auto-merge was enabled (see MergeOptions.Auto).
+	AutoMergeArmed bool
+}
+
+// CascadeSummary describes what the cascade did to child branches, e.g.
+// "rebased feature-b, feature-c; updated feature-b base to main". It is
+// empty when there were no child branches.
+func (r *MergeResult) CascadeSummary() string {
+	if r == nil {
+		return ""
+	}
+	var parts []string
+	if len(r.RebasedBranches) > 0 {
+		parts = append(parts, "rebased "+strings.Join(r.RebasedBranches, ", "))
+	}
+	branches := make([]string, 0, len(r.UpdatedBases))
+	for branch := range r.UpdatedBases {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+	for _, branch := range branches {
+		parts = append(parts, fmt.Sprintf("updated %s base to %s", branch, r.UpdatedBases[branch]))
+	}
+	if len(r.Errors) > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", len(r.Errors)))
+	}
+	return strings.Join(parts, "; ")
 }
 
 // BranchDependency represents a branch that depends on another.
@@ -1282,26 +2240,30 @@ type BranchDependency struct {
 }
 
 // MergePR merges the PR for the current worktree and handles cleanup.
-func (m *Manager) MergePR(ctx context.Context, opts MergeOptions) error {
+func (m *Manager) MergePR(ctx context.Context, opts MergeOptions) (*MergeResult, error) {
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
 	}
 
 	// Get current branch
 	result, err := m.git.Run(ctx, []string{"branch", "--show-current"}, cwd)
 	if err != nil {
-		return fmt.Errorf("failed to get current branch: %w", err)
+		return nil, fmt.Errorf("failed to get current branch: %w", err)
 	}
 	currentBranch := strings.TrimSpace(result.Stdout)
 	if currentBranch == "" {
-		return fmt.Errorf("not on a branch (detached HEAD?)")
+		return nil, fmt.Errorf("not on a branch (detached HEAD?)")
 	}
 
-	prNumber, err := m.mergePR(ctx, currentBranch, cwd, opts)
+	res, err := m.mergePR(ctx, currentBranch, cwd, opts)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if res.AutoMergeArmed {
+		// Nothing has merged yet; the worktree stays until it does.
+		return res, nil
 	}
 
 	bareDir := m.BareDir()
@@ -1310,38 +2272,40 @@ func (m *Manager) MergePR(ctx context.Context, opts MergeOptions) error {
 	// Cleanup unless --keep
 	if !opts.Keep {
 		// Navigate away from current worktree before removing it
-		m.output.Info("Navigating to default branch worktree...")
-		fmt.Printf("__WT_CD__:%s\n", filepath.Join(m.RepoDir(), defaultBranch))
+		if !m.dryRun {
+			m.output.Info("Navigating to default branch worktree...")
+			fmt.Printf("__WT_CD__:%s\n", filepath.Join(m.RepoDir(), defaultBranch))
+		}
 
-		if err := m.Remove(ctx, currentBranch, true, false); err != nil {
+		if err := m.remove(ctx, currentBranch, &PRInfo{Number: res.PRNumber, URL: res.PRURL}, true, false); err != nil {
 			m.output.Warn(fmt.Sprintf("Failed to cleanup worktree: %v", err))
 		}
 	}
 
-	_ = prNumber
-	return nil
+	return res, nil
 }
 
 // MergePRForBranch merges the PR for the given branch. Unlike MergePR, it does
 // not rely on os.Getwd() and always keeps the worktree (caller handles cleanup).
-func (m *Manager) MergePRForBranch(ctx context.Context, branch string, opts MergeOptions) (int, error) {
+func (m *Manager) MergePRForBranch(ctx context.Context, branch string, opts MergeOptions) (*MergeResult, error) {
 	return m.mergePR(ctx, branch, m.BareDir(), opts)
 }
 
 // mergePR is the shared implementation for MergePR and MergePRForBranch.
-// It looks up the PR, merges it, fetches, and handles child branches.
-func (m *Manager) mergePR(ctx context.Context, branch, dir string, opts MergeOptions) (int, error) {
+// It looks up the PR, merges it (or arms auto-merge), fetches, and handles
+// child branches. Cascade handling only runs for synchronous merges.
+func (m *Manager) mergePR(ctx context.Context, branch, dir string, opts MergeOptions) (*MergeResult, error) {
 	bareDir := m.BareDir()
 	defaultBranch, _ := GetDefaultBranch(ctx, m.git, bareDir)
 
 	if branch == defaultBranch {
-		return 0, fmt.Errorf("cannot merge the default branch (%s)", defaultBranch)
+		return nil, fmt.Errorf("cannot merge the default branch (%s)", defaultBranch)
 	}
 
 	// Get PR info for the branch
 	prInfo, err := GetPRByBranch(ctx, m.gh, branch, dir)
 	if err != nil {
-		return 0, fmt.Errorf("no PR found for branch %s: %w", branch, err)
+		return nil, fmt.Errorf("no PR found for branch %s: %w", branch, err)
 	}
 
 	if prInfo.ReviewDecision != "" && prInfo.ReviewDecision != "APPROVED" {
@@ -1350,14 +2314,15 @@ func (m *Manager) mergePR(ctx context.Context, branch, dir string, opts MergeOpt
 
 	m.output.Info(fmt.Sprintf("Merging PR #%d for branch %s...", prInfo.Number, branch))
 
-	// Find child branches BEFORE merging
-	childDeps, err := m.findChildBranches(ctx, branch, dir)
-	if err != nil {
-		m.output.Warn(fmt.Sprintf("Failed to find child branches: %v", err))
-	}
-
 	// Merge the PR
-	mergeArgs := []string{"pr", "merge", strconv.Itoa(prInfo.Number), "--delete-branch"}
+	mergeArgs := []string{"pr", "merge", strconv.Itoa(prInfo.Number)}
+	if opts.DeleteRemoteBranch != nil {
+		if *opts.DeleteRemoteBranch {
+			mergeArgs = append(mergeArgs, "--delete-branch")
+		} else if deletes, err := RepoDeletesBranchOnMerge(ctx, m.gh, dir); err == nil && deletes {
+			m.output.Warn(fmt.Sprintf("The repository deletes head branches on merge; GitHub will still delete %s on the remote", branch))
+		}
+	}
 	switch opts.MergeMethod {
 	case "squash":
 		mergeArgs = append(mergeArgs, "--squash")
@@ -1367,21 +2332,51 @@ func (m *Manager) mergePR(ctx context.Context, branch, dir string, opts MergeOpt
 		mergeArgs = append(mergeArgs, "--merge")
 	}
 
+	if opts.Auto {
+		pending, err := PRChecksPending(ctx, m.gh, prInfo.Number, dir)
+		if err != nil {
+			m.output.Warn(fmt.Sprintf("Failed to read checks for PR #%d, merging now: %v", prInfo.Number, err))
+		}
+		if pending {
+			if _, err := m.gh.Run(ctx, append(mergeArgs, "--auto"), dir); err != nil {
+				return nil, fmt.Errorf("failed to enable auto-merge: %w", err)
+			}
+			if m.dryRun {
+				m.output.Info(fmt.Sprintf("Dry run: would enable auto-merge for PR #%d", prInfo.Number))
+			} else {
+				m.output.Success(fmt.Sprintf("Auto-merge enabled for PR #%d; it will merge when checks pass", prInfo.Number))
+			}
+			m.output.Info("Child branches are not rebased for auto-merges; run 'wt sync' after it lands")
+			return &MergeResult{PRNumber: prInfo.Number, PRURL: prInfo.URL, AutoMergeArmed: true}, nil
+		}
+	}
+
+	// Find child branches BEFORE merging
+	childDeps, err := m.findChildBranches(ctx, branch, dir)
+	if err != nil {
+		m.output.Warn(fmt.Sprintf("Failed to find child branches: %v", err))
+	}
+
 	if _, err := m.gh.Run(ctx, mergeArgs, dir); err != nil {
-		return 0, fmt.Errorf("failed to merge PR: %w", err)
+		return nil, fmt.Errorf("failed to merge PR: %w", err)
+	}
+	if m.dryRun {
+		m.output.Info(fmt.Sprintf("Dry run: would merge PR #%d", prInfo.Number))
+	} else {
+		m.output.Success(fmt.Sprintf("Merged PR #%d", prInfo.Number))
 	}
-	m.output.Success(fmt.Sprintf("Merged PR #%d", prInfo.Number))
 
 	// Fetch to get updated remote state
 	m.git.Run(ctx, []string{"fetch", "--prune"}, bareDir)
 
 	// Handle child branches
+	res := &MergeResult{PRNumber: prInfo.Number, PRURL: prInfo.URL}
 	if len(childDeps) > 0 {
 		m.output.Info(fmt.Sprintf("Found %d child branches depending on %s", len(childDeps), branch))
-		m.handleChildBranches(ctx, childDeps, defaultBranch)
+		m.handleChildBranches(ctx, childDeps, defaultBranch, res)
 	}
 
-	return prInfo.Number, nil
+	return res, nil
 }
 
 // findChildBranches finds all branches that have PRs targeting the given branch.
@@ -1422,14 +2417,16 @@ func (m *Manager) findChildBranches(ctx context.Context, parentBranch, dir strin
 	return children, nil
 }
 
-// handleChildBranches rebases child branches onto the new base and updates their PRs.
-func (m *Manager) handleChildBranches(ctx context.Context, children []BranchDependency, newBase string) {
+// handleChildBranches rebases child branches onto the new base and updates
+// their PRs, recording what it did in res.
+func (m *Manager) handleChildBranches(ctx context.Context, children []BranchDependency, newBase string, res *MergeResult) {
 	failedBranches := make(map[string]bool)
 
 	for _, child := range children {
 		// Check if ancestor failed
 		if failedBranches[child.BaseBranch] {
 			m.output.Warn(fmt.Sprintf("Skipping %s - ancestor branch failed to rebase", child.Branch))
+			res.Errors = append(res.Errors, fmt.Errorf("%s: skipped because ancestor %s failed", child.Branch, child.BaseBranch))
 			failedBranches[child.Branch] = true
 			continue
 		}
@@ -1443,13 +2440,16 @@ func (m *Manager) handleChildBranches(ctx context.Context, children []BranchDepe
 			if _, err := m.git.Run(ctx, []string{"rebase", "origin/" + newBase}, child.WorktreePath); err != nil {
 				m.output.Error(fmt.Sprintf("Failed to rebase %s - resolve conflicts manually:\n  cd %s\n  git rebase --continue\n  git rebase --abort",
 					child.Branch, child.WorktreePath))
+				res.Errors = append(res.Errors, fmt.Errorf("%s: rebase onto %s failed: %w", child.Branch, newBase, err))
 				failedBranches[child.Branch] = true
 				continue
 			}
 
 			// Force push
 			if result, err := m.git.Run(ctx, []string{"push", "--force-with-lease"}, child.WorktreePath); err != nil {
-				m.output.Error(fmt.Sprintf("Failed to push %s: %v", child.Branch, wrapAuthError(err, result)))
+				err = wrapAuthError(err, result)
+				m.output.Error(fmt.Sprintf("Failed to push %s: %v", child.Branch, err))
+				res.Errors = append(res.Errors, fmt.Errorf("%s: push failed: %w", child.Branch, err))
 				failedBranches[child.Branch] = true
 				continue
 			}
@@ -1458,6 +2458,7 @@ func (m *Manager) handleChildBranches(ctx context.Context, children []BranchDepe
 			SetBranchDescription(ctx, m.git, child.Branch, "parent:"+newBase, child.WorktreePath)
 
 			m.output.Success(fmt.Sprintf("Rebased %s onto %s", child.Branch, newBase))
+			res.RebasedBranches = append(res.RebasedBranches, child.Branch)
 		} else {
 			m.output.Warn(fmt.Sprintf("Branch %s has no worktree, updating PR base only (rebase manually later)", child.Branch))
 		}
@@ -1466,8 +2467,13 @@ func (m *Manager) handleChildBranches(ctx context.Context, children []BranchDepe
 		if child.PRNumber > 0 {
 			if err := UpdatePRBase(ctx, m.gh, child.PRNumber, newBase, m.BareDir()); err != nil {
 				m.output.Warn(fmt.Sprintf("Failed to update PR #%d base: %v", child.PRNumber, err))
+				res.Errors = append(res.Errors, fmt.Errorf("%s: updating PR #%d base failed: %w", child.Branch, child.PRNumber, err))
 			} else {
 				m.output.Success(fmt.Sprintf("Updated PR #%d base to %s", child.PRNumber, newBase))
+				if res.UpdatedBases == nil {
+					res.UpdatedBases = make(map[string]string)
+				}
+				res.UpdatedBases[child.Branch] = newBase
 			}
 		}
 	}
@@ -1571,7 +2577,7 @@ func (m *Manager) pruneMergedPRs(ctx context.Context, bareDir string, dryRun boo
 		}
 
 		m.output.Info(fmt.Sprintf("Removing %s (PR #%d merged)...", wt.Branch, pr.Number))
-		if err := m.Remove(ctx, wt.Branch, true, true); err != nil {
+		if err := m.remove(ctx, wt.Branch, pr, true, true); err != nil {
 			m.output.Error(fmt.Sprintf("Failed to remove %s: %v", wt.Branch, err))
 			continue
 		}
@@ -1686,7 +2692,7 @@ func (m *Manager) pruneStaleLocks(ctx context.Context, bareDir string, remove, d
 			// orphaned-branch step / -D to handle. forceLocked=true: the worktree
 			// is locked (that is what makes it stale), so the double --force is
 			// required here.
-			if err := m.removeResolved(ctx, wt.Path, wt.Branch, false, false, true); err != nil {
+			if err := m.removeResolved(ctx, wt.Path, wt.Branch, nil, false, false, true); err != nil {
 				info.KeepReason = fmt.Sprintf("removal failed: %v", err)
 				m.output.Error(fmt.Sprintf("Failed to remove %s: %v", info.Name, err))
 			} else {
@@ -1909,6 +2915,9 @@ func (m *Manager) GetWorktreePath(branch string) (string, error) {
 // If the current branch has no parent config, it falls back to checking the
 // directory name (the original branch the worktree was created for), which
 // handles the case where the user ran `git checkout -b` inside a worktree.
+// A branch created outside wt, with no description at all, gets a parent
+// inferred from the worktree branches' fork points (see inferParentBranch);
+// the guess is not recorded, so read-only callers never write config.
 func (m *Manager) GetParentBranch(ctx context.Context, branch, dir string) (string, error) {
 	desc, err := GetBranchDescription(ctx, m.git, branch, dir)
 	if err == nil {
@@ -1920,14 +2929,31 @@ func (m *Manager) GetParentBranch(ctx context.Context, branch, dir string) (stri
 	// check the original branch's config (worktree may have been checked out to a different branch)
 	dirName := filepath.Base(dir)
 	if dirName != branch {
-		desc, err = GetBranchDescription(ctx, m.git, dirName, dir)
+		dirDesc, err := GetBranchDescription(ctx, m.git, dirName, dir)
 		if err == nil {
-			if parent, ok := strings.CutPrefix(desc, "parent:"); ok {
+			if parent, ok := strings.CutPrefix(dirDesc, "parent:"); ok {
 				return parent, nil
 			}
 		}
 	}
-	return "", nil
+	if desc != "" {
+		// Some other description: leave the branch untracked.
+		return "", nil
+	}
+	return m.inferParentBranch(ctx, branch, dir), nil
+}
+
+// trackedBranch returns the "<remote>/<branch>" a branch created with
+// NewOptions.Track follows, or "" for any other branch.
+func (m *Manager) trackedBranch(ctx context.Context, branch, dir string) string {
+	desc, err := GetBranchDescription(ctx, m.git, branch, dir)
+	if err != nil {
+		return ""
+	}
+	if track, ok := strings.CutPrefix(desc, "track:"); ok {
+		return track
+	}
+	return ""
 }
 
 // SetGoal sets the goal for a branch in a worktree.
@@ -1951,6 +2977,28 @@ func (m *Manager) GetGoal(ctx context.Context, branch, dir string) (string, erro
 	return "", nil
 }
 
+// GetAllGoals returns the goal of every worktree in the repository, keyed by
+// branch name. Worktrees without a goal, detached worktrees, and worktrees
+// whose directory no longer exists are omitted.
+func (m *Manager) GetAllGoals(ctx context.Context) (map[string]string, error) {
+	worktrees, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goals := make(map[string]string)
+	for _, w := range worktrees {
+		if w.Branch == "" || w.IsGone {
+			continue
+		}
+		goal, _ := m.GetGoal(ctx, w.Branch, w.Path)
+		if goal != "" {
+			goals[w.Branch] = goal
+		}
+	}
+	return goals, nil
+}
+
 // PROptions configures PR creation.
 type PROptions struct {
 	Title  string
@@ -2046,6 +3094,43 @@ func (m *Manager) CreatePR(ctx context.Context, opts PROptions) (*PRResult, erro
 	}, nil
 }
 
+// MarkPRReady marks the open PR for branch as ready for review. It returns an
+// error wrapping ErrPRStateUnchanged if the PR is not a draft.
+func (m *Manager) MarkPRReady(ctx context.Context, branch string) error {
+	return m.setPRDraft(ctx, branch, false)
+}
+
+// MarkPRDraft converts the open PR for branch back to a draft. It returns an
+// error wrapping ErrPRStateUnchanged if the PR is already a draft.
+func (m *Manager) MarkPRDraft(ctx context.Context, branch string) error {
+	return m.setPRDraft(ctx, branch, true)
+}
+
+func (m *Manager) setPRDraft(ctx context.Context, branch string, draft bool) error {
+	dir := m.BareDir()
+	prInfo, err := GetPRByBranch(ctx, m.gh, branch, dir)
+	if err != nil {
+		return fmt.Errorf("no PR found for branch %s: %w", branch, err)
+	}
+	if prInfo.State != "" && prInfo.State != "OPEN" {
+		return fmt.Errorf("PR #%d for branch %s is %s", prInfo.Number, branch, strings.ToLower(prInfo.State))
+	}
+
+	target := "ready for review"
+	if draft {
+		target = "a draft"
+	}
+	if prInfo.IsDraft == draft {
+		return fmt.Errorf("PR #%d is already %s: %w", prInfo.Number, target, ErrPRStateUnchanged)
+	}
+
+	if err := SetPRDraft(ctx, m.gh, prInfo.Number, draft, dir); err != nil {
+		return fmt.Errorf("failed to mark PR #%d as %s: %w", prInfo.Number, target, err)
+	}
+	m.output.Success(fmt.Sprintf("PR #%d is now %s", prInfo.Number, target))
+	return nil
+}
+
 // WorktreeInfo contains extended information about a worktree.
 // This combines Worktree data with branch metadata like goals and parent.
 type WorktreeInfo struct {
@@ -2080,6 +3165,54 @@ func (m *Manager) GetWorktreeByBranch(ctx context.Context, branch string) (*Work
 	return nil, ErrWorktreeNotFound
 }
 
+// CurrentWorktree returns the worktree containing cwd, which may be the
+// worktree root or any directory below it. Symlinks are resolved on both
+// sides so e.g. /tmp vs /private/tmp on macOS still match. A detached
+// worktree is returned with IsDetached set; callers that need a branch must
+// check it. Returns ErrWorktreeNotFound when cwd is outside every worktree.
+//
+// It only searches this Manager's repo. Finding the repo from a bare path
+// (Bramble's repo auto-detect, medivac's resolveWTRoot) is a separate walk
+// up to the .bare directory and does not go through here.
+func (m *Manager) CurrentWorktree(ctx context.Context, cwd string) (*Worktree, error) {
+	worktrees, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target := canonicalPath(cwd)
+	var best *Worktree
+	for i := range worktrees {
+		if worktrees[i].IsGone {
+			continue
+		}
+		p := canonicalPath(worktrees[i].Path)
+		if target != p && !strings.HasPrefix(target, p+string(filepath.Separator)) {
+			continue
+		}
+		// Prefer the deepest match in case one worktree is nested in another.
+		if best == nil || len(p) > len(canonicalPath(best.Path)) {
+			best = &worktrees[i]
+		}
+	}
+	if best == nil {
+		return nil, ErrWorktreeNotFound
+	}
+	return best, nil
+}
+
+// canonicalPath returns an absolute, symlink-resolved, cleaned form of p,
+// falling back to the cleaned absolute path when resolution fails.
+func canonicalPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		p = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		p = resolved
+	}
+	return filepath.Clean(p)
+}
+
 // GetWorktreeInfo returns extended information about a worktree.
 func (m *Manager) GetWorktreeInfo(ctx context.Context, branch string) (*WorktreeInfo, error) {
 	wt, err := m.GetWorktreeByBranch(ctx, branch)
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -395,7 +396,8 @@ func (r *concurrentGitRunner) Run(ctx context.Context, args []string, dir string
 
 func TestGetAllGitStatusesLimitsConcurrency(t *testing.T) {
 	runner := &concurrentGitRunner{
-		started: make(chan struct{}, 12),
+		// Each worktree runs git status and git log.
+		started: make(chan struct{}, 24),
 		release: make(chan struct{}),
 	}
 	manager := NewManager(t.TempDir(), "test-repo", WithGitRunner(runner))
@@ -505,11 +507,122 @@ func TestGetStatusReturnsNilOnGitError(t *testing.T) {
 	}
 }
 
-// MockGitRunner implements GitRunner for testing Manager.
+func TestGetStatusTakesChecksFromPRView(t *testing.T) {
+	t.Parallel()
+
+	git := NewMockGitRunner()
+	git.Results["status --porcelain=v2 --branch"] = &CmdResult{Stdout: "# branch.oid abc123\n# branch.head feature\n"}
+	gh := NewMockGHRunner()
+	gh.Results["pr view --json number,url,state,isDraft,reviewDecision,statusCheckRollup"] = &CmdResult{
+		Stdout: `{"number":7,"state":"OPEN","statusCheckRollup":[{"__typename":"CheckRun","status":"IN_PROGRESS"}]}`,
+	}
+	m := NewManager(t.TempDir(), "test-repo", WithGitRunner(git), WithGHRunner(gh))
+
+	status, err := m.GetStatus(context.Background(), Worktree{Path: "/tmp/wt-feature", Branch: "feature"})
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if status.PRNumber != 7 || status.PRChecksState != ChecksPending {
+		t.Errorf("PR #%d checks %q, want #7 %q", status.PRNumber, status.PRChecksState, ChecksPending)
+	}
+	if len(gh.Calls) != 1 {
+		t.Errorf("gh calls = %v, want a single pr view", gh.Calls)
+	}
+}
+
+func TestStatusAllCombinesGitAndPRStatus(t *testing.T) {
+	t.Parallel()
+	_, repoDir := newExternalStackFixture(t)
+	if err := os.WriteFile(filepath.Join(repoDir, "ext-a", "dirty.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gh := NewMockGHRunner()
+	gh.Results["pr list --json number,headRefName,baseRefName,state,isDraft,reviewDecision,url,statusCheckRollup --state open --limit 1000"] = &CmdResult{
+		Stdout: `[{"number": 7, "headRefName": "ext-b", "state": "OPEN", "isDraft": true, "statusCheckRollup": [{"__typename":"CheckRun","status":"IN_PROGRESS"}]}]`,
+	}
+	m := NewManager(filepath.Dir(repoDir), "test-repo", WithGHRunner(gh), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	statuses, err := m.StatusAll(context.Background())
+	if err != nil {
+		t.Fatalf("StatusAll() error = %v", err)
+	}
+	byBranch := make(map[string]WorktreeStatus, len(statuses))
+	for _, s := range statuses {
+		byBranch[s.Worktree.Branch] = s
+	}
+	if len(byBranch) != 4 {
+		t.Fatalf("StatusAll() returned %d statuses, want 4: %+v", len(statuses), statuses)
+	}
+	if !byBranch["ext-a"].IsDirty || byBranch["ext-b"].IsDirty {
+		t.Errorf("dirty: ext-a = %v, ext-b = %v; want true, false", byBranch["ext-a"].IsDirty, byBranch["ext-b"].IsDirty)
+	}
+	if b := byBranch["ext-b"]; b.PRNumber != 7 || !b.PRIsDraft || b.PRChecksState != ChecksPending {
+		t.Errorf("ext-b PR fields = #%d draft=%v checks=%q, want #7 draft=true checks=%q", b.PRNumber, b.PRIsDraft, b.PRChecksState, ChecksPending)
+	}
+	if byBranch["ext-a"].PRNumber != 0 {
+		t.Errorf("ext-a PRNumber = %d, want none", byBranch["ext-a"].PRNumber)
+	}
+	if calls := len(gh.Calls); calls != 1 {
+		t.Errorf("StatusAll made %d gh calls, want 1", calls)
+	}
+}
+
+func TestStatusAllReportsPRFailureWithGitStatuses(t *testing.T) {
+	t.Parallel()
+	_, repoDir := newExternalStackFixture(t)
+	gh := NewMockGHRunner()
+	gh.Err = errors.New("gh: not logged in")
+	m := NewManager(filepath.Dir(repoDir), "test-repo", WithGHRunner(gh), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	statuses, err := m.StatusAll(context.Background())
+	if !errors.Is(err, ErrPRInfoUnavailable) {
+		t.Fatalf("StatusAll() error = %v, want ErrPRInfoUnavailable", err)
+	}
+	if len(statuses) != 4 {
+		t.Errorf("StatusAll() returned %d statuses, want the 4 git statuses", len(statuses))
+	}
+}
+
+func TestStatusAllReusesKnownGitStatuses(t *testing.T) {
+	t.Parallel()
+	_, repoDir := newExternalStackFixture(t)
+	extA := filepath.Join(repoDir, "ext-a")
+	if err := os.WriteFile(filepath.Join(extA, "dirty.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gh := NewMockGHRunner()
+	gh.Results["pr list --json number,headRefName,baseRefName,state,isDraft,reviewDecision,url,statusCheckRollup --state open --limit 1000"] = &CmdResult{
+		Stdout: `[{"number": 5, "headRefName": "ext-a", "state": "OPEN"}]`,
+	}
+	m := NewManager(filepath.Dir(repoDir), "test-repo", WithGHRunner(gh), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	statuses, err := m.StatusAll(context.Background(), StatusAllOptions{
+		Known: map[string]WorktreeStatus{extA: {Ahead: 9, PRNumber: 1}},
+	})
+	if err != nil {
+		t.Fatalf("StatusAll() error = %v", err)
+	}
+	for _, s := range statuses {
+		if s.Worktree.Branch != "ext-a" {
+			continue
+		}
+		// The known status is used as is (git status would see dirty.txt),
+		// with its PR fields refreshed.
+		if s.IsDirty || s.Ahead != 9 || s.PRNumber != 5 || s.Worktree.Path != extA {
+			t.Errorf("ext-a status = %+v, want the known git fields with PR #5", s)
+		}
+		return
+	}
+	t.Fatalf("StatusAll() left out ext-a: %+v", statuses)
+}
+
+// MockGitRunner implements GitRunner for testing Manager. Run is safe for
+// concurrent use (Sync rebases independent branches in parallel).
 type MockGitRunner struct {
 	Results map[string]*CmdResult
 	Errors  map[string]error
 	Calls   [][]string
+	mu      sync.Mutex
 }
 
 func NewMockGitRunner() *MockGitRunner {
@@ -520,6 +633,8 @@ func NewMockGitRunner() *MockGitRunner {
 }
 
 func (m *MockGitRunner) Run(ctx context.Context, args []string, dir string) (*CmdResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.Calls = append(m.Calls, args)
 	key := strings.Join(args, " ")
 	if err, ok := m.Errors[key]; ok {
@@ -601,6 +716,276 @@ func TestManagerNewFetchError(t *testing.T) {
 	}
 }
 
+func TestManagerNewExistingLocalBranch(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	if err := os.MkdirAll(filepath.Join(repoDir, ".bare"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	featurePath := filepath.Join(repoDir, "feature")
+
+	newManager := func() (*Manager, *MockGitRunner) {
+		mockGit := NewMockGitRunner()
+		mockGit.Results["branch --list feature"] = &CmdResult{Stdout: "  feature\n"}
+		return NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(NewMockGHRunner()),
+			WithOutput(NewOutput(&bytes.Buffer{}, false))), mockGit
+	}
+	ctx := context.Background()
+
+	m, mockGit := newManager()
+	if _, err := m.New(ctx, "feature", "", ""); !errors.Is(err, ErrBranchExists) {
+		t.Fatalf("New() without Reuse error = %v, want ErrBranchExists", err)
+	}
+	for _, call := range mockGit.Calls {
+		if call[0] == "worktree" && len(call) > 1 && call[1] == "add" {
+			t.Errorf("New() without Reuse ran %v", call)
+		}
+	}
+
+	m, mockGit = newManager()
+	path, err := m.New(ctx, "feature", "", "", NewOptions{Reuse: true})
+	if err != nil {
+		t.Fatalf("New() with Reuse error = %v", err)
+	}
+	if path != featurePath {
+		t.Errorf("New() path = %q, want %q", path, featurePath)
+	}
+	var added bool
+	for _, call := range mockGit.Calls {
+		switch strings.Join(call, " ") {
+		case "worktree add " + featurePath + " feature":
+			added = true
+		case "fetch origin", "config branch.feature.description parent:main":
+			t.Errorf("New() with Reuse ran %v", call)
+		}
+	}
+	if !added {
+		t.Errorf("New() with Reuse did not check out the existing branch: %v", mockGit.Calls)
+	}
+
+	// An explicit base is recorded as the reused branch's parent.
+	m, mockGit = newManager()
+	if _, err := m.New(ctx, "feature", "develop", "", NewOptions{Reuse: true}); err != nil {
+		t.Fatalf("New() with Reuse and base error = %v", err)
+	}
+	if !hasCall(mockGit.Calls, "config branch.feature.description parent:develop") {
+		t.Errorf("New() with Reuse and base did not record the parent: %v", mockGit.Calls)
+	}
+}
+
+func hasCall(calls [][]string, want string) bool {
+	for _, call := range calls {
+		if strings.Join(call, " ") == want {
+			return true
+		}
+	}
+	return false
+}
+
+func newAdoptMockGit(srcPath, currentBranch string) *MockGitRunner {
+	mockGit := NewMockGitRunner()
+	mockGit.Results["rev-parse --show-toplevel"] = &CmdResult{Stdout: srcPath + "\n"}
+	mockGit.Results["remote get-url origin"] = &CmdResult{Stdout: "git@github.com:user/test-repo.git\n"}
+	mockGit.Results["branch --show-current"] = &CmdResult{Stdout: currentBranch + "\n"}
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	return mockGit
+}
+
+func TestManagerAdopt(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcPath := filepath.Join(tmpDir, "src", "test-repo")
+	root := filepath.Join(tmpDir, "worktrees")
+	repoDir := filepath.Join(root, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+
+	mockGit := newAdoptMockGit(srcPath, "feature")
+	m := NewManager(root, "", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	path, err := m.Adopt(context.Background(), srcPath)
+	if err != nil {
+		t.Fatalf("Adopt() error = %v", err)
+	}
+	if want := filepath.Join(repoDir, "feature"); path != want {
+		t.Errorf("Adopt() path = %q, want %q", path, want)
+	}
+	if m.RepoDir() != repoDir {
+		t.Errorf("RepoDir() = %q, want %q", m.RepoDir(), repoDir)
+	}
+
+	called := make(map[string]bool)
+	for _, call := range mockGit.Calls {
+		called[strings.Join(call, " ")] = true
+	}
+	for _, want := range []string{
+		"clone --bare " + srcPath + " " + bareDir,
+		"remote set-url origin git@github.com:user/test-repo.git",
+		"fetch origin",
+		"remote set-head origin --auto",
+		"worktree add " + filepath.Join(repoDir, "main") + " main",
+		"worktree add " + filepath.Join(repoDir, "feature") + " feature",
+	} {
+		if !called[want] {
+			t.Errorf("expected git %q to be called; calls = %v", want, mockGit.Calls)
+		}
+	}
+}
+
+func TestManagerAdoptOnDefaultBranch(t *testing.T) {
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "worktrees")
+
+	mockGit := newAdoptMockGit(filepath.Join(tmpDir, "src"), "main")
+	m := NewManager(root, "", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	path, err := m.Adopt(context.Background(), filepath.Join(tmpDir, "src"))
+	if err != nil {
+		t.Fatalf("Adopt() error = %v", err)
+	}
+	if want := filepath.Join(root, "test-repo", "main"); path != want {
+		t.Errorf("Adopt() path = %q, want %q", path, want)
+	}
+	worktreeAdds := 0
+	for _, call := range mockGit.Calls {
+		if len(call) >= 2 && call[0] == "worktree" && call[1] == "add" {
+			worktreeAdds++
+		}
+	}
+	if worktreeAdds != 1 {
+		t.Errorf("worktree add called %d times, want 1", worktreeAdds)
+	}
+}
+
+func TestManagerAdoptValidation(t *testing.T) {
+	t.Run("not a git repo", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		mockGit := NewMockGitRunner()
+		mockGit.Errors["rev-parse --show-toplevel"] = errors.New("not a git repository")
+		m := NewManager(tmpDir, "", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+		_, err := m.Adopt(context.Background(), tmpDir)
+		if err == nil || !strings.Contains(err.Error(), "is not a git repository") {
+			t.Fatalf("Adopt() error = %v, want not a git repository", err)
+		}
+	})
+
+	t.Run("no origin remote", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		mockGit := newAdoptMockGit(tmpDir, "main")
+		mockGit.Errors["remote get-url origin"] = errors.New("No such remote 'origin'")
+		m := NewManager(tmpDir, "", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+		_, err := m.Adopt(context.Background(), tmpDir)
+		if err == nil || !strings.Contains(err.Error(), "no origin remote") {
+			t.Fatalf("Adopt() error = %v, want no origin remote", err)
+		}
+	})
+
+	t.Run("repo dir already exists", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repoDir := filepath.Join(tmpDir, "test-repo")
+		if err := os.MkdirAll(repoDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		mockGit := newAdoptMockGit(filepath.Join(tmpDir, "src"), "main")
+		m := NewManager(tmpDir, "", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+		_, err := m.Adopt(context.Background(), filepath.Join(tmpDir, "src"))
+		if err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Fatalf("Adopt() error = %v, want already exists", err)
+		}
+		if _, err := os.Stat(repoDir); err != nil {
+			t.Errorf("existing repo dir must be left alone: %v", err)
+		}
+		for _, call := range mockGit.Calls {
+			if call[0] == "clone" {
+				t.Errorf("clone must not run when repo dir exists")
+			}
+		}
+	})
+}
+
+func TestManagerAdoptCleansUpOnFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+
+	mockGit := newAdoptMockGit(filepath.Join(tmpDir, "src"), "main")
+	mockGit.Errors["fetch origin"] = errors.New("could not read from remote")
+	m := NewManager(tmpDir, "", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	_, err := m.Adopt(context.Background(), filepath.Join(tmpDir, "src"))
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch") {
+		t.Fatalf("Adopt() error = %v, want failed to fetch", err)
+	}
+	if _, err := os.Stat(repoDir); !os.IsNotExist(err) {
+		t.Errorf("repo dir should be removed after a failed adopt, stat err = %v", err)
+	}
+}
+
+func TestManagerCurrentWorktree(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	mainDir := filepath.Join(repoDir, "main")
+	featureDir := filepath.Join(repoDir, "feature")
+	detachedDir := filepath.Join(repoDir, "detached")
+	for _, d := range []string{bareDir, filepath.Join(mainDir, "pkg", "sub"), featureDir, detachedDir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A symlink into the feature worktree must resolve to it.
+	linkDir := filepath.Join(tmpDir, "link")
+	if err := os.Symlink(featureDir, linkDir); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{
+		Stdout: "worktree " + bareDir + "\nbare\n\n" +
+			"worktree " + mainDir + "\nHEAD abc1234567890\nbranch refs/heads/main\n\n" +
+			"worktree " + featureDir + "\nHEAD def5678901234\nbranch refs/heads/feature/x\n\n" +
+			"worktree " + detachedDir + "\nHEAD 0123456789ab\ndetached\n\n",
+	}
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	ctx := context.Background()
+
+	tests := []struct {
+		wantErr    error
+		name       string
+		cwd        string
+		wantBranch string
+	}{
+		{name: "worktree root", cwd: mainDir, wantBranch: "main"},
+		{name: "subdirectory", cwd: filepath.Join(mainDir, "pkg", "sub"), wantBranch: "main"},
+		{name: "slashed branch", cwd: featureDir, wantBranch: "feature/x"},
+		{name: "through symlink", cwd: linkDir, wantBranch: "feature/x"},
+		{name: "detached", cwd: detachedDir, wantBranch: "(detached)"},
+		{name: "sibling prefix", cwd: mainDir + "-other", wantErr: ErrWorktreeNotFound},
+		{name: "repo dir", cwd: repoDir, wantErr: ErrWorktreeNotFound},
+		{name: "outside", cwd: tmpDir, wantErr: ErrWorktreeNotFound},
+	}
+	for _, tt := range tests {
+		got, err := m.CurrentWorktree(ctx, tt.cwd)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: CurrentWorktree() error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: CurrentWorktree() error = %v", tt.name, err)
+			continue
+		}
+		if got.Branch != tt.wantBranch {
+			t.Errorf("%s: Branch = %q, want %q", tt.name, got.Branch, tt.wantBranch)
+		}
+		if got.IsDetached != (tt.name == "detached") {
+			t.Errorf("%s: IsDetached = %v", tt.name, got.IsDetached)
+		}
+	}
+}
+
 func TestManagerList(t *testing.T) {
 	tmpDir := t.TempDir()
 	repoDir := filepath.Join(tmpDir, "test-repo")
@@ -696,6 +1081,85 @@ func TestGetParentBranch(t *testing.T) {
 	}
 }
 
+func TestHookEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	wtPath := filepath.Join(tmpDir, "test-repo", "feature-b")
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
+	mockGit.Errors["config branch.untracked.description"] = os.ErrNotExist
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/trunk\n"}
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	ctx := context.Background()
+
+	env := m.hookEnv(ctx, wtPath, "feature-b", nil)
+	want := HookEnv{Branch: "feature-b", Path: wtPath, Repo: "test-repo", Root: tmpDir, Base: "feature-a"}
+	if env != want {
+		t.Errorf("hookEnv() = %+v, want %+v", env, want)
+	}
+
+	// An untracked branch falls back to the default branch.
+	if env := m.hookEnv(ctx, filepath.Join(tmpDir, "test-repo", "untracked"), "untracked", nil); env.Base != "trunk" {
+		t.Errorf("hookEnv(untracked).Base = %q, want trunk", env.Base)
+	}
+
+	// A PR supplies its number, URL and base.
+	pr := &PRInfo{Number: 7, URL: "https://github.com/o/r/pull/7", BaseRefName: "release"}
+	env = m.hookEnv(ctx, wtPath, "feature-b", pr)
+	if env.PRNumber != 7 || env.PRURL != pr.URL || env.Base != "release" {
+		t.Errorf("hookEnv(pr) = %+v, want PR #7 based on release", env)
+	}
+}
+
+func TestGetAllGoals(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+
+	for _, dir := range []string{bareDir, filepath.Join(repoDir, "main"), filepath.Join(repoDir, "feature-a"), filepath.Join(repoDir, "feature-b")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{
+		Stdout: "worktree " + bareDir + "\nbare\n\n" +
+			"worktree " + filepath.Join(repoDir, "main") + "\nHEAD abc1234567890\nbranch refs/heads/main\n\n" +
+			"worktree " + filepath.Join(repoDir, "feature-a") + "\nHEAD def5678901234\nbranch refs/heads/feature-a\n\n" +
+			"worktree " + filepath.Join(repoDir, "feature-b") + "\nHEAD 0123456789abc\nbranch refs/heads/renamed\n\n" +
+			"worktree " + filepath.Join(repoDir, "gone") + "\nHEAD 0123456789abc\nbranch refs/heads/gone\n\n",
+	}
+	mockGit.Errors["config branch.main.goal"] = os.ErrNotExist
+	mockGit.Results["config branch.feature-a.goal"] = &CmdResult{Stdout: "Add OAuth login\n"}
+	// feature-b's branch was renamed inside the worktree; the goal is still
+	// found under the directory name.
+	mockGit.Errors["config branch.renamed.goal"] = os.ErrNotExist
+	mockGit.Results["config branch.feature-b.goal"] = &CmdResult{Stdout: "Fix flaky test\n"}
+	mockGit.Results["config branch.gone.goal"] = &CmdResult{Stdout: "Should be skipped\n"}
+
+	output := NewOutput(&bytes.Buffer{}, false)
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithOutput(output))
+
+	goals, err := m.GetAllGoals(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllGoals() error = %v", err)
+	}
+	want := map[string]string{
+		"feature-a": "Add OAuth login",
+		"renamed":   "Fix flaky test",
+	}
+	if len(goals) != len(want) {
+		t.Fatalf("GetAllGoals() = %v, want %v", goals, want)
+	}
+	for branch, goal := range want {
+		if goals[branch] != goal {
+			t.Errorf("goals[%q] = %q, want %q", branch, goals[branch], goal)
+		}
+	}
+}
+
 // TestBuildDependencyOrder tests topological sorting of worktrees.
 func TestBuildDependencyOrder(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -803,11 +1267,11 @@ func TestIsParentBranchMerged(t *testing.T) {
 			mockGH := NewMockGHRunner()
 
 			if tt.prMerged {
-				mockGH.Results["pr view feature-a --json number,url,headRefName,baseRefName,state,reviewDecision"] = &CmdResult{
+				mockGH.Results["pr view feature-a --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"] = &CmdResult{
 					Stdout: `{"number":1,"state":"MERGED"}`,
 				}
 			} else {
-				mockGH.Errors["pr view feature-a --json number,url,headRefName,baseRefName,state,reviewDecision"] = os.ErrNotExist
+				mockGH.Errors["pr view feature-a --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"] = os.ErrNotExist
 			}
 
 			if tt.branchExists {
@@ -1235,6 +1699,120 @@ func newMockGHRunnerWithPRError() *MockGHRunner {
 	return gh
 }
 
+// newDriftFixture sets up a repo with stacked worktrees for PR base drift
+// tests:
+//
+//	feature-a: parent main, PR #10 base main            (ok)
+//	feature-b: parent feature-a, PR #11 base main       (drift: parent still open)
+//	feature-c: parent feature-x, PR #12 base feature-x  (drift: parent merged)
+//	feature-d: no recorded parent, PR #13 base develop  (skipped)
+func newDriftFixture(t *testing.T) (*Manager, *MockGitRunner, *MockGHRunner) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	branches := []string{"main", "feature-a", "feature-b", "feature-c", "feature-d"}
+	list := "worktree " + bareDir + "\nbare\n\n"
+	for _, dir := range append([]string{".bare"}, branches...) {
+		if err := os.MkdirAll(filepath.Join(repoDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, b := range branches {
+		list += "worktree " + filepath.Join(repoDir, b) + "\nHEAD abc1234567890\nbranch refs/heads/" + b + "\n\n"
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{Stdout: list}
+	mockGit.Results["config branch.feature-a.description"] = &CmdResult{Stdout: "parent:main\n"}
+	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
+	mockGit.Results["config branch.feature-c.description"] = &CmdResult{Stdout: "parent:feature-x\n"}
+	mockGit.Errors["config branch.feature-d.description"] = errors.New("exit status 1")
+	mockGit.Results["ls-remote --heads origin feature-a"] = &CmdResult{Stdout: "abc\trefs/heads/feature-a\n"}
+
+	mockGH := NewMockGHRunner()
+	mockGH.Results["auth status"] = &CmdResult{Stdout: "Logged in"}
+	mockGH.Results["pr list --json number,headRefName,baseRefName,state,isDraft,reviewDecision,url --state open --limit 1000"] = &CmdResult{
+		Stdout: `[
+			{"number":10,"headRefName":"feature-a","baseRefName":"main","state":"OPEN"},
+			{"number":11,"headRefName":"feature-b","baseRefName":"main","state":"OPEN"},
+			{"number":12,"headRefName":"feature-c","baseRefName":"feature-x","state":"OPEN"},
+			{"number":13,"headRefName":"feature-d","baseRefName":"develop","state":"OPEN"}
+		]`,
+	}
+	mockGH.Results["pr view feature-x --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"] = &CmdResult{
+		Stdout: `{"number":9,"headRefName":"feature-x","baseRefName":"main","state":"MERGED"}`,
+	}
+	mockGH.Result = &CmdResult{Stdout: "{}"}
+
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(mockGH), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	return m, mockGit, mockGH
+}
+
+func TestCheckPRBaseDrift(t *testing.T) {
+	t.Parallel()
+	m, _, _ := newDriftFixture(t)
+
+	warnings, err := m.CheckPRBaseDrift(context.Background())
+	if err != nil {
+		t.Fatalf("CheckPRBaseDrift() error = %v", err)
+	}
+	want := []BaseDriftWarning{
+		{Branch: "feature-b", CurrentBase: "main", ExpectedBase: "feature-a", Parent: "feature-a", PRNumber: 11},
+		{Branch: "feature-c", CurrentBase: "feature-x", ExpectedBase: "main", Parent: "feature-x", PRNumber: 12, ParentMerged: true},
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("CheckPRBaseDrift() = %+v, want %+v", warnings, want)
+	}
+	for i := range want {
+		if warnings[i] != want[i] {
+			t.Errorf("warnings[%d] = %+v, want %+v", i, warnings[i], want[i])
+		}
+	}
+	if got := warnings[1].String(); got != "PR #12 (feature-c) base should be main, not feature-x (parent feature-x merged)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestSyncFixPRBase(t *testing.T) {
+	t.Parallel()
+	m, _, mockGH := newDriftFixture(t)
+
+	if _, err := m.Sync(context.Background(), "feature-b", SyncOptions{FixPRBase: true}); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	var edits []string
+	for _, call := range mockGH.Calls {
+		if len(call) > 1 && call[0] == "pr" && call[1] == "edit" {
+			edits = append(edits, strings.Join(call, " "))
+		}
+	}
+	// Only the synced branch is retargeted.
+	if len(edits) != 1 || edits[0] != "pr edit 11 --base feature-a" {
+		t.Errorf("pr edit calls = %v, want [pr edit 11 --base feature-a]", edits)
+	}
+}
+
+func TestSyncReportsPRBaseDriftWithoutFixing(t *testing.T) {
+	t.Parallel()
+	m, _, mockGH := newDriftFixture(t)
+	var buf bytes.Buffer
+	m.output = NewOutput(&buf, false)
+
+	if _, err := m.Sync(context.Background(), "feature-b"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, call := range mockGH.Calls {
+		if len(call) > 1 && call[0] == "pr" && call[1] == "edit" {
+			t.Errorf("unexpected %v without FixPRBase", call)
+		}
+	}
+	if !strings.Contains(buf.String(), "PR #11 (feature-b) base should be feature-a") {
+		t.Errorf("expected drift warning in output, got:\n%s", buf.String())
+	}
+}
+
 // TestSyncFetchesOnlyDefaultBranch verifies that Sync() without FetchAll fetches
 // only the default branch (not all remotes).
 func TestSyncFetchesOnlyDefaultBranch(t *testing.T) {
@@ -1261,7 +1839,7 @@ func TestSyncFetchesOnlyDefaultBranch(t *testing.T) {
 
 	ctx := context.Background()
 	// Sync may fail later (e.g., no commits to rebase), but what matters here is fetch behavior.
-	_ = m.Sync(ctx, "")
+	_, _ = m.Sync(ctx, "")
 
 	// Verify fetch origin main was called, but NOT fetch --all --prune
 	fetchMainCalled := false
@@ -1306,7 +1884,7 @@ func TestSyncFetchAllFlagUsesWideScope(t *testing.T) {
 	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(output))
 
 	ctx := context.Background()
-	_ = m.Sync(ctx, "", SyncOptions{FetchAll: true})
+	_, _ = m.Sync(ctx, "", SyncOptions{FetchAll: true})
 
 	fetchAllCalled := false
 	for _, call := range mockGit.Calls {
@@ -1346,23 +1924,21 @@ func TestSyncFetchesParentBranchForStackedWorktrees(t *testing.T) {
 	}
 	// feature-b tracks feature-a as parent
 	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
-	mockGit.Results["fetch origin main"] = &CmdResult{}
-	mockGit.Results["fetch origin feature-a"] = &CmdResult{}
 
 	output := NewOutput(&bytes.Buffer{}, false)
 	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(output))
 
 	ctx := context.Background()
-	_ = m.Sync(ctx, "")
+	_, _ = m.Sync(ctx, "")
 
 	fetchFeatureACalled := false
 	for _, call := range mockGit.Calls {
-		if strings.Join(call, " ") == "fetch origin feature-a" {
+		if strings.Join(call, " ") == "fetch origin main feature-a" {
 			fetchFeatureACalled = true
 		}
 	}
 	if !fetchFeatureACalled {
-		t.Error("Expected 'fetch origin feature-a' to be called for stacked parent branch")
+		t.Error("Expected 'fetch origin main feature-a' to be called for stacked parent branch")
 	}
 }
 
@@ -1390,22 +1966,21 @@ func TestSyncParentFetchFailureFatal(t *testing.T) {
 			"worktree " + featureBPath + "\nHEAD cde3456789012\nbranch refs/heads/feature-b\n\n",
 	}
 	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
-	mockGit.Results["fetch origin main"] = &CmdResult{}
-	// fetch for parent branch fails (simulating network/auth error)
-	mockGit.Errors["fetch origin feature-a"] = os.ErrPermission
-	// ls-remote says branch still exists on remote
-	mockGit.Results["ls-remote --heads origin feature-a"] = &CmdResult{Stdout: "abc123 refs/heads/feature-a\n"}
+	// fetch fails (simulating network/auth error)
+	mockGit.Errors["fetch origin main feature-a"] = os.ErrPermission
+	// ls-remote says the parent still exists on remote
+	mockGit.Results["ls-remote --heads origin"] = &CmdResult{Stdout: "abc123\trefs/heads/main\nabc123\trefs/heads/feature-a\n"}
 
 	output := NewOutput(&bytes.Buffer{}, false)
 	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(output))
 
 	ctx := context.Background()
-	err := m.Sync(ctx, "")
+	_, err := m.Sync(ctx, "")
 	if err == nil {
 		t.Fatal("Expected Sync() to return error when parent branch fetch fails and branch still exists on remote")
 	}
-	if !strings.Contains(err.Error(), "failed to fetch parent branch") {
-		t.Errorf("Error = %q, want to contain 'failed to fetch parent branch'", err.Error())
+	if !strings.Contains(err.Error(), "failed to fetch main feature-a") {
+		t.Errorf("Error = %q, want to contain 'failed to fetch main feature-a'", err.Error())
 	}
 }
 
@@ -1433,11 +2008,10 @@ func TestSyncParentFetchFailureNonFatalWhenDeleted(t *testing.T) {
 			"worktree " + featureBPath + "\nHEAD cde3456789012\nbranch refs/heads/feature-b\n\n",
 	}
 	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
-	mockGit.Results["fetch origin main"] = &CmdResult{}
-	// fetch for parent branch fails
-	mockGit.Errors["fetch origin feature-a"] = os.ErrPermission
-	// ls-remote returns empty: branch no longer exists on remote
-	mockGit.Results["ls-remote --heads origin feature-a"] = &CmdResult{Stdout: ""}
+	// fetch fails because the parent is gone
+	mockGit.Errors["fetch origin main feature-a"] = os.ErrPermission
+	// ls-remote no longer lists the parent, so the retry fetches main alone
+	mockGit.Results["ls-remote --heads origin"] = &CmdResult{Stdout: "abc123\trefs/heads/main\n"}
 
 	output := NewOutput(&bytes.Buffer{}, false)
 	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(output))
@@ -1445,10 +2019,20 @@ func TestSyncParentFetchFailureNonFatalWhenDeleted(t *testing.T) {
 	ctx := context.Background()
 	// Sync should not return an error for the fetch; it may fail later for other reasons.
 	// We verify by checking the error does NOT mention the parent fetch.
-	err := m.Sync(ctx, "")
-	if err != nil && strings.Contains(err.Error(), "failed to fetch parent branch") {
+	_, err := m.Sync(ctx, "")
+	if err != nil && strings.Contains(err.Error(), "failed to fetch") {
 		t.Errorf("Sync() should not return parent fetch error when branch is gone from remote, got: %v", err)
 	}
+	var fetches []string
+	for _, call := range mockGit.Calls {
+		if call[0] == "fetch" {
+			fetches = append(fetches, strings.Join(call, " "))
+		}
+	}
+	want := []string{"fetch origin main feature-a", "fetch origin main"}
+	if !slices.Equal(fetches, want) {
+		t.Errorf("fetch calls = %v, want %v", fetches, want)
+	}
 }
 
 func TestManagerNewGitErrorIncludesStderr(t *testing.T) {
@@ -2616,3 +3200,564 @@ func TestPruneStaleLocks(t *testing.T) {
 		}
 	})
 }
+
+func TestMarkPRReadyAndDraft(t *testing.T) {
+	const viewKey = "pr view feature --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"
+
+	tests := []struct {
+		name      string
+		view      string
+		wantCall  string
+		wantErrIs error
+		wantErr   string
+		draft     bool
+		noPR      bool
+	}{
+		{name: "ready from draft", view: `{"number":7,"state":"OPEN","isDraft":true}`, wantCall: "pr ready 7"},
+		{name: "draft from ready", view: `{"number":7,"state":"OPEN","isDraft":false}`, draft: true, wantCall: "pr ready 7 --undo"},
+		{name: "already ready", view: `{"number":7,"state":"OPEN","isDraft":false}`, wantErrIs: ErrPRStateUnchanged},
+		{name: "already draft", view: `{"number":7,"state":"OPEN","isDraft":true}`, draft: true, wantErrIs: ErrPRStateUnchanged},
+		{name: "merged", view: `{"number":7,"state":"MERGED"}`, wantErr: "is merged"},
+		{name: "no PR", noPR: true, wantErr: "no PR found for branch feature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGH := NewMockGHRunner()
+			if tt.noPR {
+				mockGH.Errors[viewKey] = errors.New("no pull requests found")
+			} else {
+				mockGH.Results[viewKey] = &CmdResult{Stdout: tt.view}
+			}
+			mockGH.Results["pr ready 7"] = &CmdResult{}
+			mockGH.Results["pr ready 7 --undo"] = &CmdResult{}
+
+			m := NewManager(t.TempDir(), "test-repo",
+				WithGitRunner(NewMockGitRunner()),
+				WithGHRunner(mockGH),
+				WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+			var err error
+			if tt.draft {
+				err = m.MarkPRDraft(context.Background(), "feature")
+			} else {
+				err = m.MarkPRReady(context.Background(), "feature")
+			}
+
+			var calls []string
+			for _, c := range mockGH.Calls {
+				if c[0] == "pr" && c[1] == "ready" {
+					calls = append(calls, strings.Join(c, " "))
+				}
+			}
+
+			switch {
+			case tt.wantErrIs != nil:
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErrIs)
+				}
+			case tt.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(calls) != 1 || calls[0] != tt.wantCall {
+					t.Fatalf("gh pr ready calls = %v, want [%s]", calls, tt.wantCall)
+				}
+				return
+			}
+			if len(calls) != 0 {
+				t.Errorf("gh pr ready should not run on error, got %v", calls)
+			}
+		})
+	}
+}
+
+func TestMergePRForBranchAuto(t *testing.T) {
+	const (
+		viewKey   = "pr view feature --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"
+		checksKey = "pr view 7 --json statusCheckRollup"
+	)
+
+	tests := []struct {
+		name      string
+		rollup    string
+		wantMerge string
+		wantArmed bool
+	}{
+		{
+			name:      "pending checks arm auto-merge",
+			rollup:    `[{"__typename":"CheckRun","status":"IN_PROGRESS"}]`,
+			wantMerge: "pr merge 7 --squash --auto",
+			wantArmed: true,
+		},
+		{
+			name:      "finished checks merge now",
+			rollup:    `[{"__typename":"CheckRun","status":"COMPLETED","conclusion":"SUCCESS"}]`,
+			wantMerge: "pr merge 7 --squash",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGH := NewMockGHRunner()
+			mockGH.Results[viewKey] = &CmdResult{Stdout: `{"number":7,"state":"OPEN"}`}
+			mockGH.Results[checksKey] = &CmdResult{Stdout: `{"statusCheckRollup":` + tt.rollup + `}`}
+
+			m := NewManager(t.TempDir(), "test-repo",
+				WithGitRunner(NewMockGitRunner()),
+				WithGHRunner(mockGH),
+				WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+			res, err := m.MergePRForBranch(context.Background(), "feature", MergeOptions{
+				MergeMethod: "squash",
+				Keep:        true,
+				Auto:        true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.PRNumber != 7 || res.AutoMergeArmed != tt.wantArmed {
+				t.Errorf("result = %+v, want PR 7 armed=%v", res, tt.wantArmed)
+			}
+
+			var merges []string
+			listedChildren := false
+			for _, c := range mockGH.Calls {
+				joined := strings.Join(c, " ")
+				if strings.HasPrefix(joined, "pr merge") {
+					merges = append(merges, joined)
+				}
+				if strings.HasPrefix(joined, "pr list") {
+					listedChildren = true
+				}
+			}
+			if len(merges) != 1 || merges[0] != tt.wantMerge {
+				t.Errorf("merge calls = %v, want [%s]", merges, tt.wantMerge)
+			}
+			// Cascade handling only runs for synchronous merges.
+			if listedChildren == tt.wantArmed {
+				t.Errorf("child branch lookup ran = %v, want %v", listedChildren, !tt.wantArmed)
+			}
+		})
+	}
+}
+
+func TestMergePRForBranchDeleteRemoteBranch(t *testing.T) {
+	const viewKey = "pr view feature --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"
+	yes, no := true, false
+
+	tests := []struct {
+		deleteRemote *bool
+		name         string
+		repoSetting  string
+		wantMerge    string
+		wantWarn     bool
+	}{
+		{name: "nil leaves it to the repository", wantMerge: "pr merge 7"},
+		{name: "true deletes the remote branch", deleteRemote: &yes, wantMerge: "pr merge 7 --delete-branch"},
+		{name: "false keeps it", deleteRemote: &no, repoSetting: `{"deleteBranchOnMerge":false}`, wantMerge: "pr merge 7"},
+		{name: "false warns when the repository deletes anyway", deleteRemote: &no, repoSetting: `{"deleteBranchOnMerge":true}`, wantMerge: "pr merge 7", wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGH := NewMockGHRunner()
+			mockGH.Results[viewKey] = &CmdResult{Stdout: `{"number":7,"state":"OPEN"}`}
+			if tt.repoSetting != "" {
+				mockGH.Results["repo view --json deleteBranchOnMerge"] = &CmdResult{Stdout: tt.repoSetting}
+			}
+			mockGH.Result = &CmdResult{Stdout: "[]"}
+
+			var out bytes.Buffer
+			m := NewManager(t.TempDir(), "test-repo",
+				WithGitRunner(NewMockGitRunner()),
+				WithGHRunner(mockGH),
+				WithOutput(NewOutput(&out, false)))
+
+			if _, err := m.MergePRForBranch(context.Background(), "feature", MergeOptions{Keep: true, DeleteRemoteBranch: tt.deleteRemote}); err != nil {
+				t.Fatalf("MergePRForBranch() error = %v", err)
+			}
+
+			var merges []string
+			for _, c := range mockGH.Calls {
+				if joined := strings.Join(c, " "); strings.HasPrefix(joined, "pr merge") {
+					merges = append(merges, joined)
+				}
+			}
+			if len(merges) != 1 || merges[0] != tt.wantMerge {
+				t.Errorf("merge calls = %v, want [%s]", merges, tt.wantMerge)
+			}
+			if warned := strings.Contains(out.String(), "will still delete feature"); warned != tt.wantWarn {
+				t.Errorf("warned = %v, want %v; output:\n%s", warned, tt.wantWarn, out.String())
+			}
+		})
+	}
+}
+
+func TestMergePRForBranchCascadeReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	if err := os.MkdirAll(filepath.Join(repoDir, ".bare"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGH := NewMockGHRunner()
+	mockGH.Results["pr view feature --json number,url,headRefName,baseRefName,state,isDraft,reviewDecision"] = &CmdResult{Stdout: `{"number":7,"state":"OPEN"}`}
+	mockGH.Results["pr list --json number,headRefName,baseRefName,state,isDraft,reviewDecision,url --state open --limit "+strconv.Itoa(prListLimit)] = &CmdResult{Stdout: `[
+		{"number":8,"headRefName":"feature-b","baseRefName":"feature"},
+		{"number":9,"headRefName":"feature-c","baseRefName":"feature"},
+		{"number":10,"headRefName":"feature-d","baseRefName":"feature"},
+		{"number":11,"headRefName":"other","baseRefName":"main"}
+	]`}
+	mockGH.Errors["pr edit 10 --base main"] = errors.New("not allowed")
+	mockGH.Result = &CmdResult{}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{Stdout: "worktree " + filepath.Join(repoDir, "feature-b") + "\nHEAD abc\nbranch refs/heads/feature-b\n\n"}
+
+	m := NewManager(tmpDir, "test-repo",
+		WithGitRunner(mockGit),
+		WithGHRunner(mockGH),
+		WithOutput(NewOutput(&bytes.Buffer{}, false)))
+
+	res, err := m.MergePRForBranch(context.Background(), "feature", MergeOptions{Keep: true})
+	if err != nil {
+		t.Fatalf("MergePRForBranch() error = %v", err)
+	}
+	if got := strings.Join(res.RebasedBranches, ","); got != "feature-b" {
+		t.Errorf("RebasedBranches = %q, want feature-b", got)
+	}
+	wantBases := map[string]string{"feature-b": "main", "feature-c": "main"}
+	if len(res.UpdatedBases) != len(wantBases) {
+		t.Errorf("UpdatedBases = %v, want %v", res.UpdatedBases, wantBases)
+	}
+	for branch, base := range wantBases {
+		if res.UpdatedBases[branch] != base {
+			t.Errorf("UpdatedBases[%s] = %q, want %q", branch, res.UpdatedBases[branch], base)
+		}
+	}
+	if len(res.Errors) != 1 || !strings.Contains(res.Errors[0].Error(), "feature-d") {
+		t.Errorf("Errors = %v, want one error for feature-d", res.Errors)
+	}
+
+	want := "rebased feature-b; updated feature-b base to main; updated feature-c base to main; 1 failed"
+	if got := res.CascadeSummary(); got != want {
+		t.Errorf("CascadeSummary() = %q, want %q", got, want)
+	}
+	if got := (&MergeResult{PRNumber: 1}).CascadeSummary(); got != "" {
+		t.Errorf("CascadeSummary() without children = %q, want empty", got)
+	}
+}
+
+func TestManagerNewTrack(t *testing.T) {
+	m, mockGit := newBranchNameFixture(t, "")
+	ctx := context.Background()
+
+	path, err := m.New(ctx, "release-fix", "", "", NewOptions{Track: "upstream/release/2.0"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	var calls []string
+	for _, call := range mockGit.Calls {
+		calls = append(calls, strings.Join(call, " "))
+	}
+	joined := strings.Join(calls, "\n")
+	for _, want := range []string{
+		"fetch upstream +refs/heads/release/2.0:refs/remotes/upstream/release/2.0",
+		"rev-parse --verify --quiet refs/remotes/upstream/release/2.0",
+		"worktree add --track -b release-fix " + path + " upstream/release/2.0",
+		"config branch.release-fix.description track:upstream/release/2.0",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing git call %q, calls:\n%s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "fetch origin") {
+		t.Errorf("tracking a remote branch should not fetch origin, calls:\n%s", joined)
+	}
+	if strings.Contains(joined, "parent:") {
+		t.Errorf("a tracked branch should not record a parent, calls:\n%s", joined)
+	}
+}
+
+func TestManagerNewStartPoint(t *testing.T) {
+	m, mockGit := newBranchNameFixture(t, "")
+	ctx := context.Background()
+
+	path, err := m.New(ctx, "lane", "feature", "", NewOptions{StartPoint: "abc123"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	var calls []string
+	for _, call := range mockGit.Calls {
+		calls = append(calls, strings.Join(call, " "))
+	}
+	joined := strings.Join(calls, "\n")
+	for _, want := range []string{
+		"worktree add -b lane " + path + " abc123",
+		"config branch.lane.description parent:feature",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing git call %q, calls:\n%s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "fetch") {
+		t.Errorf("a start point should not fetch, calls:\n%s", joined)
+	}
+}
+
+func TestManagerNewTrackValidation(t *testing.T) {
+	m, mockGit := newBranchNameFixture(t, "")
+	mockGit.Errors["rev-parse --verify --quiet refs/remotes/upstream/missing"] = errors.New("exit status 1")
+	ctx := context.Background()
+
+	if _, err := m.New(ctx, "x", "", "", NewOptions{Track: "upstream/missing", SkipFetch: true}); !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("missing remote branch error = %v, want ErrBranchNotFound", err)
+	}
+	if _, err := m.New(ctx, "x", "", "", NewOptions{Track: "no-slash", SkipFetch: true}); err == nil || !strings.Contains(err.Error(), "<remote>/<branch>") {
+		t.Errorf("malformed track error = %v", err)
+	}
+	if _, err := m.New(ctx, "x", "develop", "", NewOptions{Track: "upstream/release", SkipFetch: true}); err == nil || !strings.Contains(err.Error(), "cannot track") {
+		t.Errorf("track with base error = %v", err)
+	}
+	if _, err := m.NewAtomic(ctx, "x", "develop", "", NewOptions{Track: "upstream/release", SkipFetch: true}); err == nil || !strings.Contains(err.Error(), "cannot track") {
+		t.Errorf("NewAtomic track with base error = %v", err)
+	}
+	for _, call := range mockGit.Calls {
+		if call[0] == "worktree" && len(call) > 1 && call[1] == "add" {
+			t.Errorf("worktree created despite invalid track target: %v", call)
+		}
+	}
+}
+
+func TestSyncLevels(t *testing.T) {
+	t.Parallel()
+	ordered := []Worktree{
+		{Branch: "main"}, {Branch: "a"}, {Branch: "c"}, {Branch: "b"}, {Branch: "d"},
+		{Branch: "x"}, {Branch: "y"}, {IsDetached: true},
+	}
+	parents := map[string]string{
+		"a": "main", "b": "a", "c": "gone", "d": "b",
+		// x and y form a cycle, so neither parent is placed first.
+		"x": "y", "y": "x",
+	}
+	var got []string
+	for _, level := range syncLevels(ordered, parents) {
+		var names []string
+		for _, wt := range level {
+			name := wt.Branch
+			if wt.IsDetached {
+				name = "detached"
+			}
+			names = append(names, name)
+		}
+		got = append(got, strings.Join(names, ","))
+	}
+	want := []string{"main,c,detached", "a", "b", "d", "x", "y"}
+	if strings.Join(got, " | ") != strings.Join(want, " | ") {
+		t.Errorf("syncLevels() = %q, want %q", got, want)
+	}
+}
+
+// TestSyncSkipsTrackedBranch checks that a branch made with --track is left
+// alone instead of being rebased onto the default branch.
+func TestSyncSkipsTrackedBranch(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	branches := []string{"main", "release-fix"}
+	list := "worktree " + bareDir + "\nbare\n\n"
+	for _, dir := range append([]string{".bare"}, branches...) {
+		if err := os.MkdirAll(filepath.Join(repoDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, b := range branches {
+		list += "worktree " + filepath.Join(repoDir, b) + "\nHEAD abc1234567890\nbranch refs/heads/" + b + "\n\n"
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{Stdout: list}
+	mockGit.Results["config branch.release-fix.description"] = &CmdResult{Stdout: "track:upstream/release\n"}
+
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(NewOutput(&bytes.Buffer{}, false)))
+	report, err := m.Sync(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := report.Skipped["release-fix"]; got != "tracks upstream/release" {
+		t.Errorf("Skipped[release-fix] = %q, want tracks upstream/release", got)
+	}
+	for _, b := range report.Rebased {
+		if b == "release-fix" {
+			t.Errorf("tracked branch was rebased: %v", report.Rebased)
+		}
+	}
+}
+
+// TestSyncParallelKeepsOutputOrdered syncs independent and stacked branches
+// and checks that a failed parent still skips its child and that each
+// branch's log lines come out together, in dependency order.
+func TestSyncParallelKeepsOutputOrdered(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	branches := []string{"main", "feature-a", "feature-b", "feature-c"}
+	list := "worktree " + bareDir + "\nbare\n\n"
+	for _, dir := range append([]string{".bare"}, branches...) {
+		if err := os.MkdirAll(filepath.Join(repoDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, b := range branches {
+		list += "worktree " + filepath.Join(repoDir, b) + "\nHEAD abc1234567890\nbranch refs/heads/" + b + "\n\n"
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{Stdout: list}
+	mockGit.Results["config branch.feature-a.description"] = &CmdResult{Stdout: "parent:main\n"}
+	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
+	mockGit.Results["config branch.feature-c.description"] = &CmdResult{Stdout: "parent:feature-x\n"}
+	mockGit.Results["ls-remote --heads origin feature-x"] = &CmdResult{Stdout: "abc\trefs/heads/feature-x\n"}
+	mockGit.Errors["rebase --autostash origin/main"] = errors.New("conflict")
+
+	var buf bytes.Buffer
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(NewOutput(&buf, false)))
+	report, err := m.Sync(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	// The mocked rebase onto origin/main fails, so main conflicts and its
+	// whole stack is skipped; only feature-c gets through.
+	if got := strings.Join(report.Rebased, ","); got != "feature-c" {
+		t.Errorf("Rebased = %q, want feature-c", got)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Branch != "main" || report.Conflicts[0].Onto != "origin/main" {
+		t.Errorf("Conflicts = %+v, want main onto origin/main", report.Conflicts)
+	}
+	if got := report.Skipped["feature-b"]; got != "ancestor branch feature-a failed to rebase" {
+		t.Errorf("Skipped[feature-b] = %q", got)
+	}
+	if got := report.String(); got != "1 rebased, 1 conflict, 2 skipped" {
+		t.Errorf("String() = %q", got)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Skipping feature-b - ancestor branch feature-a failed to rebase") {
+		t.Errorf("feature-b should be skipped after feature-a failed:\n%s", out)
+	}
+	if !strings.Contains(out, "Rebased feature-c") {
+		t.Errorf("independent feature-c should still be rebased:\n%s", out)
+	}
+	// Each branch's "Rebasing" line is immediately followed by its result.
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, "Rebasing ") {
+			continue
+		}
+		branch := strings.Fields(strings.SplitN(line, "Rebasing ", 2)[1])[0]
+		if i+1 >= len(lines) || !strings.Contains(lines[i+1], branch) {
+			t.Errorf("output for %s is interleaved:\n%s", branch, out)
+		}
+	}
+	if strings.Index(out, "Failed to rebase feature-a") > strings.Index(out, "Skipping feature-b") {
+		t.Errorf("feature-a's result should precede feature-b's skip:\n%s", out)
+	}
+}
+
+// TestSyncFetchesWorktreeBranchesInOneCall verifies that Sync() refreshes the
+// remote-tracking branches of pushed worktrees in the same fetch as the
+// default branch and parents, skipping unpushed branches, without ls-remote.
+func TestSyncFetchesWorktreeBranchesInOneCall(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	if err := os.MkdirAll(bareDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	porcelain := "worktree " + bareDir + "\nbare\n\n"
+	for _, b := range []string{"main", "feature-a", "feature-b", "local-only"} {
+		path := filepath.Join(repoDir, b)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		porcelain += "worktree " + path + "\nHEAD abc1234567890\nbranch refs/heads/" + b + "\n\n"
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{Stdout: porcelain}
+	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
+	mockGit.Results["for-each-ref --format=%(refname:short) refs/remotes/origin"] = &CmdResult{
+		Stdout: "origin/HEAD\norigin/main\norigin/feature-a\norigin/feature-b\norigin/unrelated\n",
+	}
+
+	output := NewOutput(&bytes.Buffer{}, false)
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(output))
+	_, _ = m.Sync(context.Background(), "")
+
+	var fetches []string
+	for _, call := range mockGit.Calls {
+		switch call[0] {
+		case "fetch":
+			fetches = append(fetches, strings.Join(call, " "))
+		case "ls-remote":
+			if len(call) == 3 {
+				t.Errorf("unexpected ls-remote of every branch: %v", call)
+			}
+		}
+	}
+	want := []string{"fetch origin main feature-a feature-b"}
+	if !slices.Equal(fetches, want) {
+		t.Errorf("fetch calls = %v, want %v", fetches, want)
+	}
+}
+
+// TestSyncFetchRefspecOverride verifies that Sync() with FetchRefspec fetches
+// those refspecs plus the stack parents in one call.
+func TestSyncFetchRefspecOverride(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "test-repo")
+	bareDir := filepath.Join(repoDir, ".bare")
+	mainPath := filepath.Join(repoDir, "main")
+	featureBPath := filepath.Join(repoDir, "feature-b")
+	for _, dir := range []string{bareDir, mainPath, featureBPath} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mockGit := NewMockGitRunner()
+	mockGit.Results["symbolic-ref refs/remotes/origin/HEAD"] = &CmdResult{Stdout: "refs/remotes/origin/main\n"}
+	mockGit.Results["worktree list --porcelain"] = &CmdResult{
+		Stdout: "worktree " + bareDir + "\nbare\n\nworktree " + mainPath + "\nHEAD abc1234567890\nbranch refs/heads/main\n\n" +
+			"worktree " + featureBPath + "\nHEAD cde3456789012\nbranch refs/heads/feature-b\n\n",
+	}
+	mockGit.Results["config branch.feature-b.description"] = &CmdResult{Stdout: "parent:feature-a\n"}
+
+	output := NewOutput(&bytes.Buffer{}, false)
+	m := NewManager(tmpDir, "test-repo", WithGitRunner(mockGit), WithGHRunner(newMockGHRunnerWithPRError()), WithOutput(output))
+	_, _ = m.Sync(context.Background(), "", SyncOptions{FetchRefspec: "main release-*:refs/remotes/origin/release-*"})
+
+	var fetches []string
+	for _, call := range mockGit.Calls {
+		if call[0] == "fetch" {
+			fetches = append(fetches, strings.Join(call, " "))
+		}
+	}
+	want := []string{"fetch origin main release-*:refs/remotes/origin/release-* feature-a"}
+	if !slices.Equal(fetches, want) {
+		t.Errorf("fetch calls = %v, want %v", fetches, want)
+	}
+}
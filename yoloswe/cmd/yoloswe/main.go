@@ -70,7 +70,8 @@ The AI will explore the codebase, consider approaches, and produce a detailed pl
 		Example: `  yoloswe plan "Create a hello world Go program"
   yoloswe plan --model opus "Implement a REST API"
   echo "Add tests" | yoloswe plan
-  yoloswe plan --build new --external-builder ./yoloswe "Add comprehensive tests"`,
+  yoloswe plan --build new --external-builder ./yoloswe "Add comprehensive tests"
+  yoloswe plan --build handoff --build-model sonnet "Refactor the config loader"`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPlan(cmd, args, flags)
@@ -82,8 +83,8 @@ The AI will explore the codebase, consider approaches, and produce a detailed pl
 	cmd.Flags().StringVar(&flags.recordDir, "record", "", "Directory for session recordings (defaults to ~/.yoloswe)")
 	cmd.Flags().StringVar(&flags.systemPrompt, "system", "", "Custom system prompt")
 	cmd.Flags().BoolVar(&flags.simple, "simple", false, "Auto-answer questions with first option and export plan on completion")
-	cmd.Flags().StringVar(&flags.build, "build", "", "After planning, execute: 'current' (same session) or 'new' (fresh session)")
-	cmd.Flags().StringVar(&flags.externalBuilder, "external-builder", "", "Path to external builder executable (e.g., yoloswe build). Used with --build new.")
+	cmd.Flags().StringVar(&flags.build, "build", "", "After planning, execute: 'current' (same session), 'new' (fresh session) or 'handoff' (new session resuming the planner's context)")
+	cmd.Flags().StringVar(&flags.externalBuilder, "external-builder", "", "Path to external builder executable (e.g., yoloswe build). Used with --build new or handoff.")
 	cmd.Flags().StringVar(&flags.buildModel, "build-model", "sonnet", "Model to use for build phase (defaults to sonnet)")
 
 	return cmd
@@ -108,7 +109,7 @@ func runPlan(cmd *cobra.Command, args []string, flags *planFlags) error {
 
 	buildMode := planner.BuildMode(flags.build)
 	if !buildMode.IsValid() {
-		return fmt.Errorf("invalid build mode %q (valid: 'current', 'new', or empty)", flags.build)
+		return fmt.Errorf("invalid build mode %q (valid: 'current', 'new', 'handoff', or empty)", flags.build)
 	}
 
 	config := planner.Config{
@@ -156,9 +157,13 @@ type buildFlags struct {
 	resumeSession   string
 	budget          float64
 	timeout         int
+	turnTimeout     int
 	maxIterations   int
+	spiralThreshold int
 	requireApproval bool
 	reviewFirst     bool
+	haltOnSpiral    bool
+	autoFollowup    bool
 }
 
 func newBuildCmd() *cobra.Command {
@@ -169,7 +174,24 @@ func newBuildCmd() *cobra.Command {
 		Short: "Run a builder-reviewer loop for software engineering tasks",
 		Long: `Build runs a builder-reviewer loop for software engineering tasks.
 The builder (Claude) implements the task, and the reviewer (Codex) reviews.
-The loop continues until the reviewer accepts or limits are reached.`,
+The loop continues until the reviewer accepts or limits are reached.
+
+Exit codes tell scripts why the loop stopped:
+  0    reviewer accepted the changes
+  1    error
+  2    builder budget (--budget) reached
+  3    time limit (--timeout) reached
+  4    iteration limit (--max-iterations) reached without the reviewer accepting
+  6    reviewer kept raising the same findings (--halt-on-spiral)
+  130  interrupted
+
+A finding the reviewer raises in --spiral-threshold rounds (matched by file and
+message, ignoring line numbers and case) is escalated: it is printed
+prominently and called out to the builder as a recurring issue.
+
+The reviewer may accept with follow-ups: minor asks such as "add a changelog
+entry" that do not warrant another review round. They are printed in the
+summary; with --auto-followup the builder also runs each as an extra turn.`,
 		Example: `  yoloswe build "Add unit tests for the user service"
   yoloswe build --budget 10 --timeout 1800 "Refactor the database layer"
   yoloswe build --builder-model opus "Fix the authentication bug"
@@ -185,12 +207,16 @@ The loop continues until the reviewer accepts or limits are reached.`,
 	cmd.Flags().StringVar(&flags.dir, "dir", "", "Working directory (default: current)")
 	cmd.Flags().Float64Var(&flags.budget, "budget", 100.0, "Max USD for builder session")
 	cmd.Flags().IntVar(&flags.timeout, "timeout", 3600, "Max seconds")
+	cmd.Flags().IntVar(&flags.turnTimeout, "turn-timeout", 0, "Max seconds for one builder or reviewer turn; a stalled turn is cancelled and retried once (0: only --timeout applies)")
 	cmd.Flags().IntVar(&flags.maxIterations, "max-iterations", 100, "Max builder-reviewer iterations")
 	cmd.Flags().StringVar(&flags.record, "record", "", "Session recordings directory (default: ~/.yoloswe)")
 	cmd.Flags().StringVar(&flags.systemPrompt, "system", "", "Custom system prompt for builder")
 	cmd.Flags().BoolVar(&flags.requireApproval, "require-approval", false, "Require user approval for tool executions (default: auto-approve)")
-	cmd.Flags().StringVar(&flags.resumeSession, "resume", "", "Resume from a previous session ID")
+	cmd.Flags().StringVar(&flags.resumeSession, "resume", "", "Resume from a previous session ID, continuing its builder-reviewer loop state")
 	cmd.Flags().BoolVar(&flags.reviewFirst, "review-first", false, "Skip first builder turn and start with review")
+	cmd.Flags().IntVar(&flags.spiralThreshold, "spiral-threshold", yoloswe.DefaultSpiralThreshold, "Review rounds a finding may recur before it is escalated")
+	cmd.Flags().BoolVar(&flags.haltOnSpiral, "halt-on-spiral", false, "Stop the loop when a finding reaches --spiral-threshold")
+	cmd.Flags().BoolVar(&flags.autoFollowup, "auto-followup", false, "Run the reviewer's follow-ups on acceptance as extra builder turns")
 
 	return cmd
 }
@@ -198,6 +224,9 @@ The loop continues until the reviewer accepts or limits are reached.`,
 func runBuild(cmd *cobra.Command, args []string, flags *buildFlags) error {
 	app := cliapp.FromContext(cmd.Context())
 	prompt := strings.Join(args, " ")
+	if flags.turnTimeout < 0 {
+		return fmt.Errorf("--turn-timeout cannot be negative: %d", flags.turnTimeout)
+	}
 
 	workDir, err := resolveWorkDir(flags.dir)
 	if err != nil {
@@ -214,19 +243,23 @@ func runBuild(cmd *cobra.Command, args []string, flags *buildFlags) error {
 	}
 
 	config := yoloswe.Config{
-		BuilderModel:    flags.builderModel,
-		BuilderWorkDir:  workDir,
-		RecordingDir:    recordingDir,
-		SystemPrompt:    flags.systemPrompt,
-		RequireApproval: flags.requireApproval,
-		ResumeSessionID: flags.resumeSession,
-		ReviewFirst:     flags.reviewFirst,
-		ReviewerModel:   flags.reviewerModel,
-		Goal:            prompt,
-		MaxBudgetUSD:    flags.budget,
-		MaxTimeSeconds:  flags.timeout,
-		MaxIterations:   flags.maxIterations,
-		Verbose:         app.Verbosity >= render.VerbosityVerbose,
+		BuilderModel:       flags.builderModel,
+		BuilderWorkDir:     workDir,
+		RecordingDir:       recordingDir,
+		SystemPrompt:       flags.systemPrompt,
+		RequireApproval:    flags.requireApproval,
+		ResumeSessionID:    flags.resumeSession,
+		ReviewFirst:        flags.reviewFirst,
+		ReviewerModel:      flags.reviewerModel,
+		Goal:               prompt,
+		MaxBudgetUSD:       flags.budget,
+		MaxTimeSeconds:     flags.timeout,
+		TurnTimeoutSeconds: flags.turnTimeout,
+		MaxIterations:      flags.maxIterations,
+		SpiralThreshold:    flags.spiralThreshold,
+		HaltOnSpiral:       flags.haltOnSpiral,
+		AutoFollowup:       flags.autoFollowup,
+		Verbose:            app.Verbosity >= render.VerbosityVerbose,
 	}
 
 	app.Logger.Info("yoloswe build config",
@@ -235,6 +268,7 @@ func runBuild(cmd *cobra.Command, args []string, flags *buildFlags) error {
 		"work_dir", config.BuilderWorkDir,
 		"budget_usd", config.MaxBudgetUSD,
 		"timeout_seconds", config.MaxTimeSeconds,
+		"turn_timeout_seconds", config.TurnTimeoutSeconds,
 		"max_iterations", config.MaxIterations,
 		"prompt", prompt,
 	)
@@ -245,8 +279,11 @@ func runBuild(cmd *cobra.Command, args []string, flags *buildFlags) error {
 	if runErr != nil {
 		return runErr
 	}
-	if swe.Stats().ExitReason != yoloswe.ExitReasonAccepted {
-		return fmt.Errorf("build did not complete successfully (reason: %v)", swe.Stats().ExitReason)
+	if reason := swe.Stats().ExitReason; reason != yoloswe.ExitReasonAccepted {
+		return &cliapp.ExitError{
+			Code: reason.ExitCode(),
+			Err:  fmt.Errorf("build did not complete successfully (reason: %v)", reason),
+		}
 	}
 	return nil
 }
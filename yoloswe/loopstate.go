@@ -0,0 +1,133 @@
+package yoloswe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// loopStateFilename is the name of the checkpoint file written under
+// RecordingDir/<session-id>/ after every builder-reviewer iteration.
+const loopStateFilename = "loop-state.json"
+
+// LoopState is the checkpoint of the builder-reviewer loop. It is persisted
+// after each iteration so that `--resume <session-id>` can continue from the
+// right iteration with the reviewer's outstanding concerns instead of
+// restarting the count and forgetting prior feedback.
+type LoopState struct {
+	UpdatedAt         time.Time      `json:"updated_at"`
+	LastVerdict       *ReviewVerdict `json:"last_verdict,omitempty"`
+	FindingCounts     map[string]int `json:"finding_counts,omitempty"` // spiral guard: rounds each finding was raised in
+	SessionID         string         `json:"session_id"`
+	WorkDir           string         `json:"work_dir"`
+	WorktreeHead      string         `json:"worktree_head,omitempty"`
+	PendingFeedback   string         `json:"pending_feedback,omitempty"`
+	BuilderCostUSD    float64        `json:"builder_cost_usd"`
+	ReviewerCostUSD   float64        `json:"reviewer_cost_usd,omitempty"`
+	Iteration         int            `json:"iteration"`
+	BuilderTokensIn   int            `json:"builder_tokens_in"`
+	BuilderTokensOut  int            `json:"builder_tokens_out"`
+	ReviewerTokensIn  int64          `json:"reviewer_tokens_in"`
+	ReviewerTokensOut int64          `json:"reviewer_tokens_out"`
+}
+
+// LoopStatePath returns the checkpoint path for the given builder session ID.
+func LoopStatePath(recordingDir, sessionID string) string {
+	return filepath.Join(recordingDir, sessionID, loopStateFilename)
+}
+
+// SaveLoopState writes state to RecordingDir/<state.SessionID>/loop-state.json.
+// The file is written to a temp path and renamed so a crash mid-write never
+// leaves a truncated checkpoint behind.
+func SaveLoopState(recordingDir string, state *LoopState) error {
+	if state.SessionID == "" {
+		return fmt.Errorf("loop state has no session ID")
+	}
+	path := LoopStatePath(recordingDir, state.SessionID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create loop state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal loop state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write loop state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write loop state: %w", err)
+	}
+	return nil
+}
+
+// LoadLoopState reads the checkpoint for sessionID. It returns (nil, nil)
+// when no checkpoint exists, so resuming a session recorded before
+// checkpoints existed degrades to the old behavior.
+func LoadLoopState(recordingDir, sessionID string) (*LoopState, error) {
+	data, err := os.ReadFile(LoopStatePath(recordingDir, sessionID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read loop state: %w", err)
+	}
+	var state LoopState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse loop state: %w", err)
+	}
+	return &state, nil
+}
+
+// CheckResumable returns an error if the worktree at workDir has diverged
+// from the checkpoint: a different directory, or a HEAD that no longer
+// descends from the commit recorded at the last iteration. Resuming in that
+// situation would hand the reviewer's feedback to a builder looking at
+// different code. Commits on top of the checkpoint are expected — a builder
+// that committed and then crashed is the main case --resume exists for.
+func (s *LoopState) CheckResumable(workDir string) error {
+	if s.WorkDir != "" && workDir != "" && filepath.Clean(s.WorkDir) != filepath.Clean(workDir) {
+		return fmt.Errorf("session %s was recorded in %s, not %s", s.SessionID, s.WorkDir, workDir)
+	}
+	if s.WorktreeHead == "" {
+		return nil
+	}
+	head := gitHead(workDir)
+	if head != "" && head != s.WorktreeHead && !gitIsAncestor(workDir, s.WorktreeHead, head) {
+		return fmt.Errorf("worktree has diverged since session %s was checkpointed (HEAD %s, expected %s)",
+			s.SessionID, shortSHA(head), shortSHA(s.WorktreeHead))
+	}
+	return nil
+}
+
+// gitHead returns the HEAD commit of the repository containing dir, or ""
+// when dir is not inside a git repository.
+func gitHead(dir string) string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// gitIsAncestor reports whether commit ancestor is reachable from commit
+// descendant in the repository containing dir.
+func gitIsAncestor(dir, ancestor, descendant string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, descendant)
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
+// shortSHA truncates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
@@ -0,0 +1,111 @@
+package yoloswe
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bazelment/yoloswe/yoloswe/testutil"
+)
+
+func TestLoopStateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	want := &LoopState{
+		SessionID:       "sess-1",
+		WorkDir:         "/work",
+		Iteration:       3,
+		BuilderCostUSD:  1.25,
+		ReviewerCostUSD: 0.5,
+		PendingFeedback: "fix the nil check",
+		LastVerdict: &ReviewVerdict{
+			Summary:  "needs work",
+			Feedback: "fix the nil check",
+			Issues:   []ReviewIssue{{Severity: "high", File: "a.go", Message: "nil deref", Line: 7}},
+		},
+	}
+	if err := SaveLoopState(dir, want); err != nil {
+		t.Fatalf("SaveLoopState: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sess-1", "loop-state.json")); err != nil {
+		t.Fatalf("checkpoint not written at expected path: %v", err)
+	}
+
+	got, err := LoadLoopState(dir, "sess-1")
+	if err != nil {
+		t.Fatalf("LoadLoopState: %v", err)
+	}
+	if got.Iteration != 3 || got.BuilderCostUSD != 1.25 || got.ReviewerCostUSD != 0.5 || got.PendingFeedback != "fix the nil check" {
+		t.Errorf("unexpected state: %+v", got)
+	}
+	if got.LastVerdict == nil || len(got.LastVerdict.Issues) != 1 || got.LastVerdict.Issues[0].Line != 7 {
+		t.Errorf("last verdict not preserved: %+v", got.LastVerdict)
+	}
+}
+
+func TestLoadLoopStateMissing(t *testing.T) {
+	got, err := LoadLoopState(t.TempDir(), "nope")
+	if err != nil {
+		t.Fatalf("expected no error for missing checkpoint, got %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil state, got %+v", got)
+	}
+}
+
+func TestSaveLoopStateRequiresSessionID(t *testing.T) {
+	if err := SaveLoopState(t.TempDir(), &LoopState{}); err == nil {
+		t.Error("expected error for empty session ID")
+	}
+}
+
+func TestLoopStateCheckResumable(t *testing.T) {
+	repo := t.TempDir()
+	testutil.InitGitRepo(t, repo)
+	head := gitHead(repo)
+	if head == "" {
+		t.Fatal("expected HEAD in test repo")
+	}
+
+	t.Run("matching head", func(t *testing.T) {
+		state := &LoopState{SessionID: "s", WorkDir: repo, WorktreeHead: head}
+		if err := state.CheckResumable(repo); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("different workdir", func(t *testing.T) {
+		state := &LoopState{SessionID: "s", WorkDir: "/elsewhere", WorktreeHead: head}
+		if err := state.CheckResumable(repo); err == nil {
+			t.Error("expected error for different workdir")
+		}
+	})
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	t.Run("builder committed after checkpoint", func(t *testing.T) {
+		state := &LoopState{SessionID: "s", WorkDir: repo, WorktreeHead: head}
+		git("commit", "--allow-empty", "-m", "builder commit")
+		if err := state.CheckResumable(repo); err != nil {
+			t.Errorf("expected resume on top of the checkpoint to be allowed, got %v", err)
+		}
+	})
+
+	t.Run("diverged head", func(t *testing.T) {
+		state := &LoopState{SessionID: "s", WorkDir: repo, WorktreeHead: gitHead(repo)}
+		git("reset", "--hard", head)
+		git("commit", "--allow-empty", "-m", "moved on")
+		err := state.CheckResumable(repo)
+		if err == nil || !strings.Contains(err.Error(), "diverged") {
+			t.Errorf("expected divergence error, got %v", err)
+		}
+	})
+}
@@ -31,6 +31,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -139,6 +140,9 @@ const (
 	BuildModeCurrent BuildMode = "current"
 	// BuildModeNewSession starts a fresh session to implement the plan.
 	BuildModeNewSession BuildMode = "new"
+	// BuildModeHandoff starts the builder as a resume of the planning
+	// session, so it begins with everything the planner already explored.
+	BuildModeHandoff BuildMode = "handoff"
 	// BuildModeReturn returns to caller at ExitPlanMode without building.
 	// The caller is responsible for handling the plan (e.g., presenting choices in a TUI).
 	BuildModeReturn BuildMode = "return"
@@ -147,7 +151,7 @@ const (
 // IsValid returns true if the BuildMode is a recognized value.
 func (m BuildMode) IsValid() bool {
 	switch m {
-	case BuildModeNone, BuildModeCurrent, BuildModeNewSession, BuildModeReturn:
+	case BuildModeNone, BuildModeCurrent, BuildModeNewSession, BuildModeHandoff, BuildModeReturn:
 		return true
 	default:
 		return false
@@ -194,6 +198,8 @@ type PlannerWrapper struct {
 	session             *claude.Session
 	renderer            *Renderer
 	planFilePath        string
+	planText            string   // plan from the last ExitPlanMode call
+	exploredFiles       []string // files read while planning, first read first
 	config              Config
 	planningStats       SessionStats
 	buildingStats       SessionStats
@@ -431,8 +437,9 @@ func (p *PlannerWrapper) handleEvent(ctx context.Context, event claude.Event) (b
 	case claude.ToolCompleteEvent:
 		p.renderer.ToolComplete(e.Name, e.Input)
 
-		// Track plan file writes
+		// Track plan file writes and what the planner explored
 		p.trackPlanFileWrite(e.Name, e.Input)
+		p.trackExploration(e.Name, e.Input)
 
 		// Handle special interactive tools
 		// Note: AskUserQuestion is now handled in the permission handler
@@ -562,6 +569,8 @@ func (p *PlannerWrapper) handleExitPlanMode(ctx context.Context, toolUseID strin
 			return p.executeInCurrentSession(ctx)
 		case BuildModeNewSession:
 			return p.executeInNewSession(ctx)
+		case BuildModeHandoff:
+			return p.executeHandoff(ctx, toolUseID)
 		default:
 			// No build mode specified, export and exit (existing behavior)
 			filename := p.generatePlanFilename()
@@ -575,8 +584,9 @@ func (p *PlannerWrapper) handleExitPlanMode(ctx context.Context, toolUseID strin
 	fmt.Println("  2. Execute in new session (fresh start)")
 	fmt.Println("  3. Export plan to markdown")
 	fmt.Println("  4. Continue refining")
+	fmt.Println("  5. Execute in new session with planner context (handoff)")
 	fmt.Println()
-	fmt.Print("Enter choice (1-4) or feedback: ")
+	fmt.Print("Enter choice (1-5) or feedback: ")
 
 	choice, err := p.readLineWithContext(ctx)
 	if err != nil {
@@ -595,6 +605,9 @@ func (p *PlannerWrapper) handleExitPlanMode(ctx context.Context, toolUseID strin
 		filename := p.generatePlanFilename()
 		return p.exportPlanAndExit(ctx, filename)
 
+	case "5":
+		return p.executeHandoff(ctx, toolUseID)
+
 	default:
 		// Continue refining - option "4" or any other input as feedback
 		msg := "Please keep refining the plan."
@@ -674,7 +687,7 @@ func (p *PlannerWrapper) executeInCurrentSession(ctx context.Context) (bool, err
 func (p *PlannerWrapper) executeInNewSession(ctx context.Context) (bool, error) {
 	// If external builder is configured, use it instead
 	if p.config.ExternalBuilderPath != "" {
-		return p.executeWithExternalBuilder(ctx)
+		return p.executeWithExternalBuilder(ctx, "")
 	}
 
 	// Check if plan file exists - new session needs a file to reference
@@ -701,12 +714,38 @@ func (p *PlannerWrapper) executeInNewSession(ctx context.Context) (bool, error)
 	p.session.Stop()
 
 	// Start new session with bypass permissions (no plan mode)
+	p.session = claude.NewSession(p.buildSessionOptions("")...)
+	if err := p.session.Start(ctx); err != nil {
+		return false, fmt.Errorf("failed to start new session: %w", err)
+	}
+
+	// Log that we're starting implementation in a new session
+	p.renderer.Status(fmt.Sprintf("Implementation session started, using plan: %s", p.planFilePath))
+
+	// For new session, we transition to build phase immediately since this is a fresh session.
+	// Note: The final planning turn's TurnComplete event (with stats) won't arrive because
+	// we stopped the old session. This means the final planning turn's stats are lost.
+	// This is an acceptable tradeoff for getting a fresh context in the new session.
+	p.inBuildPhase = true
+	// Keep waitingForUserInput=true (set by handleExitPlanMode) so the event loop continues.
+	// It will be set to false by the TurnComplete handler when the build turn completes.
+
+	// Don't switch to plan mode - we want to execute directly
+	msg := fmt.Sprintf("Implement the plan in %s", p.planFilePath)
+	_, err := p.session.SendMessage(ctx, msg)
+	return false, err
+}
+
+// buildSessionOptions returns the options for a builder session: the build
+// model (falling back to Model) with permissions bypassed. A non-empty
+// resume ID continues that recorded conversation instead of starting fresh.
+func (p *PlannerWrapper) buildSessionOptions(resume string) []claude.SessionOption {
 	// Use BuildModel if specified, otherwise fall back to Model
 	modelToUse := p.config.BuildModel
 	if modelToUse == "" {
 		modelToUse = p.config.Model
 	}
-	newOpts := []claude.SessionOption{
+	opts := []claude.SessionOption{
 		claude.WithModel(modelToUse),
 		claude.WithPermissionMode(claude.PermissionModeBypass),
 		claude.WithPermissionPromptToolStdio(),
@@ -717,37 +756,82 @@ func (p *PlannerWrapper) executeInNewSession(ctx context.Context) (bool, error)
 		claude.WithRecording(p.config.RecordingDir),
 	}
 	if p.config.WorkDir != "" {
-		newOpts = append(newOpts, claude.WithWorkDir(p.config.WorkDir))
+		opts = append(opts, claude.WithWorkDir(p.config.WorkDir))
 	}
 	if p.config.SystemPrompt != "" {
-		newOpts = append(newOpts, claude.WithSystemPrompt(p.config.SystemPrompt))
+		opts = append(opts, claude.WithSystemPrompt(p.config.SystemPrompt))
+	}
+	if resume != "" {
+		opts = append(opts, claude.WithResume(resume))
 	}
+	return opts
+}
 
-	p.session = claude.NewSession(newOpts...)
-	if err := p.session.Start(ctx); err != nil {
-		return false, fmt.Errorf("failed to start new session: %w", err)
+// executeHandoff stops the planning session and starts the builder as a
+// resume of it, so the builder keeps the planner's whole conversation while
+// running on the build model. When the planner's session ID is unknown the
+// builder starts fresh, primed with ContextSummary instead. An external
+// builder always gets the summary, since it can't resume our session.
+//
+// The planner stops before ExitPlanMode (toolUseID) gets a result, so the
+// resumed conversation ends with that tool_use pending; the builder's first
+// message answers it with the approval.
+func (p *PlannerWrapper) executeHandoff(ctx context.Context, toolUseID string) (bool, error) {
+	summary := p.ContextSummary()
+	if p.config.ExternalBuilderPath != "" {
+		return p.executeWithExternalBuilder(ctx, summary)
 	}
 
-	// Log that we're starting implementation in a new session
-	p.renderer.Status(fmt.Sprintf("Implementation session started, using plan: %s", p.planFilePath))
+	sessionID := p.CLISessionID()
+	fmt.Println("\n→ Handing off to a builder session with the planner's context...")
+	if oldRecordingPath := p.session.RecordingPath(); oldRecordingPath != "" {
+		p.renderer.Status(fmt.Sprintf("Planning session recorded to: %s", oldRecordingPath))
+	}
+	p.session.Stop()
 
-	// For new session, we transition to build phase immediately since this is a fresh session.
-	// Note: The final planning turn's TurnComplete event (with stats) won't arrive because
-	// we stopped the old session. This means the final planning turn's stats are lost.
-	// This is an acceptable tradeoff for getting a fresh context in the new session.
+	p.session = claude.NewSession(p.buildSessionOptions(sessionID)...)
+	if err := p.session.Start(ctx); err != nil {
+		return false, fmt.Errorf("failed to start handoff session: %w", err)
+	}
+
+	// As with executeInNewSession, the final planning turn's stats are lost
+	// with the stopped session and the build phase starts immediately.
 	p.inBuildPhase = true
-	// Keep waitingForUserInput=true (set by handleExitPlanMode) so the event loop continues.
-	// It will be set to false by the TurnComplete handler when the build turn completes.
 
-	// Don't switch to plan mode - we want to execute directly
-	msg := fmt.Sprintf("Implement the plan in %s", p.planFilePath)
-	_, err := p.session.SendMessage(ctx, msg)
+	if sessionID == "" {
+		p.renderer.Status("Planning session ID unavailable, builder starts from a context summary")
+		_, err := p.session.SendMessage(ctx, handoffPrompt(p.planFilePath, summary))
+		return false, err
+	}
+
+	// The resumed conversation comes back in plan mode; leave it so the
+	// builder can edit files.
+	if err := p.session.SetPermissionMode(ctx, claude.PermissionModeBypass); err != nil {
+		return false, fmt.Errorf("failed to switch permission mode: %w", err)
+	}
+	p.renderer.Status(fmt.Sprintf("Builder resumed planning session %s", sessionID))
+	_, err := p.session.SendToolResult(ctx, toolUseID, "I approve this plan. Please proceed with implementation.")
 	return false, err
 }
 
+// handoffPrompt is the first builder message when the planner's session
+// can't be resumed: the implement instruction followed by the planner's
+// context summary.
+func handoffPrompt(planFilePath, summary string) string {
+	msg := "Implement the plan."
+	if planFilePath != "" {
+		msg = fmt.Sprintf("Implement the plan in %s", planFilePath)
+	}
+	if summary != "" {
+		msg += "\n\n" + summary
+	}
+	return msg
+}
+
 // executeWithExternalBuilder launches an external builder tool with the plan file.
+// A non-empty contextSummary is appended to the builder's prompt.
 // Returns (done=true, error) to signal session should exit.
-func (p *PlannerWrapper) executeWithExternalBuilder(ctx context.Context) (bool, error) {
+func (p *PlannerWrapper) executeWithExternalBuilder(ctx context.Context, contextSummary string) (bool, error) {
 	// 1. Validate external builder path
 	builderPath, err := validateExecutablePath(p.config.ExternalBuilderPath)
 	if err != nil {
@@ -763,7 +847,7 @@ func (p *PlannerWrapper) executeWithExternalBuilder(ctx context.Context) (bool,
 	}
 
 	// 3. Build command arguments
-	args := p.buildExternalBuilderArgs()
+	args := p.buildExternalBuilderArgs(contextSummary)
 
 	// 4. Create command with context (for cancellation)
 	cmd := exec.CommandContext(ctx, builderPath, args...)
@@ -841,7 +925,7 @@ func validateExecutablePath(path string) (string, error) {
 
 // buildExternalBuilderArgs constructs command arguments for the external builder.
 // Maps yoloplanner config to external builder CLI flags (yoloswe format).
-func (p *PlannerWrapper) buildExternalBuilderArgs() []string {
+func (p *PlannerWrapper) buildExternalBuilderArgs(contextSummary string) []string {
 	args := []string{}
 
 	// Map model to builder-model
@@ -874,9 +958,8 @@ func (p *PlannerWrapper) buildExternalBuilderArgs() []string {
 		args = append(args, "--verbose")
 	}
 
-	// Add prompt: "Implement the plan in <plan-file>"
-	prompt := fmt.Sprintf("Implement the plan in %s", p.planFilePath)
-	args = append(args, prompt)
+	// Add prompt: "Implement the plan in <plan-file>", plus any handoff context
+	args = append(args, handoffPrompt(p.planFilePath, contextSummary))
 
 	return args
 }
@@ -967,6 +1050,63 @@ func (p *PlannerWrapper) trackPlanFileWrite(toolName string, input map[string]in
 	}
 }
 
+// maxSummaryFiles caps how many explored files ContextSummary lists.
+const maxSummaryFiles = 50
+
+// trackExploration records, during planning, the files the planner reads
+// and the plan it proposes, for ContextSummary.
+func (p *PlannerWrapper) trackExploration(toolName string, input map[string]interface{}) {
+	if p.inBuildPhase {
+		return
+	}
+	switch toolName {
+	case "Read":
+		filePath, ok := input["file_path"].(string)
+		if ok && filePath != "" && !slices.Contains(p.exploredFiles, filePath) {
+			p.exploredFiles = append(p.exploredFiles, filePath)
+		}
+	case "ExitPlanMode":
+		if plan, ok := input["plan"].(string); ok {
+			p.planText = plan
+		}
+	}
+}
+
+// ContextSummary distills the planning session into text a builder without
+// the planner's conversation can start from: the task, the plan file (or the
+// plan itself when it was never written to a file) and the files the
+// planner already read. It returns "" before planning has produced any of
+// these.
+func (p *PlannerWrapper) ContextSummary() string {
+	if p.config.Prompt == "" && p.planFilePath == "" && p.planText == "" && len(p.exploredFiles) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Context from the planning session:\n")
+	if p.config.Prompt != "" {
+		fmt.Fprintf(&b, "\nTask: %s\n", p.config.Prompt)
+	}
+	if p.planFilePath != "" {
+		fmt.Fprintf(&b, "\nPlan file: %s\n", p.planFilePath)
+	} else if p.planText != "" {
+		fmt.Fprintf(&b, "\nPlan:\n%s\n", strings.TrimSpace(p.planText))
+	}
+	if len(p.exploredFiles) > 0 {
+		b.WriteString("\nFiles the planner already read (no need to rediscover them):\n")
+		files := p.exploredFiles
+		if len(files) > maxSummaryFiles {
+			files = files[:maxSummaryFiles]
+		}
+		for _, f := range files {
+			fmt.Fprintf(&b, "- %s\n", f)
+		}
+		if more := len(p.exploredFiles) - len(files); more > 0 {
+			fmt.Fprintf(&b, "- ... and %d more\n", more)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
 // exportPlanAndExit exports the plan to the given filename and returns done=true.
 // If no plan file was detected, it asks Claude to write the plan first.
 // Returns (done, error) where done=true means the session should end.
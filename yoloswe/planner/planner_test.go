@@ -3,6 +3,7 @@ package planner
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
@@ -292,6 +293,7 @@ func TestBuildModeConstants(t *testing.T) {
 		{"BuildModeNone", BuildModeNone, ""},
 		{"BuildModeCurrent", BuildModeCurrent, "current"},
 		{"BuildModeNewSession", BuildModeNewSession, "new"},
+		{"BuildModeHandoff", BuildModeHandoff, "handoff"},
 	}
 
 	for _, tt := range tests {
@@ -371,6 +373,7 @@ func TestBuildModeIsValid(t *testing.T) {
 		{BuildModeNone, true},
 		{BuildModeCurrent, true},
 		{BuildModeNewSession, true},
+		{BuildModeHandoff, true},
 		{BuildMode("invalid"), false},
 		{BuildMode("CURRENT"), false}, // Case sensitive
 		{BuildMode("  "), false},      // Whitespace
@@ -385,6 +388,68 @@ func TestBuildModeIsValid(t *testing.T) {
 	}
 }
 
+func TestContextSummary(t *testing.T) {
+	p := &PlannerWrapper{config: Config{Prompt: "Add retries to the fetcher"}}
+	p.trackExploration("Read", map[string]interface{}{"file_path": "/repo/fetch.go"})
+	p.trackExploration("Grep", map[string]interface{}{"pattern": "retry"})
+	p.trackExploration("Read", map[string]interface{}{"file_path": "/repo/client.go"})
+	p.trackExploration("Read", map[string]interface{}{"file_path": "/repo/fetch.go"})
+	p.trackExploration("ExitPlanMode", map[string]interface{}{"plan": "1. Wrap Fetch in a retry loop\n"})
+
+	want := `Context from the planning session:
+
+Task: Add retries to the fetcher
+
+Plan:
+1. Wrap Fetch in a retry loop
+
+Files the planner already read (no need to rediscover them):
+- /repo/fetch.go
+- /repo/client.go`
+	if got := p.ContextSummary(); got != want {
+		t.Errorf("ContextSummary() =\n%s\nwant\n%s", got, want)
+	}
+
+	// With a plan file the builder reads the plan from disk, so the summary
+	// points at it instead of repeating it.
+	p.planFilePath = "/home/u/.claude/plans/retry.md"
+	got := p.ContextSummary()
+	if !strings.Contains(got, "Plan file: /home/u/.claude/plans/retry.md") || strings.Contains(got, "Wrap Fetch") {
+		t.Errorf("ContextSummary() = %q, want the plan file instead of the plan text", got)
+	}
+
+	// Reads during the build phase aren't planner exploration.
+	p.inBuildPhase = true
+	p.trackExploration("Read", map[string]interface{}{"file_path": "/repo/build_only.go"})
+	if strings.Contains(p.ContextSummary(), "build_only.go") {
+		t.Error("ContextSummary() lists a file read during the build phase")
+	}
+}
+
+func TestContextSummaryEmpty(t *testing.T) {
+	p := &PlannerWrapper{}
+	if got := p.ContextSummary(); got != "" {
+		t.Errorf("ContextSummary() = %q, want empty before planning", got)
+	}
+}
+
+func TestHandoffPrompt(t *testing.T) {
+	tests := []struct {
+		name, planFile, summary, want string
+	}{
+		{"plan file only", "/p/plan.md", "", "Implement the plan in /p/plan.md"},
+		{"plan file and summary", "/p/plan.md", "Context", "Implement the plan in /p/plan.md\n\nContext"},
+		{"summary only", "", "Context", "Implement the plan.\n\nContext"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := handoffPrompt(tt.planFile, tt.summary); got != tt.want {
+				t.Errorf("handoffPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPlanFilePathRequired(t *testing.T) {
 	// Test that executeInNewSession requires a valid plan file path
 	tests := []struct {
@@ -121,6 +121,14 @@ type Backend interface {
 	RunPrompt(ctx context.Context, prompt string, handler EventHandler) (*ReviewResult, error)
 }
 
+// interrupter is implemented by backends whose turn keeps running after
+// RunPrompt returns on a cancelled context. Interrupt stops that turn and
+// consumes its completion, so the next RunPrompt doesn't mistake it for its
+// own.
+type interrupter interface {
+	Interrupt(ctx context.Context) error
+}
+
 // EventHandler receives streaming events from the agent backend.
 type EventHandler interface {
 	OnSessionInfo(sessionID, model string)
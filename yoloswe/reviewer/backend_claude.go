@@ -0,0 +1,232 @@
+package reviewer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
+)
+
+// claudeBackend wraps the Claude SDK as a Backend.
+// The claude session is started once in Start() and kept alive across
+// RunPrompt calls, so FollowUp continues the same conversation like the
+// codex backend's cached thread.
+//
+// The session runs in plan mode and every tool call is routed through
+// claudeReadOnlyPermissions, which allows only tools that cannot change the
+// tree; ExitPlanMode is denied by claudeReviewHandler.
+type claudeBackend struct {
+	session *claude.Session
+	// resumeStatus is fixed when the first turn observes the session's
+	// ReadyEvent; later turns reuse the same session, so the same resume
+	// contract applies (mirrors codexBackend.threadResumeStatus).
+	resumeStatus  ResumeStatus
+	config        Config
+	infoDelivered bool
+}
+
+func newClaudeBackend(config Config) *claudeBackend {
+	return &claudeBackend{config: config}
+}
+
+func (b *claudeBackend) Start(ctx context.Context) error {
+	opts := []claude.SessionOption{
+		claude.WithModel(b.config.Model),
+		claude.WithPermissionMode(claude.PermissionModeDefault),
+		claude.WithPermissionHandler(claudeReadOnlyPermissions()),
+		claude.WithPermissionPromptToolStdio(),
+		claude.WithInteractiveToolHandler(claudeReviewHandler{}),
+		claude.WithStderrHandler(stderrPrefixHandler("claude")),
+	}
+	if b.config.WorkDir != "" {
+		opts = append(opts, claude.WithWorkDir(b.config.WorkDir))
+	}
+	if b.config.Effort != "" {
+		opts = append(opts, claude.WithEffort(claude.EffortLevel(b.config.Effort)))
+	}
+	if b.config.ResumeSessionID != "" {
+		// Start at Unverified so an early exit (no Ready event) still
+		// surfaces "resume was attempted" in the envelope.
+		b.resumeStatus = ResumeStatusUnverified
+		opts = append(opts, claude.WithResume(b.config.ResumeSessionID))
+	}
+
+	session := claude.NewSession(opts...)
+	if err := session.Start(ctx); err != nil {
+		return fmt.Errorf("claude: failed to start session: %w", err)
+	}
+	// Switch to plan mode (read-only) via control message. The permission
+	// mode is not persisted across --resume, so this is applied on resumed
+	// sessions too.
+	if err := session.SetPermissionMode(ctx, claude.PermissionModePlan); err != nil {
+		_ = session.Stop()
+		return fmt.Errorf("claude: failed to enter read-only mode: %w", err)
+	}
+	b.session = session
+	return nil
+}
+
+func (b *claudeBackend) Stop() error {
+	if b.session != nil {
+		return b.session.Stop()
+	}
+	return nil
+}
+
+// Interrupt implements interrupter.
+func (b *claudeBackend) Interrupt(ctx context.Context) error {
+	if b.session == nil {
+		return nil
+	}
+	if err := b.session.Interrupt(ctx); err != nil {
+		return fmt.Errorf("claude: failed to interrupt: %w", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-b.session.Events():
+			if !ok {
+				return nil
+			}
+			if _, done := ev.(claude.TurnCompleteEvent); done {
+				return nil
+			}
+		}
+	}
+}
+
+func (b *claudeBackend) RunPrompt(ctx context.Context, prompt string, handler EventHandler) (*ReviewResult, error) {
+	if b.session == nil {
+		return nil, fmt.Errorf("claude: backend not started")
+	}
+
+	if _, err := b.session.SendMessage(ctx, prompt); err != nil {
+		return reviewErrorResult(b.resumeStatus, fmt.Errorf("claude: failed to send message: %w", err))
+	}
+
+	// Derived context unblocks the filter goroutine's sends on early return.
+	adapterCtx, adapterCancel := context.WithCancel(ctx)
+	defer adapterCancel()
+
+	bridged, err := bridgeStreamEvents(adapterCtx, b.filterEvents(adapterCtx, handler), handler, "", b.config.IdleTimeout)
+	if err != nil {
+		return reviewErrorResult(b.resumeStatus, fmt.Errorf("claude: %w", err))
+	}
+
+	result := &ReviewResult{
+		ResponseText: bridged.responseText,
+		Success:      bridged.success,
+		DurationMs:   bridged.durationMs,
+		ResumeStatus: b.resumeStatus,
+	}
+	if tc, ok := bridged.turnEvent.(claude.TurnCompleteEvent); ok {
+		result.InputTokens = int64(tc.Usage.InputTokens)
+		result.OutputTokens = int64(tc.Usage.OutputTokens)
+		result.CostUSD = tc.Usage.CostUSD
+		if tc.Error != nil {
+			result.ErrorMessage = tc.Error.Error()
+		}
+	}
+	return result, nil
+}
+
+// filterEvents re-emits the session's events, intercepting ReadyEvent to
+// report session info (the bridge ignores KindReady) and to finalize the
+// resume status once the actual session ID is known.
+func (b *claudeBackend) filterEvents(ctx context.Context, handler EventHandler) <-chan claude.Event {
+	out := make(chan claude.Event)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-b.session.Events():
+				if !ok {
+					return
+				}
+				if ready, isReady := ev.(claude.ReadyEvent); isReady {
+					b.onReady(ready.Info, handler)
+					continue
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+func (b *claudeBackend) onReady(info claude.SessionInfo, handler EventHandler) {
+	if b.infoDelivered {
+		return
+	}
+	b.infoDelivered = true
+	b.resumeStatus = resumeStatusAfterSessionReady(b.resumeStatus, b.config.ResumeSessionID, info.SessionID)
+	if b.resumeStatus == ResumeStatusFallback {
+		slog.Warn("claude resume unavailable; running in a fresh session", "session_id", b.config.ResumeSessionID, "actual", info.SessionID)
+	}
+	if handler != nil {
+		model := info.Model
+		if model == "" {
+			model = b.config.Model
+		}
+		handler.OnSessionInfo(info.SessionID, model)
+	}
+}
+
+// claudeReviewHandler answers interactive tools for the review session. The
+// reviewer never needs user input, and it must stay in plan mode.
+type claudeReviewHandler struct{}
+
+// HandleAskUserQuestion selects the first option for each question so the
+// review never blocks on a prompt nobody will answer.
+func (claudeReviewHandler) HandleAskUserQuestion(_ context.Context, questions []claude.Question) (map[string]string, error) {
+	answers := make(map[string]string, len(questions))
+	for _, q := range questions {
+		answer := "yes"
+		if len(q.Options) > 0 {
+			answer = q.Options[0].Label
+		}
+		answers[q.Text] = answer
+	}
+	return answers, nil
+}
+
+// HandleExitPlanMode denies any attempt to leave plan mode; the reviewer is
+// read-only.
+func (claudeReviewHandler) HandleExitPlanMode(_ context.Context, _ claude.PlanInfo) (string, error) {
+	return "", fmt.Errorf("review sessions are read-only; exiting plan mode is not allowed")
+}
+
+// claudeReadOnlyTools lists the tools a review session may use. Anything not
+// listed, including Bash, Edit and Write, is denied.
+var claudeReadOnlyTools = map[string]bool{
+	"Read":         true,
+	"Glob":         true,
+	"Grep":         true,
+	"LS":           true,
+	"NotebookRead": true,
+	"WebFetch":     true,
+	"WebSearch":    true,
+	"TodoWrite":    true,
+}
+
+// claudeReadOnlyPermissions returns a permission handler that allows
+// claudeReadOnlyTools and denies every other tool.
+func claudeReadOnlyPermissions() claude.PermissionHandler {
+	return claude.PermissionHandlerFunc(func(_ context.Context, req *claude.PermissionRequest) (*claude.PermissionResponse, error) {
+		if claudeReadOnlyTools[req.ToolName] {
+			return &claude.PermissionResponse{Behavior: claude.PermissionAllow}, nil
+		}
+		return &claude.PermissionResponse{
+			Behavior: claude.PermissionDeny,
+			Message:  fmt.Sprintf("review sessions are read-only; %s is not allowed", req.ToolName),
+		}, nil
+	})
+}
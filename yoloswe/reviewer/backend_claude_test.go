@@ -0,0 +1,117 @@
+package reviewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
+)
+
+type sessionInfoRecorder struct {
+	rendererEventHandler
+	sessionID string
+	model     string
+	calls     int
+}
+
+func (r *sessionInfoRecorder) OnSessionInfo(sessionID, model string) {
+	r.calls++
+	r.sessionID = sessionID
+	r.model = model
+}
+
+func TestNew_ClaudeBackend(t *testing.T) {
+	r := New(Config{BackendType: BackendClaude})
+	if r.config.Model != DefaultClaudeModel {
+		t.Errorf("expected default model %s, got %s", DefaultClaudeModel, r.config.Model)
+	}
+	if _, ok := r.backend.(*claudeBackend); !ok {
+		t.Errorf("expected *claudeBackend, got %T", r.backend)
+	}
+	if r.config.ApprovalPolicy != "" || r.config.Sandbox != "" {
+		t.Errorf("codex-only defaults leaked into claude config: %+v", r.config)
+	}
+}
+
+func TestNewClaudeBackend_StopBeforeStartIsNoop(t *testing.T) {
+	b := newClaudeBackend(Config{BackendType: BackendClaude})
+	if err := b.Stop(); err != nil {
+		t.Errorf("Stop before Start should be no-op, got error: %v", err)
+	}
+	if _, err := b.RunPrompt(context.Background(), "review", nil); err == nil {
+		t.Error("RunPrompt before Start should fail")
+	}
+}
+
+func TestClaudeBackend_OnReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		resumeID   string
+		actualID   string
+		initial    ResumeStatus
+		wantStatus ResumeStatus
+	}{
+		{name: "fresh session", actualID: "s1", wantStatus: ""},
+		{name: "resume ok", resumeID: "s1", actualID: "s1", initial: ResumeStatusUnverified, wantStatus: ResumeStatusOK},
+		{name: "resume fallback", resumeID: "s1", actualID: "s2", initial: ResumeStatusUnverified, wantStatus: ResumeStatusFallback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newClaudeBackend(Config{Model: "sonnet", ResumeSessionID: tt.resumeID})
+			b.resumeStatus = tt.initial
+			rec := &sessionInfoRecorder{}
+
+			b.onReady(claude.SessionInfo{SessionID: tt.actualID}, rec)
+			// A second ReadyEvent (e.g. after a follow-up) must not re-report.
+			b.onReady(claude.SessionInfo{SessionID: "other"}, rec)
+
+			if b.resumeStatus != tt.wantStatus {
+				t.Errorf("resumeStatus = %q, want %q", b.resumeStatus, tt.wantStatus)
+			}
+			if rec.calls != 1 || rec.sessionID != tt.actualID {
+				t.Errorf("OnSessionInfo calls=%d id=%q, want 1 call with %q", rec.calls, rec.sessionID, tt.actualID)
+			}
+			if rec.model != "sonnet" {
+				t.Errorf("model = %q, want configured fallback sonnet", rec.model)
+			}
+		})
+	}
+}
+
+func TestClaudeReviewHandler(t *testing.T) {
+	h := claudeReviewHandler{}
+	answers, err := h.HandleAskUserQuestion(context.Background(), []claude.Question{
+		{Text: "pick", Options: []claude.QuestionOption{{Label: "first"}, {Label: "second"}}},
+		{Text: "confirm"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if answers["pick"] != "first" || answers["confirm"] != "yes" {
+		t.Errorf("unexpected answers: %v", answers)
+	}
+	if _, err := h.HandleExitPlanMode(context.Background(), claude.PlanInfo{}); err == nil {
+		t.Error("expected ExitPlanMode to be denied")
+	}
+}
+
+func TestClaudeReadOnlyPermissions(t *testing.T) {
+	h := claudeReadOnlyPermissions()
+	for tool, want := range map[string]claude.PermissionBehavior{
+		"Read":         claude.PermissionAllow,
+		"Grep":         claude.PermissionAllow,
+		"Bash":         claude.PermissionDeny,
+		"Edit":         claude.PermissionDeny,
+		"Write":        claude.PermissionDeny,
+		"NotebookEdit": claude.PermissionDeny,
+		"mcp__x__y":    claude.PermissionDeny,
+	} {
+		resp, err := h.HandlePermission(context.Background(), &claude.PermissionRequest{ToolName: tool})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tool, err)
+		}
+		if resp.Behavior != want {
+			t.Errorf("%s: behavior = %s, want %s", tool, resp.Behavior, want)
+		}
+	}
+}
@@ -58,6 +58,42 @@ func (b *codexBackend) Stop() error {
 	return nil
 }
 
+// Interrupt implements interrupter. When the turn finished on its own in
+// the meantime, only the completion already queued is consumed.
+func (b *codexBackend) Interrupt(ctx context.Context) error {
+	if b.thread == nil {
+		return nil
+	}
+	err := b.thread.Interrupt(ctx)
+	idle := errors.Is(err, codex.ErrNoTurnInProgress)
+	if err != nil && !idle {
+		return fmt.Errorf("codex: failed to interrupt: %w", err)
+	}
+	for {
+		var ev codex.Event
+		var ok bool
+		if idle {
+			select {
+			case ev, ok = <-b.client.Events():
+			default:
+				return nil
+			}
+		} else {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case ev, ok = <-b.client.Events():
+			}
+		}
+		if !ok {
+			return nil
+		}
+		if done, isDone := ev.(codex.TurnCompletedEvent); isDone && done.ThreadID == b.thread.ID() {
+			return nil
+		}
+	}
+}
+
 func (b *codexBackend) RunPrompt(ctx context.Context, prompt string, handler EventHandler) (*ReviewResult, error) {
 	// Create a new thread if none exists, or reuse for follow-ups.
 	// resumeStatus on the thread-reuse path comes from the last creation —
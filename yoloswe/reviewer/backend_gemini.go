@@ -83,7 +83,7 @@ func (b *geminiBackend) RunPrompt(ctx context.Context, prompt string, handler Ev
 		resumeStatus = ResumeStatusUnverified
 		session, err = b.client.LoadSession(ctx, b.config.ResumeSessionID, sessionOpts...)
 		if err != nil && errors.Is(err, acp.ErrSessionNotFound) {
-			slog.Warn("gemini resume unavailable; falling back to fresh session", "session_id", b.config.ResumeSessionID, "error", err.Error())
+			slog.Warn("gemini resume unavailable; falling back to fresh session", "session_id", b.config.ResumeSessionID, "load_supported", b.client.CanLoadSession(), "error", err.Error())
 			resumeStatus = ResumeStatusFallback
 			session, err = b.client.NewSession(ctx, sessionOpts...)
 		} else if err == nil {
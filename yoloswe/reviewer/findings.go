@@ -0,0 +1,93 @@
+package reviewer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Finding is one reviewer issue reduced to what a loop needs to count and
+// compare findings across rounds. Path is the file for code reviews and the
+// section heading for design-doc reviews; Line is 0 when the reviewer gave
+// none (always, for design docs). ResponseText on ReviewResult keeps the full
+// prose and JSON.
+// Field order: strings before int per fieldalignment.
+type Finding struct {
+	Path     string `json:"path"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// severityOrder lists severities from most to least severe. It matches
+// validSeverities and is the order FormatFindingCounts reports them in.
+var severityOrder = []string{"critical", "high", "medium", "low"}
+
+// ParseFindings extracts the issues array from a reviewer response that
+// follows the JSON output rules. Severities are lowercased; a response with
+// no parseable JSON yields nil. Class-level issues without a top-level file
+// are addressed by their first site.
+func ParseFindings(text string) []Finding {
+	body, err := extractReviewBody(text)
+	if err != nil {
+		return nil
+	}
+	return findingsFromIssues(body.Issues)
+}
+
+func findingsFromIssues(issues []ReviewIssue) []Finding {
+	if len(issues) == 0 {
+		return nil
+	}
+	findings := make([]Finding, 0, len(issues))
+	for _, issue := range issues {
+		f := Finding{
+			Path:     issue.File,
+			Line:     issue.Line,
+			Severity: strings.ToLower(strings.TrimSpace(issue.Severity)),
+			Message:  issue.Message,
+		}
+		if f.Path == "" && len(issue.Sites) > 0 {
+			f.Path = issue.Sites[0].File
+			f.Line = issue.Sites[0].Line
+		}
+		if f.Path == "" {
+			f.Path = issue.Section
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// CountFindings tallies findings by severity.
+func CountFindings(findings []Finding) map[string]int {
+	counts := make(map[string]int, len(severityOrder))
+	for _, f := range findings {
+		counts[f.Severity]++
+	}
+	return counts
+}
+
+// FormatFindingCounts renders a severity tally such as "3 high, 2 medium",
+// most severe first, with unrecognized severities last. It returns
+// "no findings" for an empty slice.
+func FormatFindingCounts(findings []Finding) string {
+	if len(findings) == 0 {
+		return "no findings"
+	}
+	counts := CountFindings(findings)
+	var parts []string
+	for _, sev := range severityOrder {
+		if n := counts[sev]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, sev))
+			delete(counts, sev)
+		}
+	}
+	other := 0
+	for _, n := range counts {
+		other += n
+	}
+	if other > 0 {
+		parts = append(parts, fmt.Sprintf("%d unrated", other))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,65 @@
+package reviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFindings(t *testing.T) {
+	text := "Review done.\n```json\n" + `{
+  "verdict": "rejected",
+  "summary": "two problems",
+  "issues": [
+    {"severity": "HIGH", "file": "a.go", "line": 12, "message": "nil deref"},
+    {"severity": "medium", "invariant": "close every body", "message": "leak",
+     "sites": [{"file": "b.go", "line": 3}, {"file": "c.go", "line": 9}]},
+    {"severity": "low", "section": "## Rollout", "dimension": "q2", "message": "vague"}
+  ]
+}` + "\n```"
+
+	got := ParseFindings(text)
+	want := []Finding{
+		{Path: "a.go", Line: 12, Severity: "high", Message: "nil deref"},
+		{Path: "b.go", Line: 3, Severity: "medium", Message: "leak"},
+		{Path: "## Rollout", Severity: "low", Message: "vague"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFindings() = %+v, want %+v", got, want)
+	}
+
+	for _, text := range []string{"", "no json here", `{"verdict":"accepted","issues":[]}`} {
+		if got := ParseFindings(text); got != nil {
+			t.Errorf("ParseFindings(%q) = %+v, want nil", text, got)
+		}
+	}
+}
+
+func TestFormatFindingCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     string
+		findings []Finding
+	}{
+		{name: "empty", want: "no findings"},
+		{
+			name: "ordered by severity",
+			findings: []Finding{
+				{Severity: "medium"}, {Severity: "high"}, {Severity: "medium"},
+				{Severity: "high"}, {Severity: "high"},
+			},
+			want: "3 high, 2 medium",
+		},
+		{
+			name:     "unknown severity last",
+			findings: []Finding{{Severity: "nit"}, {Severity: "critical"}},
+			want:     "1 critical, 1 unrated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatFindingCounts(tt.findings); got != tt.want {
+				t.Errorf("FormatFindingCounts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
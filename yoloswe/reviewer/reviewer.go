@@ -1,5 +1,5 @@
 // Package reviewer provides a multi-backend wrapper for code review using
-// agent CLIs (Codex, Cursor, Gemini).
+// agent CLIs (Codex, Cursor, Gemini, Claude).
 package reviewer
 
 import (
@@ -48,6 +48,7 @@ const (
 	BackendCodex  BackendType = "codex"
 	BackendCursor BackendType = "cursor"
 	BackendGemini BackendType = "gemini"
+	BackendClaude BackendType = "claude"
 
 	// DefaultGeminiModel is the model used when BackendGemini is selected and
 	// no --model flag is provided.
@@ -60,6 +61,10 @@ const (
 	// DefaultCursorModel is the model used when BackendCursor is selected and
 	// no --model flag is provided.
 	DefaultCursorModel = "composer-2.5"
+
+	// DefaultClaudeModel is the model used when BackendClaude is selected and
+	// no --model flag is provided.
+	DefaultClaudeModel = "sonnet"
 )
 
 // Config holds reviewer configuration.
@@ -111,7 +116,7 @@ type Config struct {
 	WorkDir         string
 	Goal            string
 	SessionLogPath  string
-	Effort          string // Reasoning effort level for codex (low, medium, high)
+	Effort          string // Reasoning effort level for codex (low, medium, high) or claude (low, medium, high, max)
 	Sandbox         string // Codex sandbox: "read-only", "workspace-write", "danger-full-access"
 	Model           string
 	BackendType     BackendType
@@ -940,14 +945,18 @@ You MUST respond with valid JSON in this exact format:
 - issues array can be empty only if verdict is "ready".
 - Output ONLY the JSON object, no other text`
 
-// ReviewResult contains the result of a review turn.
+// ReviewResult contains the result of a review turn. Findings holds the
+// issues parsed from ResponseText's JSON (see ParseFindings); it is nil when
+// the turn failed or the response had no parseable issues.
 type ReviewResult struct {
 	ResponseText string
 	ErrorMessage string
 	ResumeStatus ResumeStatus
+	Findings     []Finding
 	DurationMs   int64
 	InputTokens  int64
 	OutputTokens int64
+	CostUSD      float64 // Populated by backends that report cost (claude)
 	Success      bool
 }
 
@@ -981,6 +990,14 @@ func New(config Config) *Reviewer {
 		}
 	}
 
+	// Apply claude-specific defaults. The claude backend is always
+	// read-only (plan mode), so ApprovalPolicy/Sandbox do not apply.
+	if config.BackendType == BackendClaude {
+		if config.Model == "" {
+			config.Model = DefaultClaudeModel
+		}
+	}
+
 	// Apply codex-specific defaults only for codex backend.
 	// See Config doc for why danger-full-access is the default sandbox.
 	if config.BackendType == BackendCodex {
@@ -1010,6 +1027,8 @@ func New(config Config) *Reviewer {
 		backend = newCursorBackend(config)
 	case BackendGemini:
 		backend = newGeminiBackend(config)
+	case BackendClaude:
+		backend = newClaudeBackend(config)
 	default:
 		backend = newCodexBackend(config)
 	}
@@ -1073,6 +1092,7 @@ func (r *Reviewer) ReviewWithResult(ctx context.Context, prompt string) (*Review
 		return result, err
 	}
 	r.renderer.TurnCompleteWithTokens(result.Success, result.DurationMs, result.InputTokens, result.OutputTokens)
+	result.Findings = ParseFindings(result.ResponseText)
 	return result, nil
 }
 
@@ -1111,11 +1131,22 @@ func (r *Reviewer) FollowUp(ctx context.Context, prompt string) (*ReviewResult,
 		return result, err
 	}
 	r.renderer.TurnCompleteWithTokens(result.Success, result.DurationMs, result.InputTokens, result.OutputTokens)
+	result.Findings = ParseFindings(result.ResponseText)
 	return result, nil
 }
 
+// Interrupt stops a turn left running by a ReviewWithResult or FollowUp
+// whose context expired, so the next prompt doesn't queue behind it. It is
+// a no-op for backends whose turns end with their context.
+func (r *Reviewer) Interrupt(ctx context.Context) error {
+	if i, ok := r.backend.(interrupter); ok {
+		return i.Interrupt(ctx)
+	}
+	return nil
+}
+
 // EffectiveModel returns the model actually used by the backend. Defaults for
-// all backends (Codex, Cursor, Gemini) are applied in New, so the value is
+// all backends (Codex, Cursor, Gemini, Claude) are applied in New, so the value is
 // set before the session starts. For Cursor, it may be replaced by the model
 // reported in the backend's ReadyEvent (OnSessionInfo). Callers should prefer
 // this over the raw --model flag, which may be empty or differ from what the
@@ -1129,10 +1160,10 @@ func (r *Reviewer) LastSessionID() string { return r.lastSessionID }
 // ValidateBackend returns an error if the given backend string is not supported.
 func ValidateBackend(backend string) error {
 	switch BackendType(backend) {
-	case BackendCursor, BackendCodex, BackendGemini:
+	case BackendCursor, BackendCodex, BackendGemini, BackendClaude:
 		return nil
 	default:
-		return fmt.Errorf("unknown backend %q (supported: cursor, codex, gemini)", backend)
+		return fmt.Errorf("unknown backend %q (supported: cursor, codex, gemini, claude)", backend)
 	}
 }
 
@@ -513,6 +513,7 @@ func TestValidateBackend(t *testing.T) {
 		{"cursor", false},
 		{"codex", false},
 		{"gemini", false},
+		{"claude", false},
 		{"unknown", true},
 		{"", true},
 	}
@@ -1244,3 +1245,31 @@ func TestLegacyJSONPromptGolden(t *testing.T) {
 		t.Errorf("legacy JSON prompt drift detected.\n--- want (testdata/legacy_json_prompt.txt) ---\n%s\n--- got ---\n%s", want, got)
 	}
 }
+
+// interruptibleBackend counts interrupts of its (never running) turn.
+type interruptibleBackend struct {
+	partialTextErrorBackend
+	interrupts int
+}
+
+func (b *interruptibleBackend) Interrupt(context.Context) error {
+	b.interrupts++
+	return nil
+}
+
+func TestInterruptReachesBackend(t *testing.T) {
+	backend := &interruptibleBackend{}
+	r := &Reviewer{backend: backend}
+	if err := r.Interrupt(context.Background()); err != nil {
+		t.Fatalf("Interrupt returned error: %v", err)
+	}
+	if backend.interrupts != 1 {
+		t.Errorf("backend interrupted %d times, want 1", backend.interrupts)
+	}
+
+	// Backends without turns of their own to stop are left alone.
+	r = &Reviewer{backend: danglingToolBackend{}}
+	if err := r.Interrupt(context.Background()); err != nil {
+		t.Errorf("Interrupt on a non-interruptible backend returned error: %v", err)
+	}
+}
@@ -2,11 +2,13 @@ package yoloswe
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
 	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude/render"
@@ -20,6 +22,10 @@ func defaultRecordingDir() string {
 	return ".yoloswe"
 }
 
+// interruptTimeout bounds the interrupt sent to a turn whose context has
+// timed out.
+const interruptTimeout = 5 * time.Second
+
 // baseSession holds the shared state and implements common methods used by
 // BuilderSession and CodeTalkSession. Callers must embed baseSession and set
 // the session field before calling any methods.
@@ -122,7 +128,7 @@ func (b *baseSession) RunTurn(ctx context.Context, message string) (*claude.Turn
 		return nil, fmt.Errorf("message cannot be empty")
 	}
 
-	_, err := b.session.SendMessage(ctx, message)
+	turn, err := b.session.SendMessage(ctx, message)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
@@ -130,6 +136,13 @@ func (b *baseSession) RunTurn(ctx context.Context, message string) (*claude.Turn
 	for {
 		select {
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				// A timed-out turn may still be running; stop it so a
+				// retry does not queue behind it.
+				interruptCtx, cancel := context.WithTimeout(context.Background(), interruptTimeout)
+				_ = b.session.Interrupt(interruptCtx)
+				cancel()
+			}
 			return nil, ctx.Err()
 		case event, ok := <-b.session.Events():
 			if !ok {
@@ -159,6 +172,11 @@ func (b *baseSession) RunTurn(ctx context.Context, message string) (*claude.Turn
 				b.renderer.ToolResultForTool(e.ToolName, e.ToolUseID, e.Content, e.IsError)
 
 			case claude.TurnCompleteEvent:
+				if e.TurnNumber < turn {
+					// Completion of an earlier turn that was interrupted
+					// after its caller gave up on it.
+					continue
+				}
 				b.stats.TurnCount++
 				b.stats.InputTokens += e.Usage.InputTokens
 				b.stats.OutputTokens += e.Usage.OutputTokens
@@ -0,0 +1,109 @@
+package yoloswe
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bazelment/yoloswe/yoloswe/reviewer"
+)
+
+// DefaultSpiralThreshold is the number of review rounds a finding may be
+// raised in before the spiral guard escalates it.
+const DefaultSpiralThreshold = 3
+
+var (
+	// findingLineRef matches line references a reviewer tends to vary between
+	// rounds: "line 42", "lines 10-12", and ":42" / ":42:7" suffixes.
+	findingLineRef = regexp.MustCompile(`\blines?\s+\d+(\s*[-–]\s*\d+)?|:\d+(:\d+)?`)
+	findingSpace   = regexp.MustCompile(`\s+`)
+)
+
+// SpiralGuard counts, per finding, the review rounds that raised it. Findings
+// are keyed by path and normalized message so that a re-raise at a shifted
+// line, or with different capitalization, counts as the same finding. The
+// counts are stored in the loop checkpoint so a resumed run keeps them.
+type SpiralGuard struct {
+	counts    map[string]int
+	threshold int
+}
+
+// RecurringFinding is a finding the guard escalated, with the number of
+// rounds it has been raised in.
+type RecurringFinding struct {
+	reviewer.Finding
+	Rounds int
+}
+
+// NewSpiralGuard creates a guard that escalates findings raised in threshold
+// or more rounds. counts seeds the guard from a checkpoint and may be nil.
+func NewSpiralGuard(threshold int, counts map[string]int) *SpiralGuard {
+	if threshold <= 0 {
+		threshold = DefaultSpiralThreshold
+	}
+	g := &SpiralGuard{threshold: threshold, counts: make(map[string]int, len(counts))}
+	for k, v := range counts {
+		g.counts[k] = v
+	}
+	return g
+}
+
+// Observe records one review round and returns the findings that have now
+// been raised in at least threshold rounds, most persistent first. A finding
+// repeated within the same round counts once.
+func (g *SpiralGuard) Observe(findings []reviewer.Finding) []RecurringFinding {
+	seen := make(map[string]bool, len(findings))
+	var recurring []RecurringFinding
+	for _, f := range findings {
+		key := findingKey(f)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		g.counts[key]++
+		if n := g.counts[key]; n >= g.threshold {
+			recurring = append(recurring, RecurringFinding{Finding: f, Rounds: n})
+		}
+	}
+	sort.SliceStable(recurring, func(i, j int) bool { return recurring[i].Rounds > recurring[j].Rounds })
+	return recurring
+}
+
+// Counts returns a copy of the per-finding round counts for checkpointing.
+func (g *SpiralGuard) Counts() map[string]int {
+	if len(g.counts) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(g.counts))
+	for k, v := range g.counts {
+		out[k] = v
+	}
+	return out
+}
+
+// findingKey identifies a finding across rounds as path plus normalized
+// message.
+func findingKey(f reviewer.Finding) string {
+	return f.Path + "\x00" + normalizeFindingMessage(f.Message)
+}
+
+// normalizeFindingMessage lowercases msg, drops line references, and
+// collapses whitespace.
+func normalizeFindingMessage(msg string) string {
+	s := strings.ToLower(msg)
+	s = findingLineRef.ReplaceAllString(s, "")
+	s = findingSpace.ReplaceAllString(s, " ")
+	return strings.Trim(s, " .,;:")
+}
+
+// spiralFeedback tells the builder which findings keep coming back, so the
+// next turn addresses the root cause instead of patching the symptom again.
+func spiralFeedback(recurring []RecurringFinding) string {
+	var sb strings.Builder
+	sb.WriteString("These findings have been raised repeatedly and are still open. Fix the underlying cause, or explain why the reviewer is wrong:\n")
+	for _, r := range recurring {
+		fmt.Fprintf(&sb, "- [%s] %s: %s (raised in %d rounds)\n", r.Severity, r.Path, r.Message, r.Rounds)
+	}
+	return sb.String()
+}
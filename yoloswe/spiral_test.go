@@ -0,0 +1,71 @@
+package yoloswe
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bazelment/yoloswe/yoloswe/reviewer"
+)
+
+func TestNormalizeFindingMessage(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Nil deref on line 42.", "nil deref on"},
+		{"nil  deref on LINE 57", "nil deref on"},
+		{"See foo.go:12:3 for the leak", "see foo.go for the leak"},
+		{"Lines 10-14 duplicate the retry loop", "duplicate the retry loop"},
+	}
+	for _, tt := range tests {
+		if got := normalizeFindingMessage(tt.in); got != tt.want {
+			t.Errorf("normalizeFindingMessage(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSpiralGuardEscalatesRecurringFinding(t *testing.T) {
+	g := NewSpiralGuard(3, nil)
+	round := func(line int, msg string) []reviewer.Finding {
+		return []reviewer.Finding{
+			{Path: "a.go", Line: line, Severity: "high", Message: msg},
+			{Path: "a.go", Line: line, Severity: "high", Message: msg}, // same round, counted once
+		}
+	}
+
+	if got := g.Observe(round(10, "Missing error check at line 10")); got != nil {
+		t.Fatalf("round 1 escalated %+v", got)
+	}
+	if got := g.Observe(round(14, "missing error check at line 14")); got != nil {
+		t.Fatalf("round 2 escalated %+v", got)
+	}
+	got := g.Observe(round(15, "Missing error check at line 15"))
+	if len(got) != 1 || got[0].Rounds != 3 || got[0].Path != "a.go" {
+		t.Fatalf("round 3 = %+v, want one finding raised in 3 rounds", got)
+	}
+	// A different file is a different finding.
+	if got := g.Observe([]reviewer.Finding{{Path: "b.go", Message: "missing error check"}}); got != nil {
+		t.Errorf("b.go finding escalated: %+v", got)
+	}
+}
+
+func TestSpiralGuardCountsSurviveResume(t *testing.T) {
+	dir := t.TempDir()
+	f := reviewer.Finding{Path: "a.go", Severity: "medium", Message: "racy map"}
+
+	g := NewSpiralGuard(2, nil)
+	g.Observe([]reviewer.Finding{f})
+	state := &LoopState{SessionID: "sess", WorkDir: dir, Iteration: 1, FindingCounts: g.Counts()}
+	if err := SaveLoopState(filepath.Join(dir, "rec"), state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadLoopState(filepath.Join(dir, "rec"), "sess")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := NewSpiralGuard(2, loaded.FindingCounts)
+	if got := resumed.Observe([]reviewer.Finding{f}); len(got) != 1 || got[0].Rounds != 2 {
+		t.Errorf("resumed guard = %+v, want the finding escalated after 2 rounds", got)
+	}
+}
@@ -18,7 +18,11 @@
 // Multiple safety mechanisms prevent runaway execution:
 //   - Budget limit: Hard cap on builder API costs
 //   - Time limit: Wall-clock timeout for entire session
+//   - Turn timeout: Optional per-turn limit; a stalled turn is cancelled and
+//     retried once
 //   - Iteration limit: Maximum number of builder-reviewer cycles
+//   - Spiral guard: Findings the reviewer re-raises round after round are
+//     escalated to the builder, and optionally stop the loop
 //   - Context cancellation: Proper Ctrl+C handling with cleanup
 //
 // # Key Features
@@ -28,6 +32,8 @@
 //   - Error recovery: Graceful handling of session failures and network issues
 //   - Input validation: Comprehensive validation of all configuration and prompts
 //   - Session recording: Optional recording of all interactions for debugging
+//   - Checkpointing: Loop state is saved to RecordingDir/<session-id>/loop-state.json
+//     after each iteration so --resume continues where the loop left off
 //
 // # Example Usage
 //
@@ -57,14 +63,19 @@
 //   - ExitReasonAccepted: Reviewer approved the changes (success)
 //   - ExitReasonBudgetExceeded: Builder costs exceeded budget limit
 //   - ExitReasonTimeExceeded: Wall-clock time exceeded timeout
-//   - ExitReasonMaxIterations: Reached maximum iteration count
+//   - ExitReasonMaxIterations: Reviewer still wanted changes when the iteration limit was reached
 //   - ExitReasonError: Unrecoverable error occurred
 //   - ExitReasonInterrupt: User cancelled with Ctrl+C
+//   - ExitReasonSpiral: The same findings recurred past SpiralThreshold with HaltOnSpiral set
+//
+// ExitReason.ExitCode maps each reason to a distinct process exit code so
+// scripts can branch on why a run stopped.
 package yoloswe
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -72,6 +83,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bazelment/yoloswe/agent-cli-wrapper/claude"
 	"github.com/bazelment/yoloswe/yoloswe/reviewer"
 )
 
@@ -85,8 +97,53 @@ const (
 	ExitReasonMaxIterations  ExitReason = "max_iterations" // Safety iteration limit reached
 	ExitReasonError          ExitReason = "error"          // Unrecoverable error
 	ExitReasonInterrupt      ExitReason = "interrupt"      // User interrupted (Ctrl+C)
+	ExitReasonSpiral         ExitReason = "spiral"         // Same findings kept recurring (HaltOnSpiral)
 )
 
+// ExitCode returns the process exit code for the reason: 0 accepted,
+// 2 budget, 3 timeout, 4 max iterations, 6 spiral, 130
+// interrupt, and 1 for errors or an unset reason.
+func (r ExitReason) ExitCode() int {
+	switch r {
+	case ExitReasonAccepted:
+		return 0
+	case ExitReasonBudgetExceeded:
+		return 2
+	case ExitReasonTimeExceeded:
+		return 3
+	case ExitReasonMaxIterations:
+		return 4
+	case ExitReasonSpiral:
+		return 6
+	case ExitReasonInterrupt:
+		return 130
+	default:
+		return 1
+	}
+}
+
+// Description returns a short human-readable explanation of the reason.
+func (r ExitReason) Description() string {
+	switch r {
+	case ExitReasonAccepted:
+		return "reviewer accepted the changes"
+	case ExitReasonBudgetExceeded:
+		return "builder budget limit reached"
+	case ExitReasonTimeExceeded:
+		return "time limit reached"
+	case ExitReasonMaxIterations:
+		return "iteration limit reached"
+	case ExitReasonError:
+		return "unrecoverable error"
+	case ExitReasonInterrupt:
+		return "interrupted by user"
+	case ExitReasonSpiral:
+		return "reviewer kept raising the same findings"
+	default:
+		return "did not finish"
+	}
+}
+
 // Config holds yoloswe configuration.
 type Config struct {
 	// Builder settings
@@ -94,20 +151,26 @@ type Config struct {
 	BuilderWorkDir  string
 	RecordingDir    string
 	SystemPrompt    string
-	ResumeSessionID string // Resume from a previous session ID instead of starting fresh
+	ResumeSessionID string // Resume from a previous session ID (and its loop-state.json checkpoint) instead of starting fresh
 
 	// Reviewer settings
 	ReviewerModel string
 	Goal          string // Goal description for reviewer context
 
 	// Limits
-	MaxBudgetUSD   float64 // Max USD to spend on builder session
-	MaxTimeSeconds int     // Max wall-clock seconds
-	MaxIterations  int     // Max builder-reviewer iterations (safety limit)
+	MaxBudgetUSD       float64 // Max USD to spend on builder session
+	MaxTimeSeconds     int     // Max wall-clock seconds
+	MaxIterations      int     // Max builder-reviewer iterations (safety limit)
+	TurnTimeoutSeconds int     // Max seconds for one builder or reviewer turn; 0 leaves turns bounded only by MaxTimeSeconds
+
+	// Spiral guard
+	SpiralThreshold int  // Rounds a finding may recur before it is escalated (default: DefaultSpiralThreshold)
+	HaltOnSpiral    bool // Stop the loop with ExitReasonSpiral instead of only escalating
 
 	// Other settings
 	RequireApproval bool // Require user approval for tool executions (default: auto-approve)
 	ReviewFirst     bool // Skip first builder turn, start with review
+	AutoFollowup    bool // Run follow-ups from an accepting review as extra builder turns
 
 	// Output settings
 	Verbose bool
@@ -116,12 +179,15 @@ type Config struct {
 // Stats tracks cumulative statistics for the SWE loop.
 type Stats struct {
 	ExitReason        ExitReason
+	Followups         []string // Minor asks the reviewer attached to its acceptance
 	BuilderCostUSD    float64
+	ReviewerCostUSD   float64 // Only backends that report cost (claude) contribute
 	BuilderTokensIn   int
 	BuilderTokensOut  int
 	ReviewerTokensIn  int64
 	ReviewerTokensOut int64
 	IterationCount    int
+	TurnTimeouts      int // Turns cancelled by TurnTimeoutSeconds, including retried ones
 	TotalDurationMs   int64
 }
 
@@ -142,17 +208,23 @@ type ReviewIssue struct {
 
 // ReviewVerdictJSON is the JSON structure returned by the reviewer.
 type ReviewVerdictJSON struct {
-	Verdict string        `json:"verdict"`
-	Summary string        `json:"summary"`
-	Issues  []ReviewIssue `json:"issues,omitempty"`
+	Verdict   string        `json:"verdict"`
+	Summary   string        `json:"summary"`
+	Issues    []ReviewIssue `json:"issues,omitempty"`
+	Followups []string      `json:"followups,omitempty"`
 }
 
 // ReviewVerdict represents the reviewer's decision.
+//
+// AcceptWithFollowups holds the minor asks an accepting reviewer wants done
+// without another review round (e.g. "add a changelog entry"). It is only
+// set when Accepted is true.
 type ReviewVerdict struct {
-	Summary  string
-	Feedback string // Formatted feedback for builder
-	Issues   []ReviewIssue
-	Accepted bool
+	Summary             string        `json:"summary"`
+	Feedback            string        `json:"feedback"` // Formatted feedback for builder
+	Issues              []ReviewIssue `json:"issues,omitempty"`
+	AcceptWithFollowups []string      `json:"accept_with_followups,omitempty"`
+	Accepted            bool          `json:"accepted"`
 }
 
 // SWEWrapper orchestrates the builder-reviewer loop.
@@ -161,6 +233,7 @@ type SWEWrapper struct {
 	output     io.Writer
 	builder    *BuilderSession
 	reviewer   *reviewer.Reviewer
+	spiral     *SpiralGuard
 	sessionLog string // Session log file path
 	config     Config
 	stats      Stats
@@ -219,6 +292,24 @@ func (s *SWEWrapper) Run(ctx context.Context, prompt string) error {
 		fmt.Fprintf(s.output, "Warning: failed to initialize session log: %v\n", err)
 	}
 
+	// Load the loop checkpoint before starting anything so a diverged
+	// worktree is rejected without spending a builder turn.
+	var state *LoopState
+	if s.config.ResumeSessionID != "" {
+		var err error
+		state, err = LoadLoopState(s.config.RecordingDir, s.config.ResumeSessionID)
+		if err != nil {
+			s.stats.ExitReason = ExitReasonError
+			return fmt.Errorf("failed to load loop state: %w", err)
+		}
+		if state != nil {
+			if err := state.CheckResumable(s.config.BuilderWorkDir); err != nil {
+				s.stats.ExitReason = ExitReasonError
+				return fmt.Errorf("cannot resume: %w", err)
+			}
+		}
+	}
+
 	startTime := time.Now()
 
 	// Start builder session
@@ -265,8 +356,27 @@ func (s *SWEWrapper) Run(ctx context.Context, prompt string) error {
 
 	currentMessage := prompt
 	isFirstReview := true
+	firstIteration := 1
+	var findingCounts map[string]int
+	if state != nil {
+		findingCounts = state.FindingCounts
+	}
+	s.spiral = NewSpiralGuard(s.config.SpiralThreshold, findingCounts)
+	if state != nil {
+		firstIteration = s.restoreLoopState(state)
+		if state.PendingFeedback != "" {
+			currentMessage = builderFeedbackMessage(state.PendingFeedback)
+		}
+	}
 
-	for iteration := 1; ; iteration++ {
+	for iteration := firstIteration; ; iteration++ {
+		// A resumed run may already have used up its iterations; the
+		// first iteration always runs.
+		if iteration > 1 && iteration > s.config.MaxIterations {
+			s.stats.ExitReason = ExitReasonMaxIterations
+			fmt.Fprintf(s.output, "\n=== Max iterations reached (%d) ===\n", s.config.MaxIterations)
+			break
+		}
 		s.stats.IterationCount = iteration
 
 		// Check time limit before iteration
@@ -283,7 +393,13 @@ func (s *SWEWrapper) Run(ctx context.Context, prompt string) error {
 			fmt.Fprintf(s.output, "=== Iteration %d: BUILDER ===\n", iteration)
 			fmt.Fprint(s.output, strings.Repeat("=", 60)+"\n\n")
 
-			builderUsage, err := s.builder.RunTurn(ctx, currentMessage)
+			var builderUsage *claude.TurnUsage
+			// RunTurn interrupts a timed-out turn itself.
+			err := s.runTurn(ctx, startTime, "builder", func(ctx context.Context) error {
+				var err error
+				builderUsage, err = s.builder.RunTurn(ctx, currentMessage)
+				return err
+			}, nil)
 			if err != nil {
 				if ctx.Err() == context.Canceled {
 					s.stats.ExitReason = ExitReasonInterrupt
@@ -318,11 +434,19 @@ func (s *SWEWrapper) Run(ctx context.Context, prompt string) error {
 		var err error
 		if isFirstReview {
 			reviewPrompt := s.buildInitialReviewPrompt()
-			reviewResult, err = s.reviewer.ReviewWithResult(ctx, reviewPrompt)
+			err = s.runTurn(ctx, startTime, "reviewer", func(ctx context.Context) error {
+				var err error
+				reviewResult, err = s.reviewer.ReviewWithResult(ctx, reviewPrompt)
+				return err
+			}, s.reviewer.Interrupt)
 			isFirstReview = false
 		} else {
 			reviewPrompt := s.buildFollowUpPrompt()
-			reviewResult, err = s.reviewer.FollowUp(ctx, reviewPrompt)
+			err = s.runTurn(ctx, startTime, "reviewer", func(ctx context.Context) error {
+				var err error
+				reviewResult, err = s.reviewer.FollowUp(ctx, reviewPrompt)
+				return err
+			}, s.reviewer.Interrupt)
 		}
 
 		if err != nil {
@@ -339,30 +463,63 @@ func (s *SWEWrapper) Run(ctx context.Context, prompt string) error {
 		// Update reviewer stats
 		s.stats.ReviewerTokensIn += reviewResult.InputTokens
 		s.stats.ReviewerTokensOut += reviewResult.OutputTokens
+		s.stats.ReviewerCostUSD += reviewResult.CostUSD
 
 		// Parse verdict from response
 		verdict := s.parseVerdict(reviewResult.ResponseText)
+		fmt.Fprintf(s.output, "\nReviewer findings: %s\n", reviewer.FormatFindingCounts(reviewResult.Findings))
+		s.logEvent("review_findings", map[string]interface{}{
+			"iteration": iteration,
+			"counts":    reviewer.CountFindings(reviewResult.Findings),
+			"findings":  reviewResult.Findings,
+		})
+
+		var recurring []RecurringFinding
+		if !verdict.Accepted {
+			recurring = s.spiral.Observe(reviewResult.Findings)
+		}
+		if len(recurring) > 0 {
+			s.reportSpiral(iteration, recurring)
+			verdict.Feedback += "\n\n" + spiralFeedback(recurring)
+		}
+
+		pendingFeedback := ""
+		if !verdict.Accepted {
+			pendingFeedback = verdict.Feedback
+		}
+		s.saveLoopState(iteration, verdict, pendingFeedback)
 
 		if verdict.Accepted {
 			s.stats.ExitReason = ExitReasonAccepted
+			s.stats.Followups = verdict.AcceptWithFollowups
 			fmt.Fprintln(s.output, "\n=== Reviewer ACCEPTED the changes ===")
+			if len(verdict.AcceptWithFollowups) > 0 {
+				s.reportFollowups(iteration, verdict.AcceptWithFollowups)
+				if s.config.AutoFollowup {
+					if err := s.runFollowups(ctx, startTime, verdict.AcceptWithFollowups); err != nil {
+						return err
+					}
+				}
+			}
+			break
+		}
+
+		if len(recurring) > 0 && s.config.HaltOnSpiral {
+			s.stats.ExitReason = ExitReasonSpiral
+			fmt.Fprintln(s.output, "\n=== Stopping: the reviewer keeps raising the same findings ===")
 			break
 		}
 
 		// Check iteration limit
 		if iteration >= s.config.MaxIterations {
 			s.stats.ExitReason = ExitReasonMaxIterations
-			fmt.Fprintf(s.output, "\n=== Max iterations reached (%d) ===\n", s.config.MaxIterations)
+			fmt.Fprintf(s.output, "\n=== Max iterations reached (%d): reviewer REJECTED the last iteration ===\n", s.config.MaxIterations)
 			break
 		}
 
 		// Format feedback for next iteration
 		fmt.Fprintln(s.output, "\n=== Reviewer requested changes, continuing... ===")
-		currentMessage = fmt.Sprintf(`The reviewer provided the following feedback on your changes:
-
-%s
-
-Please address this feedback and improve the implementation.`, verdict.Feedback)
+		currentMessage = builderFeedbackMessage(verdict.Feedback)
 	}
 
 	s.stats.TotalDurationMs = time.Since(startTime).Milliseconds()
@@ -371,10 +528,13 @@ Please address this feedback and improve the implementation.`, verdict.Feedback)
 	s.logEvent("session_complete", map[string]interface{}{
 		"exit_reason":     s.stats.ExitReason,
 		"iterations":      s.stats.IterationCount,
+		"turn_timeouts":   s.stats.TurnTimeouts,
 		"duration_ms":     s.stats.TotalDurationMs,
 		"builder_cost":    s.stats.BuilderCostUSD,
 		"builder_tokens":  s.stats.BuilderTokensIn + s.stats.BuilderTokensOut,
+		"reviewer_cost":   s.stats.ReviewerCostUSD,
 		"reviewer_tokens": s.stats.ReviewerTokensIn + s.stats.ReviewerTokensOut,
+		"followups":       len(s.stats.Followups),
 	})
 
 	if s.sessionLog != "" {
@@ -384,9 +544,208 @@ Please address this feedback and improve the implementation.`, verdict.Feedback)
 	return nil
 }
 
+// builderFeedbackMessage wraps reviewer feedback into the next builder prompt.
+func builderFeedbackMessage(feedback string) string {
+	return fmt.Sprintf(`The reviewer provided the following feedback on your changes:
+
+%s
+
+Please address this feedback and improve the implementation.`, feedback)
+}
+
+// followupMessage turns one of the reviewer's follow-ups into a builder prompt.
+func followupMessage(followup string) string {
+	return fmt.Sprintf(`The reviewer accepted your changes and asked for one small follow-up:
+
+%s
+
+Please make this change. It will not be reviewed again, so keep it minimal.`, followup)
+}
+
+// reportFollowups prints the follow-ups attached to an accepting review.
+func (s *SWEWrapper) reportFollowups(iteration int, followups []string) {
+	fmt.Fprintf(s.output, "\nReviewer follow-ups (%d):\n", len(followups))
+	for i, f := range followups {
+		fmt.Fprintf(s.output, "  %d. %s\n", i+1, f)
+	}
+	if !s.config.AutoFollowup {
+		fmt.Fprintln(s.output, "Run with --auto-followup to have the builder apply them.")
+	}
+	s.logEvent("review_followups", map[string]interface{}{
+		"iteration": iteration,
+		"followups": followups,
+	})
+}
+
+// runFollowups runs each follow-up as its own builder turn without another
+// review round. The loop has already been accepted, so hitting the budget or
+// time limit stops the remaining follow-ups but keeps ExitReasonAccepted.
+func (s *SWEWrapper) runFollowups(ctx context.Context, startTime time.Time, followups []string) error {
+	for i, followup := range followups {
+		if s.stats.BuilderCostUSD >= s.config.MaxBudgetUSD {
+			fmt.Fprintf(s.output, "\n=== Budget limit reached, skipping %d follow-up(s) ===\n", len(followups)-i)
+			return nil
+		}
+		if time.Since(startTime).Seconds() >= float64(s.config.MaxTimeSeconds) {
+			fmt.Fprintf(s.output, "\n=== Time limit reached, skipping %d follow-up(s) ===\n", len(followups)-i)
+			return nil
+		}
+
+		fmt.Fprint(s.output, "\n"+strings.Repeat("=", 60)+"\n")
+		fmt.Fprintf(s.output, "=== Follow-up %d/%d: BUILDER ===\n", i+1, len(followups))
+		fmt.Fprint(s.output, strings.Repeat("=", 60)+"\n\n")
+
+		var usage *claude.TurnUsage
+		err := s.runTurn(ctx, startTime, "builder", func(ctx context.Context) error {
+			var err error
+			usage, err = s.builder.RunTurn(ctx, followupMessage(followup))
+			return err
+		}, nil)
+		if err != nil {
+			if ctx.Err() == context.Canceled {
+				s.stats.ExitReason = ExitReasonInterrupt
+				fmt.Fprintln(s.output, "\n=== Builder interrupted by user ===")
+				return nil
+			}
+			s.stats.ExitReason = ExitReasonError
+			fmt.Fprintf(s.output, "\n=== Builder failed on follow-up: %v ===\n", err)
+			return fmt.Errorf("builder error on follow-up: %w", err)
+		}
+		s.stats.BuilderCostUSD += usage.CostUSD
+		s.stats.BuilderTokensIn += usage.InputTokens
+		s.stats.BuilderTokensOut += usage.OutputTokens
+	}
+	return nil
+}
+
+// maxTurnTimeoutRetries is how many times a turn cancelled by
+// TurnTimeoutSeconds is retried before the loop gives up on it.
+const maxTurnTimeoutRetries = 1
+
+// ErrTurnTimeout is wrapped by the error returned when a turn keeps
+// exceeding TurnTimeoutSeconds.
+var ErrTurnTimeout = errors.New("turn timed out")
+
+// runTurn runs one builder or reviewer turn under TurnTimeoutSeconds. A turn
+// that stalls past the limit is cancelled, stopped with interrupt (when
+// non-nil) so a retry doesn't queue behind it, counted in
+// Stats.TurnTimeouts, and retried while retries and overall time remain.
+// Any other error is returned as is: only the timeout is transient. With no
+// turn timeout configured the turn runs on ctx directly.
+func (s *SWEWrapper) runTurn(ctx context.Context, startTime time.Time, role string, turn, interrupt func(context.Context) error) error {
+	if s.config.TurnTimeoutSeconds <= 0 {
+		return turn(ctx)
+	}
+	timeout := time.Duration(s.config.TurnTimeoutSeconds) * time.Second
+	for attempt := 1; ; attempt++ {
+		turnCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := turn(turnCtx)
+		timedOut := errors.Is(err, context.DeadlineExceeded) && errors.Is(turnCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+		cancel()
+		if !timedOut {
+			return err
+		}
+		if interrupt != nil {
+			interruptCtx, cancel := context.WithTimeout(ctx, interruptTimeout)
+			if err := interrupt(interruptCtx); err != nil {
+				fmt.Fprintf(s.output, "\n=== Failed to interrupt the timed-out %s turn: %v ===\n", role, err)
+			}
+			cancel()
+		}
+
+		s.stats.TurnTimeouts++
+		s.logEvent("turn_timeout", map[string]interface{}{
+			"role":            role,
+			"attempt":         attempt,
+			"timeout_seconds": s.config.TurnTimeoutSeconds,
+		})
+		if attempt > maxTurnTimeoutRetries || time.Since(startTime).Seconds() >= float64(s.config.MaxTimeSeconds) {
+			return fmt.Errorf("%s %w after %s (%d attempts)", role, ErrTurnTimeout, timeout, attempt)
+		}
+		fmt.Fprintf(s.output, "\n=== %s turn timed out after %s, retrying ===\n", role, timeout)
+	}
+}
+
+// restoreLoopState seeds stats from a checkpoint and returns the iteration
+// the loop should continue from.
+func (s *SWEWrapper) restoreLoopState(state *LoopState) int {
+	s.stats.IterationCount = state.Iteration
+	s.stats.BuilderCostUSD = state.BuilderCostUSD
+	s.stats.ReviewerCostUSD = state.ReviewerCostUSD
+	s.stats.BuilderTokensIn = state.BuilderTokensIn
+	s.stats.BuilderTokensOut = state.BuilderTokensOut
+	s.stats.ReviewerTokensIn = state.ReviewerTokensIn
+	s.stats.ReviewerTokensOut = state.ReviewerTokensOut
+	fmt.Fprintf(s.output, "\n=== Resuming from iteration %d ($%.4f spent) ===\n", state.Iteration, state.BuilderCostUSD)
+	s.logEvent("loop_resumed", map[string]interface{}{
+		"session_id": state.SessionID,
+		"iteration":  state.Iteration,
+	})
+	return state.Iteration + 1
+}
+
+// saveLoopState checkpoints the loop after a completed iteration. Failures
+// are reported but never abort the loop.
+func (s *SWEWrapper) saveLoopState(iteration int, verdict *ReviewVerdict, pendingFeedback string) {
+	sessionID := s.builder.CLISessionID()
+	if sessionID == "" {
+		return
+	}
+	state := &LoopState{
+		UpdatedAt:         time.Now(),
+		LastVerdict:       verdict,
+		SessionID:         sessionID,
+		WorkDir:           s.config.BuilderWorkDir,
+		WorktreeHead:      gitHead(s.config.BuilderWorkDir),
+		PendingFeedback:   pendingFeedback,
+		BuilderCostUSD:    s.stats.BuilderCostUSD,
+		ReviewerCostUSD:   s.stats.ReviewerCostUSD,
+		Iteration:         iteration,
+		BuilderTokensIn:   s.stats.BuilderTokensIn,
+		BuilderTokensOut:  s.stats.BuilderTokensOut,
+		ReviewerTokensIn:  s.stats.ReviewerTokensIn,
+		ReviewerTokensOut: s.stats.ReviewerTokensOut,
+	}
+	if s.spiral != nil {
+		state.FindingCounts = s.spiral.Counts()
+	}
+	if err := SaveLoopState(s.config.RecordingDir, state); err != nil {
+		fmt.Fprintf(s.output, "Warning: failed to save loop state: %v\n", err)
+	}
+}
+
+// reportSpiral surfaces findings the spiral guard escalated.
+func (s *SWEWrapper) reportSpiral(iteration int, recurring []RecurringFinding) {
+	fmt.Fprint(s.output, "\n"+strings.Repeat("!", 60)+"\n")
+	fmt.Fprintf(s.output, "!!! Spiral guard: %d finding(s) keep recurring\n", len(recurring))
+	for _, r := range recurring {
+		loc := r.Path
+		if r.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", r.Path, r.Line)
+		}
+		fmt.Fprintf(s.output, "!!!   [%s] %s — %s (%d rounds)\n", r.Severity, loc, r.Message, r.Rounds)
+	}
+	fmt.Fprint(s.output, strings.Repeat("!", 60)+"\n")
+	s.logEvent("spiral_detected", map[string]interface{}{
+		"iteration": iteration,
+		"findings":  recurring,
+	})
+}
+
+// followupRules extends the reviewer's output format with the optional
+// follow-up list an accepting review may carry.
+const followupRules = `
+
+## Follow-ups (optional)
+If the changes are acceptable but you have minor asks that do not justify
+another review round (e.g. "add a changelog entry"), return "verdict":
+"accepted" with a top-level "followups" array of short, self-contained
+instructions for the builder. Anything you would reject the change over
+belongs in "issues" with "verdict": "rejected", not in "followups".`
+
 // buildInitialReviewPrompt creates the prompt for the first review.
 func (s *SWEWrapper) buildInitialReviewPrompt() string {
-	return reviewer.BuildJSONPrompt(s.config.Goal)
+	return reviewer.BuildJSONPrompt(s.config.Goal) + followupRules
 }
 
 // buildFollowUpPrompt creates the prompt for follow-up reviews.
@@ -431,11 +790,23 @@ func (s *SWEWrapper) parseVerdict(text string) *ReviewVerdict {
 	verdict := strings.TrimSpace(result.Verdict)
 	accepted := strings.EqualFold(verdict, "accepted")
 
+	// Follow-ups only mean something on an acceptance; a rejecting review
+	// carries its asks in Issues.
+	var followups []string
+	if accepted {
+		for _, f := range result.Followups {
+			if f = strings.TrimSpace(f); f != "" {
+				followups = append(followups, f)
+			}
+		}
+	}
+
 	return &ReviewVerdict{
-		Accepted: accepted,
-		Summary:  result.Summary,
-		Issues:   result.Issues,
-		Feedback: formatFeedback(result),
+		Accepted:            accepted,
+		Summary:             result.Summary,
+		Issues:              result.Issues,
+		Feedback:            formatFeedback(result),
+		AcceptWithFollowups: followups,
 	}
 }
 
@@ -608,9 +979,18 @@ func (s *SWEWrapper) PrintSummary() {
 	fmt.Fprintln(s.output, "\n"+strings.Repeat("=", 60))
 	fmt.Fprintln(s.output, "YOLOSWE SESSION SUMMARY")
 	fmt.Fprintln(s.output, strings.Repeat("-", 60))
-	fmt.Fprintf(s.output, "Exit reason:        %s\n", s.stats.ExitReason)
+	fmt.Fprintf(s.output, "Exit reason:        %s (%s)\n", s.stats.ExitReason, s.stats.ExitReason.Description())
 	fmt.Fprintf(s.output, "Iterations:         %d\n", s.stats.IterationCount)
 	fmt.Fprintf(s.output, "Duration:           %.1fs\n", float64(s.stats.TotalDurationMs)/1000)
+	if s.stats.TurnTimeouts > 0 {
+		fmt.Fprintf(s.output, "Turn timeouts:      %d\n", s.stats.TurnTimeouts)
+	}
+	if len(s.stats.Followups) > 0 {
+		fmt.Fprintf(s.output, "Follow-ups:         %d\n", len(s.stats.Followups))
+		for _, f := range s.stats.Followups {
+			fmt.Fprintf(s.output, "  - %s\n", f)
+		}
+	}
 	fmt.Fprintln(s.output, strings.Repeat("-", 60))
 	fmt.Fprintln(s.output, "Builder:")
 	fmt.Fprintf(s.output, "  Cost:             $%.4f\n", s.stats.BuilderCostUSD)
@@ -618,6 +998,9 @@ func (s *SWEWrapper) PrintSummary() {
 	fmt.Fprintf(s.output, "  Output tokens:    %d\n", s.stats.BuilderTokensOut)
 	fmt.Fprintln(s.output, strings.Repeat("-", 60))
 	fmt.Fprintln(s.output, "Reviewer:")
+	if s.stats.ReviewerCostUSD > 0 {
+		fmt.Fprintf(s.output, "  Cost:             $%.4f\n", s.stats.ReviewerCostUSD)
+	}
 	fmt.Fprintf(s.output, "  Input tokens:     %d\n", s.stats.ReviewerTokensIn)
 	fmt.Fprintf(s.output, "  Output tokens:    %d\n", s.stats.ReviewerTokensOut)
 	fmt.Fprintln(s.output, strings.Repeat("=", 60))
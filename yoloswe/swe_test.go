@@ -2,10 +2,14 @@ package yoloswe
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bazelment/yoloswe/yoloswe/reviewer"
 )
@@ -250,6 +254,52 @@ func TestParseVerdict_ConfidenceOmittedStaysNil(t *testing.T) {
 	}
 }
 
+func TestParseVerdict_Followups(t *testing.T) {
+	swe := New(Config{})
+
+	accepted := swe.parseVerdict(`{"verdict": "accepted", "summary": "LGTM", "followups": ["add a changelog entry", "  ", " bump the version "]}`)
+	if !accepted.Accepted {
+		t.Fatal("expected accepted verdict")
+	}
+	want := []string{"add a changelog entry", "bump the version"}
+	if !slices.Equal(accepted.AcceptWithFollowups, want) {
+		t.Errorf("AcceptWithFollowups = %q, want %q", accepted.AcceptWithFollowups, want)
+	}
+
+	// A rejection keeps its asks in Issues; stray followups are dropped.
+	rejected := swe.parseVerdict(`{"verdict": "rejected", "summary": "no", "issues": [{"severity":"high","file":"a.go","message":"bug"}], "followups": ["add docs"]}`)
+	if rejected.Accepted || len(rejected.AcceptWithFollowups) != 0 {
+		t.Errorf("rejected verdict = %+v, want no follow-ups", rejected)
+	}
+
+	plain := swe.parseVerdict(`{"verdict": "accepted", "summary": "LGTM"}`)
+	if plain.AcceptWithFollowups != nil {
+		t.Errorf("AcceptWithFollowups = %q, want nil", plain.AcceptWithFollowups)
+	}
+}
+
+func TestReportFollowups(t *testing.T) {
+	var buf bytes.Buffer
+	swe := New(Config{})
+	swe.output = &buf
+
+	swe.reportFollowups(2, []string{"add a changelog entry"})
+
+	out := buf.String()
+	for _, want := range []string{"Reviewer follow-ups (1)", "1. add a changelog entry", "--auto-followup"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	swe.config.AutoFollowup = true
+	swe.reportFollowups(2, []string{"add a changelog entry"})
+	if strings.Contains(buf.String(), "--auto-followup") {
+		t.Errorf("hint shown with AutoFollowup set:\n%s", buf.String())
+	}
+}
+
 func TestExtractJSON(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -431,6 +481,10 @@ func TestBuildInitialReviewPrompt(t *testing.T) {
 			swe := New(Config{Goal: tt.goal})
 			prompt := swe.buildInitialReviewPrompt()
 
+			if !strings.Contains(prompt, `"followups"`) {
+				t.Errorf("expected prompt to describe the followups field")
+			}
+
 			if !strings.Contains(prompt, tt.shouldMatch) {
 				t.Errorf("expected prompt to contain %q, got: %q", tt.shouldMatch, prompt)
 			}
@@ -515,6 +569,98 @@ func TestStats(t *testing.T) {
 	}
 }
 
+func TestRunTurnRetriesTimedOutTurn(t *testing.T) {
+	swe := New(Config{TurnTimeoutSeconds: 1})
+	swe.output = &bytes.Buffer{}
+
+	attempts := 0
+	err := swe.runTurn(context.Background(), time.Now(), "builder", func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if swe.stats.TurnTimeouts != 1 {
+		t.Errorf("expected 1 turn timeout, got %d", swe.stats.TurnTimeouts)
+	}
+}
+
+func TestRunTurnGivesUpAfterRetry(t *testing.T) {
+	swe := New(Config{TurnTimeoutSeconds: 1})
+	swe.output = &bytes.Buffer{}
+
+	attempts := 0
+	interrupts := 0
+	err := swe.runTurn(context.Background(), time.Now(), "reviewer", func(ctx context.Context) error {
+		attempts++
+		<-ctx.Done()
+		return ctx.Err()
+	}, func(ctx context.Context) error {
+		interrupts++
+		return nil
+	})
+	if !errors.Is(err, ErrTurnTimeout) {
+		t.Fatalf("expected ErrTurnTimeout, got %v", err)
+	}
+	if attempts != 1+maxTurnTimeoutRetries {
+		t.Errorf("expected %d attempts, got %d", 1+maxTurnTimeoutRetries, attempts)
+	}
+	if interrupts != attempts {
+		t.Errorf("expected every timed-out turn interrupted, got %d interrupts for %d attempts", interrupts, attempts)
+	}
+	if swe.stats.TurnTimeouts != attempts {
+		t.Errorf("expected %d turn timeouts, got %d", attempts, swe.stats.TurnTimeouts)
+	}
+}
+
+func TestRunTurnDoesNotRetryOtherErrors(t *testing.T) {
+	swe := New(Config{TurnTimeoutSeconds: 1})
+	swe.output = &bytes.Buffer{}
+
+	attempts := 0
+	authErr := errors.New("not logged in")
+	err := swe.runTurn(context.Background(), time.Now(), "reviewer", func(ctx context.Context) error {
+		attempts++
+		// Fails for its own reason, even though the deadline has passed.
+		<-ctx.Done()
+		return authErr
+	}, nil)
+	if !errors.Is(err, authErr) {
+		t.Fatalf("expected the turn's own error, got %v", err)
+	}
+	if attempts != 1 || swe.stats.TurnTimeouts != 0 {
+		t.Errorf("expected 1 attempt and no turn timeouts, got %d and %d", attempts, swe.stats.TurnTimeouts)
+	}
+}
+
+func TestRunTurnWithoutTimeout(t *testing.T) {
+	swe := New(Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := swe.runTurn(ctx, time.Now(), "builder", func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("expected no per-turn deadline")
+		}
+		return ctx.Err()
+	}, nil)
+	// A cancelled run is an interrupt, not a turn timeout.
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if swe.stats.TurnTimeouts != 0 {
+		t.Errorf("expected no turn timeouts, got %d", swe.stats.TurnTimeouts)
+	}
+}
+
 func TestPrintSummary(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -568,10 +714,32 @@ func TestPrintSummary(t *testing.T) {
 			},
 			shouldFind: []string{
 				"timeout",
+				"time limit reached",
 				"2",
 				"600.0s",
 			},
 		},
+		{
+			name: "accepted with follow-ups",
+			stats: Stats{
+				ExitReason: ExitReasonAccepted,
+				Followups:  []string{"add a changelog entry"},
+			},
+			shouldFind: []string{
+				"Follow-ups:         1",
+				"- add a changelog entry",
+			},
+		},
+		{
+			name: "max iterations",
+			stats: Stats{
+				ExitReason:     ExitReasonMaxIterations,
+				IterationCount: 3,
+			},
+			shouldFind: []string{
+				"max_iterations (iteration limit reached)",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -613,6 +781,7 @@ func TestExitReasonConstants(t *testing.T) {
 		ExitReasonMaxIterations,
 		ExitReasonError,
 		ExitReasonInterrupt,
+		ExitReasonSpiral,
 	}
 
 	seen := make(map[ExitReason]bool)
@@ -627,6 +796,27 @@ func TestExitReasonConstants(t *testing.T) {
 	}
 }
 
+func TestExitReasonExitCode(t *testing.T) {
+	tests := []struct {
+		reason ExitReason
+		want   int
+	}{
+		{ExitReasonAccepted, 0},
+		{ExitReasonError, 1},
+		{ExitReasonBudgetExceeded, 2},
+		{ExitReasonTimeExceeded, 3},
+		{ExitReasonMaxIterations, 4},
+		{ExitReasonSpiral, 6},
+		{ExitReasonInterrupt, 130},
+		{"", 1},
+	}
+	for _, tt := range tests {
+		if got := tt.reason.ExitCode(); got != tt.want {
+			t.Errorf("ExitReason(%q).ExitCode() = %d, want %d", tt.reason, got, tt.want)
+		}
+	}
+}
+
 // TestRunEdgeCases tests edge cases in the Run method
 // Note: These are unit tests for logic, not integration tests
 func TestRunEdgeCases(t *testing.T) {
@@ -107,6 +107,10 @@ func ValidateConfig(config Config) error {
 		}
 	}
 
+	if config.TurnTimeoutSeconds < 0 {
+		errors = append(errors, fmt.Sprintf("turn timeout cannot be negative: %d", config.TurnTimeoutSeconds))
+	}
+
 	// Validate max iterations
 	if config.MaxIterations < 0 {
 		errors = append(errors, fmt.Sprintf("max iterations cannot be negative: %d", config.MaxIterations))
@@ -218,11 +222,20 @@ func SanitizeConfig(config *Config) {
 		config.MaxTimeSeconds = 3600 // 1 hour
 	}
 
+	// A negative turn timeout means none, like zero
+	if config.TurnTimeoutSeconds < 0 {
+		config.TurnTimeoutSeconds = 0
+	}
+
 	// Apply max iterations default
 	if config.MaxIterations <= 0 {
 		config.MaxIterations = 10
 	}
 
+	if config.SpiralThreshold <= 0 {
+		config.SpiralThreshold = DefaultSpiralThreshold
+	}
+
 	// Trim whitespace from paths
 	config.BuilderWorkDir = strings.TrimSpace(config.BuilderWorkDir)
 	config.RecordingDir = strings.TrimSpace(config.RecordingDir)
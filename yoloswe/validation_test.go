@@ -93,6 +93,14 @@ func TestValidateConfig(t *testing.T) {
 			wantError: true,
 			errorText: "too low",
 		},
+		{
+			name: "negative turn timeout",
+			config: Config{
+				TurnTimeoutSeconds: -1,
+			},
+			wantError: true,
+			errorText: "cannot be negative",
+		},
 		{
 			name: "negative iterations",
 			config: Config{